@@ -25,7 +25,14 @@ func (m *manager) verifyNewBlock(b module.BlockData, prev module.Block) (module.
 	if !bytes.Equal(b.PrevID(), prev.ID()) {
 		return nil, errors.New("bad prev ID")
 	}
-	csi, prevVoters, err := m.verifyProofForLastBlock(prev, b.Votes())
+	anchor := m.chain.SyncAnchor()
+	if anchor != nil && b.Height() == anchor.Height {
+		if err := verifySyncAnchor(anchor, b); err != nil {
+			return nil, err
+		}
+	}
+	trusted := anchor != nil && prev.Height() < anchor.Height
+	csi, prevVoters, err := m.verifyProofForLastBlock(prev, b.Votes(), trusted)
 	if err != nil {
 		return nil, err
 	}
@@ -35,11 +42,30 @@ func (m *manager) verifyNewBlock(b module.BlockData, prev module.Block) (module.
 	return csi, nil
 }
 
+// verifySyncAnchor verifies that the block b at the height of the anchor is
+// the trusted one.
+func verifySyncAnchor(anchor *module.SyncAnchor, b module.BlockData) error {
+	if !bytes.Equal(b.ID(), anchor.BlockID) {
+		return errors.InvalidStateError.Errorf(
+			"InvalidSyncAnchor(height=%d,id=%x,exp=%x)",
+			b.Height(), b.ID(), anchor.BlockID)
+	}
+	if !bytes.Equal(b.NextValidatorsHash(), anchor.ValidatorsHash) {
+		return errors.InvalidStateError.Errorf(
+			"InvalidSyncAnchor(height=%d,validators=%x,exp=%x)",
+			b.Height(), b.NextValidatorsHash(), anchor.ValidatorsHash)
+	}
+	return nil
+}
+
 // verifyProofForLastBlock returns consensusInfo, prevVoters and nil error if
-// succeeds. b must be the last finalized block.
+// succeeds. b must be the last finalized block. If trusted is true, it
+// skips verifying BTP proofs. Votes are still used to get voters for the
+// consensus information, which may affect the result of execution.
 func (m *manager) verifyProofForLastBlock(
 	b module.Block,
 	votes module.CommitVoteSet,
+	trusted bool,
 ) (module.ConsensusInfo, module.ValidatorList, error) {
 	validators, err := b.(base.BlockVersionSpec).GetVoters(m.handlerContext)
 	if err != nil {
@@ -49,6 +75,9 @@ func (m *manager) verifyProofForLastBlock(
 	if err != nil {
 		return nil, nil, err
 	}
+	if trusted {
+		return common.NewConsensusInfoWithSeed(b.Proposer(), validators, voted, votes, b.ID()), validators, nil
+	}
 	bd, err := b.BTPDigest()
 	if err != nil {
 		return nil, nil, errors.InvalidStateError.Wrapf(err, "fail to get digest id=%x", b.ID())
@@ -60,5 +89,5 @@ func (m *manager) verifyProofForLastBlock(
 		return nil, nil, errors.Wrapf(err, "fail to verify block id=%x", b.ID())
 	}
 
-	return common.NewConsensusInfo(b.Proposer(), validators, voted), validators, nil
+	return common.NewConsensusInfoWithSeed(b.Proposer(), validators, voted, votes, b.ID()), validators, nil
 }
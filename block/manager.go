@@ -9,6 +9,7 @@ import (
 	"github.com/icon-project/goloop/btp"
 	"github.com/icon-project/goloop/chain/base"
 	"github.com/icon-project/goloop/chain/gs"
+	"github.com/icon-project/goloop/common/chaos"
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
@@ -89,6 +90,7 @@ type Chain interface {
 	GenesisStorage() module.GenesisStorage
 	CommitVoteSetDecoder() module.CommitVoteSetDecoder
 	Genesis() []byte
+	SyncAnchor() *module.SyncAnchor
 }
 
 type chainContext struct {
@@ -450,7 +452,7 @@ func (m *manager) _propose(
 	if bn == nil {
 		return nil, errors.Errorf("NoParentBlock(id=<%x>)", parentID)
 	}
-	csi, _, err := m.verifyProofForLastBlock(bn.block, votes)
+	csi, _, err := m.verifyProofForLastBlock(bn.block, votes, false)
 	if err != nil {
 		return nil, err
 	}
@@ -668,6 +670,16 @@ func NewManager(
 			cid, m.chain.CID())
 	}
 
+	if anchor := m.chain.SyncAnchor(); anchor != nil && anchor.Height <= height {
+		blk, err := m.getBlockByHeight(anchor.Height)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifySyncAnchor(anchor, blk); err != nil {
+			return nil, err
+		}
+	}
+
 	mtr, _ := m.sm.CreateInitialTransition(lastFinalized.Result(), lastFinalized.NextValidators())
 	if mtr == nil {
 		return nil, err
@@ -984,6 +996,10 @@ func (m *manager) finalize(bn *bnode, updatePCM bool) error {
 	// TODO update nmap
 	block := bn.block
 
+	if err := chaos.Inject(chaos.PointBlockFinalize); err != nil {
+		return err
+	}
+
 	if m.finalized != nil {
 		m.removeNodeExcept(m.finalized, bn)
 		err := m.sm.Finalize(
@@ -1097,6 +1113,47 @@ func WriteTransactionLocators(
 	return nil
 }
 
+// RemoveBlockIndex removes the index of the block at the height and the
+// locators of its transactions. It's used to roll back the chain, so the
+// locators of transactions included in other blocks are not touched.
+func RemoveBlockIndex(
+	dbase db.Database,
+	height int64,
+	ptl module.TransactionList,
+	ntl module.TransactionList,
+) error {
+	bk, err := db.NewCodedBucket(dbase, db.TransactionLocatorByHash, nil)
+	if err != nil {
+		return err
+	}
+	for _, tl := range []module.TransactionList{ptl, ntl} {
+		for it := tl.Iterator(); it.Has(); log.Must(it.Next()) {
+			tr, _, err := it.Get()
+			if err != nil {
+				return err
+			}
+			var trLoc transactionLocator
+			if err = bk.Get(db.Raw(tr.ID()), &trLoc); err != nil {
+				if errors.NotFoundError.Equals(err) {
+					continue
+				}
+				return err
+			}
+			if trLoc.BlockHeight != height {
+				continue
+			}
+			if err = bk.Delete(db.Raw(tr.ID())); err != nil {
+				return err
+			}
+		}
+	}
+	hb, err := db.NewCodedBucket(dbase, db.BlockHeaderHashByHeight, nil)
+	if err != nil {
+		return err
+	}
+	return hb.Delete(height)
+}
+
 func newProposer(bs []byte) (module.Address, error) {
 	if bs != nil {
 		addr, err := common.NewAddress(bs)
@@ -1565,6 +1622,25 @@ func (m *manager) ExportBlocksWithFlag(from, to int64, dst db.Database, flag int
 	return nil
 }
 
+func (m *manager) ExportHeaders(from, to int64, dst db.Database, cb module.ProgressCallback) error {
+	ctx := merkle.NewCopyContext(m.db(), dst)
+	for h := from; h <= to; h++ {
+		blk, err := m.GetBlockByHeight(h)
+		if err != nil {
+			return errors.Wrapf(err, "fail to get a block height=%d", h)
+		}
+		if err := m._export(blk, ctx, exportBlock|exportIndex); err != nil {
+			return errors.Wrapf(err, "fail to export header height=%d", h)
+		}
+		if cb != nil {
+			if err := cb(h, 0, 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (m *manager) _export(blk module.Block, ctx *merkle.CopyContext, flag int) error {
 	ctx.SetHeight(blk.Height())
 	if hasBits(flag, exportResult) {
@@ -1684,7 +1760,7 @@ func (m *manager) newConsensusInfo(blk module.Block) (module.ConsensusInfo, erro
 	if err != nil {
 		return nil, err
 	}
-	return common.NewConsensusInfo(pblk.Proposer(), vl, voted), nil
+	return common.NewConsensusInfoWithSeed(pblk.Proposer(), vl, voted, blk.Votes(), pblk.ID()), nil
 }
 
 func GetBlockHeaderHashByHeight(
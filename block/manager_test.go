@@ -28,6 +28,8 @@ import (
 	"github.com/icon-project/goloop/btp/ntm"
 	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/wallet"
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/platform/basic"
@@ -541,6 +543,41 @@ func TestManager_ExportBlocks(t *testing.T) {
 	assert.EqualValues(blk.ID(), blk2.ID())
 }
 
+func TestManager_ExportHeaders(t *testing.T) {
+	assert := assert.New(t)
+	nd := test.NewNode(t)
+	defer nd.Close()
+	nd.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+	nd.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+	dbase := db.NewMapDB()
+	var heights []int64
+	err := nd.BM.ExportHeaders(0, 1, dbase, func(h int64, r, u int) error {
+		heights = append(heights, h)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]int64{0, 1}, heights)
+	err = nd.BM.ExportBlocks(2, 2, dbase, nil)
+	assert.NoError(err)
+	block.ResetDB(dbase, nil, 2)
+
+	nd2 := test.NewNode(t, test.UseDB(dbase))
+	defer nd2.Close()
+	for h := int64(0); h <= 2; h++ {
+		blk, err := nd.BM.GetBlockByHeight(h)
+		assert.NoError(err)
+		blk2, err := nd2.BM.GetBlockByHeight(h)
+		assert.NoError(err)
+		assert.EqualValues(blk.ID(), blk2.ID())
+	}
+
+	errStop := errors.New("stop")
+	err = nd.BM.ExportHeaders(0, 2, db.NewMapDB(), func(h int64, r, u int) error {
+		return errStop
+	})
+	assert.Equal(errStop, err)
+}
+
 func TestManager_GetTransactionInfo(t *testing.T) {
 	assert := assert.New(t)
 	nd := test.NewNode(t)
@@ -562,3 +599,75 @@ func TestManager_GetTransactionInfo(t *testing.T) {
 	assert.NoError(err)
 	assert.EqualValues(module.StatusSuccess, rct.Status())
 }
+
+func newScriptedFixture(t *testing.T) (*test.Fixture, *test.ScriptedServiceManager, *test.ScriptedServiceManager) {
+	f := test.NewFixture(t, test.AddValidatorNodes(1), test.UseSMFactory(
+		func(ctx *test.NodeContext) module.ServiceManager {
+			return test.NewScriptedServiceManager(ctx.C)
+		},
+	))
+	return f, f.Validators[0].SM.(*test.ScriptedServiceManager), f.SM.(*test.ScriptedServiceManager)
+}
+
+func TestBlockManager_ScriptedValidators(t *testing.T) {
+	assert := assert.New(t)
+	f, vsm, sm := newScriptedFixture(t)
+	defer f.Close()
+
+	w1, w2 := wallet.New(), wallet.New()
+	vsm.At(1).Validators(w1.Address(), w2.Address())
+	sm.At(1).Validators(w1.Address(), w2.Address())
+
+	vNode := f.Validators[0]
+	vNode.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+	assert.EqualValues(1, vNode.LastBlock.NextValidators().Len())
+	f.ImportFinalizeBlockByReader(getReaderForBlock(t, vNode.LastBlock))
+
+	vNode.ProposeFinalizeBlock(vNode.NewVoteListForLastBlock())
+	vl := vNode.LastBlock.NextValidators()
+	assert.EqualValues(2, vl.Len())
+	assert.EqualValues(1, vl.IndexOf(w2.Address()))
+	f.ImportFinalizeBlockByReader(getReaderForBlock(t, vNode.LastBlock))
+	assert.Equal(vl.Hash(), f.LastBlock.NextValidatorsHash())
+}
+
+func importBlockError(nd *test.Node, blk module.Block) error {
+	ch := make(chan error, 1)
+	_, err := nd.BM.Import(getReaderForBlock(nd.T, blk), 0, func(bc module.BlockCandidate, err error) {
+		ch <- err
+	})
+	if err != nil {
+		return err
+	}
+	return <-ch
+}
+
+func TestBlockManager_ScriptedResultMismatch(t *testing.T) {
+	assert := assert.New(t)
+	f, _, sm := newScriptedFixture(t)
+	defer f.Close()
+
+	sm.At(1).Result([]byte("other"))
+
+	vNode := f.Validators[0]
+	vNode.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+	f.ImportFinalizeBlockByReader(getReaderForBlock(t, vNode.LastBlock))
+
+	vNode.ProposeFinalizeBlock(vNode.NewVoteListForLastBlock())
+	assert.Error(importBlockError(f.Node, vNode.LastBlock))
+}
+
+func TestBlockManager_ScriptedExecuteError(t *testing.T) {
+	assert := assert.New(t)
+	f, _, sm := newScriptedFixture(t)
+	defer f.Close()
+
+	sm.At(1).ExecuteError(errors.ExecutionFailError.New("ScriptedFailure"))
+
+	vNode := f.Validators[0]
+	vNode.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+	f.ImportFinalizeBlockByReader(getReaderForBlock(t, vNode.LastBlock))
+
+	vNode.ProposeFinalizeBlock(vNode.NewVoteListForLastBlock())
+	assert.Error(importBlockError(f.Node, vNode.LastBlock))
+}
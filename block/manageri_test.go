@@ -286,6 +286,28 @@ func TestBlockManager_Import_OK(t *testing.T) {
 	}
 }
 
+func TestBlockManager_Import_SyncAnchor(t *testing.T) {
+	s := newBlockManagerTestSetUp(t)
+	anchor := s.bg.getBlock(3)
+	s.chain.anchor = &module.SyncAnchor{
+		Height:         anchor.Height(),
+		BlockID:        getBadBlockID(t, s.bm),
+		ValidatorsHash: anchor.NextValidatorsHash(),
+	}
+	for i := int64(1); i < 3; i++ {
+		br := importSync(s.bm, s.bg.getReaderForBlock(i))
+		br.assertOK(t)
+		assert.NoError(t, s.bm.Finalize(br.blk))
+	}
+	br := importSync(s.bm, s.bg.getReaderForBlock(3))
+	br.assertError(t)
+
+	s.chain.anchor.BlockID = anchor.ID()
+	br = importSync(s.bm, s.bg.getReaderForBlock(3))
+	br.assertOK(t)
+	assert.NoError(t, s.bm.Finalize(br.blk))
+}
+
 func TestBlockManager_Import_Cancel(t *testing.T) {
 	s := newBlockManagerTestSetUp(t)
 	ec := make(chan struct{})
@@ -32,6 +32,7 @@ type testChain struct {
 	vld      module.CommitVoteSetDecoder
 	sm       *testServiceManager
 	bm       module.BlockManager
+	anchor   *module.SyncAnchor
 }
 
 func (c *testChain) DefaultWaitTimeout() time.Duration {
@@ -82,6 +83,10 @@ func (c *testChain) ServiceManager() module.ServiceManager {
 	return c.sm
 }
 
+func (c *testChain) SyncAnchor() *module.SyncAnchor {
+	return c.anchor
+}
+
 func (c *testChain) Logger() log.Logger {
 	return log.GlobalLogger()
 }
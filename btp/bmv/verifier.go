@@ -0,0 +1,185 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package bmv provides the registry of BTP message verifiers for source
+// chains. A verifier for a network type is registered with Register (usually
+// in init() of its package), and instances of the verifier are opened with
+// their own configuration and state storage.
+package bmv
+
+import (
+	"sort"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+const (
+	InstanceIDKey   = "bmvInstanceID"
+	InstanceByIDKey = "bmvInstanceByID"
+	StoreByIDKey    = "bmvStoreByID"
+)
+
+// Store is the state storage of a verifier instance. Keys of instances
+// don't conflict with each other.
+type Store interface {
+	Get(key []byte) []byte
+	Set(key, value []byte) error
+	Delete(key []byte) error
+}
+
+// Verifier verifies relay messages of a source chain.
+type Verifier interface {
+	// Verify verifies the relay message with the state in the store and
+	// updates the state. It returns BTP messages in the relay message.
+	Verify(store Store, height int64, msg []byte) ([][]byte, error)
+}
+
+// Factory returns the verifier for the configuration. It returns error if
+// the configuration is invalid.
+type Factory func(config []byte) (Verifier, error)
+
+var factories = make(map[string]Factory)
+
+// Register registers the factory of the verifier for the network type.
+func Register(uid string, f Factory) {
+	if _, ok := factories[uid]; ok {
+		panic("Duplicate verifier uid=" + uid)
+	}
+	factories[uid] = f
+}
+
+func ForUID(uid string) Factory {
+	return factories[uid]
+}
+
+// UIDs returns the network types of registered verifiers in ascending order.
+func UIDs() []string {
+	uids := make([]string, 0, len(factories))
+	for uid := range factories {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+	return uids
+}
+
+// Instance is the verifier instance opened with the configuration.
+type Instance struct {
+	UID    string
+	Config []byte
+}
+
+func (i *Instance) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(i)
+}
+
+func (i *Instance) NewVerifier() (Verifier, error) {
+	f := ForUID(i.UID)
+	if f == nil {
+		return nil, errors.NotFoundError.Errorf("UnknownVerifier(uid=%s)", i.UID)
+	}
+	return f(i.Config)
+}
+
+func NewInstanceFromBytes(bs []byte) (*Instance, error) {
+	i := new(Instance)
+	if _, err := codec.BC.UnmarshalFromBytes(bs, i); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidInstance")
+	}
+	return i, nil
+}
+
+type instanceStore struct {
+	dict *containerdb.DictDB
+}
+
+func (s *instanceStore) Get(key []byte) []byte {
+	if value := s.dict.Get(key); value != nil {
+		return value.Bytes()
+	}
+	return nil
+}
+
+func (s *instanceStore) Set(key, value []byte) error {
+	return s.dict.Set(key, value)
+}
+
+func (s *instanceStore) Delete(key []byte) error {
+	return s.dict.Delete(key)
+}
+
+// NewStore returns the state storage of the instance in the store.
+func NewStore(store containerdb.BytesStoreState, id int64) Store {
+	return &instanceStore{
+		dict: scoredb.NewDictDB(store, StoreByIDKey, 2).GetDB(id),
+	}
+}
+
+// Open opens a new instance of the verifier for the network type, and
+// returns its ID. IDs start from 1.
+func Open(store containerdb.BytesStoreState, uid string, config []byte) (int64, error) {
+	i := &Instance{UID: uid, Config: config}
+	if _, err := i.NewVerifier(); err != nil {
+		return 0, errors.IllegalArgumentError.Wrapf(err, "InvalidVerifier(uid=%s)", uid)
+	}
+	idDB := scoredb.NewVarDB(store, InstanceIDKey)
+	id := idDB.Int64() + 1
+	if err := idDB.Set(id); err != nil {
+		return 0, err
+	}
+	if err := scoredb.NewDictDB(store, InstanceByIDKey, 1).Set(id, i.Bytes()); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetInstance returns the instance of the ID. It returns nil if there is
+// no instance for the ID.
+func GetInstance(store containerdb.BytesStoreState, id int64) (*Instance, error) {
+	value := scoredb.NewDictDB(store, InstanceByIDKey, 1).Get(id)
+	if value == nil {
+		return nil, nil
+	}
+	return NewInstanceFromBytes(value.Bytes())
+}
+
+// Close closes the instance. The state of the instance is left in the store.
+func Close(store containerdb.BytesStoreState, id int64) error {
+	dict := scoredb.NewDictDB(store, InstanceByIDKey, 1)
+	if dict.Get(id) == nil {
+		return errors.NotFoundError.Errorf("InstanceNotFound(id=%d)", id)
+	}
+	return dict.Delete(id)
+}
+
+// Verify verifies the relay message with the instance of the ID, and
+// returns BTP messages in it.
+func Verify(store containerdb.BytesStoreState, id int64, height int64, msg []byte) ([][]byte, error) {
+	i, err := GetInstance(store, id)
+	if err != nil {
+		return nil, err
+	}
+	if i == nil {
+		return nil, errors.NotFoundError.Errorf("InstanceNotFound(id=%d)", id)
+	}
+	v, err := i.NewVerifier()
+	if err != nil {
+		return nil, err
+	}
+	return v.Verify(NewStore(store, id), height, msg)
+}
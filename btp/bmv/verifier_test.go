@@ -0,0 +1,126 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bmv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/trie/trie_manager"
+)
+
+const testUID = "test"
+
+var keyLastHeight = []byte("lastHeight")
+
+// testVerifier accepts messages of the height greater than the last one,
+// and returns the message split by the separator in the config.
+type testVerifier struct {
+	sep []byte
+}
+
+func (v *testVerifier) Verify(store Store, height int64, msg []byte) ([][]byte, error) {
+	if bs := store.Get(keyLastHeight); bs != nil && int64(bs[0]) >= height {
+		return nil, errors.InvalidStateError.Errorf("InvalidHeight(height=%d)", height)
+	}
+	if err := store.Set(keyLastHeight, []byte{byte(height)}); err != nil {
+		return nil, err
+	}
+	return bytes.Split(msg, v.sep), nil
+}
+
+func init() {
+	Register(testUID, func(config []byte) (Verifier, error) {
+		if len(config) == 0 {
+			return nil, errors.IllegalArgumentError.New("EmptySeparator")
+		}
+		return &testVerifier{sep: config}, nil
+	})
+}
+
+func newTestStore() containerdb.BytesStoreState {
+	return containerdb.NewBytesStoreStateFromRaw(
+		trie_manager.NewMutable(db.NewMapDB(), nil))
+}
+
+func TestRegister(t *testing.T) {
+	assert.NotNil(t, ForUID(testUID))
+	assert.Nil(t, ForUID("unknown"))
+	assert.Contains(t, UIDs(), testUID)
+	assert.Panics(t, func() {
+		Register(testUID, nil)
+	})
+}
+
+func TestOpen(t *testing.T) {
+	store := newTestStore()
+
+	_, err := Open(store, "unknown", []byte(","))
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+	_, err = Open(store, testUID, nil)
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+
+	id1, err := Open(store, testUID, []byte(","))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, id1)
+	id2, err := Open(store, testUID, []byte(";"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, id2)
+
+	i, err := GetInstance(store, id2)
+	assert.NoError(t, err)
+	assert.Equal(t, &Instance{UID: testUID, Config: []byte(";")}, i)
+
+	assert.NoError(t, Close(store, id1))
+	i, err = GetInstance(store, id1)
+	assert.NoError(t, err)
+	assert.Nil(t, i)
+	assert.True(t, errors.NotFoundError.Equals(Close(store, id1)))
+
+	// IDs are not reused
+	id3, err := Open(store, testUID, []byte(","))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, id3)
+}
+
+func TestVerify(t *testing.T) {
+	store := newTestStore()
+	id1, err := Open(store, testUID, []byte(","))
+	assert.NoError(t, err)
+	id2, err := Open(store, testUID, []byte(";"))
+	assert.NoError(t, err)
+
+	msgs, err := Verify(store, id1, 2, []byte("a,b;c"))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b;c")}, msgs)
+
+	_, err = Verify(store, id1, 2, []byte("a"))
+	assert.True(t, errors.InvalidStateError.Equals(err))
+
+	// state of each instance is separated
+	msgs, err = Verify(store, id2, 1, []byte("a,b;c"))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a,b"), []byte("c")}, msgs)
+
+	_, err = Verify(store, 5, 1, []byte("a"))
+	assert.True(t, errors.NotFoundError.Equals(err))
+}
@@ -19,6 +19,7 @@ package btp
 import (
 	"github.com/icon-project/goloop/common/atomic"
 	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
 )
 
@@ -126,3 +127,53 @@ func NewBTPBlockHeader(
 	}
 	return bb, nil
 }
+
+// NewBTPBlockHeaderFromBytes returns a BTPBlockHeader decoded from the bytes
+// returned by HeaderBytes.
+func NewBTPBlockHeaderFromBytes(bs []byte) (module.BTPBlockHeader, error) {
+	bb := &btpBlockHeader{}
+	if _, err := codec.UnmarshalFromBytes(bs, &bb.format); err != nil {
+		return nil, errors.Wrap(err, "InvalidBTPBlockHeader")
+	}
+	bb.bytes.Set(bs)
+	return bb, nil
+}
+
+// MerkleRootFromProof returns the merkle root calculated from the leaf and
+// the proof returned by NetworkTypeModule.MerkleProof.
+func MerkleRootFromProof(mod module.NetworkTypeModule, leaf []byte, proof []module.MerkleNode) []byte {
+	hash := leaf
+	for _, n := range proof {
+		if n.Value == nil {
+			continue
+		}
+		if n.Dir == module.DirLeft {
+			hash = mod.Hash(append(append([]byte(nil), n.Value...), hash...))
+		} else {
+			hash = mod.Hash(append(append([]byte(nil), hash...), n.Value...))
+		}
+	}
+	return hash
+}
+
+// NetworkSectionHash returns the hash of the network section for the header.
+func NetworkSectionHash(mod module.NetworkTypeModule, bh module.BTPBlockHeader) []byte {
+	return mod.Hash(codec.MustMarshalToBytes(&networkSectionFormat{
+		NetworkID:    bh.NetworkID(),
+		UpdateNumber: bh.UpdateNumber(),
+		PrevHash:     bh.PrevNetworkSectionHash(),
+		MessageCount: bh.MessageCount(),
+		MessagesRoot: bh.MessagesRoot(),
+	}))
+}
+
+// NetworkTypeSectionHash returns the hash of the network type section for
+// the header.
+func NetworkTypeSectionHash(mod module.NetworkTypeModule, bh module.BTPBlockHeader) []byte {
+	return mod.Hash(codec.MustMarshalToBytes(&networkTypeSectionFormat{
+		NextProofContextHash: bh.NextProofContextHash(),
+		NetworkSectionsRoot: MerkleRootFromProof(
+			mod, NetworkSectionHash(mod, bh), bh.NetworkSectionToRoot(),
+		),
+	}))
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/icon-project/goloop/btp/ntm"
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/module"
 )
@@ -53,3 +54,44 @@ func TestBTPBlockHeader_Basics(t *testing.T) {
 	codec.MustUnmarshalFromBytes(bs, &bb2.format)
 	assert.EqualValues(bb.(*btpBlockHeader).format, bb2.format)
 }
+
+func TestBTPBlockHeader_FromBytes(t *testing.T) {
+	assert := assert.New(t)
+	s := newComplexTestBuilderSetup(t)
+	mod := ntm.ForUID("eth")
+	for _, nid := range []int64{1, 2} {
+		nts, err := s.bs.NetworkTypeSectionFor(1)
+		assert.NoError(err)
+		ns, err := nts.NetworkSectionFor(nid)
+		assert.NoError(err)
+		bb, err := NewBTPBlockHeader(10, 1, nts, nid, 0)
+		assert.NoError(err)
+
+		bb2, err := NewBTPBlockHeaderFromBytes(bb.HeaderBytes())
+		assert.NoError(err)
+		assert.EqualValues(bb.HeaderBytes(), bb2.HeaderBytes())
+		assert.EqualValues(bb.(*btpBlockHeader).format, bb2.(*btpBlockHeader).format)
+
+		assert.EqualValues(ns.Hash(), NetworkSectionHash(mod, bb2))
+		assert.EqualValues(nts.Hash(), NetworkTypeSectionHash(mod, bb2))
+	}
+
+	_, err := NewBTPBlockHeaderFromBytes([]byte{0x01})
+	assert.Error(err)
+}
+
+func TestMerkleRootFromProof(t *testing.T) {
+	assert := assert.New(t)
+	mod := ntm.ForUID("eth")
+	for n := 1; n <= 7; n++ {
+		var leaves module.BytesSlice
+		for i := 0; i < n; i++ {
+			leaves = append(leaves, mod.Hash([]byte{byte(i)}))
+		}
+		root := mod.MerkleRoot(&leaves)
+		for i := 0; i < n; i++ {
+			proof := mod.MerkleProof(&leaves, i)
+			assert.EqualValues(root, MerkleRootFromProof(mod, leaves[i], proof), "n=%d i=%d", n, i)
+		}
+	}
+}
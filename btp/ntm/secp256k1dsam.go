@@ -18,6 +18,7 @@ package ntm
 
 import (
 	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
 )
 
 const (
@@ -36,6 +37,28 @@ func (s secp256k1DSAModule) Verify(pubKey []byte) error {
 	return err
 }
 
+// VerifyProofOfPossession verifies that proof is the recoverable signature
+// for SHA3-256 hash of msg made with the private key of pubKey.
+func (s secp256k1DSAModule) VerifyProofOfPossession(pubKey, msg, proof []byte) error {
+	pk, err := crypto.ParsePublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.ParseSignature(proof)
+	if err != nil {
+		return err
+	}
+	signer, err := sig.RecoverPublicKey(crypto.SHA3Sum256(msg))
+	if err != nil {
+		return err
+	}
+	if !signer.Equal(pk) {
+		return errors.Errorf("signer mismatch signer=%x pubKey=%x",
+			signer.SerializeCompressed(), pubKey)
+	}
+	return nil
+}
+
 var secp256k1DSAModuleInstance secp256k1DSAModule
 
 func init() {
@@ -41,3 +41,18 @@ func TestSecp256k1DSAModule_Verify(t *testing.T) {
 
 	assert.Error(dsam.Verify(pkBytes[:len(pkBytes)-1]))
 }
+
+func TestSecp256k1DSAModule_VerifyProofOfPossession(t *testing.T) {
+	assert := assert.New(t)
+
+	w := wallet.New()
+	dsam := DSAModuleForName(secp256k1DSA)
+	msg := []byte("message")
+	proof, err := w.Sign(crypto.SHA3Sum256(msg))
+	assert.NoError(err)
+	assert.NoError(dsam.VerifyProofOfPossession(w.PublicKey(), msg, proof))
+
+	assert.Error(dsam.VerifyProofOfPossession(w.PublicKey(), []byte("other"), proof))
+	assert.Error(dsam.VerifyProofOfPossession(wallet.New().PublicKey(), msg, proof))
+	assert.Error(dsam.VerifyProofOfPossession(w.PublicKey(), msg, proof[:len(proof)-1]))
+}
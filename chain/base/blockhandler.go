@@ -22,6 +22,7 @@ import (
 
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/module"
 )
 
@@ -77,4 +78,5 @@ type Chain interface {
 	Regulator() module.Regulator
 	Wallet() module.Wallet
 	WalletFor(dsa string) module.BaseWallet
+	SyncServerThrottle() *throttle.Throttle
 }
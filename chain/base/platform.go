@@ -52,3 +52,34 @@ type ExecutionResult interface {
 	TotalFee() *big.Int
 	VirtualFee() *big.Int
 }
+
+// NodeRoleHandler is called when the platform elects new set of nodes.
+// Validators are the nodes for producing blocks, and candidates are the
+// nodes which may be validators later (ex: sub P-Reps of ICON).
+type NodeRoleHandler func(validators, candidates []module.Address)
+
+// NodeRoleReporter is implemented by the platform which can report elected
+// nodes to the chain.
+type NodeRoleReporter interface {
+	SetNodeRoleHandler(h NodeRoleHandler)
+}
+
+// PlatformInspector is implemented by the platform which can report its
+// own status for inspection of the chain.
+type PlatformInspector interface {
+	Inspect(informal bool) map[string]interface{}
+}
+
+// RollbackHandler is implemented by the platform which keeps its own data
+// depending on blocks out of the world state. OnRollback is called before
+// blocks after the height are removed, and it may be called again for the
+// same height if the rollback is resumed.
+type RollbackHandler interface {
+	OnRollback(dbase db.Database, height int64) error
+}
+
+// ValidatorWeightsGetter is implemented by the platform which weights
+// validators for selecting proposers with VRF (ex: stakes of validators).
+type ValidatorWeightsGetter interface {
+	GetValidatorWeights(wss state.WorldSnapshot, validators module.ValidatorList) ([]*big.Int, error)
+}
@@ -13,9 +13,11 @@ import (
 	"github.com/icon-project/goloop/block"
 	"github.com/icon-project/goloop/chain/base"
 	"github.com/icon-project/goloop/chain/gs"
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/common/trie/cache"
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/module"
@@ -97,10 +99,13 @@ type singleChain struct {
 
 	regulator *regulator
 
+	syncThrottle *throttle.Throttle
+
 	state      State
 	lastErr    error
 	mtx        sync.RWMutex
 	task       chainTask
+	tasks      *taskHistory
 	termWaiter *sync.Cond
 
 	// monitor
@@ -113,6 +118,7 @@ const (
 	DefaultContractDir = "contract"
 	DefaultCacheDir    = "cache"
 	DefaultTmpDBDir    = "tmp"
+	DefaultTxPoolDir   = "txpool"
 )
 
 func (c *singleChain) Database() db.Database {
@@ -232,6 +238,46 @@ func (c *singleChain) TransactionTimeout() time.Duration {
 	return ConfigDefaultTxTimeout
 }
 
+func (c *singleChain) TxTimestampWindow() time.Duration {
+	if c.cfg.TxTimestampWindow > 0 {
+		return time.Duration(c.cfg.TxTimestampWindow) * time.Millisecond
+	}
+	return 0
+}
+
+func (c *singleChain) TxPoolTTL() time.Duration {
+	if c.cfg.TxPoolTTL > 0 {
+		return time.Duration(c.cfg.TxPoolTTL) * time.Millisecond
+	}
+	return 0
+}
+
+func (c *singleChain) TxPoolDir() string {
+	if c.cfg.TxPoolPersist {
+		return path.Join(c.cfg.AbsBaseDir(), DefaultTxPoolDir)
+	}
+	return ""
+}
+
+func (c *singleChain) PriorityTxRatio() int {
+	if c.cfg.PriorityTxRatio > 0 && c.cfg.PriorityTxRatio <= 100 {
+		return c.cfg.PriorityTxRatio
+	}
+	return 0
+}
+
+func (c *singleChain) Sequencer() module.Address {
+	if len(c.cfg.Sequencer) == 0 {
+		return nil
+	}
+	addr, err := common.NewAddressFromString(c.cfg.Sequencer)
+	if err != nil || addr.IsContract() {
+		c.logger.Warnf("invalid sequencer=%q", c.cfg.Sequencer)
+		return nil
+	}
+	return addr
+}
+
 func (c *singleChain) ChildrenLimit() int {
 	if c.cfg.ChildrenLimit != nil && *c.cfg.ChildrenLimit >= 0 {
 		return *c.cfg.ChildrenLimit
@@ -250,6 +296,46 @@ func (c *singleChain) ValidateTxOnSend() bool {
 	return c.cfg.ValidateTxOnSend
 }
 
+func (c *singleChain) RecordInternalCalls() bool {
+	return c.cfg.RecordInternalCalls
+}
+
+func (c *singleChain) RecordAddressIndex() bool {
+	return c.cfg.RecordAddressIndex
+}
+
+func (c *singleChain) RecordTokenIndex() bool {
+	return c.cfg.RecordTokenIndex
+}
+
+func (c *singleChain) SyncServerThrottle() *throttle.Throttle {
+	return c.syncThrottle
+}
+
+func (c *singleChain) isReadReplica() bool {
+	return len(c.cfg.ReplicaDBDir) > 0
+}
+
+func (c *singleChain) SyncAnchor() *module.SyncAnchor {
+	if a := c.cfg.SyncAnchor; a != nil {
+		return &module.SyncAnchor{
+			Height:         a.Height,
+			BlockID:        a.BlockID,
+			ValidatorsHash: a.ValidatorsHash,
+		}
+	}
+	return nil
+}
+
+func (c *singleChain) HistoryCapability() *module.HistoryCapability {
+	hc, err := module.NewHistoryCapability(c.cfg.HistoryDepth, c.cfg.HistoryQueries)
+	if err != nil {
+		c.logger.Warnf("invalid history_queries=%q", c.cfg.HistoryQueries)
+		return nil
+	}
+	return hc
+}
+
 func (c *singleChain) State() (string, int64, error) {
 	c.mtx.RLock()
 	defer c.mtx.RUnlock()
@@ -262,6 +348,11 @@ func (c *singleChain) State() (string, int64, error) {
 				height = blk.Height()
 			}
 		}
+		if c.state == Started && c.cs != nil {
+			if s := c.cs.GetStatus(); s != nil && s.HaltReason != nil {
+				return "halted", height, s.HaltReason
+			}
+		}
 		return c.task.DetailOf(c.state), height, c.lastErr
 	default:
 		return c.state.String(), c.lastBlockHeight(), c.lastErr
@@ -275,6 +366,16 @@ func (c *singleChain) lastBlockHeight() int64 {
 	return block.GetLastHeightOf(c.database)
 }
 
+func (c *singleChain) Tasks() []*module.ChainTaskStatus {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	if c.tasks == nil {
+		return []*module.ChainTaskStatus{}
+	}
+	return c.tasks.list(c.state)
+}
+
 func (c *singleChain) IsStarted() bool {
 	c.mtx.RLock()
 	defer c.mtx.RUnlock()
@@ -395,22 +496,58 @@ func (c *singleChain) ensureDatabase() {
 }
 
 func (c *singleChain) prepareDatabase(chainDir string) error {
-	DBDir := path.Join(chainDir, DefaultDBDir)
-	cdb, err := c.openDatabase(DBDir, c.cfg.DBType)
+	database, err := c.newDatabase(chainDir)
 	if err != nil {
 		return err
 	}
+	c.database = database
+	return nil
+}
+
+// openReplicaDatabase opens the database of the chain followed by the read
+// replica in read-only mode.
+func (c *singleChain) openReplicaDatabase() (db.Database, error) {
+	dbDir := c.cfg.ResolveAbsolute(c.cfg.ReplicaDBDir)
+	DBName := strconv.FormatInt(int64(c.cfg.NID), 16)
+	if cdb, err := db.OpenReadOnly(dbDir, c.cfg.DBType, DBName); err != nil {
+		return nil, errors.Wrapf(err,
+			"fail to open replica database dir=%s type=%s name=%s", dbDir, c.cfg.DBType, DBName)
+	} else {
+		return cdb, nil
+	}
+}
+
+func (c *singleChain) newDatabase(chainDir string) (db.Database, error) {
+	var cdb db.Database
+	var err error
+	if c.isReadReplica() {
+		cdb, err = c.openReplicaDatabase()
+	} else {
+		cdb, err = c.openDatabase(path.Join(chainDir, DefaultDBDir), c.cfg.DBType)
+	}
+	if err != nil {
+		return nil, err
+	}
 	if len(c.cfg.NodeCache) == 0 {
 		c.cfg.NodeCache = NodeCacheDefault
 	}
 	mLevel, fLevel, stores, err := ParseNodeCacheOption(c.cfg.NodeCache)
 	if err != nil {
 		_ = cdb.Close()
-		return errors.Wrapf(err, "UnknownCacheStrategy(%s)", c.cfg.NodeCache)
+		return nil, errors.Wrapf(err, "UnknownCacheStrategy(%s)", c.cfg.NodeCache)
+	}
+	if len(c.cfg.ReceiptCompression) == 0 {
+		c.cfg.ReceiptCompression = string(db.CompressionDefault)
 	}
 	cacheDir := path.Join(chainDir, DefaultCacheDir)
-	c.database = cache.AttachManager(cdb, cacheDir, mLevel, fLevel, stores)
-	return nil
+	database, err := db.NewCompressDB(
+		cache.AttachManager(cdb, cacheDir, mLevel, fLevel, stores),
+		db.Compression(c.cfg.ReceiptCompression))
+	if err != nil {
+		_ = cdb.Close()
+		return nil, err
+	}
+	return database, nil
 }
 
 func (c *singleChain) releaseDatabase() {
@@ -444,11 +581,18 @@ func (c *singleChain) _init() error {
 	} else {
 		c.plt = plt
 	}
+	c.prepareNodeRole()
 
 	if err := c.prepareDatabase(chainDir); err != nil {
 		return err
 	}
 
+	if th, err := newTaskHistory(path.Join(chainDir, DefaultTaskHistoryFile), c.logger); err != nil {
+		return err
+	} else {
+		c.tasks = th
+	}
+
 	c.vld = c.plt.CommitVoteSetDecoder()
 	if c.vld == nil {
 		c.vld = consensus.NewCommitVoteSetFromBytes
@@ -459,6 +603,7 @@ func (c *singleChain) _init() error {
 }
 
 func (c *singleChain) prepareManagers() error {
+	c.syncThrottle = throttle.New(c.cfg.SyncServerLimit, c.cfg.SyncServerBandwidth)
 	pr := network.PeerRoleFlag(c.cfg.Role)
 	c.nm = network.NewManager(c, c.nt, c.cfg.SeedAddr, pr.ToRoles()...)
 
@@ -501,13 +646,31 @@ func (c *singleChain) releaseManagers() {
 	}
 }
 
+// _recordTask returns the history to record the task. Tasks other than
+// the consensus are recorded as maintenance tasks.
+func (c *singleChain) _recordTask(task chainTask) *taskHistory {
+	if _, ok := task.(*taskConsensus); ok {
+		return nil
+	}
+	return c.tasks
+}
+
 func (c *singleChain) _runTask(task chainTask, wait bool) error {
+	if _, ok := task.(*taskReadReplica); c.isReadReplica() && !ok {
+		return errors.InvalidStateError.Errorf("ReadReplicaNotAllowed(task=%s)", task.String())
+	}
 	if err := c._setStartingTask(task); err != nil {
 		return err
 	}
+	if th := c._recordTask(task); th != nil {
+		th.onStart(task)
+	}
 	if err := task.Start(); err != nil {
 		c.logger.Infof("Fail to start %s err=%v",
 			task.String(), err)
+		if th := c._recordTask(task); th != nil {
+			th.onEnd(task, err)
+		}
 		c._transitOrTerminate(Failed, err, Starting)
 		return err
 	}
@@ -527,6 +690,9 @@ func (c *singleChain) _runTask(task chainTask, wait bool) error {
 func (c *singleChain) _waitResultOf(task chainTask) error {
 	result := task.Wait()
 	c.logger.Infof("DONE %s err=%+v", task.String(), result)
+	if th := c._recordTask(task); th != nil {
+		th.onEnd(task, result)
+	}
 
 	if result == nil {
 		c._transitOrTerminate(Finished, nil, Started, Stopping)
@@ -552,7 +718,12 @@ func (c *singleChain) Init() error {
 }
 
 func (c *singleChain) Start() error {
-	task := newTaskConsensus(c)
+	var task chainTask
+	if c.isReadReplica() {
+		task = newTaskReadReplica(c)
+	} else {
+		task = newTaskConsensus(c)
+	}
 	return c._runTask(task, false)
 }
 
@@ -582,16 +753,21 @@ func (c *singleChain) Import(src string, height int64) error {
 	return c._runTask(task, false)
 }
 
-func (c *singleChain) Prune(gsfile string, dbtype string, height int64) error {
+func (c *singleChain) Prune(gsfile string, dbtype string, height int64, headers bool) error {
 	if dbtype == "" {
 		dbtype = c.cfg.DBType
 	}
-	task := newTaskPruning(c, gsfile, dbtype, height)
+	task := newTaskPruning(c, gsfile, dbtype, height, headers)
+	return c._runTask(task, false)
+}
+
+func (c *singleChain) Rollback(height int64) error {
+	task := newTaskRollback(c, height)
 	return c._runTask(task, false)
 }
 
-func (c *singleChain) Backup(file string, extra []string) error {
-	task := newTaskBackup(c, file, extra)
+func (c *singleChain) Backup(file string, base string, extra []string) error {
+	task := newTaskBackup(c, file, base, extra)
 	return c._runTask(task, false)
 }
 
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
@@ -29,6 +30,23 @@ const (
 	NodeCacheDefault = NodeCacheNone
 )
 
+// SyncAnchor is the trusted block for fast bootstrap.
+type SyncAnchor struct {
+	Height         int64           `json:"height"`
+	BlockID        common.HexBytes `json:"blockID"`
+	ValidatorsHash common.HexBytes `json:"validatorsHash"`
+}
+
+func (a *SyncAnchor) Validate() error {
+	if a.Height < 1 {
+		return errors.IllegalArgumentError.Errorf("InvalidAnchorHeight(height=%d)", a.Height)
+	}
+	if len(a.BlockID) == 0 || len(a.ValidatorsHash) == 0 {
+		return errors.IllegalArgumentError.New("NoAnchorBlockIDOrValidatorsHash")
+	}
+	return nil
+}
+
 type Config struct {
 	// fixed
 	NID    int    `json:"nid"`
@@ -37,17 +55,35 @@ type Config struct {
 	Platform string `json:"platform,omitempty"`
 
 	// static
-	SeedAddr         string `json:"seed_addr"`
-	Role             uint   `json:"role"`
-	ConcurrencyLevel int    `json:"concurrency_level,omitempty"`
-	NormalTxPoolSize int    `json:"normal_tx_pool,omitempty"`
-	PatchTxPoolSize  int    `json:"patch_tx_pool,omitempty"`
-	MaxBlockTxBytes  int    `json:"max_block_tx_bytes,omitempty"`
-	NodeCache        string `json:"node_cache,omitempty"`
-	AutoStart        bool   `json:"auto_start,omitempty"`
-	ChildrenLimit    *int   `json:"children_limit,omitempty"`
-	NephewsLimit     *int   `json:"nephews_limit,omitempty"`
-	ValidateTxOnSend bool   `json:"validate_tx_on_send,omitempty"`
+	SeedAddr            string      `json:"seed_addr"`
+	Role                uint        `json:"role"`
+	ConcurrencyLevel    int         `json:"concurrency_level,omitempty"`
+	NormalTxPoolSize    int         `json:"normal_tx_pool,omitempty"`
+	PatchTxPoolSize     int         `json:"patch_tx_pool,omitempty"`
+	MaxBlockTxBytes     int         `json:"max_block_tx_bytes,omitempty"`
+	NodeCache           string      `json:"node_cache,omitempty"`
+	AutoStart           bool        `json:"auto_start,omitempty"`
+	ChildrenLimit       *int        `json:"children_limit,omitempty"`
+	NephewsLimit        *int        `json:"nephews_limit,omitempty"`
+	ValidateTxOnSend    bool        `json:"validate_tx_on_send,omitempty"`
+	AutoRole            bool        `json:"auto_role,omitempty"`
+	RecordInternalCalls bool        `json:"record_internal_calls,omitempty"`
+	RecordAddressIndex  bool        `json:"record_address_index,omitempty"`
+	RecordTokenIndex    bool        `json:"record_token_index,omitempty"`
+	SyncServerLimit     int         `json:"sync_server_limit,omitempty"`
+	SyncServerBandwidth int64       `json:"sync_server_bandwidth,omitempty"`
+	SyncAnchor          *SyncAnchor `json:"sync_anchor,omitempty"`
+	TxTimestampWindow   int64       `json:"tx_timestamp_window,omitempty"`
+	TxPoolTTL           int64       `json:"tx_pool_ttl,omitempty"`
+	TxPoolPersist       bool        `json:"tx_pool_persist,omitempty"`
+	PriorityTxRatio     int         `json:"priority_tx_ratio,omitempty"`
+	Sequencer           string      `json:"sequencer,omitempty"`
+	ReceiptCompression  string      `json:"receipt_compression,omitempty"`
+	ReplicaDBDir        string      `json:"replica_db_dir,omitempty"`
+	ReplicaInterval     int64       `json:"replica_interval,omitempty"`
+	HistoryDepth        int64       `json:"history_depth,omitempty"`
+	HistoryQueries      string      `json:"history_queries,omitempty"`
+	WatchdogRestart     bool        `json:"watchdog_restart,omitempty"`
 
 	// runtime
 	Channel        string `json:"channel"`
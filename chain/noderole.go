@@ -0,0 +1,78 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"github.com/icon-project/goloop/chain/base"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/network"
+)
+
+func containsAddress(addrs []module.Address, addr module.Address) bool {
+	for _, a := range addrs {
+		if a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func addRole(roles []module.Role, r module.Role) []module.Role {
+	for _, role := range roles {
+		if role == r {
+			return roles
+		}
+	}
+	return append(roles, r)
+}
+
+// rolesForElection returns the roles of the node for the elected nodes.
+// Validators take both of seed and validator roles, and candidates take
+// seed role in addition to the configured roles.
+func rolesForElection(role uint, self module.Address, validators, candidates []module.Address) []module.Role {
+	pr := network.PeerRoleFlag(role)
+	roles := pr.ToRoles()
+	if containsAddress(validators, self) {
+		roles = addRole(roles, module.ROLE_SEED)
+		roles = addRole(roles, module.ROLE_VALIDATOR)
+	} else if containsAddress(candidates, self) {
+		roles = addRole(roles, module.ROLE_SEED)
+	}
+	return roles
+}
+
+func (c *singleChain) onElectedNodes(validators, candidates []module.Address) {
+	nm := c.nm
+	if nm == nil {
+		return
+	}
+	roles := rolesForElection(c.cfg.Role, c.wallet.Address(), validators, candidates)
+	c.logger.Infof("Update node roles validators=%d candidates=%d roles=%v",
+		len(validators), len(candidates), roles)
+	nm.SetInitialRoles(roles...)
+}
+
+func (c *singleChain) prepareNodeRole() {
+	if !c.cfg.AutoRole {
+		return
+	}
+	if r, ok := c.plt.(base.NodeRoleReporter); ok {
+		r.SetNodeRoleHandler(c.onElectedNodes)
+	} else {
+		c.logger.Warnf("Platform doesn't support auto role platform=%s", c.cfg.Platform)
+	}
+}
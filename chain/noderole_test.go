@@ -0,0 +1,62 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestRolesForElection(t *testing.T) {
+	self := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	other := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	others := []module.Address{other}
+	selfAndOthers := []module.Address{other, self}
+
+	tests := []struct {
+		name       string
+		role       uint
+		validators []module.Address
+		candidates []module.Address
+		want       []module.Role
+	}{
+		{"NotElected", 0, others, others, []module.Role{}},
+		{"NotElectedSeed", 1, others, nil, []module.Role{module.ROLE_SEED}},
+		{"Validator", 0, selfAndOthers, others,
+			[]module.Role{module.ROLE_SEED, module.ROLE_VALIDATOR}},
+		{"ValidatorSeed", 1, selfAndOthers, nil,
+			[]module.Role{module.ROLE_SEED, module.ROLE_VALIDATOR}},
+		{"ValidatorBoth", 3, selfAndOthers, nil,
+			[]module.Role{module.ROLE_SEED, module.ROLE_VALIDATOR}},
+		{"ValidatorAndCandidate", 0, selfAndOthers, selfAndOthers,
+			[]module.Role{module.ROLE_SEED, module.ROLE_VALIDATOR}},
+		{"Candidate", 0, others, selfAndOthers, []module.Role{module.ROLE_SEED}},
+		{"CandidateValidator", 2, others, selfAndOthers,
+			[]module.Role{module.ROLE_VALIDATOR, module.ROLE_SEED}},
+		{"NoElection", 2, nil, nil, []module.Role{module.ROLE_VALIDATOR}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := rolesForElection(tt.role, self, tt.validators, tt.candidates)
+			assert.Equal(t, tt.want, roles)
+		})
+	}
+}
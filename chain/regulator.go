@@ -49,6 +49,13 @@ type regulator struct {
 	blockInterval    time.Duration
 	minCommitTimeout time.Duration
 
+	// maxBlockInterval and txThreshold are bounds of the adaptive interval.
+	// It proposes after minCommitTimeout if pendingTxCount reaches
+	// txThreshold, and after maxBlockInterval if there is no pending tx.
+	maxBlockInterval time.Duration
+	txThreshold      int
+	pendingTxCount   int
+
 	history      [30]txExecutionEntry
 	sum          txExecutionSum
 	currentIndex int
@@ -83,6 +90,37 @@ func (r *regulator) SetBlockInterval(blockInterval time.Duration, commitTimeout
 	r.currentTxCount = txCount
 }
 
+func (r *regulator) SetIntervalPolicy(max time.Duration, threshold int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.maxBlockInterval == max && r.txThreshold == threshold {
+		return
+	}
+
+	r.log.Printf("Regulator.SetIntervalPolicy(max=%s,threshold=%d)", max, threshold)
+
+	r.maxBlockInterval = max
+	r.txThreshold = threshold
+}
+
+func (r *regulator) OnTxPoolUpdated(count int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.pendingTxCount = count
+}
+
+func (r *regulator) intervalInLock() time.Duration {
+	if r.txThreshold > 0 && r.pendingTxCount >= r.txThreshold {
+		return r.minCommitTimeout
+	}
+	if r.maxBlockInterval > r.blockInterval && r.pendingTxCount == 0 {
+		return r.maxBlockInterval
+	}
+	return r.blockInterval
+}
+
 func (r *regulator) OnPropose(now time.Time) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -94,7 +132,7 @@ func (r *regulator) CommitTimeout() time.Duration {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	timeout := r.blockInterval - time.Now().Sub(r.proposeTime)
+	timeout := r.intervalInLock() - time.Now().Sub(r.proposeTime)
 	if timeout < r.minCommitTimeout {
 		timeout = r.minCommitTimeout
 	}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+)
+
+func TestRegulator_IntervalInLock(t *testing.T) {
+	const (
+		interval = 2 * time.Second
+		timeout  = 500 * time.Millisecond
+		max      = 10 * time.Second
+	)
+	for _, tt := range []struct {
+		name      string
+		max       time.Duration
+		threshold int
+		pending   int
+		expected  time.Duration
+	}{
+		{"NoPolicy", 0, 0, 0, interval},
+		{"NoPolicyWithTxs", 0, 0, 100, interval},
+		{"Idle", max, 10, 0, max},
+		{"Pending", max, 10, 9, interval},
+		{"Threshold", max, 10, 10, timeout},
+		{"OverThreshold", max, 10, 100, timeout},
+		{"NoThreshold", max, 0, 100, interval},
+		{"MaxUnderInterval", time.Second, 10, 0, interval},
+		{"ThresholdOnly", 0, 10, 10, timeout},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegulator(log.New())
+			r.SetBlockInterval(interval, timeout)
+			r.SetIntervalPolicy(tt.max, tt.threshold)
+			r.OnTxPoolUpdated(tt.pending)
+			assert.Equal(t, tt.expected, r.intervalInLock())
+		})
+	}
+}
+
+func TestRegulator_CommitTimeout(t *testing.T) {
+	r := NewRegulator(log.New())
+	r.SetBlockInterval(time.Hour, time.Second)
+	r.SetIntervalPolicy(2*time.Hour, 10)
+
+	r.OnPropose(time.Now())
+	assert.Greater(t, r.CommitTimeout(), time.Hour)
+
+	// proposes early with pending transactions over the threshold
+	r.OnTxPoolUpdated(10)
+	assert.Equal(t, time.Second, r.CommitTimeout())
+
+	// back to the interval after the transactions are removed
+	r.OnTxPoolUpdated(1)
+	timeout := r.CommitTimeout()
+	assert.Greater(t, timeout, time.Second)
+	assert.LessOrEqual(t, timeout, time.Hour)
+}
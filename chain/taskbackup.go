@@ -1,5 +1,5 @@
 /*
- * Copyright 2020 ICON Foundation
+ * Copyright 2022 ICON Foundation
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -33,7 +33,10 @@ import (
 	"github.com/icon-project/goloop/common/errors"
 )
 
-const TemporalBackupFile = ".backup"
+const (
+	TemporalBackupFile = ".backup"
+	BackupManifestFile = ".manifest.json"
+)
 
 type BackupInfo struct {
 	NID     common.HexInt32 `json:"nid"`
@@ -41,6 +44,22 @@ type BackupInfo struct {
 	Channel string          `json:"channel"`
 	Height  int64           `json:"height"`
 	Codec   string          `json:"codec"`
+	Base    string          `json:"base,omitempty"`
+}
+
+// BackupFileInfo is used to detect changed files between backups.
+// Database files (like ones of LevelDB) are not modified once they are
+// written, so size and modification time are enough for it.
+type BackupFileInfo struct {
+	Size    int64 `json:"size"`
+	ModTime int64 `json:"modTime"`
+}
+
+// BackupManifest lists all files of the chain at the time of the backup.
+// Incremental backup includes only files changed after the base backup,
+// so the files not included in the backup should be found in its bases.
+type BackupManifest struct {
+	Files map[string]BackupFileInfo `json:"files"`
 }
 
 var backupStates = map[State]string{
@@ -53,7 +72,9 @@ var backupStates = map[State]string{
 type taskBackup struct {
 	chain   *singleChain
 	file    string
+	base    string
 	extra   []string
+	bm      *BackupManifest
 	fd      io.WriteCloser
 	zw      *zip.Writer
 	current int32
@@ -63,9 +84,20 @@ type taskBackup struct {
 }
 
 func (t *taskBackup) String() string {
+	if t.base != "" {
+		return fmt.Sprintf("Backup(file=%s,base=%s)", path.Base(t.file), path.Base(t.base))
+	}
 	return fmt.Sprintf("Backup(file=%s)", path.Base(t.file))
 }
 
+func (t *taskBackup) Progress() (int64, int64) {
+	total := atomic.LoadInt32(&t.total)
+	if total <= 0 {
+		return 0, 0
+	}
+	return int64(atomic.LoadInt32(&t.current)), int64(total)
+}
+
 func (t *taskBackup) DetailOf(s State) string {
 	switch s {
 	case Started:
@@ -94,6 +126,20 @@ func (t *taskBackup) Start() (ret error) {
 		t.chain.releaseDatabase()
 		return nil
 	}
+	var baseName string
+	if t.base != "" {
+		bm, err := GetBackupManifestOf(t.base)
+		if err != nil {
+			return errors.IllegalArgumentError.Wrapf(err,
+				"InvalidBaseBackup(base=%s)", path.Base(t.base))
+		}
+		if bm == nil {
+			return errors.IllegalArgumentError.Errorf(
+				"NoManifestInBaseBackup(base=%s)", path.Base(t.base))
+		}
+		t.bm = bm
+		baseName = path.Base(t.base)
+	}
 	tmp, err := ioutil.TempFile(path.Dir(t.file), TemporalBackupFile)
 	if err != nil {
 		return errors.Wrap(err, "Fail to make temporal file")
@@ -117,6 +163,7 @@ func (t *taskBackup) Start() (ret error) {
 		Channel: t.chain.Channel(),
 		Height:  t.chain.lastBlockHeight(),
 		Codec:   codec.BC.Name(),
+		Base:    baseName,
 	}); err != nil {
 		return err
 	}
@@ -136,100 +183,60 @@ func (t *taskBackup) Start() (ret error) {
 	return nil
 }
 
-func zipWrite(writer *zip.Writer, p, n string, on func(int64) error) error {
+// listFiles calls on for each regular file under the path n relative to p
+// in consistent order.
+func listFiles(p, n string, on func(n string, st os.FileInfo) error) error {
 	p2 := path.Join(p, n)
 	st, err := os.Stat(p2)
 	if errors.Is(err, fs.ErrNotExist) {
 		return nil
 	} else if err != nil {
-		return errors.Wrap(err, "writeToZip: FAIL on os.State")
+		return errors.Wrap(err, "listFiles: FAIL on os.State")
 	}
 	if st.Mode().IsRegular() {
-		fd, err := os.Open(p2)
-		defer fd.Close()
-		if err != nil {
-			return errors.Wrapf(err, "writeToZip: fail to open %s", p2)
-		}
-
-		fh, err := zip.FileInfoHeader(st)
-		if err != nil {
-			return errors.Wrapf(err, "writeToZip: fail to make header for %s", p2)
-		}
-		fh.Name = n
-		fh.Method = zip.Deflate
-		zf, err := writer.CreateHeader(fh)
-		if err != nil {
-			return err
-		}
-		if err != nil {
-			return errors.Wrapf(err, "writeToZip: fail to create entry %s", n)
-		}
-		if _, err := io.Copy(zf, fd); err != nil {
-			return errors.Wrap(err, "writeToZip: fail to copy")
-		}
-		if err := on(st.Size()); err != nil {
-			return err
-		}
-		return nil
+		return on(n, st)
 	} else if !st.IsDir() {
 		return nil
 	}
 
 	fis, err := ioutil.ReadDir(p2)
 	if err != nil {
-		return errors.Wrap(err, "writeToZip: FAIL on ReadDir")
+		return errors.Wrap(err, "listFiles: FAIL on ReadDir")
 	}
 	// make it generate consistent compressed zip file.
 	sort.SliceStable(fis, func(i, j int) bool {
 		return fis[i].Name() < fis[j].Name()
 	})
 	for _, fi := range fis {
-		if err := zipWrite(writer, p, path.Join(n, fi.Name()), on); err != nil {
+		if err := listFiles(p, path.Join(n, fi.Name()), on); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func countFiles(p string) (int, error) {
-	st, err := os.Stat(p)
-	if errors.Is(err, fs.ErrNotExist) {
-		return 0, nil
-	} else if err != nil {
-		return 0, err
-	}
-	if !st.IsDir() {
-		return 1, nil
+func zipWriteFile(writer *zip.Writer, p, n string, st os.FileInfo) error {
+	p2 := path.Join(p, n)
+	fd, err := os.Open(p2)
+	if err != nil {
+		return errors.Wrapf(err, "writeToZip: fail to open %s", p2)
 	}
-	fis, err := ioutil.ReadDir(p)
+	defer fd.Close()
+
+	fh, err := zip.FileInfoHeader(st)
 	if err != nil {
-		return 0, err
+		return errors.Wrapf(err, "writeToZip: fail to make header for %s", p2)
 	}
-	cnt := 0
-	for _, fi := range fis {
-		if fi.IsDir() {
-			if c, err := countFiles(path.Join(p, fi.Name())); err != nil {
-				return 0, err
-			} else {
-				cnt += c
-			}
-		} else if fi.Mode().IsRegular() {
-			cnt += 1
-		}
+	fh.Name = n
+	fh.Method = zip.Deflate
+	zf, err := writer.CreateHeader(fh)
+	if err != nil {
+		return errors.Wrapf(err, "writeToZip: fail to create entry %s", n)
 	}
-	return cnt, nil
-}
-
-func (t *taskBackup) _countFiles(chainDir string, names []string) (int, error) {
-	count := 0
-	for _, name := range names {
-		if cnt, err := countFiles(path.Join(chainDir, name)); err != nil {
-			return 0, err
-		} else {
-			count += cnt
-		}
+	if _, err := io.Copy(zf, fd); err != nil {
+		return errors.Wrap(err, "writeToZip: fail to copy")
 	}
-	return count, nil
+	return nil
 }
 
 func (t *taskBackup) _isInterrupted() bool {
@@ -254,19 +261,41 @@ func (t *taskBackup) _backup() error {
 	}, t.extra...)
 
 	chainDir := t.chain.cfg.AbsBaseDir()
-	if cnt, err := t._countFiles(chainDir, names); err != nil {
-		return err
-	} else {
-		t.total = int32(cnt)
+	manifest := &BackupManifest{
+		Files: make(map[string]BackupFileInfo),
 	}
-
+	var files []string
+	var stats []os.FileInfo
 	for _, name := range names {
-		if err := zipWrite(t.zw, chainDir, name, t.OnWrite); err != nil {
+		if err := listFiles(chainDir, name, func(n string, st os.FileInfo) error {
+			fi := BackupFileInfo{
+				Size:    st.Size(),
+				ModTime: st.ModTime().UnixNano(),
+			}
+			manifest.Files[n] = fi
+			if t.bm != nil {
+				if bfi, ok := t.bm.Files[n]; ok && bfi == fi {
+					return nil
+				}
+			}
+			files = append(files, n)
+			stats = append(stats, st)
+			return nil
+		}); err != nil {
 			return err
 		}
 	}
+	atomic.StoreInt32(&t.total, int32(len(files)))
 
-	return nil
+	for idx, name := range files {
+		if err := zipWriteFile(t.zw, chainDir, name, stats[idx]); err != nil {
+			return err
+		}
+		if err := t.OnWrite(stats[idx].Size()); err != nil {
+			return err
+		}
+	}
+	return writeBackupManifest(t.zw, manifest)
 }
 
 func (t *taskBackup) Stop() {
@@ -283,10 +312,11 @@ func (t *taskBackup) Wait() error {
 	return t.result.Wait()
 }
 
-func newTaskBackup(chain *singleChain, file string, extra []string) chainTask {
+func newTaskBackup(chain *singleChain, file string, base string, extra []string) chainTask {
 	return &taskBackup{
 		chain: chain,
 		file:  file,
+		base:  base,
 		extra: extra,
 	}
 }
@@ -324,3 +354,46 @@ func ReadBackupInfo(zr *zip.Reader) (*BackupInfo, error) {
 	}
 	return info, nil
 }
+
+func writeBackupManifest(zw *zip.Writer, m *BackupManifest) error {
+	bs, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	w, err := zw.Create(BackupManifestFile)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bs)
+	return err
+}
+
+// ReadBackupManifest returns manifest of the backup. It returns nil
+// without error if the backup doesn't have a manifest (made by old version).
+func ReadBackupManifest(zr *zip.Reader) (*BackupManifest, error) {
+	for _, f := range zr.File {
+		if f.Name != BackupManifestFile {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		m := new(BackupManifest)
+		if err := json.NewDecoder(rc).Decode(m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	return nil, nil
+}
+
+func GetBackupManifestOf(f string) (*BackupManifest, error) {
+	zr, err := zip.OpenReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return ReadBackupManifest(&zr.Reader)
+}
@@ -22,6 +22,7 @@ import (
 
 type taskConsensus struct {
 	chain  *singleChain
+	probe  *consensusProbe
 	result resultStore
 }
 
@@ -54,6 +55,7 @@ func (t *taskConsensus) Start() error {
 		t.result.SetValue(err)
 		return err
 	}
+	t.probe = startConsensusProbe(t.chain)
 	return nil
 }
 
@@ -70,6 +72,9 @@ func (t *taskConsensus) _start(c *singleChain) error {
 }
 
 func (t *taskConsensus) Stop() {
+	if t.probe != nil {
+		t.probe.Stop()
+	}
 	t.chain.srv.RemoveChain(t.chain.cfg.Channel)
 	t.chain.releaseManagers()
 	t.result.SetValue(errors.ErrInterrupted)
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	DefaultTaskHistoryFile = "tasks.json"
+	ConfigTaskHistorySize  = 20
+)
+
+const (
+	TaskStateRunning     = "running"
+	TaskStateDone        = "done"
+	TaskStateFailed      = "failed"
+	TaskStateCanceled    = "canceled"
+	TaskStateInterrupted = "interrupted"
+)
+
+// taskProgress is implemented by the task reporting its progress as
+// the number of processed items and the total number of them.
+type taskProgress interface {
+	Progress() (current int64, total int64)
+}
+
+type taskHistoryData struct {
+	LastID  int64                     `json:"lastID"`
+	Records []*module.ChainTaskStatus `json:"records"`
+}
+
+// taskHistory keeps status of maintenance tasks of the chain in the file,
+// so they are kept across restarts of the node.
+type taskHistory struct {
+	mtx     sync.Mutex
+	file    string
+	logger  log.Logger
+	data    taskHistoryData
+	running *module.ChainTaskStatus
+	task    chainTask
+}
+
+// newTaskHistory loads the history from the file. Tasks which were running
+// on the shutdown of the node are marked as interrupted.
+func newTaskHistory(file string, logger log.Logger) (*taskHistory, error) {
+	h := &taskHistory{
+		file:   file,
+		logger: logger,
+	}
+	bs, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &h.data); err != nil {
+		return nil, errors.CriticalFormatError.Wrapf(err, "InvalidTaskHistory(file=%s)", file)
+	}
+	changed := false
+	for _, r := range h.data.Records {
+		if r.State == TaskStateRunning {
+			r.State = TaskStateInterrupted
+			changed = true
+		}
+	}
+	if changed {
+		h.save()
+	}
+	return h, nil
+}
+
+func (h *taskHistory) save() {
+	bs, err := json.MarshalIndent(&h.data, "", "  ")
+	if err == nil {
+		err = ioutil.WriteFile(h.file, bs, 0644)
+	}
+	if err != nil {
+		h.logger.Warnf("Fail to save task history file=%s err=%+v", h.file, err)
+	}
+}
+
+func (h *taskHistory) onStart(task chainTask) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h.data.LastID += 1
+	r := &module.ChainTaskStatus{
+		ID:        h.data.LastID,
+		Name:      task.String(),
+		State:     TaskStateRunning,
+		StartTime: time.Now(),
+	}
+	h.data.Records = append(h.data.Records, r)
+	if len(h.data.Records) > ConfigTaskHistorySize {
+		h.data.Records = h.data.Records[len(h.data.Records)-ConfigTaskHistorySize:]
+	}
+	h.running = r
+	h.task = task
+	h.save()
+}
+
+func (h *taskHistory) onEnd(task chainTask, err error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if h.task != task {
+		return
+	}
+	r := h.running
+	if err == nil {
+		r.State = TaskStateDone
+		r.Detail = task.DetailOf(Finished)
+	} else if errors.InterruptedError.Equals(err) {
+		r.State = TaskStateCanceled
+		r.Detail = task.DetailOf(Stopped)
+	} else {
+		r.State = TaskStateFailed
+		r.Detail = task.DetailOf(Failed)
+		r.Error = err.Error()
+	}
+	if p, ok := task.(taskProgress); ok {
+		r.Current, r.Total = p.Progress()
+	}
+	now := time.Now()
+	r.EndTime = &now
+	h.running = nil
+	h.task = nil
+	h.save()
+}
+
+// list returns copies of the records, the latest first. The running one
+// reports the detail for the state of the chain.
+func (h *taskHistory) list(s State) []*module.ChainTaskStatus {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	records := make([]*module.ChainTaskStatus, len(h.data.Records))
+	for i, r := range h.data.Records {
+		rc := *r
+		if r == h.running {
+			rc.Detail = h.task.DetailOf(s)
+			if p, ok := h.task.(taskProgress); ok {
+				rc.Current, rc.Total = p.Progress()
+			}
+		}
+		records[len(records)-1-i] = &rc
+	}
+	return records
+}
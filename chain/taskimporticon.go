@@ -244,6 +244,10 @@ func (t *taskImportICON) Wait() error {
 	result := t.result.Wait()
 	if t.sm.Finished() {
 		result = nil
+	} else if errors.InterruptedError.Equals(result) {
+		// the accumulator is rolled back to the last checkpoint on the
+		// next import, so it resumes from the last imported block.
+		t.chain.logger.Infof("%s canceled at %s", ImportICONName, t.sm.GetStatus())
 	}
 	t.chain.releaseManagers()
 	t._releaseDatabase()
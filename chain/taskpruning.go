@@ -18,8 +18,10 @@ package chain
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
 	"sync/atomic"
 
 	"github.com/icon-project/goloop/chain/gs"
@@ -40,22 +42,29 @@ type taskPruning struct {
 	gsfile  string
 	dbtype  string
 	height  int64
+	headers bool
+	base    int64
 	blocks  int64
 	current int64
+	offset  int64
 
 	resolved   uint64
 	unresolved uint64
+
+	dbSize int64
 }
 
 func (t *taskPruning) String() string {
-	return fmt.Sprintf("Pruning(height=%d)", t.height)
+	return fmt.Sprintf("Pruning(height=%d,headers=%v)", t.height, t.headers)
 }
 
 func (t *taskPruning) DetailOf(s State) string {
 	switch s {
 	case Started:
 		i, a, r, u := t._progress()
-		return fmt.Sprintf("pruning %d/%d resolved=%d unresolved=%d", i, a, r, u)
+		size := t._tmpDBSize()
+		return fmt.Sprintf("pruning %d/%d resolved=%d unresolved=%d size=%s/%s",
+			i, a, r, u, formatSize(size), formatSize(t.dbSize))
 	default:
 		if st, ok := pruningStates[s]; ok {
 			return st
@@ -74,12 +83,18 @@ func (t *taskPruning) Start() error {
 		t.chain.releaseManagers()
 		return err
 	}
-	if t.height >= blk.Height() {
+	t.base = t.chain.cfg.GenesisStorage.Height()
+	if t.height >= blk.Height() || t.height <= t.base {
 		t.chain.releaseManagers()
 		return errors.IllegalArgumentError.Errorf(
-			"InvalidHeight(height=%d,last=%d)", t.height, blk.Height())
+			"InvalidHeight(height=%d,genesis=%d,last=%d)", t.height, t.base, blk.Height())
 	}
+	chainDir := t.chain.cfg.ResolveAbsolute(t.chain.cfg.BaseDir)
+	t.dbSize = sizeOfDir(path.Join(chainDir, DefaultDBDir))
 	t.blocks = blk.Height() - t.height + 1
+	if t.headers {
+		t.blocks += t.height - t.base
+	}
 	t.current = 0
 	go t.doPruning()
 	return nil
@@ -94,7 +109,7 @@ func (t *taskPruning) OnExport(height int64, r, u int) error {
 	if atomic.LoadInt64(&t.blocks) == 0 {
 		return errors.ErrInterrupted
 	}
-	atomic.StoreInt64(&t.current, height-t.height+1)
+	atomic.StoreInt64(&t.current, atomic.LoadInt64(&t.offset)+height-t.height+1)
 	atomic.StoreUint64(&t.resolved, uint64(r))
 	atomic.StoreUint64(&t.unresolved, uint64(u))
 	return nil
@@ -109,6 +124,11 @@ func (t *taskPruning) _progress() (int64, int64, uint64, uint64) {
 	return current, blocks, atomic.LoadUint64(&t.resolved), atomic.LoadUint64(&t.unresolved)
 }
 
+func (t *taskPruning) Progress() (int64, int64) {
+	current, blocks, _, _ := t._progress()
+	return current, blocks
+}
+
 func (t *taskPruning) _exportGenesis(blk module.Block, votes module.CommitVoteSet, gsfile string) (rerr error) {
 	os.RemoveAll(gsfile)
 	fd, err := os.OpenFile(gsfile, os.O_CREATE|os.O_WRONLY|os.O_EXCL|os.O_TRUNC, 0700)
@@ -141,9 +161,54 @@ func (t *taskPruning) _copyDatabase(dbpath, dbtype string, from, to int64) (rerr
 			os.RemoveAll(dbpath)
 		}
 	}()
+	if t.headers {
+		if err := t.chain.bm.ExportHeaders(t.base, from-1, dbase, t.OnExportHeader); err != nil {
+			return err
+		}
+		atomic.StoreInt64(&t.offset, from-t.base)
+	}
 	return t.chain.bm.ExportBlocks(from, to, dbase, t.OnExport)
 }
 
+func (t *taskPruning) OnExportHeader(height int64, r, u int) error {
+	if atomic.LoadInt64(&t.blocks) == 0 {
+		return errors.ErrInterrupted
+	}
+	atomic.StoreInt64(&t.current, height-t.base+1)
+	return nil
+}
+
+func (t *taskPruning) _tmpDBSize() int64 {
+	chainDir := t.chain.cfg.ResolveAbsolute(t.chain.cfg.BaseDir)
+	return sizeOfDir(path.Join(chainDir, DefaultTmpDBDir))
+}
+
+// sizeOfDir returns total size of regular files under the directory.
+// It ignores errors, so it returns partial sum on failure.
+func sizeOfDir(dir string) int64 {
+	var size int64
+	_ = filepath.Walk(dir, func(p string, info fs.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+func formatSize(sz int64) string {
+	const unit = 1024
+	if sz < unit {
+		return fmt.Sprintf("%dB", sz)
+	}
+	div, exp := int64(unit), 0
+	for n := sz / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(sz)/float64(div), "KMGTPE"[exp])
+}
+
 func (t *taskPruning) _interrupted() bool {
 	return atomic.LoadInt64(&t.blocks) == 0
 }
@@ -209,6 +274,13 @@ func (t *taskPruning) _prune(gsfile, dbtype string, height int64) (rerr error) {
 		return errors.ErrInterrupted
 	}
 
+	if t.dbSize > 0 {
+		size := sizeOfDir(dbpath)
+		c.logger.Infof("Pruned database size=%s original=%s saving=%s(%.1f%%)",
+			formatSize(size), formatSize(t.dbSize), formatSize(t.dbSize-size),
+			float64(t.dbSize-size)*100/float64(t.dbSize))
+	}
+
 	c.releaseManagers()
 	c.releaseDatabase()
 	defer c.ensureDatabase()
@@ -285,11 +357,12 @@ func (t *taskPruning) Wait() error {
 	return t.result.Wait()
 }
 
-func newTaskPruning(chain *singleChain, gsfile, dbtype string, height int64) chainTask {
+func newTaskPruning(chain *singleChain, gsfile, dbtype string, height int64, headers bool) chainTask {
 	return &taskPruning{
-		chain:  chain,
-		gsfile: gsfile,
-		dbtype: dbtype,
-		height: height,
+		chain:   chain,
+		gsfile:  gsfile,
+		dbtype:  dbtype,
+		height:  height,
+		headers: headers,
 	}
 }
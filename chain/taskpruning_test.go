@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+func TestSizeOfDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.EqualValues(t, 0, sizeOfDir(dir))
+	assert.EqualValues(t, 0, sizeOfDir(filepath.Join(dir, "none")))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a"), make([]byte, 10), 0644))
+	sub := filepath.Join(dir, "sub")
+	assert.NoError(t, os.Mkdir(sub, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(sub, "b"), make([]byte, 5), 0644))
+	assert.EqualValues(t, 15, sizeOfDir(dir))
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{1024, "1.0KiB"},
+		{1536, "1.5KiB"},
+		{1024 * 1024, "1.0MiB"},
+		{5 * 1024 * 1024 * 1024, "5.0GiB"},
+		{3 * 1024 * 1024 * 1024 * 1024, "3.0TiB"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatSize(tt.size))
+	}
+}
+
+func TestTaskPruning_Progress(t *testing.T) {
+	task := &taskPruning{
+		height:  10,
+		headers: true,
+		base:    0,
+		blocks:  10 + 11,
+	}
+
+	// headers of blocks before the height are exported first
+	assert.NoError(t, task.OnExportHeader(0, 0, 0))
+	i, a, _, _ := task._progress()
+	assert.EqualValues(t, 1, i)
+	assert.EqualValues(t, 21, a)
+	assert.NoError(t, task.OnExportHeader(9, 0, 0))
+	i, _, _, _ = task._progress()
+	assert.EqualValues(t, 10, i)
+
+	// then blocks from the height are counted after them
+	task.offset = task.height - task.base
+	assert.NoError(t, task.OnExport(10, 3, 2))
+	i, a, r, u := task._progress()
+	assert.EqualValues(t, 11, i)
+	assert.EqualValues(t, 21, a)
+	assert.EqualValues(t, 3, r)
+	assert.EqualValues(t, 2, u)
+	assert.NoError(t, task.OnExport(20, 0, 0))
+	i, _, _, _ = task._progress()
+	assert.EqualValues(t, 21, i)
+
+	task.blocks = 0
+	assert.True(t, errors.Is(task.OnExportHeader(1, 0, 0), errors.ErrInterrupted))
+	assert.True(t, errors.Is(task.OnExport(11, 0, 0), errors.ErrInterrupted))
+}
@@ -0,0 +1,309 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service"
+)
+
+const (
+	ReadReplicaName = "ReadReplica"
+
+	// DefaultReplicaInterval is the default interval in milliseconds to check
+	// new blocks in the database followed by the read replica.
+	DefaultReplicaInterval = 1000
+
+	// replicaReleaseDelay is the delay to release the managers and the
+	// database replaced by new ones, so queries using them can finish.
+	replicaReleaseDelay = 10 * time.Second
+
+	// replicaMaxFailures is the number of failures in a row to give up
+	// following the database.
+	replicaMaxFailures = 30
+)
+
+var readReplicaStates = map[State]string{
+	Starting: "read_replica starting",
+	Stopping: "read_replica stopping",
+	Failed:   "read_replica failed",
+}
+
+// dbWatermark summarizes files of the database. It changes on writes of
+// the database or the replication of it.
+type dbWatermark struct {
+	files   int
+	size    int64
+	modTime time.Time
+}
+
+func watermarkOf(dir string) (dbWatermark, error) {
+	var wm dbWatermark
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return wm, err
+	}
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil || fi.IsDir() {
+			continue
+		}
+		wm.files += 1
+		wm.size += fi.Size()
+		if fi.ModTime().After(wm.modTime) {
+			wm.modTime = fi.ModTime()
+		}
+	}
+	return wm, nil
+}
+
+// taskReadReplica serves queries with the database of other node opened in
+// read-only mode without network and consensus. On changes of the database
+// files, it opens the database again and replaces the managers if there are
+// new blocks.
+//
+// The database backend locks the database even in read-only mode, so the
+// followed directory must be a copy replicated from other node, like file
+// system snapshots, not the database used by a running node. It fails on the
+// database locked by others.
+type taskReadReplica struct {
+	chain  *singleChain
+	result resultStore
+
+	mtx      sync.Mutex
+	dir      string
+	mark     dbWatermark
+	failures int
+	stop     chan struct{}
+
+	newManagers  func() (module.ServiceManager, module.BlockManager, error)
+	releaseDelay time.Duration
+}
+
+func (t *taskReadReplica) String() string {
+	return ReadReplicaName
+}
+
+func (t *taskReadReplica) DetailOf(s State) string {
+	if s == Started {
+		return "read_replica running"
+	}
+	if st, ok := readReplicaStates[s]; ok {
+		return st
+	}
+	return "read_replica " + s.String()
+}
+
+// _newManagers returns the managers for the database of the chain. They
+// work without the network manager, because the read replica doesn't
+// communicate with other nodes.
+func (t *taskReadReplica) _newManagers() (module.ServiceManager, module.BlockManager, error) {
+	c := t.chain
+	ContractDir := path.Join(c.cfg.AbsBaseDir(), DefaultContractDir)
+	sm, err := service.NewManager(c, nil, c.pm, c.plt, ContractDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	rsm := &replicaServiceManager{sm}
+	c.sm = rsm
+	bm, err := block.NewManager(c, nil, c.plt.NewBlockHandlers(c))
+	if err != nil {
+		sm.Term()
+		return nil, nil, err
+	}
+	return rsm, bm, nil
+}
+
+func (t *taskReadReplica) Start() error {
+	c := t.chain
+	t.dir = filepath.Join(c.cfg.ResolveAbsolute(c.cfg.ReplicaDBDir),
+		strconv.FormatInt(int64(c.cfg.NID), 16))
+	if mark, err := watermarkOf(t.dir); err != nil {
+		t.result.SetValue(err)
+		return err
+	} else {
+		t.mark = mark
+	}
+	c.ensureDatabase()
+	sm, bm, err := t.newManagers()
+	if err != nil {
+		t.result.SetValue(err)
+		return err
+	}
+	c.sm, c.bm = sm, bm
+	c.srv.SetChain(c.cfg.Channel, c)
+	go t._follow()
+	return nil
+}
+
+func (t *taskReadReplica) _follow() {
+	interval := t.chain.cfg.ReplicaInterval
+	if interval <= 0 {
+		interval = DefaultReplicaInterval
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			if err := t._refresh(); err != nil {
+				t.chain.logger.Errorf("fail to follow replica database err=%+v", err)
+				t._terminate(err)
+				return
+			}
+		}
+	}
+}
+
+// _onFailure counts the failure to follow the database. The replication of
+// the database may be in progress, so it returns the error only if it fails
+// replicaMaxFailures times in a row.
+func (t *taskReadReplica) _onFailure(err error) error {
+	t.failures += 1
+	if t.failures >= replicaMaxFailures {
+		return err
+	}
+	t.chain.logger.Warnf("fail to follow replica database failures=%d err=%+v",
+		t.failures, err)
+	return nil
+}
+
+// _refresh replaces the database and the managers with new ones if there
+// are new blocks in the database followed. It returns an error if it can't
+// follow the database any more.
+func (t *taskReadReplica) _refresh() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	select {
+	case <-t.stop:
+		return nil
+	default:
+	}
+	c := t.chain
+	mark, err := watermarkOf(t.dir)
+	if err != nil {
+		return t._onFailure(err)
+	}
+	if mark == t.mark {
+		return nil
+	}
+	dbase, err := c.newDatabase(c.cfg.AbsBaseDir())
+	if err != nil {
+		if errors.Is(err, db.ErrDatabaseLocked) {
+			return err
+		}
+		return t._onFailure(err)
+	}
+	height := block.GetLastHeightOf(dbase)
+	if height <= block.GetLastHeightOf(c.Database()) {
+		_ = dbase.Close()
+		t.mark, t.failures = mark, 0
+		return nil
+	}
+
+	c.dbLock.Lock()
+	oldDB, oldSM, oldBM := c.database, c.sm, c.bm
+	c.database = dbase
+	sm, bm, err := t.newManagers()
+	if err != nil {
+		c.database, c.sm = oldDB, oldSM
+		c.dbLock.Unlock()
+		_ = dbase.Close()
+		return t._onFailure(err)
+	}
+	c.sm, c.bm = sm, bm
+	c.dbLock.Unlock()
+	t.mark, t.failures = mark, 0
+	c.logger.Infof("read replica follows height=%d", height)
+
+	time.AfterFunc(t.releaseDelay, func() {
+		oldBM.Term()
+		oldSM.Term()
+		_ = oldDB.Close()
+	})
+	return nil
+}
+
+// _terminate releases the managers and finishes the task with the result
+// unless it's already finished.
+func (t *taskReadReplica) _terminate(result error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	select {
+	case <-t.stop:
+		return
+	default:
+	}
+	close(t.stop)
+	t.chain.srv.RemoveChain(t.chain.cfg.Channel)
+	t.chain.releaseManagers()
+	t.result.SetValue(result)
+}
+
+func (t *taskReadReplica) Stop() {
+	t._terminate(errors.ErrInterrupted)
+}
+
+func (t *taskReadReplica) Wait() error {
+	return t.result.Wait()
+}
+
+func newTaskReadReplica(chain *singleChain) chainTask {
+	t := &taskReadReplica{
+		chain:        chain,
+		stop:         make(chan struct{}),
+		releaseDelay: replicaReleaseDelay,
+	}
+	t.newManagers = t._newManagers
+	return t
+}
+
+// replicaServiceManager rejects transactions, which can't be delivered to
+// other nodes by the read replica.
+type replicaServiceManager struct {
+	module.ServiceManager
+}
+
+func (m *replicaServiceManager) SendTransaction(result []byte, height int64, tx interface{}) ([]byte, error) {
+	return nil, errors.UnsupportedError.New("ReadReplica")
+}
+
+func (m *replicaServiceManager) SendTransactionBundle(result []byte, height int64, txs []interface{}, sig []byte) ([][]byte, error) {
+	return nil, errors.UnsupportedError.New("ReadReplica")
+}
+
+func (m *replicaServiceManager) SendTransactionAndWait(result []byte, height int64, tx interface{}) ([]byte, <-chan interface{}, error) {
+	return nil, nil, errors.UnsupportedError.New("ReadReplica")
+}
+
+func (m *replicaServiceManager) SendPatch(patch module.Patch) error {
+	return errors.UnsupportedError.New("ReadReplica")
+}
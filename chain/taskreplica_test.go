@@ -0,0 +1,224 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestWatermarkOf(t *testing.T) {
+	dir := t.TempDir()
+	_, err := watermarkOf(filepath.Join(dir, "none"))
+	assert.Error(t, err)
+
+	wm0, err := watermarkOf(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, dbWatermark{}, wm0)
+
+	file := filepath.Join(dir, "000001.log")
+	assert.NoError(t, os.WriteFile(file, []byte("a"), 0644))
+	wm1, err := watermarkOf(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, wm1.files)
+	assert.EqualValues(t, 1, wm1.size)
+	assert.NotEqual(t, wm0, wm1)
+
+	// directories are ignored
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	wm2, err := watermarkOf(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, wm1, wm2)
+
+	assert.NoError(t, os.WriteFile(file, []byte("ab"), 0644))
+	wm3, err := watermarkOf(dir)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, wm3.size)
+	assert.NotEqual(t, wm1, wm3)
+}
+
+type testReplicaServiceManager struct {
+	module.ServiceManager
+	terminated chan struct{}
+}
+
+func (m *testReplicaServiceManager) Term() {
+	close(m.terminated)
+}
+
+type testReplicaBlockManager struct {
+	module.BlockManager
+	terminated chan struct{}
+}
+
+func (m *testReplicaBlockManager) Term() {
+	close(m.terminated)
+}
+
+// replicateTestDB writes the height to the database in the origin and
+// replaces files in the replica with the ones of the origin.
+func replicateTestDB(t *testing.T, origin, replica string, height int64) {
+	name := "1"
+	dbase, err := db.Open(origin, string(db.GoLevelDBBackend), name)
+	assert.NoError(t, err)
+	assert.NoError(t, block.SetLastHeight(dbase, nil, height))
+	assert.NoError(t, dbase.Close())
+
+	dst := filepath.Join(replica, name)
+	assert.NoError(t, os.RemoveAll(dst))
+	assert.NoError(t, os.MkdirAll(dst, 0755))
+	entries, err := os.ReadDir(filepath.Join(origin, name))
+	assert.NoError(t, err)
+	for _, e := range entries {
+		bs, err := os.ReadFile(filepath.Join(origin, name, e.Name()))
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(dst, e.Name()), bs, 0644))
+	}
+}
+
+type testReadReplica struct {
+	*taskReadReplica
+	origin  string
+	replica string
+	sms     []*testReplicaServiceManager
+	bms     []*testReplicaBlockManager
+	failure error
+}
+
+func (r *testReadReplica) newManagers() (module.ServiceManager, module.BlockManager, error) {
+	if r.failure != nil {
+		return nil, nil, r.failure
+	}
+	sm := &testReplicaServiceManager{terminated: make(chan struct{})}
+	bm := &testReplicaBlockManager{terminated: make(chan struct{})}
+	r.sms = append(r.sms, sm)
+	r.bms = append(r.bms, bm)
+	return sm, bm, nil
+}
+
+func newTestReadReplica(t *testing.T) *testReadReplica {
+	base := t.TempDir()
+	r := &testReadReplica{
+		origin:  filepath.Join(base, "origin"),
+		replica: filepath.Join(base, "replica"),
+	}
+	replicateTestDB(t, r.origin, r.replica, 1)
+
+	c := &singleChain{logger: log.New()}
+	c.cfg.NID = 1
+	c.cfg.DBType = string(db.GoLevelDBBackend)
+	c.cfg.BaseDir = filepath.Join(base, "chain")
+	c.cfg.ReplicaDBDir = r.replica
+	dbase, err := c.newDatabase(c.cfg.AbsBaseDir())
+	assert.NoError(t, err)
+	c.database = dbase
+	t.Cleanup(func() {
+		_ = c.Database().Close()
+	})
+
+	r.taskReadReplica = newTaskReadReplica(c).(*taskReadReplica)
+	r.dir = filepath.Join(r.replica, "1")
+	r.mark, err = watermarkOf(r.dir)
+	assert.NoError(t, err)
+	r.releaseDelay = 0
+	r.taskReadReplica.newManagers = r.newManagers
+
+	sm, bm, _ := r.newManagers()
+	c.sm, c.bm = sm, bm
+	return r
+}
+
+func assertTerminated(t *testing.T, ch chan struct{}) {
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		assert.Fail(t, "not terminated")
+	}
+}
+
+func TestTaskReadReplica_Refresh(t *testing.T) {
+	r := newTestReadReplica(t)
+	c := r.chain
+
+	// no changes
+	assert.NoError(t, r._refresh())
+	assert.Len(t, r.sms, 1)
+
+	// new blocks replace the managers
+	replicateTestDB(t, r.origin, r.replica, 2)
+	assert.NoError(t, r._refresh())
+	assert.Len(t, r.sms, 2)
+	assert.Equal(t, r.sms[1], c.sm)
+	assert.Equal(t, r.bms[1], c.bm)
+	assert.EqualValues(t, 2, block.GetLastHeightOf(c.Database()))
+	assertTerminated(t, r.sms[0].terminated)
+	assertTerminated(t, r.bms[0].terminated)
+
+	// changes without new blocks keep the managers
+	replicateTestDB(t, r.origin, r.replica, 2)
+	assert.NoError(t, r._refresh())
+	assert.Len(t, r.sms, 2)
+	assert.Equal(t, r.sms[1], c.sm)
+}
+
+func TestTaskReadReplica_RefreshFailure(t *testing.T) {
+	r := newTestReadReplica(t)
+	c := r.chain
+	dbase := c.Database()
+
+	r.failure = errors.New("test failure")
+	for i := 1; i < replicaMaxFailures; i++ {
+		replicateTestDB(t, r.origin, r.replica, int64(i+1))
+		assert.NoError(t, r._refresh())
+		assert.Equal(t, i, r.failures)
+		assert.Equal(t, r.sms[0], c.sm)
+		assert.Equal(t, dbase, c.Database())
+	}
+	// it tries again without changes
+	assert.Error(t, r._refresh())
+
+	// success resets the failures
+	r.failure = nil
+	r.failures = 1
+	replicateTestDB(t, r.origin, r.replica, replicaMaxFailures+2)
+	assert.NoError(t, r._refresh())
+	assert.Equal(t, 0, r.failures)
+	assert.Equal(t, r.sms[1], c.sm)
+}
+
+func TestTaskReadReplica_RefreshLocked(t *testing.T) {
+	r := newTestReadReplica(t)
+
+	replicateTestDB(t, r.origin, r.replica, 2)
+	dbase, err := db.Open(r.replica, string(db.GoLevelDBBackend), "1")
+	assert.NoError(t, err)
+	defer dbase.Close()
+
+	err = r._refresh()
+	assert.True(t, errors.Is(err, db.ErrDatabaseLocked))
+	assert.Len(t, r.sms, 1)
+}
@@ -132,6 +132,11 @@ func (t *taskReset) _cleanUp() error {
 		return err
 	}
 
+	TxPoolDir := path.Join(chainDir, DefaultTxPoolDir)
+	if err := os.RemoveAll(TxPoolDir); err != nil {
+		return err
+	}
+
 	TmpDir := path.Join(chainDir, DefaultTmpDBDir)
 	if err := os.RemoveAll(TmpDir); err != nil {
 		return err
@@ -402,6 +407,10 @@ func (t *taskReset) _syncBlocksWithDB(height int64, blockHash []byte, votes modu
 	if ret = rb.Delete(cacheDir); ret != nil {
 		return
 	}
+	txPoolDir := path.Join(chainDir, DefaultTxPoolDir)
+	if ret = rb.Delete(txPoolDir); ret != nil {
+		return
+	}
 	return
 }
 
@@ -451,6 +460,10 @@ func (t *taskReset) _syncBlocksWithNetwork(height int64, blockHash []byte) (rblk
 	if ret = rb.Delete(CacheDir); ret != nil {
 		return
 	}
+	TxPoolDir := path.Join(chainDir, DefaultTxPoolDir)
+	if ret = rb.Delete(TxPoolDir); ret != nil {
+		return
+	}
 
 	rblk, rvotes, ret = t._prepareBlocks(height, blockHash)
 	rb.Append(func(revert bool) {
@@ -458,6 +471,7 @@ func (t *taskReset) _syncBlocksWithNetwork(height int64, blockHash []byte) (rblk
 			log.Must(os.RemoveAll(contractDir))
 			log.Must(os.RemoveAll(WALDir))
 			log.Must(os.RemoveAll(CacheDir))
+			log.Must(os.RemoveAll(TxPoolDir))
 		}
 	})
 	return
@@ -0,0 +1,228 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/chain/base"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/consensus"
+	"github.com/icon-project/goloop/module"
+)
+
+var rollbackStates = map[State]string{
+	Starting: "rollback starting",
+	Stopping: "rollback stopping",
+	Failed:   "rollback failed",
+	Finished: "rollback done",
+}
+
+// taskRollback rolls back the chain to the height keeping the database.
+// It prepares the consensus WAL for the height in a staging directory, rolls
+// back the platform data, and removes the index of the blocks after the
+// height from the last one. The WAL is replaced with the staged one after
+// all the blocks are removed. So it can be run again with the same height on
+// interruption to finish rollback.
+type taskRollback struct {
+	chain   *singleChain
+	result  resultStore
+	height  int64
+	last    int64
+	current int64
+	stopped int32
+}
+
+func (t *taskRollback) String() string {
+	return fmt.Sprintf("Rollback(height=%d)", t.height)
+}
+
+func (t *taskRollback) Progress() (int64, int64) {
+	if t.last == 0 {
+		return 0, 0
+	}
+	return t.last - atomic.LoadInt64(&t.current), t.last - t.height
+}
+
+func (t *taskRollback) DetailOf(s State) string {
+	switch s {
+	case Started:
+		current, total := t.Progress()
+		return fmt.Sprintf("rollback %d/%d", current, total)
+	default:
+		if st, ok := rollbackStates[s]; ok {
+			return st
+		} else {
+			return s.String()
+		}
+	}
+}
+
+func (t *taskRollback) Start() error {
+	if err := t.chain.prepareManagers(); err != nil {
+		return err
+	}
+	blk, err := t.chain.bm.GetLastBlock()
+	if err != nil {
+		t.chain.releaseManagers()
+		return err
+	}
+	genesis := t.chain.cfg.GenesisStorage.Height()
+	resume := t.height == blk.Height() && t._hasStagedWAL()
+	if (t.height >= blk.Height() && !resume) || t.height < genesis {
+		t.chain.releaseManagers()
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidHeight(height=%d,genesis=%d,last=%d)", t.height, genesis, blk.Height())
+	}
+	t.last = blk.Height()
+	t.current = blk.Height()
+	go t.doRollback(genesis)
+	return nil
+}
+
+func (t *taskRollback) doRollback(genesis int64) {
+	err := t._rollback(genesis)
+	t.result.SetValue(err)
+}
+
+func (t *taskRollback) _interrupted() bool {
+	return atomic.LoadInt32(&t.stopped) != 0
+}
+
+func (t *taskRollback) _commitWALRecord(genesis int64) ([]byte, error) {
+	c := t.chain
+	// consensus uses votes in the genesis storage for the genesis block
+	if t.height == genesis {
+		return nil, nil
+	}
+	blk, err := c.bm.GetBlockByHeight(t.height)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Version() <= module.BlockVersion1 {
+		return nil, errors.UnsupportedError.Errorf(
+			"UnsupportedBlockVersion(height=%d,version=%d)", t.height, blk.Version())
+	}
+	prev, err := c.bm.GetBlockByHeight(t.height - 1)
+	if err != nil {
+		return nil, err
+	}
+	next, err := c.bm.GetBlockByHeight(t.height + 1)
+	if err != nil {
+		return nil, err
+	}
+	return consensus.WALRecordBytesForBlock(blk, prev, next.Votes(), c.Database())
+}
+
+func (t *taskRollback) _walDir() string {
+	return path.Join(t.chain.cfg.AbsBaseDir(), DefaultWALDir)
+}
+
+func (t *taskRollback) _stagedWALDir() string {
+	return t._walDir() + ".rollback"
+}
+
+func (t *taskRollback) _hasStagedWAL() bool {
+	fi, err := os.Stat(t._stagedWALDir())
+	return err == nil && fi.IsDir()
+}
+
+func (t *taskRollback) _stageWAL(genesis int64) error {
+	rec, err := t._commitWALRecord(genesis)
+	if err != nil {
+		return err
+	}
+	stagedDir := t._stagedWALDir()
+	if err := consensus.ResetWAL(t.height, stagedDir, rec); err != nil {
+		return err
+	}
+	// the directory is left as a mark of rollback for the height.
+	return os.MkdirAll(stagedDir, 0700)
+}
+
+func (t *taskRollback) _replaceWAL() error {
+	walDir := t._walDir()
+	t.chain.logger.Infof("Reset WAL dir=%s height=%d", walDir, t.height)
+	if err := os.RemoveAll(walDir); err != nil {
+		return err
+	}
+	return os.Rename(t._stagedWALDir(), walDir)
+}
+
+func (t *taskRollback) _rollback(genesis int64) error {
+	c := t.chain
+	defer c.releaseManagers()
+
+	// the vote of the next block is not available after its index is
+	// removed, so the WAL is prepared before removing blocks.
+	if t.last > t.height {
+		if err := t._stageWAL(genesis); err != nil {
+			return err
+		}
+	}
+
+	dbase := c.Database()
+	if rh, ok := c.plt.(base.RollbackHandler); ok {
+		if err := rh.OnRollback(dbase, t.height); err != nil {
+			return err
+		}
+	}
+
+	for h := t.last; h > t.height; h-- {
+		if t._interrupted() {
+			return errors.ErrInterrupted
+		}
+		blk, err := c.bm.GetBlockByHeight(h)
+		if err != nil {
+			return err
+		}
+		err = block.RemoveBlockIndex(dbase, h,
+			blk.PatchTransactions(), blk.NormalTransactions())
+		if err != nil {
+			return err
+		}
+		if err := block.SetLastHeight(dbase, nil, h-1); err != nil {
+			return err
+		}
+		atomic.StoreInt64(&t.current, h-1)
+	}
+
+	if err := t._replaceWAL(); err != nil {
+		return err
+	}
+	c.logger.Infof("Rollback done height=%d last=%d", t.height, t.last)
+	return nil
+}
+
+func (t *taskRollback) Stop() {
+	atomic.StoreInt32(&t.stopped, 1)
+}
+
+func (t *taskRollback) Wait() error {
+	return t.result.Wait()
+}
+
+func newTaskRollback(chain *singleChain, height int64) chainTask {
+	return &taskRollback{
+		chain:  chain,
+		height: height,
+	}
+}
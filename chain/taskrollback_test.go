@@ -0,0 +1,55 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+)
+
+func TestTaskRollback_StagedWAL(t *testing.T) {
+	base := t.TempDir()
+	c := &singleChain{
+		cfg:    Config{BaseDir: base},
+		logger: log.New(),
+	}
+	task := newTaskRollback(c, 0).(*taskRollback)
+
+	walDir := filepath.Join(base, DefaultWALDir)
+	assert.NoError(t, os.MkdirAll(walDir, 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(walDir, "old"), []byte("old"), 0600))
+
+	// the WAL is kept until blocks are removed
+	assert.False(t, task._hasStagedWAL())
+	assert.NoError(t, task._stageWAL(0))
+	assert.True(t, task._hasStagedWAL())
+	_, err := os.Stat(filepath.Join(walDir, "old"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, task._replaceWAL())
+	assert.False(t, task._hasStagedWAL())
+	_, err = os.Stat(filepath.Join(walDir, "old"))
+	assert.True(t, os.IsNotExist(err))
+	fi, err := os.Stat(walDir)
+	assert.NoError(t, err)
+	assert.True(t, fi.IsDir())
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/icon/blockv0/lcstore"
+	"github.com/icon-project/goloop/icon/lcimporter"
+)
+
+const (
+	VerifyICONTask = "verify_icon"
+	VerifyICONName = "VerifyICON"
+
+	// VerifyICONDir is the temporal directory for the verification.
+	VerifyICONDir = "verify_icon"
+	// VerifyICONReport is the file name of the verification report in the
+	// chain directory.
+	VerifyICONReport = "verify_icon.json"
+)
+
+var verifyICONStates = map[State]string{
+	Starting: "verify_icon starting",
+	Stopping: "verify_icon stopping",
+	Failed:   "verify_icon failed",
+	Finished: "verify_icon done",
+}
+
+type verifyICONParams struct {
+	StoreURI    string               `json:"store_uri"`
+	MaxRPS      int                  `json:"max_rps"`
+	CacheConfig *lcstore.CacheConfig `json:"cache_config,omitempty"`
+	Height      *int64               `json:"height,omitempty"`
+}
+
+// iconVerifier verifies blocks of ICON 1. It's implemented by
+// lcimporter.Verifier.
+type iconVerifier interface {
+	Run(to int64) (*lcimporter.VerifyReport, error)
+	Height() int64
+	Stop()
+}
+
+// taskVerifyICON executes and verifies blocks of ICON 1 without importing
+// them, then it writes the report to VerifyICONReport.
+type taskVerifyICON struct {
+	chain  *singleChain
+	params *verifyICONParams
+
+	result resultStore
+
+	dbase db.Database
+	v     iconVerifier
+}
+
+func (t *taskVerifyICON) String() string {
+	return VerifyICONName
+}
+
+func (t *taskVerifyICON) DetailOf(s State) string {
+	switch s {
+	case Started:
+		return fmt.Sprintf("%s %d running", VerifyICONTask, t.v.Height())
+	default:
+		if st, ok := verifyICONStates[s]; ok {
+			return st
+		}
+		return VerifyICONTask + " " + s.String()
+	}
+}
+
+func (t *taskVerifyICON) tmpDir() string {
+	return path.Join(t.chain.cfg.AbsBaseDir(), VerifyICONDir)
+}
+
+func (t *taskVerifyICON) Start() error {
+	tmpDir := t.tmpDir()
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	dbase, err := t.chain.openDatabase(tmpDir, t.chain.cfg.DBType)
+	if err != nil {
+		return err
+	}
+	t.dbase = dbase
+
+	config := &lcimporter.Config{
+		StoreURI: t.params.StoreURI,
+		MaxRPS:   t.params.MaxRPS,
+	}
+	if t.params.CacheConfig != nil {
+		config.CacheConfig = *t.params.CacheConfig
+	} else {
+		config.CacheConfig = lcStoreDefaultCacheConfig
+	}
+	config.BaseDir = tmpDir
+	config.Platform = t.chain.plt
+	config.ProxyMgr = t.chain.pm
+
+	if v, err := lcimporter.NewVerifier(t.chain, dbase, config); err != nil {
+		t._release()
+		return err
+	} else {
+		t.v = v
+	}
+	go t._verify()
+	return nil
+}
+
+func (t *taskVerifyICON) _verify() {
+	to := int64(-1)
+	if t.params.Height != nil {
+		to = *t.params.Height
+	}
+	report, err := t.v.Run(to)
+	if werr := t._writeReport(report); werr != nil && err == nil {
+		err = werr
+	}
+	t.result.SetValue(err)
+}
+
+func (t *taskVerifyICON) _writeReport(report *lcimporter.VerifyReport) error {
+	bs, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	file := path.Join(t.chain.cfg.AbsBaseDir(), VerifyICONReport)
+	return ioutil.WriteFile(file, bs, 0600)
+}
+
+func (t *taskVerifyICON) _release() {
+	if t.dbase != nil {
+		t.dbase.Close()
+		t.dbase = nil
+	}
+	os.RemoveAll(t.tmpDir())
+}
+
+func (t *taskVerifyICON) Stop() {
+	t.v.Stop()
+}
+
+func (t *taskVerifyICON) Wait() error {
+	result := t.result.Wait()
+	t._release()
+	return result
+}
+
+func taskVerifyIconFactory(c *singleChain, params json.RawMessage) (chainTask, error) {
+	p := new(verifyICONParams)
+	if err := json.Unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	return &taskVerifyICON{
+		chain:  c,
+		params: p,
+	}, nil
+}
+
+func init() {
+	registerTaskFactory(VerifyICONTask, taskVerifyIconFactory)
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/icon/lcimporter"
+)
+
+type testICONVerifier struct {
+	report *lcimporter.VerifyReport
+	err    error
+	to     int64
+}
+
+func (v *testICONVerifier) Run(to int64) (*lcimporter.VerifyReport, error) {
+	v.to = to
+	if v.err != nil {
+		v.report.Error = v.err.Error()
+	}
+	return v.report, v.err
+}
+
+func (v *testICONVerifier) Height() int64 {
+	return v.report.Height
+}
+
+func (v *testICONVerifier) Stop() {}
+
+func newTestTaskVerifyICON(t *testing.T, v iconVerifier, height *int64) *taskVerifyICON {
+	c := &singleChain{
+		cfg:    Config{BaseDir: t.TempDir()},
+		logger: log.New(),
+	}
+	return &taskVerifyICON{
+		chain:  c,
+		params: &verifyICONParams{Height: height},
+		v:      v,
+	}
+}
+
+func readVerifyICONReport(t *testing.T, task *taskVerifyICON) *lcimporter.VerifyReport {
+	bs, err := os.ReadFile(filepath.Join(task.chain.cfg.AbsBaseDir(), VerifyICONReport))
+	assert.NoError(t, err)
+	report := new(lcimporter.VerifyReport)
+	assert.NoError(t, json.Unmarshal(bs, report))
+	return report
+}
+
+func TestTaskVerifyICON_Match(t *testing.T) {
+	height := int64(10)
+	v := &testICONVerifier{
+		report: &lcimporter.VerifyReport{Height: 10, Blocks: 11, Transactions: 20},
+	}
+	task := newTestTaskVerifyICON(t, v, &height)
+	go task._verify()
+
+	assert.NoError(t, task.Wait())
+	assert.EqualValues(t, 10, v.to)
+	report := readVerifyICONReport(t, task)
+	assert.EqualValues(t, 10, report.Height)
+	assert.EqualValues(t, 20, report.Transactions)
+	assert.Empty(t, report.Error)
+}
+
+func TestTaskVerifyICON_Mismatch(t *testing.T) {
+	v := &testICONVerifier{
+		report: &lcimporter.VerifyReport{Height: 4, Blocks: 5},
+		err:    errors.New("ReceiptComparisonFailure(idx=0)"),
+	}
+	task := newTestTaskVerifyICON(t, v, nil)
+	go task._verify()
+
+	// the task stops with the error, and the report has it
+	assert.Equal(t, v.err, task.Wait())
+	assert.EqualValues(t, -1, v.to)
+	report := readVerifyICONReport(t, task)
+	assert.EqualValues(t, 4, report.Height)
+	assert.Equal(t, v.err.Error(), report.Error)
+	assert.Equal(t, VerifyICONTask+" 4 running", task.DetailOf(Started))
+	assert.Equal(t, "verify_icon failed", task.DetailOf(Failed))
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"time"
+
+	"github.com/icon-project/goloop/common/watchdog"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	ConfigConsensusProbeInterval   = 5 * time.Second
+	ConfigConsensusWatchdogTimeout = time.Minute
+	ConfigWatchdogStopPoll         = 100 * time.Millisecond
+	ConfigWatchdogStopTimeout      = time.Minute
+)
+
+// consensusProbe checks the consensus periodically by getting the status,
+// which needs the lock of the consensus. The consensus is stalled if it's
+// not returned in the timeout.
+type consensusProbe struct {
+	cs       module.Consensus
+	hb       *watchdog.Heartbeat
+	interval time.Duration
+	stop     chan struct{}
+}
+
+func (p *consensusProbe) probeRoutine() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.hb.Beat()
+			p.cs.GetStatus()
+			p.hb.Idle()
+		}
+	}
+}
+
+func (p *consensusProbe) Stop() {
+	p.hb.Close()
+	close(p.stop)
+}
+
+func newConsensusProbe(
+	cs module.Consensus, w *watchdog.Watchdog, name string,
+	interval, timeout time.Duration, handler func(),
+) *consensusProbe {
+	p := &consensusProbe{
+		cs:       cs,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+	p.hb = w.Register(name, timeout, handler)
+	go p.probeRoutine()
+	return p
+}
+
+func startConsensusProbe(c *singleChain) *consensusProbe {
+	return newConsensusProbe(c.cs, watchdog.Default(), "Consensus "+c.cfg.Channel,
+		ConfigConsensusProbeInterval, ConfigConsensusWatchdogTimeout,
+		c.onConsensusStalled)
+}
+
+// onConsensusStalled restarts the chain if it's configured. Stopping may not
+// be finished if the consensus keeps the lock, then the chain is left to be
+// restarted by the operator.
+func (c *singleChain) onConsensusStalled() {
+	if !c.cfg.WatchdogRestart {
+		return
+	}
+	c.logger.Warnf("RESTART by watchdog")
+	if err := c.Stop(); err != nil {
+		c.logger.Warnf("Fail to stop by watchdog err=%+v", err)
+		return
+	}
+	for after := time.Duration(0); !c.IsStopped(); after += ConfigWatchdogStopPoll {
+		if after >= ConfigWatchdogStopTimeout {
+			c.logger.Errorf("Fail to stop in %v by watchdog", ConfigWatchdogStopTimeout)
+			return
+		}
+		time.Sleep(ConfigWatchdogStopPoll)
+	}
+	if err := c.Start(); err != nil {
+		c.logger.Warnf("Fail to start by watchdog err=%+v", err)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/watchdog"
+	"github.com/icon-project/goloop/module"
+)
+
+type testProbedConsensus struct {
+	module.Consensus
+	block chan struct{}
+}
+
+func (c *testProbedConsensus) GetStatus() *module.ConsensusStatus {
+	<-c.block
+	return nil
+}
+
+func TestConsensusProbe(t *testing.T) {
+	w := watchdog.New(10*time.Millisecond, log.New())
+	defer w.Term()
+
+	cs := &testProbedConsensus{block: make(chan struct{})}
+	stalled := make(chan struct{}, 10)
+	p := newConsensusProbe(cs, w, "test", 10*time.Millisecond,
+		50*time.Millisecond, func() {
+			stalled <- struct{}{}
+		})
+
+	// blocked consensus is stalled
+	select {
+	case <-stalled:
+	case <-time.After(time.Second):
+		assert.Fail(t, "no stall")
+	}
+
+	// working consensus isn't stalled
+	close(cs.block)
+	for len(stalled) > 0 {
+		<-stalled
+	}
+	select {
+	case <-stalled:
+		assert.Fail(t, "stalled while working")
+	case <-time.After(200 * time.Millisecond):
+	}
+	p.Stop()
+}
+
+func TestSingleChain_OnConsensusStalled(t *testing.T) {
+	// without the configuration, it doesn't touch the chain
+	c := &singleChain{logger: log.New()}
+	c.onConsensusStalled()
+	assert.Equal(t, Created, c.state)
+}
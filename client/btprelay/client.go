@@ -0,0 +1,243 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package btprelay
+
+import (
+	"bytes"
+	"encoding/base64"
+
+	"github.com/icon-project/goloop/btp"
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	v3 "github.com/icon-project/goloop/server/v3"
+)
+
+type Client struct {
+	*client.ClientV3
+}
+
+func NewClient(endpoint string) *Client {
+	return &Client{client.NewClientV3(endpoint)}
+}
+
+func hexInt(v int64) jsonrpc.HexInt {
+	return jsonrpc.HexInt(intconv.FormatInt(v))
+}
+
+func hashBytes(h jsonrpc.HexBytes) []byte {
+	if len(h) == 0 {
+		return nil
+	}
+	return h.Bytes()
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+func decodeMessages(msgs []string) ([][]byte, error) {
+	res := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		bs, err := decodeBase64(msg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "InvalidMessage(idx=%d)", i)
+		}
+		res[i] = bs
+	}
+	return res, nil
+}
+
+func newMessageProof(header, proof string, msgs []string) (*MessageProof, error) {
+	bs, err := decodeBase64(header)
+	if err != nil {
+		return nil, errors.Wrap(err, "InvalidHeader")
+	}
+	bh, err := btp.NewBTPBlockHeaderFromBytes(bs)
+	if err != nil {
+		return nil, err
+	}
+	pf, err := decodeBase64(proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "InvalidProof")
+	}
+	messages, err := decodeMessages(msgs)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageProof{Header: bh, Proof: pf, Messages: messages}, nil
+}
+
+// NewVerifier returns a verifier for the BTP blocks of the network after the
+// height. The initial state of the verifier is trusted from the node, so
+// the height should be the one which the destination chain already knows.
+func (c *Client) NewVerifier(nid, height int64) (*Verifier, error) {
+	si, err := c.GetBTPSourceInformation()
+	if err != nil {
+		return nil, err
+	}
+	ni, err := c.GetBTPNetworkInfo(&v3.BTPQueryParam{
+		Height: hexInt(height),
+		Id:     hexInt(nid),
+	})
+	if err != nil {
+		return nil, err
+	}
+	nti, err := c.GetBTPNetworkTypeInfo(&v3.BTPQueryParam{
+		Height: hexInt(height),
+		Id:     ni.NetworkTypeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pc, err := decodeBase64(string(nti.NextProofContext))
+	if err != nil {
+		return nil, errors.Wrap(err, "InvalidProofContext")
+	}
+	ntid, err := ni.NetworkTypeID.Int64()
+	if err != nil {
+		return nil, err
+	}
+	sn, err := ni.NextMessageSN.Int64()
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifier(&VerifierState{
+		SrcNetworkUID:          []byte(si.SrcNetworkUID),
+		NetworkTypeID:          ntid,
+		NetworkTypeName:        ni.NetworkTypeName,
+		NetworkID:              nid,
+		Height:                 height,
+		ProofContext:           pc,
+		NextMessageSN:          sn,
+		LastNetworkSectionHash: hashBytes(ni.LastNSHash),
+	})
+}
+
+// GetMessageProof returns the BTP block of the network at the height.
+func (c *Client) GetMessageProof(nid, height int64) (*MessageProof, error) {
+	param := &v3.BTPMessagesParam{
+		Height:    hexInt(height),
+		NetworkId: hexInt(nid),
+	}
+	header, err := c.GetBTPHeader(param)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := c.GetBTPProof(param)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := c.GetBTPMessages(param)
+	if err != nil {
+		return nil, err
+	}
+	return newMessageProof(header, proof, msgs)
+}
+
+func (c *Client) lastNSHashAt(nid, height int64) ([]byte, error) {
+	ni, err := c.GetBTPNetworkInfo(&v3.BTPQueryParam{
+		Height: hexInt(height),
+		Id:     hexInt(nid),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashBytes(ni.LastNSHash), nil
+}
+
+// fillGap verifies BTP blocks of the network before the height, which are
+// not returned by btp_getMessageProofs because they have no messages (on
+// change of the proof context). It finds them with binary search on the
+// last network section hash.
+func (c *Client) fillGap(v *Verifier, height int64) error {
+	nid := v.state.NetworkID
+	for {
+		low, high := v.state.Height+1, height-1
+		if low > high {
+			return nil
+		}
+		last, err := c.lastNSHashAt(nid, high)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(last, v.state.LastNetworkSectionHash) {
+			return nil
+		}
+		for low < high {
+			mid := low + (high-low)/2
+			hash, err := c.lastNSHashAt(nid, mid)
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(hash, v.state.LastNetworkSectionHash) {
+				low = mid + 1
+			} else {
+				high = mid
+			}
+		}
+		mp, err := c.GetMessageProof(nid, low)
+		if err != nil {
+			return err
+		}
+		if err := v.Verify(mp); err != nil {
+			return err
+		}
+	}
+}
+
+// Sync fetches BTP blocks with messages from the next message SN of the
+// verifier, and calls cb for each of them after verification. It returns
+// when there are no more messages, or cb returns error.
+func (c *Client) Sync(v *Verifier, limit int64, cb func(mp *MessageProof) error) error {
+	nid := v.state.NetworkID
+	for {
+		param := &v3.BTPMessageProofsParam{
+			NetworkId: hexInt(nid),
+			From:      hexInt(v.state.NextMessageSN),
+		}
+		if limit > 0 {
+			param.Limit = hexInt(limit)
+		}
+		mps, err := c.GetBTPMessageProofs(param)
+		if err != nil {
+			return err
+		}
+		for _, p := range mps.Proofs {
+			mp, err := newMessageProof(p.Header, p.Proof, p.Messages)
+			if err != nil {
+				return err
+			}
+			if err := c.fillGap(v, mp.Header.MainHeight()); err != nil {
+				return err
+			}
+			if err := v.Verify(mp); err != nil {
+				return err
+			}
+			if err := cb(mp); err != nil {
+				return err
+			}
+		}
+		if mps.Next == nil || len(mps.Proofs) == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package btprelay provides the client for relays of BTP 2.0. It fetches
+// BTP blocks and messages of a network from a goloop node, and verifies
+// them locally with the proof context tracked by Verifier.
+package btprelay
+
+import (
+	"bytes"
+
+	"github.com/icon-project/goloop/btp"
+	"github.com/icon-project/goloop/btp/ntm"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+var ErrDiscontinuity = errors.NewBase(errors.InvalidStateError, "Discontinuity")
+
+// VerifierState is the state of Verifier after verifying the BTP block of
+// Height. Relays may store it to resume verification.
+type VerifierState struct {
+	SrcNetworkUID          []byte
+	NetworkTypeID          int64
+	NetworkTypeName        string
+	NetworkID              int64
+	Height                 int64
+	ProofContext           []byte
+	NextMessageSN          int64
+	LastNetworkSectionHash []byte
+}
+
+// MessageProof is a BTP block of the network with messages in it.
+type MessageProof struct {
+	Header   module.BTPBlockHeader
+	Proof    []byte
+	Messages [][]byte
+}
+
+type Verifier struct {
+	state VerifierState
+	mod   module.NetworkTypeModule
+	pc    module.BTPProofContext
+}
+
+func NewVerifier(s *VerifierState) (*Verifier, error) {
+	mod := ntm.ForUID(s.NetworkTypeName)
+	if mod == nil {
+		return nil, errors.NotFoundError.Errorf(
+			"UnknownNetworkType(name=%s)", s.NetworkTypeName)
+	}
+	pc, err := mod.NewProofContextFromBytes(s.ProofContext)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidProofContext")
+	}
+	return &Verifier{
+		state: *s,
+		mod:   mod,
+		pc:    pc,
+	}, nil
+}
+
+// State returns the current state of the verifier.
+func (v *Verifier) State() *VerifierState {
+	s := v.state
+	return &s
+}
+
+func (v *Verifier) NetworkTypeModule() module.NetworkTypeModule {
+	return v.mod
+}
+
+func messageHashes(mod module.NetworkTypeModule, msgs [][]byte) module.BytesSlice {
+	hashes := make(module.BytesSlice, len(msgs))
+	for i, msg := range msgs {
+		hashes[i] = mod.Hash(msg)
+	}
+	return hashes
+}
+
+// Verify verifies the BTP block with the proof and the messages in it, and
+// updates the state on success. The BTP block shall be the next one of the
+// network after the last verified, otherwise it returns ErrDiscontinuity.
+func (v *Verifier) Verify(mp *MessageProof) error {
+	bh := mp.Header
+	if bh.NetworkID() != v.state.NetworkID {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidNetworkID(exp=%d,real=%d)", v.state.NetworkID, bh.NetworkID())
+	}
+	if bh.MainHeight() <= v.state.Height {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidHeight(height=%d,last=%d)", bh.MainHeight(), v.state.Height)
+	}
+	if v.state.LastNetworkSectionHash != nil &&
+		!bytes.Equal(bh.PrevNetworkSectionHash(), v.state.LastNetworkSectionHash) {
+		return errors.Wrapf(ErrDiscontinuity, "InvalidPrevNSHash(exp=%#x,real=%#x)",
+			v.state.LastNetworkSectionHash, bh.PrevNetworkSectionHash())
+	}
+	if bh.FirstMessageSN() != v.state.NextMessageSN {
+		return errors.Wrapf(ErrDiscontinuity, "InvalidFirstMessageSN(exp=%d,real=%d)",
+			v.state.NextMessageSN, bh.FirstMessageSN())
+	}
+	if bh.MessageCount() != int64(len(mp.Messages)) {
+		return errors.InvalidStateError.Errorf(
+			"InvalidMessageCount(exp=%d,real=%d)", bh.MessageCount(), len(mp.Messages))
+	}
+	hashes := messageHashes(v.mod, mp.Messages)
+	if root := v.mod.MerkleRoot(&hashes); !bytes.Equal(root, bh.MessagesRoot()) {
+		return errors.InvalidStateError.Errorf(
+			"InvalidMessagesRoot(exp=%#x,calc=%#x)", bh.MessagesRoot(), root)
+	}
+
+	npc := v.pc
+	if bh.NextProofContextChanged() {
+		var err error
+		npc, err = v.mod.NewProofContextFromBytes(bh.NextProofContext())
+		if err != nil {
+			return errors.InvalidStateError.Wrap(err, "InvalidNextProofContext")
+		}
+	}
+	if !bytes.Equal(npc.Hash(), bh.NextProofContextHash()) {
+		return errors.InvalidStateError.Errorf(
+			"InvalidNextProofContextHash(exp=%#x,calc=%#x)",
+			bh.NextProofContextHash(), npc.Hash())
+	}
+
+	pf, err := v.pc.NewProofFromBytes(mp.Proof)
+	if err != nil {
+		return errors.InvalidStateError.Wrap(err, "InvalidProof")
+	}
+	ntsd := v.pc.NewDecision(
+		v.state.SrcNetworkUID, v.state.NetworkTypeID,
+		bh.MainHeight(), bh.Round(),
+		btp.NetworkTypeSectionHash(v.mod, bh),
+	)
+	if err := v.pc.Verify(ntsd.Hash(), pf); err != nil {
+		return err
+	}
+
+	v.pc = npc
+	v.state.ProofContext = npc.Bytes()
+	v.state.Height = bh.MainHeight()
+	v.state.NextMessageSN = bh.FirstMessageSN() + bh.MessageCount()
+	v.state.LastNetworkSectionHash = btp.NetworkSectionHash(v.mod, bh)
+	return nil
+}
+
+// NewMessageInclusionProof returns the merkle proof of the message at the
+// index for MessagesRoot of the BTP block including the messages.
+func NewMessageInclusionProof(mod module.NetworkTypeModule, msgs [][]byte, idx int) ([]module.MerkleNode, error) {
+	if idx < 0 || idx >= len(msgs) {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidIndex(idx=%d,len=%d)", idx, len(msgs))
+	}
+	hashes := messageHashes(mod, msgs)
+	return mod.MerkleProof(&hashes, idx), nil
+}
+
+// VerifyMessageInclusion returns whether the message is included in the
+// messages of the root with the proof.
+func VerifyMessageInclusion(mod module.NetworkTypeModule, root []byte, msg []byte, proof []module.MerkleNode) bool {
+	return bytes.Equal(btp.MerkleRootFromProof(mod, mod.Hash(msg), proof), root)
+}
@@ -146,6 +146,7 @@ type BTPNetworkInfo struct {
 	LastNSHash              jsonrpc.HexBytes `json:"lastNSHash"`
 	NetworkID               jsonrpc.HexInt   `json:"networkID"`
 	NetworkTypeName         string           `json:"networkTypeName"`
+	NextProofContext        string           `json:"nextProofContext,omitempty"`
 }
 
 //refer service/state/btp.go:752 networkType.ToJSON
@@ -157,12 +158,36 @@ type BTPNetworkTypeInfo struct {
 	NetworkTypeID    jsonrpc.HexInt   `json:"networkTypeID"`
 }
 
+//refer server/v3/api_v3.go getBTPMessageProofs
+type BTPMessageProof struct {
+	Height         jsonrpc.HexInt `json:"height"`
+	FirstMessageSN jsonrpc.HexInt `json:"firstMessageSN"`
+	Header         string         `json:"header"`
+	Proof          string         `json:"proof"`
+	Messages       []string       `json:"messages"`
+}
+
+type BTPMessageProofs struct {
+	Proofs []*BTPMessageProof `json:"proofs"`
+	Next   *jsonrpc.HexInt    `json:"next"`
+}
+
 //refer server/v3/api_v3.go:953 getBTPSourceInformation
 type BTPSourceInformation struct {
 	SrcNetworkUID  string           `json:"srcNetworkUID"`
 	NetworkTypeIDs []jsonrpc.HexInt `json:"networkTypeIDs"`
 }
 
+//refer server/v3/api_v3.go getReceiptProof
+type ReceiptProof struct {
+	TxHash       jsonrpc.HexBytes   `json:"txHash"`
+	BlockHash    jsonrpc.HexBytes   `json:"blockHash"`
+	BlockHeight  jsonrpc.HexInt     `json:"blockHeight"`
+	TxIndex      jsonrpc.HexInt     `json:"txIndex"`
+	ResultHeader jsonrpc.HexBytes   `json:"resultHeader"`
+	Proof        []jsonrpc.HexBytes `json:"proof"`
+}
+
 func (c *ClientV3) GetLastBlock() (*Block, error) {
 	blk := &Block{}
 	_, err := c.Do("icx_getLastBlock", nil, blk)
@@ -285,26 +310,44 @@ func (c *ClientV3) SendTransaction(w module.Wallet, param *v3.TransactionParam)
 	return &result, nil
 }
 
-func (c *ClientV3) SendRawTransaction(w module.Wallet, param map[string]interface{}) (*jsonrpc.HexBytes, error) {
-	param["timestamp"] = intconv.FormatInt(time.Now().UnixNano() / int64(time.Microsecond))
+// SignRawTransaction sets signature of the transaction with the wallet.
+// It doesn't require connection to the node, so it can be used offline.
+func SignRawTransaction(w module.Wallet, param map[string]interface{}) error {
 	bs, err := transaction.SerializeMap(param, nil, txSerializeExcludes)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	bs = append([]byte("icx_sendTransaction."), bs...)
 	sig, err := w.Sign(crypto.SHA3Sum256(bs))
 	if err != nil {
-		return nil, err
+		return err
 	}
-
 	param["signature"] = base64.StdEncoding.EncodeToString(sig)
+	return nil
+}
+
+func (c *ClientV3) SendRawTransaction(w module.Wallet, param map[string]interface{}) (*jsonrpc.HexBytes, error) {
+	param["timestamp"] = intconv.FormatInt(time.Now().UnixNano() / int64(time.Microsecond))
+	if err := SignRawTransaction(w, param); err != nil {
+		return nil, err
+	}
 	var result jsonrpc.HexBytes
-	if _, err = c.Do("icx_sendTransaction", param, &result); err != nil {
+	if _, err := c.Do("icx_sendTransaction", param, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// FillTransaction returns the transaction with version, nid, timestamp and
+// stepLimit filled by the node. The returned transaction is not signed.
+func (c *ClientV3) FillTransaction(param map[string]interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if _, err := c.Do("icx_fillTransaction", param, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 //using blockHeader.NextValidatorsHash
 func (c *ClientV3) GetDataByHash(param *v3.DataHashParam) ([]byte, error) {
 	var result []byte
@@ -352,6 +395,14 @@ func (c *ClientV3) GetProofForEvents(param *v3.ProofEventsParam) ([][][]byte, er
 	return result, nil
 }
 
+func (c *ClientV3) GetReceiptProof(param *v3.TransactionHashParam) (*ReceiptProof, error) {
+	rp := &ReceiptProof{}
+	if _, err := c.Do("icx_getReceiptProof", param, rp); err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
 func (c *ClientV3) GetBTPNetworkInfo(param *v3.BTPQueryParam) (*BTPNetworkInfo, error) {
 	ni := &BTPNetworkInfo{}
 	if _, err := c.Do("btp_getNetworkInfo", param, ni); err != nil {
@@ -404,6 +455,44 @@ func (c *ClientV3) GetBTPSourceInformation() (*BTPSourceInformation, error) {
 	return si, nil
 }
 
+func (c *ClientV3) GetBTPNetworks(param *v3.BTPNetworksParam) ([]*BTPNetworkInfo, error) {
+	var nis []*BTPNetworkInfo
+	if _, err := c.Do("btp_getNetworks", param, &nis); err != nil {
+		return nil, err
+	}
+	return nis, nil
+}
+
+func (c *ClientV3) GetBTPMessageProofs(param *v3.BTPMessageProofsParam) (*BTPMessageProofs, error) {
+	mps := &BTPMessageProofs{}
+	if _, err := c.Do("btp_getMessageProofs", param, mps); err != nil {
+		return nil, err
+	}
+	return mps, nil
+}
+
+func (c *ClientV3) GetRevisionInfo(param *v3.HeightParam) (interface{}, error) {
+	var result interface{}
+	var nullableParam interface{}
+	if param != nil {
+		nullableParam = param
+	}
+	_, err := c.Do("icx_getRevisionInfo", nullableParam, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetFeeSharingInfo(param *v3.ScoreAddressParam) (interface{}, error) {
+	var result interface{}
+	_, err := c.Do("icx_getFeeSharingInfo", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *ClientV3) GetScoreStatus(param *v3.ScoreAddressParam) (interface{}, error) {
 	var result interface{}
 	_, err := c.Do("icx_getScoreStatus", param, &result)
@@ -413,6 +502,15 @@ func (c *ClientV3) GetScoreStatus(param *v3.ScoreAddressParam) (interface{}, err
 	return result, nil
 }
 
+func (c *ClientV3) GetScoreInfo(param *v3.ScoreAddressParam) (interface{}, error) {
+	var result interface{}
+	_, err := c.Do("icx_getScoreInfo", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *ClientV3) MonitorBlock(param *server.BlockRequest, cb func(v *server.BlockNotification), cancelCh <-chan bool) error {
 	resp := &server.BlockNotification{}
 	return c.Monitor("/block", param, resp, func(v interface{}) {
@@ -440,6 +538,15 @@ func (c *ClientV3) MonitorBtp(param *server.BTPRequest, cb func(v *server.BTPNot
 	}, cancelCh)
 }
 
+func (c *ClientV3) MonitorHeader(param *server.HeaderRequest, cb func(v *server.HeaderNotification), cancelCh <-chan bool) error {
+	resp := &server.HeaderNotification{}
+	return c.Monitor("/header", param, resp, func(v interface{}) {
+		if hn, ok := v.(*server.HeaderNotification); ok {
+			cb(hn)
+		}
+	}, cancelCh)
+}
+
 func (c *ClientV3) Monitor(reqUrl string, reqPtr, respPtr interface{},
 	cb func(v interface{}), cancelCh <-chan bool) error {
 	if cb == nil {
@@ -555,3 +662,22 @@ func (c *ClientV3) EstimateStep(param *v3.TransactionParamForEstimate) (*common.
 	}
 	return &result, nil
 }
+
+func (c *ClientV3) EstimateStepDetails(param *v3.TransactionParamForEstimate) (interface{}, error) {
+	if len(c.DebugEndPoint) == 0 {
+		return nil, errors.InvalidStateError.New("UnavailableDebugEndPoint")
+	}
+	param.Timestamp = jsonrpc.HexInt(intconv.FormatInt(time.Now().UnixNano() / int64(time.Microsecond)))
+	var result interface{}
+	if _, err := c.DoURL(c.DebugEndPoint,
+		"debug_estimateStepDetails", param, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ContractAddressForDeploy returns the address of the contract deployed by
+// from with the salt and the content, so it can be used before deployment.
+func ContractAddressForDeploy(from module.Address, salt []byte, content []byte) module.Address {
+	return common.NewContractAddressWithSalt(from, salt, content)
+}
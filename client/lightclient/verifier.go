@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lightclient provides verifiers for light clients, which trust
+// block IDs only. They verify data fetched from a goloop node locally
+// without full blocks.
+package lightclient
+
+import (
+	"bytes"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+// DecodeHeader decodes the block header after checking that it's the
+// header of the block with the id.
+func DecodeHeader(header []byte, id []byte) (*block.V2HeaderFormat, error) {
+	if hid := crypto.SHA3Sum256(header); !bytes.Equal(hid, id) {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidHeader(id=%#x,exp=%#x)", hid, id)
+	}
+	hf := new(block.V2HeaderFormat)
+	if _, err := codec.BC.UnmarshalFromBytes(header, hf); err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidHeader")
+	}
+	return hf, nil
+}
+
+// VerifyReceipt verifies the receipt proof with the id of the result block,
+// which is the next block of the one including the transaction. It returns
+// the receipt at the index of the transaction on success.
+//
+// It doesn't prove that the transaction is at the index in the block, so
+// light clients shall check it with the transactions of the block.
+func VerifyReceipt(rp *client.ReceiptProof, resultBlockID []byte) (module.Receipt, error) {
+	hf, err := DecodeHeader(rp.ResultHeader.Bytes(), resultBlockID)
+	if err != nil {
+		return nil, err
+	}
+	height, err := rp.BlockHeight.Int64()
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidBlockHeight")
+	}
+	if hf.Height != height+1 {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidResultHeight(height=%d,exp=%d)", hf.Height, height+1)
+	}
+	if !bytes.Equal(hf.PrevID, rp.BlockHash.Bytes()) {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidBlockHash(hash=%#x,exp=%#x)", rp.BlockHash.Bytes(), hf.PrevID)
+	}
+	index, err := rp.TxIndex.ParseInt(32)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidTxIndex")
+	}
+	rh, err := service.ReceiptHashFromResult(hf.Result, module.TransactionGroupNormal)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidResult")
+	}
+	proof := make([][]byte, len(rp.Proof))
+	for i, p := range rp.Proof {
+		proof[i] = p.Bytes()
+	}
+	return txresult.ProveReceipt(rh, int(index), proof)
+}
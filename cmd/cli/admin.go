@@ -22,9 +22,42 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/node"
+	"github.com/icon-project/goloop/service/transaction"
 )
 
+func parseHexBytes(s string) ([]byte, error) {
+	if len(s) >= 2 && s[:2] == "0x" {
+		s = s[2:]
+	}
+	return hex.DecodeString(s)
+}
+
+func parseSyncAnchor(s string) (*chain.SyncAnchor, error) {
+	fields := strings.Split(s, ":")
+	if len(fields) != 3 {
+		return nil, errors.Errorf("invalid sync anchor %q (HEIGHT:BLOCK_ID:VALIDATORS_HASH)", s)
+	}
+	anchor := &chain.SyncAnchor{}
+	var err error
+	if anchor.Height, err = strconv.ParseInt(fields[0], 0, 64); err != nil {
+		return nil, errors.Wrapf(err, "invalid sync anchor height %q", fields[0])
+	}
+	if anchor.BlockID, err = parseHexBytes(fields[1]); err != nil {
+		return nil, errors.Wrapf(err, "invalid sync anchor block id %q", fields[1])
+	}
+	if anchor.ValidatorsHash, err = parseHexBytes(fields[2]); err != nil {
+		return nil, errors.Wrapf(err, "invalid sync anchor validators hash %q", fields[2])
+	}
+	if err = anchor.Validate(); err != nil {
+		return nil, err
+	}
+	return anchor, nil
+}
+
 func ReadFile(name string) ([]byte, error) {
 	if name == "-" {
 		if bs, err := ioutil.ReadAll(os.Stdin); err != nil {
@@ -106,7 +139,7 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			if err != nil {
 				return err
 			}
-			if err = JsonPrettyPrintln(os.Stdout, l); err != nil {
+			if err = PrintOutput(os.Stdout, l); err != nil {
 				return errors.Errorf("failed JsonIntend resp=%+v, err=%+v", resp, err)
 			}
 			return nil
@@ -146,6 +179,29 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 				param.NephewsLimit = &nephewsLimit
 			}
 			param.ValidateTxOnSend, _ = fs.GetBool("validate_tx_on_send")
+			param.AutoRole, _ = fs.GetBool("auto_role")
+			param.RecordInternalCalls, _ = fs.GetBool("record_internal_calls")
+			param.RecordAddressIndex, _ = fs.GetBool("record_address_index")
+			param.RecordTokenIndex, _ = fs.GetBool("record_token_index")
+			param.TxTimestampWindow, _ = fs.GetInt64("tx_timestamp_window")
+			param.TxPoolTTL, _ = fs.GetInt64("tx_pool_ttl")
+			param.TxPoolPersist, _ = fs.GetBool("tx_pool_persist")
+			param.PriorityTxRatio, _ = fs.GetInt("priority_tx_ratio")
+			param.SyncServerLimit, _ = fs.GetInt("sync_server_limit")
+			param.SyncServerBandwidth, _ = fs.GetInt64("sync_server_bandwidth")
+			param.Sequencer, _ = fs.GetString("sequencer")
+			param.ReceiptCompression, _ = fs.GetString("receipt_compression")
+			param.ReplicaDBDir, _ = fs.GetString("replica_db_dir")
+			param.ReplicaInterval, _ = fs.GetInt64("replica_interval")
+			param.HistoryDepth, _ = fs.GetInt64("history_depth")
+			param.HistoryQueries, _ = fs.GetString("history_queries")
+			param.WatchdogRestart, _ = fs.GetBool("watchdog_restart")
+			if anchor, _ := fs.GetString("sync_anchor"); len(anchor) > 0 {
+				var err error
+				if param.SyncAnchor, err = parseSyncAnchor(anchor); err != nil {
+					return err
+				}
+			}
 
 			var buf *bytes.Buffer
 			if len(genesisZip) > 0 {
@@ -203,6 +259,24 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	joinFlags.Int("children_limit", -1, "Maximum number of child connections (-1: uses system default value)")
 	joinFlags.Int("nephews_limit", -1, "Maximum number of nephew connections (-1: uses system default value)")
 	joinFlags.Bool("validate_tx_on_send", false, "Validate transaction on send")
+	joinFlags.Bool("record_internal_calls", false, "Record internal calls of transactions")
+	joinFlags.Bool("record_address_index", false, "Record index of transactions by addresses involved in them")
+	joinFlags.Bool("record_token_index", false, "Record balances and transfers of IRC-2 and IRC-3 tokens")
+	joinFlags.Int64("tx_timestamp_window", 0, "Timestamp window of transactions for the pool in milli-second (0: uses network threshold)")
+	joinFlags.Int64("tx_pool_ttl", 0, "Max duration of transactions in the pool in milli-second (0: no limit)")
+	joinFlags.Bool("tx_pool_persist", false, "Persist pending transactions in the pool to restore them after restart")
+	joinFlags.Int("priority_tx_ratio", 0, "Percentage of transactions in a block reserved for system and governance (0: no reservation)")
+	joinFlags.Int("sync_server_limit", 0, "Maximum number of state sync and block sync requests of peers served concurrently (0: no limit)")
+	joinFlags.Int64("sync_server_bandwidth", 0, "Maximum bytes per second of state sync and block sync responses to peers (0: no limit)")
+	joinFlags.String("sequencer", "", "Account allowed to submit transaction bundles to be proposed verbatim")
+	joinFlags.String("receipt_compression", string(db.CompressionDefault), "Compression of receipts and event logs in the database (none,zstd)")
+	joinFlags.Bool("auto_role", false, "Adjust role of the node by election of the platform")
+	joinFlags.String("replica_db_dir", "", "Database directory replicated from other node to serve queries as a read replica")
+	joinFlags.Int64("replica_interval", 0, "Interval to check new blocks as a read replica in milli-second (0: uses system default value)")
+	joinFlags.Int64("history_depth", 0, "Number of the latest blocks served for all historical queries (0: no limit as an archive)")
+	joinFlags.String("history_queries", "", "Historical queries served for older blocks than history_depth (state,trace,receipt) - Comma separated string")
+	joinFlags.Bool("watchdog_restart", false, "Restart the chain if the consensus is stalled")
+	joinFlags.String("sync_anchor", "", "Trusted block for fast bootstrap (HEIGHT:BLOCK_ID:VALIDATORS_HASH)")
 
 	leaveCmd := &cobra.Command{
 		Use:   "leave CID",
@@ -244,7 +318,7 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 				return err
 			}
 			if format == "" {
-				if err = JsonPrettyPrintln(os.Stdout, v); err != nil {
+				if err = PrintOutput(os.Stdout, v); err != nil {
 					return errors.Errorf("failed JsonIntend resp=%+v, err=%+v", resp, err)
 				}
 				return nil
@@ -359,6 +433,25 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	importFlags.Int64("height", 0, "Block Height")
 	MarkAnnotationRequired(importFlags, "db_path", "height")
 
+	importStatusCmd := &cobra.Command{
+		Use:   "import_status CID",
+		Short: "Show progress of importing ICON 1 blocks",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := new(node.ChainInspectView)
+			reqUrl := node.UrlChain + "/" + args[0]
+			if _, err := adminClient.Get(reqUrl, v); err != nil {
+				return err
+			}
+			status, ok := v.Module["importer"]
+			if !ok {
+				return errors.Errorf("chain isn't importing state=%s", v.State)
+			}
+			return PrintOutput(os.Stdout, status)
+		},
+	}
+	rootCmd.AddCommand(importStatusCmd)
+
 	pruneCmd := &cobra.Command{
 		Use:   "prune CID",
 		Short: "Start to prune the database based on the height",
@@ -368,6 +461,11 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			param := &node.ChainPruneParam{}
 			param.DBType, _ = fs.GetString("db_type")
 			param.Height, _ = fs.GetInt64("height")
+			param.Keep, _ = fs.GetInt64("keep")
+			param.Headers, _ = fs.GetBool("headers")
+			if (param.Height > 0) == (param.Keep > 0) {
+				return errors.New("one of height or keep shall be specified")
+			}
 
 			var v string
 			reqUrl := node.UrlChain + "/" + args[0] + "/prune"
@@ -383,7 +481,114 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	pruneFlags := pruneCmd.Flags()
 	pruneFlags.String("db_type", "", "Database type(default:original database type)")
 	pruneFlags.Int64("height", 0, "Block Height")
-	MarkAnnotationRequired(pruneFlags, "height")
+	pruneFlags.Int64("keep", 0, "Number of latest blocks to keep (instead of height)")
+	pruneFlags.Bool("headers", false, "Keep headers of pruned blocks")
+
+	tasksCmd := &cobra.Command{
+		Use:   "tasks CID",
+		Short: "List recent maintenance tasks of the chain",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var v []*module.ChainTaskStatus
+			reqUrl := node.UrlChain + "/" + args[0] + "/tasks"
+			if _, err := adminClient.Get(reqUrl, &v); err != nil {
+				return err
+			}
+			return PrintOutput(os.Stdout, v)
+		},
+	}
+	rootCmd.AddCommand(tasksCmd)
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback CID",
+		Short: "Start to rollback the chain to the height",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			param := &node.ChainRollbackParam{}
+			param.Height, _ = fs.GetInt64("height")
+			if param.Height < 0 {
+				return errors.New("height shall be specified")
+			}
+
+			var v string
+			reqUrl := node.UrlChain + "/" + args[0] + "/rollback"
+			_, err := adminClient.PostWithJson(reqUrl, param, &v)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(rollbackCmd)
+	rollbackFlags := rollbackCmd.Flags()
+	rollbackFlags.Int64("height", -1, "Block Height to rollback to")
+
+	bundleCmd := &cobra.Command{
+		Use:   "bundle CID BUNDLE_FILE",
+		Short: "Submit the bundle of transactions signed by the sequencer",
+		Long: "Submit the bundle of transactions in the JSON array of signed transactions.\n" +
+			"The bundle is proposed verbatim after validation.",
+		Args: ArgsWithDefaultErrorFunc(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			bs, err := readFile(args[1])
+			if err != nil {
+				return err
+			}
+			param := &node.ChainBundleParam{}
+			if err = json.Unmarshal(bs, &param.Transactions); err != nil {
+				return errors.Errorf("fail to parse bundle file=%s err=%+v", args[1], err)
+			}
+			ids := make([][]byte, len(param.Transactions))
+			for i, txj := range param.Transactions {
+				tx, err := transaction.NewTransactionFromJSON(txj)
+				if err != nil {
+					return errors.Errorf("fail to parse transaction index=%d err=%+v", i, err)
+				}
+				ids[i] = tx.ID()
+			}
+
+			var kb, pb []byte
+			ksf := vc.GetString("key_store")
+			if ksf == "" {
+				return errors.New("key_store shall be specified")
+			}
+			if kb, err = ioutil.ReadFile(ksf); err != nil {
+				return fmt.Errorf("fail to open KeyStore file=%s err=%+v", ksf, err)
+			}
+			ksec, _ := fs.GetString("key_secret")
+			kpass, _ := fs.GetString("key_password")
+			if ksec != "" {
+				if pb, err = ioutil.ReadFile(ksec); err != nil {
+					return fmt.Errorf("fail to open KeySecret file=%s err=%+v", ksec, err)
+				}
+			} else if kpass != "" {
+				pb = []byte(kpass)
+			} else {
+				return fmt.Errorf("there is no password information for the KeyStore, use --key_secret or --key_password")
+			}
+			w, err := wallet.NewFromKeyStore(kb, pb)
+			if err != nil {
+				return fmt.Errorf("fail to create wallet err=%+v", err)
+			}
+			if param.Signature, err = w.Sign(transaction.BundleHash(ids)); err != nil {
+				return err
+			}
+
+			var v []common.HexBytes
+			reqUrl := node.UrlChain + "/" + args[0] + "/bundle"
+			if _, err = adminClient.PostWithJson(reqUrl, param, &v); err != nil {
+				return err
+			}
+			return PrintOutput(os.Stdout, v)
+		},
+	}
+	rootCmd.AddCommand(bundleCmd)
+	bundleFlags := bundleCmd.Flags()
+	bundleFlags.String("key_secret", "", "Secret (password) file for KeyStore")
+	bundleFlags.String("key_password", "", "Password for the KeyStore file")
 
 	backupCmd := &cobra.Command{
 		Use:   "backup CID",
@@ -392,8 +597,10 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fs := cmd.Flags()
 			manual, _ := fs.GetBool("manual")
+			incremental, _ := fs.GetBool("incremental")
 			param := &node.ChainBackupParam{
-				Manual: manual,
+				Manual:      manual,
+				Incremental: incremental,
 			}
 			var v string
 			reqUrl := node.UrlChain + "/" + args[0] + "/backup"
@@ -408,6 +615,130 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	rootCmd.AddCommand(backupCmd)
 	backupFlags := backupCmd.Flags()
 	backupFlags.Bool("manual", false, "Manual backup mode (just release database)")
+	backupFlags.Bool("incremental", false, "Backup only changed files after the latest backup")
+
+	scheduleCmd := &cobra.Command{
+		Use:   "schedule CID",
+		Short: "Manage backup schedule of the channel",
+		Long: "Manage backup schedule of the channel.\n" +
+			"Each backup stops the chain until the backup is done, and starts it again.",
+		Args: ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			reqUrl := node.UrlChain + "/" + args[0] + "/backup/schedule"
+			if remove, _ := fs.GetBool("remove"); remove {
+				var v string
+				if _, err := adminClient.Delete(reqUrl, &v); err != nil {
+					return err
+				}
+				fmt.Println(v)
+				return nil
+			}
+			if !fs.Changed("interval") {
+				resp, err := adminClient.Get(reqUrl, nil)
+				if err != nil {
+					return err
+				}
+				return PrintOutputAndClose(os.Stdout, resp.Body)
+			}
+			param := &node.BackupSchedule{}
+			param.Interval, _ = fs.GetString("interval")
+			param.Incremental, _ = fs.GetBool("incremental")
+			param.FullEvery, _ = fs.GetInt("full_every")
+			param.Retention, _ = fs.GetInt("retention")
+			param.Verify, _ = fs.GetBool("verify")
+			var v string
+			if _, err := adminClient.PostWithJson(reqUrl, param, &v); err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleFlags := scheduleCmd.Flags()
+	scheduleFlags.String("interval", "", "Interval between backups (ex: 12h), show current schedule if it's not specified")
+	scheduleFlags.Bool("incremental", false, "Make incremental backups")
+	scheduleFlags.Int("full_every", 0, "Number of incremental backups before the next full backup (0: only first one)")
+	scheduleFlags.Int("retention", 0, "Number of backups to keep (0: keep all)")
+	scheduleFlags.Bool("verify", false, "Verify the backup after it's done")
+	scheduleFlags.Bool("remove", false, "Remove the backup schedule")
+
+	logCmd := &cobra.Command{
+		Use:   "log CID",
+		Short: "Manage log routing of the chain",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			reqUrl := node.UrlChain + "/" + args[0] + "/log"
+			if remove, _ := fs.GetBool("remove"); remove {
+				var v string
+				if _, err := adminClient.Delete(reqUrl, &v); err != nil {
+					return err
+				}
+				fmt.Println(v)
+				return nil
+			}
+			useWriter, _ := fs.GetBool("writer")
+			useWriter = useWriter || fs.Changed("filename")
+			vendor, _ := fs.GetString("forwarder_vendor")
+			if !useWriter && vendor == "" {
+				resp, err := adminClient.Get(reqUrl, nil)
+				if err != nil {
+					return err
+				}
+				return PrintOutputAndClose(os.Stdout, resp.Body)
+			}
+			param := &log.ChainLogConfig{}
+			if useWriter {
+				w := &log.WriterConfig{}
+				w.Filename, _ = fs.GetString("filename")
+				w.MaxSize, _ = fs.GetInt("maxsize")
+				w.MaxAge, _ = fs.GetInt("maxage")
+				w.MaxBackups, _ = fs.GetInt("maxbackups")
+				w.LocalTime, _ = fs.GetBool("localtime")
+				w.Compress, _ = fs.GetBool("compress")
+				w.Rotate, _ = fs.GetString("rotate")
+				param.Writer = w
+			}
+			if vendor != "" {
+				f := &log.ForwarderConfig{Vendor: vendor}
+				f.Address, _ = fs.GetString("forwarder_address")
+				f.Level, _ = fs.GetString("forwarder_level")
+				f.Name, _ = fs.GetString("forwarder_name")
+				opts, _ := fs.GetStringToString("forwarder_options")
+				if len(opts) > 0 {
+					f.Options = make(map[string]interface{})
+					for k, v := range opts {
+						f.Options[k] = v
+					}
+				}
+				param.Forwarders = []*log.ForwarderConfig{f}
+			}
+			var v string
+			if _, err := adminClient.PostWithJson(reqUrl, param, &v); err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(logCmd)
+	logFlags := logCmd.Flags()
+	logFlags.Bool("writer", false, "Write logs of the chain to its own file, show current routing if neither it nor forwarder is specified")
+	logFlags.String("filename", "", "Log filename (default: chain.log in the chain directory)")
+	logFlags.Int("maxsize", 100, "Maximum log file size in MiB")
+	logFlags.Int("maxage", 0, "Maximum age of log file in day")
+	logFlags.Int("maxbackups", 0, "Maximum number of backups")
+	logFlags.Bool("localtime", false, "Use localtime on rotated log file instead of UTC")
+	logFlags.Bool("compress", false, "Use gzip on rotated log file")
+	logFlags.String("rotate", "", "Interval of time based rotation (ex: 24h)")
+	logFlags.String("forwarder_vendor", "", "LogForwarder vendor (fluentd,logstash,syslog,loki)")
+	logFlags.String("forwarder_address", "", "LogForwarder address")
+	logFlags.String("forwarder_level", "info", "LogForwarder level")
+	logFlags.String("forwarder_name", "", "LogForwarder name")
+	logFlags.StringToString("forwarder_options", nil, "LogForwarder options, comma-separated 'key=value'")
+	logFlags.Bool("remove", false, "Remove the log routing")
 
 	genesisCmd := &cobra.Command{
 		Use:   "genesis CID FILE",
@@ -436,6 +767,86 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	}
 	rootCmd.AddCommand(genesisCmd)
 
+	exportStateCmd := &cobra.Command{
+		Use:   "export-state CID",
+		Short: "Export the world state at the height as hash-chained chunks",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			height, _ := fs.GetInt64("height")
+			size, _ := fs.GetInt("chunk_size")
+			out, _ := fs.GetString("out")
+			if height < 0 {
+				return errors.New("height shall be specified")
+			}
+			params := &url.Values{}
+			params.Add("height", strconv.FormatInt(height, 10))
+			params.Add("chunk_size", strconv.Itoa(size))
+			reqUrl := node.UrlChain + "/" + args[0] + "/state"
+			resp, err := adminClient.Get(reqUrl, nil, params)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+
+			w := os.Stdout
+			if out != "-" {
+				if w, err = os.Create(out); err != nil {
+					return errors.Wrapf(err, "Fail to create file=%s", out)
+				}
+				defer w.Close()
+			}
+			var prev common.HexBytes
+			var summary *node.StateExportSummary
+			dec := json.NewDecoder(resp.Body)
+			for dec.More() {
+				var line json.RawMessage
+				if err := dec.Decode(&line); err != nil {
+					return err
+				}
+				if summary != nil {
+					return errors.New("unexpected line after the summary")
+				}
+				var v struct {
+					node.StateChunk
+					Chunks *int64 `json:"chunks"`
+				}
+				if err := json.Unmarshal(line, &v); err != nil {
+					return err
+				}
+				if v.Chunks != nil {
+					summary = &node.StateExportSummary{}
+					if err := json.Unmarshal(line, summary); err != nil {
+						return err
+					}
+					continue
+				}
+				if !bytes.Equal(v.PrevHash, prev) {
+					return errors.Errorf("invalid prevHash of chunk index=%d", v.Index)
+				}
+				prev = v.Hash
+				if _, err := fmt.Fprintln(w, string(line)); err != nil {
+					return err
+				}
+			}
+			if summary == nil {
+				return errors.New("incomplete export (no summary)")
+			}
+			if !bytes.Equal(summary.Hash, prev) {
+				return errors.Errorf("invalid hash of the summary hash=%s", summary.Hash)
+			}
+			if out == "-" {
+				return nil
+			}
+			return PrintOutput(os.Stdout, summary)
+		},
+	}
+	rootCmd.AddCommand(exportStateCmd)
+	exportStateFlags := exportStateCmd.Flags()
+	exportStateFlags.Int64("height", -1, "Block height (required)")
+	exportStateFlags.Int("chunk_size", node.DefaultStateExportChunkSize, "Number of records in a chunk")
+	exportStateFlags.StringP("out", "o", "-", "Output file (\"-\" for stdout)")
+
 	configCmd := &cobra.Command{
 		Use:   "config CID KEY VALUE",
 		Short: "Configure chain",
@@ -448,7 +859,7 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 				if err != nil {
 					return err
 				}
-				if err = JsonPrettyPrintln(os.Stdout, v); err != nil {
+				if err = PrintOutput(os.Stdout, v); err != nil {
 					return errors.Errorf("failed JsonIntend resp=%+v, err=%+v", resp, err)
 				}
 			} else {
@@ -525,7 +936,7 @@ func NewSystemCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comma
 				return err
 			}
 			if format == "" {
-				if err = JsonPrettyPrintln(os.Stdout, v); err != nil {
+				if err = PrintOutput(os.Stdout, v); err != nil {
 					return errors.Errorf("failed JsonIntend resp=%+v, err=%+v", resp, err)
 				}
 				return nil
@@ -551,7 +962,7 @@ func NewSystemCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comma
 				if err != nil {
 					return err
 				}
-				if err = JsonPrettyPrintln(os.Stdout, v); err != nil {
+				if err = PrintOutput(os.Stdout, v); err != nil {
 					return errors.Errorf("failed JsonIntend resp=%+v, err=%+v", resp, err)
 				}
 			} else {
@@ -572,6 +983,8 @@ func NewSystemCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comma
 
 	NewBackupCmd(rootCmd, &adminClient)
 	NewRestoreCmd(rootCmd, &adminClient)
+	NewAuditCmd(rootCmd, &adminClient)
+	NewDoctorCmd(rootCmd, vc)
 
 	return rootCmd, vc
 }
@@ -592,10 +1005,75 @@ func NewBackupCmd(parent *cobra.Command, client *node.UnixDomainSockHttpClient)
 			if err != nil {
 				return err
 			}
-			return JsonPrettyCopyAndClose(os.Stdout, resp.Body)
+			return PrintOutputAndClose(os.Stdout, resp.Body)
+		},
+	}
+	rootCmd.AddCommand(listCmd)
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify NAME",
+		Short: "Verify the backup (including its bases) is restorable",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := client.Get(node.UrlSystem+"/backup/"+url.PathEscape(args[0])+"/verify", nil)
+			if err != nil {
+				return err
+			}
+			return PrintOutputAndClose(os.Stdout, resp.Body)
+		},
+	}
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func NewAuditCmd(parent *cobra.Command, client *node.UnixDomainSockHttpClient) {
+	rootCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Retrieve and verify audit log of admin API calls",
+	}
+	parent.AddCommand(rootCmd)
+
+	listCmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List audit records",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			from, _ := fs.GetInt64("from")
+			limit, _ := fs.GetInt("limit")
+			params := &url.Values{}
+			params.Add("from", strconv.FormatInt(from, 10))
+			params.Add("limit", strconv.Itoa(limit))
+			var l []*node.AuditRecord
+			if _, err := client.Get(node.UrlSystem+"/audit", &l, params); err != nil {
+				return err
+			}
+			return PrintOutput(os.Stdout, l)
 		},
 	}
 	rootCmd.AddCommand(listCmd)
+	listFlags := listCmd.Flags()
+	listFlags.Int64("from", 1, "Sequence number of the first record")
+	listFlags.Int("limit", 100, "Maximum number of records")
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify hash chain of the audit log",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := new(node.AuditVerifyResult)
+			if _, err := client.Get(node.UrlSystem+"/audit/verify", v); err != nil {
+				return err
+			}
+			if err := PrintOutput(os.Stdout, v); err != nil {
+				return err
+			}
+			if v.Error != "" {
+				return errors.InvalidStateError.Errorf("AuditLogBroken(count=%d)", v.Count)
+			}
+			return nil
+		},
+	}
+	rootCmd.AddCommand(verifyCmd)
 }
 
 func NewRestoreCmd(parent *cobra.Command, client *node.UnixDomainSockHttpClient) {
@@ -614,7 +1092,7 @@ func NewRestoreCmd(parent *cobra.Command, client *node.UnixDomainSockHttpClient)
 			if err != nil {
 				return err
 			}
-			return JsonPrettyCopyAndClose(os.Stdout, resp.Body)
+			return PrintOutputAndClose(os.Stdout, resp.Body)
 		},
 	}
 	rootCmd.AddCommand(statusCmd)
@@ -675,7 +1153,7 @@ func NewUserCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command
 			if err != nil {
 				return err
 			}
-			if err = JsonPrettyPrintln(os.Stdout, l); err != nil {
+			if err = PrintOutput(os.Stdout, l); err != nil {
 				return errors.Errorf("failed JsonIntend resp=%+v, err=%+v", resp, err)
 			}
 			return nil
@@ -0,0 +1,449 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/icon-project/goloop/module"
+	v3 "github.com/icon-project/goloop/server/v3"
+)
+
+type consoleCommand struct {
+	usage  string
+	desc   string
+	handle func(c *console, args []string) error
+}
+
+var consoleCommands map[string]*consoleCommand
+
+func init() {
+	consoleCommands = map[string]*consoleCommand{
+		".help":     {"", "Show this help", (*console).cmdHelp},
+		".exit":     {"", "Exit the console", (*console).cmdExit},
+		".uri":      {"[URI]", "Show or change URI of JSON-RPC API", (*console).cmdURI},
+		".debuguri": {"[URI]", "Show or change URI of JSON-RPC Debug API", (*console).cmdDebugURI},
+		".nid":      {"[NID]", "Show or change network ID for transactions", (*console).cmdNID},
+		".wallet":   {"[KEYSTORE [SECRET]]", "Show or change wallet for transactions", (*console).cmdWallet},
+		".decode":   {"[VALUE]", "Decode HEX integer or address (or strings of the last result)", (*console).cmdDecode},
+		".encode":   {"VALUE", "Encode decimal integer or ICX (ex. 1.5icx) to HEX integer", (*console).cmdEncode},
+		".transfer": {"TO VALUE", "Transfer coin (ex. 1.5icx) to the address", (*console).cmdTransfer},
+	}
+}
+
+type console struct {
+	vc      *viper.Viper
+	out     io.Writer
+	editor  *lineEditor
+	client  *client.ClientV3
+	nid     string
+	wallet  module.Wallet
+	methods []string
+	last    interface{}
+	exit    bool
+}
+
+func NewConsoleCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command, *viper.Viper) {
+	var rpcClient client.ClientV3
+	cmd, vc := NewCommand(parentCmd, parentVc, "console", "Interactive console for JSON-RPC API")
+	cmd.PreRunE = RpcPersistentPreRunE(vc, &rpcClient)
+	cmd.Args = cobra.NoArgs
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		c := newConsole(vc, &rpcClient, os.Stdin, os.Stdout)
+		if ksf := vc.GetString("key_store"); ksf != "" {
+			if err := c.loadWallet(ksf, vc.GetString("key_secret"), vc.GetString("key_password")); err != nil {
+				return err
+			}
+		}
+		return c.Run()
+	}
+	AddRpcRequiredFlags(cmd)
+	pFlags := cmd.PersistentFlags()
+	pFlags.String("nid", "", "Network ID")
+	pFlags.String("key_store", "", "KeyStore file for wallet")
+	pFlags.String("key_secret", "", "Secret(password) file for KeyStore")
+	pFlags.String("key_password", "", "Password for the KeyStore file")
+	BindPFlags(vc, pFlags)
+	return cmd, vc
+}
+
+func newConsole(vc *viper.Viper, rpcClient *client.ClientV3, in *os.File, out io.Writer) *console {
+	methods := append(
+		v3.MethodRepository(nil).Methods(),
+		v3.DebugMethodRepository(nil).Methods()...)
+	for name := range consoleCommands {
+		methods = append(methods, name)
+	}
+	sort.Strings(methods)
+	c := &console{
+		vc:      vc,
+		out:     out,
+		client:  rpcClient,
+		nid:     vc.GetString("nid"),
+		methods: methods,
+	}
+	c.editor = newLineEditor(in, out, c.complete)
+	return c
+}
+
+func (c *console) complete(word string, first bool) []string {
+	if !first {
+		return nil
+	}
+	var candidates []string
+	for _, m := range c.methods {
+		if strings.HasPrefix(m, word) {
+			candidates = append(candidates, m)
+		}
+	}
+	return candidates
+}
+
+func (c *console) prompt() string {
+	if c.wallet != nil {
+		return fmt.Sprintf("%s> ", c.wallet.Address().String())
+	}
+	return "> "
+}
+
+func (c *console) Run() error {
+	fmt.Fprintf(c.out, "Connected to %s (.help for commands)\n", c.client.Endpoint)
+	for !c.exit {
+		line, err := c.editor.ReadLine(c.prompt())
+		if err != nil {
+			if err == errInterrupted {
+				continue
+			}
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := c.Execute(line); err != nil {
+			fmt.Fprintf(c.out, "Error: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// Execute handles a line of the console. The line is either a console
+// command starting with "." or a JSON-RPC method with its parameters.
+func (c *console) Execute(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	name := line
+	rest := ""
+	if idx := strings.IndexAny(line, " \t"); idx >= 0 {
+		name, rest = line[:idx], strings.TrimSpace(line[idx+1:])
+	}
+	if strings.HasPrefix(name, ".") {
+		cmd, ok := consoleCommands[name]
+		if !ok {
+			return errors.IllegalArgumentError.Errorf("UnknownCommand(name=%s)", name)
+		}
+		return cmd.handle(c, strings.Fields(rest))
+	}
+	params, err := parseConsoleParams(rest)
+	if err != nil {
+		return err
+	}
+	return c.call(name, params)
+}
+
+// parseConsoleParams parses parameters given as a JSON object or
+// key=value pairs. A value starting with "{" or "[" is parsed as JSON.
+func parseConsoleParams(s string) (map[string]interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+	params := make(map[string]interface{})
+	if strings.HasPrefix(s, "{") {
+		if err := json.Unmarshal([]byte(s), &params); err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidJSON(params=%s)", s)
+		}
+		return params, nil
+	}
+	for _, kv := range strings.Fields(s) {
+		idx := strings.Index(kv, "=")
+		if idx <= 0 {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidParam(param=%s)", kv)
+		}
+		k, v := kv[:idx], kv[idx+1:]
+		if strings.HasPrefix(v, "{") || strings.HasPrefix(v, "[") {
+			var jv interface{}
+			if err := json.Unmarshal([]byte(v), &jv); err != nil {
+				return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidJSON(param=%s)", kv)
+			}
+			params[k] = jv
+		} else {
+			params[k] = v
+		}
+	}
+	return params, nil
+}
+
+func (c *console) call(method string, params map[string]interface{}) error {
+	var result interface{}
+	switch {
+	case method == "icx_sendTransaction":
+		txHash, err := c.sendTransaction(params)
+		if err != nil {
+			return err
+		}
+		result = txHash
+	case strings.HasPrefix(method, "debug_"):
+		if c.client.DebugEndPoint == "" {
+			return errors.InvalidStateError.New("NoDebugURI")
+		}
+		var ptr interface{}
+		if params != nil {
+			ptr = params
+		}
+		if _, err := c.client.DoURL(c.client.DebugEndPoint, method, ptr, &result); err != nil {
+			return err
+		}
+	default:
+		var ptr interface{}
+		if params != nil {
+			ptr = params
+		}
+		if _, err := c.client.Do(method, ptr, &result); err != nil {
+			return err
+		}
+	}
+	c.last = result
+	return JsonPrettyPrintln(c.out, result)
+}
+
+// sendTransaction signs the transaction with the wallet of the console
+// filling version, from and nid if they are not specified.
+func (c *console) sendTransaction(params map[string]interface{}) (interface{}, error) {
+	if c.wallet == nil {
+		return nil, errors.InvalidStateError.New("NoWallet(use .wallet)")
+	}
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	if _, ok := params["version"]; !ok {
+		params["version"] = "0x3"
+	}
+	if _, ok := params["from"]; !ok {
+		params["from"] = c.wallet.Address().String()
+	}
+	if _, ok := params["nid"]; !ok {
+		if c.nid == "" {
+			return nil, errors.InvalidStateError.New("NoNetworkID(use .nid)")
+		}
+		params["nid"] = c.nid
+	}
+	if _, ok := params["stepLimit"]; !ok {
+		return nil, errors.IllegalArgumentError.New("NoStepLimit")
+	}
+	return c.client.SendRawTransaction(c.wallet, params)
+}
+
+func (c *console) loadWallet(ksf, ksec, kpass string) error {
+	kb, err := ioutil.ReadFile(ksf)
+	if err != nil {
+		return errors.Wrapf(err, "fail to open KeyStore file=%s", ksf)
+	}
+	var pb []byte
+	if ksec != "" {
+		if pb, err = ioutil.ReadFile(ksec); err != nil {
+			return errors.Wrapf(err, "fail to open KeySecret file=%s", ksec)
+		}
+	} else if kpass != "" {
+		pb = []byte(kpass)
+	} else {
+		pass, err := c.editor.ReadPassword("Password: ")
+		if err != nil {
+			return err
+		}
+		pb = []byte(pass)
+	}
+	w, err := wallet.NewFromKeyStore(kb, pb)
+	if err != nil {
+		return errors.Wrap(err, "fail to create wallet")
+	}
+	c.wallet = w
+	return nil
+}
+
+func (c *console) cmdHelp(args []string) error {
+	names := make([]string, 0, len(consoleCommands))
+	for name := range consoleCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(c.out, "METHOD [PARAMS]        Call JSON-RPC method with PARAMS")
+	fmt.Fprintln(c.out, "                       as JSON object or KEY=VALUE pairs")
+	for _, name := range names {
+		cmd := consoleCommands[name]
+		fmt.Fprintf(c.out, "%-22s %s\n", strings.TrimSpace(name+" "+cmd.usage), cmd.desc)
+	}
+	return nil
+}
+
+func (c *console) cmdExit(args []string) error {
+	c.exit = true
+	return nil
+}
+
+func (c *console) cmdURI(args []string) error {
+	if len(args) > 0 {
+		nc := client.NewClientV3(args[0])
+		nc.CustomHeader = c.client.CustomHeader
+		nc.Pre = c.client.Pre
+		if c.client.DebugEndPoint != "" && nc.DebugEndPoint == "" {
+			nc.DebugEndPoint = c.client.DebugEndPoint
+		}
+		c.client = nc
+	}
+	fmt.Fprintln(c.out, c.client.Endpoint)
+	return nil
+}
+
+func (c *console) cmdDebugURI(args []string) error {
+	if len(args) > 0 {
+		c.client.DebugEndPoint = args[0]
+	}
+	fmt.Fprintln(c.out, c.client.DebugEndPoint)
+	return nil
+}
+
+func (c *console) cmdNID(args []string) error {
+	if len(args) > 0 {
+		nid, err := intconv.ParseInt(args[0], 64)
+		if err != nil {
+			return errors.IllegalArgumentError.Wrapf(err, "InvalidNetworkID(nid=%s)", args[0])
+		}
+		c.nid = intconv.FormatInt(nid)
+	}
+	fmt.Fprintln(c.out, c.nid)
+	return nil
+}
+
+func (c *console) cmdWallet(args []string) error {
+	if len(args) > 0 {
+		ksec := ""
+		if len(args) > 1 {
+			ksec = args[1]
+		}
+		if err := c.loadWallet(args[0], ksec, ""); err != nil {
+			return err
+		}
+	}
+	if c.wallet != nil {
+		fmt.Fprintln(c.out, c.wallet.Address().String())
+	}
+	return nil
+}
+
+func (c *console) cmdDecode(args []string) error {
+	if len(args) > 0 {
+		for _, arg := range args {
+			if d := decodeConsoleValue(arg); d != "" {
+				fmt.Fprintf(c.out, "%s => %s\n", arg, d)
+			} else {
+				fmt.Fprintf(c.out, "%s => unknown\n", arg)
+			}
+		}
+		return nil
+	}
+	c.decodeResult("", c.last)
+	return nil
+}
+
+func (c *console) decodeResult(path string, v interface{}) {
+	switch obj := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			c.decodeResult(path+"."+k, obj[k])
+		}
+	case []interface{}:
+		for i, e := range obj {
+			c.decodeResult(fmt.Sprintf("%s[%d]", path, i), e)
+		}
+	case string:
+		if d := decodeConsoleValue(obj); d != "" {
+			if path == "" {
+				path = obj
+			}
+			fmt.Fprintf(c.out, "%s => %s\n", path, d)
+		}
+	}
+}
+
+// decodeConsoleValue returns human readable form of HEX integer or
+// address. It returns empty string for other values.
+func decodeConsoleValue(s string) string {
+	if strings.HasPrefix(s, "hx") || strings.HasPrefix(s, "cx") {
+		addr := new(common.Address)
+		if err := addr.SetStringStrict(s); err != nil {
+			return ""
+		}
+		if addr.IsContract() {
+			return "contract address"
+		}
+		return "EOA address"
+	}
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "-0x") {
+		return ""
+	}
+	v := new(big.Int)
+	if err := intconv.ParseBigInt(v, s); err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", v.String(), formatICXValue(v))
+}
+
+func (c *console) cmdEncode(args []string) error {
+	if len(args) != 1 {
+		return errors.IllegalArgumentError.New("Usage: .encode VALUE")
+	}
+	v, err := parseICXValue(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, intconv.FormatBigInt(v))
+	return nil
+}
+
+func (c *console) cmdTransfer(args []string) error {
+	if len(args) != 2 {
+		return errors.IllegalArgumentError.New("Usage: .transfer TO VALUE")
+	}
+	to := new(common.Address)
+	if err := to.SetStringStrict(args[0]); err != nil {
+		return errors.IllegalArgumentError.Wrapf(err, "InvalidAddress(to=%s)", args[0])
+	}
+	v, err := parseICXValue(args[1])
+	if err != nil {
+		return err
+	}
+	return c.call("icx_sendTransaction", map[string]interface{}{
+		"to":        to.String(),
+		"value":     intconv.FormatBigInt(v),
+		"stepLimit": intconv.FormatInt(100000),
+	})
+}
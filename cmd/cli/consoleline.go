@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+var errInterrupted = errors.New("Interrupted")
+
+const (
+	keyCtrlA     = 0x01
+	keyCtrlC     = 0x03
+	keyCtrlD     = 0x04
+	keyCtrlE     = 0x05
+	keyBackspace = 0x08
+	keyTab       = 0x09
+	keyLF        = 0x0a
+	keyCtrlK     = 0x0b
+	keyCR        = 0x0d
+	keyCtrlU     = 0x15
+	keyEscape    = 0x1b
+	keyDelete    = 0x7f
+)
+
+// CompleteFunc returns candidates for the word under the cursor.
+// first is true if the word is the first one of the line.
+type CompleteFunc func(word string, first bool) []string
+
+// lineEditor reads lines from the terminal with history and completion.
+// If the input is not a terminal, it reads lines without editing.
+type lineEditor struct {
+	fd       int
+	in       *bufio.Reader
+	out      io.Writer
+	terminal bool
+	history  []string
+	complete CompleteFunc
+}
+
+func newLineEditor(in *os.File, out io.Writer, complete CompleteFunc) *lineEditor {
+	fd := int(in.Fd())
+	return &lineEditor{
+		fd:       fd,
+		in:       bufio.NewReader(in),
+		out:      out,
+		terminal: isTerminal(fd),
+		complete: complete,
+	}
+}
+
+func (le *lineEditor) readPlainLine(prompt string) (string, error) {
+	fmt.Fprint(le.out, prompt)
+	line, err := le.in.ReadString('\n')
+	if err != nil {
+		if err == io.EOF && len(line) > 0 {
+			return strings.TrimRight(line, "\r\n"), nil
+		}
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// ReadPassword reads a line without echo.
+func (le *lineEditor) ReadPassword(prompt string) (string, error) {
+	if !le.terminal {
+		return le.readPlainLine(prompt)
+	}
+	rt, err := makeRaw(le.fd)
+	if err != nil {
+		return "", err
+	}
+	defer rt.Restore()
+
+	fmt.Fprint(le.out, prompt)
+	var line []rune
+	for {
+		r, _, err := le.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case keyCR, keyLF:
+			fmt.Fprint(le.out, "\r\n")
+			return string(line), nil
+		case keyCtrlC:
+			fmt.Fprint(le.out, "^C\r\n")
+			return "", errInterrupted
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprint(le.out, "\r\n")
+				return "", io.EOF
+			}
+		case keyBackspace, keyDelete:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+			}
+		case keyCtrlU:
+			line = line[:0]
+		default:
+			if unicode.IsPrint(r) {
+				line = append(line, r)
+			}
+		}
+	}
+}
+
+// ReadLine reads a line with editing. It returns io.EOF on Ctrl-D with
+// empty line, and errInterrupted on Ctrl-C.
+func (le *lineEditor) ReadLine(prompt string) (string, error) {
+	if !le.terminal {
+		return le.readPlainLine(prompt)
+	}
+	rt, err := makeRaw(le.fd)
+	if err != nil {
+		return "", err
+	}
+	defer rt.Restore()
+
+	var line []rune
+	pos := 0
+	hidx := len(le.history)
+	var pending []rune
+
+	refresh := func() {
+		fmt.Fprintf(le.out, "\r\x1b[K%s%s", prompt, string(line))
+		if back := len(line) - pos; back > 0 {
+			fmt.Fprintf(le.out, "\x1b[%dD", back)
+		}
+	}
+	insert := func(rs []rune) {
+		line = append(line[:pos], append(rs, line[pos:]...)...)
+		pos += len(rs)
+	}
+	setLine := func(s string) {
+		line = []rune(s)
+		pos = len(line)
+	}
+
+	refresh()
+	for {
+		r, _, err := le.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case keyCR, keyLF:
+			fmt.Fprint(le.out, "\r\n")
+			s := string(line)
+			if strings.TrimSpace(s) != "" {
+				if n := len(le.history); n == 0 || le.history[n-1] != s {
+					le.history = append(le.history, s)
+				}
+			}
+			return s, nil
+		case keyCtrlC:
+			fmt.Fprint(le.out, "^C\r\n")
+			return "", errInterrupted
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprint(le.out, "\r\n")
+				return "", io.EOF
+			}
+			if pos < len(line) {
+				line = append(line[:pos], line[pos+1:]...)
+			}
+		case keyBackspace, keyDelete:
+			if pos > 0 {
+				line = append(line[:pos-1], line[pos:]...)
+				pos--
+			}
+		case keyCtrlA:
+			pos = 0
+		case keyCtrlE:
+			pos = len(line)
+		case keyCtrlK:
+			line = line[:pos]
+		case keyCtrlU:
+			line = line[pos:]
+			pos = 0
+		case keyTab:
+			le.completeWord(line[:pos], insert)
+			refresh()
+		case keyEscape:
+			if r, _, err = le.in.ReadRune(); err != nil {
+				return "", err
+			}
+			if r != '[' && r != 'O' {
+				break
+			}
+			if r, _, err = le.in.ReadRune(); err != nil {
+				return "", err
+			}
+			switch r {
+			case 'A':
+				if hidx > 0 {
+					if hidx == len(le.history) {
+						pending = line
+					}
+					hidx--
+					setLine(le.history[hidx])
+				}
+			case 'B':
+				if hidx < len(le.history) {
+					hidx++
+					if hidx == len(le.history) {
+						setLine(string(pending))
+					} else {
+						setLine(le.history[hidx])
+					}
+				}
+			case 'C':
+				if pos < len(line) {
+					pos++
+				}
+			case 'D':
+				if pos > 0 {
+					pos--
+				}
+			case 'H':
+				pos = 0
+			case 'F':
+				pos = len(line)
+			case '3':
+				if r, _, err = le.in.ReadRune(); err != nil {
+					return "", err
+				}
+				if r == '~' && pos < len(line) {
+					line = append(line[:pos], line[pos+1:]...)
+				}
+			}
+		default:
+			if unicode.IsPrint(r) {
+				insert([]rune{r})
+			}
+		}
+		refresh()
+	}
+}
+
+func (le *lineEditor) completeWord(head []rune, insert func([]rune)) {
+	if le.complete == nil {
+		return
+	}
+	start := len(head)
+	for start > 0 && !unicode.IsSpace(head[start-1]) {
+		start--
+	}
+	word := string(head[start:])
+	first := strings.TrimSpace(string(head[:start])) == ""
+	candidates := le.complete(word, first)
+	switch len(candidates) {
+	case 0:
+		return
+	case 1:
+		insert([]rune(strings.TrimPrefix(candidates[0], word) + " "))
+		return
+	}
+	prefix := candidates[0]
+	for _, c := range candidates[1:] {
+		for !strings.HasPrefix(c, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(prefix) > len(word) {
+		insert([]rune(strings.TrimPrefix(prefix, word)))
+		return
+	}
+	fmt.Fprintf(le.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+}
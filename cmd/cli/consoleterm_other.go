@@ -0,0 +1,22 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package cli
+
+import (
+	"github.com/icon-project/goloop/common/errors"
+)
+
+type rawTerminal struct{}
+
+func isTerminal(fd int) bool {
+	return false
+}
+
+func makeRaw(fd int) (*rawTerminal, error) {
+	return nil, errors.UnsupportedError.New("RawTerminalNotSupported")
+}
+
+func (rt *rawTerminal) Restore() error {
+	return nil
+}
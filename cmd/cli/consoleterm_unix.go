@@ -0,0 +1,42 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package cli
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+type rawTerminal struct {
+	fd   int
+	orig unix.Termios
+}
+
+func isTerminal(fd int) bool {
+	_, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	return err == nil
+}
+
+func makeRaw(fd int) (*rawTerminal, error) {
+	t, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+	rt := &rawTerminal{fd: fd, orig: *t}
+
+	t.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP |
+		unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	t.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	t.Cflag &^= unix.CSIZE | unix.PARENB
+	t.Cflag |= unix.CS8
+	t.Cc[unix.VMIN] = 1
+	t.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, t); err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+func (rt *rawTerminal) Restore() error {
+	return unix.IoctlSetTermios(rt.fd, ioctlSetTermios, &rt.orig)
+}
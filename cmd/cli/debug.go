@@ -17,6 +17,7 @@
 package cli
 
 import (
+	"io/ioutil"
 	"net/http"
 	"os"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	v3 "github.com/icon-project/goloop/server/v3"
 )
@@ -63,10 +65,77 @@ func NewDebugCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			if err != nil {
 				return err
 			}
-			return JsonPrettyPrintln(os.Stdout, trace.Result)
+			return PrintOutput(os.Stdout, trace.Result)
 		},
 	}
 	rootCmd.AddCommand(traceCmd)
 
+	storageCmd := &cobra.Command{
+		Use:   "storage ADDRESS",
+		Short: "Get raw storage entries of the account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			param := &v3.ScoreStorageParam{
+				Address: jsonrpc.Address(args[0]),
+			}
+			if height, _ := fs.GetInt64("height"); height != -1 {
+				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
+			}
+			var err error
+			prefix, _ := fs.GetString("prefix")
+			if param.Prefix, err = parseHexBytes(prefix); err != nil {
+				return err
+			}
+			start, _ := fs.GetString("start")
+			if param.Start, err = parseHexBytes(start); err != nil {
+				return err
+			}
+			if limit, _ := fs.GetInt("limit"); limit > 0 {
+				param.Limit = jsonrpc.HexInt(intconv.FormatInt(int64(limit)))
+			}
+			entries, err := debugClient.Do("debug_getScoreStorage", param, nil)
+			if err != nil {
+				return err
+			}
+			return PrintOutput(os.Stdout, entries.Result)
+		},
+	}
+	rootCmd.AddCommand(storageCmd)
+	storageFlags := storageCmd.Flags()
+	storageFlags.Int64("height", -1, "BlockHeight")
+	storageFlags.String("prefix", "", "Prefix of keys in hex")
+	storageFlags.String("start", "", "Key to start from in hex, use 'next' of the previous result")
+	storageFlags.Int("limit", 0, "Max number of entries (0: uses server default value)")
+
+	inspectCmd := &cobra.Command{
+		Use:   "inspect TX",
+		Short: "Inspect the transaction in the file without sending it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.InspectTransactionParam{}
+			if raw, _ := cmd.Flags().GetBool("raw"); raw {
+				bs, err := parseHexBytes(args[0])
+				if err != nil {
+					return err
+				}
+				param.Raw = bs
+			} else {
+				bs, err := ioutil.ReadFile(args[0])
+				if err != nil {
+					return err
+				}
+				param.Transaction = bs
+			}
+			r, err := debugClient.Do("debug_inspectTransaction", param, nil)
+			if err != nil {
+				return err
+			}
+			return PrintOutput(os.Stdout, r.Result)
+		},
+	}
+	rootCmd.AddCommand(inspectCmd)
+	inspectCmd.Flags().Bool("raw", false, "TX is the serialized transaction in hex instead of the file")
+
 	return rootCmd, vc
 }
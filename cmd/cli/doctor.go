@@ -0,0 +1,507 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/icon-project/goloop/chain"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/node"
+)
+
+const (
+	DoctorStatusOK   = "OK"
+	DoctorStatusWarn = "WARN"
+	DoctorStatusFail = "FAIL"
+	DoctorStatusSkip = "SKIP"
+)
+
+const (
+	doctorMinFileLimit     = 8192
+	doctorWarnDiskSpace    = 10 << 30
+	doctorMinDiskSpace     = 1 << 30
+	doctorMaxSocketPath    = 103
+	doctorWarnTimeDrift    = 2 * time.Second
+	doctorMaxTimeDrift     = 60 * time.Second
+	doctorTimeCheckTimeout = 5 * time.Second
+)
+
+type DoctorResult struct {
+	Check   string `json:"check"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint"`
+}
+
+type doctor struct {
+	vc      *viper.Viper
+	cfg     *ServerConfig
+	results []*DoctorResult
+}
+
+func (d *doctor) report(check, status, hint, format string, args ...interface{}) {
+	d.results = append(d.results, &DoctorResult{
+		Check:   check,
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+		Hint:    hint,
+	})
+}
+
+func (d *doctor) failures() int {
+	cnt := 0
+	for _, r := range d.results {
+		if r.Status == DoctorStatusFail {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+func (d *doctor) checkConfig() bool {
+	if err := MergeWithViper(d.vc, d.cfg); err != nil {
+		d.report("config", DoctorStatusFail,
+			"fix the configuration file or the flags and environment variables",
+			"%v", err)
+		return false
+	}
+	if d.cfg.FilePath != "" {
+		d.report("config", DoctorStatusOK, "", "loaded %s", d.cfg.FilePath)
+	} else {
+		d.report("config", DoctorStatusOK, "",
+			"no configuration file, using flags and environment variables")
+	}
+	if _, err := log.ParseLevel(d.cfg.LogLevel); err != nil {
+		d.report("config", DoctorStatusFail,
+			"use one of trace,debug,info,warn,error,fatal,panic",
+			"invalid log_level=%s", d.cfg.LogLevel)
+	}
+	if _, err := log.ParseLevel(d.cfg.ConsoleLevel); err != nil {
+		d.report("config", DoctorStatusFail,
+			"use one of trace,debug,info,warn,error,fatal,panic",
+			"invalid console_level=%s", d.cfg.ConsoleLevel)
+	}
+	for _, e := range strings.Split(d.cfg.Engines, ",") {
+		switch e {
+		case "python", "java":
+		default:
+			d.report("config", DoctorStatusFail,
+				"use comma-separated execution engines (python,java)",
+				"unknown engine=%q in engines=%s", e, d.cfg.Engines)
+		}
+	}
+	return true
+}
+
+func checkFileMode(name string) (os.FileMode, error) {
+	st, err := os.Stat(name)
+	if err != nil {
+		return 0, err
+	}
+	return st.Mode().Perm(), nil
+}
+
+func (d *doctor) checkSecretFile(check, name string) {
+	if name == "" {
+		return
+	}
+	perm, err := checkFileMode(name)
+	if err != nil {
+		d.report(check, DoctorStatusFail, "check the path of the file", "%v", err)
+		return
+	}
+	if perm&0077 != 0 {
+		d.report(check, DoctorStatusWarn,
+			fmt.Sprintf("chmod 600 %s", name),
+			"%s is accessible by other users (mode=%04o)", name, perm)
+	} else {
+		d.report(check, DoctorStatusOK, "", "%s (mode=%04o)", name, perm)
+	}
+}
+
+func (d *doctor) checkKey() {
+	d.checkSecretFile("key_store", d.vc.GetString("key_store"))
+	d.checkSecretFile("key_secret", d.vc.GetString("key_secret"))
+
+	if d.cfg.KeyPlugin == "" && len(d.cfg.KeyStoreData) == 0 {
+		d.report("wallet", DoctorStatusWarn,
+			"specify --key_store or save the configuration with 'goloop server save --save_key_store'",
+			"no key store, a new key is generated on every start")
+		return
+	}
+	if err := d.cfg.MakesureWallet(false); err != nil {
+		d.report("wallet", DoctorStatusFail,
+			"check key_password or key_secret for the key store",
+			"%v", err)
+		return
+	}
+	d.report("wallet", DoctorStatusOK, "", "address=%s", d.cfg.Wallet.Address())
+}
+
+// nearestDir returns the nearest existing directory of the path,
+// because the node makes the directories on start.
+func nearestDir(p string) string {
+	for {
+		if st, err := os.Stat(p); err == nil && st.IsDir() {
+			return p
+		}
+		parent := filepath.Dir(p)
+		if parent == p {
+			return p
+		}
+		p = parent
+	}
+}
+
+func (d *doctor) checkNodeDir() string {
+	addr := d.cfg.GetAddress()
+	if d.cfg.BaseDir == "" && addr == nil {
+		d.report("node_dir", DoctorStatusFail,
+			"specify --node_dir or --key_store",
+			"unable to decide node directory")
+		return ""
+	}
+	d.cfg.FillEmpty(addr)
+	nodeDir := d.cfg.AbsBaseDir()
+	if st, err := os.Stat(nodeDir); err != nil {
+		if !os.IsNotExist(err) {
+			d.report("node_dir", DoctorStatusFail, "check the node directory", "%v", err)
+			return ""
+		}
+		d.report("node_dir", DoctorStatusOK, "",
+			"%s doesn't exist, it will be created on start", nodeDir)
+	} else if !st.IsDir() {
+		d.report("node_dir", DoctorStatusFail,
+			"remove the file or specify another --node_dir",
+			"%s is not a directory", nodeDir)
+		return ""
+	} else {
+		f, err := ioutil.TempFile(nodeDir, ".doctor")
+		if err != nil {
+			d.report("node_dir", DoctorStatusFail,
+				"make the directory writable by the user running the node",
+				"%s is not writable err=%v", nodeDir, err)
+			return ""
+		}
+		f.Close()
+		os.Remove(f.Name())
+		d.report("node_dir", DoctorStatusOK, "", "%s", nodeDir)
+	}
+
+	for _, s := range []struct {
+		name string
+		path string
+	}{
+		{"node_sock", d.cfg.ResolveAbsolute(d.cfg.CliSocket)},
+		{"ee_socket", d.cfg.ResolveAbsolute(d.cfg.EESocket)},
+	} {
+		if len(s.path) > doctorMaxSocketPath {
+			d.report(s.name, DoctorStatusFail,
+				fmt.Sprintf("specify shorter path with --%s", s.name),
+				"socket path is too long (len=%d,max=%d) path=%s",
+				len(s.path), doctorMaxSocketPath, s.path)
+			continue
+		}
+		if conn, err := net.Dial("unix", s.path); err == nil {
+			conn.Close()
+			d.report(s.name, DoctorStatusWarn,
+				"stop the running node before start",
+				"%s is in use, the node may be running", s.path)
+			continue
+		}
+		d.report(s.name, DoctorStatusOK, "", "%s", s.path)
+	}
+	return nodeDir
+}
+
+func formatBytes(v uint64) string {
+	const unit = 1024
+	if v < unit {
+		return fmt.Sprintf("%d B", v)
+	}
+	div, exp := uint64(unit), 0
+	for n := v / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(v)/float64(div), "KMGTPE"[exp])
+}
+
+func (d *doctor) checkDiskSpace(nodeDir string) {
+	dir := nearestDir(nodeDir)
+	free, err := getDiskFree(dir)
+	if err != nil {
+		d.report("disk_space", DoctorStatusSkip, "", "%v", err)
+		return
+	}
+	switch {
+	case free < doctorMinDiskSpace:
+		d.report("disk_space", DoctorStatusFail,
+			"free up the disk or specify --node_dir on another disk",
+			"%s available on %s", formatBytes(free), dir)
+	case free < doctorWarnDiskSpace:
+		d.report("disk_space", DoctorStatusWarn,
+			"prepare more disk space for the growth of the database",
+			"%s available on %s", formatBytes(free), dir)
+	default:
+		d.report("disk_space", DoctorStatusOK, "",
+			"%s available on %s", formatBytes(free), dir)
+	}
+}
+
+func (d *doctor) checkFileLimit() {
+	cur, max, err := getFileLimit()
+	if err != nil {
+		d.report("file_limit", DoctorStatusSkip, "", "%v", err)
+		return
+	}
+	if cur < doctorMinFileLimit {
+		hint := fmt.Sprintf("raise the limit with 'ulimit -n %d'", doctorMinFileLimit)
+		if max < doctorMinFileLimit {
+			hint = "raise the hard limit in /etc/security/limits.conf or the service configuration"
+		}
+		d.report("file_limit", DoctorStatusWarn, hint,
+			"open files limit is low (soft=%d,hard=%d,recommended=%d)",
+			cur, max, doctorMinFileLimit)
+		return
+	}
+	d.report("file_limit", DoctorStatusOK, "", "soft=%d,hard=%d", cur, max)
+}
+
+func (d *doctor) checkListen(check, addr string) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		d.report(check, DoctorStatusFail,
+			"stop the process using the port or specify another address",
+			"fail to listen %s err=%v", addr, err)
+		return
+	}
+	l.Close()
+	d.report(check, DoctorStatusOK, "", "%s is available", addr)
+}
+
+func (d *doctor) checkPorts() {
+	p2pListen := d.cfg.P2PListenAddr
+	if p2pListen == "" {
+		p2pListen = d.cfg.P2PAddr
+	}
+	d.checkListen("p2p_listen", p2pListen)
+	d.checkListen("rpc_addr", d.cfg.RPCAddr)
+
+	host, port, err := net.SplitHostPort(d.cfg.P2PAddr)
+	if err != nil {
+		d.report("p2p", DoctorStatusFail,
+			"use HOST:PORT for --p2p", "invalid p2p=%s err=%v", d.cfg.P2PAddr, err)
+		return
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		d.report("p2p", DoctorStatusFail,
+			"use HOST:PORT for --p2p", "invalid port of p2p=%s", d.cfg.P2PAddr)
+		return
+	}
+	if ip := net.ParseIP(host); host == "" || host == "localhost" ||
+		(ip != nil && (ip.IsLoopback() || ip.IsUnspecified())) {
+		d.report("p2p", DoctorStatusWarn,
+			"specify the address reachable from other nodes with --p2p",
+			"%s is not reachable from other nodes", d.cfg.P2PAddr)
+		return
+	}
+	d.report("p2p", DoctorStatusOK, "", "%s", d.cfg.P2PAddr)
+}
+
+func (d *doctor) checkChains(nodeDir string) {
+	fis, err := ioutil.ReadDir(nodeDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			d.report("database", DoctorStatusFail, "check the node directory", "%v", err)
+		}
+		return
+	}
+	for _, fi := range fis {
+		if !fi.IsDir() || strings.HasPrefix(fi.Name(), node.RestoreDirectoryPrefix) {
+			continue
+		}
+		d.checkChain(path.Join(nodeDir, fi.Name()))
+	}
+}
+
+func (d *doctor) checkChain(chainDir string) {
+	cfgFile := path.Join(chainDir, node.ChainConfigFileName)
+	if st, err := os.Stat(cfgFile); err != nil || !st.Mode().IsRegular() {
+		return
+	}
+	check := "chain:" + filepath.Base(chainDir)
+	bs, err := ioutil.ReadFile(cfgFile)
+	if err != nil {
+		d.report(check, DoctorStatusFail, "check the permission of the file", "%v", err)
+		return
+	}
+	cfg := &chain.Config{}
+	if err := json.Unmarshal(bs, cfg); err != nil {
+		d.report(check, DoctorStatusFail,
+			"fix or restore the chain configuration",
+			"invalid chain configuration file=%s err=%v", cfgFile, err)
+		return
+	}
+	cfg.FilePath = cfgFile
+	if cfg.SyncAnchor != nil {
+		if err := cfg.SyncAnchor.Validate(); err != nil {
+			d.report(check, DoctorStatusFail,
+				"fix sync_anchor with 'goloop chain config'", "%v", err)
+		}
+	}
+	gsFile := path.Join(chainDir, node.ChainGenesisZipFileName)
+	if _, err := os.Stat(gsFile); err != nil {
+		d.report(check, DoctorStatusFail,
+			"join the chain again with the genesis", "no genesis file=%s", gsFile)
+	}
+
+	dbType := cfg.DBType
+	if dbType == "" {
+		dbType = string(db.GoLevelDBBackend)
+	}
+	if dbType == string(db.MapDBBackend) {
+		d.report(check, DoctorStatusOK, "", "nid=%#x db_type=%s", cfg.NID, dbType)
+		return
+	}
+	supported := false
+	for _, t := range db.RegisteredBackendTypes() {
+		if t == dbType {
+			supported = true
+		}
+	}
+	if !supported {
+		d.report(check, DoctorStatusFail,
+			"use the binary built with the database backend",
+			"db_type=%s is not supported (supported=%s)",
+			dbType, strings.Join(db.RegisteredBackendTypes(), ","))
+		return
+	}
+	dbDir := path.Join(cfg.AbsBaseDir(), chain.DefaultDBDir)
+	dbName := strconv.FormatInt(int64(cfg.NID), 16)
+	if _, err := os.Stat(path.Join(dbDir, dbName)); os.IsNotExist(err) {
+		d.report(check, DoctorStatusOK, "",
+			"nid=%#x db_type=%s (database is not created yet)", cfg.NID, dbType)
+		return
+	}
+	database, err := db.Open(dbDir, dbType, dbName)
+	if err != nil {
+		d.report(check, DoctorStatusFail,
+			"stop the running node, or restore the database from the backup",
+			"fail to open database dir=%s err=%v", dbDir, err)
+		return
+	}
+	database.Close()
+	d.report(check, DoctorStatusOK, "", "nid=%#x db_type=%s", cfg.NID, dbType)
+}
+
+func (d *doctor) checkTimeDrift() {
+	server := d.vc.GetString("time_server")
+	if server == "" {
+		d.report("time_drift", DoctorStatusSkip,
+			"specify --time_server to check time drift", "no time server")
+		return
+	}
+	hc := &http.Client{Timeout: doctorTimeCheckTimeout}
+	start := time.Now()
+	resp, err := hc.Head(server)
+	if err != nil {
+		d.report("time_drift", DoctorStatusSkip,
+			"check the network or --time_server", "%v", err)
+		return
+	}
+	resp.Body.Close()
+	rtt := time.Since(start)
+	remote, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		d.report("time_drift", DoctorStatusSkip,
+			"use the server returning Date header", "invalid Date header from %s", server)
+		return
+	}
+	drift := start.Add(rtt / 2).Sub(remote)
+	if drift < 0 {
+		drift = -drift
+	}
+	// Date header has precision of a second.
+	drift = drift.Truncate(time.Second)
+	switch {
+	case drift > doctorMaxTimeDrift:
+		d.report("time_drift", DoctorStatusFail,
+			"synchronize the system clock with NTP", "drift=%s server=%s", drift, server)
+	case drift > doctorWarnTimeDrift:
+		d.report("time_drift", DoctorStatusWarn,
+			"synchronize the system clock with NTP", "drift=%s server=%s", drift, server)
+	default:
+		d.report("time_drift", DoctorStatusOK, "", "drift=%s server=%s", drift, server)
+	}
+}
+
+func (d *doctor) run() {
+	if !d.checkConfig() {
+		return
+	}
+	d.checkKey()
+	nodeDir := d.checkNodeDir()
+	if nodeDir != "" {
+		d.checkDiskSpace(nodeDir)
+	}
+	d.checkFileLimit()
+	d.checkPorts()
+	if nodeDir != "" {
+		d.checkChains(nodeDir)
+	}
+	d.checkTimeDrift()
+}
+
+func NewDoctorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command, *viper.Viper) {
+	cmd, vc := NewCommand(parentCmd, parentVc, "doctor", "Check configuration and environment before starting the node")
+	cmd.Args = cobra.NoArgs
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// it doesn't require running node
+		return nil
+	}
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		d := &doctor{
+			vc:  vc,
+			cfg: &ServerConfig{},
+		}
+		d.run()
+		if err := PrintOutput(os.Stdout, d.results); err != nil {
+			return err
+		}
+		if cnt := d.failures(); cnt > 0 {
+			return errors.InvalidStateError.Errorf("DoctorFailed(failures=%d)", cnt)
+		}
+		return nil
+	}
+	flags := cmd.Flags()
+	flags.String("p2p", "127.0.0.1:8080", "Advertise ip-port of P2P")
+	flags.String("p2p_listen", "", "Listen ip-port of P2P")
+	flags.String("rpc_addr", ":9080", "Listen ip-port of JSON-RPC")
+	flags.String("ee_socket", "", "Execution engine socket path")
+	flags.String("key_secret", "", "Secret (password) file for KeyStore")
+	flags.String("key_password", "", "Password for the KeyStore file")
+	flags.String("key_plugin", "", "KeyPlugin file for wallet")
+	flags.StringToString("key_plugin_options", nil, "KeyPlugin options")
+	flags.String("backup_dir", "", "Node backup directory (default: [node_dir]/backup")
+	flags.String("log_level", "debug", "Global log level (trace,debug,info,warn,error,fatal,panic)")
+	flags.String("console_level", "trace", "Console log level (trace,debug,info,warn,error,fatal,panic)")
+	flags.String("engines", "python", "Execution engines, comma-separated (python,java)")
+	flags.String("time_server", "", "HTTP(S) URL to compare the system clock with its Date header")
+	BindPFlags(vc, flags)
+	return cmd, vc
+}
@@ -0,0 +1,16 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package cli
+
+import (
+	"github.com/icon-project/goloop/common/errors"
+)
+
+func getFileLimit() (uint64, uint64, error) {
+	return 0, 0, errors.UnsupportedError.New("FileLimitNotSupported")
+}
+
+func getDiskFree(dir string) (uint64, error) {
+	return 0, errors.UnsupportedError.New("DiskFreeNotSupported")
+}
@@ -0,0 +1,24 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package cli
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+func getFileLimit() (uint64, uint64, error) {
+	var rl unix.Rlimit
+	if err := unix.Getrlimit(unix.RLIMIT_NOFILE, &rl); err != nil {
+		return 0, 0, err
+	}
+	return uint64(rl.Cur), uint64(rl.Max), nil
+}
+
+func getDiskFree(dir string) (uint64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return 0, err
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), nil
+}
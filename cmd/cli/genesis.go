@@ -84,6 +84,49 @@ func getFeeNames() []string {
 	return names
 }
 
+// makeGenesis returns genesis transaction for the validators. The supply is
+// given to god, and the configs are applied to the chain configuration
+// (empty value removes the default).
+func makeGenesis(god, treasury module.Address, supply *common.HexInt, validators []module.Address, configs map[string]string, feeName string) (map[string]interface{}, error) {
+	chainConfig := make(map[string]interface{})
+	if basic.LatestRevision != basic.DefaultRevision {
+		chainConfig["revision"] = &common.HexInt32{Value: basic.LatestRevision}
+	}
+	for k, v := range configs {
+		if len(v) == 0 {
+			delete(chainConfig, k)
+		} else {
+			chainConfig[k] = v
+		}
+	}
+	chainConfig["validatorList"] = validators
+
+	if info, err := getFeeInfoOf(feeName); err != nil {
+		return nil, err
+	} else {
+		if info != nil {
+			chainConfig["fee"] = info
+		}
+	}
+
+	return map[string]interface{}{
+		"accounts": []interface{}{
+			map[string]interface{}{
+				"name":    "god",
+				"address": god,
+				"balance": supply,
+			},
+			map[string]interface{}{
+				"name":    "treasury",
+				"address": treasury,
+				"balance": "0x0",
+			},
+		},
+		"chain":   chainConfig,
+		"message": fmt.Sprintf("generated %s", time.Now()),
+	}, nil
+}
+
 func newGenesisGenCmd(c string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   fmt.Sprintf("%s [address or keystore...]", c),
@@ -115,17 +158,6 @@ func newGenesisGenCmd(c string) *cobra.Command {
 			log.Panicf("Total supply value=%s is invalid", *supply)
 		}
 
-		chainConfig := make(map[string]interface{})
-		if basic.LatestRevision != basic.DefaultRevision {
-			chainConfig["revision"] = &common.HexInt32{Value: basic.LatestRevision}
-		}
-		for k, v := range *configs {
-			if len(v) == 0 {
-				delete(chainConfig, k)
-			} else {
-				chainConfig[k] = v
-			}
-		}
 		validators := make([]module.Address, len(args))
 		for i, arg := range args {
 			validators[i] = mustParseAddress(arg)
@@ -133,31 +165,10 @@ func newGenesisGenCmd(c string) *cobra.Command {
 				godAddr = validators[i]
 			}
 		}
-		chainConfig["validatorList"] = validators
-
-		if info, err := getFeeInfoOf(*feeName); err != nil {
-			log.Panicf("Fail to get fee info err=%+v", err)
-		} else {
-			if info != nil {
-				chainConfig["fee"] = info
-			}
-		}
 
-		genesis := map[string]interface{}{
-			"accounts": []interface{}{
-				map[string]interface{}{
-					"name":    "god",
-					"address": godAddr,
-					"balance": supplyValue,
-				},
-				map[string]interface{}{
-					"name":    "treasury",
-					"address": treasuryAddr,
-					"balance": "0x0",
-				},
-			},
-			"chain":   chainConfig,
-			"message": fmt.Sprintf("generated %s", time.Now()),
+		genesis, err := makeGenesis(godAddr, treasuryAddr, supplyValue, validators, *configs, *feeName)
+		if err != nil {
+			log.Panicf("Fail to make genesis err=%+v", err)
 		}
 
 		bs, err := json.MarshalIndent(genesis, "", "    ")
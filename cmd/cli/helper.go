@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"os"
 	"os/signal"
@@ -27,6 +28,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/common/log"
 )
 
@@ -755,3 +757,33 @@ func OnInterrupt(cb func()) {
 		cb()
 	}()
 }
+
+var icxUnit = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// parseICXValue parses decimal or HEX integer in loop, or ICX with "icx"
+// suffix (ex. "1.5icx").
+func parseICXValue(s string) (*big.Int, error) {
+	if strings.HasSuffix(s, "icx") {
+		r, ok := new(big.Rat).SetString(strings.TrimSuffix(s, "icx"))
+		if !ok {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidValue(value=%s)", s)
+		}
+		r.Mul(r, new(big.Rat).SetInt(icxUnit))
+		if !r.IsInt() {
+			return nil, errors.IllegalArgumentError.Errorf("TooSmallValue(value=%s)", s)
+		}
+		return r.Num(), nil
+	}
+	v := new(big.Int)
+	if err := intconv.ParseBigInt(v, s); err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidValue(value=%s)", s)
+	}
+	return v, nil
+}
+
+// formatICXValue returns the value in loop as ICX (ex. "1.5 icx").
+func formatICXValue(v *big.Int) string {
+	icx := new(big.Rat).SetFrac(v, icxUnit).FloatString(18)
+	icx = strings.TrimRight(strings.TrimRight(icx, "0"), ".")
+	return icx + " icx"
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/icon-project/goloop/icon/blockv0/lcstore"
+)
+
+const auditProgressInterval = 1000
+
+func newAuditLCStoreCmd(c string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   c + " STORE_URI",
+		Short: "Audit loopchain block store before import",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+	}
+	flags := cmd.Flags()
+	from := flags.Int64("from", 0, "Block height to start audit")
+	to := flags.Int64("to", -1, "Block height to end audit (-1 for the last block)")
+	maxRPS := flags.Int("max_rps", 0, "Maximum requests per second for the store")
+	out := flags.StringP("out", "o", "", "Output file path of the report (stdout if empty)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		lc, err := lcstore.OpenStore(args[0], *maxRPS)
+		if err != nil {
+			return err
+		}
+		defer lc.Close()
+
+		report, err := lcstore.Audit(lc, *from, *to, func(height int64) {
+			if height%auditProgressInterval == 0 {
+				fmt.Fprintf(os.Stderr, "audited height=%d\n", height)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if len(*out) > 0 {
+			if err := JsonPrettySaveFile(*out, 0644, report); err != nil {
+				return err
+			}
+		} else {
+			if err := JsonPrettyPrintln(os.Stdout, report); err != nil {
+				return err
+			}
+		}
+		if heights := report.CorruptHeights(); len(heights) > 0 {
+			return fmt.Errorf("%d corrupt or missing heights found", len(heights))
+		}
+		return nil
+	}
+	return cmd
+}
+
+func NewIconCmd(c string) *cobra.Command {
+	cmd := &cobra.Command{Use: c, Short: "ICON specific tools"}
+	cmd.AddCommand(newAuditLCStoreCmd("audit-lcstore"))
+	return cmd
+}
@@ -0,0 +1,360 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	v3 "github.com/icon-project/goloop/server/v3"
+)
+
+type iissTx struct {
+	vc        *viper.Viper
+	client    *client.ClientV3
+	wallet    module.Wallet
+	summary   io.Writer
+	nid       int64
+	stepLimit int64
+}
+
+// query calls the read-only method of the chain SCORE for the wallet.
+func (tx *iissTx) query(method string) (map[string]interface{}, error) {
+	r, err := tx.client.Call(&v3.CallParam{
+		ToAddress: jsonrpc.Address(chainScoreAddress),
+		DataType:  "call",
+		Data: map[string]interface{}{
+			"method": method,
+			"params": map[string]interface{}{
+				"address": tx.wallet.Address().String(),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := r.(map[string]interface{}); ok {
+		return m, nil
+	}
+	return nil, errors.UnknownError.Errorf("InvalidResponse(method=%s,result=%v)", method, r)
+}
+
+func (tx *iissTx) queryValue(method, key string) (*big.Int, error) {
+	m, err := tx.query(method)
+	if err != nil {
+		return nil, err
+	}
+	return iissValue(m[key])
+}
+
+func iissValue(v interface{}) (*big.Int, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errors.UnknownError.Errorf("InvalidValue(value=%v)", v)
+	}
+	value := new(big.Int)
+	if err := intconv.ParseBigInt(value, s); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// sumIISSValues returns sum of "value" fields of the list.
+func sumIISSValues(v interface{}) (*big.Int, error) {
+	sum := new(big.Int)
+	l, _ := v.([]interface{})
+	for _, e := range l {
+		m, _ := e.(map[string]interface{})
+		value, err := iissValue(m["value"])
+		if err != nil {
+			return nil, err
+		}
+		sum.Add(sum, value)
+	}
+	return sum, nil
+}
+
+// send estimates steps of the call if step_limit is not specified, then
+// signs and sends it. With estimate flag, it returns the estimated steps.
+func (tx *iissTx) send(method string, params map[string]interface{}) (interface{}, error) {
+	data := map[string]interface{}{"method": method}
+	if params != nil {
+		data["params"] = params
+	}
+	param := &v3.TransactionParam{
+		Version:     v3.VersionValue,
+		FromAddress: jsonrpc.Address(tx.wallet.Address().String()),
+		ToAddress:   jsonrpc.Address(chainScoreAddress),
+		NetworkID:   jsonrpc.HexInt(intconv.FormatInt(tx.nid)),
+		DataType:    "call",
+		Data:        data,
+	}
+	estimate := tx.vc.GetBool("estimate")
+	if estimate || tx.stepLimit == 0 {
+		step, err := tx.client.EstimateStep(&v3.TransactionParamForEstimate{
+			Version:     param.Version,
+			FromAddress: param.FromAddress,
+			ToAddress:   param.ToAddress,
+			NetworkID:   param.NetworkID,
+			DataType:    param.DataType,
+			Data:        param.Data,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if estimate {
+			return step, nil
+		}
+		fmt.Fprintf(tx.summary, "Estimated steps: %s\n", step.Int.String())
+		param.StepLimit = jsonrpc.HexInt(step.String())
+	} else {
+		param.StepLimit = jsonrpc.HexInt(intconv.FormatInt(tx.stepLimit))
+	}
+	return tx.client.SendTransaction(tx.wallet, param)
+}
+
+type iissEntry struct {
+	address *common.Address
+	value   *big.Int
+}
+
+// parseIISSEntries parses ADDRESS=VALUE arguments for delegations and
+// bonds. It returns entries and the sum of values.
+func parseIISSEntries(args []string) ([]iissEntry, *big.Int, error) {
+	entries := make([]iissEntry, 0, len(args))
+	seen := make(map[string]bool)
+	total := new(big.Int)
+	for _, arg := range args {
+		idx := strings.Index(arg, "=")
+		if idx < 0 {
+			return nil, nil, errors.IllegalArgumentError.Errorf("InvalidEntry(arg=%s)", arg)
+		}
+		addr := new(common.Address)
+		if err := addr.SetStringStrict(arg[:idx]); err != nil {
+			return nil, nil, errors.IllegalArgumentError.Wrapf(err, "InvalidAddress(arg=%s)", arg)
+		}
+		if addr.IsContract() {
+			return nil, nil, errors.IllegalArgumentError.Errorf("NotEOAAddress(arg=%s)", arg)
+		}
+		if seen[addr.String()] {
+			return nil, nil, errors.IllegalArgumentError.Errorf("DuplicateAddress(address=%s)", addr)
+		}
+		seen[addr.String()] = true
+		value, err := parseICXValue(arg[idx+1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		if value.Sign() < 0 {
+			return nil, nil, errors.IllegalArgumentError.Errorf("NegativeValue(arg=%s)", arg)
+		}
+		entries = append(entries, iissEntry{addr, value})
+		total.Add(total, value)
+	}
+	return entries, total, nil
+}
+
+func iissEntriesToJSON(entries []iissEntry) []interface{} {
+	l := make([]interface{}, len(entries))
+	for i, e := range entries {
+		l[i] = map[string]interface{}{
+			"address": e.address.String(),
+			"value":   intconv.FormatBigInt(e.value),
+		}
+	}
+	return l
+}
+
+func (tx *iissTx) printEntries(title string, entries []iissEntry, total *big.Int) {
+	fmt.Fprintf(tx.summary, "%s of %s\n", title, tx.wallet.Address())
+	for _, e := range entries {
+		fmt.Fprintf(tx.summary, "  %s %s\n", e.address, formatICXValue(e.value))
+	}
+	fmt.Fprintf(tx.summary, "  total %s\n", formatICXValue(total))
+}
+
+func (tx *iissTx) setStake(value *big.Int) (interface{}, error) {
+	if value.Sign() < 0 {
+		return nil, errors.IllegalArgumentError.Errorf("NegativeValue(value=%s)", value)
+	}
+	stake, err := tx.queryValue("getStake", "stake")
+	if err != nil {
+		return nil, err
+	}
+	delegated, err := tx.queryValue("getDelegation", "totalDelegated")
+	if err != nil {
+		return nil, err
+	}
+	bonded, err := tx.queryValue("getBond", "totalBonded")
+	if err != nil {
+		return nil, err
+	}
+	if using := new(big.Int).Add(delegated, bonded); value.Cmp(using) < 0 {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"StakeLessThanVoting(stake=%s,delegated=%s,bonded=%s)",
+			formatICXValue(value), formatICXValue(delegated), formatICXValue(bonded))
+	}
+	fmt.Fprintf(tx.summary, "Stake of %s: %s -> %s\n",
+		tx.wallet.Address(), formatICXValue(stake), formatICXValue(value))
+	return tx.send("setStake", map[string]interface{}{
+		"value": intconv.FormatBigInt(value),
+	})
+}
+
+func (tx *iissTx) setDelegation(entries []iissEntry, total *big.Int) (interface{}, error) {
+	m, err := tx.query("getDelegation")
+	if err != nil {
+		return nil, err
+	}
+	delegated, err := iissValue(m["totalDelegated"])
+	if err != nil {
+		return nil, err
+	}
+	power, err := iissValue(m["votingPower"])
+	if err != nil {
+		return nil, err
+	}
+	if available := new(big.Int).Add(delegated, power); total.Cmp(available) > 0 {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"NotEnoughVotingPower(delegation=%s,available=%s)",
+			formatICXValue(total), formatICXValue(available))
+	}
+	tx.printEntries("Delegations", entries, total)
+	return tx.send("setDelegation", map[string]interface{}{
+		"delegations": iissEntriesToJSON(entries),
+	})
+}
+
+func (tx *iissTx) setBond(entries []iissEntry, total *big.Int) (interface{}, error) {
+	m, err := tx.query("getBond")
+	if err != nil {
+		return nil, err
+	}
+	bonded, err := iissValue(m["totalBonded"])
+	if err != nil {
+		return nil, err
+	}
+	power, err := iissValue(m["votingPower"])
+	if err != nil {
+		return nil, err
+	}
+	unbonding, err := sumIISSValues(m["unbonds"])
+	if err != nil {
+		return nil, err
+	}
+	available := new(big.Int).Add(bonded, power)
+	available.Add(available, unbonding)
+	if total.Cmp(available) > 0 {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"NotEnoughVotingPower(bond=%s,available=%s)",
+			formatICXValue(total), formatICXValue(available))
+	}
+	tx.printEntries("Bonds", entries, total)
+	return tx.send("setBond", map[string]interface{}{
+		"bonds": iissEntriesToJSON(entries),
+	})
+}
+
+func (tx *iissTx) claimIScore() (interface{}, error) {
+	m, err := tx.query("queryIScore")
+	if err != nil {
+		return nil, err
+	}
+	if icx, err := iissValue(m["estimatedICX"]); err == nil {
+		fmt.Fprintf(tx.summary, "Claim IScore of %s: %s\n",
+			tx.wallet.Address(), formatICXValue(icx))
+	}
+	return tx.send("claimIScore", nil)
+}
+
+func NewIISSCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command, *viper.Viper) {
+	var rpcClient client.ClientV3
+	tx := &iissTx{client: &rpcClient, summary: os.Stderr}
+	rootCmd, vc := NewCommand(parentCmd, parentVc, "iiss", "Send IISS transactions to the chain SCORE")
+	tx.vc = vc
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := RpcPersistentPreRunE(vc, &rpcClient)(cmd, args); err != nil {
+			return err
+		}
+		nid, err := intconv.ParseInt(vc.GetString("nid"), 64)
+		if err != nil {
+			return errors.IllegalArgumentError.Wrapf(err, "InvalidNetworkID(nid=%s)", vc.GetString("nid"))
+		}
+		tx.nid = nid
+		tx.stepLimit = vc.GetInt64("step_limit")
+		tx.wallet, err = NewWalletFromViper(vc)
+		return err
+	}
+	AddRpcRequiredFlags(rootCmd)
+	rootPFlags := rootCmd.PersistentFlags()
+	rootPFlags.String("key_store", "", "KeyStore file for wallet")
+	rootPFlags.String("key_secret", "", "Secret(password) file for KeyStore")
+	rootPFlags.String("key_password", "", "Password for the KeyStore file")
+	rootPFlags.String("nid", "", "Network ID")
+	rootPFlags.Int64("step_limit", 0, "StepLimit (estimated if it's not specified)")
+	rootPFlags.Bool("estimate", false, "Just estimate steps for the tx")
+	MarkAnnotationCustom(rootPFlags, "key_store", "nid")
+	BindPFlags(vc, rootCmd.PersistentFlags())
+
+	printResult := func(r interface{}, err error) error {
+		if err != nil {
+			return err
+		}
+		return PrintOutput(os.Stdout, r)
+	}
+
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "setStake VALUE",
+		Short: "Set stake (VALUE in loop, or in ICX with 'icx' suffix)",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := parseICXValue(args[0])
+			if err != nil {
+				return err
+			}
+			return printResult(tx.setStake(value))
+		},
+	})
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "setDelegation [ADDRESS=VALUE...]",
+		Short: "Set delegations (all delegations are removed without arguments)",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ArbitraryArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, total, err := parseIISSEntries(args)
+			if err != nil {
+				return err
+			}
+			return printResult(tx.setDelegation(entries, total))
+		},
+	})
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "setBond [ADDRESS=VALUE...]",
+		Short: "Set bonds (all bonds are removed without arguments)",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ArbitraryArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, total, err := parseIISSEntries(args)
+			if err != nil {
+				return err
+			}
+			return printResult(tx.setBond(entries, total))
+		},
+	})
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "claimIScore",
+		Short: "Claim IScore",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printResult(tx.claimIScore())
+		},
+	})
+	return rootCmd, vc
+}
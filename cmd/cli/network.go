@@ -0,0 +1,440 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/icon-project/goloop/chain"
+	"github.com/icon-project/goloop/chain/gs"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/node"
+)
+
+const (
+	networkGenesisFile      = "genesis.json"
+	networkGenesisStorage   = "gs.zip"
+	networkGodKeyStore      = "god_keystore.json"
+	networkGodKeySecret     = "god_keysecret"
+	networkServerConfigFile = "server.json"
+	networkKeyStoreFile     = "keystore.json"
+	networkKeySecretFile    = "keysecret"
+	networkNodeDataDir      = "data"
+	networkComposeFile      = "docker-compose.yml"
+	networkMaxNodes         = 100
+
+	// paths and ports in the container of goloop docker image
+	networkDockerConfigDir = "/goloop/config"
+	networkDockerP2PPort   = 8080
+	networkDockerRPCPort   = 9080
+)
+
+type NetworkNode struct {
+	Name      string         `json:"name"`
+	Address   module.Address `json:"address"`
+	P2P       string         `json:"p2p"`
+	RPC       string         `json:"rpc"`
+	Config    string         `json:"config"`
+	KeyStore  string         `json:"keyStore"`
+	KeySecret string         `json:"keySecret"`
+
+	dir string
+}
+
+type NetworkInfo struct {
+	NID          common.HexInt32 `json:"nid"`
+	CID          common.HexInt32 `json:"cid"`
+	Channel      string          `json:"channel"`
+	God          module.Address  `json:"god"`
+	GodKeyStore  string          `json:"godKeyStore"`
+	GodKeySecret string          `json:"godKeySecret"`
+	Genesis      string          `json:"genesis"`
+	Nodes        []*NetworkNode  `json:"nodes"`
+	Compose      string          `json:"compose,omitempty"`
+}
+
+func NewNetworkCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command, *viper.Viper) {
+	rootCmd, vc := NewCommand(parentCmd, parentVc, "network", "Local network management")
+	newNetworkBootstrapCmd(rootCmd, vc)
+	return rootCmd, vc
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// saveNewKeyStore generates a keystore with the password, or with random
+// password if it's empty, then saves the keystore and the password.
+func saveNewKeyStore(ksFile, secretFile, password string) (module.Wallet, error) {
+	if password == "" {
+		var err error
+		if password, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+	w := wallet.New()
+	ks, err := wallet.KeyStoreFromWallet(w, []byte(password))
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to make keystore")
+	}
+	if err := ioutil.WriteFile(ksFile, ks, 0600); err != nil {
+		return nil, errors.Wrapf(err, "fail to write keystore file=%s", ksFile)
+	}
+	if err := ioutil.WriteFile(secretFile, []byte(password), 0600); err != nil {
+		return nil, errors.Wrapf(err, "fail to write secret file=%s", secretFile)
+	}
+	return w, nil
+}
+
+func checkEmptyDirectory(dir string) error {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if len(fis) > 0 {
+		return errors.InvalidStateError.Errorf("NotEmptyDirectory(dir=%s)", dir)
+	}
+	return nil
+}
+
+type networkBootstrap struct {
+	vc      *viper.Viper
+	outDir  string
+	nodes   int
+	docker  bool
+	prefix  string
+	host    string
+	p2pPort int
+	rpcPort int
+}
+
+func (b *networkBootstrap) relPath(p string) string {
+	if r, err := filepath.Rel(b.outDir, p); err == nil {
+		return r
+	}
+	return p
+}
+
+func (b *networkBootstrap) makeNodes() ([]*NetworkNode, error) {
+	nodes := make([]*NetworkNode, b.nodes)
+	for i := range nodes {
+		n := &NetworkNode{
+			Name: fmt.Sprintf("%s%d", b.prefix, i),
+		}
+		n.dir = filepath.Join(b.outDir, n.Name)
+		if err := os.MkdirAll(filepath.Join(n.dir, networkNodeDataDir), 0700); err != nil {
+			return nil, errors.Wrapf(err, "fail to make directory dir=%s", n.dir)
+		}
+		ksFile := filepath.Join(n.dir, networkKeyStoreFile)
+		secretFile := filepath.Join(n.dir, networkKeySecretFile)
+		w, err := saveNewKeyStore(ksFile, secretFile, b.vc.GetString("key_password"))
+		if err != nil {
+			return nil, err
+		}
+		n.Address = w.Address()
+		n.KeyStore = b.relPath(ksFile)
+		n.KeySecret = b.relPath(secretFile)
+		n.Config = b.relPath(filepath.Join(n.dir, networkServerConfigFile))
+		if b.docker {
+			n.P2P = net.JoinHostPort(n.Name, strconv.Itoa(networkDockerP2PPort))
+			n.RPC = net.JoinHostPort("localhost", strconv.Itoa(b.rpcPort+i))
+		} else {
+			n.P2P = net.JoinHostPort(b.host, strconv.Itoa(b.p2pPort+i))
+			n.RPC = net.JoinHostPort(b.host, strconv.Itoa(b.rpcPort+i))
+		}
+		nodes[i] = n
+	}
+	return nodes, nil
+}
+
+func (b *networkBootstrap) makeGenesisStorage(info *NetworkInfo) ([]byte, error) {
+	godKS := filepath.Join(b.outDir, networkGodKeyStore)
+	godSecret := filepath.Join(b.outDir, networkGodKeySecret)
+	god, err := saveNewKeyStore(godKS, godSecret, b.vc.GetString("key_password"))
+	if err != nil {
+		return nil, err
+	}
+	info.God = god.Address()
+	info.GodKeyStore = b.relPath(godKS)
+	info.GodKeySecret = b.relPath(godSecret)
+
+	treasury := new(common.Address)
+	if err := treasury.SetStringStrict(b.vc.GetString("treasury")); err != nil || treasury.IsContract() {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidTreasury(treasury=%s)", b.vc.GetString("treasury"))
+	}
+	supply := new(common.HexInt)
+	if _, ok := supply.SetString(b.vc.GetString("supply"), 0); !ok {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidSupply(supply=%s)", b.vc.GetString("supply"))
+	}
+	validators := make([]module.Address, len(info.Nodes))
+	for i, n := range info.Nodes {
+		validators[i] = n.Address
+	}
+	genesis, err := makeGenesis(god.Address(), treasury, supply, validators,
+		b.vc.GetStringMapString("config"), b.vc.GetString("fee"))
+	if err != nil {
+		return nil, err
+	}
+	if s := b.vc.GetString("nid"); s != "" {
+		nid, err := intconv.ParseInt(s, 32)
+		if err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidNetworkID(nid=%s)", s)
+		}
+		genesis["nid"] = intconv.FormatInt(nid)
+	}
+	genesisFile := filepath.Join(b.outDir, networkGenesisFile)
+	if err := JsonPrettySaveFile(genesisFile, 0644, genesis); err != nil {
+		return nil, err
+	}
+	info.Genesis = b.relPath(filepath.Join(b.outDir, networkGenesisStorage))
+
+	buf := bytes.NewBuffer(nil)
+	if err := gs.WriteFromPath(buf, genesisFile); err != nil {
+		return nil, errors.Wrapf(err, "fail to make genesis storage")
+	}
+	gsBytes := buf.Bytes()
+	gst, err := gs.New(gsBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to parse genesis storage")
+	}
+	cid, err := gst.CID()
+	if err != nil {
+		return nil, err
+	}
+	nid, err := gst.NID()
+	if err != nil {
+		return nil, err
+	}
+	info.CID.Value = int32(cid)
+	info.NID.Value = int32(nid)
+	info.Channel = chain.GetChannel(b.vc.GetString("channel"), nid)
+	if err := ioutil.WriteFile(filepath.Join(b.outDir, networkGenesisStorage), gsBytes, 0644); err != nil {
+		return nil, err
+	}
+	return gsBytes, nil
+}
+
+func (b *networkBootstrap) saveNodeConfig(n *NetworkNode, info *NetworkInfo, genesis []byte) error {
+	cfg := &ServerConfig{}
+	cfg.P2PAddr = n.P2P
+	if b.docker {
+		cfg.P2PListenAddr = fmt.Sprintf(":%d", networkDockerP2PPort)
+		cfg.RPCAddr = fmt.Sprintf(":%d", networkDockerRPCPort)
+	} else {
+		cfg.RPCAddr = n.RPC
+	}
+	cfg.Engines = b.vc.GetString("engines")
+	cfg.LogLevel = b.vc.GetString("log_level")
+	cfg.ConsoleLevel = b.vc.GetString("console_level")
+	cfg.BaseDir = filepath.Join(n.dir, networkNodeDataDir)
+	ks, err := ioutil.ReadFile(filepath.Join(n.dir, networkKeyStoreFile))
+	if err != nil {
+		return err
+	}
+	cfg.KeyStoreData = ks
+	cfgFile := filepath.Join(n.dir, networkServerConfigFile)
+	cfg.SetFilePath(cfgFile)
+	if err := JsonPrettySaveFile(cfgFile, 0644, cfg); err != nil {
+		return err
+	}
+
+	var seeds []string
+	for _, o := range info.Nodes {
+		if o != n {
+			seeds = append(seeds, o.P2P)
+		}
+	}
+	chainDir := filepath.Join(n.dir, networkNodeDataDir,
+		strconv.FormatInt(int64(info.CID.Value), 16))
+	if err := os.MkdirAll(chainDir, 0700); err != nil {
+		return errors.Wrapf(err, "fail to make directory dir=%s", chainDir)
+	}
+	chainCfg := &chain.Config{
+		NID:            int(info.NID.Value),
+		DBType:         b.vc.GetString("db_type"),
+		Platform:       b.vc.GetString("platform"),
+		Channel:        info.Channel,
+		SeedAddr:       strings.Join(seeds, ","),
+		Role:           3,
+		AutoStart:      true,
+		DefWaitTimeout: b.vc.GetInt64("default_wait_timeout"),
+		FilePath:       filepath.Join(chainDir, node.ChainConfigFileName),
+	}
+	if err := chainCfg.Save(); err != nil {
+		return errors.Wrapf(err, "fail to save chain configuration file=%s", chainCfg.FilePath)
+	}
+	gsFile := filepath.Join(chainDir, node.ChainGenesisZipFileName)
+	if err := ioutil.WriteFile(gsFile, genesis, 0644); err != nil {
+		return errors.Wrapf(err, "fail to write genesis file=%s", gsFile)
+	}
+	return nil
+}
+
+func (b *networkBootstrap) saveCompose(info *NetworkInfo) error {
+	services := make(map[string]interface{})
+	configDir := networkDockerConfigDir
+	for i, n := range info.Nodes {
+		services[n.Name] = map[string]interface{}{
+			"image":          b.vc.GetString("docker_image"),
+			"container_name": n.Name,
+			"hostname":       n.Name,
+			"environment": map[string]string{
+				"GOLOOP_CONFIG":     configDir + "/" + networkServerConfigFile,
+				"GOLOOP_KEY_STORE":  configDir + "/" + networkKeyStoreFile,
+				"GOLOOP_KEY_SECRET": configDir + "/" + networkKeySecretFile,
+				"GOLOOP_NODE_DIR":   configDir + "/" + networkNodeDataDir,
+				"GOLOOP_P2P":        n.P2P,
+			},
+			"volumes": []string{
+				"./" + n.Name + ":" + configDir,
+			},
+			"ports": []string{
+				fmt.Sprintf("%d:%d", b.rpcPort+i, networkDockerRPCPort),
+			},
+		}
+	}
+	compose := map[string]interface{}{
+		"version":  "3",
+		"services": services,
+	}
+	bs, err := yaml.Marshal(compose)
+	if err != nil {
+		return errors.Wrapf(err, "fail to marshal docker-compose")
+	}
+	composeFile := filepath.Join(b.outDir, networkComposeFile)
+	if err := ioutil.WriteFile(composeFile, bs, 0644); err != nil {
+		return errors.Wrapf(err, "fail to write file=%s", composeFile)
+	}
+	info.Compose = b.relPath(composeFile)
+	return nil
+}
+
+func (b *networkBootstrap) run() (*NetworkInfo, error) {
+	if err := checkEmptyDirectory(b.outDir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(b.outDir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "fail to make directory dir=%s", b.outDir)
+	}
+	info := &NetworkInfo{}
+	nodes, err := b.makeNodes()
+	if err != nil {
+		return nil, err
+	}
+	info.Nodes = nodes
+	genesis, err := b.makeGenesisStorage(info)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range info.Nodes {
+		if err := b.saveNodeConfig(n, info, genesis); err != nil {
+			return nil, err
+		}
+	}
+	if b.docker {
+		if err := b.saveCompose(info); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+func newNetworkBootstrapCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Command {
+	cmd, vc := NewCommand(parentCmd, parentVc, "bootstrap", "Generate wallets, genesis and configurations for local network")
+	cmd.Args = cobra.NoArgs
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		outDir, err := filepath.Abs(vc.GetString("out"))
+		if err != nil {
+			return err
+		}
+		b := &networkBootstrap{
+			vc:      vc,
+			outDir:  outDir,
+			nodes:   vc.GetInt("nodes"),
+			docker:  vc.GetBool("docker"),
+			prefix:  vc.GetString("prefix"),
+			host:    vc.GetString("host"),
+			p2pPort: vc.GetInt("p2p_port"),
+			rpcPort: vc.GetInt("rpc_port"),
+		}
+		if b.nodes < 1 || b.nodes > networkMaxNodes {
+			return errors.IllegalArgumentError.Errorf(
+				"InvalidNumberOfNodes(nodes=%d,max=%d)", b.nodes, networkMaxNodes)
+		}
+		if b.p2pPort < 1 || b.rpcPort < 1 || b.p2pPort+b.nodes > 65536 || b.rpcPort+b.nodes > 65536 {
+			return errors.IllegalArgumentError.Errorf(
+				"InvalidPorts(p2p_port=%d,rpc_port=%d)", b.p2pPort, b.rpcPort)
+		}
+		if !b.docker && b.p2pPort < b.rpcPort+b.nodes && b.rpcPort < b.p2pPort+b.nodes {
+			return errors.IllegalArgumentError.Errorf(
+				"OverlappedPorts(p2p_port=%d,rpc_port=%d,nodes=%d)", b.p2pPort, b.rpcPort, b.nodes)
+		}
+		if dbType := vc.GetString("db_type"); dbType == string(db.MapDBBackend) {
+			return errors.IllegalArgumentError.Errorf("InvalidDBType(db_type=%s)", dbType)
+		}
+		info, err := b.run()
+		if err != nil {
+			return err
+		}
+		if err := PrintOutput(os.Stdout, info); err != nil {
+			return err
+		}
+		if b.docker {
+			fmt.Fprintf(os.Stderr, "Start the network with 'docker-compose -f %s up'\n",
+				filepath.Join(outDir, networkComposeFile))
+		} else {
+			fmt.Fprintf(os.Stderr, "Start each node with 'goloop server -c %s --key_secret %s start'\n",
+				filepath.Join(outDir, "NODE", networkServerConfigFile),
+				filepath.Join(outDir, "NODE", networkKeySecretFile))
+		}
+		return nil
+	}
+	flags := cmd.Flags()
+	flags.IntP("nodes", "n", 4, "Number of validator nodes")
+	flags.StringP("out", "o", "network", "Output directory (it should be empty)")
+	flags.String("prefix", "node", "Prefix of the name of nodes")
+	flags.String("host", "127.0.0.1", "Host of the nodes")
+	flags.Int("p2p_port", 8080, "P2P port of the first node (increased for others)")
+	flags.Int("rpc_port", 9080, "JSON-RPC port of the first node (increased for others)")
+	flags.String("key_password", "", "Password for keystores (random for each keystore if it's omitted)")
+	flags.String("nid", "", "Network ID (derived from genesis if it's omitted)")
+	flags.String("channel", "", "Channel (default: [NID])")
+	flags.String("platform", "", "Name of service platform")
+	flags.String("db_type", string(db.GoLevelDBBackend), "Name of database system")
+	flags.Int64("default_wait_timeout", 0, "Default wait timeout in milli-second (0: disable)")
+	flags.String("supply", "0x2961fff8ca4a62327800000", "Total supply of the chain")
+	flags.String("treasury", "hx1000000000000000000000000000000000000000", "Treasury address")
+	flags.StringToString("config", nil, "Chain configuration of the genesis")
+	flags.String("fee", "none",
+		fmt.Sprintf("Fee configuration (%s)", strings.Join(getFeeNames(), ",")))
+	flags.String("engines", "python", "Execution engines, comma-separated (python,java)")
+	flags.String("log_level", "debug", "Global log level (trace,debug,info,warn,error,fatal,panic)")
+	flags.String("console_level", "trace", "Console log level (trace,debug,info,warn,error,fatal,panic)")
+	flags.Bool("docker", false, "Generate docker-compose.yml for the network")
+	flags.String("docker_image", "goloop:latest", "Docker image for docker-compose.yml")
+	BindPFlags(vc, flags)
+	return cmd
+}
@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const (
+	OutputFormatJSON  = "json"
+	OutputFormatYAML  = "yaml"
+	OutputFormatTable = "table"
+)
+
+var outputVc *viper.Viper
+
+// AddOutputFlags adds --output and --query flags to the command, which are
+// applied to the result printed by PrintOutput.
+func AddOutputFlags(c *cobra.Command, vc *viper.Viper) {
+	pFlags := c.PersistentFlags()
+	pFlags.String("output", OutputFormatJSON, "Output format (json, yaml or table)")
+	pFlags.String("query", "", "Query to select values of the output (ex. 'height', 'list[0].txHash', 'list[*].txHash')")
+	BindPFlags(vc, pFlags)
+	outputVc = vc
+}
+
+func outputOptions() (string, string) {
+	if outputVc == nil {
+		return OutputFormatJSON, ""
+	}
+	return outputVc.GetString("output"), outputVc.GetString("query")
+}
+
+// PrintOutput writes v in the format given by --output after applying the
+// query given by --query.
+func PrintOutput(w io.Writer, v interface{}) error {
+	format, query := outputOptions()
+	if format == OutputFormatJSON && query == "" {
+		return JsonPrettyPrintln(w, v)
+	}
+	obj, err := toGenericValue(v)
+	if err != nil {
+		return err
+	}
+	if query != "" {
+		if obj, err = QueryValue(obj, query); err != nil {
+			return err
+		}
+	}
+	switch format {
+	case OutputFormatJSON:
+		return JsonPrettyPrintln(w, obj)
+	case OutputFormatYAML:
+		bs, err := yaml.Marshal(toYAMLValue(obj))
+		if err != nil {
+			return errors.Wrapf(err, "fail to marshal yaml v=%+v", obj)
+		}
+		_, err = w.Write(bs)
+		return err
+	case OutputFormatTable:
+		_, err := fmt.Fprintln(w, toTable(obj))
+		return err
+	default:
+		return errors.IllegalArgumentError.Errorf("InvalidOutputFormat(format=%s)", format)
+	}
+}
+
+// PrintOutputAndClose writes JSON from the reader like PrintOutput.
+func PrintOutputAndClose(w io.Writer, r io.ReadCloser) error {
+	defer r.Close()
+	bs, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return PrintOutput(w, json.RawMessage(bs))
+}
+
+func toGenericValue(v interface{}) (interface{}, error) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to marshal v=%+v", v)
+	}
+	var obj interface{}
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, errors.Wrapf(err, "fail to decode json=%s", bs)
+	}
+	return obj, nil
+}
+
+func toYAMLValue(v interface{}) interface{} {
+	switch obj := v.(type) {
+	case json.Number:
+		if i, err := obj.Int64(); err == nil {
+			return i
+		}
+		if f, err := obj.Float64(); err == nil {
+			return f
+		}
+		return obj.String()
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(obj))
+		for k, e := range obj {
+			m[k] = toYAMLValue(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(obj))
+		for i, e := range obj {
+			l[i] = toYAMLValue(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+func tableCell(v interface{}) string {
+	switch obj := v.(type) {
+	case nil:
+		return TableCellDisplayNil
+	case string:
+		return obj
+	case json.Number:
+		return obj.String()
+	case bool:
+		return strconv.FormatBool(obj)
+	default:
+		bs, _ := json.Marshal(obj)
+		return string(bs)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toTable makes a table with columns of keys for a list of objects,
+// with KEY and VALUE columns for an object, and with a VALUE column
+// for others.
+func toTable(v interface{}) *uitable.Table {
+	table := uitable.New()
+	switch obj := v.(type) {
+	case []interface{}:
+		var columns []string
+		seen := make(map[string]bool)
+		for _, e := range obj {
+			if m, ok := e.(map[string]interface{}); ok {
+				for _, k := range sortedKeys(m) {
+					if !seen[k] {
+						seen[k] = true
+						columns = append(columns, k)
+					}
+				}
+			}
+		}
+		if len(columns) == 0 {
+			table.AddRow("VALUE")
+			for _, e := range obj {
+				table.AddRow(tableCell(e))
+			}
+			return table
+		}
+		header := make([]interface{}, len(columns))
+		for i, k := range columns {
+			header[i] = strings.ToUpper(k)
+		}
+		table.AddRow(header...)
+		for _, e := range obj {
+			m, _ := e.(map[string]interface{})
+			row := make([]interface{}, len(columns))
+			for i, k := range columns {
+				row[i] = tableCell(m[k])
+			}
+			table.AddRow(row...)
+		}
+	case map[string]interface{}:
+		table.AddRow("KEY", "VALUE")
+		for _, k := range sortedKeys(obj) {
+			table.AddRow(k, tableCell(obj[k]))
+		}
+	default:
+		table.AddRow("VALUE")
+		table.AddRow(tableCell(v))
+	}
+	return table
+}
+
+type queryStep struct {
+	field   string
+	index   int
+	kind    byte
+	project bool
+}
+
+const (
+	queryField = 'f'
+	queryIndex = 'i'
+)
+
+func parseQuery(q string) ([]queryStep, error) {
+	var steps []queryStep
+	s := q
+	for len(s) > 0 {
+		switch {
+		case s[0] == '.':
+			if len(steps) == 0 {
+				return nil, errors.IllegalArgumentError.Errorf("InvalidQuery(query=%s)", q)
+			}
+			s = s[1:]
+			if len(s) == 0 || s[0] == '.' || s[0] == '[' {
+				return nil, errors.IllegalArgumentError.Errorf("InvalidQuery(query=%s)", q)
+			}
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, errors.IllegalArgumentError.Errorf("InvalidQuery(query=%s)", q)
+			}
+			idx := strings.TrimSpace(s[1:end])
+			if idx == "*" {
+				steps = append(steps, queryStep{kind: queryIndex, project: true})
+			} else {
+				i, err := strconv.Atoi(idx)
+				if err != nil {
+					return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidQuery(query=%s)", q)
+				}
+				steps = append(steps, queryStep{kind: queryIndex, index: i})
+			}
+			s = s[end+1:]
+		case s[0] == '"':
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				return nil, errors.IllegalArgumentError.Errorf("InvalidQuery(query=%s)", q)
+			}
+			steps = append(steps, queryStep{kind: queryField, field: s[1 : end+1]})
+			s = s[end+2:]
+		default:
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			field := strings.TrimSpace(s[:end])
+			if field == "*" {
+				steps = append(steps, queryStep{kind: queryField, project: true})
+			} else {
+				steps = append(steps, queryStep{kind: queryField, field: field})
+			}
+			s = s[end:]
+		}
+	}
+	return steps, nil
+}
+
+func applyQuery(v interface{}, steps []queryStep) interface{} {
+	for i, step := range steps {
+		if v == nil {
+			return nil
+		}
+		if step.project {
+			var values []interface{}
+			switch obj := v.(type) {
+			case []interface{}:
+				if step.kind != queryIndex {
+					return nil
+				}
+				values = obj
+			case map[string]interface{}:
+				if step.kind != queryField {
+					return nil
+				}
+				for _, k := range sortedKeys(obj) {
+					values = append(values, obj[k])
+				}
+			default:
+				return nil
+			}
+			result := make([]interface{}, 0, len(values))
+			for _, e := range values {
+				if r := applyQuery(e, steps[i+1:]); r != nil {
+					result = append(result, r)
+				}
+			}
+			return result
+		}
+		switch step.kind {
+		case queryField:
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			v = m[step.field]
+		case queryIndex:
+			l, ok := v.([]interface{})
+			if !ok {
+				return nil
+			}
+			idx := step.index
+			if idx < 0 {
+				idx += len(l)
+			}
+			if idx < 0 || idx >= len(l) {
+				return nil
+			}
+			v = l[idx]
+		}
+	}
+	return v
+}
+
+// QueryValue selects values from the value decoded from JSON with the
+// query. The query is a subset of JMESPath, which supports fields
+// (ex. "a.b" or "\"a-b\""), indexes (ex. "a[0]" or "a[-1]") and
+// projections (ex. "a[*].b" or "a.*.b").
+func QueryValue(v interface{}, query string) (interface{}, error) {
+	steps, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return applyQuery(v, steps), nil
+}
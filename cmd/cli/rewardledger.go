@@ -0,0 +1,179 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	v3 "github.com/icon-project/goloop/server/v3"
+)
+
+const chainScoreAddress = "cx0000000000000000000000000000000000000000"
+
+var rewardLedgerColumns = []string{
+	"address", "startBlockHeight", "blockProduce", "voted",
+	"delegating", "bonding", "iscore", "estimatedICX",
+}
+
+type rewardLedgerFetcher func(offset int64) (map[string]interface{}, error)
+
+func newRewardLedgerCmd(rpcClient *client.ClientV3) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rewardledger [START_HEIGHT]",
+		Short: "Export rewards of all addresses for the term (the latest term if START_HEIGHT is omitted)",
+		Args:  ArgsWithDefaultErrorFunc(cobra.RangeArgs(0, 1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := make(map[string]interface{})
+			if len(args) > 0 {
+				startHeight, err := newHexIntByString(args[0])
+				if err != nil {
+					return err
+				}
+				params["startBlockHeight"] = startHeight
+			}
+			param := &v3.CallParam{
+				ToAddress: jsonrpc.Address(chainScoreAddress),
+				DataType:  "call",
+			}
+			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			if err != nil {
+				return err
+			}
+			if height != -1 {
+				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
+			}
+			fetch := func(offset int64) (map[string]interface{}, error) {
+				params["offset"] = jsonrpc.HexInt(intconv.FormatInt(offset))
+				param.Data = map[string]interface{}{
+					"method": "getRewardLedger",
+					"params": params,
+				}
+				r, err := rpcClient.Call(param)
+				if err != nil {
+					return nil, err
+				}
+				if page, ok := r.(map[string]interface{}); ok {
+					return page, nil
+				}
+				return nil, errors.Errorf("InvalidResult(result=%v)", r)
+			}
+
+			w := io.Writer(os.Stdout)
+			if output := cmd.Flag("output").Value.String(); output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			return exportRewardLedger(w, cmd.Flag("format").Value.String(), fetch)
+		},
+	}
+	flags := cmd.Flags()
+	flags.Int("height", -1, "BlockHeight")
+	flags.String("format", "json", "Output format, json(JSON lines) or csv")
+	flags.String("output", "", "Output file, stdout if it's omitted")
+	return cmd
+}
+
+// exportRewardLedger writes all entries of the ledger fetching them page by
+// page. Amounts are written in decimal for csv.
+func exportRewardLedger(w io.Writer, format string, fetch rewardLedgerFetcher) error {
+	var write func(entry map[string]interface{}) error
+	var cw *csv.Writer
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		write = func(entry map[string]interface{}) error {
+			return enc.Encode(entry)
+		}
+	case "csv":
+		cw = csv.NewWriter(w)
+		if err := cw.Write(rewardLedgerColumns); err != nil {
+			return err
+		}
+		write = func(entry map[string]interface{}) error {
+			return cw.Write(rewardLedgerRecord(entry))
+		}
+	default:
+		return errors.IllegalArgumentError.Errorf("InvalidFormat(format=%s)", format)
+	}
+
+	for offset := int64(0); ; {
+		page, err := fetch(offset)
+		if err != nil {
+			return err
+		}
+		entries, _ := page["entries"].([]interface{})
+		for _, e := range entries {
+			entry, ok := e.(map[string]interface{})
+			if !ok {
+				return errors.Errorf("InvalidEntry(entry=%v)", e)
+			}
+			if err = write(entry); err != nil {
+				return err
+			}
+		}
+		offset += int64(len(entries))
+		total, err := rewardLedgerInt64(page["total"])
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 || offset >= total {
+			break
+		}
+	}
+	if cw != nil {
+		cw.Flush()
+		return cw.Error()
+	}
+	return nil
+}
+
+func rewardLedgerRecord(entry map[string]interface{}) []string {
+	record := make([]string, len(rewardLedgerColumns))
+	for i, col := range rewardLedgerColumns {
+		s, _ := entry[col].(string)
+		if col != "address" {
+			var v big.Int
+			if err := intconv.ParseBigInt(&v, s); err == nil {
+				s = v.String()
+			}
+		}
+		record[i] = s
+	}
+	return record
+}
+
+func rewardLedgerInt64(v interface{}) (int64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, errors.Errorf("InvalidValue(value=%v)", v)
+	}
+	return intconv.ParseInt(s, 64)
+}
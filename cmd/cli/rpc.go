@@ -124,6 +124,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 	BindPFlags(vc, rootCmd.PersistentFlags())
 
 	NewSendTxCmd(rootCmd, vc)
+	NewIISSCmd(rootCmd, vc)
 	NewMonitorCmd(rootCmd, vc)
 
 	rootCmd.AddCommand(
@@ -136,7 +137,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, blk)
+				return PrintOutput(os.Stdout, blk)
 			},
 		},
 		&cobra.Command{
@@ -153,7 +154,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, blk)
+				return PrintOutput(os.Stdout, blk)
 			},
 		},
 		&cobra.Command{
@@ -166,7 +167,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, blk)
+				return PrintOutput(os.Stdout, blk)
 			},
 		},
 		&cobra.Command{
@@ -179,7 +180,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, txResult)
+				return PrintOutput(os.Stdout, txResult)
 			},
 		},
 		&cobra.Command{
@@ -192,7 +193,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, tx)
+				return PrintOutput(os.Stdout, tx)
 			},
 		})
 
@@ -213,7 +214,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 			if err != nil {
 				return err
 			}
-			return JsonPrettyPrintln(os.Stdout, balance)
+			return PrintOutput(os.Stdout, balance)
 		},
 	}
 	rootCmd.AddCommand(balanceCmd)
@@ -237,7 +238,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 			if err != nil {
 				return err
 			}
-			return JsonPrettyPrintln(os.Stdout, scoreApi)
+			return PrintOutput(os.Stdout, scoreApi)
 		},
 	}
 	rootCmd.AddCommand(scoreAPICmd)
@@ -263,13 +264,39 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 			if err != nil {
 				return err
 			}
-			return JsonPrettyPrintln(os.Stdout, supply)
+			return PrintOutput(os.Stdout, supply)
 		},
 	}
 	rootCmd.AddCommand(tsCmd)
 	flags = tsCmd.Flags()
 	flags.Int("height", -1, "BlockHeight")
 
+	revisionCmd := &cobra.Command{
+		Use:   "revision",
+		Short: "Get revision and block version with pending changes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var param *v3.HeightParam
+			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			if err != nil {
+				return err
+			}
+			if height != -1 {
+				param = &v3.HeightParam{
+					Height: jsonrpc.HexInt(intconv.FormatInt(height)),
+				}
+			}
+			info, err := rpcClient.GetRevisionInfo(param)
+			if err != nil {
+				return err
+			}
+			return PrintOutput(os.Stdout, info)
+		},
+	}
+	rootCmd.AddCommand(revisionCmd)
+	flags = revisionCmd.Flags()
+	flags.Int("height", -1, "BlockHeight")
+
 	callCmd := &cobra.Command{
 		Use:   "call",
 		Short: "Call",
@@ -319,7 +346,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 			if err != nil {
 				return err
 			}
-			if err = JsonPrettyPrintln(os.Stdout, blk); err != nil {
+			if err = PrintOutput(os.Stdout, blk); err != nil {
 				return errors.Errorf("failed JsonIntend blk=%+v, err=%+v", blk, err)
 			}
 			return nil
@@ -368,7 +395,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, raw)
+				return PrintOutput(os.Stdout, raw)
 			},
 		},
 		&cobra.Command{
@@ -385,7 +412,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, raw)
+				return PrintOutput(os.Stdout, raw)
 			},
 		},
 		&cobra.Command{
@@ -402,7 +429,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, raw)
+				return PrintOutput(os.Stdout, raw)
 			},
 		},
 		&cobra.Command{
@@ -422,7 +449,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, raw)
+				return PrintOutput(os.Stdout, raw)
 			},
 		},
 		&cobra.Command{
@@ -452,7 +479,20 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, raw)
+				return PrintOutput(os.Stdout, raw)
+			},
+		},
+		&cobra.Command{
+			Use:   "receiptproof HASH",
+			Short: "GetReceiptProof",
+			Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				param := &v3.TransactionHashParam{Hash: jsonrpc.HexBytes(args[0])}
+				rp, err := rpcClient.GetReceiptProof(param)
+				if err != nil {
+					return err
+				}
+				return PrintOutput(os.Stdout, rp)
 			},
 		})
 	scoreStatusCmd := &cobra.Command{
@@ -472,13 +512,63 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 			if err != nil {
 				return err
 			}
-			return JsonPrettyPrintln(os.Stdout, scoreStatus)
+			return PrintOutput(os.Stdout, scoreStatus)
 		},
 	}
 	rootCmd.AddCommand(scoreStatusCmd)
 	flags = scoreStatusCmd.Flags()
 	flags.Int("height", -1, "BlockHeight")
 
+	scoreInfoCmd := &cobra.Command{
+		Use:   "scoreinfo ADDRESS",
+		Short: "Get status and method signatures of the smart contract",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.ScoreAddressParam{Address: jsonrpc.Address(args[0])}
+			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			if err != nil {
+				return err
+			}
+			if height != -1 {
+				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
+			}
+			scoreInfo, err := rpcClient.GetScoreInfo(param)
+			if err != nil {
+				return err
+			}
+			return PrintOutput(os.Stdout, scoreInfo)
+		},
+	}
+	rootCmd.AddCommand(scoreInfoCmd)
+	flags = scoreInfoCmd.Flags()
+	flags.Int("height", -1, "BlockHeight")
+
+	feeSharingCmd := &cobra.Command{
+		Use:   "feesharing ADDRESS",
+		Short: "Get fee sharing information of the smart contract",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.ScoreAddressParam{Address: jsonrpc.Address(args[0])}
+			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			if err != nil {
+				return err
+			}
+			if height != -1 {
+				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
+			}
+			info, err := rpcClient.GetFeeSharingInfo(param)
+			if err != nil {
+				return err
+			}
+			return PrintOutput(os.Stdout, info)
+		},
+	}
+	rootCmd.AddCommand(feeSharingCmd)
+	flags = feeSharingCmd.Flags()
+	flags.Int("height", -1, "BlockHeight")
+
+	rootCmd.AddCommand(newRewardLedgerCmd(&rpcClient))
+
 	rootCmd.AddCommand(
 		&cobra.Command{
 			Use:   "btpnetwork ID [HEIGHT]",
@@ -493,7 +583,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, r)
+				return PrintOutput(os.Stdout, r)
 			},
 		},
 		&cobra.Command{
@@ -509,7 +599,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, r)
+				return PrintOutput(os.Stdout, r)
 			},
 		},
 		&cobra.Command{
@@ -525,7 +615,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, r)
+				return PrintOutput(os.Stdout, r)
 			},
 		},
 		&cobra.Command{
@@ -541,7 +631,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, r)
+				return PrintOutput(os.Stdout, r)
 			},
 		},
 		&cobra.Command{
@@ -557,7 +647,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, r)
+				return PrintOutput(os.Stdout, r)
 			},
 		},
 		&cobra.Command{
@@ -569,7 +659,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				if err != nil {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, r)
+				return PrintOutput(os.Stdout, r)
 			},
 		})
 	return rootCmd, vc
@@ -614,6 +704,34 @@ func newBTPMessagesParam(args []string) (p *v3.BTPMessagesParam, err error) {
 	return p, nil
 }
 
+// NewWalletFromViper loads the wallet from the KeyStore with key_store,
+// key_secret and key_password.
+func NewWalletFromViper(vc *viper.Viper) (module.Wallet, error) {
+	var kb, pb []byte
+	var err error
+	ksf := vc.GetString("key_store")
+	if kb, err = ioutil.ReadFile(ksf); err != nil {
+		return nil, fmt.Errorf("fail to open KeyStore file=%s err=%+v", ksf, err)
+	}
+	//key_secret -> key_password
+	ksec := vc.GetString("key_secret")
+	kpass := vc.GetString("key_password")
+	if ksec != "" {
+		if pb, err = ioutil.ReadFile(ksec); err != nil {
+			return nil, fmt.Errorf("fail to open KeySecret file=%s err=%+v", ksec, err)
+		}
+	} else if kpass != "" {
+		pb = []byte(kpass)
+	} else {
+		return nil, fmt.Errorf("there is no password information for the KeyStore, use --key_secret or --key_password")
+	}
+	w, err := wallet.NewFromKeyStore(kb, pb)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create wallet err=%+v", err)
+	}
+	return w, nil
+}
+
 func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Command {
 	var rpcClient client.ClientV3
 	var rpcClientSendTx func(w module.Wallet, params *v3.TransactionParam) (interface{}, error)
@@ -627,7 +745,8 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 			return err
 		}
 
-		if estimate := vc.GetBool("estimate"); estimate {
+		estimate, details := vc.GetBool("estimate"), vc.GetBool("estimate_details")
+		if estimate || details {
 			rpcClientSendTx = func(w module.Wallet, p *v3.TransactionParam) (interface{}, error) {
 				params := &v3.TransactionParamForEstimate{
 					Version:     p.Version,
@@ -640,6 +759,9 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 					DataType:    p.DataType,
 					Data:        p.Data,
 				}
+				if details {
+					return rpcClient.EstimateStepDetails(params)
+				}
 				step, err := rpcClient.EstimateStep(params)
 				if err != nil {
 					return nil, err
@@ -664,29 +786,9 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 				return err
 			}
 		}
-		var kb, pb []byte
 		var err error
-		ksf := vc.GetString("key_store")
-		if kb, err = ioutil.ReadFile(ksf); err != nil {
-			return fmt.Errorf("fail to open KeyStore file=%s err=%+v", ksf, err)
-		}
-		//key_secret -> key_password
-		ksec := vc.GetString("key_secret")
-		kpass := vc.GetString("key_password")
-		if ksec != "" {
-			if pb, err = ioutil.ReadFile(ksec); err != nil {
-				return fmt.Errorf("fail to open KeySecret file=%s err=%+v", ksec, err)
-			}
-		} else if kpass != "" {
-			pb = []byte(kpass)
-		} else {
-			return fmt.Errorf("there is no password information for the KeyStore, use --key_secret or --key_password")
-		}
-		rpcWallet, err = wallet.NewFromKeyStore(kb, pb)
-		if err != nil {
-			return fmt.Errorf("fail to create wallet err=%+v", err)
-		}
-		return nil
+		rpcWallet, err = NewWalletFromViper(vc)
+		return err
 	}
 	rootCmd.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
 		txHash, ok := vc.Get("txHash").(*jsonrpc.HexBytes)
@@ -765,7 +867,7 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 				if err, ok := v.(error); ok {
 					return err
 				}
-				return JsonPrettyPrintln(os.Stdout, v)
+				return PrintOutput(os.Stdout, v)
 			}
 		}
 		return nil
@@ -781,6 +883,7 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 	rootPFlags.Int("wait_interval", 1000, "Polling interval(msec) for wait transaction result")
 	rootPFlags.Int("wait_timeout", 10, "Timeout(sec) for wait transaction result")
 	rootPFlags.Bool("estimate", false, "Just estimate steps for the tx")
+	rootPFlags.Bool("estimate_details", false, "Just estimate steps for the tx with payment details")
 	rootPFlags.String("save", "", "Store transaction to the file")
 	MarkAnnotationCustom(rootPFlags, "key_store", "nid")
 	BindPFlags(vc, rootCmd.PersistentFlags())
@@ -831,7 +934,7 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 				return err
 			}
 			vc.Set("txhash", txHash)
-			return JsonPrettyPrintln(os.Stdout, txHash)
+			return PrintOutput(os.Stdout, txHash)
 		},
 	}
 	rootCmd.AddCommand(rawCmd)
@@ -854,7 +957,7 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 				return err
 			}
 			vc.Set("txhash", txHash)
-			return JsonPrettyPrintln(os.Stdout, txHash)
+			return PrintOutput(os.Stdout, txHash)
 		},
 	}
 	rootCmd.AddCommand(raw2Cmd)
@@ -878,7 +981,7 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 				return err
 			}
 			vc.Set("txhash", &result)
-			return JsonPrettyPrintln(os.Stdout, &result)
+			return PrintOutput(os.Stdout, &result)
 		},
 	}
 	rootCmd.AddCommand(raw3Cmd)
@@ -920,7 +1023,7 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 				return err
 			}
 			vc.Set("txhash", txHash)
-			return JsonPrettyPrintln(os.Stdout, txHash)
+			return PrintOutput(os.Stdout, txHash)
 		},
 	}
 	rootCmd.AddCommand(transferCmd)
@@ -990,7 +1093,7 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 				return err
 			}
 			vc.Set("txhash", txHash)
-			return JsonPrettyPrintln(os.Stdout, txHash)
+			return PrintOutput(os.Stdout, txHash)
 		},
 	}
 	rootCmd.AddCommand(callCmd)
@@ -1045,6 +1148,9 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 				}
 			}
 			dataM["content"] = "0x" + hex.EncodeToString(b)
+			if salt := cmd.Flag("salt").Value.String(); salt != "" {
+				dataM["salt"] = salt
+			}
 			if dataParams, err := getParamsFromFlags(cmd.Flags()); err != nil {
 				return err
 			} else if dataParams != nil {
@@ -1058,7 +1164,7 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 				return err
 			}
 			vc.Set("txhash", txHash)
-			return JsonPrettyPrintln(os.Stdout, txHash)
+			return PrintOutput(os.Stdout, txHash)
 		},
 	}
 	rootCmd.AddCommand(deployCmd)
@@ -1070,6 +1176,8 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 		"raw json string or '@<json file>' or '-' for stdin for parameter JSON")
 	deployFlags.StringToString("param", nil,
 		"key=value, Function parameters will be delivered to on_install() or on_update()")
+	deployFlags.String("salt", "",
+		"Salt(hex, up to 32 bytes) to derive the contract address from the sender and the content")
 	MarkAnnotationHidden(deployFlags, "content-type")
 	return rootCmd
 }
@@ -1119,7 +1227,7 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 			}
 			OnInterrupt(rpcClient.Cleanup)
 			err = rpcClient.MonitorBlock(param, func(v *server.BlockNotification) {
-				JsonPrettyPrintln(os.Stdout, v)
+				PrintOutput(os.Stdout, v)
 			}, nil)
 			if err != nil {
 				return err
@@ -1193,7 +1301,7 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 			}
 			OnInterrupt(rpcClient.Cleanup)
 			err := rpcClient.MonitorEvent(param, func(v *server.EventNotification) {
-				JsonPrettyPrintln(os.Stdout, v)
+				PrintOutput(os.Stdout, v)
 			}, nil)
 			if err != nil {
 				return err
@@ -1240,7 +1348,7 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 
 			OnInterrupt(rpcClient.Cleanup)
 			err := rpcClient.MonitorBtp(param, func(v *server.BTPNotification) {
-				JsonPrettyPrintln(os.Stdout, v)
+				PrintOutput(os.Stdout, v)
 			}, nil)
 			if err != nil {
 				return err
@@ -1254,5 +1362,38 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 		"BTP Network ID")
 	monitorBTPFlags.Bool("proof_flag", false, "Includes proof")
 
+	monitorHeaderCmd := &cobra.Command{
+		Use:   "header HEIGHT",
+		Short: "MonitorHeader",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &server.HeaderRequest{}
+			height, err := intconv.ParseInt(args[0], 64)
+			if err != nil {
+				return err
+			}
+			param.Height = common.HexInt64{Value: height}
+			if nid := cmd.Flag("networkId").Value.String(); nid != "" {
+				networkId, err := intconv.ParseInt(nid, 64)
+				if err != nil {
+					return err
+				}
+				param.NetworkID = &common.HexInt64{Value: networkId}
+			}
+
+			OnInterrupt(rpcClient.Cleanup)
+			err = rpcClient.MonitorHeader(param, func(v *server.HeaderNotification) {
+				PrintOutput(os.Stdout, v)
+			}, nil)
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	rootCmd.AddCommand(monitorHeaderCmd)
+	monitorHeaderCmd.Flags().String("networkId", "",
+		"BTP Network ID to get headers of BTP blocks")
+
 	return rootCmd
 }
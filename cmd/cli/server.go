@@ -157,7 +157,7 @@ func NewServerCmd(parentCmd *cobra.Command, parentVc *viper.Viper, version, buil
 	rootPFlags.String("key_plugin", "", "KeyPlugin file for wallet")
 	rootPFlags.StringToString("key_plugin_options", nil, "KeyPlugin options")
 	//
-	rootPFlags.String("log_forwarder_vendor", "", "LogForwarder vendor (fluentd,logstash)")
+	rootPFlags.String("log_forwarder_vendor", "", "LogForwarder vendor (fluentd,logstash,syslog,loki)")
 	rootPFlags.String("log_forwarder_address", "", "LogForwarder address")
 	rootPFlags.String("log_forwarder_level", "info", "LogForwarder level")
 	rootPFlags.String("log_forwarder_name", "", "LogForwarder name")
@@ -170,6 +170,7 @@ func NewServerCmd(parentCmd *cobra.Command, parentVc *viper.Viper, version, buil
 	rootPFlags.Int("log_writer_maxbackups", 0, "Maximum number of backups")
 	rootPFlags.Bool("log_writer_localtime", false, "Use localtime on rotated log file instead of UTC")
 	rootPFlags.Bool("log_writer_compress", false, "Use gzip on rotated log file")
+	rootPFlags.String("log_writer_rotate", "", "Interval of time based rotation (ex: 24h)")
 
 	BindPFlags(vc, rootCmd.PersistentFlags())
 
@@ -278,6 +279,7 @@ func NewServerCmd(parentCmd *cobra.Command, parentVc *viper.Viper, version, buil
 	startFlags.String("memprofile", "", "Memory Profiling data file")
 	startFlags.Bool("auth_skip_if_empty_users", false, "Skip admin API authentication if empty users")
 	startFlags.Bool("nid_for_p2p", false, "Use NID instead of CID for p2p network")
+	startFlags.Bool("watchdog_recover", false, "Close stalled peers and kill stalled executors detected by the watchdog")
 	startFlags.MarkHidden("mod_level")
 	startFlags.MarkHidden("auth_skip_if_empty_users")
 	startFlags.MarkHidden("nid_for_p2p")
@@ -372,6 +374,7 @@ func MergeWithViper(vc *viper.Viper, cfg *ServerConfig) error {
 		MaxBackups: vc.GetInt("log_writer_maxbackups"),
 		LocalTime:  vc.GetBool("log_writer_localtime"),
 		Compress:   vc.GetBool("log_writer_compress"),
+		Rotate:     vc.GetString("log_writer_rotate"),
 	}
 	if len(lwFilename) > 0 {
 		lwCfg.Filename = cfg.ResolveRelative(lwFilename)
@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	v3 "github.com/icon-project/goloop/server/v3"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+func readTxFile(name string) (map[string]interface{}, error) {
+	bs, err := readFile(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to read transaction file=%s", name)
+	}
+	var tx map[string]interface{}
+	if err := json.Unmarshal(bs, &tx); err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidTransactionJSON(file=%s)", name)
+	}
+	return tx, nil
+}
+
+func writeTxFile(name string, tx map[string]interface{}) error {
+	if name == "" || name == "-" {
+		return JsonPrettyPrintln(os.Stdout, tx)
+	}
+	return JsonPrettySaveFile(name, 0644, tx)
+}
+
+func NewTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command, *viper.Viper) {
+	rootCmd, vc := NewCommand(parentCmd, parentVc, "tx", "Build, sign and send transactions separately for offline signing")
+
+	newTxBuildCmd(rootCmd, vc)
+	newTxSignCmd(rootCmd, vc)
+	newTxSendCmd(rootCmd, vc)
+	return rootCmd, vc
+}
+
+func newTxBuildCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Command {
+	cmd, vc := NewCommand(parentCmd, parentVc, "build", "Build unsigned transaction")
+	cmd.Args = cobra.NoArgs
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := ValidateFlagsWithViper(vc, cmd.Flags()); err != nil {
+			return err
+		}
+		fillURI := vc.GetString("fill")
+		if fillURI == "" {
+			if err := CheckFlagsWithViper(vc, cmd.Flags(), "nid", "step_limit"); err != nil {
+				return err
+			}
+		}
+		from := new(common.Address)
+		if err := from.SetStringStrict(vc.GetString("from")); err != nil || from.IsContract() {
+			return errors.IllegalArgumentError.Errorf("InvalidFromAddress(from=%s)", vc.GetString("from"))
+		}
+		to := new(common.Address)
+		if err := to.SetStringStrict(vc.GetString("to")); err != nil {
+			return errors.IllegalArgumentError.Wrapf(err, "InvalidToAddress(to=%s)", vc.GetString("to"))
+		}
+		tx := map[string]interface{}{
+			"version": v3.VersionValue,
+			"from":    from.String(),
+			"to":      to.String(),
+		}
+		if s := vc.GetString("nid"); s != "" {
+			nid, err := intconv.ParseInt(s, 64)
+			if err != nil {
+				return errors.IllegalArgumentError.Wrapf(err, "InvalidNetworkID(nid=%s)", s)
+			}
+			tx["nid"] = intconv.FormatInt(nid)
+		}
+		if s := vc.GetString("step_limit"); s != "" {
+			stepLimit, err := intconv.ParseInt(s, 64)
+			if err != nil {
+				return errors.IllegalArgumentError.Wrapf(err, "InvalidStepLimit(step_limit=%s)", s)
+			}
+			tx["stepLimit"] = intconv.FormatInt(stepLimit)
+		}
+		if s := vc.GetString("value"); s != "" {
+			value, err := parseICXValue(s)
+			if err != nil {
+				return err
+			}
+			tx["value"] = intconv.FormatBigInt(value)
+		}
+		if s := vc.GetString("nonce"); s != "" {
+			nonce := new(big.Int)
+			if err := intconv.ParseBigInt(nonce, s); err != nil {
+				return errors.IllegalArgumentError.Wrapf(err, "InvalidNonce(nonce=%s)", s)
+			}
+			tx["nonce"] = intconv.FormatBigInt(nonce)
+		}
+		if s := vc.GetString("timestamp"); s != "" {
+			ts, err := intconv.ParseInt(s, 64)
+			if err != nil {
+				return errors.IllegalArgumentError.Wrapf(err, "InvalidTimestamp(timestamp=%s)", s)
+			}
+			tx["timestamp"] = intconv.FormatInt(ts)
+		}
+
+		params, err := getParamsFromFlags(cmd.Flags())
+		if err != nil {
+			return err
+		}
+		method := vc.GetString("method")
+		content := vc.GetString("content")
+		message := vc.GetString("message")
+		switch {
+		case method != "":
+			data := map[string]interface{}{"method": method}
+			if params != nil {
+				data["params"] = params
+			}
+			tx["dataType"] = "call"
+			tx["data"] = data
+		case content != "":
+			isDir, err := IsDirectory(content)
+			if err != nil {
+				return err
+			}
+			var b []byte
+			if isDir {
+				if b, err = ZipDirectory(content, "__pycache__"); err != nil {
+					return fmt.Errorf("fail to zip with directory %s err:%+v", content, err)
+				}
+			} else {
+				if b, err = readFile(content); err != nil {
+					return fmt.Errorf("fail to read %s err:%+v", content, err)
+				}
+			}
+			data := map[string]interface{}{
+				"contentType": vc.GetString("content_type"),
+				"content":     "0x" + hex.EncodeToString(b),
+			}
+			if params != nil {
+				data["params"] = params
+			}
+			tx["dataType"] = "deploy"
+			tx["data"] = data
+		case message != "":
+			tx["dataType"] = "message"
+			tx["data"] = "0x" + hex.EncodeToString([]byte(message))
+		}
+		if fillURI != "" {
+			filled, err := client.NewClientV3(fillURI).FillTransaction(tx)
+			if err != nil {
+				return err
+			}
+			tx = filled
+		}
+		return writeTxFile(vc.GetString("out"), tx)
+	}
+	flags := cmd.Flags()
+	flags.String("from", "", "FromAddress (address of the signer)")
+	flags.String("to", "", "ToAddress")
+	flags.String("nid", "", "Network ID (filled by the node with '--fill')")
+	flags.String("step_limit", "", "StepLimit (estimated by the node with '--fill')")
+	flags.String("value", "", "Value of transfer (in loop, or in ICX with 'icx' suffix)")
+	flags.String("nonce", "", "Nonce")
+	flags.String("timestamp", "", "Timestamp in microseconds (set on signing if it's not specified)")
+	flags.String("method", "", "Name of the function to call")
+	flags.String("params", "",
+		"raw json string or '@<json file>' or '-' for stdin for parameter JSON")
+	flags.StringToString("param", nil, "key=value, Function parameters")
+	flags.String("content", "", "SCORE zip file or directory to deploy")
+	flags.String("content_type", "application/zip", "Mime-type of the content")
+	flags.String("message", "", "Message")
+	flags.String("out", "", "Output file of the unsigned transaction (stdout if it's omitted)")
+	flags.String("fill", "",
+		"URI of JSON-RPC API to fill nid, timestamp and stepLimit of the transaction")
+	MarkAnnotationCustom(flags, "from", "to")
+	BindPFlags(vc, flags)
+	return cmd
+}
+
+func newTxSignCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Command {
+	cmd, vc := NewCommand(parentCmd, parentVc, "sign FILE", "Sign the transaction (no connection is required)")
+	cmd.Args = ArgsWithDefaultErrorFunc(cobra.ExactArgs(1))
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := ValidateFlagsWithViper(vc, cmd.Flags()); err != nil {
+			return err
+		}
+		w, err := NewWalletFromViper(vc)
+		if err != nil {
+			return err
+		}
+		tx, err := readTxFile(args[0])
+		if err != nil {
+			return err
+		}
+		if _, ok := tx["signature"]; ok {
+			return errors.InvalidStateError.New("AlreadySigned")
+		}
+		if from, ok := tx["from"]; !ok {
+			tx["from"] = w.Address().String()
+		} else if from != w.Address().String() {
+			return errors.IllegalArgumentError.Errorf(
+				"FromMismatch(from=%v,wallet=%s)", from, w.Address())
+		}
+		if _, ok := tx["timestamp"]; !ok {
+			tx["timestamp"] = intconv.FormatInt(time.Now().UnixNano() / int64(time.Microsecond))
+		}
+		if err := client.SignRawTransaction(w, tx); err != nil {
+			return err
+		}
+
+		bs, err := json.Marshal(tx)
+		if err != nil {
+			return err
+		}
+		signed, err := transaction.NewTransactionFromJSON(bs)
+		if err != nil {
+			return errors.IllegalArgumentError.Wrap(err, "InvalidTransaction")
+		}
+		if err := signed.Verify(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Transaction hash: 0x%x\n", signed.ID())
+		return writeTxFile(vc.GetString("out"), tx)
+	}
+	flags := cmd.Flags()
+	flags.String("key_store", "", "KeyStore file for wallet")
+	flags.String("key_secret", "", "Secret(password) file for KeyStore")
+	flags.String("key_password", "", "Password for the KeyStore file")
+	flags.String("out", "", "Output file of the signed transaction (stdout if it's omitted)")
+	MarkAnnotationCustom(flags, "key_store")
+	BindPFlags(vc, flags)
+	return cmd
+}
+
+func newTxSendCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Command {
+	var rpcClient client.ClientV3
+	cmd, vc := NewCommand(parentCmd, parentVc, "send FILE", "Send the signed transaction")
+	cmd.Args = ArgsWithDefaultErrorFunc(cobra.ExactArgs(1))
+	cmd.PreRunE = RpcPersistentPreRunE(vc, &rpcClient)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		tx, err := readTxFile(args[0])
+		if err != nil {
+			return err
+		}
+		if _, ok := tx["signature"]; !ok {
+			return errors.InvalidStateError.New("NotSigned")
+		}
+		var result jsonrpc.HexBytes
+		if _, err = rpcClient.Do("icx_sendTransaction", tx, &result); err != nil {
+			return err
+		}
+		return PrintOutput(os.Stdout, &result)
+	}
+	AddRpcRequiredFlags(cmd)
+	BindPFlags(vc, cmd.PersistentFlags())
+	return cmd
+}
@@ -34,17 +34,18 @@ const (
 
 type GoChainConfig struct {
 	chain.Config
-	P2PAddr       string `json:"p2p"`
-	P2PListenAddr string `json:"p2p_listen"`
-	EESocket      string `json:"ee_socket"`
-	RPCAddr       string `json:"rpc_addr"`
-	RPCDump       bool   `json:"rpc_dump"`
-	RPCDebug      bool   `json:"rpc_debug"`
-	RPCRosetta    bool   `json:"rpc_rosetta"`
-	RPCBatchLimit int    `json:"rpc_batch_limit,omitempty"`
-	EEInstances   int    `json:"ee_instances"`
-	Engines       string `json:"engines"`
-	WSMaxSession  int    `json:"ws_max_session"`
+	P2PAddr        string `json:"p2p"`
+	P2PListenAddr  string `json:"p2p_listen"`
+	EESocket       string `json:"ee_socket"`
+	RPCAddr        string `json:"rpc_addr"`
+	RPCDump        bool   `json:"rpc_dump"`
+	RPCDebug       bool   `json:"rpc_debug"`
+	RPCRosetta     bool   `json:"rpc_rosetta"`
+	RPCBatchLimit  int    `json:"rpc_batch_limit,omitempty"`
+	EEInstances    int    `json:"ee_instances"`
+	EEMaxInstances int    `json:"ee_max_instances,omitempty"`
+	Engines        string `json:"engines"`
+	WSMaxSession   int    `json:"ws_max_session"`
 
 	Key          []byte          `json:"key,omitempty"`
 	KeyStoreData json.RawMessage `json:"key_store"`
@@ -132,12 +133,24 @@ func main() {
 	flag.StringVar(&memProfile, "memprofile", "", "Memory Profiling data file")
 	flag.StringVar(&chainDir, "chain_dir", "", "Chain data directory (default: .chain/<address>/<nid>)")
 	flag.IntVar(&cfg.EEInstances, "ee_instances", 1, "Number of execution engines")
+	flag.IntVar(&cfg.EEMaxInstances, "ee_max_instances", 0, "Maximum number of execution engines for autoscaling")
 	flag.IntVar(&cfg.ConcurrencyLevel, "concurrency", 1, "Maximum number of executors to be used for concurrency")
 	flag.IntVar(&cfg.NormalTxPoolSize, "normal_tx_pool", 0, "Normal transaction pool size")
 	flag.IntVar(&cfg.PatchTxPoolSize, "patch_tx_pool", 0, "Patch transaction pool size")
 	flag.IntVar(&cfg.MaxBlockTxBytes, "max_block_tx_bytes", 0, "Maximum size of transactions in a block")
 	flag.StringVar(&cfg.NodeCache, "node_cache", chain.NodeCacheDefault, "Node cache (none,small,large)")
+	flag.StringVar(&cfg.ReceiptCompression, "receipt_compression", string(db.CompressionDefault), "Compression of receipts and event logs in the database (none,zstd)")
 	flag.BoolVar(&cfg.ValidateTxOnSend, "validate_tx_on_send", false, "Validate transaction on send")
+	flag.BoolVar(&cfg.RecordInternalCalls, "record_internal_calls", false, "Record internal calls of transactions")
+	flag.BoolVar(&cfg.RecordAddressIndex, "record_address_index", false, "Record index of transactions by addresses involved in them")
+	flag.BoolVar(&cfg.RecordTokenIndex, "record_token_index", false, "Record balances and transfers of IRC-2 and IRC-3 tokens")
+	flag.IntVar(&cfg.SyncServerLimit, "sync_server_limit", 0, "Maximum number of state sync and block sync requests of peers served concurrently (0: no limit)")
+	flag.Int64Var(&cfg.SyncServerBandwidth, "sync_server_bandwidth", 0, "Maximum bytes per second of state sync and block sync responses to peers (0: no limit)")
+	flag.BoolVar(&cfg.AutoRole, "auto_role", false, "Adjust role of the node by election of the platform")
+	flag.StringVar(&cfg.ReplicaDBDir, "replica_db_dir", "", "Database directory replicated from other node to serve queries as a read replica")
+	flag.Int64Var(&cfg.ReplicaInterval, "replica_interval", 0, "Interval to check new blocks as a read replica in milli-second (0: uses system default value)")
+	flag.Int64Var(&cfg.HistoryDepth, "history_depth", 0, "Number of the latest blocks served for all historical queries (0: no limit as an archive)")
+	flag.StringVar(&cfg.HistoryQueries, "history_queries", "", "Historical queries served for older blocks than history_depth (state,trace,receipt) - Comma separated string")
 	cfg.ChildrenLimit = flag.Int("children_limit", -1, "Maximum number of child connections (-1: uses system default value)")
 	cfg.NephewsLimit = flag.Int("nephews_limit", -1, "Maximum number of nephew connections (-1: uses system default value)")
 	flag.StringVar(&cfg.LogLevel, "log_level", "debug", "Main log level")
@@ -484,6 +497,7 @@ func Execute(cmd *cobra.Command, args []string) {
 	go pm.Loop()
 
 	pm.SetInstances(cfg.EEInstances, cfg.EEInstances, cfg.EEInstances)
+	pm.SetMaxInstances(cfg.EEMaxInstances)
 
 	config := &server.Config{
 		ServerAddress:       cfg.RPCAddr,
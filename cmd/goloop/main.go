@@ -38,6 +38,7 @@ func main() {
 		" \\____|\\___/|_____\\___/ \\___/|_|",
 	}
 
+	cli.AddOutputFlags(rootCmd, rootVc)
 	cli.NewServerCmd(rootCmd, rootVc, version, build, logoLines)
 	cli.NewChainCmd(rootCmd, rootVc)
 	cli.NewSystemCmd(rootCmd, rootVc)
@@ -45,10 +46,14 @@ func main() {
 	cli.NewStatsCmd(rootCmd, rootVc)
 	cli.NewRpcCmd(rootCmd, nil)
 	cli.NewDebugCmd(rootCmd, nil)
+	cli.NewConsoleCmd(rootCmd, nil)
+	cli.NewTxCmd(rootCmd, nil)
+	cli.NewNetworkCmd(rootCmd, nil)
 	rootCmd.AddCommand(
 		cli.NewGStorageCmd("gs"),
 		cli.NewGenesisCmd("gn"),
-		cli.NewKeystoreCmd("ks"))
+		cli.NewKeystoreCmd("ks"),
+		cli.NewIconCmd("icon"))
 
 	genMdCmd := cli.NewGenerateMarkdownCommand(rootCmd, nil)
 	genMdCmd.Hidden = true
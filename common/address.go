@@ -14,8 +14,9 @@ import (
 )
 
 const (
-	AddressIDBytes = 20
-	AddressBytes   = AddressIDBytes + 1
+	AddressIDBytes   = 20
+	AddressBytes     = AddressIDBytes + 1
+	AddressSaltBytes = 32
 )
 
 type Address [AddressBytes]byte
@@ -223,6 +224,25 @@ func NewAccountAddressFromPublicKey(pubKey *crypto.PublicKey) *Address {
 	return NewAccountAddress(digest[len(digest)-AddressIDBytes:])
 }
 
+// NewContractAddressWithSalt returns the address of the contract deployed
+// by from with the salt and the content. It doesn't depend on the transaction,
+// so the address is known before the deployment. The salt is left padded to
+// AddressSaltBytes.
+// digest = sha3_256(0xff + from(21 bytes) + salt(32 bytes) + sha3_256(content))
+// contract address = digest[len(digest) - 20:]
+func NewContractAddressWithSalt(from module.Address, salt []byte, content []byte) *Address {
+	data := make([]byte, 0, 1+AddressBytes+AddressSaltBytes+32)
+	data = append(data, 0xff)
+	data = append(data, from.Bytes()...)
+	if len(salt) < AddressSaltBytes {
+		data = append(data, make([]byte, AddressSaltBytes-len(salt))...)
+	}
+	data = append(data, salt...)
+	data = append(data, crypto.SHA3Sum256(content)...)
+	digest := crypto.SHA3Sum256(data)
+	return NewContractAddress(digest[len(digest)-AddressIDBytes:])
+}
+
 func (a *Address) Equal(a2 module.Address) bool {
 	a2IsNil := a2 == nil || reflect.ValueOf(a2).IsNil()
 	if a2IsNil && a == nil {
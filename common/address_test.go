@@ -458,3 +458,48 @@ func TestAddress_ToString(t *testing.T) {
 		})
 	}
 }
+
+func TestNewContractAddressWithSalt(t *testing.T) {
+	from := MustNewAddressFromString("hx1234567890abcdef1234567890abcdef12345678")
+	content := []byte("content")
+
+	addr1 := NewContractAddressWithSalt(from, []byte{0x01}, content)
+	assert.True(t, addr1.IsContract())
+
+	padded := make([]byte, AddressSaltBytes)
+	padded[AddressSaltBytes-1] = 0x01
+	assert.True(t, addr1.Equal(NewContractAddressWithSalt(from, padded, content)))
+
+	assert.False(t, addr1.Equal(NewContractAddressWithSalt(from, []byte{0x02}, content)))
+	assert.False(t, addr1.Equal(NewContractAddressWithSalt(from, []byte{0x01}, []byte("other"))))
+	other := MustNewAddressFromString("cx1234567890abcdef1234567890abcdef12345678")
+	assert.False(t, addr1.Equal(NewContractAddressWithSalt(other, []byte{0x01}, content)))
+}
+
+func FuzzNewAddressFromString(f *testing.F) {
+	f.Add("hx8888888888888888888888888888888888888888")
+	f.Add("cx0000000000000000000000000000000000000001")
+	f.Add("0x")
+	f.Fuzz(func(t *testing.T, s string) {
+		addr, err := NewAddressFromString(s)
+		if err == nil {
+			addr2, err := NewAddressFromString(addr.String())
+			assert.NoError(t, err)
+			assert.True(t, addr.Equal(addr2))
+		}
+	})
+}
+
+func FuzzAddress_SetBytes(f *testing.F) {
+	f.Add([]byte("\x01\x12\x34\x56\x78\x90\xab\xcd\xef\x12\x34\x56\x78\x90\xab\xcd\xef\x12\x34\x56\x78"))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var addr Address
+		if err := addr.SetBytes(bs); err == nil {
+			assert.NotEmpty(t, addr.String())
+		}
+		var addr2 Address
+		if _, err := codec.BC.UnmarshalFromBytes(bs, &addr2); err == nil {
+			assert.NotEmpty(t, addr2.String())
+		}
+	})
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package chaos injects faults into specific operations for resilience
+// tests. Faults are injected only by binaries built with "chaos" tag, and
+// rules are configured with the environment variable GOLOOP_CHAOS.
+//
+// Rules are separated by comma, and each rule is formatted as
+// <point>:<action>:<probability>[:<delay>]. For example,
+// "db.flush:fail:0.01,network.send:delay:0.1:200ms" fails 1% of flushes and
+// delays 10% of packets for 200ms.
+package chaos
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const EnvRules = "GOLOOP_CHAOS"
+
+// Points of injection.
+const (
+	PointDBFlush       = "db.flush"
+	PointBlockFinalize = "block.finalize"
+	PointExecute       = "service.execute"
+	PointPacketSend    = "network.send"
+)
+
+type Action int
+
+const (
+	ActionFail Action = iota
+	ActionDelay
+)
+
+var actionNames = map[string]Action{
+	"fail":  ActionFail,
+	"delay": ActionDelay,
+}
+
+type Rule struct {
+	Point       string
+	Action      Action
+	Probability float64
+	Delay       time.Duration
+}
+
+func ParseRule(s string) (*Rule, error) {
+	tokens := strings.Split(strings.TrimSpace(s), ":")
+	if len(tokens) < 3 || len(tokens) > 4 {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidRule(rule=%q)", s)
+	}
+	action, ok := actionNames[tokens[1]]
+	if !ok {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidAction(rule=%q)", s)
+	}
+	p, err := strconv.ParseFloat(tokens[2], 64)
+	if err != nil || p < 0 || p > 1 {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidProbability(rule=%q)", s)
+	}
+	r := &Rule{
+		Point:       tokens[0],
+		Action:      action,
+		Probability: p,
+	}
+	if action == ActionDelay {
+		if len(tokens) != 4 {
+			return nil, errors.IllegalArgumentError.Errorf("NoDelay(rule=%q)", s)
+		}
+		if r.Delay, err = time.ParseDuration(tokens[3]); err != nil || r.Delay < 0 {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidDelay(rule=%q)", s)
+		}
+	} else if len(tokens) != 3 {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidRule(rule=%q)", s)
+	}
+	return r, nil
+}
+
+func ParseRules(spec string) ([]*Rule, error) {
+	var rules []*Rule
+	for _, s := range strings.Split(spec, ",") {
+		if len(strings.TrimSpace(s)) == 0 {
+			continue
+		}
+		r, err := ParseRule(s)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// Injector applies rules to operations on the points.
+type Injector struct {
+	mu     sync.Mutex
+	rules  map[string][]*Rule
+	random func() float64
+}
+
+func (i *Injector) SetRules(rules []*Rule) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.rules = make(map[string][]*Rule)
+	for _, r := range rules {
+		i.rules[r.Point] = append(i.rules[r.Point], r)
+	}
+}
+
+// Inject applies rules for the point. It returns an error if one of the
+// rules decides to fail the operation, and it sleeps for delaying rules.
+func (i *Injector) Inject(point string) error {
+	var delay time.Duration
+	var err error
+	i.mu.Lock()
+	for _, r := range i.rules[point] {
+		if i.random() >= r.Probability {
+			continue
+		}
+		switch r.Action {
+		case ActionDelay:
+			delay += r.Delay
+		case ActionFail:
+			if err == nil {
+				err = errors.UnknownError.Errorf("InjectedFault(point=%s)", point)
+			}
+		}
+	}
+	i.mu.Unlock()
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	return err
+}
+
+func NewInjector(random func() float64) *Injector {
+	if random == nil {
+		random = rand.Float64
+	}
+	return &Injector{random: random}
+}
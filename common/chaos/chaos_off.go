@@ -0,0 +1,40 @@
+//go:build !chaos
+// +build !chaos
+
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chaos
+
+import (
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const Enabled = false
+
+// Configure returns an error for non-empty spec, because faults are not
+// injected without "chaos" tag.
+func Configure(spec string) error {
+	if len(spec) == 0 {
+		return nil
+	}
+	return errors.UnsupportedError.New("ChaosNotEnabled")
+}
+
+// Inject does nothing without "chaos" tag.
+func Inject(point string) error {
+	return nil
+}
@@ -0,0 +1,55 @@
+//go:build chaos
+// +build chaos
+
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chaos
+
+import (
+	"os"
+
+	"github.com/icon-project/goloop/common/log"
+)
+
+const Enabled = true
+
+var injector = NewInjector(nil)
+
+func init() {
+	if spec := os.Getenv(EnvRules); len(spec) > 0 {
+		if err := Configure(spec); err != nil {
+			log.Errorf("fail to configure chaos rules err=%+v", err)
+		} else {
+			log.Warnf("chaos rules are enabled rules=%q", spec)
+		}
+	}
+}
+
+// Configure replaces rules with the spec.
+func Configure(spec string) error {
+	rules, err := ParseRules(spec)
+	if err != nil {
+		return err
+	}
+	injector.SetRules(rules)
+	return nil
+}
+
+// Inject applies configured rules for the point.
+func Inject(point string) error {
+	return injector.Inject(point)
+}
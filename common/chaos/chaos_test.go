@@ -0,0 +1,71 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chaos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("db.flush:fail:0.01, network.send:delay:0.5:10ms")
+	assert.NoError(t, err)
+	assert.Equal(t, []*Rule{
+		{PointDBFlush, ActionFail, 0.01, 0},
+		{PointPacketSend, ActionDelay, 0.5, 10 * time.Millisecond},
+	}, rules)
+
+	rules, err = ParseRules("")
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	for _, spec := range []string{
+		"db.flush",
+		"db.flush:crash:0.1",
+		"db.flush:fail:1.5",
+		"db.flush:fail:0.1:10ms",
+		"network.send:delay:0.1",
+		"network.send:delay:0.1:-1s",
+	} {
+		_, err = ParseRules(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestInjector_Inject(t *testing.T) {
+	var v float64
+	i := NewInjector(func() float64 {
+		return v
+	})
+	assert.NoError(t, i.Inject(PointDBFlush))
+
+	rules, err := ParseRules("db.flush:fail:0.5,service.execute:delay:0.5:10ms")
+	assert.NoError(t, err)
+	i.SetRules(rules)
+
+	v = 0.7
+	assert.NoError(t, i.Inject(PointDBFlush))
+	v = 0.2
+	assert.Error(t, i.Inject(PointDBFlush))
+	assert.NoError(t, i.Inject(PointPacketSend))
+
+	start := time.Now()
+	assert.NoError(t, i.Inject(PointExecute))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
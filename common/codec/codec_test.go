@@ -581,3 +581,48 @@ func TestMap(t *testing.T) {
 		})
 	})
 }
+
+func TestLimits(t *testing.T) {
+	type nested struct {
+		Items [][]int64
+	}
+	RunWithCodecs(t, func(t *testing.T, c Codec) {
+		obj := &nested{
+			Items: [][]int64{{1, 2, 3}, {4}},
+		}
+		bs, err := c.MarshalToBytes(obj)
+		assert.NoError(t, err)
+
+		cases := []struct {
+			name   string
+			limits Limits
+			ok     bool
+		}{
+			{"NoLimits", Limits{}, true},
+			{"Enough", Limits{MaxBytes: 16, MaxLength: 3, MaxDepth: 3}, true},
+			{"MaxLength", Limits{MaxLength: 2}, false},
+			{"MaxDepth", Limits{MaxDepth: 2}, false},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				lc := c.(bytesWrapper).WithLimits(tc.limits)
+				var v nested
+				_, err := lc.UnmarshalFromBytes(bs, &v)
+				if tc.ok {
+					assert.NoError(t, err)
+					assert.Equal(t, obj, &v)
+				} else {
+					assert.Error(t, err)
+				}
+			})
+		}
+
+		bs, err = c.MarshalToBytes([]byte("test data for larger data over 16 bytes"))
+		assert.NoError(t, err)
+		var v []byte
+		_, err = c.(bytesWrapper).WithLimits(Limits{MaxBytes: 16}).UnmarshalFromBytes(bs, &v)
+		assert.Error(t, err)
+		_, err = c.(bytesWrapper).WithLimits(Limits{MaxBytes: 64}).UnmarshalFromBytes(bs, &v)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,155 @@
+package codec
+
+import (
+	"io"
+	"reflect"
+
+	cerrors "github.com/icon-project/goloop/common/errors"
+)
+
+// Limits restricts decoding of data from remote peers, so crafted data can't
+// make the decoder allocate huge memory or go deep into nested structures.
+// Zero for a field means no limit.
+type Limits struct {
+	MaxBytes  int // max size of a byte string or a raw value
+	MaxLength int // max number of items in a list or items and keys in a map
+	MaxDepth  int // max depth of nested lists and maps
+}
+
+type limitedCodec struct {
+	codecImpl
+	limits Limits
+}
+
+func (c *limitedCodec) NewDecoder(r io.Reader) DecodeAndCloser {
+	d := c.codecImpl.NewDecoder(r)
+	if di, ok := d.(*decoderImpl); ok {
+		di.real = newLimitedReader(di.real, &c.limits, 0)
+		if c.limits.MaxBytes > 0 {
+			di.SetMaxBytes(c.limits.MaxBytes)
+		}
+	}
+	return d
+}
+
+// WithLimits returns the codec decoding with the limits.
+func (c bytesWrapper) WithLimits(l Limits) bytesWrapper {
+	if lc, ok := c.codecImpl.(*limitedCodec); ok {
+		return bytesWrapper{&limitedCodec{lc.codecImpl, l}}
+	}
+	return bytesWrapper{&limitedCodec{c.codecImpl, l}}
+}
+
+type limitedReader struct {
+	real   Reader
+	limits *Limits
+	depth  int
+	count  int
+}
+
+func newLimitedReader(r Reader, l *Limits, depth int) *limitedReader {
+	return &limitedReader{
+		real:   r,
+		limits: l,
+		depth:  depth,
+	}
+}
+
+func (r *limitedReader) consumeN(cnt int) error {
+	if r.depth == 0 || r.limits.MaxLength <= 0 {
+		return nil
+	}
+	r.count += cnt
+	if r.count > r.limits.MaxLength {
+		return cerrors.Wrapf(ErrInvalidFormat, "TooManyItems(%d>%d)", r.count, r.limits.MaxLength)
+	}
+	return nil
+}
+
+func (r *limitedReader) checkBytes(bs []byte) error {
+	if r.limits.MaxBytes > 0 && len(bs) > r.limits.MaxBytes {
+		return cerrors.Wrapf(ErrInvalidFormat, "TooLargeBytes(%d>%d)", len(bs), r.limits.MaxBytes)
+	}
+	return nil
+}
+
+func (r *limitedReader) Skip(cnt int) error {
+	if err := r.real.Skip(cnt); err != nil {
+		return err
+	}
+	return r.consumeN(cnt)
+}
+
+func (r *limitedReader) child(read func() (Reader, error)) (Reader, error) {
+	if r.limits.MaxDepth > 0 && r.depth >= r.limits.MaxDepth {
+		return nil, cerrors.Wrapf(ErrInvalidFormat, "TooDeep(%d>=%d)", r.depth, r.limits.MaxDepth)
+	}
+	cr, err := read()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.consumeN(1); err != nil {
+		return nil, err
+	}
+	return newLimitedReader(cr, r.limits, r.depth+1), nil
+}
+
+func (r *limitedReader) ReadList() (Reader, error) {
+	return r.child(r.real.ReadList)
+}
+
+func (r *limitedReader) ReadMap() (Reader, error) {
+	return r.child(r.real.ReadMap)
+}
+
+func (r *limitedReader) ReadBytes() ([]byte, error) {
+	bs, err := r.real.ReadBytes()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkBytes(bs); err != nil {
+		return nil, err
+	}
+	return bs, r.consumeN(1)
+}
+
+func (r *limitedReader) ReadRaw() ([]byte, error) {
+	bs, err := r.real.ReadRaw()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkBytes(bs); err != nil {
+		return nil, err
+	}
+	return bs, r.consumeN(1)
+}
+
+func (r *limitedReader) ReadValue(v reflect.Value) error {
+	if err := r.real.ReadValue(v); err != nil {
+		return err
+	}
+	if v.Kind() == reflect.String {
+		if r.limits.MaxBytes > 0 && v.Len() > r.limits.MaxBytes {
+			return cerrors.Wrapf(ErrInvalidFormat, "TooLargeBytes(%d>%d)", v.Len(), r.limits.MaxBytes)
+		}
+	}
+	return r.consumeN(1)
+}
+
+func (r *limitedReader) Close() error {
+	return r.real.Close()
+}
+
+// SetMaxBytes sets the max size of bytes of the real reader, if it's
+// supported, within MaxBytes of the limits.
+func (r *limitedReader) SetMaxBytes(sz int) {
+	type setMaxByteser interface {
+		SetMaxBytes(sz int)
+	}
+	if r.limits.MaxBytes > 0 && sz > r.limits.MaxBytes {
+		sz = r.limits.MaxBytes
+	}
+	if ri, ok := r.real.(setMaxByteser); ok {
+		ri.SetMaxBytes(sz)
+	}
+}
@@ -75,3 +75,17 @@ func TestMarshalAny(t *testing.T) {
 		}
 	}
 }
+
+func FuzzUnmarshalAny(f *testing.F) {
+	bs, _ := MarshalAny(codec.BC, map[string]interface{}{
+		"addr":  MustNewAddressFromString("hx8888888888888888888888888888888888888888"),
+		"value": NewHexInt(2),
+	})
+	f.Add(bs)
+	f.Add([]byte{0xc0})
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		_, _ = UnmarshalAny(codec.BC, bs)
+		var v HexInt
+		_, _ = codec.BC.UnmarshalFromBytes(bs, &v)
+	})
+}
@@ -28,6 +28,8 @@ type consensusInfo struct {
 	proposer module.Address
 	voters   module.ValidatorList
 	voted    []bool
+	seed     []byte
+	blockID  []byte
 }
 
 func (c *consensusInfo) Proposer() module.Address {
@@ -42,9 +44,17 @@ func (c *consensusInfo) Voted() []bool {
 	return c.voted
 }
 
+func (c *consensusInfo) Seed() []byte {
+	return c.seed
+}
+
+func (c *consensusInfo) BlockID() []byte {
+	return c.blockID
+}
+
 func (c *consensusInfo) String() string {
-	return fmt.Sprintf("ConsensusInfo(proposer=%v,voters=%v,voted=%v)",
-		c.proposer, c.voters, c.voted)
+	return fmt.Sprintf("ConsensusInfo(proposer=%v,voters=%v,voted=%v,seed=%#x)",
+		c.proposer, c.voters, c.voted, c.seed)
 }
 
 func NewConsensusInfo(
@@ -52,7 +62,63 @@ func NewConsensusInfo(
 	voters module.ValidatorList,
 	voted []bool,
 ) module.ConsensusInfo {
-	return &consensusInfo{proposer, voters, voted}
+	return &consensusInfo{proposer, voters, voted, nil, nil}
+}
+
+// NewConsensusInfoWithSeed returns ConsensusInfo with the random seed for
+// the block derived from the proof of the proposer in the votes, and the ID
+// of the previous block voted by them.
+func NewConsensusInfoWithSeed(
+	proposer module.Address,
+	voters module.ValidatorList,
+	voted []bool,
+	votes module.CommitVoteSet,
+	blockID []byte,
+) module.ConsensusInfo {
+	return &consensusInfo{proposer, voters, voted, BlockSeedOf(votes), blockID}
+}
+
+type seedProvider interface {
+	Seed() []byte
+}
+
+// SeedOf returns the random seed of the block in the consensus information.
+// It returns nil if the information doesn't have it.
+func SeedOf(csi module.ConsensusInfo) []byte {
+	if sp, ok := csi.(seedProvider); ok {
+		return sp.Seed()
+	}
+	return nil
+}
+
+type blockIDProvider interface {
+	BlockID() []byte
+}
+
+// VotedBlockIDOf returns the ID of the previous block voted by the voters in
+// the consensus information. It returns nil if the information doesn't have
+// it.
+func VotedBlockIDOf(csi module.ConsensusInfo) []byte {
+	if bp, ok := csi.(blockIDProvider); ok {
+		return bp.BlockID()
+	}
+	return nil
+}
+
+type proposerSeedProvider interface {
+	ProposerSeed() []byte
+}
+
+// BlockSeedOf returns the random seed of the block including the votes.
+// It's the VRF output of the proposer for the height, which can be verified
+// with the public key of the proposer. The proposer knows it before proposing
+// the block, but can't choose it. It returns nil if the votes don't have the
+// proof of the proposer.
+func BlockSeedOf(votes module.CommitVoteSet) []byte {
+	if sp, ok := votes.(proposerSeedProvider); ok {
+		return sp.ProposerSeed()
+	}
+	return nil
 }
 
 func validatorListEqual(vl1, vl2 module.ValidatorList) bool {
@@ -92,5 +158,7 @@ func ConsensusInfoEqual(csi1, csi2 module.ConsensusInfo) bool {
 	}
 	return AddressEqual(csi1.Proposer(), csi2.Proposer()) &&
 		validatorListEqual(csi1.Voters(), csi2.Voters()) &&
-		votedEqual(csi1.Voted(), csi2.Voted())
+		votedEqual(csi1.Voted(), csi2.Voted()) &&
+		bytes.Equal(SeedOf(csi1), SeedOf(csi2)) &&
+		bytes.Equal(VotedBlockIDOf(csi1), VotedBlockIDOf(csi2))
 }
@@ -70,6 +70,23 @@ func (d dummyConsensusInfo) Voted() []bool {
 	return nil
 }
 
+type dummyCommitVoteSet struct {
+	module.CommitVoteSet
+	seed []byte
+}
+
+func (d *dummyCommitVoteSet) ProposerSeed() []byte {
+	return d.seed
+}
+
+type dummyLegacyCommitVoteSet struct {
+	module.CommitVoteSet
+}
+
+func (d *dummyLegacyCommitVoteSet) Hash() []byte {
+	return []byte{1}
+}
+
 func TestConsensusInfo_NewConsensusInfo(t *testing.T) {
 	voters := validatorList([]module.Address{
 		MustNewAddressFromString("hx5"),
@@ -155,6 +172,45 @@ func TestConsensusInfo_ConsensusInfoEqual(t *testing.T) {
 		csi2 := NewConsensusInfo(proposer, voters, voted3)
 		assert.False(t, ConsensusInfoEqual(csi, csi2))
 	})
+	t.Run("SeedDiff", func(t *testing.T) {
+		votes1 := &dummyCommitVoteSet{seed: []byte{1}}
+		votes2 := &dummyCommitVoteSet{seed: []byte{2}}
+		csi1 := NewConsensusInfoWithSeed(proposer, voters, voted, votes1, nil)
+		csi2 := NewConsensusInfoWithSeed(proposer, voters, voted, votes2, nil)
+		csi3 := NewConsensusInfoWithSeed(proposer, voters, voted, votes1, nil)
+		assert.False(t, ConsensusInfoEqual(csi, csi1))
+		assert.False(t, ConsensusInfoEqual(csi1, csi2))
+		assert.True(t, ConsensusInfoEqual(csi1, csi3))
+	})
+	t.Run("BlockIDDiff", func(t *testing.T) {
+		votes := &dummyCommitVoteSet{seed: []byte{1}}
+		csi1 := NewConsensusInfoWithSeed(proposer, voters, voted, votes, []byte("block1"))
+		csi2 := NewConsensusInfoWithSeed(proposer, voters, voted, votes, []byte("block2"))
+		csi3 := NewConsensusInfoWithSeed(proposer, voters, voted, votes, []byte("block1"))
+		assert.False(t, ConsensusInfoEqual(csi1, csi2))
+		assert.True(t, ConsensusInfoEqual(csi1, csi3))
+	})
+}
+
+func TestConsensusInfo_SeedOf(t *testing.T) {
+	votes := &dummyCommitVoteSet{seed: []byte{1, 2, 3}}
+	csi := NewConsensusInfoWithSeed(nil, nil, nil, votes, nil)
+	assert.Equal(t, []byte{1, 2, 3}, SeedOf(csi))
+
+	assert.Nil(t, SeedOf(NewConsensusInfo(nil, nil, nil)))
+	assert.Nil(t, SeedOf(NewConsensusInfoWithSeed(nil, nil, nil, nil, nil)))
+	assert.Nil(t, SeedOf(NewConsensusInfoWithSeed(nil, nil, nil, &dummyLegacyCommitVoteSet{}, nil)))
+	assert.Nil(t, SeedOf(dummyConsensusInfo(0)))
+	assert.Nil(t, SeedOf(nil))
+}
+
+func TestConsensusInfo_VotedBlockIDOf(t *testing.T) {
+	csi := NewConsensusInfoWithSeed(nil, nil, nil, nil, []byte("block"))
+	assert.Equal(t, []byte("block"), VotedBlockIDOf(csi))
+
+	assert.Nil(t, VotedBlockIDOf(NewConsensusInfo(nil, nil, nil)))
+	assert.Nil(t, VotedBlockIDOf(dummyConsensusInfo(0)))
+	assert.Nil(t, VotedBlockIDOf(nil))
 }
 
 func TestConsensusInfo_String(t *testing.T) {
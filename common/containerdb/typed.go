@@ -0,0 +1,200 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package containerdb
+
+import (
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// versionMarker starts bytes of objects stored with the version. Objects of
+// typed containers are lists like structs and slices, so bytes of them never
+// start with it.
+const versionMarker = 0x00
+
+const MaxSchemaVersion = 0xff
+
+// Schema is the encoding of objects of T in typed containers. Objects are
+// encoded with codec.BC. Objects are stored as they're encoded if Version is
+// zero, which is compatible with objects stored without the schema.
+// Otherwise, they're stored with the version, and objects stored in older
+// versions, including ones without the version, are converted by Migrate
+// on reading.
+type Schema[T any] struct {
+	Version int
+	Migrate func(version int, bs []byte) (T, error)
+}
+
+func (s *Schema[T]) encode(v T) ([]byte, error) {
+	bs, err := codec.BC.MarshalToBytes(v)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil || s.Version == 0 {
+		return bs, nil
+	}
+	if s.Version < 0 || s.Version > MaxSchemaVersion {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidSchemaVersion(version=%d)", s.Version)
+	}
+	return append([]byte{versionMarker, byte(s.Version)}, bs...), nil
+}
+
+func (s *Schema[T]) decode(bs []byte) (T, error) {
+	var v T
+	if len(bs) == 0 {
+		return v, nil
+	}
+	version := 0
+	if bs[0] == versionMarker {
+		if len(bs) < 2 {
+			return v, errors.CriticalFormatError.Errorf("InvalidVersionedBytes(bs=%#x)", bs)
+		}
+		version, bs = int(bs[1]), bs[2:]
+	}
+	current := 0
+	if s != nil {
+		current = s.Version
+	}
+	if version != current {
+		if version > current || s.Migrate == nil {
+			return v, errors.CriticalFormatError.Errorf(
+				"UnknownSchemaVersion(version=%d,current=%d)", version, current)
+		}
+		return s.Migrate(version, bs)
+	}
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &v); err != nil {
+		return v, errors.CriticalFormatError.Wrap(err, "InvalidTypedObject")
+	}
+	return v, nil
+}
+
+// TypedVarDB is VarDB storing an object of T with the schema.
+type TypedVarDB[T any] struct {
+	db     *VarDB
+	schema *Schema[T]
+}
+
+func NewTypedVarDB[T any](source interface{}, key KeyBuilder, schema *Schema[T]) *TypedVarDB[T] {
+	return &TypedVarDB[T]{
+		db:     NewVarDB(source, key),
+		schema: schema,
+	}
+}
+
+// Get returns the object, or zero value of T if it's not set.
+func (db *TypedVarDB[T]) Get() (T, error) {
+	return db.schema.decode(db.db.Bytes())
+}
+
+func (db *TypedVarDB[T]) Set(v T) error {
+	bs, err := db.schema.encode(v)
+	if err != nil {
+		return err
+	}
+	return db.db.Set(bs)
+}
+
+func (db *TypedVarDB[T]) Delete() error {
+	_, err := db.db.Delete()
+	return err
+}
+
+// TypedDictDB is DictDB of depth 1 storing objects of T with the schema.
+type TypedDictDB[T any] struct {
+	db     *DictDB
+	schema *Schema[T]
+}
+
+func NewTypedDictDB[T any](source interface{}, key KeyBuilder, schema *Schema[T]) *TypedDictDB[T] {
+	return &TypedDictDB[T]{
+		db:     NewDictDB(source, 1, key),
+		schema: schema,
+	}
+}
+
+// Get returns the object for the key, or zero value of T if it's not set.
+func (d *TypedDictDB[T]) Get(key interface{}) (T, error) {
+	var bs []byte
+	if value := d.db.Get(key); value != nil {
+		bs = value.Bytes()
+	}
+	return d.schema.decode(bs)
+}
+
+func (d *TypedDictDB[T]) Set(key interface{}, v T) error {
+	bs, err := d.schema.encode(v)
+	if err != nil {
+		return err
+	}
+	return d.db.Set(key, bs)
+}
+
+func (d *TypedDictDB[T]) Delete(key interface{}) error {
+	return d.db.Delete(key)
+}
+
+// TypedArrayDB is ArrayDB storing objects of T with the schema.
+type TypedArrayDB[T any] struct {
+	db     *ArrayDB
+	schema *Schema[T]
+}
+
+func NewTypedArrayDB[T any](source interface{}, key KeyBuilder, schema *Schema[T]) *TypedArrayDB[T] {
+	return &TypedArrayDB[T]{
+		db:     NewArrayDB(source, key),
+		schema: schema,
+	}
+}
+
+func (a *TypedArrayDB[T]) Size() int {
+	return a.db.Size()
+}
+
+func (a *TypedArrayDB[T]) Get(i int) (T, error) {
+	if i < 0 || i >= a.db.Size() {
+		var v T
+		return v, scoreresult.ErrInvalidContainerAccess
+	}
+	return a.schema.decode(a.db.Get(i).Bytes())
+}
+
+func (a *TypedArrayDB[T]) Set(i int, v T) error {
+	bs, err := a.schema.encode(v)
+	if err != nil {
+		return err
+	}
+	return a.db.Set(i, bs)
+}
+
+func (a *TypedArrayDB[T]) Put(v T) error {
+	bs, err := a.schema.encode(v)
+	if err != nil {
+		return err
+	}
+	return a.db.Put(bs)
+}
+
+// Pop removes the last object and returns it. It returns zero value of T if
+// it's empty.
+func (a *TypedArrayDB[T]) Pop() (T, error) {
+	var bs []byte
+	if value := a.db.Pop(); value != nil {
+		bs = value.Bytes()
+	}
+	return a.schema.decode(bs)
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package containerdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/trie/trie_manager"
+)
+
+type testRecordV0 struct {
+	Height int64
+}
+
+type testRecord struct {
+	Height int64
+	Name   string
+}
+
+func migrateTestRecord(version int, bs []byte) (*testRecord, error) {
+	switch version {
+	case 0:
+		var r0 testRecordV0
+		if _, err := codec.BC.UnmarshalFromBytes(bs, &r0); err != nil {
+			return nil, err
+		}
+		return &testRecord{Height: r0.Height, Name: "unknown"}, nil
+	default:
+		return nil, errors.IllegalArgumentError.Errorf("UnknownVersion(%d)", version)
+	}
+}
+
+func TestTypedVarDB(t *testing.T) {
+	tree := trie_manager.NewMutable(db.NewMapDB(), nil)
+	store := &TestStore{tree}
+	key := ToKey(HashBuilder, 1)
+
+	// stored without the schema
+	v0 := NewTypedVarDB(store, key, &Schema[*testRecordV0]{})
+	r0, err := v0.Get()
+	assert.NoError(t, err)
+	assert.Nil(t, r0)
+	assert.NoError(t, v0.Set(&testRecordV0{Height: 10}))
+	assert.Equal(t, codec.BC.MustMarshalToBytes(&testRecordV0{Height: 10}),
+		NewVarDB(store, key).Bytes())
+
+	schema := &Schema[*testRecord]{Version: 1, Migrate: migrateTestRecord}
+	v1 := NewTypedVarDB(store, key, schema)
+	r, err := v1.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, &testRecord{Height: 10, Name: "unknown"}, r)
+
+	assert.NoError(t, v1.Set(&testRecord{Height: 11, Name: "test"}))
+	r, err = v1.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, &testRecord{Height: 11, Name: "test"}, r)
+
+	// newer version can't be read
+	_, err = v0.Get()
+	assert.True(t, errors.CriticalFormatError.Equals(err))
+
+	assert.NoError(t, v1.Delete())
+	r, err = v1.Get()
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestTypedDictDB(t *testing.T) {
+	tree := trie_manager.NewMutable(db.NewMapDB(), nil)
+	d := NewTypedDictDB(&TestStore{tree}, ToKey(HashBuilder, 2), &Schema[[]int64]{})
+
+	v, err := d.Get("a")
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	assert.NoError(t, d.Set("a", []int64{1, 2}))
+	v, err = d.Get("a")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, v)
+
+	assert.NoError(t, d.Delete("a"))
+	v, err = d.Get("a")
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestTypedArrayDB(t *testing.T) {
+	tree := trie_manager.NewMutable(db.NewMapDB(), nil)
+	schema := &Schema[*testRecord]{Version: 1}
+	a := NewTypedArrayDB(&TestStore{tree}, ToKey(HashBuilder, 3), schema)
+
+	assert.NoError(t, a.Put(&testRecord{Height: 1, Name: "a"}))
+	assert.NoError(t, a.Put(&testRecord{Height: 2, Name: "b"}))
+	assert.Equal(t, 2, a.Size())
+
+	assert.NoError(t, a.Set(0, &testRecord{Height: 3, Name: "c"}))
+	r, err := a.Get(0)
+	assert.NoError(t, err)
+	assert.Equal(t, &testRecord{Height: 3, Name: "c"}, r)
+
+	_, err = a.Get(2)
+	assert.Error(t, err)
+
+	r, err = a.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, &testRecord{Height: 2, Name: "b"}, r)
+	assert.Equal(t, 1, a.Size())
+}
@@ -0,0 +1,187 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// VRF is ECVRF on secp256k1 with SHA-256 and try-and-increment hash to
+// curve, following the structure of RFC 9381.
+const (
+	// VRFProofLen is the bytes length of the VRF proof, [Gamma|c|s]
+	VRFProofLen = 33 + vrfChallengeLen + 32
+	// VRFOutputLen is the bytes length of the VRF output
+	VRFOutputLen = 32
+
+	vrfSuite        = 0xfe
+	vrfChallengeLen = 16
+)
+
+const (
+	vrfDomainHashToCurve = 0x01
+	vrfDomainChallenge   = 0x02
+	vrfDomainProofToHash = 0x03
+	vrfDomainBackTag     = 0x00
+)
+
+func vrfEncodePoint(p *secp256k1.JacobianPoint) []byte {
+	pt := *p
+	pt.ToAffine()
+	return secp256k1.NewPublicKey(&pt.X, &pt.Y).SerializeCompressed()
+}
+
+func vrfDecodePoint(bs []byte, p *secp256k1.JacobianPoint) error {
+	if len(bs) != PublicKeyLenCompressed {
+		return errors.New("invalid point length")
+	}
+	pk, err := secp256k1.ParsePubKey(bs)
+	if err != nil {
+		return err
+	}
+	pk.AsJacobian(p)
+	return nil
+}
+
+func vrfHashToCurve(pk []byte, alpha []byte, p *secp256k1.JacobianPoint) error {
+	buf := make([]byte, 0, 2+len(pk)+len(alpha)+2)
+	buf = append(buf, vrfSuite, vrfDomainHashToCurve)
+	buf = append(buf, pk...)
+	buf = append(buf, alpha...)
+	buf = append(buf, 0, vrfDomainBackTag)
+	ctrIdx := len(buf) - 2
+	for ctr := 0; ctr < 256; ctr++ {
+		buf[ctrIdx] = byte(ctr)
+		h := sha256.Sum256(buf)
+		if err := vrfDecodePoint(append([]byte{0x02}, h[:]...), p); err == nil {
+			return nil
+		}
+	}
+	return errors.New("fail to hash to curve")
+}
+
+func vrfChallenge(points ...*secp256k1.JacobianPoint) []byte {
+	h := sha256.New()
+	h.Write([]byte{vrfSuite, vrfDomainChallenge})
+	for _, p := range points {
+		h.Write(vrfEncodePoint(p))
+	}
+	h.Write([]byte{vrfDomainBackTag})
+	return h.Sum(nil)[:vrfChallengeLen]
+}
+
+func vrfProofToHash(gamma *secp256k1.JacobianPoint) []byte {
+	h := sha256.New()
+	h.Write([]byte{vrfSuite, vrfDomainProofToHash})
+	h.Write(vrfEncodePoint(gamma))
+	h.Write([]byte{vrfDomainBackTag})
+	return h.Sum(nil)
+}
+
+func vrfDecodeProof(proof []byte) (*secp256k1.JacobianPoint, *secp256k1.ModNScalar, *secp256k1.ModNScalar, error) {
+	if len(proof) != VRFProofLen {
+		return nil, nil, nil, errors.New("invalid proof length")
+	}
+	var gamma secp256k1.JacobianPoint
+	if err := vrfDecodePoint(proof[:33], &gamma); err != nil {
+		return nil, nil, nil, err
+	}
+	var c, s secp256k1.ModNScalar
+	c.SetByteSlice(proof[33 : 33+vrfChallengeLen])
+	if overflow := s.SetByteSlice(proof[33+vrfChallengeLen:]); overflow {
+		return nil, nil, nil, errors.New("invalid proof scalar")
+	}
+	return &gamma, &c, &s, nil
+}
+
+// VRFProve returns the VRF proof of alpha with the private key.
+func VRFProve(privKey *PrivateKey, alpha []byte) ([]byte, error) {
+	if privKey == nil {
+		return nil, errors.New("Invalid arguments")
+	}
+	pk := privKey.PublicKey().SerializeCompressed()
+	var h secp256k1.JacobianPoint
+	if err := vrfHashToCurve(pk, alpha, &h); err != nil {
+		return nil, err
+	}
+	x := &privKey.real.Key
+
+	var gamma secp256k1.JacobianPoint
+	secp256k1.ScalarMultNonConst(x, &h, &gamma)
+
+	hh := sha256.Sum256(vrfEncodePoint(&h))
+	skBytes := privKey.Bytes()
+	k := secp256k1.NonceRFC6979(skBytes, hh[:], nil, nil, 0)
+	defer k.Zero()
+
+	var u, v secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(k, &u)
+	secp256k1.ScalarMultNonConst(k, &h, &v)
+
+	var y secp256k1.JacobianPoint
+	privKey.real.PubKey().AsJacobian(&y)
+	cBytes := vrfChallenge(&y, &h, &gamma, &u, &v)
+
+	var c, s secp256k1.ModNScalar
+	c.SetByteSlice(cBytes)
+	s.Mul2(&c, x).Add(k)
+	sBytes := s.Bytes()
+
+	proof := make([]byte, 0, VRFProofLen)
+	proof = append(proof, vrfEncodePoint(&gamma)...)
+	proof = append(proof, cBytes...)
+	proof = append(proof, sBytes[:]...)
+	return proof, nil
+}
+
+// VRFVerify verifies the VRF proof of alpha with the public key, and
+// returns the VRF output of the proof.
+func VRFVerify(pubKey *PublicKey, alpha []byte, proof []byte) ([]byte, error) {
+	if pubKey == nil {
+		return nil, errors.New("Invalid arguments")
+	}
+	gamma, c, s, err := vrfDecodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	var h secp256k1.JacobianPoint
+	if err := vrfHashToCurve(pubKey.SerializeCompressed(), alpha, &h); err != nil {
+		return nil, err
+	}
+	var y secp256k1.JacobianPoint
+	pubKey.real.AsJacobian(&y)
+
+	var negC secp256k1.ModNScalar
+	negC.NegateVal(c)
+
+	// U = s*B - c*Y
+	var sb, cy, u secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(s, &sb)
+	secp256k1.ScalarMultNonConst(&negC, &y, &cy)
+	secp256k1.AddNonConst(&sb, &cy, &u)
+
+	// V = s*H - c*Gamma
+	var sh, cg, v secp256k1.JacobianPoint
+	secp256k1.ScalarMultNonConst(s, &h, &sh)
+	secp256k1.ScalarMultNonConst(&negC, gamma, &cg)
+	secp256k1.AddNonConst(&sh, &cg, &v)
+
+	if u.Z.Normalize().IsZero() || v.Z.Normalize().IsZero() {
+		return nil, errors.New("invalid proof")
+	}
+	if !bytes.Equal(vrfChallenge(&y, &h, gamma, &u, &v), proof[33:33+vrfChallengeLen]) {
+		return nil, errors.New("invalid proof")
+	}
+	return vrfProofToHash(gamma), nil
+}
+
+// VRFProofToHash returns the VRF output of the proof without verification.
+func VRFProofToHash(proof []byte) ([]byte, error) {
+	gamma, _, _, err := vrfDecodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	return vrfProofToHash(gamma), nil
+}
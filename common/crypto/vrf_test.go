@@ -0,0 +1,65 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVRF_ProveAndVerify(t *testing.T) {
+	sk, pk := GenerateKeyPair()
+	alpha := []byte("sample")
+
+	proof, err := VRFProve(sk, alpha)
+	assert.NoError(t, err)
+	assert.Len(t, proof, VRFProofLen)
+
+	proof2, err := VRFProve(sk, alpha)
+	assert.NoError(t, err)
+	assert.Equal(t, proof, proof2)
+
+	beta, err := VRFVerify(pk, alpha, proof)
+	assert.NoError(t, err)
+	assert.Len(t, beta, VRFOutputLen)
+
+	beta2, err := VRFProofToHash(proof)
+	assert.NoError(t, err)
+	assert.Equal(t, beta, beta2)
+
+	proof3, err := VRFProve(sk, []byte("other"))
+	assert.NoError(t, err)
+	beta3, err := VRFVerify(pk, []byte("other"), proof3)
+	assert.NoError(t, err)
+	assert.NotEqual(t, beta, beta3)
+}
+
+func TestVRF_VerifyInvalid(t *testing.T) {
+	sk, pk := GenerateKeyPair()
+	_, pk2 := GenerateKeyPair()
+	alpha := []byte("sample")
+	proof, err := VRFProve(sk, alpha)
+	assert.NoError(t, err)
+
+	_, err = VRFVerify(pk, []byte("other"), proof)
+	assert.Error(t, err)
+
+	_, err = VRFVerify(pk2, alpha, proof)
+	assert.Error(t, err)
+
+	for _, i := range []int{0, 1, 33, VRFProofLen - 1} {
+		bad := make([]byte, len(proof))
+		copy(bad, proof)
+		bad[i] ^= 0x01
+		_, err = VRFVerify(pk, alpha, bad)
+		assert.Error(t, err, "index=%d", i)
+	}
+
+	_, err = VRFVerify(pk, alpha, proof[:VRFProofLen-1])
+	assert.Error(t, err)
+	_, err = VRFProofToHash(nil)
+	assert.Error(t, err)
+	_, err = VRFVerify(nil, alpha, proof)
+	assert.Error(t, err)
+	_, err = VRFProve(nil, alpha)
+	assert.Error(t, err)
+}
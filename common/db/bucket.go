@@ -66,6 +66,33 @@ const (
 	// ListByMerkleRootBase is the base for the bucket that maps list
 	// from network type dependent merkle root(list)
 	ListByMerkleRootBase BucketID = "L"
+
+	// InternalCallsByTxHash maps internal calls from transaction hash.
+	// They are recorded only if the chain is configured for it.
+	InternalCallsByTxHash BucketID = "N"
+
+	// ValidatorStatsByHeight maps statistics of validators from height.
+	// They are recorded by the consensus for recent heights.
+	ValidatorStatsByHeight BucketID = "V"
+
+	// AccountBloomByEpoch maps bloom filter of the accounts involved in
+	// transactions from the epoch of heights.
+	// They are recorded for the heights finalized by the node.
+	AccountBloomByEpoch BucketID = "A"
+
+	// TransactionsByAddress maps locators of transactions from the address
+	// involved in them. They are recorded only if the chain is configured
+	// for it.
+	TransactionsByAddress BucketID = "X"
+
+	// TokenBalanceByHolder maps balances of IRC-2 and IRC-3 tokens from
+	// the token and the holder. TokensByHolder maps tokens received by
+	// the holder, and TokenTransfersByAddress maps transfers from the
+	// address involved in them. They are recorded only if the chain is
+	// configured for it.
+	TokenBalanceByHolder    BucketID = "B"
+	TokensByHolder          BucketID = "O"
+	TokenTransfersByAddress BucketID = "F"
 )
 
 // internalKey returns key prefixed with the bucket's id.
@@ -117,3 +117,15 @@ func (b *CodedBucket) Put(value interface{}) error {
 	}
 	return err
 }
+
+func (b *CodedBucket) Delete(key interface{}) error {
+	keyBS, err := b._marshal(key)
+	if err != nil {
+		return err
+	}
+	err = b.dbBucket.Delete(keyBS)
+	if err != nil {
+		err = errors.Wrap(err, "Fail to delete KV DB")
+	}
+	return err
+}
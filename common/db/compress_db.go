@@ -0,0 +1,205 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+// FlagCompression is the flag for the database context. Values written
+// through the context with true for the flag are compressed if the
+// database is made by NewCompressDB with the compression enabled.
+const FlagCompression = "db.compression"
+
+type Compression string
+
+const (
+	CompressionNone    Compression = "none"
+	CompressionZstd    Compression = "zstd"
+	CompressionDefault             = CompressionNone
+)
+
+// Compressed value is stored with the header below. Buckets having
+// dictionaries shouldn't have values starting with compressMagic (ex:
+// RLP encoded lists).
+//
+//	compressMagic | codec | dictionary index | compressed bytes
+const (
+	compressMagic        = 0x00
+	compressHeaderSize   = 3
+	compressMinValueSize = 64
+)
+
+const (
+	codecZstd byte = 1
+)
+
+// compressDictionary compresses values with the dictionary as the raw
+// content. Encoder and decoder are safe for concurrent use with EncodeAll
+// and DecodeAll.
+type compressDictionary struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newCompressDictionary(id uint32, dict []byte) *compressDictionary {
+	encoder, err := zstd.NewWriter(nil,
+		zstd.WithEncoderDictRaw(id, dict),
+		zstd.WithEncoderCRC(false),
+		zstd.WithSingleSegment(true),
+	)
+	if err != nil {
+		panic(err)
+	}
+	decoder, err := zstd.NewReader(nil,
+		zstd.WithDecoderDictRaw(id, dict),
+		zstd.WithDecoderConcurrency(0),
+	)
+	if err != nil {
+		panic(err)
+	}
+	return &compressDictionary{encoder: encoder, decoder: decoder}
+}
+
+func (d *compressDictionary) compress(header, value []byte) ([]byte, error) {
+	buf := make([]byte, len(header), len(value))
+	copy(buf, header)
+	return d.encoder.EncodeAll(value, buf), nil
+}
+
+func (d *compressDictionary) decompress(value []byte) ([]byte, error) {
+	return d.decoder.DecodeAll(value, nil)
+}
+
+// dictionaryMap keeps dictionaries for the buckets to be compressed. Values
+// refer the dictionary with its index, so registered dictionary shouldn't be
+// changed or removed. New dictionary is used for writing after it's added.
+// The ID of the dictionary in compressed frames is the index plus one.
+var dictionaryMap = map[BucketID][]*compressDictionary{
+	MerkleTrie: {newCompressDictionary(1, merkleTrieDictionary)},
+}
+
+func RegisterCompressionDictionary(bk BucketID, dict []byte) {
+	dicts := dictionaryMap[bk]
+	if len(dicts) >= 0xff {
+		panic("TooManyDictionaries")
+	}
+	dictionaryMap[bk] = append(dicts, newCompressDictionary(uint32(len(dicts)+1), dict))
+}
+
+func IsCompression(s string) bool {
+	switch Compression(s) {
+	case CompressionNone, CompressionZstd:
+		return true
+	default:
+		return false
+	}
+}
+
+type compressBucket struct {
+	real     Bucket
+	dicts    []*compressDictionary
+	compress bool
+}
+
+func (bk *compressBucket) Get(key []byte) ([]byte, error) {
+	value, err := bk.real.Get(key)
+	if err != nil || len(value) < compressHeaderSize || value[0] != compressMagic {
+		return value, err
+	}
+	if value[1] != codecZstd || int(value[2]) >= len(bk.dicts) {
+		return nil, errors.InvalidStateError.Errorf(
+			"UnknownCompression(codec=%d,dict=%d)", value[1], value[2])
+	}
+	org, err := bk.dicts[value[2]].decompress(value[compressHeaderSize:])
+	if err != nil {
+		return nil, errors.InvalidStateError.Wrapf(err,
+			"FailToDecompress(key=%#x)", key)
+	}
+	return org, nil
+}
+
+func (bk *compressBucket) Has(key []byte) (bool, error) {
+	return bk.real.Has(key)
+}
+
+func (bk *compressBucket) Set(key []byte, value []byte) error {
+	if bk.compress && len(value) >= compressMinValueSize {
+		idx := len(bk.dicts) - 1
+		header := []byte{compressMagic, codecZstd, byte(idx)}
+		if cv, err := bk.dicts[idx].compress(header, value); err != nil {
+			return err
+		} else if len(cv) < len(value) {
+			return bk.real.Set(key, cv)
+		}
+	}
+	return bk.real.Set(key, value)
+}
+
+func (bk *compressBucket) Delete(key []byte) error {
+	return bk.real.Delete(key)
+}
+
+type compressDB struct {
+	Database
+	compression Compression
+}
+
+func (cdb *compressDB) GetBucket(id BucketID) (Bucket, error) {
+	bk, err := cdb.Database.GetBucket(id)
+	if err != nil {
+		return nil, err
+	}
+	dicts, ok := dictionaryMap[id]
+	if !ok {
+		return bk, nil
+	}
+	compress, _ := GetFlag(cdb.Database, FlagCompression).(bool)
+	return &compressBucket{
+		real:     bk,
+		dicts:    dicts,
+		compress: compress && cdb.compression != CompressionNone,
+	}, nil
+}
+
+func (cdb *compressDB) WithFlags(flags Flags) Context {
+	return &compressDB{WithFlags(cdb.Database, flags), cdb.compression}
+}
+
+func (cdb *compressDB) GetFlag(name string) interface{} {
+	return GetFlag(cdb.Database, name)
+}
+
+func (cdb *compressDB) Flags() Flags {
+	if ctx, ok := cdb.Database.(Context); ok {
+		return ctx.Flags()
+	}
+	return nil
+}
+
+// NewCompressDB returns the database decompressing values of the buckets
+// having dictionaries on reading. Values written through the context
+// with FlagCompression are compressed with the compression.
+func NewCompressDB(database Database, c Compression) (Database, error) {
+	if !IsCompression(string(c)) {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidCompression(%q)", c)
+	}
+	return &compressDB{database, c}, nil
+}
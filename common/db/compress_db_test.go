@@ -0,0 +1,95 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressDB_Basic(t *testing.T) {
+	mdb := NewMapDB()
+	_, err := NewCompressDB(mdb, "lz4")
+	assert.Error(t, err)
+
+	cdb, err := NewCompressDB(mdb, CompressionZstd)
+	assert.NoError(t, err)
+
+	key1, key2 := []byte("key1"), []byte("key2")
+	value := append([]byte{0xf8, 0x80}, bytes.Repeat(
+		[]byte("Transfer(Address,Address,int,bytes)"), 4)...)
+
+	// plain writes are not compressed
+	bk, err := cdb.GetBucket(MerkleTrie)
+	assert.NoError(t, err)
+	assert.NoError(t, bk.Set(key1, value))
+
+	// writes through the context with the flag are compressed
+	bk, err = WithFlags(cdb, Flags{FlagCompression: true}).GetBucket(MerkleTrie)
+	assert.NoError(t, err)
+	assert.NoError(t, bk.Set(key2, value))
+
+	raw, err := mdb.GetBucket(MerkleTrie)
+	assert.NoError(t, err)
+	stored, err := raw.Get(key1)
+	assert.NoError(t, err)
+	assert.Equal(t, value, stored)
+	stored, err = raw.Get(key2)
+	assert.NoError(t, err)
+	assert.True(t, len(stored) < len(value))
+
+	// reads are decompressed regardless of the flag
+	for _, k := range [][]byte{key1, key2} {
+		v, err := bk.Get(k)
+		assert.NoError(t, err)
+		assert.Equal(t, value, v)
+	}
+	bk, err = cdb.GetBucket(MerkleTrie)
+	assert.NoError(t, err)
+	v, err := bk.Get(key2)
+	assert.NoError(t, err)
+	assert.Equal(t, value, v)
+
+	// it can read compressed values without the compression
+	ndb, err := NewCompressDB(mdb, CompressionNone)
+	assert.NoError(t, err)
+	bk, err = WithFlags(ndb, Flags{FlagCompression: true}).GetBucket(MerkleTrie)
+	assert.NoError(t, err)
+	v, err = bk.Get(key2)
+	assert.NoError(t, err)
+	assert.Equal(t, value, v)
+	assert.NoError(t, bk.Set(key1, value))
+	stored, err = raw.Get(key1)
+	assert.NoError(t, err)
+	assert.Equal(t, value, stored)
+}
+
+func TestCompressDB_Flags(t *testing.T) {
+	cdb, err := NewCompressDB(WithFlags(NewMapDB(), Flags{"test": 1}), CompressionZstd)
+	assert.NoError(t, err)
+
+	ctx := WithFlags(cdb, Flags{FlagCompression: true})
+	assert.Equal(t, 1, GetFlag(ctx, "test"))
+	assert.Equal(t, true, GetFlag(ctx, FlagCompression))
+	assert.Nil(t, GetFlag(cdb, FlagCompression))
+
+	// it keeps the compression for the contexts made by others
+	_, ok := WithFlags(ctx, Flags{"other": 2}).(*compressDB)
+	assert.True(t, ok)
+}
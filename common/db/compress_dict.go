@@ -0,0 +1,58 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+// merkleTrieDictionary is the dictionary for receipts and event logs in
+// MerkleTrie. It has signatures of frequent events, and more frequent ones
+// are placed later for shorter distances.
+// DO NOT MODIFY; stored values depend on it. Register new one instead.
+var merkleTrieDictionary = []byte("" +
+	"BTPMessage(int,int)" +
+	"BTPNetworkOpened(int,int)" +
+	"BTPNetworkClosed(int,int)" +
+	"BTPNetworkTypeActivated(str,int)" +
+	"ContractSet(Address,Address,str,bytes)" +
+	"ProposalRegistered(bytes,Address,int)" +
+	"ProposalVoted(bytes,Address,bool)" +
+	"ProposalStatusChanged(bytes,int)" +
+	"DepositAdded(bytes,Address,int,int)" +
+	"DepositWithdrawn(bytes,Address,int,int)" +
+	"PRepRegistered(Address)" +
+	"PRepUnregistered(Address)" +
+	"PRepSet(Address)" +
+	"PRepJailed(Address,int)" +
+	"PRepUnjailed(Address,int)" +
+	"PenaltyImposed(Address,int,int)" +
+	"Slashed(Address,Address,int)" +
+	"SlashingRateChanged(str,int)" +
+	"CommissionRateScheduled(Address,int,int)" +
+	"GovernanceVariablesSet(Address,int)" +
+	"RewardFundTransferred(str,Address,Address,int)" +
+	"RewardFundBurned(str,Address,int)" +
+	"TermStarted(int,int,int)" +
+	"ICXIssued(int,int,int,int)" +
+	"PRepIssued(int,int,int,int)" +
+	"IScoreClaimed(int,int)" +
+	"StakeChanged(Address,int,int)" +
+	"UnbondChanged(Address,int,int)" +
+	"DelegationChanged(Address,Address,int,int)" +
+	"Approval(Address,Address,int)" +
+	"TransferSingle(Address,Address,Address,int,int)" +
+	"ICXBurned(int)" +
+	"ICXTransfer(Address,Address,int)" +
+	"Transfer(Address,Address,int)" +
+	"Transfer(Address,Address,int,bytes)")
@@ -31,6 +31,18 @@ func registerDBCreator(backend BackendType, creator dbCreator, force bool) {
 	backends[backend] = creator
 }
 
+var readOnlyBackends = map[BackendType]dbCreator{}
+
+// ErrDatabaseLocked is returned by OpenReadOnly if the database is locked
+// by the node using it.
+var ErrDatabaseLocked = errors.NewBase(errors.InvalidStateError, "DatabaseLocked")
+
+// registerReadOnlyDBCreator registers the creator opening an existing
+// database in read-only mode for the backend supporting it.
+func registerReadOnlyDBCreator(backend BackendType, creator dbCreator) {
+	readOnlyBackends[backend] = creator
+}
+
 func RegisteredBackendTypes() []string {
 	l := make([]string, 0)
 	for k := range backends {
@@ -44,6 +56,19 @@ func Open(dir, dbtype, name string) (Database, error) {
 	return openDatabase(BackendType(dbtype), name, dir)
 }
 
+// OpenReadOnly opens an existing database in read-only mode. Writing to the
+// database fails. It's used to serve queries with the database of others.
+// The backend may lock the database even in read-only mode, so only the
+// database not used by other nodes, like a replicated copy, can be opened.
+// It returns ErrDatabaseLocked for the database in use.
+func OpenReadOnly(dir, dbtype, name string) (Database, error) {
+	dbCreator, ok := readOnlyBackends[BackendType(dbtype)]
+	if !ok {
+		return nil, errors.UnsupportedError.Errorf("ReadOnlyNotSupported(type=%s)", dbtype)
+	}
+	return dbCreator(name, dir)
+}
+
 func openDatabase(backend BackendType, name string, dir string) (Database, error) {
 	dbCreator, ok := backends[backend]
 	if !ok {
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
 )
 
 func testDatabase_GetSetDelete(t *testing.T, creator dbCreator) {
@@ -258,3 +260,51 @@ func TestDatabase_OperationAfterClose(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabase_OpenReadOnly(t *testing.T) {
+	for name := range readOnlyBackends {
+		t.Run(string(name), func(t *testing.T) {
+			dir := t.TempDir()
+			key := []byte("hello")
+			value := []byte("world")
+
+			testDB, err := Open(dir, string(name), "test")
+			assert.NoError(t, err)
+			bucket, err := testDB.GetBucket("hello")
+			assert.NoError(t, err)
+			assert.NoError(t, bucket.Set(key, value))
+			assert.NoError(t, testDB.Close())
+
+			roDB, err := OpenReadOnly(dir, string(name), "test")
+			assert.NoError(t, err)
+			defer roDB.Close()
+			bucket, err = roDB.GetBucket("hello")
+			assert.NoError(t, err)
+			stored, err := bucket.Get(key)
+			assert.NoError(t, err)
+			assert.Equal(t, value, stored)
+			assert.Error(t, bucket.Set(key, []byte("other")))
+		})
+	}
+
+	_, err := OpenReadOnly(t.TempDir(), "unknown", "test")
+	assert.Error(t, err)
+}
+
+func TestDatabase_OpenReadOnlyLocked(t *testing.T) {
+	for name := range readOnlyBackends {
+		t.Run(string(name), func(t *testing.T) {
+			dir := t.TempDir()
+			testDB, err := Open(dir, string(name), "test")
+			assert.NoError(t, err)
+
+			_, err = OpenReadOnly(dir, string(name), "test")
+			assert.True(t, errors.Is(err, ErrDatabaseLocked))
+
+			assert.NoError(t, testDB.Close())
+			roDB, err := OpenReadOnly(dir, string(name), "test")
+			assert.NoError(t, err)
+			assert.NoError(t, roDB.Close())
+		})
+	}
+}
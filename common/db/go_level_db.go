@@ -3,9 +3,12 @@ package db
 import (
 	"path/filepath"
 	"sync"
+	"syscall"
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	"github.com/icon-project/goloop/common/errors"
 )
 
 const GoLevelDBBackend BackendType = "goleveldb"
@@ -15,6 +18,21 @@ func init() {
 		return NewGoLevelDB(name, dir)
 	}
 	registerDBCreator(GoLevelDBBackend, dbCreator, false)
+	registerReadOnlyDBCreator(GoLevelDBBackend, newGoLevelDBReadOnly)
+}
+
+// newGoLevelDBReadOnly opens the database in read-only mode. goleveldb takes
+// the shared lock of the database even in read-only mode, so it fails on the
+// database used by a running node, which holds the exclusive lock.
+func newGoLevelDBReadOnly(name string, dir string) (Database, error) {
+	database, err := NewGoLevelDBWithOpts(name, dir, &opt.Options{ReadOnly: true})
+	if err != nil {
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, errors.Wrapf(ErrDatabaseLocked, "fail to lock database name=%s err=%v", name, err)
+		}
+		return nil, err
+	}
+	return database, nil
 }
 
 func NewGoLevelDB(name string, dir string) (*GoLevelDB, error) {
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// remoteError is the error came from other process. Only the code and the
+// message are known, so it's caused by the base error of the same code.
+type remoteError struct {
+	code
+	message
+}
+
+func (e *remoteError) Format(f fmt.State, c rune) {
+	formatError(e, f, c)
+}
+
+func (e *remoteError) Is(target error) bool {
+	if base, ok := target.(*codedError); ok {
+		return base.code == e.code
+	}
+	return false
+}
+
+// NewRemote returns the error of the code and the message came from other
+// process. It can be matched with base errors of the code by Is.
+func NewRemote(c Code, msg string) error {
+	return &remoteError{code(c), message(msg)}
+}
+
+// Marshal returns compact bytes of the error including the code, which is
+// the varint of the code followed by the message. It returns nil for nil.
+func Marshal(e error) []byte {
+	if e == nil {
+		return nil
+	}
+	msg := e.Error()
+	bs := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(msg))
+	n := binary.PutVarint(bs, int64(CodeOf(e)))
+	return append(bs[:n], msg...)
+}
+
+// Unmarshal returns the error from bytes returned by Marshal. It returns nil
+// for empty bytes.
+func Unmarshal(bs []byte) error {
+	if len(bs) == 0 {
+		return nil
+	}
+	c, n := binary.Varint(bs)
+	if n <= 0 {
+		return NewRemote(UnknownError, fmt.Sprintf("InvalidErrorBytes(bs=%#x)", bs))
+	}
+	return NewRemote(Code(c), string(bs[n:]))
+}
+
+// IsCode returns whether the code of the error is one of the codes.
+func IsCode(e error, codes ...Code) bool {
+	c := CodeOf(e)
+	for _, code := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshal(t *testing.T) {
+	assert.Nil(t, Marshal(nil))
+	assert.NoError(t, Unmarshal(nil))
+
+	cases := []struct {
+		name string
+		err  error
+		code Code
+	}{
+		{"Coded", NotFoundError.Errorf("NoBlock(height=%d)", 10), NotFoundError},
+		{"Wrapped", Wrap(ErrTimeout, "Waiting"), TimeoutError},
+		{"Critical", CriticalIOError.New("Disk"), CriticalIOError},
+		{"NoCode", fmt.Errorf("plain"), UnknownError},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal(Marshal(tt.err))
+			assert.Error(t, err)
+			assert.Equal(t, tt.code, CodeOf(err))
+			assert.Equal(t, tt.err.Error(), err.Error())
+		})
+	}
+
+	err := Unmarshal(Marshal(NotFoundError.New("NoBlock")))
+	assert.True(t, Is(err, ErrNotFound))
+	assert.False(t, Is(err, ErrTimeout))
+
+	err = Unmarshal([]byte{0x80})
+	assert.Equal(t, UnknownError, CodeOf(err))
+}
+
+func TestIsCode(t *testing.T) {
+	err := Wrap(NewRemote(TimeoutError, "Timeout"), "Query")
+	assert.True(t, IsCode(err, TimeoutError))
+	assert.True(t, IsCode(err, NotFoundError, TimeoutError))
+	assert.False(t, IsCode(err, NotFoundError))
+	assert.False(t, IsCode(err))
+	assert.True(t, IsCode(nil, Success))
+}
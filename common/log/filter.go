@@ -13,6 +13,8 @@ type logFilter struct {
 
 	fileWriter  io.Writer
 	filterLevel Level
+
+	router *chainRouter
 }
 
 func newLogFilter(formatter logrus.Formatter) *logFilter {
@@ -21,6 +23,7 @@ func newLogFilter(formatter logrus.Formatter) *logFilter {
 		defaultLevel: TraceLevel,
 		filterLevel:  TraceLevel,
 		moduleLevels: make(map[string]Level, 6),
+		router:       newChainRouter(),
 	}
 }
 
@@ -42,12 +45,16 @@ func (f *logFilter) Format(e *logrus.Entry) ([]byte, error) {
 		}
 	}
 
-	if e.Level > logrus.Level(level) && f.fileWriter == nil {
+	fileWriter := f.fileWriter
+	if w := f.router.writerOf(e); w != nil {
+		fileWriter = w
+	}
+	if e.Level > logrus.Level(level) && fileWriter == nil {
 		return nil, nil
 	}
 	buf, err := f.formatter.Format(e)
-	if f.fileWriter != nil && len(buf) > 0 {
-		f.fileWriter.Write(buf)
+	if fileWriter != nil && len(buf) > 0 {
+		fileWriter.Write(buf)
 	}
 	if e.Level > logrus.Level(level) {
 		return nil, nil
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"bytes"
 	"fmt"
 	"path"
 	"strings"
@@ -57,3 +58,19 @@ func (customFormatter) Format(e *logrus.Entry) ([]byte, error) {
 	buf.WriteString("\n")
 	return buf.Bytes(), nil
 }
+
+// formatEntry formats the entry with its own buffer, so that hooks can use
+// it before the buffer of the entry is prepared. Fields added by HookWrapper
+// are excluded, because the line already has them.
+func formatEntry(e *logrus.Entry) ([]byte, error) {
+	ce := *e
+	ce.Buffer = new(bytes.Buffer)
+	ce.Data = make(logrus.Fields, len(e.Data))
+	for k, v := range e.Data {
+		if k == "logtime" || k == "src" {
+			continue
+		}
+		ce.Data[k] = v
+	}
+	return customFormatter{}.Format(&ce)
+}
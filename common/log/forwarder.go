@@ -3,6 +3,7 @@ package log
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"path"
 	"strconv"
@@ -17,6 +18,8 @@ import (
 const (
 	HookVendorFluentd  = "fluentd"
 	HookVendorLogstash = "logstash"
+	HookVendorSyslog   = "syslog"
+	HookVendorLoki     = "loki"
 )
 
 type ForwarderConfig struct {
@@ -138,9 +141,25 @@ func (h *HookWrapper) Fire(e *logrus.Entry) error {
 	return err
 }
 
+func (h *HookWrapper) Close() error {
+	if c, ok := h.h.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 type HookCreater func(c *ForwarderConfig) (logrus.Hook, error)
 
 func AddForwarder(c *ForwarderConfig) error {
+	h, err := newForwarderHook(c)
+	if err != nil {
+		return err
+	}
+	globalLogger.addHook(h)
+	return nil
+}
+
+func newForwarderHook(c *ForwarderConfig) (logrus.Hook, error) {
 	if c.Level == "" {
 		c.Level = "info"
 	}
@@ -158,14 +177,17 @@ func AddForwarder(c *ForwarderConfig) error {
 		h, err = newHook(c, fluentHookCreater)
 	case HookVendorLogstash:
 		h, err = newHook(c, logstashHookCreater)
+	case HookVendorSyslog:
+		h, err = newHook(c, syslogHookCreater)
+	case HookVendorLoki:
+		h, err = newHook(c, lokiHookCreater)
 	default:
-		return fmt.Errorf("not supported forwarder %s", c.Vendor)
+		return nil, fmt.Errorf("not supported forwarder %s", c.Vendor)
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
-	globalLogger.addHook(h)
-	return nil
+	return h, nil
 }
 
 func newHook(c *ForwarderConfig, f HookCreater) (logrus.Hook, error) {
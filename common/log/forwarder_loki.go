@@ -0,0 +1,188 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const (
+	lokiPushPath         = "/loki/api/v1/push"
+	lokiDefaultBatchSize = 512
+	lokiDefaultBatchWait = time.Second
+	lokiDefaultTimeout   = 5 * time.Second
+	lokiQueueSize        = 8192
+)
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPush struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+// lokiHook pushes entries to Loki in batches on its own goroutine, so that
+// logging is not blocked by the remote server.
+type lokiHook struct {
+	url       string
+	labels    map[string]string
+	client    *http.Client
+	batchSize int
+	batchWait time.Duration
+
+	queue chan [2]string
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+func (h *lokiHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *lokiHook) Fire(e *logrus.Entry) error {
+	bs, err := formatEntry(e)
+	if err != nil {
+		return err
+	}
+	v := [2]string{
+		strconv.FormatInt(e.Time.UnixNano(), 10),
+		strings.TrimRight(string(bs), "\n"),
+	}
+	select {
+	case h.queue <- v:
+		return nil
+	default:
+		return errors.InvalidStateError.New("LokiQueueFull")
+	}
+}
+
+func (h *lokiHook) push(values [][2]string) error {
+	bs, err := json.Marshal(&lokiPush{
+		Streams: []*lokiStream{{Stream: h.labels, Values: values}},
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(bs))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("LokiPushFailure(status=%s)", resp.Status)
+	}
+	return nil
+}
+
+func (h *lokiHook) run() {
+	defer close(h.done)
+
+	ticker := time.NewTicker(h.batchWait)
+	defer ticker.Stop()
+
+	values := make([][2]string, 0, h.batchSize)
+	flush := func() {
+		if len(values) == 0 {
+			return
+		}
+		if err := h.push(values); err != nil {
+			// it can't use logger for it, because it would be routed here again.
+			fmt.Fprintf(os.Stderr, "Fail to push logs to %s err=%+v\n", h.url, err)
+		}
+		values = values[:0]
+	}
+	for {
+		select {
+		case v := <-h.queue:
+			values = append(values, v)
+			if len(values) >= h.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-h.stop:
+			for {
+				select {
+				case v := <-h.queue:
+					values = append(values, v)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (h *lokiHook) Close() error {
+	h.once.Do(func() {
+		close(h.stop)
+	})
+	<-h.done
+	return nil
+}
+
+// lokiHookCreater makes a hook pushing entries to Loki. The address is the
+// URL of the Loki server (ex: "http://localhost:3100"), and the push path
+// is used if the URL doesn't have it.
+func lokiHookCreater(c *ForwarderConfig) (logrus.Hook, error) {
+	addr := c.Address
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidLokiAddress(address=%s)", c.Address)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = lokiPushPath
+	}
+	opt := struct {
+		Labels    map[string]string `json:"labels"`
+		BatchSize int               `json:"batch_size"`
+		BatchWait time.Duration     `json:"batch_wait"`
+		Timeout   time.Duration     `json:"timeout"`
+	}{
+		BatchSize: lokiDefaultBatchSize,
+		BatchWait: lokiDefaultBatchWait,
+		Timeout:   lokiDefaultTimeout,
+	}
+	if err := c.UnmarshalByOptions(&opt); err != nil {
+		return nil, err
+	}
+	if opt.BatchSize <= 0 || opt.BatchWait <= 0 {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidBatch(size=%d,wait=%s)", opt.BatchSize, opt.BatchWait)
+	}
+	labels := map[string]string{"job": c.Name}
+	for k, v := range opt.Labels {
+		labels[k] = v
+	}
+	h := &lokiHook{
+		url:       u.String(),
+		labels:    labels,
+		client:    &http.Client{Timeout: opt.Timeout},
+		batchSize: opt.BatchSize,
+		batchWait: opt.BatchWait,
+		queue:     make(chan [2]string, lokiQueueSize),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go h.run()
+	return h, nil
+}
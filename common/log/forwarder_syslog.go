@@ -0,0 +1,72 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package log
+
+import (
+	"log/syslog"
+
+	"github.com/sirupsen/logrus"
+)
+
+type syslogHook struct {
+	w *syslog.Writer
+}
+
+func (h *syslogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *syslogHook) Fire(e *logrus.Entry) error {
+	bs, err := formatEntry(e)
+	if err != nil {
+		return err
+	}
+	line := string(bs)
+	switch e.Level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return h.w.Crit(line)
+	case logrus.ErrorLevel:
+		return h.w.Err(line)
+	case logrus.WarnLevel:
+		return h.w.Warning(line)
+	case logrus.InfoLevel:
+		return h.w.Info(line)
+	default:
+		return h.w.Debug(line)
+	}
+}
+
+func (h *syslogHook) Close() error {
+	return h.w.Close()
+}
+
+// syslogHookCreater makes a hook for the syslog server of the address.
+// It uses the local syslog server if the address is empty.
+func syslogHookCreater(c *ForwarderConfig) (logrus.Hook, error) {
+	opt := struct {
+		Facility int `json:"facility"`
+	}{
+		Facility: int(syslog.LOG_LOCAL0),
+	}
+	if err := c.UnmarshalByOptions(&opt); err != nil {
+		return nil, err
+	}
+	priority := syslog.Priority(opt.Facility) | syslog.LOG_INFO
+	if c.Address == "" {
+		w, err := syslog.New(priority, c.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &syslogHook{w}, nil
+	}
+	network, hostPort, err := c.NetworkAndHostPort("udp")
+	if err != nil {
+		return nil, err
+	}
+	w, err := syslog.Dial(network, hostPort, priority, c.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogHook{w}, nil
+}
@@ -0,0 +1,14 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package log
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+func syslogHookCreater(c *ForwarderConfig) (logrus.Hook, error) {
+	return nil, errors.UnsupportedError.New("SyslogNotSupported")
+}
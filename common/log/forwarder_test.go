@@ -0,0 +1,149 @@
+package log
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForwarderConfig_Parse(t *testing.T) {
+	var c ForwarderConfig
+	err := json.Unmarshal([]byte(`{
+		"vendor": "loki",
+		"address": "localhost:3100",
+		"level": "warn",
+		"options": { "batch_size": 10, "labels": { "env": "test" } }
+	}`), &c)
+	assert.NoError(t, err)
+	assert.Equal(t, HookVendorLoki, c.Vendor)
+
+	var opt struct {
+		BatchSize int               `json:"batch_size"`
+		Labels    map[string]string `json:"labels"`
+	}
+	assert.NoError(t, c.UnmarshalByOptions(&opt))
+	assert.Equal(t, 10, opt.BatchSize)
+	assert.Equal(t, map[string]string{"env": "test"}, opt.Labels)
+
+	lvs, err := c.HookLevels()
+	assert.NoError(t, err)
+	assert.Equal(t, []logrus.Level{
+		logrus.WarnLevel, logrus.ErrorLevel, logrus.FatalLevel, logrus.PanicLevel,
+	}, lvs)
+
+	c.Level = "invalid"
+	_, err = c.HookLevels()
+	assert.Error(t, err)
+}
+
+func TestForwarderConfig_NetworkAndHostPort(t *testing.T) {
+	cases := []struct {
+		address  string
+		network  string
+		hostPort string
+		ok       bool
+	}{
+		{"localhost:514", "udp", "localhost:514", true},
+		{"tcp://localhost:514", "tcp", "localhost:514", true},
+		{"unix://localhost:514", "", "", false},
+		{"tcp://", "", "", false},
+	}
+	for _, c := range cases {
+		fc := &ForwarderConfig{Address: c.address}
+		network, hostPort, err := fc.NetworkAndHostPort("udp")
+		if !c.ok {
+			assert.Error(t, err, c.address)
+			continue
+		}
+		assert.NoError(t, err, c.address)
+		assert.Equal(t, c.network, network)
+		assert.Equal(t, c.hostPort, hostPort)
+	}
+}
+
+func TestNewForwarderHook_Invalid(t *testing.T) {
+	cases := []*ForwarderConfig{
+		{Vendor: "unknown"},
+		{Vendor: HookVendorLoki, Address: "http://"},
+		{Vendor: HookVendorLoki, Address: "localhost:3100", Level: "invalid"},
+		{Vendor: HookVendorLoki, Address: "localhost:3100",
+			Options: map[string]interface{}{"batch_size": 0}},
+		{Vendor: HookVendorLoki, Address: "localhost:3100",
+			Options: map[string]interface{}{"batch_size": "ten"}},
+		{Vendor: HookVendorSyslog, Address: "unix://localhost:514"},
+	}
+	for _, c := range cases {
+		_, err := newForwarderHook(c)
+		assert.Error(t, err, "config=%+v", c)
+	}
+}
+
+func TestLokiHook(t *testing.T) {
+	var lock sync.Mutex
+	var pushes []*lokiPush
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, lokiPushPath, r.URL.Path)
+		bs, _ := io.ReadAll(r.Body)
+		push := new(lokiPush)
+		assert.NoError(t, json.Unmarshal(bs, push))
+		lock.Lock()
+		pushes = append(pushes, push)
+		lock.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	h, err := newForwarderHook(&ForwarderConfig{
+		Vendor:  HookVendorLoki,
+		Address: server.URL,
+		Name:    "test",
+		Options: map[string]interface{}{
+			"batch_size": 2,
+			"batch_wait": int64(time.Hour),
+			"labels":     map[string]string{"env": "test"},
+		},
+	})
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		e := newTestEntry(logrus.InfoLevel, logrus.Fields{})
+		e.Message = "message"
+		assert.NoError(t, h.Fire(e))
+	}
+	// remaining entries are pushed on close
+	assert.NoError(t, h.(*HookWrapper).Close())
+
+	lock.Lock()
+	defer lock.Unlock()
+	var values int
+	for _, p := range pushes {
+		assert.Len(t, p.Streams, 1)
+		assert.Equal(t, map[string]string{"job": "test", "env": "test"}, p.Streams[0].Stream)
+		values += len(p.Streams[0].Values)
+	}
+	assert.Equal(t, 3, values)
+}
+
+func TestLokiHook_PushFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	h, err := lokiHookCreater(&ForwarderConfig{Address: server.URL, Name: "test"})
+	assert.NoError(t, err)
+	lh := h.(*lokiHook)
+	defer lh.Close()
+	assert.Error(t, lh.push([][2]string{{"0", "message"}}))
+
+	// entries are dropped if the queue is full
+	full := &lokiHook{queue: make(chan [2]string)}
+	assert.Error(t, full.Fire(newTestEntry(logrus.InfoLevel, logrus.Fields{})))
+}
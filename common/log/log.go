@@ -144,6 +144,7 @@ type Logger interface {
 	SetOutput(output io.Writer)
 
 	addHook(hook logrus.Hook)
+	chainRouter() *chainRouter
 }
 
 type entryWrapper struct {
@@ -160,6 +161,10 @@ func (w entryWrapper) addHook(hook logrus.Hook) {
 	w.Logger.AddHook(hook)
 }
 
+func (w entryWrapper) chainRouter() *chainRouter {
+	return w.Logger.Formatter.(*logFilter).router
+}
+
 func (w entryWrapper) WithFields(fields Fields) Logger {
 	return &entryWrapper{
 		w.Entry.WithFields(logrus.Fields(fields)),
@@ -230,6 +235,10 @@ func (w loggerWrapper) addHook(hook logrus.Hook) {
 	w.Logger.AddHook(hook)
 }
 
+func (w loggerWrapper) chainRouter() *chainRouter {
+	return w.Logger.Formatter.(*logFilter).router
+}
+
 func (w loggerWrapper) WithFields(fields Fields) Logger {
 	return &entryWrapper{
 		w.Logger.WithFields(logrus.Fields(fields)),
@@ -360,7 +369,9 @@ func New() Logger {
 	logger.Out = os.Stderr
 	logger.Level = logrus.DebugLevel
 	logger.SetReportCaller(true)
-	logger.SetFormatter(newLogFilter(customFormatter{}))
+	filter := newLogFilter(customFormatter{})
+	logger.SetFormatter(filter)
+	logger.AddHook(filter.router)
 	return &loggerWrapper{
 		Logger: logger,
 	}
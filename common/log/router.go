@@ -0,0 +1,159 @@
+package log
+
+import (
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+// ChainLogConfig is the configuration for routing logs of a chain.
+type ChainLogConfig struct {
+	// Writer for logs of the chain. Logs written to it are not written to
+	// the common file writer.
+	Writer *WriterConfig `json:"writer,omitempty"`
+	// Forwarders for logs of the chain, used in addition to the common
+	// forwarders.
+	Forwarders []*ForwarderConfig `json:"forwarders,omitempty"`
+}
+
+type chainRoute struct {
+	writer io.Writer
+	hooks  []logrus.Hook
+}
+
+func (r *chainRoute) close() {
+	if c, ok := r.writer.(io.Closer); ok {
+		_ = c.Close()
+	}
+	for _, h := range r.hooks {
+		if c, ok := h.(io.Closer); ok {
+			_ = c.Close()
+		}
+	}
+}
+
+// chainRouter routes entries to the writer and the hooks of the chain
+// by the value of FieldKeyCID.
+type chainRouter struct {
+	lock   sync.RWMutex
+	routes map[string]*chainRoute
+}
+
+func (r *chainRouter) routeOf(e *logrus.Entry) *chainRoute {
+	cid, ok := e.Data[FieldKeyCID]
+	if !ok {
+		return nil
+	}
+	key, ok := cid.(string)
+	if !ok {
+		return nil
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.routes[key]
+}
+
+func (r *chainRouter) writerOf(e *logrus.Entry) io.Writer {
+	if route := r.routeOf(e); route != nil {
+		return route.writer
+	}
+	return nil
+}
+
+func (r *chainRouter) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (r *chainRouter) Fire(e *logrus.Entry) error {
+	route := r.routeOf(e)
+	if route == nil {
+		return nil
+	}
+	for _, h := range route.hooks {
+		if !hasLevel(h.Levels(), e.Level) {
+			continue
+		}
+		if err := h.Fire(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *chainRouter) set(key string, route *chainRoute) {
+	r.lock.Lock()
+	old := r.routes[key]
+	if route != nil {
+		r.routes[key] = route
+	} else {
+		delete(r.routes, key)
+	}
+	r.lock.Unlock()
+
+	if old != nil {
+		old.close()
+	}
+}
+
+func newChainRouter() *chainRouter {
+	return &chainRouter{
+		routes: make(map[string]*chainRoute),
+	}
+}
+
+func hasLevel(lvs []logrus.Level, lv logrus.Level) bool {
+	for _, l := range lvs {
+		if l == lv {
+			return true
+		}
+	}
+	return false
+}
+
+func chainKey(cid int) string {
+	return strconv.FormatInt(int64(cid), 16)
+}
+
+func newChainRoute(cfg *ChainLogConfig) (*chainRoute, error) {
+	route := new(chainRoute)
+	if cfg.Writer != nil {
+		if cfg.Writer.Filename == "" {
+			return nil, errors.IllegalArgumentError.New("NoFilenameForWriter")
+		}
+		w, err := NewWriter(cfg.Writer)
+		if err != nil {
+			return nil, err
+		}
+		route.writer = w
+	}
+	for _, fc := range cfg.Forwarders {
+		h, err := newForwarderHook(fc)
+		if err != nil {
+			route.close()
+			return nil, err
+		}
+		route.hooks = append(route.hooks, h)
+	}
+	return route, nil
+}
+
+// SetChainLog routes logs of the chain to the writer and the forwarders
+// of the configuration, replacing the previous routing of the chain.
+func SetChainLog(cid int, cfg *ChainLogConfig) error {
+	route, err := newChainRoute(cfg)
+	if err != nil {
+		return err
+	}
+	globalLogger.chainRouter().set(chainKey(cid), route)
+	return nil
+}
+
+// RemoveChainLog removes routing of logs of the chain, then logs of
+// the chain are written to the common log stream.
+func RemoveChainLog(cid int) {
+	globalLogger.chainRouter().set(chainKey(cid), nil)
+}
@@ -0,0 +1,116 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type testHook struct {
+	levels  []logrus.Level
+	entries []*logrus.Entry
+	closed  bool
+}
+
+func (h *testHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+func (h *testHook) Fire(e *logrus.Entry) error {
+	h.entries = append(h.entries, e)
+	return nil
+}
+
+func (h *testHook) Close() error {
+	h.closed = true
+	return nil
+}
+
+func newTestEntry(level logrus.Level, fields logrus.Fields) *logrus.Entry {
+	e := logrus.NewEntry(logrus.New())
+	e.Level = level
+	e.Data = fields
+	return e
+}
+
+func TestChainRouter_Route(t *testing.T) {
+	r := newChainRouter()
+	buf := new(bytes.Buffer)
+	hook := &testHook{levels: []logrus.Level{logrus.WarnLevel}}
+	r.set(chainKey(0x10), &chainRoute{
+		writer: buf,
+		hooks:  []logrus.Hook{hook},
+	})
+
+	cases := []struct {
+		name   string
+		fields logrus.Fields
+		routed bool
+	}{
+		{"Matched", logrus.Fields{FieldKeyCID: "10"}, true},
+		{"OtherChain", logrus.Fields{FieldKeyCID: "11"}, false},
+		{"NoChain", logrus.Fields{}, false},
+		{"NotString", logrus.Fields{FieldKeyCID: 0x10}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := newTestEntry(logrus.WarnLevel, c.fields)
+			if c.routed {
+				assert.Equal(t, buf, r.writerOf(e))
+			} else {
+				assert.Nil(t, r.writerOf(e))
+			}
+			hook.entries = nil
+			assert.NoError(t, r.Fire(e))
+			if c.routed {
+				assert.Equal(t, []*logrus.Entry{e}, hook.entries)
+			} else {
+				assert.Empty(t, hook.entries)
+			}
+		})
+	}
+
+	// hooks get entries of their levels only
+	hook.entries = nil
+	e := newTestEntry(logrus.InfoLevel, logrus.Fields{FieldKeyCID: "10"})
+	assert.NoError(t, r.Fire(e))
+	assert.Empty(t, hook.entries)
+}
+
+func TestChainRouter_Set(t *testing.T) {
+	r := newChainRouter()
+	key := chainKey(1)
+	hook1 := &testHook{levels: logrus.AllLevels}
+	r.set(key, &chainRoute{hooks: []logrus.Hook{hook1}})
+
+	hook2 := &testHook{levels: logrus.AllLevels}
+	r.set(key, &chainRoute{hooks: []logrus.Hook{hook2}})
+	assert.True(t, hook1.closed)
+	assert.False(t, hook2.closed)
+
+	e := newTestEntry(logrus.InfoLevel, logrus.Fields{FieldKeyCID: key})
+	assert.NoError(t, r.Fire(e))
+	assert.Empty(t, hook1.entries)
+	assert.Len(t, hook2.entries, 1)
+
+	r.set(key, nil)
+	assert.True(t, hook2.closed)
+	assert.Nil(t, r.routeOf(e))
+}
+
+func TestNewChainRoute(t *testing.T) {
+	_, err := newChainRoute(&ChainLogConfig{Writer: &WriterConfig{}})
+	assert.Error(t, err)
+
+	_, err = newChainRoute(&ChainLogConfig{
+		Forwarders: []*ForwarderConfig{{Vendor: "unknown"}},
+	})
+	assert.Error(t, err)
+
+	route, err := newChainRoute(&ChainLogConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, route.writer)
+	assert.Empty(t, route.hooks)
+}
@@ -2,8 +2,12 @@ package log
 
 import (
 	"io"
+	"sync"
+	"time"
 
 	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/icon-project/goloop/common/errors"
 )
 
 type WriterConfig struct {
@@ -13,15 +17,73 @@ type WriterConfig struct {
 	MaxBackups int    `json:"maxbackups"`
 	LocalTime  bool   `json:"localtime"`
 	Compress   bool   `json:"compress"`
+	// Rotate is the interval of time based rotation in time.Duration
+	// format (ex: "24h"). Empty means size based rotation only.
+	Rotate string `json:"rotate,omitempty"`
+}
+
+// rotateWriter rotates the log file on every boundary of the interval
+// in addition to size based rotation.
+type rotateWriter struct {
+	*lumberjack.Logger
+	interval time.Duration
+
+	lock   sync.Mutex
+	timer  *time.Timer
+	closed bool
+}
+
+func (w *rotateWriter) schedule() {
+	now := time.Now()
+	next := now.Truncate(w.interval).Add(w.interval)
+	w.timer = time.AfterFunc(next.Sub(now), w.onTime)
+}
+
+func (w *rotateWriter) onTime() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		return
+	}
+	_ = w.Logger.Rotate()
+	w.schedule()
+}
+
+func (w *rotateWriter) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if !w.closed {
+		w.closed = true
+		w.timer.Stop()
+	}
+	return w.Logger.Close()
 }
 
 func NewWriter(cfg *WriterConfig) (io.Writer, error) {
-	return &lumberjack.Logger{
+	l := &lumberjack.Logger{
 		Filename:   cfg.Filename,
 		MaxSize:    cfg.MaxSize,
 		MaxAge:     cfg.MaxAge,
 		MaxBackups: cfg.MaxBackups,
 		LocalTime:  cfg.LocalTime,
 		Compress:   cfg.Compress,
-	}, nil
+	}
+	if cfg.Rotate == "" {
+		return l, nil
+	}
+	d, err := time.ParseDuration(cfg.Rotate)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err,
+			"InvalidRotate(rotate=%s)", cfg.Rotate)
+	}
+	if d < time.Minute {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"TooShortRotate(rotate=%s)", cfg.Rotate)
+	}
+	w := &rotateWriter{
+		Logger:   l,
+		interval: d,
+	}
+	w.schedule()
+	return w, nil
 }
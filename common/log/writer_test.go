@@ -0,0 +1,55 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+func TestNewWriter(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "test.log")
+
+	w, err := NewWriter(&WriterConfig{Filename: filename})
+	assert.NoError(t, err)
+	assert.IsType(t, &lumberjack.Logger{}, w)
+
+	_, err = NewWriter(&WriterConfig{Filename: filename, Rotate: "invalid"})
+	assert.Error(t, err)
+
+	_, err = NewWriter(&WriterConfig{Filename: filename, Rotate: "59s"})
+	assert.Error(t, err)
+}
+
+func TestRotateWriter(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "test.log")
+
+	w, err := NewWriter(&WriterConfig{Filename: filename, Rotate: "1h"})
+	assert.NoError(t, err)
+	rw := w.(*rotateWriter)
+
+	_, err = rw.Write([]byte("first\n"))
+	assert.NoError(t, err)
+	rw.onTime()
+	_, err = rw.Write([]byte("second\n"))
+	assert.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	bs, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, "second\n", string(bs))
+
+	assert.NoError(t, rw.Close())
+
+	// it doesn't rotate after close
+	rw.onTime()
+	files, err = os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package throttle
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Throttle limits the number of requests of peers served concurrently and
+// the bandwidth used for responses. If all slots are used, a released slot
+// is given to the waiter of the peer using the least slots, and to the
+// oldest one among them, so a peer sending many requests can't starve
+// others. Nil Throttle doesn't limit anything.
+type Throttle struct {
+	mutex     sync.Mutex
+	limit     int
+	bandwidth int64
+
+	active  int
+	peers   map[string]int
+	waiters list.List
+	next    time.Time
+}
+
+type waiter struct {
+	peer string
+	ch   chan struct{}
+}
+
+// New returns the throttle serving up to limit requests concurrently and
+// sending up to bandwidth bytes per second. Zero or negative value means no
+// limit for it.
+func New(limit int, bandwidth int64) *Throttle {
+	return &Throttle{
+		limit:     limit,
+		bandwidth: bandwidth,
+		peers:     make(map[string]int),
+	}
+}
+
+func (t *Throttle) Limit() int {
+	if t == nil {
+		return 0
+	}
+	return t.limit
+}
+
+func (t *Throttle) Bandwidth() int64 {
+	if t == nil {
+		return 0
+	}
+	return t.bandwidth
+}
+
+func (t *Throttle) _grant(peer string) {
+	t.active += 1
+	t.peers[peer] += 1
+}
+
+// Acquire waits for a slot to serve the request of the peer. It returns
+// false if cancel is closed before getting it. The slot shall be released
+// by Release with the same peer.
+func (t *Throttle) Acquire(peer string, cancel <-chan struct{}) bool {
+	if t == nil || t.limit <= 0 {
+		return true
+	}
+	t.mutex.Lock()
+	if t.active < t.limit && t.waiters.Len() == 0 {
+		t._grant(peer)
+		t.mutex.Unlock()
+		return true
+	}
+	w := &waiter{peer: peer, ch: make(chan struct{})}
+	e := t.waiters.PushBack(w)
+	t.mutex.Unlock()
+
+	select {
+	case <-w.ch:
+		return true
+	case <-cancel:
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		select {
+		case <-w.ch:
+			// granted already, so give it to others
+			t._release(peer)
+		default:
+			t.waiters.Remove(e)
+		}
+		return false
+	}
+}
+
+func (t *Throttle) _release(peer string) {
+	t.active -= 1
+	if cnt := t.peers[peer] - 1; cnt > 0 {
+		t.peers[peer] = cnt
+	} else {
+		delete(t.peers, peer)
+	}
+	for t.active < t.limit && t.waiters.Len() > 0 {
+		var next *list.Element
+		for e := t.waiters.Front(); e != nil; e = e.Next() {
+			if next == nil || t.peers[e.Value.(*waiter).peer] < t.peers[next.Value.(*waiter).peer] {
+				next = e
+			}
+		}
+		w := t.waiters.Remove(next).(*waiter)
+		t._grant(w.peer)
+		close(w.ch)
+	}
+}
+
+// Release releases the slot acquired for the peer.
+func (t *Throttle) Release(peer string) {
+	if t == nil || t.limit <= 0 {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t._release(peer)
+}
+
+// WaitToSend waits until n bytes can be sent under the bandwidth. Bytes are
+// reserved in the order of calls. It returns false if cancel is closed
+// before it.
+func (t *Throttle) WaitToSend(n int, cancel <-chan struct{}) bool {
+	if t == nil || t.bandwidth <= 0 {
+		return true
+	}
+	t.mutex.Lock()
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	delay := t.next.Sub(now)
+	t.next = t.next.Add(time.Duration(int64(n) * int64(time.Second) / t.bandwidth))
+	t.mutex.Unlock()
+
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-cancel:
+		return false
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package throttle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func acquireAsync(t *Throttle, peer string, cancel <-chan struct{}) <-chan bool {
+	ch := make(chan bool, 1)
+	go func() {
+		ch <- t.Acquire(peer, cancel)
+	}()
+	return ch
+}
+
+func waitFor(ch <-chan bool) (bool, bool) {
+	select {
+	case v := <-ch:
+		return v, true
+	case <-time.After(200 * time.Millisecond):
+		return false, false
+	}
+}
+
+func TestThrottle_Acquire(t *testing.T) {
+	th := New(2, 0)
+	assert.True(t, th.Acquire("a", nil))
+	assert.True(t, th.Acquire("a", nil))
+
+	// a waits first, but b gets the slot as a uses more slots
+	a3 := acquireAsync(th, "a", nil)
+	time.Sleep(20 * time.Millisecond)
+	b1 := acquireAsync(th, "b", nil)
+	time.Sleep(20 * time.Millisecond)
+
+	th.Release("a")
+	ok, done := waitFor(b1)
+	assert.True(t, done)
+	assert.True(t, ok)
+	_, done = waitFor(a3)
+	assert.False(t, done)
+
+	th.Release("a")
+	ok, done = waitFor(a3)
+	assert.True(t, done)
+	assert.True(t, ok)
+
+	// canceled while waiting
+	cancel := make(chan struct{})
+	c1 := acquireAsync(th, "c", cancel)
+	time.Sleep(20 * time.Millisecond)
+	close(cancel)
+	ok, done = waitFor(c1)
+	assert.True(t, done)
+	assert.False(t, ok)
+
+	th.Release("a")
+	th.Release("b")
+	assert.Equal(t, 0, th.active)
+	assert.Len(t, th.peers, 0)
+	assert.Equal(t, 0, th.waiters.Len())
+}
+
+func TestThrottle_WaitToSend(t *testing.T) {
+	th := New(0, 1000)
+	start := time.Now()
+	assert.True(t, th.WaitToSend(100, nil))
+	assert.True(t, th.WaitToSend(100, nil))
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+
+	cancel := make(chan struct{})
+	close(cancel)
+	assert.False(t, th.WaitToSend(100, cancel))
+}
+
+func TestThrottle_Nil(t *testing.T) {
+	var th *Throttle
+	assert.True(t, th.Acquire("a", nil))
+	th.Release("a")
+	assert.True(t, th.WaitToSend(1<<20, nil))
+	assert.Equal(t, 0, th.Limit())
+	assert.EqualValues(t, 0, th.Bandwidth())
+}
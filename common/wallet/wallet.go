@@ -27,6 +27,10 @@ func (w *softwareWallet) PublicKey() []byte {
 	return w.pkey.SerializeCompressed()
 }
 
+func (w *softwareWallet) ProveVRF(alpha []byte) ([]byte, error) {
+	return crypto.VRFProve(w.skey, alpha)
+}
+
 func New() module.Wallet {
 	sk, pk := crypto.GenerateKeyPair()
 	return &softwareWallet{
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package watchdog detects goroutines of components stopped making progress.
+// Long-running loops register heartbeats, and beat while they work. The
+// watchdog logs stack dumps of all goroutines if one doesn't beat in its
+// timeout, and calls the handler of the heartbeat. Handlers recover their
+// components only if it's configured, so stalls are only logged by default.
+package watchdog
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/icon-project/goloop/common/log"
+)
+
+const (
+	DefaultCheckInterval     = time.Second
+	DefaultStackDumpInterval = 10 * time.Minute
+	maxStackDumpSize         = 64 * 1024 * 1024
+)
+
+type Watchdog struct {
+	lock     sync.Mutex
+	beats    map[*Heartbeat]struct{}
+	interval time.Duration
+	logger   log.Logger
+	stop     chan struct{}
+	recover  int32
+
+	// stack dumps are logged only once in dumpInterval
+	dumpInterval time.Duration
+	lastDump     time.Time
+}
+
+// Heartbeat is registered to the watchdog for a loop. The loop calls Beat
+// when it starts working, and Idle when it waits for new works, so it isn't
+// considered as stalled while it waits. All methods of nil Heartbeat do
+// nothing.
+type Heartbeat struct {
+	w       *Watchdog
+	name    string
+	timeout time.Duration
+	handler func()
+
+	beat    int64 // unix nano of the last beat, 0 if idle
+	stalled int32
+}
+
+func (h *Heartbeat) Beat() {
+	if h == nil {
+		return
+	}
+	atomic.StoreInt64(&h.beat, time.Now().UnixNano())
+	atomic.StoreInt32(&h.stalled, 0)
+}
+
+func (h *Heartbeat) Idle() {
+	if h == nil {
+		return
+	}
+	atomic.StoreInt64(&h.beat, 0)
+	atomic.StoreInt32(&h.stalled, 0)
+}
+
+// Close unregisters the heartbeat from the watchdog.
+func (h *Heartbeat) Close() {
+	if h == nil {
+		return
+	}
+	h.w.unregister(h)
+}
+
+// checkStall returns true once for the stall.
+func (h *Heartbeat) checkStall(now int64) bool {
+	beat := atomic.LoadInt64(&h.beat)
+	if beat == 0 || now-beat < int64(h.timeout) {
+		return false
+	}
+	return atomic.CompareAndSwapInt32(&h.stalled, 0, 1)
+}
+
+// Register registers the heartbeat for the loop with the name. The loop is
+// stalled if it doesn't beat in the timeout while it's working. The handler
+// is called in a new goroutine on the stall if it's not nil, and it shall
+// recover the component only if it's configured, like by SetRecover. The
+// heartbeat starts idle.
+func (w *Watchdog) Register(name string, timeout time.Duration, handler func()) *Heartbeat {
+	h := &Heartbeat{
+		w:       w,
+		name:    name,
+		timeout: timeout,
+		handler: handler,
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.beats[h] = struct{}{}
+	return h
+}
+
+func (w *Watchdog) unregister(h *Heartbeat) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	delete(w.beats, h)
+}
+
+func (w *Watchdog) check() []*Heartbeat {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	now := time.Now().UnixNano()
+	var stalled []*Heartbeat
+	for h := range w.beats {
+		if h.checkStall(now) {
+			stalled = append(stalled, h)
+		}
+	}
+	sort.Slice(stalled, func(i, j int) bool {
+		return stalled[i].name < stalled[j].name
+	})
+	return stalled
+}
+
+// SetRecover sets whether handlers of heartbeats recover stalled components
+// like peers and executors.
+func (w *Watchdog) SetRecover(yes bool) {
+	var v int32
+	if yes {
+		v = 1
+	}
+	atomic.StoreInt32(&w.recover, v)
+}
+
+// Recover returns true if handlers of heartbeats shall recover stalled
+// components.
+func (w *Watchdog) Recover() bool {
+	return atomic.LoadInt32(&w.recover) != 0
+}
+
+func stackDump() string {
+	buf := make([]byte, 1024*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= maxStackDumpSize {
+			return string(buf[:n])
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+}
+
+// needStackDump returns true if the stack dump wasn't logged in the interval,
+// since it may be large and stalls of many components tend to continue.
+func (w *Watchdog) needStackDump(now time.Time) bool {
+	if !w.lastDump.IsZero() && now.Sub(w.lastDump) < w.dumpInterval {
+		return false
+	}
+	w.lastDump = now
+	return true
+}
+
+func (w *Watchdog) onStalled(stalled []*Heartbeat) {
+	names := make([]string, len(stalled))
+	for i, h := range stalled {
+		names[i] = h.name
+	}
+	if w.needStackDump(time.Now()) {
+		w.logger.Errorf("Watchdog: STALLED [%s]\n%s",
+			strings.Join(names, ","), stackDump())
+	} else {
+		w.logger.Errorf("Watchdog: STALLED [%s] (stack dump skipped)",
+			strings.Join(names, ","))
+	}
+	for _, h := range stalled {
+		if h.handler != nil {
+			go h.handler()
+		}
+	}
+}
+
+func (w *Watchdog) checkRoutine() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if stalled := w.check(); len(stalled) > 0 {
+				w.onStalled(stalled)
+			}
+		}
+	}
+}
+
+// Term stops checking heartbeats.
+func (w *Watchdog) Term() {
+	close(w.stop)
+}
+
+func New(interval time.Duration, logger log.Logger) *Watchdog {
+	w := &Watchdog{
+		beats:    make(map[*Heartbeat]struct{}),
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+
+		dumpInterval: DefaultStackDumpInterval,
+	}
+	go w.checkRoutine()
+	return w
+}
+
+var (
+	defaultWatchdog     *Watchdog
+	defaultWatchdogOnce sync.Once
+)
+
+// Default returns the watchdog shared in the process.
+func Default() *Watchdog {
+	defaultWatchdogOnce.Do(func() {
+		defaultWatchdog = New(DefaultCheckInterval, log.WithFields(log.Fields{
+			log.FieldKeyModule: "WD",
+		}))
+	})
+	return defaultWatchdog
+}
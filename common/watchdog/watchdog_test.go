@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+)
+
+func TestWatchdog_Stall(t *testing.T) {
+	w := New(10*time.Millisecond, log.New())
+	defer w.Term()
+
+	stalled := make(chan string, 10)
+	hb1 := w.Register("hb1", 50*time.Millisecond, func() {
+		stalled <- "hb1"
+	})
+	hb2 := w.Register("hb2", 50*time.Millisecond, func() {
+		stalled <- "hb2"
+	})
+	defer hb2.Close()
+
+	// idle one isn't stalled
+	hb1.Beat()
+	hb2.Idle()
+	select {
+	case name := <-stalled:
+		assert.Equal(t, "hb1", name)
+	case <-time.After(time.Second):
+		assert.Fail(t, "no stall")
+	}
+
+	// stall is reported once until it beats again
+	select {
+	case name := <-stalled:
+		assert.Fail(t, "stalled again", name)
+	case <-time.After(100 * time.Millisecond):
+	}
+	hb1.Beat()
+	select {
+	case name := <-stalled:
+		assert.Equal(t, "hb1", name)
+	case <-time.After(time.Second):
+		assert.Fail(t, "no stall")
+	}
+
+	// closed one isn't checked
+	hb1.Beat()
+	hb1.Close()
+	select {
+	case name := <-stalled:
+		assert.Fail(t, "stalled after close", name)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	var nilBeat *Heartbeat
+	nilBeat.Beat()
+	nilBeat.Idle()
+	nilBeat.Close()
+}
+
+func TestWatchdog_Recover(t *testing.T) {
+	w := New(time.Second, log.New())
+	defer w.Term()
+
+	assert.False(t, w.Recover())
+	w.SetRecover(true)
+	assert.True(t, w.Recover())
+	w.SetRecover(false)
+	assert.False(t, w.Recover())
+}
+
+func TestWatchdog_StackDumpInterval(t *testing.T) {
+	w := New(time.Second, log.New())
+	defer w.Term()
+
+	now := time.Now()
+	assert.True(t, w.needStackDump(now))
+	assert.False(t, w.needStackDump(now.Add(time.Minute)))
+	assert.False(t, w.needStackDump(now.Add(DefaultStackDumpInterval-time.Second)))
+	assert.True(t, w.needStackDump(now.Add(DefaultStackDumpInterval)))
+	assert.False(t, w.needStackDump(now.Add(DefaultStackDumpInterval+time.Second)))
+}
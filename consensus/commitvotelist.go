@@ -15,6 +15,7 @@ import (
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service"
+	"github.com/icon-project/goloop/service/sigverify"
 )
 
 var vlCodec = codec.BC
@@ -47,14 +48,22 @@ func (bvl *blockCommitVoteList) VerifyBlock(block module.BlockData, validators m
 		}
 	}
 	vset := make([]bool, validators.Len())
-	msg := newVoteMessage()
-	msg.Height = block.Height()
-	msg.Round = bvl.Round
-	msg.Type = VoteTypePrecommit
-	msg.SetRoundDecision(block.ID(), bvl.BlockPartSetIDAndNTSVoteCount, nil)
+	msgs := make([]*VoteMessage, len(bvl.Items))
 	for i, item := range bvl.Items {
+		msg := newVoteMessage()
+		msg.Height = block.Height()
+		msg.Round = bvl.Round
+		msg.Type = VoteTypePrecommit
+		msg.SetRoundDecision(block.ID(), bvl.BlockPartSetIDAndNTSVoteCount, nil)
 		msg.Timestamp = item.Timestamp
 		msg.setSignature(item.Signature)
+		msgs[i] = msg
+	}
+	// recover public keys in parallel, and they are kept in messages
+	sigverify.Default().Run(len(msgs), func(i int) error {
+		return msgs[i].verify()
+	})
+	for i, msg := range msgs {
 		index := validators.IndexOf(msg.address())
 		if index < 0 {
 			return nil, errors.Errorf("bad voter %v at index %d in vote list", msg.address(), i)
@@ -107,10 +116,20 @@ func (bvl *blockCommitVoteList) VoteRound() int32 {
 
 type CommitVoteList struct {
 	blockCommitVoteList
-	NTSDProves [][]byte
+	NTSDProves    [][]byte
+	ProposerProof *proposerProof
 }
 
 func (vl *CommitVoteList) RLPEncodeSelf(e codec.Encoder) error {
+	if vl.ProposerProof != nil {
+		return e.EncodeListOf(
+			vl.Round,
+			vl.BlockPartSetIDAndNTSVoteCount,
+			vl.Items,
+			vl.NTSDProves,
+			vl.ProposerProof,
+		)
+	}
 	if len(vl.NTSDProves) == 0 {
 		return e.EncodeListOf(vl.Round, vl.BlockPartSetIDAndNTSVoteCount, vl.Items)
 	}
@@ -132,9 +151,15 @@ func (vl *CommitVoteList) RLPDecodeSelf(d codec.Decoder) error {
 		&vl.BlockPartSetIDAndNTSVoteCount,
 		&vl.Items,
 		&vl.NTSDProves,
+		&vl.ProposerProof,
 	)
 	if cnt == 3 && err == io.EOF {
 		vl.NTSDProves = nil
+		vl.ProposerProof = nil
+		return nil
+	}
+	if cnt == 4 && err == io.EOF {
+		vl.ProposerProof = nil
 		return nil
 	}
 	return err
@@ -328,6 +353,32 @@ func NewCommitVoteSetFromBytes(bs []byte) module.CommitVoteSet {
 	return vl
 }
 
+// WALRecordBytesForBlock returns the record of the commit WAL for the votes
+// of the block, so that the consensus can continue after the block. prevBlk
+// is the block before blk.
+func WALRecordBytesForBlock(
+	blk module.BlockData, prevBlk module.Block,
+	votes module.CommitVoteSet, dbase db.Database,
+) ([]byte, error) {
+	cvl, ok := votes.(*CommitVoteList)
+	if !ok {
+		return nil, errors.UnsupportedError.Errorf("UnsupportedVoteSet(type=%T)", votes)
+	}
+	vl, err := cvl.toVoteListWithBlock(blk, prevBlk, dbase)
+	if err != nil {
+		return nil, err
+	}
+	vlm := newVoteListMessage()
+	vlm.VoteList = vl
+	rec := make([]byte, 2, 32)
+	binary.BigEndian.PutUint16(rec, vlm.subprotocol())
+	writer := bytes.NewBuffer(rec)
+	if err := msgCodec.Marshal(writer, vlm); err != nil {
+		return nil, err
+	}
+	return writer.Bytes(), nil
+}
+
 func WALRecordBytesFromCommitVoteListBytes(
 	bs []byte, h int64, bid []byte, result []byte,
 	validators module.ValidatorList,
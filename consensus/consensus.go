@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"path"
 	"time"
@@ -92,6 +93,8 @@ type consensus struct {
 	members            module.MemberList
 	minimizeBlockGen   bool
 	roundLimit         int32
+	proposerSeed       []byte
+	proposerWeights    []*big.Int
 	sentPatch          bool
 	lastVotes          VoteSet
 	hvs                heightVoteSet
@@ -108,7 +111,8 @@ type consensus struct {
 	pcmForLastBlock    module.BTPProofContextMap
 	nextPCM            module.BTPProofContextMap
 
-	timer *time.Timer
+	clock common.Clock
+	timer *common.Timer
 
 	// commit cache
 	commitCache *commitCache
@@ -120,6 +124,10 @@ type consensus struct {
 	metric *metric.ConsensusMetric
 
 	lastVoteData *LastVoteData
+
+	// fork detection
+	forkDetector *forkDetector
+	haltReason   error
 }
 
 func NewConsensus(
@@ -155,14 +163,30 @@ func New(
 		bpp:          bpp,
 		srcUID:       module.GetSourceNetworkUID(c),
 		lastVoteData: lastVoteData,
+		clock:        &common.GoTimeClock{},
 	}
 	cs.log = c.Logger().WithFields(log.Fields{
 		log.FieldKeyModule: "CS",
 	})
+	cs.forkDetector = newForkDetector(cs.getCommittedBlockIDAndValidators)
 
 	return cs
 }
 
+// SetClock sets the clock for timers and timestamps of the consensus. It
+// should be called before Start.
+func (cs *consensus) SetClock(cl common.Clock) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	cs.clock = cl
+}
+
+func (cs *consensus) afterFunc(d time.Duration, f func()) *common.Timer {
+	timer := cs.clock.AfterFunc(d, f)
+	return &timer
+}
+
 func (cs *consensus) _resetForNewHeight(prevBlock module.Block, votes *voteSet) {
 	cs.height = prevBlock.Height() + 1
 	cs.lastBlock = prevBlock
@@ -194,6 +218,8 @@ func (cs *consensus) _resetForNewHeight(prevBlock module.Block, votes *voteSet)
 	}
 	cs.minimizeBlockGen = cs.c.ServiceManager().GetMinimizeBlockGen(cs.lastBlock.Result())
 	cs.roundLimit = int32(cs.c.ServiceManager().GetRoundLimit(cs.lastBlock.Result(), cs.validators.Len()))
+	cs.proposerSeed = proposerSeedFor(cs.c, cs.lastBlock)
+	cs.proposerWeights = proposerWeightsFor(cs.c, cs.lastBlock, cs.validators, cs.proposerSeed)
 	cs.sentPatch = false
 	cs.lastVotes = votes
 	cs.hvs.reset(cs.validators.Len())
@@ -238,7 +264,7 @@ func (cs *consensus) resetForNewRound(round int32) {
 func (cs *consensus) resetForNewStep(step step) {
 	cs.endStep()
 	if cs.step < stepPropose && step > stepPropose {
-		now := time.Now()
+		now := cs.clock.Now()
 		cs.nextProposeTime = now
 		cs.c.Regulator().OnPropose(now)
 	}
@@ -316,6 +342,17 @@ func (cs *consensus) OnReceive(
 	return true, nil
 }
 
+// OnReceivePayload handles the payload without copying, because messages
+// are decoded with their own bytes.
+func (cs *consensus) OnReceivePayload(
+	sp module.ProtocolInfo,
+	p module.Payload,
+	id module.PeerID,
+) (bool, error) {
+	defer p.Release()
+	return cs.OnReceive(sp, p.Bytes(), id)
+}
+
 func (cs *consensus) OnFailure(err error, pi module.ProtocolInfo, b []byte) {
 	cs.log.Debugf("OnFailure(subprotocol:%v,  err:%+v)\n", pi, err)
 }
@@ -404,6 +441,12 @@ func (cs *consensus) ReceiveVoteMessage(msg *VoteMessage, unicast bool) (int, er
 			}
 		}
 	}
+	if msg.Height < cs.height && msg.Type == VoteTypePrecommit {
+		cs.checkFork(msg)
+		if !cs.started {
+			return -1, nil
+		}
+	}
 
 	if msg.Height != cs.height {
 		return -1, nil
@@ -437,7 +480,7 @@ func (cs *consensus) ReceiveVoteMessage(msg *VoteMessage, unicast bool) (int, er
 
 func (cs *consensus) ReceiveVoteListMessage(msg *VoteListMessage, unicast bool) error {
 	var err error
-	for i := 0; i < msg.VoteList.Len(); i++ {
+	for i := 0; i < msg.VoteList.Len() && cs.started; i++ {
 		vmsg := msg.VoteList.Get(i)
 		if _, e := cs.ReceiveVoteMessage(vmsg, unicast); e != nil {
 			cs.log.Warnf("bad vote in vote list. VoteMessage:%v Error:%+v\n", vmsg, e)
@@ -501,6 +544,60 @@ func (cs *consensus) handlePrecommitMessage(msg *VoteMessage, precommits *voteSe
 	}
 }
 
+func (cs *consensus) getCommittedBlockIDAndValidators(height int64) ([]byte, addressIndexer, error) {
+	if height == cs.height-1 && cs.prevValidators != nil {
+		return cs.lastBlock.ID(), cs.prevValidators, nil
+	}
+	if height < 1 {
+		return nil, nil, errors.IllegalArgumentError.Errorf("InvalidHeight(height=%d)", height)
+	}
+	bm := cs.c.BlockManager()
+	blk, err := bm.GetBlockByHeight(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	prev, err := bm.GetBlockByHeight(height - 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return blk.ID(), prev.NextValidators(), nil
+}
+
+// checkFork checks the precommit for the committed height, and halts the
+// consensus if over two thirds of validators committed another block.
+func (cs *consensus) checkFork(msg *VoteMessage) {
+	e, err := cs.forkDetector.add(msg, cs.height-configForkDetectorHeights)
+	if err != nil {
+		cs.log.Debugf("fail to check fork vote:%v err:%+v\n", msg, err)
+		return
+	}
+	if e != nil {
+		cs.haltByFork(e)
+	}
+}
+
+func (cs *consensus) haltByFork(e *ForkEvidence) {
+	cs.haltReason = e.Error()
+	cs.log.Errorf("HALT consensus on fork (%v) evidence:%s",
+		cs.haltReason, forkEvidencePath(cs.walDir))
+	if err := writeForkEvidence(cs.walDir, e); err != nil {
+		cs.log.Errorf("fail to write fork evidence err:%+v", err)
+	}
+
+	cs.started = false
+	if cs.syncer != nil {
+		cs.syncer.Stop()
+		cs.syncer = nil
+	}
+	if cs.timer != nil {
+		cs.timer.Stop()
+	}
+	if cs.cancelBlockRequest != nil {
+		cs.cancelBlockRequest.Cancel()
+		cs.cancelBlockRequest = nil
+	}
+}
+
 func (cs *consensus) notifySyncer() {
 	if cs.syncer != nil {
 		cs.syncer.OnEngineStepChange()
@@ -532,7 +629,7 @@ func (cs *consensus) processPrefetchItems() {
 func (cs *consensus) enterPropose() {
 	cs.resetForNewStep(stepPropose)
 
-	now := time.Now()
+	now := cs.clock.Now()
 	if int(cs.round) > cs.validators.Len()*configRoundTimeoutThresholdFactor {
 		cs.nextProposeTime = now.Add(timeoutNewRound)
 	} else {
@@ -541,7 +638,7 @@ func (cs *consensus) enterPropose() {
 	cs.c.Regulator().OnPropose(now)
 
 	hrs := cs.hrs
-	cs.timer = time.AfterFunc(timeoutPropose, func() {
+	cs.timer = cs.afterFunc(timeoutPropose, func() {
 		cs.mutex.Lock()
 		defer cs.mutex.Unlock()
 
@@ -570,6 +667,13 @@ func (cs *consensus) enterPropose() {
 			if err != nil {
 				cs.log.Panicf("fail to make CommitVoteSet: %+v", err)
 			}
+			if cs.proposerSeed != nil {
+				if cvl, err = cs.withProposerProof(cvl); err != nil {
+					cs.log.Warnf("fail to make proposer proof: %+v", err)
+					cs.notifySyncer()
+					return
+				}
+			}
 			cs.cancelBlockRequest, err = cs.c.BlockManager().Propose(cs.lastBlock.ID(), cvl,
 				func(blk module.BlockCandidate, err error) {
 					cs.mutex.Lock()
@@ -625,6 +729,12 @@ func (cs *consensus) proposalHasValidProposer() bool {
 		if cs.getProposerIndex(cs.height, cs.round) != index {
 			return false
 		}
+		if cs.proposerSeed != nil {
+			if err := verifyProposerProof(cs.currentBlockParts.block, cs.proposerSeed); err != nil {
+				cs.log.Warnf("invalid proposer proof: %+v", err)
+				return false
+			}
+		}
 	}
 	return true
 }
@@ -727,7 +837,7 @@ func (cs *consensus) enterPrevoteWait() {
 		cs.enterPrecommit()
 	} else {
 		hrs := cs.hrs
-		cs.timer = time.AfterFunc(timeoutPrevote, func() {
+		cs.timer = cs.afterFunc(timeoutPrevote, func() {
 			cs.mutex.Lock()
 			defer cs.mutex.Unlock()
 
@@ -827,7 +937,7 @@ func (cs *consensus) enterPrecommitWait() {
 	} else {
 		cs.log.Traceln("enterPrecommitWait: start timer")
 		hrs := cs.hrs
-		cs.timer = time.AfterFunc(timeoutPrecommit, func() {
+		cs.timer = cs.afterFunc(timeoutPrecommit, func() {
 			cs.mutex.Lock()
 			defer cs.mutex.Unlock()
 
@@ -896,7 +1006,7 @@ func (cs *consensus) enterCommit(precommits *voteSet, partSetID *PartSetID, roun
 		cs.log.Errorf("fail to sync WAL: cs.enterCommit: %+v\n", err)
 	}
 
-	cs.nextProposeTime = time.Now()
+	cs.nextProposeTime = cs.clock.Now()
 	if cs.consumedNonunicast || cs.validators.Len() == 1 {
 		if cs.timestamper == nil {
 			cs.nextProposeTime = cs.nextProposeTime.Add(cs.c.Regulator().CommitTimeout())
@@ -916,10 +1026,10 @@ func (cs *consensus) enterNewRound() {
 	cs.resetForNewRound(cs.round + 1)
 	cs.notifySyncer()
 
-	now := time.Now()
+	now := cs.clock.Now()
 	if cs.nextProposeTime.After(now) {
 		hrs := cs.hrs
-		cs.timer = time.AfterFunc(cs.nextProposeTime.Sub(now), func() {
+		cs.timer = cs.afterFunc(cs.nextProposeTime.Sub(now), func() {
 			cs.mutex.Lock()
 			defer cs.mutex.Unlock()
 
@@ -964,14 +1074,15 @@ func (cs *consensus) enterTransactionWait() {
 }
 
 func (cs *consensus) enterNewHeight() {
+	cs.recordHeightStats()
 	votes := cs.hvs.votesFor(cs.commitRound, VoteTypePrecommit)
 	cs.resetForNewHeight(cs.currentBlockParts.validatedBlock, votes)
 	cs.notifySyncer()
 
-	now := time.Now()
+	now := cs.clock.Now()
 	if cs.nextProposeTime.After(now) {
 		hrs := cs.hrs
-		cs.timer = time.AfterFunc(cs.nextProposeTime.Sub(now), func() {
+		cs.timer = cs.afterFunc(cs.nextProposeTime.Sub(now), func() {
 			cs.mutex.Lock()
 			defer cs.mutex.Unlock()
 
@@ -1073,7 +1184,7 @@ func (cs *consensus) voteTimestamp() int64 {
 	} else if cs.currentBlockParts.HasBlockData() {
 		timestamp = cs.currentBlockParts.block.Timestamp() + blockIota
 	}
-	now := common.UnixMicroFromTime(time.Now())
+	now := common.UnixMicroFromTime(cs.clock.Now())
 	if now > timestamp {
 		timestamp = now
 	}
@@ -1180,23 +1291,15 @@ func (cs *consensus) doSendVote(vt VoteType, blockParts *blockPartSet) error {
 	return err
 }
 
-func getProposerIndex(
-	validators module.ValidatorList,
-	height int64,
-	round int32,
-) int {
-	return int((height + int64(round)) % int64(validators.Len()))
-}
-
 func (cs *consensus) getProposerIndex(height int64, round int32) int {
-	return getProposerIndex(cs.validators, height, round)
+	return getProposerIndex(cs.validators, height, round, cs.proposerSeed, cs.proposerWeights)
 }
 
 func (cs *consensus) isProposerFor(height int64, round int32) bool {
 	if cs.validators == nil || cs.validators.Len() == 0 {
 		return false
 	}
-	pindex := getProposerIndex(cs.validators, height, round)
+	pindex := cs.getProposerIndex(height, round)
 	v, _ := cs.validators.Get(pindex)
 	if v == nil {
 		return false
@@ -1643,6 +1746,14 @@ func (cs *consensus) Start() error {
 	cs.mutex.Lock()
 	defer cs.mutex.Unlock()
 
+	if e, err := readForkEvidence(cs.walDir); err != nil {
+		return err
+	} else if e != nil {
+		cs.haltReason = e.Error()
+		return errors.Wrapf(cs.haltReason,
+			"remove %s to resume", forkEvidencePath(cs.walDir))
+	}
+
 	lastBlock, err := cs.c.BlockManager().GetLastBlock()
 	if err != nil {
 		return err
@@ -1711,7 +1822,7 @@ func (cs *consensus) Start() error {
 
 	cs.started = true
 	cs.log.Infof("Start consensus wallet:%v", common.HexPre(cs.c.Wallet().Address().ID()))
-	cs.syncer, err = newSyncer(cs, cs.log, cs.c.NetworkManager(), cs.c.BlockManager(), &cs.mutex, cs.c.Wallet().Address())
+	cs.syncer, err = newSyncer(cs, cs.log, cs.c.NetworkManager(), cs.c.BlockManager(), cs.c.SyncServerThrottle(), &cs.mutex, cs.c.Wallet().Address())
 	if err != nil {
 		return err
 	}
@@ -1782,6 +1893,8 @@ func (cs *consensus) GetStatus() *module.ConsensusStatus {
 	res := &module.ConsensusStatus{
 		Height: cs.height,
 		Round:  cs.round,
+
+		HaltReason: cs.haltReason,
 	}
 	if cs.validators != nil {
 		res.Proposer = cs.isProposer()
@@ -27,12 +27,14 @@ import (
 
 	"github.com/icon-project/goloop/btp/ntm"
 	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/consensus/fastsync"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/platform/basic"
 	"github.com/icon-project/goloop/test"
+	"github.com/icon-project/goloop/test/clock"
 )
 
 func TestConsensus_FastSyncServer(t *testing.T) {
@@ -110,7 +112,9 @@ func TestConsensus_FastSyncServerFail(t *testing.T) {
 		&fastsync.BlockMetadata{
 			RequestID:   0,
 			BlockLength: -1,
-			Proof:       nil,
+			Proof: errors.Marshal(
+				errors.NotFoundError.New("no block for 1"),
+			),
 		},
 	)
 }
@@ -218,6 +222,41 @@ func TestConsensus_BasicConsensus(t *testing.T) {
 	}
 }
 
+func TestConsensus_ProposeTimeoutWithClock(t *testing.T) {
+	cl := &clock.Clock{}
+	cl.SetTime(time.Now())
+	f := test.NewNode(t, test.UseClock(cl))
+	defer f.Close()
+
+	h := make([]*test.SimplePeerHandler, 3)
+	for i := 0; i < len(h); i++ {
+		_, h[i] = f.NM.NewPeerFor(module.ProtoConsensus)
+	}
+
+	// h[2] is the proposer of the first round, and it doesn't propose.
+	f.ProposeImportFinalizeBlockWithTX(
+		consensus.NewEmptyCommitVoteList(),
+		test.NewTx().SetValidatorsAddresser(
+			f.Chain.Wallet(), h[0], h[1], h[2],
+		).String(),
+	)
+	f.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+
+	err := f.CS.Start()
+	assert.NoError(t, err)
+
+	start := time.Now()
+	cl.PassTime(time.Second)
+
+	var vm consensus.VoteMessage
+	h[0].Receive(consensus.ProtoVote, nil, &vm)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.EqualValues(t, consensus.VoteTypePrevote, vm.Type)
+	assert.EqualValues(t, 3, vm.Height)
+	assert.EqualValues(t, 0, vm.Round)
+	assert.Nil(t, vm.BlockPartSetIDAndNTSVoteCount)
+}
+
 func TestConsensus_BasicConsensus2(t *testing.T) {
 	f := test.NewFixture(t,
 		test.AddDefaultNode(false),
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consensus
+
+import (
+	"bytes"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+// DoubleSign is the result of verified double sign evidence. BlockIDs are
+// IDs of blocks in the votes.
+type DoubleSign struct {
+	Height   int64
+	Round    int32
+	Signer   module.Address
+	BlockIDs [][]byte
+}
+
+// VerifyDoubleSign verifies two encoded vote messages as double sign
+// evidence. They must be signed by the same signer for the same height,
+// round and type with different blocks. Only signed fields are compared.
+// Votes don't have the network, so the caller must check that one of the
+// blocks is the block of the network at the height.
+func VerifyDoubleSign(vote1, vote2 []byte) (*DoubleSign, error) {
+	v1, err := decodeVoteMessage(vote1)
+	if err != nil {
+		return nil, err
+	}
+	v2, err := decodeVoteMessage(vote2)
+	if err != nil {
+		return nil, err
+	}
+	if v1.Height != v2.Height || v1.Round != v2.Round || v1.Type != v2.Type {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"DifferentVoteRound(v1=%s,v2=%s)", v1, v2)
+	}
+	signer := v1.address()
+	if !signer.Equal(v2.address()) {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"DifferentSigner(v1=%s,v2=%s)", signer, v2.address())
+	}
+	if bytes.Equal(v1.BlockID, v2.BlockID) &&
+		v1.BlockPartSetIDAndNTSVoteCount.ID().Equal(v2.BlockPartSetIDAndNTSVoteCount.ID()) {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"NotConflictingVotes(v1=%s,v2=%s)", v1, v2)
+	}
+	return &DoubleSign{
+		Height:   v1.Height,
+		Round:    v1.Round,
+		Signer:   signer,
+		BlockIDs: [][]byte{v1.BlockID, v2.BlockID},
+	}, nil
+}
+
+func decodeVoteMessage(bs []byte) (*VoteMessage, error) {
+	msg := newVoteMessage()
+	if _, err := msgCodec.UnmarshalFromBytes(bs, msg); err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidVoteMessage")
+	}
+	if err := msg.Verify(); err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidVoteMessage")
+	}
+	return msg, nil
+}
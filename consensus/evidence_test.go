@@ -0,0 +1,44 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/wallet"
+)
+
+func TestVerifyDoubleSign(t *testing.T) {
+	w := wallet.New()
+	encode := func(vm *VoteMessage) []byte {
+		return msgCodec.MustMarshalToBytes(vm)
+	}
+	psID := &PartSetID{Count: 1, Hash: []byte("parts")}
+	v1 := encode(NewPrecommitMessage(w, 10, 1, []byte("block1"), psID, 100))
+	v2 := encode(NewPrecommitMessage(w, 10, 1, []byte("block2"), psID, 200))
+
+	ds, err := VerifyDoubleSign(v1, v2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, ds.Height)
+	assert.EqualValues(t, 1, ds.Round)
+	assert.True(t, ds.Signer.Equal(w.Address()))
+	assert.Equal(t, [][]byte{[]byte("block1"), []byte("block2")}, ds.BlockIDs)
+
+	// same block with different timestamp
+	v3 := encode(NewPrecommitMessage(w, 10, 1, []byte("block1"), psID, 300))
+	_, err = VerifyDoubleSign(v1, v3)
+	assert.Error(t, err)
+
+	// different round
+	v4 := encode(NewPrecommitMessage(w, 10, 2, []byte("block2"), psID, 200))
+	_, err = VerifyDoubleSign(v1, v4)
+	assert.Error(t, err)
+
+	// different signer
+	v5 := encode(NewPrecommitMessage(wallet.New(), 10, 1, []byte("block2"), psID, 200))
+	_, err = VerifyDoubleSign(v1, v5)
+	assert.Error(t, err)
+
+	_, err = VerifyDoubleSign(v1, []byte("invalid"))
+	assert.Error(t, err)
+}
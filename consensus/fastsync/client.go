@@ -356,6 +356,16 @@ func (cl *client) notifyBlockResult() {
 
 var errNoBlock = errors.New("errNoBlock")
 
+// failureOf returns the error in the metadata for the failure. A server
+// without the error or without the block is considered to have no block.
+func failureOf(msg *BlockMetadata) error {
+	err := errors.Unmarshal(msg.Proof)
+	if err == nil || errors.IsCode(err, errors.NotFoundError) {
+		return errNoBlock
+	}
+	return err
+}
+
 func isNoBlock(err error) bool {
 	return errors.Is(err, errNoBlock)
 }
@@ -488,7 +498,7 @@ func (f *fetcher) onReceive(pi module.ProtocolInfo, b []byte) {
 			return
 		}
 		var msg BlockMetadata
-		_, err := codec.UnmarshalFromBytes(b, &msg)
+		_, err := msgCodec.UnmarshalFromBytes(b, &msg)
 		if err != nil {
 			return
 		}
@@ -502,7 +512,8 @@ func (f *fetcher) onReceive(pi module.ProtocolInfo, b []byte) {
 				f.timer.Stop()
 				f.timer = nil
 			}
-			f.cl.onResult(f, errNoBlock, nil, nil)
+			f.cl.onResult(f, failureOf(&msg), nil, nil)
+			return
 		}
 		f.left = msg.BlockLength
 		f.voteList = msg.Proof
@@ -512,7 +523,7 @@ func (f *fetcher) onReceive(pi module.ProtocolInfo, b []byte) {
 			return
 		}
 		var msg BlockData
-		_, err := codec.UnmarshalFromBytes(b, &msg)
+		_, err := msgCodec.UnmarshalFromBytes(b, &msg)
 		if err != nil {
 			return
 		}
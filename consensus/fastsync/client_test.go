@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/consensus/internal/test"
 	"github.com/icon-project/goloop/module"
@@ -40,7 +41,7 @@ func newClientTestSetUp(t *testing.T, n int) *clientTestSetUp {
 		}
 	}
 	var err error
-	s.m, err = NewManager(s.nms[0], s.bm, s.bm, log.New())
+	s.m, err = NewManager(s.nms[0], s.bm, s.bm, nil, log.New())
 	assert.Nil(t, err)
 	s.cb = newTFetchCallback()
 	return s
@@ -175,3 +176,19 @@ func TestClient_SuccessMulti(t *testing.T) {
 	ev2 = <-s.cb.ch
 	s.assertEndEvent(nil, ev2)
 }
+
+func TestFailureOf(t *testing.T) {
+	// servers without the error
+	assert.True(t, isNoBlock(failureOf(&BlockMetadata{BlockLength: -1})))
+
+	md := &BlockMetadata{
+		BlockLength: -1,
+		Proof:       errors.Marshal(errors.NotFoundError.New("NoBlock")),
+	}
+	assert.True(t, isNoBlock(failureOf(md)))
+
+	md.Proof = errors.Marshal(errors.CriticalIOError.New("DBFailure"))
+	err := failureOf(md)
+	assert.False(t, isNoBlock(err))
+	assert.True(t, errors.CriticalIOError.Equals(err))
+}
@@ -121,8 +121,7 @@ func (bm *tBlockManager) SetBlock(height int64, blk module.Block) {
 func (bm *tBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
 	blk := bm.bmap[height]
 	if blk == nil {
-		// TODO
-		return nil, errors.New("NoBlock")
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
 	}
 	return blk, nil
 }
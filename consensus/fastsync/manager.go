@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/module"
 )
 
@@ -104,16 +105,19 @@ func (m *manager) Term() {
 	}
 }
 
+// NewManager returns the manager for block sync. Requests of other peers are
+// served under the throttle, and nil throttle means no limit.
 func NewManager(
 	nm module.NetworkManager,
 	bm module.BlockManager,
 	bpp BlockProofProvider,
+	th *throttle.Throttle,
 	logger log.Logger,
 ) (Manager, error) {
 	m := &manager{
 		nm: nm,
 	}
-	m.server = newServer(nm, nil, bm, bpp, logger)
+	m.server = newServer(nm, nil, bm, bpp, th, logger)
 	m.client = newClient(nm, nil, bm, logger)
 
 	// lock to prevent enter server.onJoin / client.onJoin
@@ -138,7 +142,7 @@ func NewManagerOnlyForClient(
 	m := &manager{
 		nm: nm,
 	}
-	m.server = newServer(nm, nil, nil, nil, logger)
+	m.server = newServer(nm, nil, nil, nil, nil, logger)
 	m.client = newClient(nm, nil, bdf, logger)
 
 	// lock to prevent enter server.onJoin / client.onJoin
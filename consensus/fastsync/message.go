@@ -7,6 +7,17 @@ import (
 	"github.com/icon-project/goloop/module"
 )
 
+const (
+	configMsgMaxLength = 10000
+	configMsgMaxDepth  = 16
+)
+
+// msgCodec decodes messages from peers within the limits.
+var msgCodec = codec.BC.WithLimits(codec.Limits{
+	MaxLength: configMsgMaxLength,
+	MaxDepth:  configMsgMaxDepth,
+})
+
 // TODO: close message
 const (
 	ProtoBlockRequest module.ProtocolInfo = iota << 8
@@ -63,8 +74,8 @@ func (m *BlockRequest) RLPDecodeSelf(d codec.Decoder) error {
 
 type BlockMetadata struct {
 	RequestID   uint32
-	BlockLength int32 // -1 if fails
-	Proof       []byte
+	BlockLength int32  // -1 if fails
+	Proof       []byte // marshaled error if fails
 }
 
 type BlockData struct {
@@ -7,7 +7,9 @@ import (
 
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/module"
 )
 
@@ -42,6 +44,7 @@ type server struct {
 	ph    module.ProtocolHandler
 	bm    module.BlockManager
 	bpp   BlockProofProvider
+	th    *throttle.Throttle
 	log   log.Logger
 	peers []*speer
 
@@ -53,6 +56,7 @@ func newServer(
 	ph module.ProtocolHandler,
 	bm module.BlockManager,
 	bpp BlockProofProvider,
+	th *throttle.Throttle,
 	logger log.Logger,
 ) *server {
 	s := &server{
@@ -60,6 +64,7 @@ func newServer(
 		ph:  ph,
 		bm:  bm,
 		bpp: bpp,
+		th:  th,
 		log: logger,
 	}
 	return s
@@ -93,7 +98,7 @@ func (s *server) _addPeer(id module.PeerID) {
 	s.peers = append(s.peers, speer)
 	h := newSConHandler(
 		speer.msgCh, stopCh, speer.stoppedCh,
-		speer.id, s.ph, s.bm, s.bpp, s.log,
+		speer.id, s.ph, s.bm, s.bpp, s.th, s.log,
 	)
 	go h.handle()
 	go func() {
@@ -184,8 +189,15 @@ type sconHandler struct {
 	ph        module.ProtocolHandler
 	bm        module.BlockManager
 	bpp       BlockProofProvider
+	th        *throttle.Throttle
 	log       log.Logger
 
+	// acquired is true while the handler has the slot of the throttle for
+	// the current request. sendReady is true if the bandwidth for nextMsg
+	// is reserved.
+	acquired  bool
+	sendReady bool
+
 	nextItems []*BlockRequest
 	buf       *bytes.Buffer
 	requestID uint32
@@ -201,6 +213,7 @@ func newSConHandler(
 	ph module.ProtocolHandler,
 	bm module.BlockManager,
 	bpp BlockProofProvider,
+	th *throttle.Throttle,
 	logger log.Logger,
 ) *sconHandler {
 	h := &sconHandler{
@@ -211,6 +224,7 @@ func newSConHandler(
 		ph:        ph,
 		bm:        bm,
 		bpp:       bpp,
+		th:        th,
 		log: logger.WithFields(log.Fields{
 			"peer": common.HexPre(id.Bytes()),
 		}),
@@ -220,14 +234,36 @@ func newSConHandler(
 
 func (h *sconHandler) cancelAllRequests() {
 	h.nextMsg = nil
+	h.sendReady = false
 	h.buf = nil
 	h.nextItems = nil
+	h.releaseSlot()
+}
+
+func (h *sconHandler) acquireSlot() bool {
+	if !h.acquired {
+		h.acquired = h.th.Acquire(h.id.String(), h.stopCh)
+	}
+	return h.acquired
 }
 
+func (h *sconHandler) releaseSlot() {
+	if h.acquired {
+		h.th.Release(h.id.String())
+		h.acquired = false
+	}
+}
+
+// updateCurrentTask starts serving the next request. It's called after
+// the current request is served, so the slot for it is released first.
 func (h *sconHandler) updateCurrentTask() {
+	h.releaseSlot()
 	if len(h.nextItems) == 0 {
 		return
 	}
+	if !h.acquireSlot() {
+		return
+	}
 	ni := h.nextItems[0]
 	copy(h.nextItems, h.nextItems[1:])
 	h.nextItems = h.nextItems[:len(h.nextItems)-1]
@@ -238,7 +274,7 @@ func (h *sconHandler) updateCurrentTask() {
 		h.nextMsg = codec.MustMarshalToBytes(&BlockMetadata{
 			RequestID:   ni.RequestID,
 			BlockLength: -1,
-			Proof:       nil,
+			Proof:       errors.Marshal(err),
 		})
 		h.buf = nil
 		return
@@ -249,7 +285,7 @@ func (h *sconHandler) updateCurrentTask() {
 		h.nextMsg = codec.MustMarshalToBytes(&BlockMetadata{
 			RequestID:   ni.RequestID,
 			BlockLength: -1,
-			Proof:       nil,
+			Proof:       errors.Marshal(err),
 		})
 		h.buf = nil
 		return
@@ -297,7 +333,7 @@ const maxNextItems = 10
 func (h *sconHandler) processMsg(msgItem *MessageItem) {
 	if msgItem.pi == ProtoBlockRequest {
 		var msg BlockRequest
-		_, err := codec.UnmarshalFromBytes(msgItem.b, &msg)
+		_, err := msgCodec.UnmarshalFromBytes(msgItem.b, &msg)
 		if err != nil {
 			h.log.Debugf("Fail to decode request %+v", err)
 			return
@@ -372,9 +408,16 @@ func (h *sconHandler) handle() {
 
 		timeout = 0
 		if h.nextMsg != nil {
+			if !h.sendReady {
+				if !h.th.WaitToSend(len(h.nextMsg), h.stopCh) {
+					break
+				}
+				h.sendReady = true
+			}
 			err := h.ph.Unicast(h.nextMsgPI, h.nextMsg, h.id)
 			if err == nil {
 				h.nextMsg = nil
+				h.sendReady = false
 			} else if isTemporary(err) {
 				h.log.Warnf("unicast temporary error %+v\n", err)
 				timeout = configSendInterval
@@ -386,5 +429,6 @@ func (h *sconHandler) handle() {
 			timeout = -1
 		}
 	}
+	h.releaseSlot()
 	h.stoppedCh <- struct{}{}
 }
@@ -3,8 +3,11 @@ package fastsync
 import (
 	"crypto/rand"
 	"testing"
+	"time"
 
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/consensus/internal/test"
 
 	"github.com/stretchr/testify/assert"
@@ -34,6 +37,10 @@ func createABytes(l int) []byte {
 }
 
 func newServerTestSetUp(t *testing.T) *serverTestSetUp {
+	return newServerTestSetUpWithThrottle(t, nil)
+}
+
+func newServerTestSetUpWithThrottle(t *testing.T, th *throttle.Throttle) *serverTestSetUp {
 	s := &serverTestSetUp{}
 	s.fastSyncTestSetUp = newFastSyncTestSetUp(t)
 	s.nm = test.NewNetworkManager()
@@ -43,7 +50,7 @@ func newServerTestSetUp(t *testing.T) *serverTestSetUp {
 	var err error
 	s.ph2, err = s.nm2.RegisterReactorForStreams("fastsync", module.ProtoFastSync, s.r2, protocols, configFastSyncPriority, module.NotRegisteredProtocolPolicyClose)
 	assert.Nil(t, err)
-	s.m, err = NewManager(s.nm, s.bm, s.bm, log.New())
+	s.m, err = NewManager(s.nm, s.bm, s.bm, th, log.New())
 	assert.Nil(t, err)
 	s.m.StartServer()
 	return s
@@ -63,27 +70,59 @@ func (s *serverTestSetUp) assertEqualReceiveEvent(pi module.ProtocolInfo, msg in
 	assert.Equal(s.t, tReceiveEvent{pi, b, id}, actual)
 }
 
-func TestServer_Success(t *testing.T) {
-	s := newServerTestSetUp(t)
-	s.sendBlockRequest(s.ph2, 0, 0)
-	ev := <-s.r2.ch
-	md := &BlockMetadata{0, int32(len(s.rawBlocks[0])), s.votes[1]}
-	s.assertEqualReceiveEvent(ProtoBlockMetadata, md, s.nm.ID, ev)
+func (s *serverTestSetUp) receiveBlockData(md *BlockMetadata) []byte {
 	recv := 0
 	data := make([]byte, md.BlockLength)
 	for recv < int(md.BlockLength) {
-		ev = <-s.r2.ch
-		ev := ev.(tReceiveEvent)
+		ev := (<-s.r2.ch).(tReceiveEvent)
 		var msg BlockData
 		codec.MustUnmarshalFromBytes(ev.b, &msg)
-		t.Logf("ev : %v\n", msg)
+		s.t.Logf("ev : %v\n", msg)
 		copy(data[recv:], msg.Data)
 		recv += len(msg.Data)
 	}
-	assert.Equal(t, data, s.rawBlocks[0])
+	return data
+}
+
+func TestServer_Success(t *testing.T) {
+	s := newServerTestSetUp(t)
+	s.sendBlockRequest(s.ph2, 0, 0)
+	ev := <-s.r2.ch
+	md := &BlockMetadata{0, int32(len(s.rawBlocks[0])), s.votes[1]}
+	s.assertEqualReceiveEvent(ProtoBlockMetadata, md, s.nm.ID, ev)
+	assert.Equal(t, s.receiveBlockData(md), s.rawBlocks[0])
+}
+
+func TestServer_Throttle(t *testing.T) {
+	th := throttle.New(1, 0)
+	assert.True(t, th.Acquire("other", nil))
+	s := newServerTestSetUpWithThrottle(t, th)
+	s.sendBlockRequest(s.ph2, 0, 0)
+	select {
+	case ev := <-s.r2.ch:
+		assert.Fail(t, "served without slot", "ev=%v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	th.Release("other")
+	ev := <-s.r2.ch
+	md := &BlockMetadata{0, int32(len(s.rawBlocks[0])), s.votes[1]}
+	s.assertEqualReceiveEvent(ProtoBlockMetadata, md, s.nm.ID, ev)
+	assert.Equal(t, s.receiveBlockData(md), s.rawBlocks[0])
 }
 
 func TestServer_Fail(t *testing.T) {
+	s := newServerTestSetUp(t)
+	s.sendBlockRequest(s.ph2, 1, tNumBlocks+1)
+	ev := (<-s.r2.ch).(tReceiveEvent)
+	assert.Equal(t, ProtoBlockMetadata, ev.pi)
+	var md BlockMetadata
+	codec.MustUnmarshalFromBytes(ev.b, &md)
+	assert.EqualValues(t, 1, md.RequestID)
+	assert.EqualValues(t, -1, md.BlockLength)
+	err := errors.Unmarshal(md.Proof)
+	assert.True(t, errors.NotFoundError.Equals(err))
+	assert.True(t, isNoBlock(failureOf(&md)))
 }
 
 func TestServer_Queue(t *testing.T) {
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consensus
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const (
+	// configForkDetectorHeights is the number of recent committed heights
+	// checked for conflicting commits.
+	configForkDetectorHeights = 10
+	configForkEvidenceFile    = "fork.json"
+)
+
+// ForkEvidence is the diagnostic data of the commit conflicting with the
+// local chain. Votes are encoded precommit messages for BlockID by over
+// two thirds of validators of the height.
+type ForkEvidence struct {
+	Height       int64             `json:"height"`
+	Round        int32             `json:"round"`
+	LocalBlockID common.HexBytes   `json:"localBlockID"`
+	BlockID      common.HexBytes   `json:"blockID"`
+	Votes        []common.HexBytes `json:"votes"`
+}
+
+func (e *ForkEvidence) Error() error {
+	return errors.InvalidStateError.Errorf(
+		"ForkDetected(height=%d,round=%d,local=%s,commit=%s)",
+		e.Height, e.Round, e.LocalBlockID, e.BlockID)
+}
+
+func forkEvidencePath(walDir string) string {
+	return path.Join(walDir, configForkEvidenceFile)
+}
+
+func writeForkEvidence(walDir string, e *ForkEvidence) error {
+	bs, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(forkEvidencePath(walDir), bs, 0644)
+}
+
+// readForkEvidence returns the evidence written on the halt of the
+// consensus, or nil if there is no evidence.
+func readForkEvidence(walDir string) (*ForkEvidence, error) {
+	bs, err := ioutil.ReadFile(forkEvidencePath(walDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	e := new(ForkEvidence)
+	if err := json.Unmarshal(bs, e); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidForkEvidence")
+	}
+	return e, nil
+}
+
+type forkHeight struct {
+	blockID    []byte
+	validators addressIndexer
+	rounds     map[int32]*voteSet
+}
+
+// forkDetector collects precommits for the committed heights, and finds
+// over two thirds of them for a block other than the committed one.
+type forkDetector struct {
+	// load returns the committed block ID and validators of the height.
+	load    func(height int64) ([]byte, addressIndexer, error)
+	heights map[int64]*forkHeight
+}
+
+func newForkDetector(load func(height int64) ([]byte, addressIndexer, error)) *forkDetector {
+	return &forkDetector{
+		load:    load,
+		heights: make(map[int64]*forkHeight),
+	}
+}
+
+// add adds the precommit for the committed height. It forgets heights
+// lower than low, and returns the evidence on a conflicting commit.
+func (d *forkDetector) add(msg *VoteMessage, low int64) (*ForkEvidence, error) {
+	for h := range d.heights {
+		if h < low {
+			delete(d.heights, h)
+		}
+	}
+	if msg.Height < low || msg.Type != VoteTypePrecommit || msg.BlockID == nil {
+		return nil, nil
+	}
+	fh, ok := d.heights[msg.Height]
+	if !ok {
+		id, validators, err := d.load(msg.Height)
+		if err != nil {
+			return nil, err
+		}
+		fh = &forkHeight{
+			blockID:    id,
+			validators: validators,
+			rounds:     make(map[int32]*voteSet),
+		}
+		d.heights[msg.Height] = fh
+	}
+	if bytes.Equal(msg.BlockID, fh.blockID) {
+		return nil, nil
+	}
+	index := fh.validators.IndexOf(msg.address())
+	if index < 0 {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidVoter(height=%d,voter=%s)", msg.Height, msg.address())
+	}
+	vs, ok := fh.rounds[msg.Round]
+	if !ok {
+		vs = newVoteSet(fh.validators.Len())
+		fh.rounds[msg.Round] = vs
+	}
+	if !vs.add(index, msg) || !vs.hasOverTwoThirds() {
+		return nil, nil
+	}
+	vl := vs.voteListForOverTwoThirds()
+	if vl == nil || vl.Len() == 0 {
+		return nil, nil
+	}
+	e := &ForkEvidence{
+		Height:       msg.Height,
+		Round:        msg.Round,
+		LocalBlockID: fh.blockID,
+		BlockID:      vl.Get(0).BlockID,
+		Votes:        make([]common.HexBytes, vl.Len()),
+	}
+	for i := 0; i < vl.Len(); i++ {
+		e.Votes[i] = msgCodec.MustMarshalToBytes(vl.Get(i))
+	}
+	return e, nil
+}
@@ -0,0 +1,98 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/icon-project/goloop/module"
+)
+
+type testAddressIndexer []module.Wallet
+
+func (ai testAddressIndexer) IndexOf(addr module.Address) int {
+	for i, w := range ai {
+		if w.Address().Equal(addr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ai testAddressIndexer) Len() int {
+	return len(ai)
+}
+
+func TestForkDetector(t *testing.T) {
+	validators := testAddressIndexer{wallet.New(), wallet.New(), wallet.New(), wallet.New()}
+	local := []byte("local")
+	other := []byte("other")
+	psID := &PartSetID{Count: 1, Hash: []byte("parts")}
+	d := newForkDetector(func(height int64) ([]byte, addressIndexer, error) {
+		return local, validators, nil
+	})
+
+	// votes for the local block are ignored
+	for _, w := range validators {
+		e, err := d.add(NewPrecommitMessage(w, 10, 0, local, psID, 100), 1)
+		assert.NoError(t, err)
+		assert.Nil(t, e)
+	}
+
+	// unknown voter
+	_, err := d.add(NewPrecommitMessage(wallet.New(), 10, 0, other, psID, 100), 1)
+	assert.Error(t, err)
+
+	// two thirds in different rounds are not a commit
+	var e *ForkEvidence
+	for i := 0; i < 3; i++ {
+		e, err = d.add(NewPrecommitMessage(validators[i], 10, int32(i), other, psID, 100), 1)
+		assert.NoError(t, err)
+		assert.Nil(t, e)
+	}
+
+	for i := 0; i < 3; i++ {
+		e, err = d.add(NewPrecommitMessage(validators[i], 10, 1, other, psID, 200), 1)
+		assert.NoError(t, err)
+	}
+	if assert.NotNil(t, e) {
+		assert.EqualValues(t, 10, e.Height)
+		assert.EqualValues(t, 1, e.Round)
+		assert.EqualValues(t, local, e.LocalBlockID)
+		assert.EqualValues(t, other, e.BlockID)
+		assert.Len(t, e.Votes, 3)
+		for _, v := range e.Votes {
+			vm, err := decodeVoteMessage(v)
+			assert.NoError(t, err)
+			assert.EqualValues(t, other, vm.BlockID)
+		}
+		assert.Error(t, e.Error())
+	}
+
+	// heights lower than low are forgotten
+	e, err = d.add(NewPrecommitMessage(validators[3], 10, 1, other, psID, 200), 11)
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+	assert.Len(t, d.heights, 0)
+}
+
+func TestForkEvidence_ReadWrite(t *testing.T) {
+	dir := t.TempDir()
+	e, err := readForkEvidence(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+
+	e1 := &ForkEvidence{
+		Height:       10,
+		Round:        1,
+		LocalBlockID: []byte("local"),
+		BlockID:      []byte("other"),
+		Votes:        []common.HexBytes{[]byte("vote")},
+	}
+	assert.NoError(t, writeForkEvidence(dir, e1))
+	e2, err := readForkEvidence(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, e1, e2)
+}
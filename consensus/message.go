@@ -11,7 +11,17 @@ import (
 	"github.com/icon-project/goloop/module"
 )
 
-var msgCodec = codec.BC
+const (
+	configMsgMaxLength = 10000
+	configMsgMaxDepth  = 16
+)
+
+// msgCodec decodes messages within the limits, so messages from peers can't
+// make consensus allocate huge memory.
+var msgCodec = codec.BC.WithLimits(codec.Limits{
+	MaxLength: configMsgMaxLength,
+	MaxDepth:  configMsgMaxDepth,
+})
 
 const (
 	ProtoProposal module.ProtocolInfo = iota << 8
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consensus
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/chain/base"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+// proposerProof is the VRF proof of the proposer of the block. It's carried
+// in the commit vote list of the block, so it's covered by the block ID.
+type proposerProof struct {
+	PublicKey []byte
+	Proof     []byte
+}
+
+// getProposerIndex returns the index of the proposer for the round. Without
+// the seed, validators take turns. With the seed, turns start from the
+// validator selected by the seed, so the proposer of the first round can't be
+// predicted before the previous block is proposed.
+//
+// The validator for the first round is selected in proportion to the weights
+// if they are given for all validators (ex: stakes of validators). Otherwise,
+// it's selected uniformly. Following rounds take turns regardless of the
+// weights, so every validator gets a chance in rounds of the number of
+// validators. The bias of taking the modulus of 256-bit hash is negligible
+// for the weights.
+func getProposerIndex(
+	validators module.ValidatorList,
+	height int64,
+	round int32,
+	seed []byte,
+	weights []*big.Int,
+) int {
+	n := int64(validators.Len())
+	if seed == nil {
+		return int((height + int64(round)) % n)
+	}
+	r := new(big.Int).SetBytes(crypto.SHA3Sum256(seed))
+	var start int64
+	if total := totalWeightOf(weights, int(n)); total.Sign() > 0 {
+		r.Mod(r, total)
+		for i, w := range weights {
+			if w == nil || w.Sign() <= 0 {
+				continue
+			}
+			if r.Cmp(w) < 0 {
+				start = int64(i)
+				break
+			}
+			r.Sub(r, w)
+		}
+	} else {
+		start = r.Mod(r, big.NewInt(n)).Int64()
+	}
+	return int((start + int64(round)) % n)
+}
+
+// totalWeightOf returns the sum of positive weights, or zero if the weights
+// are not for all n validators.
+func totalWeightOf(weights []*big.Int, n int) *big.Int {
+	total := new(big.Int)
+	if len(weights) != n {
+		return total
+	}
+	for _, w := range weights {
+		if w != nil && w.Sign() > 0 {
+			total.Add(total, w)
+		}
+	}
+	return total
+}
+
+// ProposerSeed returns the VRF output in the proof of the proposer, or nil
+// if the list doesn't have a valid one. The output is determined by the key
+// of the proposer and the seed of the height, so the proposer can't choose it.
+func (vl *CommitVoteList) ProposerSeed() []byte {
+	if vl.ProposerProof == nil {
+		return nil
+	}
+	beta, err := crypto.VRFProofToHash(vl.ProposerProof.Proof)
+	if err != nil {
+		return nil
+	}
+	return beta
+}
+
+// proposerAlpha returns the input of VRF for the proposer of the height.
+func proposerAlpha(seed []byte, height int64) []byte {
+	return codec.BC.MustMarshalToBytes([]interface{}{seed, height})
+}
+
+func proposerProofOf(blk module.BlockData) *proposerProof {
+	if cvl, ok := blk.Votes().(*CommitVoteList); ok {
+		return cvl.ProposerProof
+	}
+	return nil
+}
+
+// verifyProposerProof verifies the proof of the proposer in the block with
+// the seed for the height of the block.
+func verifyProposerProof(blk module.BlockData, seed []byte) error {
+	pp := proposerProofOf(blk)
+	if pp == nil {
+		return errors.InvalidStateError.Errorf("NoProposerProof(height=%d)", blk.Height())
+	}
+	pk, err := crypto.ParsePublicKey(pp.PublicKey)
+	if err != nil {
+		return errors.InvalidStateError.Wrapf(err, "InvalidProposerKey(height=%d)", blk.Height())
+	}
+	if addr := common.NewAccountAddressFromPublicKey(pk); !addr.Equal(blk.Proposer()) {
+		return errors.InvalidStateError.Errorf(
+			"InvalidProposerKey(height=%d,addr=%s,proposer=%s)",
+			blk.Height(), addr, blk.Proposer())
+	}
+	if _, err := crypto.VRFVerify(pk, proposerAlpha(seed, blk.Height()), pp.Proof); err != nil {
+		return errors.InvalidStateError.Wrapf(err, "InvalidProposerProof(height=%d)", blk.Height())
+	}
+	return nil
+}
+
+// proposerSeedFor returns the seed for selecting proposers of the height
+// next to the block, or nil if validators take turns. It's the VRF output of
+// the proposer of the block if the block was proposed with VRF proposer
+// selection, or ID of the block on the first height using it.
+func proposerSeedFor(c base.Chain, blk module.Block) []byte {
+	sm := c.ServiceManager()
+	if !sm.GetVRFProposerSelection(blk.Result()) {
+		return nil
+	}
+	if blk.Height() > 0 {
+		if cvl, ok := blk.Votes().(*CommitVoteList); ok && cvl.ProposerProof != nil {
+			prev, err := c.BlockManager().GetBlockByHeight(blk.Height() - 1)
+			if err != nil {
+				c.Logger().Warnf("fail to get block height=%d err=%+v", blk.Height()-1, err)
+			} else if sm.GetVRFProposerSelection(prev.Result()) {
+				if beta := cvl.ProposerSeed(); beta != nil {
+					return beta
+				}
+			}
+		}
+	}
+	return blk.ID()
+}
+
+// proposerWeightsFor returns the weights of validators for selecting
+// proposers of the height next to the block with the seed, or nil if they
+// are selected uniformly.
+func proposerWeightsFor(c base.Chain, blk module.Block, validators module.ValidatorList, seed []byte) []*big.Int {
+	if seed == nil || validators == nil {
+		return nil
+	}
+	return c.ServiceManager().GetValidatorWeights(blk.Result(), validators)
+}
+
+// withProposerProof returns the commit vote list including the proof of this
+// node for proposing a block of the height.
+func (cs *consensus) withProposerProof(cvs module.CommitVoteSet) (module.CommitVoteSet, error) {
+	cvl, ok := cvs.(*CommitVoteList)
+	if !ok {
+		return nil, errors.InvalidStateError.Errorf("InvalidCommitVoteSet(type=%T)", cvs)
+	}
+	w := cs.c.Wallet()
+	prover, ok := w.(module.VRFProver)
+	if !ok {
+		return nil, errors.UnsupportedError.New("VRFUnsupportedWallet")
+	}
+	proof, err := prover.ProveVRF(proposerAlpha(cs.proposerSeed, cs.height))
+	if err != nil {
+		return nil, err
+	}
+	ncvl := *cvl
+	ncvl.bytes = nil
+	ncvl.ProposerProof = &proposerProof{
+		PublicKey: w.PublicKey(),
+		Proof:     proof,
+	}
+	return &ncvl, nil
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consensus
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestGetProposerIndex(t *testing.T) {
+	vl := testValidatorList{
+		common.MustNewAddressFromString("hx1"),
+		common.MustNewAddressFromString("hx2"),
+		common.MustNewAddressFromString("hx3"),
+	}
+	assert.Equal(t, 1, getProposerIndex(vl, 10, 0, nil, nil))
+	assert.Equal(t, 2, getProposerIndex(vl, 10, 1, nil, nil))
+
+	seed := []byte("seed")
+	start := getProposerIndex(vl, 10, 0, seed, nil)
+	assert.Equal(t, start, getProposerIndex(vl, 11, 0, seed, nil))
+	for round := int32(0); round < 6; round++ {
+		assert.Equal(t, (start+int(round))%3, getProposerIndex(vl, 10, round, seed, nil))
+	}
+}
+
+func TestGetProposerIndex_Uniform(t *testing.T) {
+	vl := testValidatorList{
+		common.MustNewAddressFromString("hx1"),
+		common.MustNewAddressFromString("hx2"),
+		common.MustNewAddressFromString("hx3"),
+		common.MustNewAddressFromString("hx4"),
+	}
+	const samples = 4000
+	counts := make([]int, len(vl))
+	for i := 0; i < samples; i++ {
+		seed := crypto.SHA3Sum256([]byte(fmt.Sprintf("seed%d", i)))
+		counts[getProposerIndex(vl, 10, 0, seed, nil)] += 1
+	}
+	for i, cnt := range counts {
+		assert.InDelta(t, samples/len(vl), cnt, samples/20, "validator %d", i)
+	}
+}
+
+func TestGetProposerIndex_Weighted(t *testing.T) {
+	vl := testValidatorList{
+		common.MustNewAddressFromString("hx1"),
+		common.MustNewAddressFromString("hx2"),
+		common.MustNewAddressFromString("hx3"),
+		common.MustNewAddressFromString("hx4"),
+	}
+	weights := []*big.Int{
+		big.NewInt(1000),
+		big.NewInt(2000),
+		big.NewInt(0),
+		big.NewInt(5000),
+	}
+	const samples = 8000
+	counts := make([]int, len(vl))
+	for i := 0; i < samples; i++ {
+		seed := crypto.SHA3Sum256([]byte(fmt.Sprintf("seed%d", i)))
+		counts[getProposerIndex(vl, 10, 0, seed, weights)] += 1
+	}
+	// the first proposer is selected in proportion to the weights
+	assert.InDelta(t, samples/8, counts[0], samples/40)
+	assert.InDelta(t, samples*2/8, counts[1], samples/40)
+	assert.Zero(t, counts[2])
+	assert.InDelta(t, samples*5/8, counts[3], samples/40)
+
+	// following rounds take turns including the validator without weight
+	seed := []byte("seed")
+	start := getProposerIndex(vl, 10, 0, seed, weights)
+	for round := int32(0); round < 8; round++ {
+		assert.Equal(t, (start+int(round))%len(vl), getProposerIndex(vl, 10, round, seed, weights))
+	}
+
+	// weights are ignored unless they are for all validators
+	for _, ws := range [][]*big.Int{
+		weights[:3],
+		{big.NewInt(0), nil, big.NewInt(0), big.NewInt(0)},
+	} {
+		assert.Equal(t,
+			getProposerIndex(vl, 10, 0, seed, nil),
+			getProposerIndex(vl, 10, 0, seed, ws))
+	}
+}
+
+type testProposedBlock struct {
+	module.BlockData
+	height   int64
+	proposer module.Address
+	votes    module.CommitVoteSet
+}
+
+func (b *testProposedBlock) Height() int64               { return b.height }
+func (b *testProposedBlock) Proposer() module.Address    { return b.proposer }
+func (b *testProposedBlock) Votes() module.CommitVoteSet { return b.votes }
+
+func newTestProposedBlock(t *testing.T, sk *crypto.PrivateKey, seed []byte, height int64) *testProposedBlock {
+	pk := sk.PublicKey()
+	proof, err := crypto.VRFProve(sk, proposerAlpha(seed, height))
+	assert.NoError(t, err)
+	cvl := &CommitVoteList{}
+	cvl.ProposerProof = &proposerProof{
+		PublicKey: pk.SerializeCompressed(),
+		Proof:     proof,
+	}
+	return &testProposedBlock{
+		height:   height,
+		proposer: common.NewAccountAddressFromPublicKey(pk),
+		votes:    NewCommitVoteSetFromBytes(cvl.Bytes()),
+	}
+}
+
+func TestVerifyProposerProof(t *testing.T) {
+	sk, _ := crypto.GenerateKeyPair()
+	seed := []byte("seed")
+
+	blk := newTestProposedBlock(t, sk, seed, 10)
+	assert.NoError(t, verifyProposerProof(blk, seed))
+	assert.Error(t, verifyProposerProof(blk, []byte("other")))
+
+	blk2 := newTestProposedBlock(t, sk, seed, 10)
+	blk2.height = 11
+	assert.Error(t, verifyProposerProof(blk2, seed))
+
+	blk3 := newTestProposedBlock(t, sk, seed, 10)
+	blk3.proposer = common.MustNewAddressFromString("hx1")
+	assert.Error(t, verifyProposerProof(blk3, seed))
+
+	blk4 := newTestProposedBlock(t, sk, seed, 10)
+	blk4.votes = NewEmptyCommitVoteList()
+	assert.Error(t, verifyProposerProof(blk4, seed))
+}
+
+func TestCommitVoteList_ProposerProof(t *testing.T) {
+	cvl := NewEmptyCommitVoteList().(*CommitVoteList)
+	legacy := cvl.Bytes()
+	assert.Nil(t, NewCommitVoteSetFromBytes(legacy).(*CommitVoteList).ProposerProof)
+
+	cvl2 := &CommitVoteList{}
+	cvl2.ProposerProof = &proposerProof{
+		PublicKey: []byte{1},
+		Proof:     []byte{2},
+	}
+	bs := cvl2.Bytes()
+	assert.NotEqual(t, legacy, bs)
+
+	cvl3 := NewCommitVoteSetFromBytes(bs).(*CommitVoteList)
+	assert.Equal(t, cvl2.ProposerProof, cvl3.ProposerProof)
+	assert.Nil(t, cvl3.NTSDProves)
+	assert.Equal(t, bs, cvl3.Bytes())
+}
+
+func TestCommitVoteList_ProposerSeed(t *testing.T) {
+	sk, _ := crypto.GenerateKeyPair()
+	seed := []byte("seed")
+
+	assert.Nil(t, NewEmptyCommitVoteList().(*CommitVoteList).ProposerSeed())
+
+	blk := newTestProposedBlock(t, sk, seed, 10)
+	cvl := blk.votes.(*CommitVoteList)
+	beta, err := crypto.VRFProofToHash(cvl.ProposerProof.Proof)
+	assert.NoError(t, err)
+	assert.Equal(t, beta, cvl.ProposerSeed())
+	assert.Equal(t, beta, common.BlockSeedOf(blk.votes))
+
+	// the output doesn't depend on anything the proposer can choose
+	blk2 := newTestProposedBlock(t, sk, seed, 10)
+	assert.Equal(t, beta, blk2.votes.(*CommitVoteList).ProposerSeed())
+
+	cvl.ProposerProof = &proposerProof{Proof: []byte{1}}
+	assert.Nil(t, cvl.ProposerSeed())
+}
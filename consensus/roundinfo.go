@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consensus
+
+import (
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+// RoundInfo is the information of rounds for committing a block. Rounds
+// before CommitRound failed by timeout, so MissedProposers are proposers of
+// them in order of the rounds. Interval is the difference of the block
+// timestamp from the previous one in microseconds.
+type RoundInfo struct {
+	Height          int64
+	CommitRound     int32
+	Interval        int64
+	MissedProposers []module.Address
+}
+
+func (ri *RoundInfo) ToJSON() map[string]interface{} {
+	missed := ri.MissedProposers
+	if missed == nil {
+		missed = []module.Address{}
+	}
+	return map[string]interface{}{
+		"height":          intconv.FormatInt(ri.Height),
+		"commitRound":     intconv.FormatInt(int64(ri.CommitRound)),
+		"interval":        intconv.FormatInt(ri.Interval),
+		"missedProposers": missed,
+	}
+}
+
+// GetRoundInfo returns the information of rounds for the block at the height.
+// votes is the commit votes for the block.
+func GetRoundInfo(c module.Chain, height int64, votes module.CommitVoteSet) (*RoundInfo, error) {
+	if height < 1 {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidHeight(height=%d)", height)
+	}
+	bm := c.BlockManager()
+	blk, err := bm.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := bm.GetBlockByHeight(height - 1)
+	if err != nil {
+		return nil, err
+	}
+	ri := &RoundInfo{
+		Height:      height,
+		CommitRound: votes.VoteRound(),
+		Interval:    blk.Timestamp() - prev.Timestamp(),
+	}
+	validators := prev.NextValidators()
+	if validators == nil || validators.Len() == 0 {
+		return ri, nil
+	}
+	seed := proposerSeedFor(c, prev)
+	weights := proposerWeightsFor(c, prev, validators, seed)
+	for round := int32(0); round < ri.CommitRound; round++ {
+		v, _ := validators.Get(getProposerIndex(validators, height, round, seed, weights))
+		if v != nil {
+			ri.MissedProposers = append(ri.MissedProposers, v.Address())
+		}
+	}
+	return ri, nil
+}
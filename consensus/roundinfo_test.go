@@ -0,0 +1,155 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+type roundTestValidator struct {
+	module.Validator
+	addr module.Address
+}
+
+func (v *roundTestValidator) Address() module.Address { return v.addr }
+
+type roundTestValidatorList struct {
+	testValidatorList
+}
+
+func (vl roundTestValidatorList) Get(i int) (module.Validator, bool) {
+	if i < 0 || i >= len(vl.testValidatorList) {
+		return nil, false
+	}
+	return &roundTestValidator{addr: vl.testValidatorList[i]}, true
+}
+
+type roundTestBlock struct {
+	module.Block
+	height     int64
+	timestamp  int64
+	validators module.ValidatorList
+}
+
+func (b *roundTestBlock) Height() int64                        { return b.height }
+func (b *roundTestBlock) Timestamp() int64                     { return b.timestamp }
+func (b *roundTestBlock) NextValidators() module.ValidatorList { return b.validators }
+func (b *roundTestBlock) Result() []byte                       { return nil }
+
+type roundTestBlockManager struct {
+	module.BlockManager
+	blocks map[int64]module.Block
+}
+
+func (bm *roundTestBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	if blk, ok := bm.blocks[height]; ok {
+		return blk, nil
+	}
+	return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+}
+
+type roundTestServiceManager struct {
+	module.ServiceManager
+}
+
+func (sm *roundTestServiceManager) GetVRFProposerSelection(result []byte) bool {
+	return false
+}
+
+type roundTestChain struct {
+	module.Chain
+	bm *roundTestBlockManager
+	sm *roundTestServiceManager
+}
+
+func (c *roundTestChain) BlockManager() module.BlockManager     { return c.bm }
+func (c *roundTestChain) ServiceManager() module.ServiceManager { return c.sm }
+
+type roundTestVotes struct {
+	module.CommitVoteSet
+	round int32
+}
+
+func (v *roundTestVotes) VoteRound() int32 { return v.round }
+
+func TestGetRoundInfo(t *testing.T) {
+	vl := roundTestValidatorList{testValidatorList{
+		common.MustNewAddressFromString("hx1"),
+		common.MustNewAddressFromString("hx2"),
+		common.MustNewAddressFromString("hx3"),
+	}}
+	c := &roundTestChain{
+		bm: &roundTestBlockManager{blocks: map[int64]module.Block{
+			0: &roundTestBlock{height: 0, timestamp: 1000},
+			1: &roundTestBlock{height: 1, timestamp: 2000, validators: vl},
+			2: &roundTestBlock{height: 2, timestamp: 5000, validators: vl},
+		}},
+		sm: &roundTestServiceManager{},
+	}
+	addr := vl.testValidatorList
+
+	tests := []struct {
+		name     string
+		height   int64
+		round    int32
+		interval int64
+		missed   []module.Address
+	}{
+		{"NoValidators", 1, 1, 1000, nil},
+		{"FirstRound", 2, 0, 3000, nil},
+		{"SecondRound", 2, 1, 3000, []module.Address{addr[2]}},
+		{"ThirdRound", 2, 2, 3000, []module.Address{addr[2], addr[0]}},
+		{"AfterAllValidators", 2, 4, 3000,
+			[]module.Address{addr[2], addr[0], addr[1], addr[2]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ri, err := GetRoundInfo(c, tt.height, &roundTestVotes{round: tt.round})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.height, ri.Height)
+			assert.Equal(t, tt.round, ri.CommitRound)
+			assert.Equal(t, tt.interval, ri.Interval)
+			assert.Equal(t, tt.missed, ri.MissedProposers)
+		})
+	}
+
+	_, err := GetRoundInfo(c, 0, &roundTestVotes{})
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+	_, err = GetRoundInfo(c, 3, &roundTestVotes{})
+	assert.True(t, errors.NotFoundError.Equals(err))
+}
+
+func TestRoundInfo_ToJSON(t *testing.T) {
+	ri := &RoundInfo{Height: 10, CommitRound: 0, Interval: 1000000}
+	jso := ri.ToJSON()
+	assert.Equal(t, "0xa", jso["height"])
+	assert.Equal(t, "0x0", jso["commitRound"])
+	assert.Equal(t, "0xf4240", jso["interval"])
+	assert.Equal(t, []module.Address{}, jso["missedProposers"])
+
+	missed := []module.Address{common.MustNewAddressFromString("hx1")}
+	ri = &RoundInfo{Height: 10, CommitRound: 1, Interval: 3000000, MissedProposers: missed}
+	jso = ri.ToJSON()
+	assert.Equal(t, "0x1", jso["commitRound"])
+	assert.Equal(t, missed, jso["missedProposers"])
+}
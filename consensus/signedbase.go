@@ -5,6 +5,7 @@ import (
 	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/sigverify"
 )
 
 type byteser interface {
@@ -30,7 +31,7 @@ func (s *signedBase) hash() []byte {
 
 func (s *signedBase) publicKey() *crypto.PublicKey {
 	if s._publicKey == nil {
-		publicKey, err := s.Signature.RecoverPublicKey(s.hash())
+		publicKey, err := sigverify.RecoverPublicKey(s.Signature, s.hash())
 		if err != nil {
 			return nil
 		}
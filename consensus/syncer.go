@@ -5,6 +5,7 @@ import (
 
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/consensus/fastsync"
 	"github.com/icon-project/goloop/module"
 )
@@ -263,8 +264,8 @@ type syncer struct {
 	fetchCanceler func() bool
 }
 
-func newSyncer(e Engine, logger log.Logger, nm module.NetworkManager, bm module.BlockManager, mutex *common.Mutex, addr module.Address) (Syncer, error) {
-	fsm, err := fastsync.NewManager(nm, bm, e, logger)
+func newSyncer(e Engine, logger log.Logger, nm module.NetworkManager, bm module.BlockManager, th *throttle.Throttle, mutex *common.Mutex, addr module.Address) (Syncer, error) {
+	fsm, err := fastsync.NewManager(nm, bm, e, th, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consensus
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	// ValidatorStatsRetention is the number of recent heights whose
+	// statistics are kept. It's about a week with 2 seconds block interval.
+	ValidatorStatsRetention = 302400
+
+	// MaxValidatorStatsRange is the maximum number of heights for a query.
+	MaxValidatorStatsRange = 43200
+)
+
+// heightStats is the record of a height committed by the consensus.
+// Latency is the difference of the block timestamp from the previous one
+// in microseconds. Seed is the seed for selecting proposers, nil if
+// validators took turns, and Weights are the weights of validators for it.
+type heightStats struct {
+	Round      int32
+	Validators []byte
+	Voted      *bitArray
+	Latency    int64
+	Seed       []byte
+	Weights    []*big.Int
+}
+
+func heightStatsKey(height int64) []byte {
+	return codec.BC.MustMarshalToBytes(height)
+}
+
+// recordValidatorStats stores the record of the height and prunes the record
+// out of ValidatorStatsRetention.
+func recordValidatorStats(dbase db.Database, height int64, hs *heightStats) error {
+	bk, err := dbase.GetBucket(db.ValidatorStatsByHeight)
+	if err != nil {
+		return err
+	}
+	bs, err := codec.BC.MarshalToBytes(hs)
+	if err != nil {
+		return err
+	}
+	if err = bk.Set(heightStatsKey(height), bs); err != nil {
+		return err
+	}
+	if height > ValidatorStatsRetention {
+		return bk.Delete(heightStatsKey(height - ValidatorStatsRetention))
+	}
+	return nil
+}
+
+func getHeightStats(bk db.Bucket, height int64) (*heightStats, error) {
+	bs, err := bk.Get(heightStatsKey(height))
+	if err != nil || bs == nil {
+		return nil, err
+	}
+	hs := new(heightStats)
+	if _, err = codec.BC.UnmarshalFromBytes(bs, hs); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidHeightStats")
+	}
+	return hs, nil
+}
+
+// ValidatorStats is the statistics of a validator for the heights committed
+// while the node was in the consensus. Heights synchronized by fast sync
+// are not recorded.
+type ValidatorStats struct {
+	Address     module.Address
+	StartHeight int64
+	EndHeight   int64
+
+	// Heights is the number of recorded heights where the address was one
+	// of the validators.
+	Heights int64
+
+	BlocksProposed int64
+	BlocksMissed   int64
+	VotesMissed    int64
+
+	latencySum int64
+}
+
+// AverageProposalLatency returns the average latency of proposed blocks
+// in microseconds.
+func (s *ValidatorStats) AverageProposalLatency() int64 {
+	if s.BlocksProposed == 0 {
+		return 0
+	}
+	return s.latencySum / s.BlocksProposed
+}
+
+func (s *ValidatorStats) add(hs *heightStats, height int64, validators module.ValidatorList) {
+	idx := validators.IndexOf(s.Address)
+	if idx < 0 {
+		return
+	}
+	s.Heights++
+	for round := int32(0); round <= hs.Round; round++ {
+		if getProposerIndex(validators, height, round, hs.Seed, hs.Weights) != idx {
+			continue
+		}
+		if round == hs.Round {
+			s.BlocksProposed++
+			s.latencySum += hs.Latency
+		} else {
+			s.BlocksMissed++
+		}
+	}
+	if hs.Voted == nil || idx >= hs.Voted.Len() || !hs.Voted.Get(idx) {
+		s.VotesMissed++
+	}
+}
+
+func (s *ValidatorStats) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"address":                s.Address,
+		"startHeight":            intconv.FormatInt(s.StartHeight),
+		"endHeight":              intconv.FormatInt(s.EndHeight),
+		"heights":                intconv.FormatInt(s.Heights),
+		"blocksProposed":         intconv.FormatInt(s.BlocksProposed),
+		"blocksMissed":           intconv.FormatInt(s.BlocksMissed),
+		"votesMissed":            intconv.FormatInt(s.VotesMissed),
+		"averageProposalLatency": intconv.FormatInt(s.AverageProposalLatency()),
+	}
+}
+
+// GetValidatorStats returns the statistics of the validator from start to
+// end height. The address is the node address of the validator.
+func GetValidatorStats(c module.Chain, addr module.Address, start, end int64) (*ValidatorStats, error) {
+	if start < 1 || start > end {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidRange(start=%d,end=%d)", start, end)
+	}
+	if end-start >= MaxValidatorStatsRange {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"TooLargeRange(start=%d,end=%d,max=%d)", start, end, MaxValidatorStatsRange)
+	}
+	bk, err := c.Database().GetBucket(db.ValidatorStatsByHeight)
+	if err != nil {
+		return nil, err
+	}
+	stats := &ValidatorStats{
+		Address:     addr,
+		StartHeight: start,
+		EndHeight:   end,
+	}
+	validatorsByHash := make(map[string]module.ValidatorList)
+	for h := start; h <= end; h++ {
+		hs, err := getHeightStats(bk, h)
+		if err != nil {
+			return nil, err
+		}
+		if hs == nil {
+			continue
+		}
+		validators, ok := validatorsByHash[string(hs.Validators)]
+		if !ok {
+			validators = c.ServiceManager().ValidatorListFromHash(hs.Validators)
+			validatorsByHash[string(hs.Validators)] = validators
+		}
+		if validators == nil || validators.Len() == 0 {
+			continue
+		}
+		stats.add(hs, h, validators)
+	}
+	return stats, nil
+}
+
+// recordHeightStats records the statistics for the height being committed.
+func (cs *consensus) recordHeightStats() {
+	blk := cs.currentBlockParts.validatedBlock
+	if blk == nil || cs.validators == nil || cs.lastBlock == nil {
+		return
+	}
+	hs := &heightStats{
+		Round:      cs.commitRound,
+		Validators: cs.validators.Hash(),
+		Latency:    blk.Timestamp() - cs.lastBlock.Timestamp(),
+		Seed:       cs.proposerSeed,
+		Weights:    cs.proposerWeights,
+	}
+	if vs := cs.hvs.votesFor(cs.commitRound, VoteTypePrecommit).voteSetForOverTwoThird(); vs != nil {
+		hs.Voted = vs.getMask()
+	}
+	if err := recordValidatorStats(cs.c.Database(), cs.height, hs); err != nil {
+		cs.log.Warnf("fail to record validator stats height=%d err=%+v", cs.height, err)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+)
+
+type testValidatorList []module.Address
+
+func (vl testValidatorList) Hash() []byte  { return []byte("validators") }
+func (vl testValidatorList) Bytes() []byte { return nil }
+func (vl testValidatorList) Flush() error  { return nil }
+func (vl testValidatorList) Len() int      { return len(vl) }
+
+func (vl testValidatorList) IndexOf(addr module.Address) int {
+	for i, a := range vl {
+		if a.Equal(addr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (vl testValidatorList) Get(i int) (module.Validator, bool) {
+	return nil, false
+}
+
+func TestValidatorStats_add(t *testing.T) {
+	vl := testValidatorList{
+		common.MustNewAddressFromString("hx1"),
+		common.MustNewAddressFromString("hx2"),
+		common.MustNewAddressFromString("hx3"),
+	}
+	voted := newBitArray(3)
+	voted.Set(0)
+	voted.Set(2)
+
+	// height 3: proposer of round 0 is vl[0], round 1 is vl[1]
+	hs := &heightStats{Round: 1, Voted: voted, Latency: 2000}
+
+	s0 := &ValidatorStats{Address: vl[0]}
+	s0.add(hs, 3, vl)
+	assert.EqualValues(t, 1, s0.Heights)
+	assert.EqualValues(t, 0, s0.BlocksProposed)
+	assert.EqualValues(t, 1, s0.BlocksMissed)
+	assert.EqualValues(t, 0, s0.VotesMissed)
+
+	s1 := &ValidatorStats{Address: vl[1]}
+	s1.add(hs, 3, vl)
+	assert.EqualValues(t, 1, s1.BlocksProposed)
+	assert.EqualValues(t, 0, s1.BlocksMissed)
+	assert.EqualValues(t, 1, s1.VotesMissed)
+	assert.EqualValues(t, 2000, s1.AverageProposalLatency())
+
+	s4 := &ValidatorStats{Address: common.MustNewAddressFromString("hx4")}
+	s4.add(hs, 3, vl)
+	assert.EqualValues(t, 0, s4.Heights)
+	assert.EqualValues(t, 0, s4.VotesMissed)
+}
+
+func TestRecordValidatorStats(t *testing.T) {
+	dbase := db.NewMapDB()
+	bk, err := dbase.GetBucket(db.ValidatorStatsByHeight)
+	assert.NoError(t, err)
+
+	voted := newBitArray(2)
+	voted.Set(1)
+	hs := &heightStats{Round: 2, Validators: []byte("validators"), Voted: voted, Latency: 100}
+	assert.NoError(t, recordValidatorStats(dbase, 1, hs))
+
+	hs2, err := getHeightStats(bk, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, hs.Round, hs2.Round)
+	assert.Equal(t, hs.Validators, hs2.Validators)
+	assert.True(t, hs.Voted.Equal(hs2.Voted))
+	assert.Equal(t, hs.Latency, hs2.Latency)
+
+	// the record out of retention is removed
+	assert.NoError(t, recordValidatorStats(dbase, 1+ValidatorStatsRetention, hs))
+	hs2, err = getHeightStats(bk, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, hs2)
+}
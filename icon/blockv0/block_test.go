@@ -0,0 +1,42 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blockv0_test
+
+import (
+	"testing"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/blockv0"
+)
+
+type emptyStore struct{}
+
+func (emptyStore) GetRepsByHash(hash []byte) (*blockv0.RepsList, error) {
+	return nil, errors.ErrNotFound
+}
+
+func FuzzParseBlock(f *testing.F) {
+	f.Add([]byte(`{"version":"0.1a","height":1,"prev_block_hash":"","block_hash":"","confirmed_transaction_list":[]}`))
+	f.Add([]byte(`{"version":"0.3","height":"0x1","prevVotes":[],"leaderVotes":[],"transactions":[]}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		blk, err := blockv0.ParseBlock(data, emptyStore{})
+		if err == nil {
+			blk.ID()
+			blk.Verify(nil)
+		}
+	})
+}
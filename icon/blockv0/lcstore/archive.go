@@ -0,0 +1,245 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const (
+	ArchiveScheme = "archive+"
+	S3Scheme      = "s3://"
+
+	DefaultS3Region = "us-east-1"
+)
+
+// S3Credential is the credential for signing requests to S3 compatible
+// storages with AWS signature version 4.
+type S3Credential struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+}
+
+// ArchiveStore reads objects of an archive, where each key-value pair of
+// loopchain database is stored as an object named with the hex encoded key
+// under the base URL.
+type ArchiveStore struct {
+	client *http.Client
+	base   *url.URL
+	region string
+	cred   *S3Credential
+}
+
+func (s *ArchiveStore) objectURL(key []byte) *url.URL {
+	u := *s.base
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + hex.EncodeToString(key)
+	u.RawPath = ""
+	return &u
+}
+
+func (s *ArchiveStore) Get(key []byte) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.cred != nil {
+		signS3Request(req, s.cred, s.region, time.Now())
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return bs, nil
+	case http.StatusNotFound:
+		return nil, errors.ErrNotFound
+	default:
+		return nil, errors.InvalidStateError.Errorf(
+			"ArchiveFailure(url=%s,status=%s,resp=%q)",
+			req.URL.Redacted(), resp.Status, bs)
+	}
+}
+
+func (s *ArchiveStore) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// signS3Request signs the request without body with AWS signature version 4.
+func signS3Request(req *http.Request, cred *S3Credential, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(nil)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	headers := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	values := []string{req.URL.Host, payloadHash, amzDate}
+	if len(cred.SessionToken) > 0 {
+		req.Header.Set("X-Amz-Security-Token", cred.SessionToken)
+		headers = append(headers, "x-amz-security-token")
+		values = append(values, cred.SessionToken)
+	}
+	var canonicalHeaders strings.Builder
+	for i, h := range headers {
+		canonicalHeaders.WriteString(h + ":" + values[i] + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+cred.SecretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		cred.AccessKey, scope, signedHeaders, signature))
+}
+
+func s3CredentialFromEnv() *S3Credential {
+	ak := os.Getenv("AWS_ACCESS_KEY_ID")
+	sk := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if len(ak) == 0 || len(sk) == 0 {
+		return nil
+	}
+	return &S3Credential{
+		AccessKey:    ak,
+		SecretKey:    sk,
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// newS3ArchiveStore returns ArchiveStore for the URI in the form of
+// "s3://<bucket>/<prefix>[?region=<region>&endpoint=<endpoint>]".
+// Objects are accessed in path style, so it works with S3 compatible
+// storages with the endpoint. Credentials are read from AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN, and requests are not signed
+// without them.
+func newS3ArchiveStore(uri string) (*ArchiveStore, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidS3URI(uri=%s)", uri)
+	}
+	if len(u.Host) == 0 {
+		return nil, errors.IllegalArgumentError.Errorf("NoBucket(uri=%s)", uri)
+	}
+	query := u.Query()
+	region := query.Get("region")
+	if len(region) == 0 {
+		region = os.Getenv("AWS_REGION")
+	}
+	if len(region) == 0 {
+		region = DefaultS3Region
+	}
+	endpoint := query.Get("endpoint")
+	if len(endpoint) == 0 {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+	base, err := url.Parse(endpoint)
+	if err != nil || len(base.Host) == 0 {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidEndpoint(endpoint=%s)", endpoint)
+	}
+	base.Path = strings.TrimSuffix(base.Path, "/") + "/" + u.Host + u.Path
+	return &ArchiveStore{
+		client: new(http.Client),
+		base:   base,
+		region: region,
+		cred:   s3CredentialFromEnv(),
+	}, nil
+}
+
+// newHTTPArchiveStore returns ArchiveStore for the URI in the form of
+// "archive+http(s)://<host>/<path>".
+func newHTTPArchiveStore(uri string) (*ArchiveStore, error) {
+	base, err := url.Parse(strings.TrimPrefix(uri, ArchiveScheme))
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidArchiveURI(uri=%s)", uri)
+	}
+	if (base.Scheme != "http" && base.Scheme != "https") || len(base.Host) == 0 {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidArchiveURI(uri=%s)", uri)
+	}
+	return &ArchiveStore{
+		client: new(http.Client),
+		base:   base,
+	}, nil
+}
+
+// OpenArchiveDB opens the archive of loopchain database with the URI.
+// It accepts "archive+http(s)://" and "s3://" URIs.
+func OpenArchiveDB(uri string, rps int) (Database, error) {
+	var store *ArchiveStore
+	var err error
+	if strings.HasPrefix(uri, S3Scheme) {
+		store, err = newS3ArchiveStore(uri)
+	} else {
+		store, err = newHTTPArchiveStore(uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDB{
+		store: store,
+		tr:    new(tpsRegulator).Init(rps),
+	}, nil
+}
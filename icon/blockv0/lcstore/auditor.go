@@ -0,0 +1,177 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcstore
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/blockv0"
+)
+
+type AuditIssueType string
+
+const (
+	// AuditMissing is for the block (or the transaction) which is not
+	// in the store.
+	AuditMissing AuditIssueType = "missing"
+
+	// AuditCorrupt is for the data which can't be parsed.
+	AuditCorrupt AuditIssueType = "corrupt"
+
+	// AuditInvalid is for the block which fails on verification of
+	// blockv0 rules (hashes, rep lists and votes).
+	AuditInvalid AuditIssueType = "invalid"
+
+	// AuditTxMismatch is for the transaction whose stored block hash,
+	// height or index is different from the block including it.
+	AuditTxMismatch AuditIssueType = "txMismatch"
+)
+
+type AuditIssue struct {
+	Height      int64           `json:"height"`
+	Type        AuditIssueType  `json:"type"`
+	Transaction common.HexBytes `json:"transaction,omitempty"`
+	Error       string          `json:"error"`
+}
+
+type AuditReport struct {
+	From         int64         `json:"from"`
+	To           int64         `json:"to"`
+	Blocks       int64         `json:"blocks"`
+	Transactions int64         `json:"transactions"`
+	Elapsed      string        `json:"elapsed"`
+	Issues       []*AuditIssue `json:"issues"`
+}
+
+// CorruptHeights returns heights with any issue in ascending order.
+func (r *AuditReport) CorruptHeights() []int64 {
+	var heights []int64
+	for _, issue := range r.Issues {
+		if l := len(heights); l == 0 || heights[l-1] != issue.Height {
+			heights = append(heights, issue.Height)
+		}
+	}
+	return heights
+}
+
+func (r *AuditReport) addIssue(height int64, t AuditIssueType, tx []byte, err error) {
+	r.Issues = append(r.Issues, &AuditIssue{
+		Height:      height,
+		Type:        t,
+		Transaction: tx,
+		Error:       fmt.Sprint(err),
+	})
+}
+
+// Audit walks blocks of the store from the height to the height, and
+// verifies them with blockv0 rules. It also checks whether transactions
+// of each block are stored with the block. If to is negative, it walks
+// to the last block. Problems of the store are reported as issues, and
+// it returns error only if it fails to continue.
+func Audit(lc *Store, from, to int64, onBlock func(height int64)) (*AuditReport, error) {
+	if from < 0 {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidFrom(from=%d)", from)
+	}
+	if to < 0 {
+		last, err := lc.GetLastBlock()
+		if err != nil {
+			return nil, errors.Wrap(err, "FailToGetLastBlock")
+		}
+		to = last.Height()
+	}
+	if to < from {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidRange(from=%d,to=%d)", from, to)
+	}
+	report := &AuditReport{
+		From:   from,
+		To:     to,
+		Issues: []*AuditIssue{},
+	}
+	start := time.Now()
+
+	var prev blockv0.Block
+	if from > 0 {
+		if blk, err := lc.GetBlockByHeight(int(from - 1)); err == nil {
+			prev = blk
+		}
+	}
+	for height := from; height <= to; height++ {
+		blk := auditBlock(lc, report, height, prev)
+		prev = blk
+		report.Blocks++
+		if onBlock != nil {
+			onBlock(height)
+		}
+	}
+	report.Elapsed = time.Since(start).String()
+	return report, nil
+}
+
+// auditBlock verifies the block at the height, and returns the block if
+// it's available.
+func auditBlock(lc *Store, report *AuditReport, height int64, prev blockv0.Block) blockv0.Block {
+	bs, err := lc.GetBlockJSONByHeight(int(height), false)
+	if err != nil || len(bs) == 0 {
+		if err == nil {
+			err = errors.ErrNotFound
+		}
+		report.addIssue(height, AuditMissing, nil, err)
+		return nil
+	}
+	blk, err := blockv0.ParseBlock(bs, lc)
+	if err != nil {
+		report.addIssue(height, AuditCorrupt, nil, err)
+		return nil
+	}
+	if blk.Height() != height {
+		report.addIssue(height, AuditInvalid, nil,
+			errors.InvalidStateError.Errorf("InvalidHeight(exp=%d,real=%d)", height, blk.Height()))
+		return nil
+	}
+	if prev != nil && !bytes.Equal(blk.PrevID(), prev.ID()) {
+		report.addIssue(height, AuditInvalid, nil,
+			errors.InvalidStateError.Errorf("InvalidPrevID(exp=%#x,real=%#x)", prev.ID(), blk.PrevID()))
+	} else if err := blk.Verify(prev); err != nil {
+		report.addIssue(height, AuditInvalid, nil, err)
+	}
+	for idx, tx := range blk.NormalTransactions() {
+		report.Transactions++
+		tinfo, err := lc.GetResult(tx.ID())
+		if err != nil {
+			if errors.NotFoundError.Equals(err) {
+				report.addIssue(height, AuditMissing, tx.ID(), err)
+			} else {
+				report.addIssue(height, AuditCorrupt, tx.ID(), err)
+			}
+			continue
+		}
+		if int64(tinfo.BlockHeight) != height ||
+			!bytes.Equal(tinfo.BlockID, blk.ID()) ||
+			tinfo.TxIndex.Value != int32(idx) {
+			report.addIssue(height, AuditTxMismatch, tx.ID(),
+				errors.InvalidStateError.Errorf(
+					"InvalidInclusion(exp=%d:%#x:%d,real=%d:%#x:%d)",
+					height, blk.ID(), idx,
+					tinfo.BlockHeight, tinfo.BlockID.Bytes(), tinfo.TxIndex.Value))
+		}
+	}
+	return blk
+}
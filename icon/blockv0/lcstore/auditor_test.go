@@ -0,0 +1,52 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	// blocks of testDatabase are not parsable, and blocks after the last
+	// are missing.
+	lc := &Store{Database: &testDatabase{last: 10}}
+
+	var walked []int64
+	report, err := Audit(lc, 9, 12, func(height int64) {
+		walked = append(walked, height)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{9, 10, 11, 12}, walked)
+	assert.EqualValues(t, 4, report.Blocks)
+	assert.EqualValues(t, 0, report.Transactions)
+	assert.Equal(t, []int64{9, 10, 11, 12}, report.CorruptHeights())
+
+	types := make([]AuditIssueType, len(report.Issues))
+	for i, issue := range report.Issues {
+		types[i] = issue.Type
+	}
+	assert.Equal(t, []AuditIssueType{
+		AuditCorrupt, AuditCorrupt, AuditMissing, AuditMissing,
+	}, types)
+
+	_, err = Audit(lc, 3, 2, nil)
+	assert.Error(t, err)
+	_, err = Audit(lc, -1, 2, nil)
+	assert.Error(t, err)
+}
@@ -28,13 +28,31 @@ import (
 )
 
 const (
-	MaxTrials        = 5
-	DelayBeforeRetry = 500 * time.Millisecond
+	MaxTrials           = 5
+	DelayBeforeRetry    = 500 * time.Millisecond
+	MaxDelayBeforeRetry = 16 * time.Second
 )
 
+// CacheConfig is the configuration of ForwardCache. MaxBlocks is the number
+// of blocks fetched ahead by MaxWorkers workers. A failed fetch is tried
+// MaxTrials times with the delay doubled for each trial.
 type CacheConfig struct {
 	MaxWorkers int `json:"max_workers"`
 	MaxBlocks  int `json:"max_blocks"`
+	MaxTrials  int `json:"max_trials,omitempty"`
+}
+
+// delayBeforeRetry returns the delay before the trial. It starts from
+// DelayBeforeRetry and doubles for each trial up to MaxDelayBeforeRetry.
+func delayBeforeRetry(trial int) time.Duration {
+	delay := DelayBeforeRetry
+	for i := 2; i < trial && delay < MaxDelayBeforeRetry; i++ {
+		delay *= 2
+	}
+	if delay > MaxDelayBeforeRetry {
+		delay = MaxDelayBeforeRetry
+	}
+	return delay
 }
 
 type blockTask struct {
@@ -106,6 +124,13 @@ func (cs *ForwardCache) workLoop() {
 	}
 }
 
+func (cs *ForwardCache) maxTrials() int {
+	if cs.config.MaxTrials > 0 {
+		return cs.config.MaxTrials
+	}
+	return MaxTrials
+}
+
 func (cs *ForwardCache) getBlockTask(height int64) *blockTask {
 	cs.lock.Lock()
 	defer cs.lock.Unlock()
@@ -165,6 +190,7 @@ func (cs *ForwardCache) scheduleFollowings(b blockv0.Block) {
 
 func (cs *ForwardCache) doGetBlockByHeight(height int) (blockv0.Block, error) {
 	trial := 1
+	maxTrials := cs.maxTrials()
 	for {
 		block, err := cs.Store.GetBlockByHeight(height)
 		if err == nil {
@@ -174,14 +200,14 @@ func (cs *ForwardCache) doGetBlockByHeight(height int) (blockv0.Block, error) {
 			return nil, err
 		} else {
 			trial += 1
-			if trial > MaxTrials {
+			if trial > maxTrials {
 				cs.log.Warnf("BLOCK failed height=%d err=%+v",
 					height, err)
 				return nil, err
 			} else {
 				cs.log.Debugf("BLOCK retry height=%d trial=[%d/%d] err=%v",
-					height, trial, MaxTrials, err)
-				time.Sleep(DelayBeforeRetry)
+					height, trial, maxTrials, err)
+				time.Sleep(delayBeforeRetry(trial))
 			}
 		}
 	}
@@ -239,18 +265,19 @@ func (cs *ForwardCache) getReceiptTask(id []byte) *receiptTask {
 }
 
 func (cs *ForwardCache) doGetReceipt(id []byte) (module.Receipt, error) {
-	trial := 0
+	trial := 1
+	maxTrials := cs.maxTrials()
 	for {
 		if rct, err := cs.Store.GetReceipt(id); err == nil {
 			return rct, nil
 		} else {
 			trial += 1
-			if trial >= MaxTrials {
+			if trial > maxTrials {
 				cs.log.Warnf("RECEIPT failure id=%#x", id)
 				return nil, err
 			} else {
-				cs.log.Debugf("RECEIPT retry tid=%#x trial=%d err=%+v", id, trial, err)
-				time.Sleep(DelayBeforeRetry)
+				cs.log.Debugf("RECEIPT retry tid=%#x trial=[%d/%d] err=%+v", id, trial, maxTrials, err)
+				time.Sleep(delayBeforeRetry(trial))
 			}
 		}
 	}
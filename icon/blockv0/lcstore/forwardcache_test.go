@@ -0,0 +1,38 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayBeforeRetry(t *testing.T) {
+	assert.Equal(t, DelayBeforeRetry, delayBeforeRetry(2))
+	assert.Equal(t, DelayBeforeRetry*2, delayBeforeRetry(3))
+	assert.Equal(t, DelayBeforeRetry*4, delayBeforeRetry(4))
+	assert.Equal(t, MaxDelayBeforeRetry, delayBeforeRetry(100))
+}
+
+func TestForwardCache_maxTrials(t *testing.T) {
+	cs := NewForwardCache(&Store{}, nil, nil)
+	assert.Equal(t, MaxTrials, cs.maxTrials())
+
+	cs = NewForwardCache(&Store{}, nil, &CacheConfig{MaxWorkers: 1, MaxBlocks: 1, MaxTrials: 10})
+	assert.Equal(t, 10, cs.maxTrials())
+}
@@ -28,18 +28,43 @@ import (
 	"github.com/icon-project/goloop/common/errors"
 )
 
-type LevelDB struct {
+// KeyValueStore is the storage of raw key-value pairs of loopchain.
+// It returns errors.ErrNotFound for an absent key.
+type KeyValueStore interface {
+	Get(key []byte) ([]byte, error)
+	Close() error
+}
+
+type levelDBStore struct {
 	leveldb *leveldb.DB
 }
 
-func (ds *LevelDB) get(key []byte) ([]byte, error) {
-	bs, err := ds.leveldb.Get(key, nil)
+func (s *levelDBStore) Get(key []byte) ([]byte, error) {
+	bs, err := s.leveldb.Get(key, nil)
 	if err == leveldb.ErrNotFound {
 		return nil, errors.ErrNotFound
 	}
 	return bs, err
 }
 
+func (s *levelDBStore) Close() error {
+	return s.leveldb.Close()
+}
+
+// LevelDB reads legacy data in the key layout of loopchain database
+// from the KeyValueStore.
+type LevelDB struct {
+	store KeyValueStore
+	tr    *tpsRegulator
+}
+
+func (ds *LevelDB) get(key []byte) ([]byte, error) {
+	if ds.tr != nil {
+		ds.tr.Wait()
+	}
+	return ds.store.Get(key)
+}
+
 func (ds *LevelDB) GetBlockJSONByHeight(height int, pre bool) ([]byte, error) {
 	prefix := "block_height_key"
 	key := make([]byte, len(prefix)+12)
@@ -127,10 +152,13 @@ func (ds *LevelDB) GetRepsJSONByHash(id []byte) ([]byte, error) {
 }
 
 func (ds *LevelDB) Close() error {
-	return ds.leveldb.Close()
+	return ds.store.Close()
 }
 
 func (ds *LevelDB) GetTPS() float32 {
+	if ds.tr != nil {
+		return ds.tr.GetTPS()
+	}
 	return 0
 }
 
@@ -141,6 +169,6 @@ func OpenLevelDB(dir string) (Database, error) {
 	if db, err := leveldb.OpenFile(dir, opts); err != nil {
 		return nil, err
 	} else {
-		return &LevelDB{db}, nil
+		return &LevelDB{store: &levelDBStore{db}}, nil
 	}
 }
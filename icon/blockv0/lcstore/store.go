@@ -158,12 +158,23 @@ func (lc *Store) SetReceiptParameter(dbase db.Database, rev module.Revision) {
 	lc.ReceiptRevision = rev
 }
 
+// OpenStore opens the store with comma separated URIs. Each URI can be
+// a directory of loopchain database, an endpoint of the citizen node
+// ("http(s)://"), or an archive of loopchain database ("archive+http(s)://"
+// or "s3://"). Multiple databases are merged in the order of the URIs.
 func OpenStore(blockuri string, rps int) (*Store, error) {
 	lcdb := new(Store)
 	uris := strings.Split(blockuri, ",")
 	var dbs []Database
 	for _, uri := range uris {
-		if strings.HasPrefix(uri, "http://") ||
+		if strings.HasPrefix(uri, ArchiveScheme) ||
+			strings.HasPrefix(uri, S3Scheme) {
+			if bs, err := OpenArchiveDB(uri, rps); err != nil {
+				return nil, err
+			} else {
+				dbs = append(dbs, bs)
+			}
+		} else if strings.HasPrefix(uri, "http://") ||
 			strings.HasPrefix(uri, "https://") {
 			if bs, err := OpenNodeDB(uri, rps); err != nil {
 				return nil, err
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/icon/blockv0"
 	"github.com/icon-project/goloop/icon/blockv1"
@@ -169,3 +170,18 @@ func TestNewBlockManager_DefaultIsV1AndPrevBlockOfLastBlockIsNonGenesisV2(t *tes
 	assert.NoError(err)
 	assert.Equal(blk.ID(), blk2.ID())
 }
+
+func FuzzNewBlockFromReader(f *testing.F) {
+	f.Add(codec.BC.MustMarshalToBytes(&blockv1.HeaderFormat{VersionV0: blockv0.Version03}))
+	f.Add([]byte{0xc0, 0xc0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		dbase := db.NewMapDB()
+		if blk, err := blockv1.NewBlockFromReader(dbase, bytes.NewReader(data)); err == nil {
+			blk.ID()
+			blk.ToJSON(module.JSONVersion3)
+		}
+		if blk, err := blockv1.NewBlockFromHeaderReader(dbase, bytes.NewReader(data)); err == nil {
+			blk.ID()
+		}
+	})
+}
@@ -360,6 +360,27 @@ var chainMethods = []*chainMethod{
 			scoreapi.Dict,
 		},
 	}, icmodule.RevisionIISS, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getIScoreBreakdown",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionIScoreBreakdown, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getRewardLedger",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		[]scoreapi.Parameter{
+			{"startBlockHeight", scoreapi.Integer, nil, nil},
+			{"offset", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionRewardLedger, 0},
 	{scoreapi.Method{
 		scoreapi.Function, "registerPRep",
 		scoreapi.FlagExternal | scoreapi.FlagPayable, 7,
@@ -454,6 +475,16 @@ var chainMethods = []*chainMethod{
 		},
 		nil,
 	}, icmodule.RevisionEnableBondAPIs, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getAccountSnapshot",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionAccountSnapshot, 0},
 	{scoreapi.Method{
 		scoreapi.Function, "getBond",
 		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 1,
@@ -559,6 +590,24 @@ var chainMethods = []*chainMethod{
 		},
 		nil,
 	}, icmodule.RevisionICON2R0, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "setIssuePolicy",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"type", scoreapi.String, nil, nil},
+			{"amount", scoreapi.Integer, nil, nil},
+			{"cap", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionIssuePolicy, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getIssuePolicy",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionIssuePolicy, 0},
 	{scoreapi.Method{
 		scoreapi.Function, "getScoreOwner",
 		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 1,
@@ -644,6 +693,149 @@ var chainMethods = []*chainMethod{
 		},
 		nil,
 	}, icmodule.RevisionICON2R3, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "setDoubleSignSlashingRate",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"slashingRate", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionDoubleSign, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "reportDoubleSign",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"vote1", scoreapi.Bytes, nil, nil},
+			{"vote2", scoreapi.Bytes, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionDoubleSign, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getPenaltyHistory",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionPenaltyHistory, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "requestUnjail",
+		scoreapi.FlagExternal | scoreapi.FlagPayable, 0,
+		nil,
+		nil,
+	}, icmodule.RevisionJail, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "setJailConfig",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"unjailPeriod", scoreapi.Integer, nil, nil},
+			{"unjailFee", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionJail, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getJailConfig",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionJail, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getExpiringEntries",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"startBlockHeight", scoreapi.Integer, nil, nil},
+			{"endBlockHeight", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionExpiringEntries, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "setCommissionRate",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"rate", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionCommission, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "setCommissionConfig",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"changeDelay", scoreapi.Integer, nil, nil},
+			{"maxChange", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionCommission, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getCommissionConfig",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionCommission, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getTreasuryAccount",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		[]scoreapi.Parameter{
+			{"termSequence", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionTreasury, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "registerProposal",
+		scoreapi.FlagExternal, 3,
+		[]scoreapi.Parameter{
+			{"type", scoreapi.Integer, nil, nil},
+			{"value", scoreapi.String, nil, nil},
+			{"applyHeight", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionNetworkProposal, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "voteProposal",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Bytes, nil, nil},
+			{"vote", scoreapi.Bool, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionNetworkProposal, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "cancelProposal",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Bytes, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionNetworkProposal, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getProposal",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Bytes, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionNetworkProposal, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getProposals",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		[]scoreapi.Parameter{
+			{"status", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionNetworkProposal, 0},
 	{scoreapi.Method{
 		scoreapi.Function, "setUseSystemDeposit",
 		scoreapi.FlagExternal, 2,
@@ -700,6 +892,45 @@ var chainMethods = []*chainMethod{
 		},
 		nil,
 	}, icmodule.RevisionBTP2, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "registerPRepNodeKey",
+		scoreapi.FlagExternal, 3,
+		[]scoreapi.Parameter{
+			{"dsa", scoreapi.String, nil, nil},
+			{"pubKey", scoreapi.Bytes, nil, nil},
+			{"proof", scoreapi.Bytes, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionPRepNodeKey, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getPRepNodeKeyHistory",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionPRepNodeKey, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "rotatePRepNodeKey",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"pubKey", scoreapi.Bytes, nil, nil},
+			{"proof", scoreapi.Bytes, nil, nil},
+		},
+		nil,
+	}, icmodule.RevisionNodeKeyRotation, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getPRepNodeKeyRotation",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, icmodule.RevisionNodeKeyRotation, 0},
 	{scoreapi.Method{
 		scoreapi.Function, "openBTPNetwork",
 		scoreapi.FlagExternal, 3,
@@ -17,6 +17,7 @@
 package icon
 
 import (
+	"github.com/icon-project/goloop/btp/ntm"
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/intconv"
@@ -97,6 +98,10 @@ func (s *chainScore) setPRepNodePublicKey(address module.Address, pubKey []byte)
 	if prep == nil {
 		return icmodule.IllegalArgumentError.New("address is not P-Rep")
 	}
+	return s.applyPRepNodePublicKey(es, prep, pubKey, register)
+}
+
+func (s *chainScore) applyPRepNodePublicKey(es *iiss.ExtensionStateImpl, prep *icstate.PRep, pubKey []byte, register bool) error {
 	nodeAddress := prep.NodeAddress()
 
 	pk, err := crypto.ParsePublicKey(pubKey)
@@ -143,6 +148,187 @@ func (s *chainScore) setPRepNodePublicKey(address module.Address, pubKey []byte)
 	return nil
 }
 
+// nodeKeyPoPMessage returns the message to be signed with the private key of
+// pubKey for the proof of possession. It binds the key to the owner and DSA,
+// so the proof can't be replayed by others.
+func nodeKeyPoPMessage(owner module.Address, dsa string, pubKey []byte) []byte {
+	msg := make([]byte, 0, len(owner.Bytes())+len(dsa)+len(pubKey))
+	msg = append(msg, owner.Bytes()...)
+	msg = append(msg, dsa...)
+	return append(msg, pubKey...)
+}
+
+func (s *chainScore) Ex_registerPRepNodeKey(dsa string, pubKey []byte, proof []byte) error {
+	if err := s.tryChargeCall(false); err != nil {
+		return err
+	}
+	if s.from.IsContract() {
+		return scoreresult.New(module.StatusAccessDenied, "NoPermission")
+	}
+	mod := ntm.DSAModuleForName(dsa)
+	if mod == nil {
+		return icmodule.IllegalArgumentError.Errorf("Invalid DSA %s", dsa)
+	}
+	if err := mod.Verify(pubKey); err != nil {
+		return icmodule.IllegalArgumentError.Wrap(err, "Invalid pubKey")
+	}
+	if err := mod.VerifyProofOfPossession(pubKey, nodeKeyPoPMessage(s.from, dsa, pubKey), proof); err != nil {
+		return icmodule.IllegalArgumentError.Wrap(err, "Invalid proof of possession")
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	prep := es.GetPRep(s.from)
+	if prep == nil {
+		return icmodule.IllegalArgumentError.New("address is not P-Rep")
+	}
+
+	if dsa == iconDSA {
+		// the node address of P-Rep follows the key for ICON DSA,
+		// so validators are updated with it.
+		if err = s.applyPRepNodePublicKey(es, prep, pubKey, false); err != nil {
+			return err
+		}
+	} else {
+		bc := s.newBTPContext()
+		bs, err := s.getBTPState()
+		if err != nil {
+			return err
+		}
+		nodeAddress := prep.NodeAddress()
+		if err = bs.SetPublicKey(bc, nodeAddress, dsa, pubKey); err != nil {
+			return err
+		}
+		prep.SetDSAMask(bc.GetPublicKeyMask(nodeAddress))
+		if err = es.OnSetPublicKey(s.newCallContext(s.cc), prep.Owner(), bc.GetDSAIndex(dsa)); err != nil {
+			return err
+		}
+	}
+
+	if err = es.State.AddNodeKeyRecord(s.from, &icstate.NodeKeyRecord{
+		BlockHeight: s.cc.BlockHeight(),
+		DSA:         dsa,
+		PubKey:      pubKey,
+	}); err != nil {
+		return err
+	}
+	s.cc.OnEvent(state.SystemAddress,
+		[][]byte{
+			[]byte("PRepNodeKeyRegistered(Address,str,bytes)"),
+			s.from.Bytes(),
+			[]byte(dsa),
+		},
+		[][]byte{
+			pubKey,
+		},
+	)
+	return nil
+}
+
+func (s *chainScore) Ex_getPRepNodeKeyHistory(address module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(false); err != nil {
+		return nil, err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	records, err := es.State.GetNodeKeyHistory(address)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]interface{}, 0, len(records))
+	for _, r := range records {
+		keys = append(keys, r.ToJSON())
+	}
+	return map[string]interface{}{
+		"address": address,
+		"keys":    keys,
+	}, nil
+}
+
+func (s *chainScore) Ex_rotatePRepNodeKey(pubKey []byte, proof []byte) error {
+	if err := s.tryChargeCall(false); err != nil {
+		return err
+	}
+	if s.from.IsContract() {
+		return scoreresult.New(module.StatusAccessDenied, "NoPermission")
+	}
+	pk, err := crypto.ParsePublicKey(pubKey)
+	if err != nil {
+		return icmodule.IllegalArgumentError.Wrap(err, "Failed to parse public key")
+	}
+	mod := ntm.DSAModuleForName(iconDSA)
+	if err = mod.VerifyProofOfPossession(pubKey, nodeKeyPoPMessage(s.from, iconDSA, pubKey), proof); err != nil {
+		return icmodule.IllegalArgumentError.Wrap(err, "Invalid proof of possession")
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	prep := es.GetPRep(s.from)
+	if prep == nil {
+		return icmodule.IllegalArgumentError.New("address is not P-Rep")
+	}
+	node := common.NewAccountAddressFromPublicKey(pk)
+	if node.Equal(prep.NodeAddress()) {
+		return icmodule.IllegalArgumentError.Errorf("SameAsCurrentNode(%s)", node)
+	}
+	if !es.State.IsNodeAvailable(node, s.from) {
+		return icmodule.IllegalArgumentError.Errorf("NodeAlreadyInUse(%s)", node)
+	}
+
+	// register the public key of the new node in advance, so BTP blocks of
+	// the next term can be verified with it.
+	bs, err := s.getBTPState()
+	if err != nil {
+		return err
+	}
+	if err = bs.SetPublicKey(s.newBTPContext(), node, iconDSA, pubKey); err != nil {
+		return err
+	}
+	if err = es.State.SetNodeKeyRotation(&icstate.NodeKeyRotation{
+		Owner:       common.AddressToPtr(s.from),
+		Node:        node,
+		PubKey:      pubKey,
+		BlockHeight: s.cc.BlockHeight(),
+	}); err != nil {
+		return err
+	}
+	s.cc.OnEvent(state.SystemAddress,
+		[][]byte{
+			[]byte("PRepNodeKeyRotationScheduled(Address,Address)"),
+			s.from.Bytes(),
+		},
+		[][]byte{
+			node.Bytes(),
+		},
+	)
+	return nil
+}
+
+func (s *chainScore) Ex_getPRepNodeKeyRotation(address module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(false); err != nil {
+		return nil, err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	rotation, err := es.State.GetNodeKeyRotation(address)
+	if err != nil {
+		return nil, err
+	}
+	jso := map[string]interface{}{
+		"address": address,
+	}
+	if rotation != nil {
+		jso["rotation"] = rotation.ToJSON()
+	}
+	return jso, nil
+}
+
 func (s *chainScore) Ex_openBTPNetwork(networkTypeName string, name string, owner module.Address) (int64, error) {
 	if err := s.checkGovernance(true); err != nil {
 		return 0, err
@@ -24,6 +24,7 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/icon/icmodule"
 	"github.com/icon-project/goloop/icon/iiss"
 	"github.com/icon-project/goloop/icon/iiss/icstate"
@@ -142,6 +143,35 @@ func (s *chainScore) Ex_getStake(address module.Address) (map[string]interface{}
 	return ia.GetStakeInJSON(blockHeight), nil
 }
 
+// Ex_getAccountSnapshot returns stake, delegations, bonds and unstaking
+// schedule of the account with the term of the state. Queries at the start
+// height of a term return the state used for the term.
+func (s *chainScore) Ex_getAccountSnapshot(address module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, scoreresult.InvalidParameterError.New("InvalidAddress")
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	ia := es.State.GetAccountSnapshot(address)
+	if ia == nil {
+		ia = icstate.GetEmptyAccountSnapshot()
+	}
+	blockHeight := s.cc.BlockHeight()
+	jso := ia.ToJSON(blockHeight)
+	jso["address"] = address
+	jso["blockHeight"] = blockHeight
+	if term := es.State.GetTermSnapshot(); term != nil {
+		jso["termSequence"] = term.Sequence()
+		jso["termStartBlockHeight"] = term.StartHeight()
+	}
+	return jso, nil
+}
+
 func (s *chainScore) Ex_setDelegation(param []interface{}) error {
 	if err := s.tryChargeCall(true); err != nil {
 		return err
@@ -365,7 +395,7 @@ func (s *chainScore) Ex_setBond(bondList []interface{}) error {
 	if err != nil {
 		return err
 	}
-	if err = es.SetBond(s.cc.BlockHeight(), s.from, bonds); err != nil {
+	if err = es.SetBond(s.newCallContext(s.cc), bonds); err != nil {
 		return err
 	}
 	logger.Tracef("Ex_setBond() end")
@@ -463,6 +493,90 @@ func (s *chainScore) Ex_queryIScore(address module.Address) (map[string]interfac
 	return jso, nil
 }
 
+// Ex_getIScoreBreakdown returns rewards of recent terms for the address by
+// their types. They are recorded by the reward calculator of the node only
+// for queries, so it's not allowed in transactions.
+func (s *chainScore) Ex_getIScoreBreakdown(address module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	if s.cc.TransactionInfo() != nil {
+		return nil, scoreresult.AccessDeniedError.New("NotAllowedInTransaction")
+	}
+	if address == nil {
+		return nil, scoreresult.InvalidParameterError.New("InvalidAddress")
+	}
+	rbs, err := iiss.LoadRewardBreakdowns(s.cc.Database(), address)
+	if err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(err, "Failed to load reward breakdown")
+	}
+	terms := make([]interface{}, 0, len(rbs))
+	for i := len(rbs) - 1; i >= 0; i-- {
+		terms = append(terms, rbs[i].ToJSON())
+	}
+	return map[string]interface{}{
+		"address": address,
+		"terms":   terms,
+	}, nil
+}
+
+// Ex_getRewardLedger returns rewards of all addresses for the term starting
+// at startBlockHeight, or the latest term if it's omitted. Like breakdowns,
+// the ledger is recorded only by the node.
+func (s *chainScore) Ex_getRewardLedger(startBlockHeight, offset *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	if s.cc.TransactionInfo() != nil {
+		return nil, scoreresult.AccessDeniedError.New("NotAllowedInTransaction")
+	}
+	terms, err := iiss.LoadRewardLedgerTerms(s.cc.Database())
+	if err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(err, "Failed to load reward ledger")
+	}
+	var height int64
+	if startBlockHeight != nil {
+		height = startBlockHeight.Int64()
+	} else if len(terms) > 0 {
+		height = terms[len(terms)-1]
+	} else {
+		return nil, scoreresult.InvalidParameterError.New("NoRewardLedger")
+	}
+	var start int
+	if offset != nil {
+		start = int(offset.Int64())
+	}
+	if start < 0 {
+		return nil, scoreresult.InvalidParameterError.Errorf("InvalidOffset(offset=%d)", start)
+	}
+	entries, err := iiss.LoadRewardLedger(s.cc.Database(), height)
+	if err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(err, "Failed to load reward ledger")
+	}
+	if entries == nil {
+		return nil, scoreresult.InvalidParameterError.Errorf("NoRewardLedger(height=%d)", height)
+	}
+	end := start + iiss.MaxRewardLedgerPage
+	if end > len(entries) {
+		end = len(entries)
+	}
+	list := make([]interface{}, 0, iiss.MaxRewardLedgerPage)
+	for i := start; i < end; i++ {
+		list = append(list, entries[i].ToJSON())
+	}
+	jsoTerms := make([]interface{}, 0, len(terms))
+	for i := len(terms) - 1; i >= 0; i-- {
+		jsoTerms = append(jsoTerms, terms[i])
+	}
+	return map[string]interface{}{
+		"startBlockHeight": height,
+		"offset":           int64(start),
+		"total":            int64(len(entries)),
+		"entries":          list,
+		"terms":            jsoTerms,
+	}, nil
+}
+
 func (s *chainScore) Ex_estimateUnstakeLockPeriod() (map[string]interface{}, error) {
 	if err := s.tryChargeCall(true); err != nil {
 		return nil, err
@@ -665,6 +779,39 @@ func (s *chainScore) Ex_setRewardFundAllocation(iprep *common.HexInt, icps *comm
 	return es.State.SetRewardFund(rf)
 }
 
+func (s *chainScore) Ex_setIssuePolicy(policy string, amount *common.HexInt, cap *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	p := icstate.NewIssuePolicyParams()
+	p.Type = policy
+	if amount != nil {
+		p.Amount = amount.Value()
+	}
+	if cap != nil {
+		p.Cap = cap.Value()
+	}
+	if err = es.State.SetIssuePolicy(p); err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidIssuePolicy")
+	}
+	return nil
+}
+
+func (s *chainScore) Ex_getIssuePolicy() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	return es.State.GetIssuePolicy().ToJSON(), nil
+}
+
 func (s *chainScore) Ex_getScoreOwner(score module.Address) (module.Address, error) {
 	if err := s.tryChargeCall(true); err != nil {
 		return nil, err
@@ -805,6 +952,290 @@ func (s *chainScore) Ex_setNonVoteSlashingRate(slashingRate *common.HexInt) erro
 	return nil
 }
 
+func (s *chainScore) Ex_setDoubleSignSlashingRate(slashingRate *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	if !slashingRate.IsInt64() {
+		return icmodule.IllegalArgumentError.Errorf("Invalid range")
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	if err = es.State.SetDoubleSignPenaltySlashRatio(int(slashingRate.Int64())); err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return icmodule.IllegalArgumentError.Errorf("Invalid range")
+		}
+		return err
+	}
+	s.onSlashingRateChangedEvent("DoubleSignPenalty", slashingRate.Int64())
+	return nil
+}
+
+// Ex_reportDoubleSign imposes the double sign penalty on the P-Rep whose
+// node signed the conflicting votes. Vote messages are not signed with the
+// network ID, so one of the votes must be for the block of this chain at the
+// height.
+func (s *chainScore) Ex_reportDoubleSign(vote1 []byte, vote2 []byte) error {
+	if err := s.tryChargeCall(true); err != nil {
+		return err
+	}
+	ds, err := consensus.VerifyDoubleSign(vote1, vote2)
+	if err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidEvidence")
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	return es.HandleDoubleSign(s.newCallContext(s.cc), ds.Signer, ds.Height, ds.BlockIDs)
+}
+
+func (s *chainScore) Ex_getPenaltyHistory(address module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	records, err := es.State.GetPenaltyHistory(address)
+	if err != nil {
+		return nil, err
+	}
+	penalties := make([]interface{}, 0, len(records))
+	for _, r := range records {
+		penalties = append(penalties, r.ToJSON())
+	}
+	return map[string]interface{}{
+		"address":   address,
+		"penalties": penalties,
+	}, nil
+}
+
+func (s *chainScore) Ex_requestUnjail() error {
+	if err := s.tryChargeCall(true); err != nil {
+		return err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	return es.RequestUnjail(s.newCallContext(s.cc), s.value)
+}
+
+func (s *chainScore) Ex_setJailConfig(unjailPeriod *common.HexInt, unjailFee *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	if !unjailPeriod.IsInt64() {
+		return icmodule.IllegalArgumentError.Errorf("Invalid range")
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	if err = es.State.SetUnjailPeriod(unjailPeriod.Int64()); err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidUnjailPeriod")
+	}
+	if err = es.State.SetUnjailFee(unjailFee.Value()); err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidUnjailFee")
+	}
+	return nil
+}
+
+func (s *chainScore) Ex_getJailConfig() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"unjailPeriod": es.State.GetUnjailPeriod(),
+		"unjailFee":    es.State.GetUnjailFee(),
+	}, nil
+}
+
+func (s *chainScore) Ex_getExpiringEntries(startBlockHeight, endBlockHeight *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	start := startBlockHeight.Int64()
+	end := endBlockHeight.Int64()
+	unstakes, err := es.State.GetExpiringUnstakes(start, end)
+	if err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrapf(
+			err, "Failed to get expiring unstakes: start=%d end=%d", start, end,
+		)
+	}
+	unbonds, err := es.State.GetExpiringUnbonds(start, end)
+	if err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrapf(
+			err, "Failed to get expiring unbonds: start=%d end=%d", start, end,
+		)
+	}
+	unstakeList := make([]interface{}, 0, len(unstakes))
+	for _, u := range unstakes {
+		unstakeList = append(unstakeList, u.ToJSON())
+	}
+	unbondList := make([]interface{}, 0, len(unbonds))
+	for _, u := range unbonds {
+		unbondList = append(unbondList, u.ToJSON())
+	}
+	return map[string]interface{}{
+		"startBlockHeight": start,
+		"endBlockHeight":   end,
+		"unstakes":         unstakeList,
+		"unbonds":          unbondList,
+	}, nil
+}
+
+func (s *chainScore) Ex_setCommissionRate(rate *common.HexInt) error {
+	if err := s.tryChargeCall(true); err != nil {
+		return err
+	}
+	if !rate.IsInt64() {
+		return icmodule.IllegalArgumentError.Errorf("Invalid range")
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	return es.SetCommissionRate(s.newCallContext(s.cc), rate.Int64())
+}
+
+func (s *chainScore) Ex_setCommissionConfig(changeDelay *common.HexInt, maxChange *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	if !changeDelay.IsInt64() || !maxChange.IsInt64() {
+		return icmodule.IllegalArgumentError.Errorf("Invalid range")
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	if err = es.State.SetCommissionChangeDelay(changeDelay.Int64()); err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidCommissionChangeDelay")
+	}
+	if err = es.State.SetMaxCommissionChange(maxChange.Int64()); err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidMaxCommissionChange")
+	}
+	return nil
+}
+
+func (s *chainScore) Ex_getCommissionConfig() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"changeDelay": es.State.GetCommissionChangeDelay(),
+		"maxChange":   es.State.GetMaxCommissionChange(),
+	}, nil
+}
+
+func (s *chainScore) Ex_getTreasuryAccount(termSequence *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	seq := int64(-1)
+	if termSequence != nil {
+		if !termSequence.IsInt64() || termSequence.Sign() < 0 {
+			return nil, icmodule.IllegalArgumentError.Errorf("Invalid range")
+		}
+		seq = termSequence.Int64()
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetTreasuryAccountInJSON(s.newCallContext(s.cc), seq)
+}
+
+func (s *chainScore) Ex_registerProposal(pType *common.HexInt, value string, applyHeight *common.HexInt) error {
+	if err := s.tryChargeCall(true); err != nil {
+		return err
+	}
+	if !pType.IsInt64() || !applyHeight.IsInt64() {
+		return icmodule.IllegalArgumentError.Errorf("Invalid range")
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	_, err = es.RegisterProposal(
+		s.newCallContext(s.cc), icstate.ProposalType(pType.Int64()), []byte(value), applyHeight.Int64())
+	return err
+}
+
+func (s *chainScore) Ex_voteProposal(id []byte, vote bool) error {
+	if err := s.tryChargeCall(true); err != nil {
+		return err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	return es.VoteProposal(s.newCallContext(s.cc), id, vote)
+}
+
+func (s *chainScore) Ex_cancelProposal(id []byte) error {
+	if err := s.tryChargeCall(true); err != nil {
+		return err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return err
+	}
+	return es.CancelProposal(s.newCallContext(s.cc), id)
+}
+
+func (s *chainScore) Ex_getProposal(id []byte) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetProposalInJSON(id, s.cc.BlockHeight())
+}
+
+func (s *chainScore) Ex_getProposals(status *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(true); err != nil {
+		return nil, err
+	}
+	st := -1
+	if status != nil {
+		if !status.IsInt64() || status.Sign() < 0 {
+			return nil, icmodule.IllegalArgumentError.Errorf("Invalid range")
+		}
+		st = int(status.Int64())
+	}
+	es, err := s.getExtensionState()
+	if err != nil {
+		return nil, err
+	}
+	proposals, err := es.GetProposalsInJSON(st, s.cc.BlockHeight())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"proposals": proposals,
+	}, nil
+}
+
 func (s *chainScore) onSlashingRateChangedEvent(name string, rate int64) {
 	s.cc.OnEvent(state.SystemAddress,
 		[][]byte{[]byte("SlashingRateChanged(str,int)"), []byte(name)},
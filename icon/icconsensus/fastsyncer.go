@@ -88,6 +88,7 @@ func (f *fastSyncer) Start() error {
 		f.c.NetworkManager(),
 		f.c.BlockManager(),
 		f,
+		f.c.SyncServerThrottle(),
 		f.c.Logger(),
 	)
 	if err != nil {
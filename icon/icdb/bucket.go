@@ -26,4 +26,17 @@ const (
 	// BlockMerkle basically maps node hash to block merkle node for v1 block.
 	// In addition, it also has merkleTreeData.
 	BlockMerkle db.BucketID = "H"
+
+	// RewardBreakdown maps address to the breakdown of I-Score rewards of
+	// recent terms. It's written by the reward calculator only for the
+	// local node, so it's not synchronized with other nodes.
+	RewardBreakdown db.BucketID = "R"
+
+	// RewardLedger maps start height of a term to the reward breakdowns of
+	// all addresses rewarded by the term. It's also local to the node.
+	RewardLedger db.BucketID = "G"
+
+	// CalculatorCheckpoint keeps intermediate state of the reward calculator
+	// to resume calculation after restart. It's also local to the node.
+	CalculatorCheckpoint db.BucketID = "K"
 )
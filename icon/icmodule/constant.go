@@ -74,6 +74,10 @@ const (
 	DefaultDelegationSlotMax                     = 100
 	DefaultExtraMainPRepCount                    = 3
 	DefaultNonVotePenaltySlashRatio              = 0  // 0%
+	DefaultUnjailPeriod                          = DecentralizedTermPeriod
+	DefaultCommissionChangeDelay                 = 1
+	DefaultMaxCommissionChange                   = 100
+	MaxCommissionRate                            = 10000
 )
 
 // The following variables are read-only
@@ -84,5 +88,6 @@ var (
 	BigIntMinIRep        = new(big.Int).Mul(big.NewInt(MinIRep), BigIntICX)
 	BigIntIScoreICXRatio = big.NewInt(IScoreICXRatio)
 	BigIntRegPRepFee     = new(big.Int).Mul(big.NewInt(2000), BigIntICX)
+	BigIntUnjailFee      = new(big.Int).Mul(big.NewInt(100), BigIntICX)
 	BigIntDayBlocks      = big.NewInt(DayBlock)
 )
@@ -35,6 +35,7 @@ type CallContext interface {
 	SumOfStepUsed() *big.Int
 	OnEvent(addr module.Address, indexed, data [][]byte)
 	CallOnTimer(to module.Address, params []byte) error
+	CallSystemByGovernance(method string, params interface{}) error
 	Governance() module.Address
 	FrameLogger() *trace.Logger
 	TransactionInfo() *state.TransactionInfo
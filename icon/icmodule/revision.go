@@ -105,6 +105,27 @@ const (
 	// RevisionJavaFixMapValues = Revision20
 
 	RevisionBTP2 = Revision21
+
+	// Features below are enabled with RevisionBTP2 until they're released.
+	// Each has its own constant to move it to another revision separately.
+	// Platform features of RevisionBTP2 (module.MultipleFeePayers,
+	// module.VRFProposerSelection and module.RevertReasonInReceipt) are
+	// listed in revisionFlags.
+	RevisionIScoreBreakdown = Revision21
+	RevisionRewardLedger    = Revision21
+	RevisionAccountSnapshot = Revision21
+	RevisionIssuePolicy     = Revision21
+	RevisionValidateIssue   = Revision21
+	RevisionDoubleSign      = Revision21
+	RevisionPenaltyHistory  = Revision21
+	RevisionJail            = Revision21
+	RevisionStakingEvents   = Revision21
+	RevisionExpiringEntries = Revision21
+	RevisionCommission      = Revision21
+	RevisionTreasury        = Revision21
+	RevisionNetworkProposal = Revision21
+	RevisionPRepNodeKey     = Revision21
+	RevisionNodeKeyRotation = Revision21
 )
 
 var revisionFlags = []module.Revision{
@@ -151,7 +172,7 @@ var revisionFlags = []module.Revision{
 	// Revision20
 	module.FixMapValues,
 	// Revision21
-	module.MultipleFeePayers,
+	module.MultipleFeePayers | module.VRFProposerSelection | module.RevertReasonInReceipt,
 }
 
 func init() {
@@ -24,6 +24,8 @@ import (
 	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/scoredb"
 	"github.com/icon-project/goloop/service/scoreresult"
@@ -240,6 +242,11 @@ func (ctx *callContext) HandleBurn(from module.Address, amount *big.Int) error {
 		if err != nil {
 			return err
 		}
+		if es, ok := ctx.GetExtensionState().(*iiss.ExtensionStateImpl); ok {
+			if err = es.AddTreasuryEntry(ctx, icstate.TreasuryBurned, amount); err != nil {
+				return err
+			}
+		}
 		ctx.onICXBurnedEvent(from, amount, ts)
 	}
 	return nil
@@ -277,6 +284,10 @@ func (ctx *callContext) CallOnTimer(to module.Address, params []byte) error {
 	return nil
 }
 
+func (ctx *callContext) CallSystemByGovernance(method string, params interface{}) error {
+	return nil
+}
+
 func (ctx *callContext) Governance() module.Address {
 	return ctx.Governance()
 }
@@ -282,6 +282,9 @@ func (es *ExtensionStateImpl) handleICXIssue(cc icmodule.CallContext, data []byt
 	if _, err = cc.AddTotalSupply(issueAmount); err != nil {
 		return err
 	}
+	if err = es.AddTreasuryEntry(cc, icstate.TreasuryIssued, issueAmount); err != nil {
+		return err
+	}
 
 	// write Issue Info
 	issue, err := es.State.GetIssue()
@@ -289,7 +292,7 @@ func (es *ExtensionStateImpl) handleICXIssue(cc icmodule.CallContext, data []byt
 		return scoreresult.InvalidContainerAccessError.Wrap(err, "Failed to get issue Info.")
 	}
 	issue = issue.Update(result.GetTotalReward(), result.GetByFee(), result.GetByOverIssuedICX())
-	if err = es.State.SetIssue(issue); err != nil {
+	if err = es.setIssue(cc.Revision().Value(), issue); err != nil {
 		return scoreresult.InvalidContainerAccessError.Wrap(err, "Failed to set issue Info.")
 	}
 
@@ -484,5 +487,10 @@ func (es *ExtensionStateImpl) OnBaseTx(cc icmodule.CallContext, data []byte) err
 			return err
 		}
 	}
+	if isProposalEnabled(cc) {
+		if err := es.handleProposals(cc); err != nil {
+			return err
+		}
+	}
 	return nil
 }
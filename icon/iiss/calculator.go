@@ -46,6 +46,8 @@ const (
 	TypeBlockProduce RewardType = iota
 	TypeVoted
 	TypeVoting
+	TypeDelegating
+	TypeBonding
 )
 
 const (
@@ -75,11 +77,14 @@ type Calculator struct {
 	global      icstage.Global
 	temp        *icreward.State
 	stats       *statistics
+	breakdowns  map[string]*RewardBreakdown
 
 	lock    sync.Mutex
 	waiters []*sync.Cond
 	err     error
 	result  *icreward.Snapshot
+	phase   CalculationPhase
+	resumed CalculationPhase
 }
 
 func (c *Calculator) Result() *icreward.Snapshot {
@@ -162,6 +167,36 @@ func (c *Calculator) Stop() {
 	}
 }
 
+func (c *Calculator) isInterrupted() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.err != nil
+}
+
+func (c *Calculator) setPhase(phase CalculationPhase) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.phase = phase
+}
+
+// Progress returns the progress of the calculation for inspection.
+func (c *Calculator) Progress() map[string]interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	jso := map[string]interface{}{
+		"startHeight": c.startHeight,
+		"phase":       c.phase.String(),
+	}
+	if c.resumed != PhasePrepare {
+		jso["resumedFrom"] = c.resumed.String()
+	}
+	if c.err != nil {
+		jso["error"] = c.err.Error()
+	}
+	return jso
+}
+
 func UpdateCalculator(c *Calculator, ess state.ExtensionSnapshot, logger log.Logger) *Calculator {
 	essi := ess.(*ExtensionSnapshotImpl)
 	back := essi.Back2()
@@ -184,44 +219,51 @@ func (c *Calculator) run() (err error) {
 		}
 	}()
 
-	startTS := time.Now()
-	if err = c.prepare(); err != nil {
-		err = icmodule.CalculationFailedError.Wrapf(err, "Failed to prepare calculator")
-		return
-	}
-	prepareTS := time.Now()
-
-	if err = c.calculateBlockProduce(); err != nil {
-		err = icmodule.CalculationFailedError.Wrapf(err, "Failed to calculate block produce reward")
-		return
-	}
-	bpTS := time.Now()
-
-	if err = c.calculateVotedReward(); err != nil {
-		err = icmodule.CalculationFailedError.Wrapf(err, "Failed to calculate P-Rep voted reward")
-		return
-	}
-	votedTS := time.Now()
-
-	if err = c.calculateVotingReward(); err != nil {
-		err = icmodule.CalculationFailedError.Wrapf(err, "Failed to calculate ICONist voting reward")
-		return
+	steps := []struct {
+		phase CalculationPhase
+		run   func() error
+		msg   string
+	}{
+		{PhasePrepare, c.prepare, "Failed to prepare calculator"},
+		{PhaseBlockProduce, c.calculateBlockProduce, "Failed to calculate block produce reward"},
+		{PhaseVoted, c.calculateVotedReward, "Failed to calculate P-Rep voted reward"},
+		{PhaseVoting, c.calculateVotingReward, "Failed to calculate ICONist voting reward"},
+		{PhasePostWork, c.postWork, "Failed to do post work of calculator"},
 	}
-	votingTS := time.Now()
+	elapsed := make([]time.Duration, len(steps))
 
-	if err = c.postWork(); err != nil {
-		err = icmodule.CalculationFailedError.Wrapf(err, "Failed to do post work of calculator")
-		return
+	startTS := time.Now()
+	resumed := c.restoreCheckpoint()
+	c.lock.Lock()
+	c.resumed = resumed
+	c.lock.Unlock()
+	for _, step := range steps[resumed:] {
+		if c.isInterrupted() {
+			return errors.ErrInterrupted
+		}
+		c.setPhase(step.phase)
+		ts := time.Now()
+		if err = step.run(); err != nil {
+			err = icmodule.CalculationFailedError.Wrap(err, step.msg)
+			return
+		}
+		elapsed[step.phase] = time.Since(ts)
+		// postWork is quick, so it's not necessary to keep the result of it
+		if step.phase < PhasePostWork {
+			c.saveCheckpoint(step.phase + 1)
+		}
 	}
-	finalTS := time.Now()
+	c.setPhase(PhaseDone)
 
-	c.log.Infof("Calculation time: total=%s prepare=%s blockProduce=%s voted=%s voting=%s postwork=%s",
-		finalTS.Sub(startTS), prepareTS.Sub(startTS), bpTS.Sub(prepareTS),
-		votedTS.Sub(bpTS), votingTS.Sub(votedTS), finalTS.Sub(votingTS),
+	c.log.Infof("Calculation time: total=%s prepare=%s blockProduce=%s voted=%s voting=%s postwork=%s resumedFrom=%s",
+		time.Since(startTS), elapsed[PhasePrepare], elapsed[PhaseBlockProduce],
+		elapsed[PhaseVoted], elapsed[PhaseVoting], elapsed[PhasePostWork], resumed,
 	)
 	c.log.Infof("Calculation statistics: Total=%d BlockProduce=%s Voted=%s Voting=%s",
 		c.stats.TotalReward(), c.stats.BlockProduce(), c.stats.Voted(), c.stats.Voting())
 
+	c.storeBreakdowns()
+	c.clearCheckpoint()
 	c.setResult(c.temp.GetSnapshot(), nil)
 	return nil
 }
@@ -323,12 +365,75 @@ func (c *Calculator) updateIScore(addr module.Address, reward *big.Int, t Reward
 		c.stats.IncreaseBlockProduce(reward)
 	case TypeVoted:
 		c.stats.IncreaseVoted(reward)
-	case TypeVoting:
+	case TypeVoting, TypeDelegating, TypeBonding:
 		c.stats.IncreaseVoting(reward)
 	}
+	c.addBreakdown(addr, reward, t)
 	return nil
 }
 
+func (c *Calculator) addBreakdown(addr module.Address, reward *big.Int, t RewardType) {
+	if reward.Sign() == 0 {
+		return
+	}
+	if c.breakdowns == nil {
+		c.breakdowns = make(map[string]*RewardBreakdown)
+	}
+	key := icutils.ToKey(addr)
+	rb, ok := c.breakdowns[key]
+	if !ok {
+		rb = newRewardBreakdown(c.startHeight)
+		c.breakdowns[key] = rb
+	}
+	rb.add(reward, t)
+}
+
+// ledgerEntries returns reward breakdowns of the calculation ordered by
+// address.
+func (c *Calculator) ledgerEntries() ([]*RewardLedgerEntry, error) {
+	keys := make([]string, 0, len(c.breakdowns))
+	for key := range c.breakdowns {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]*RewardLedgerEntry, 0, len(keys))
+	for _, key := range keys {
+		addr, err := common.NewAddress([]byte(key))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &RewardLedgerEntry{addr, c.breakdowns[key]})
+	}
+	return entries, nil
+}
+
+// storeBreakdowns writes reward breakdowns and the ledger of the term to
+// the local database. They are only for queries, so failure doesn't fail
+// the calculation.
+func (c *Calculator) storeBreakdowns() {
+	entries, err := c.ledgerEntries()
+	if err != nil {
+		c.log.Warnf("Failed to make reward ledger err=%+v", err)
+		return
+	}
+	for _, e := range entries {
+		if err = StoreRewardBreakdown(c.database, e.Address, e.Breakdown); err != nil {
+			c.log.Warnf("Failed to store reward breakdown addr=%s err=%+v", e.Address, err)
+			return
+		}
+	}
+	if err = StoreRewardLedger(c.database, c.startHeight, entries); err != nil {
+		c.log.Warnf("Failed to store reward ledger err=%+v", err)
+	}
+}
+
+func votingRewardType(_type int) RewardType {
+	if _type == icreward.TypeDelegating {
+		return TypeDelegating
+	}
+	return TypeBonding
+}
+
 // varForBlockProduceReward return variable for block produce reward
 // return (((irep * MonthPerYear) / (YearBlock * 2)) * mainPRepCount * IScoreICXRatio) / 2
 func varForBlockProduceReward(irep *big.Int, mainPRepCount int) *big.Int {
@@ -876,7 +981,7 @@ func (c *Calculator) processVoting(
 			}
 			reward = c.votingReward(multiplier, divider, from, to, prepInfo, voting.Iterator())
 		}
-		if err = c.updateIScore(addr, reward, TypeVoting); err != nil {
+		if err = c.updateIScore(addr, reward, votingRewardType(_type)); err != nil {
 			return err
 		}
 	}
@@ -998,7 +1103,7 @@ func (c *Calculator) processVotingEvent(
 		if err = c.writeVoting(addr, voting); err != nil {
 			return nil
 		}
-		if err = c.updateIScore(addr, reward, TypeVoting); err != nil {
+		if err = c.updateIScore(addr, reward, votingRewardType(_type)); err != nil {
 			return err
 		}
 	}
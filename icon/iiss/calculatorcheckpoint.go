@@ -0,0 +1,175 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/icdb"
+	"github.com/icon-project/goloop/icon/iiss/icreward"
+	"github.com/icon-project/goloop/icon/iiss/icutils"
+)
+
+// CalculationPhase is the step of the reward calculation.
+type CalculationPhase int
+
+const (
+	PhasePrepare CalculationPhase = iota
+	PhaseBlockProduce
+	PhaseVoted
+	PhaseVoting
+	PhasePostWork
+	PhaseDone
+)
+
+var calculationPhaseNames = []string{
+	"prepare", "blockProduce", "voted", "voting", "postWork", "done",
+}
+
+func (p CalculationPhase) String() string {
+	if p >= 0 && int(p) < len(calculationPhaseNames) {
+		return calculationPhaseNames[p]
+	}
+	return "unknown"
+}
+
+var calculatorCheckpointKey = []byte("checkpoint")
+
+// calculatorCheckpoint is the state of the calculator after the phases
+// before Phase are done. It's valid only for the same calculation, which
+// is identified by the start height, the hash of back and base.
+type calculatorCheckpoint struct {
+	StartHeight  int64
+	Back         []byte
+	Base         []byte
+	Phase        CalculationPhase
+	Temp         []byte
+	BlockProduce *big.Int
+	Voted        *big.Int
+	Voting       *big.Int
+	Breakdowns   []*RewardLedgerEntry
+}
+
+func (cp *calculatorCheckpoint) isFor(c *Calculator) bool {
+	return cp.StartHeight == c.startHeight &&
+		bytes.Equal(cp.Back, c.back.Bytes()) &&
+		bytes.Equal(cp.Base, c.base.Bytes()) &&
+		cp.Phase > PhasePrepare && cp.Phase < PhaseDone
+}
+
+func loadCalculatorCheckpoint(dbase db.Database) (*calculatorCheckpoint, error) {
+	bk, err := dbase.GetBucket(icdb.CalculatorCheckpoint)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := bk.Get(calculatorCheckpointKey)
+	if err != nil || len(bs) == 0 {
+		return nil, err
+	}
+	cp := new(calculatorCheckpoint)
+	if _, err := codec.BC.UnmarshalFromBytes(bs, cp); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidCalculatorCheckpoint")
+	}
+	return cp, nil
+}
+
+func storeCalculatorCheckpoint(dbase db.Database, cp *calculatorCheckpoint) error {
+	bk, err := dbase.GetBucket(icdb.CalculatorCheckpoint)
+	if err != nil {
+		return err
+	}
+	if cp == nil {
+		return bk.Delete(calculatorCheckpointKey)
+	}
+	bs, err := codec.BC.MarshalToBytes(cp)
+	if err != nil {
+		return err
+	}
+	return bk.Set(calculatorCheckpointKey, bs)
+}
+
+// saveCheckpoint writes the state of the calculator to resume from the phase.
+// Checkpoints are only for the local node, so failure is ignored.
+func (c *Calculator) saveCheckpoint(phase CalculationPhase) {
+	if c.isInterrupted() {
+		// a new calculator may be using the checkpoint
+		return
+	}
+	ss := c.temp.GetSnapshot()
+	if err := ss.Flush(); err != nil {
+		c.log.Warnf("Failed to flush calculator checkpoint err=%+v", err)
+		return
+	}
+	breakdowns, err := c.ledgerEntries()
+	if err != nil {
+		c.log.Warnf("Failed to make calculator checkpoint err=%+v", err)
+		return
+	}
+	cp := &calculatorCheckpoint{
+		StartHeight:  c.startHeight,
+		Back:         c.back.Bytes(),
+		Base:         c.base.Bytes(),
+		Phase:        phase,
+		Temp:         ss.Bytes(),
+		BlockProduce: c.stats.BlockProduce(),
+		Voted:        c.stats.Voted(),
+		Voting:       c.stats.Voting(),
+		Breakdowns:   breakdowns,
+	}
+	if err := storeCalculatorCheckpoint(c.database, cp); err != nil {
+		c.log.Warnf("Failed to store calculator checkpoint err=%+v", err)
+	}
+}
+
+// restoreCheckpoint restores the state of the calculator from the checkpoint
+// of the same calculation. It returns the phase to resume from.
+func (c *Calculator) restoreCheckpoint() CalculationPhase {
+	cp, err := loadCalculatorCheckpoint(c.database)
+	if err != nil {
+		c.log.Warnf("Failed to load calculator checkpoint err=%+v", err)
+		return PhasePrepare
+	}
+	if cp == nil || !cp.isFor(c) {
+		return PhasePrepare
+	}
+	c.temp = icreward.NewState(c.database, cp.Temp)
+	c.stats.blockProduce = cp.BlockProduce
+	c.stats.voted = cp.Voted
+	c.stats.voting = cp.Voting
+	c.breakdowns = nil
+	for _, e := range cp.Breakdowns {
+		if c.breakdowns == nil {
+			c.breakdowns = make(map[string]*RewardBreakdown)
+		}
+		c.breakdowns[icutils.ToKey(e.Address)] = e.Breakdown
+	}
+	c.log.Infof("Resume calculation %d from %s", c.startHeight, cp.Phase)
+	return cp.Phase
+}
+
+func (c *Calculator) clearCheckpoint() {
+	if c.isInterrupted() {
+		return
+	}
+	if err := storeCalculatorCheckpoint(c.database, nil); err != nil {
+		c.log.Warnf("Failed to clear calculator checkpoint err=%+v", err)
+	}
+}
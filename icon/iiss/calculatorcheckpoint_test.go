@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/icon/iiss/icstage"
+)
+
+func newCheckpointTestCalculator(database db.Database, startHeight int64) *Calculator {
+	c := MakeCalculator(database, icstage.NewSnapshot(database, nil))
+	c.database = database
+	c.startHeight = startHeight
+	c.stats = newStatistics()
+	return c
+}
+
+func TestCalculator_Checkpoint(t *testing.T) {
+	database := db.NewMapDB()
+	addr := common.MustNewAddressFromString("hx1")
+	reward := big.NewInt(100)
+
+	c := newCheckpointTestCalculator(database, 10)
+	assert.Equal(t, PhasePrepare, c.restoreCheckpoint())
+
+	assert.NoError(t, c.updateIScore(addr, reward, TypeVoted))
+	c.saveCheckpoint(PhaseVoting)
+
+	// other calculation can't use the checkpoint
+	c2 := newCheckpointTestCalculator(database, 20)
+	assert.Equal(t, PhasePrepare, c2.restoreCheckpoint())
+
+	c3 := newCheckpointTestCalculator(database, 10)
+	assert.Equal(t, PhaseVoting, c3.restoreCheckpoint())
+	iScore, err := c3.temp.GetIScore(addr)
+	assert.NoError(t, err)
+	assert.Zero(t, reward.Cmp(iScore.Value()))
+	assert.Zero(t, reward.Cmp(c3.stats.Voted()))
+	assert.Zero(t, reward.Cmp(c3.TotalReward()))
+	assert.Zero(t, reward.Cmp(c3.breakdowns[string(addr.Bytes())].Voted))
+
+	c3.clearCheckpoint()
+	c4 := newCheckpointTestCalculator(database, 10)
+	assert.Equal(t, PhasePrepare, c4.restoreCheckpoint())
+}
+
+func TestCalculator_Progress(t *testing.T) {
+	c := newCheckpointTestCalculator(db.NewMapDB(), 10)
+	c.setPhase(PhaseVoted)
+	c.resumed = PhaseBlockProduce
+	jso := c.Progress()
+	assert.Equal(t, int64(10), jso["startHeight"])
+	assert.Equal(t, "voted", jso["phase"])
+	assert.Equal(t, "blockProduce", jso["resumedFrom"])
+	assert.NotContains(t, jso, "error")
+
+	c.Stop()
+	assert.True(t, c.isInterrupted())
+	assert.Contains(t, c.Progress(), "error")
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/state"
+)
+
+const sigCommissionRateScheduled = "CommissionRateScheduled(Address,int,int)"
+
+// SetCommissionRate schedules the commission rate of the P-Rep.
+// The new rate becomes effective after the commission change delay in terms.
+func (es *ExtensionStateImpl) SetCommissionRate(cc icmodule.CallContext, rate int64) error {
+	owner := cc.From()
+	ps := es.State.GetPRepStatusByOwner(owner, false)
+	if ps == nil || !ps.IsActive() {
+		return scoreresult.InvalidParameterError.Errorf("NotActivePRep(owner=%s)", owner)
+	}
+	term := es.State.GetTermSnapshot()
+	if term == nil {
+		return scoreresult.InvalidRequestError.New("TermNotFound")
+	}
+	pb := es.State.GetPRepBaseByOwner(owner, false)
+	if pb == nil {
+		return scoreresult.InvalidParameterError.Errorf("PRepNotFound(owner=%s)", owner)
+	}
+	c, err := pb.SetCommissionRate(
+		rate,
+		int64(term.Sequence()),
+		es.State.GetCommissionChangeDelay(),
+		es.State.GetMaxCommissionChange(),
+	)
+	if err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidCommissionRate")
+	}
+	nextRate, effectiveTerm, _ := c.Pending(int64(term.Sequence()))
+
+	// Record CommissionRateScheduled eventlog with the term where it becomes effective
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte(sigCommissionRateScheduled), owner.Bytes()},
+		[][]byte{intconv.Int64ToBytes(nextRate), intconv.Int64ToBytes(effectiveTerm)},
+	)
+	return nil
+}
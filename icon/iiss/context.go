@@ -8,6 +8,7 @@ import (
 	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
 	"github.com/icon-project/goloop/icon/iiss/icutils"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/contract"
@@ -224,6 +225,11 @@ func (ctx *callContextImpl) HandleBurn(from module.Address, amount *big.Int) err
 		if err != nil {
 			return err
 		}
+		if es, ok := ctx.cc.GetExtensionState().(*ExtensionStateImpl); ok {
+			if err = es.AddTreasuryEntry(ctx, icstate.TreasuryBurned, amount); err != nil {
+				return err
+			}
+		}
 		ctx.onICXBurnedEvent(from, amount, ts)
 	}
 	return nil
@@ -280,6 +286,34 @@ func (ctx *callContextImpl) CallOnTimer(to module.Address, params []byte) error
 	return nil
 }
 
+// CallSystemByGovernance calls the method of the chain SCORE on behalf of the
+// governance SCORE. It's used to apply approved network proposals.
+func (ctx *callContextImpl) CallSystemByGovernance(method string, params interface{}) error {
+	cc := ctx.cc
+	cm := cc.ContractManager()
+	bs, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	jso := &contract.DataCallJSON{Method: method, Params: bs}
+	callData, _ := json.Marshal(jso)
+	sl := cc.GetStepLimit(state.StepLimitTypeInvoke)
+	ch, err := cm.GetHandler(
+		cc.Governance(),
+		state.SystemAddress,
+		new(big.Int),
+		contract.CTypeCall,
+		callData,
+	)
+	if err != nil {
+		return err
+	}
+	if err, _, _, _ = cc.Call(ch, sl); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (ctx *callContextImpl) Governance() module.Address {
 	return ctx.cc.Governance()
 }
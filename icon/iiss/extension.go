@@ -124,6 +124,55 @@ func (s *ExtensionSnapshotImpl) NewState(readonly bool) state.ExtensionState {
 	}
 }
 
+// GetElectedNodes returns the sequence of the current term and node addresses
+// of main and sub P-Reps elected for the term.
+func (s *ExtensionSnapshotImpl) GetElectedNodes() (int, []module.Address, []module.Address) {
+	if s == nil {
+		return -1, nil, nil
+	}
+	st := icstate.NewStateFromSnapshot(s.state, true, icutils.NewIconLogger(nil))
+	term := st.GetTermSnapshot()
+	if term == nil {
+		return -1, nil, nil
+	}
+	mainPRepCount := term.MainPRepCount()
+	electedPRepCount := term.GetElectedPRepCount()
+	if cnt := term.GetPRepSnapshotCount(); electedPRepCount > cnt {
+		electedPRepCount = cnt
+	}
+	var main, sub []module.Address
+	for i := 0; i < electedPRepCount; i++ {
+		node := st.GetNodeByOwner(term.GetPRepSnapshotByIndex(i).Owner())
+		if node == nil {
+			continue
+		}
+		if i < mainPRepCount {
+			main = append(main, node)
+		} else {
+			sub = append(sub, node)
+		}
+	}
+	return term.Sequence(), main, sub
+}
+
+// GetPowersOfNodes returns the powers of P-Reps for the nodes. The power of
+// an unknown node is zero.
+func (s *ExtensionSnapshotImpl) GetPowersOfNodes(nodes []module.Address) []*big.Int {
+	if s == nil {
+		return nil
+	}
+	st := icstate.NewStateFromSnapshot(s.state, true, icutils.NewIconLogger(nil))
+	br := st.GetBondRequirement()
+	powers := make([]*big.Int, len(nodes))
+	for i, node := range nodes {
+		powers[i] = new(big.Int)
+		if ps := st.GetPRepStatusByOwner(st.GetOwnerByNode(node), false); ps != nil {
+			powers[i] = ps.GetPower(br)
+		}
+	}
+	return powers
+}
+
 func NewExtensionSnapshot(database db.Database, hash []byte) state.ExtensionSnapshot {
 	if hash == nil {
 		return &ExtensionSnapshotImpl{
@@ -435,7 +484,9 @@ func (es *ExtensionStateImpl) SetDelegation(
 		es.AppendExtensionLog(dLog)
 	}
 
+	oldDs := account.Delegations()
 	account.SetDelegation(ds)
+	onDelegationChanged(cc, from, oldDs, ds)
 	if icmodule.RevisionMultipleUnstakes <= revision && revision < icmodule.RevisionFixInvalidUnstake {
 		migrate.ReproduceUnstakeBugForDelegation(cc, es.logger)
 	}
@@ -606,10 +657,16 @@ func (es *ExtensionStateImpl) PenalizeNonVoters(cc icmodule.CallContext, address
 		},
 	)
 
-	return es.slash(cc, address, es.State.GetNonVotePenaltySlashRatio())
+	slashed, err := es.slash(cc, address, es.State.GetNonVotePenaltySlashRatio())
+	if err != nil {
+		return err
+	}
+	return es.recordPenalty(cc, address, icmodule.PenaltyNonVote, 0, slashed)
 }
 
-func (es *ExtensionStateImpl) SetBond(blockHeight int64, from module.Address, bonds icstate.Bonds) error {
+func (es *ExtensionStateImpl) SetBond(cc icmodule.CallContext, bonds icstate.Bonds) error {
+	from := cc.From()
+	blockHeight := cc.BlockHeight()
 	es.logger.Tracef("SetBond() start: from=%s bonds=%+v", from, bonds)
 
 	var account *icstate.AccountState
@@ -652,6 +709,8 @@ func (es *ExtensionStateImpl) SetBond(blockHeight int64, from module.Address, bo
 		return scoreresult.UnknownFailureError.Wrapf(err, "Failed to update total bond")
 	}
 
+	oldBonds := account.Bonds()
+	oldUnbond := account.Unbond()
 	account.SetBonds(bonds)
 	unbondingHeight := es.State.GetUnbondingPeriodMultiplier()*es.State.GetTermPeriod() + blockHeight
 	tl, err := account.UpdateUnbonds(delta, unbondingHeight)
@@ -676,6 +735,8 @@ func (es *ExtensionStateImpl) SetBond(blockHeight int64, from module.Address, bo
 	if err = es.AddEventBond(blockHeight, from, delta); err != nil {
 		return scoreresult.UnknownFailureError.Wrapf(err, "Failed to add EventBond")
 	}
+	onBondChanged(cc, from, oldBonds, bonds)
+	onUnbondChanged(cc, from, oldUnbond, account.Unbond())
 
 	es.logger.Tracef("SetBond() end")
 	return nil
@@ -824,11 +885,32 @@ func (es *ExtensionStateImpl) OnExecutionBegin(wc icmodule.WorldContext) error {
 			return err
 		}
 	}
+	if wc.Revision().Value() >= icmodule.RevisionDoubleSign {
+		if err := es.recordBlockID(wc); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// recordBlockID records the ID of the previous block voted in the block to
+// verify double sign evidence.
+func (es *ExtensionStateImpl) recordBlockID(wc icmodule.WorldContext) error {
+	csi := wc.ConsensusInfo()
+	if csi == nil {
+		return nil
+	}
+	id := common.VotedBlockIDOf(csi)
+	if id == nil {
+		return nil
+	}
+	height := wc.BlockHeight() - 1
+	return es.State.AddBlockID(height, id, height-es.State.GetTermPeriod())
+}
+
 func (es *ExtensionStateImpl) OnExecutionEnd(wc icmodule.WorldContext, totalFee *big.Int, calculator *Calculator) error {
 	var err error
+	revision := wc.Revision().Value()
 	if err = es.handleTimerJob(wc); err != nil {
 		return err
 	}
@@ -836,9 +918,12 @@ func (es *ExtensionStateImpl) OnExecutionEnd(wc icmodule.WorldContext, totalFee
 	if term == nil {
 		return nil
 	}
+	if err = es.AddTreasuryEntry(wc, icstate.TreasuryFee, totalFee); err != nil {
+		return err
+	}
 
 	if term.IsDecentralized() {
-		if err = es.setIssuePrevBlockFee(totalFee); err != nil {
+		if err = es.setIssuePrevBlockFee(revision, totalFee); err != nil {
 			return err
 		}
 	}
@@ -851,7 +936,7 @@ func (es *ExtensionStateImpl) OnExecutionEnd(wc icmodule.WorldContext, totalFee
 		if err = es.checkCalculationDone(calculator); err != nil {
 			return err
 		}
-		if err = es.regulateIssue(calculator.TotalReward()); err != nil {
+		if err = es.regulateIssue(revision, calculator.TotalReward()); err != nil {
 			return err
 		}
 	case term.GetEndHeight():
@@ -862,12 +947,12 @@ func (es *ExtensionStateImpl) OnExecutionEnd(wc icmodule.WorldContext, totalFee
 
 		nTerm := es.State.GetTermSnapshot()
 		if term.IsDecentralized() {
-			if err = es.resetIssueTotalReward(); err != nil {
+			if err = es.resetIssueTotalReward(revision); err != nil {
 				return err
 			}
 		} else if nTerm.IsDecentralized() {
 			// last centralized block
-			if err = es.setIssuePrevBlockFee(totalFee); err != nil {
+			if err = es.setIssuePrevBlockFee(revision, totalFee); err != nil {
 				return err
 			}
 		}
@@ -905,7 +990,7 @@ func (es *ExtensionStateImpl) checkCalculationDone(calculator *Calculator) error
 	return nil
 }
 
-func (es *ExtensionStateImpl) regulateIssue(iScore *big.Int) error {
+func (es *ExtensionStateImpl) regulateIssue(revision int, iScore *big.Int) error {
 	// Update Issue with calculation result from 2nd Term of decentralization
 	term := es.State.GetTermSnapshot()
 	if !term.IsDecentralized() || term.Sequence() == 0 {
@@ -933,14 +1018,14 @@ func (es *ExtensionStateImpl) regulateIssue(iScore *big.Int) error {
 	}
 
 	is, err := es.State.GetIssue()
-	issue := is.Clone()
 	if err != nil {
 		return err
 	}
+	issue := is.Clone()
 
 	RegulateIssueInfo(issue, reward)
 
-	if err = es.State.SetIssue(issue); err != nil {
+	if err = es.setIssue(revision, issue); err != nil {
 		return err
 	}
 
@@ -960,6 +1045,13 @@ func (es *ExtensionStateImpl) onTermEnd(wc icmodule.WorldContext) error {
 	}
 	electedPRepCount := mainPRepCount + subPRepCount
 
+	if revision >= icmodule.RevisionNodeKeyRotation {
+		// node keys rotated in this term are used from the next term
+		if err = es.State.ApplyNodeKeyRotations(wc.BlockHeight(), wc.GetBTPContext()); err != nil {
+			return err
+		}
+	}
+
 	totalSupply := wc.GetTotalSupply()
 	isDecentralized := es.IsDecentralized()
 	prepSet := es.State.GetPRepSet(wc.GetBTPContext(), revision)
@@ -1013,6 +1105,7 @@ func (es *ExtensionStateImpl) moveOnToNextTerm(
 	}
 
 	es.setRrepToTerm(revision, totalSupply, nextTerm)
+	NewIssuePolicy(es.State.GetIssuePolicy()).ApplyToTerm(nextTerm, totalSupply)
 
 	term := es.State.GetTermSnapshot()
 	if !term.IsDecentralized() && nextTerm.IsDecentralized() {
@@ -1049,27 +1142,38 @@ func (es *ExtensionStateImpl) setRrepToTerm(revision int, totalSupply *big.Int,
 	term.SetRrep(rrep)
 }
 
-func (es *ExtensionStateImpl) resetIssueTotalReward() error {
+// setIssue stores the issue. Invariants of the issue are checked from
+// RevisionValidateIssue, so blocks executed before are not affected.
+func (es *ExtensionStateImpl) setIssue(revision int, issue *icstate.Issue) error {
+	if revision >= icmodule.RevisionValidateIssue {
+		if err := issue.Validate(); err != nil {
+			return err
+		}
+	}
+	return es.State.SetIssue(issue)
+}
+
+func (es *ExtensionStateImpl) resetIssueTotalReward(revision int) error {
 	is, err := es.State.GetIssue()
 	if err != nil {
 		return err
 	}
 	issue := is.Clone()
 	issue.ResetTotalReward()
-	if err = es.State.SetIssue(issue); err != nil {
+	if err = es.setIssue(revision, issue); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (es *ExtensionStateImpl) setIssuePrevBlockFee(fee *big.Int) error {
+func (es *ExtensionStateImpl) setIssuePrevBlockFee(revision int, fee *big.Int) error {
 	is, err := es.State.GetIssue()
 	if err != nil {
 		return err
 	}
 	issue := is.Clone()
 	issue.SetPrevBlockFee(fee)
-	if err = es.State.SetIssue(issue); err != nil {
+	if err = es.setIssue(revision, issue); err != nil {
 		return err
 	}
 	return nil
@@ -1146,14 +1250,39 @@ func (es *ExtensionStateImpl) updateValidators(wc icmodule.WorldContext, isTermE
 	}
 
 	blockHeight := wc.BlockHeight()
-	if isTermEnd || vss.IsUpdated(blockHeight) {
+	updated := isTermEnd || vss.IsUpdated(blockHeight)
+	if updated {
 		newValidators := vss.NewValidatorSet()
-		err = wc.SetValidators(newValidators)
+		if err = wc.SetValidators(newValidators); err != nil {
+			return err
+		}
 		es.logger.Debugf("New validators: bh=%d vss=%+v", blockHeight, vss)
 	}
+	if wc.Revision().Value() >= icmodule.RevisionDoubleSign {
+		err = es.recordValidators(blockHeight, vss, updated)
+	}
 	return err
 }
 
+// recordValidators records validators to verify double sign evidence.
+// Validators set in the block are used from two blocks later, as the next
+// block has the result of the block.
+func (es *ExtensionStateImpl) recordValidators(
+	blockHeight int64, vss *icstate.ValidatorsSnapshot, updated bool,
+) error {
+	if !updated {
+		if recorded, err := es.State.HasValidatorsHistory(); err != nil || recorded {
+			return err
+		}
+	}
+	nodes := make([]module.Address, vss.Len())
+	for i := range nodes {
+		nodes[i] = vss.Get(i)
+	}
+	minHeight := blockHeight - es.State.GetTermPeriod()
+	return es.State.AddValidatorsRecord(blockHeight+2, nodes, minHeight)
+}
+
 func (es *ExtensionStateImpl) GetPRepTermInJSON(blockHeight int64) (map[string]interface{}, error) {
 	term := es.State.GetTermSnapshot()
 	if term == nil {
@@ -1202,7 +1331,8 @@ func (es *ExtensionStateImpl) SetStake(cc icmodule.CallContext, v *big.Int) (err
 	}
 
 	revision := cc.Revision().Value()
-	stakeInc := new(big.Int).Sub(v, ia.Stake())
+	oldStake := ia.Stake()
+	stakeInc := new(big.Int).Sub(v, oldStake)
 	// ICON1 update unstakes when stakeInc == 0
 	if stakeInc.Sign() == 0 && revision >= icmodule.RevisionStopICON1Support {
 		return nil
@@ -1276,6 +1406,7 @@ func (es *ExtensionStateImpl) SetStake(cc icmodule.CallContext, v *big.Int) (err
 			return err
 		}
 	}
+	onStakeChanged(cc, from, oldStake, v)
 	if icmodule.RevisionMultipleUnstakes <= revision && revision < icmodule.RevisionFixInvalidUnstake {
 		migrate.ReproduceUnstakeBugForStake(cc, es.logger)
 	}
@@ -7,7 +7,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
 	"github.com/icon-project/goloop/icon/iiss/icutils"
+	"github.com/icon-project/goloop/module"
 )
 
 func TestExtension_calculateRRep(t *testing.T) {
@@ -155,3 +160,45 @@ func TestExtension_validateRewardFund(t *testing.T) {
 		})
 	}
 }
+
+func TestExtensionSnapshot_GetPowersOfNodes(t *testing.T) {
+	es := NewExtensionSnapshot(db.NewMapDB(), nil).NewState(false).(*ExtensionStateImpl)
+	assert.NoError(t, es.State.SetBondRequirement(5))
+	n1 := common.MustNewAddressFromString("hx1")
+	n2 := common.MustNewAddressFromString("hx2")
+	n3 := common.MustNewAddressFromString("hx3")
+	for _, tt := range []struct {
+		node      module.Address
+		bonded    int64
+		delegated int64
+	}{
+		{n1, 10, 1000},
+		{n2, 1, 1000},
+	} {
+		ps := es.State.GetPRepStatusByOwner(tt.node, true)
+		assert.NoError(t, ps.Activate())
+		ps.SetBonded(big.NewInt(tt.bonded))
+		ps.SetDelegated(big.NewInt(tt.delegated))
+	}
+
+	ess := es.GetSnapshot().(*ExtensionSnapshotImpl)
+	powers := ess.GetPowersOfNodes([]module.Address{n1, n2, n3})
+	// powers are bonded delegations, and zero for unknown nodes
+	assert.Equal(t, []*big.Int{big.NewInt(200), big.NewInt(20), big.NewInt(0)}, powers)
+}
+
+func TestExtensionState_setIssue(t *testing.T) {
+	es := NewExtensionSnapshot(db.NewMapDB(), nil).NewState(false).(*ExtensionStateImpl)
+	issue := icstate.NewIssue()
+	issue.SetPrevBlockFee(big.NewInt(-1))
+
+	// invalid issue is stored as before for old blocks
+	assert.NoError(t, es.setIssue(icmodule.RevisionValidateIssue-1, issue))
+	stored, err := es.State.GetIssue()
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(-1), stored.PrevBlockFee())
+
+	assert.Error(t, es.setIssue(icmodule.RevisionValidateIssue, issue))
+	issue.SetPrevBlockFee(big.NewInt(10))
+	assert.NoError(t, es.setIssue(icmodule.RevisionValidateIssue, issue))
+}
@@ -127,6 +127,19 @@ func (a accountData) GetDelegationInJSON() map[string]interface{} {
 	return jso
 }
 
+// ToJSON returns stake, delegations and bonds of the account with
+// the unstaking schedule as of the block height.
+func (a *accountData) ToJSON(blockHeight int64) map[string]interface{} {
+	jso := a.GetStakeInJSON(blockHeight)
+	for k, v := range a.GetDelegationInJSON() {
+		jso[k] = v
+	}
+	for k, v := range a.GetBondInJSON() {
+		jso[k] = v
+	}
+	return jso
+}
+
 func (a *accountData) GetVotingPower() *big.Int {
 	return new(big.Int).Sub(a.stake, a.UsingStake())
 }
@@ -93,6 +93,21 @@ func TestAccount_SetStake(t *testing.T) {
 	assert.Equal(t, 0, s.Cmp(account.Stake()))
 }
 
+func TestAccount_ToJSON(t *testing.T) {
+	account := newAccountStateWithSnapshot(nil)
+	assert.NoError(t, account.SetStake(big.NewInt(100)))
+
+	jso := account.GetSnapshot().ToJSON(10)
+	for _, key := range []string{
+		"stake", "unstakes", "totalDelegated", "delegations",
+		"totalBonded", "bonds", "unbonds", "votingPower",
+	} {
+		assert.Contains(t, jso, key)
+	}
+	assert.Equal(t, 0, big.NewInt(100).Cmp(jso["stake"].(*big.Int)))
+	assert.Equal(t, 0, big.NewInt(100).Cmp(jso["votingPower"].(*big.Int)))
+}
+
 func TestAccount_UpdateUnbonds(t *testing.T) {
 	a := getTestAccount() // unbonds : [{address: hx5, value:10, bh: 20}, {hx6, 10, 30}]
 
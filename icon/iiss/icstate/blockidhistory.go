@@ -0,0 +1,54 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+var blockIDHistoryKey = containerdb.ToKey(
+	containerdb.HashBuilder,
+	scoredb.DictDBPrefix,
+	"block_id_history",
+)
+
+func (s *State) blockIDHistoryDB() *containerdb.DictDB {
+	return containerdb.NewDictDB(s.store, 1, blockIDHistoryKey)
+}
+
+// AddBlockID records the ID of the block at the height, and removes the one
+// at the height just before minHeight.
+func (s *State) AddBlockID(height int64, id []byte, minHeight int64) error {
+	db := s.blockIDHistoryDB()
+	if err := db.Set(height, id); err != nil {
+		return err
+	}
+	if minHeight > 0 {
+		return db.Delete(minHeight - 1)
+	}
+	return nil
+}
+
+// GetBlockID returns the ID of the block at the height. It returns nil if
+// the block is not recorded.
+func (s *State) GetBlockID(height int64) []byte {
+	if v := s.blockIDHistoryDB().Get(height); v != nil {
+		return v.Bytes()
+	}
+	return nil
+}
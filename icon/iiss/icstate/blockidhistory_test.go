@@ -0,0 +1,24 @@
+package icstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_BlockIDHistory(t *testing.T) {
+	s := newDummyState(false)
+	assert.Nil(t, s.GetBlockID(10))
+
+	assert.NoError(t, s.AddBlockID(10, []byte("block10"), 5))
+	assert.NoError(t, s.AddBlockID(11, []byte("block11"), 6))
+	s = flushAndNewState(s, false)
+	assert.Equal(t, []byte("block10"), s.GetBlockID(10))
+	assert.Equal(t, []byte("block11"), s.GetBlockID(11))
+	assert.Nil(t, s.GetBlockID(12))
+
+	// blocks lower than minHeight are removed
+	assert.NoError(t, s.AddBlockID(12, []byte("block12"), 11))
+	assert.Nil(t, s.GetBlockID(10))
+	assert.Equal(t, []byte("block11"), s.GetBlockID(11))
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"fmt"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/icmodule"
+)
+
+// Commission is the commission rate of a P-Rep in basis point.
+// A new rate is scheduled with the term sequence where it becomes effective,
+// so delegators can notice the change in advance.
+type Commission struct {
+	rate          int64
+	nextRate      int64
+	effectiveTerm int64
+}
+
+// Rate returns the commission rate which is effective in the term.
+func (c *Commission) Rate(term int64) int64 {
+	if c == nil {
+		return 0
+	}
+	if c.effectiveTerm > 0 && term >= c.effectiveTerm {
+		return c.nextRate
+	}
+	return c.rate
+}
+
+// Pending returns the scheduled rate and the term where it becomes effective.
+// It returns false if there is no change scheduled after the term.
+func (c *Commission) Pending(term int64) (int64, int64, bool) {
+	if c == nil || c.effectiveTerm == 0 || term >= c.effectiveTerm {
+		return 0, 0, false
+	}
+	return c.nextRate, c.effectiveTerm, true
+}
+
+// Schedule returns a new Commission whose rate changes to rate at
+// term+delay. The change from the rate effective in the term shouldn't exceed
+// maxChange. A scheduled change that isn't effective yet is replaced.
+func (c *Commission) Schedule(rate, term, delay, maxChange int64) (*Commission, error) {
+	if rate < 0 || rate > icmodule.MaxCommissionRate {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidCommissionRate(rate=%d)", rate)
+	}
+	if delay < 1 {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidCommissionChangeDelay(delay=%d)", delay)
+	}
+	current := c.Rate(term)
+	diff := rate - current
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > maxChange {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"TooLargeCommissionChange(current=%d,rate=%d,max=%d)", current, rate, maxChange)
+	}
+	return &Commission{
+		rate:          current,
+		nextRate:      rate,
+		effectiveTerm: term + delay,
+	}, nil
+}
+
+func (c *Commission) ToJSON(term int64) map[string]interface{} {
+	jso := map[string]interface{}{
+		"commissionRate": c.Rate(term),
+	}
+	if rate, effectiveTerm, ok := c.Pending(term); ok {
+		jso["nextCommissionRate"] = rate
+		jso["commissionEffectiveTerm"] = effectiveTerm
+	}
+	return jso
+}
+
+func (c *Commission) Equal(c2 *Commission) bool {
+	if c == c2 {
+		return true
+	}
+	if c == nil || c2 == nil {
+		return false
+	}
+	return c.rate == c2.rate &&
+		c.nextRate == c2.nextRate &&
+		c.effectiveTerm == c2.effectiveTerm
+}
+
+func (c *Commission) String() string {
+	return fmt.Sprintf("Commission{rate=%d nextRate=%d effectiveTerm=%d}",
+		c.rate, c.nextRate, c.effectiveTerm)
+}
+
+func (c *Commission) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(c.rate, c.nextRate, c.effectiveTerm)
+}
+
+func (c *Commission) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&c.rate, &c.nextRate, &c.effectiveTerm)
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icobject"
+)
+
+func TestCommission_Schedule(t *testing.T) {
+	var c *Commission
+	assert.Equal(t, int64(0), c.Rate(10))
+	_, _, ok := c.Pending(10)
+	assert.False(t, ok)
+
+	_, err := c.Schedule(-1, 10, 1, 100)
+	assert.Error(t, err)
+	_, err = c.Schedule(icmodule.MaxCommissionRate+1, 10, 1, icmodule.MaxCommissionRate+1)
+	assert.Error(t, err)
+	_, err = c.Schedule(101, 10, 1, 100)
+	assert.Error(t, err)
+	_, err = c.Schedule(100, 10, 0, 100)
+	assert.Error(t, err)
+
+	c, err = c.Schedule(100, 10, 2, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), c.Rate(11))
+	assert.Equal(t, int64(100), c.Rate(12))
+	rate, term, ok := c.Pending(11)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), rate)
+	assert.Equal(t, int64(12), term)
+	_, _, ok = c.Pending(12)
+	assert.False(t, ok)
+
+	// pending change is replaced and the limit is checked with the effective rate
+	_, err = c.Schedule(150, 11, 2, 100)
+	assert.Error(t, err)
+	c2, err := c.Schedule(50, 11, 2, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), c2.Rate(12))
+	assert.Equal(t, int64(50), c2.Rate(13))
+
+	// after the change is effective, the limit is checked with the new rate
+	c3, err := c.Schedule(200, 12, 1, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), c3.Rate(12))
+	assert.Equal(t, int64(200), c3.Rate(13))
+	jso := c3.ToJSON(12)
+	assert.Equal(t, int64(100), jso["commissionRate"])
+	assert.Equal(t, int64(200), jso["nextCommissionRate"])
+	assert.Equal(t, int64(13), jso["commissionEffectiveTerm"])
+	jso = c3.ToJSON(13)
+	assert.Equal(t, 1, len(jso))
+	assert.Equal(t, int64(200), jso["commissionRate"])
+}
+
+func TestPRepBase_Commission(t *testing.T) {
+	database := icobject.AttachObjectFactory(db.NewMapDB(), NewObjectImpl)
+	pb := newDummyPRepBase(0)
+	old := icobject.New(TypePRepBase, pb.GetSnapshot()).Bytes()
+
+	_, err := pb.SetCommissionRate(100, 10, 1, 100)
+	assert.NoError(t, err)
+	pbs1 := pb.GetSnapshot()
+	o1 := icobject.New(TypePRepBase, pbs1)
+	assert.NotEqual(t, old, o1.Bytes())
+
+	o2 := new(icobject.Object)
+	assert.NoError(t, o2.Reset(database, o1.Bytes()))
+	pbs2 := ToPRepBase(o2)
+	assert.True(t, pbs1.Equal(pbs2))
+	assert.Equal(t, int64(100), pbs2.Commission().Rate(11))
+
+	// PRepBase without commission is decoded as before
+	o3 := new(icobject.Object)
+	assert.NoError(t, o3.Reset(database, old))
+	assert.Nil(t, ToPRepBase(o3).Commission())
+	assert.Equal(t, old, o3.Bytes())
+}
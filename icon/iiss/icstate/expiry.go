@@ -0,0 +1,121 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/module"
+)
+
+// MaxExpiryQueryRange is the maximum number of blocks to look up expiring
+// unstakes and unbonds at once.
+const MaxExpiryQueryRange = icmodule.DayBlock
+
+// ExpiringUnstake is an unstake of Owner which expires at Unstake.Expire.
+type ExpiringUnstake struct {
+	Owner module.Address
+	*Unstake
+}
+
+func (e *ExpiringUnstake) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"address":            e.Owner,
+		"unstake":            e.Value,
+		"unstakeBlockHeight": e.Expire,
+	}
+}
+
+// ExpiringUnbond is an unbond of Owner which expires at Unbond.Expire().
+type ExpiringUnbond struct {
+	Owner module.Address
+	*Unbond
+}
+
+func (e *ExpiringUnbond) ToJSON() map[string]interface{} {
+	jso := e.Unbond.ToJSON()
+	jso["bonder"] = e.Owner
+	return jso
+}
+
+func checkExpiryQueryRange(start, end int64) error {
+	if start < 0 || start > end {
+		return errors.IllegalArgumentError.Errorf("InvalidRange(start=%d,end=%d)", start, end)
+	}
+	if end-start >= MaxExpiryQueryRange {
+		return errors.IllegalArgumentError.Errorf(
+			"TooLargeRange(start=%d,end=%d,max=%d)", start, end, MaxExpiryQueryRange)
+	}
+	return nil
+}
+
+// GetExpiringUnstakes returns the unstakes expiring from start to end height.
+// They're ordered by expiration height, then by the order of the timer.
+// Only timers of the heights are looked up, so it doesn't scan all accounts.
+func (s *State) GetExpiringUnstakes(start, end int64) ([]*ExpiringUnstake, error) {
+	if err := checkExpiryQueryRange(start, end); err != nil {
+		return nil, err
+	}
+	var entries []*ExpiringUnstake
+	for h := start; h <= end; h++ {
+		ts := s.GetUnstakingTimerSnapshot(h)
+		if ts == nil {
+			continue
+		}
+		for itr := ts.Iterator(); itr.Has(); itr.Next() {
+			owner, _ := itr.Get()
+			as := s.GetAccountSnapshot(owner)
+			if as == nil {
+				continue
+			}
+			for _, u := range as.UnStakes() {
+				if u.Expire == h {
+					entries = append(entries, &ExpiringUnstake{owner, u})
+				}
+			}
+		}
+	}
+	return entries, nil
+}
+
+// GetExpiringUnbonds returns the unbonds expiring from start to end height.
+// They're ordered by expiration height, then by the order of the timer.
+func (s *State) GetExpiringUnbonds(start, end int64) ([]*ExpiringUnbond, error) {
+	if err := checkExpiryQueryRange(start, end); err != nil {
+		return nil, err
+	}
+	var entries []*ExpiringUnbond
+	for h := start; h <= end; h++ {
+		ts := s.GetUnbondingTimerSnapshot(h)
+		if ts == nil {
+			continue
+		}
+		for itr := ts.Iterator(); itr.Has(); itr.Next() {
+			owner, _ := itr.Get()
+			as := s.GetAccountSnapshot(owner)
+			if as == nil {
+				continue
+			}
+			for _, u := range as.Unbonds() {
+				if u.Expire() == h {
+					entries = append(entries, &ExpiringUnbond{owner, u})
+				}
+			}
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icutils"
+)
+
+func TestState_GetExpiringUnstakes(t *testing.T) {
+	s := newDummyState(false)
+	revision := icmodule.RevisionMultipleUnstakes
+	owners := []*common.Address{
+		common.MustNewAddressFromString("hx1"),
+		common.MustNewAddressFromString("hx2"),
+	}
+	unstakes := []struct {
+		owner  int
+		value  int64
+		expire int64
+	}{
+		{0, 10, 100},
+		{1, 20, 100},
+		{0, 30, 105},
+		{1, 40, 200},
+	}
+	for _, u := range unstakes {
+		owner := owners[u.owner]
+		as := s.GetAccountState(owner)
+		tl, err := as.IncreaseUnstake(big.NewInt(u.value), u.expire, 10, revision)
+		assert.NoError(t, err)
+		for _, ti := range tl {
+			ScheduleTimerJob(s.GetUnstakingTimerState(ti.Height), ti, owner)
+		}
+	}
+
+	entries, err := s.GetExpiringUnstakes(100, 110)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(entries))
+	for i, e := range entries {
+		u := unstakes[i]
+		assert.True(t, owners[u.owner].Equal(e.Owner))
+		assert.Zero(t, big.NewInt(u.value).Cmp(e.Value))
+		assert.Equal(t, u.expire, e.Expire)
+	}
+
+	entries, err = s.GetExpiringUnstakes(101, 104)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = s.GetExpiringUnstakes(110, 100)
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+	_, err = s.GetExpiringUnstakes(0, MaxExpiryQueryRange)
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+}
+
+func TestState_GetExpiringUnbonds(t *testing.T) {
+	s := newDummyState(false)
+	bonder := common.MustNewAddressFromString("hx1")
+	p1 := common.MustNewAddressFromString("hx11")
+	p2 := common.MustNewAddressFromString("hx12")
+
+	as := s.GetAccountState(bonder)
+	for _, d := range []struct {
+		prep   *common.Address
+		value  int64
+		expire int64
+	}{
+		{p1, 10, 100},
+		{p2, 20, 150},
+	} {
+		delta := map[string]*big.Int{icutils.ToKey(d.prep): big.NewInt(-d.value)}
+		tl, err := as.UpdateUnbonds(delta, d.expire)
+		assert.NoError(t, err)
+		for _, ti := range tl {
+			ScheduleTimerJob(s.GetUnbondingTimerState(ti.Height), ti, bonder)
+		}
+	}
+
+	entries, err := s.GetExpiringUnbonds(100, 149)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+	assert.True(t, bonder.Equal(entries[0].Owner))
+	assert.True(t, p1.Equal(entries[0].Address()))
+	assert.Equal(t, int64(100), entries[0].Expire())
+
+	jso := entries[0].ToJSON()
+	assert.Equal(t, bonder, jso["bonder"])
+
+	entries, err = s.GetExpiringUnbonds(100, 150)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(entries))
+	assert.True(t, p2.Equal(entries[1].Address()))
+}
@@ -21,6 +21,7 @@ import (
 	"math/big"
 
 	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/icon/iiss/icobject"
 	"github.com/icon-project/goloop/icon/iiss/icutils"
 )
@@ -44,12 +45,11 @@ func newIssue(_ icobject.Tag) *Issue {
 }
 
 func NewIssue() *Issue {
-	bigIntZero := new(big.Int)
 	return &Issue{
-		totalReward:      bigIntZero,
-		prevTotalReward:  bigIntZero,
-		overIssuedIScore: bigIntZero,
-		prevBlockFee:     bigIntZero,
+		totalReward:      new(big.Int),
+		prevTotalReward:  new(big.Int),
+		overIssuedIScore: new(big.Int),
+		prevBlockFee:     new(big.Int),
 	}
 }
 
@@ -86,15 +86,47 @@ func (i *Issue) Equal(o icobject.Impl) bool {
 	}
 }
 
+// Clone returns a deep copy, so changing values of the copy doesn't affect
+// the original.
 func (i *Issue) Clone() *Issue {
 	return &Issue{
-		totalReward:      i.totalReward,
-		prevTotalReward:  i.prevTotalReward,
-		overIssuedIScore: i.overIssuedIScore,
-		prevBlockFee:     i.prevBlockFee,
+		totalReward:      cloneBigInt(i.totalReward),
+		prevTotalReward:  cloneBigInt(i.prevTotalReward),
+		overIssuedIScore: cloneBigInt(i.overIssuedIScore),
+		prevBlockFee:     cloneBigInt(i.prevBlockFee),
 	}
 }
 
+func cloneBigInt(v *big.Int) *big.Int {
+	if v == nil {
+		return nil
+	}
+	return new(big.Int).Set(v)
+}
+
+// Validate checks invariants of the issue. Rewards and the block fee can't
+// be negative. overIssuedIScore can be negative, which means that less than
+// the calculated reward was issued, and it's compensated in the next term.
+func (i *Issue) Validate() error {
+	if i.totalReward == nil || i.prevTotalReward == nil ||
+		i.overIssuedIScore == nil || i.prevBlockFee == nil {
+		return errors.InvalidStateError.Errorf("InvalidIssue(%+v)", i)
+	}
+	if i.totalReward.Sign() < 0 {
+		return errors.InvalidStateError.Errorf(
+			"NegativeTotalReward(value=%s)", i.totalReward)
+	}
+	if i.prevTotalReward.Sign() < 0 {
+		return errors.InvalidStateError.Errorf(
+			"NegativePrevTotalReward(value=%s)", i.prevTotalReward)
+	}
+	if i.prevBlockFee.Sign() < 0 {
+		return errors.InvalidStateError.Errorf(
+			"NegativePrevBlockFee(value=%s)", i.prevBlockFee)
+	}
+	return nil
+}
+
 func (i *Issue) TotalReward() *big.Int {
 	return i.totalReward
 }
@@ -131,15 +163,17 @@ func (i *Issue) SetPrevBlockFee(v *big.Int) {
 	i.prevBlockFee = v
 }
 
+// Update returns a new issue with the amounts issued in a block. The issue
+// itself is not changed.
 func (i *Issue) Update(totalReward *big.Int, byFee *big.Int, byOverIssued *big.Int) *Issue {
 	overIssuedDelta := new(big.Int).Add(byFee, byOverIssued)
 	overIssuedDelta.Sub(overIssuedDelta, i.prevBlockFee)
 
 	return &Issue{
 		totalReward:      new(big.Int).Add(i.totalReward, totalReward),
-		prevTotalReward:  i.prevTotalReward,
+		prevTotalReward:  cloneBigInt(i.prevTotalReward),
 		overIssuedIScore: new(big.Int).Sub(i.overIssuedIScore, icutils.ICXToIScore(overIssuedDelta)),
-		prevBlockFee:     i.prevBlockFee,
+		prevBlockFee:     cloneBigInt(i.prevBlockFee),
 	}
 }
 
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/icon/icmodule"
 )
 
 func Test_NewIssue(t *testing.T) {
@@ -77,6 +79,87 @@ func TestIssue_ResetTotalReward(t *testing.T) {
 	assert.Zero(t, issue.PrevTotalReward().Cmp(totalReward))
 }
 
+func TestIssue_Clone(t *testing.T) {
+	issue := NewIssue()
+	setValues := func(i *Issue, v int64) {
+		i.SetTotalReward(big.NewInt(v))
+		i.SetPrevTotalReward(big.NewInt(v + 1))
+		i.SetOverIssuedIScore(big.NewInt(v + 2))
+		i.SetPrevBlockFee(big.NewInt(v + 3))
+	}
+	setValues(issue, 100)
+
+	issue2 := issue.Clone()
+	assert.True(t, issue.Equal(issue2))
+
+	// changing values of the copy in place doesn't affect the original
+	issue2.TotalReward().SetInt64(1)
+	issue2.PrevTotalReward().SetInt64(1)
+	issue2.OverIssuedIScore().SetInt64(1)
+	issue2.PrevBlockFee().SetInt64(1)
+	assert.False(t, issue.Equal(issue2))
+	assert.EqualValues(t, 100, issue.TotalReward().Int64())
+	assert.EqualValues(t, 101, issue.PrevTotalReward().Int64())
+	assert.EqualValues(t, 102, issue.OverIssuedIScore().Int64())
+	assert.EqualValues(t, 103, issue.PrevBlockFee().Int64())
+
+	// fields of a new issue are not shared
+	issue3 := NewIssue()
+	issue3.TotalReward().SetInt64(1)
+	assert.Zero(t, issue3.PrevTotalReward().Sign())
+	assert.Zero(t, issue3.OverIssuedIScore().Sign())
+	assert.Zero(t, issue3.PrevBlockFee().Sign())
+}
+
+func TestIssue_Validate(t *testing.T) {
+	issue := NewIssue()
+	assert.NoError(t, issue.Validate())
+
+	issue.SetOverIssuedIScore(big.NewInt(-100))
+	assert.NoError(t, issue.Validate())
+
+	for _, set := range []func(i *Issue, v *big.Int){
+		(*Issue).SetTotalReward,
+		(*Issue).SetPrevTotalReward,
+		(*Issue).SetPrevBlockFee,
+	} {
+		i := issue.Clone()
+		set(i, big.NewInt(-1))
+		assert.Error(t, i.Validate())
+		set(i, nil)
+		assert.Error(t, i.Validate())
+	}
+}
+
+func TestIssue_UpdateAndReset(t *testing.T) {
+	issue := NewIssue()
+	issue.SetPrevBlockFee(big.NewInt(10))
+
+	// issue 100 including the previous block fee
+	ni := issue.Update(big.NewInt(100), big.NewInt(10), big.NewInt(0))
+	assert.NoError(t, ni.Validate())
+	assert.EqualValues(t, 100, ni.TotalReward().Int64())
+	assert.Zero(t, ni.OverIssuedIScore().Sign())
+	assert.Zero(t, issue.TotalReward().Sign())
+
+	ni = ni.Update(big.NewInt(100), big.NewInt(0), big.NewInt(0))
+	assert.EqualValues(t, 200, ni.TotalReward().Int64())
+	assert.EqualValues(t, 10*icmodule.IScoreICXRatio, ni.OverIssuedIScore().Int64())
+
+	prev := ni.Clone()
+	ni.ResetTotalReward()
+	assert.NoError(t, ni.Validate())
+	assert.Zero(t, ni.TotalReward().Sign())
+	assert.EqualValues(t, 200, ni.PrevTotalReward().Int64())
+	assert.EqualValues(t, 200, prev.TotalReward().Int64())
+	assert.Zero(t, prev.PrevTotalReward().Sign())
+
+	// the total reward of the new term doesn't share the previous one
+	ni = ni.Update(big.NewInt(50), big.NewInt(0), big.NewInt(0))
+	assert.EqualValues(t, 50, ni.TotalReward().Int64())
+	assert.EqualValues(t, 200, ni.PrevTotalReward().Int64())
+}
+
 func BenchmarkIssue_Update(b *testing.B) {
 	issue := NewIssue()
 	totalReward := big.NewInt(1_000_000_000_000_000_000)
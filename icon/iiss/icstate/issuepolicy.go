@@ -0,0 +1,125 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const (
+	// IssuePolicyIISS issues ICX with the formula of IISS version of the term.
+	IssuePolicyIISS = "iiss"
+	// IssuePolicyFixed issues the fixed amount of ICX for each block.
+	IssuePolicyFixed = "fixed"
+	// IssuePolicyCapped issues ICX like IssuePolicyIISS until the total
+	// supply reaches the cap.
+	IssuePolicyCapped = "capped"
+	// IssuePolicyNone doesn't issue ICX. Rewards are paid with fees and
+	// the treasury.
+	IssuePolicyNone = "none"
+)
+
+// IssuePolicyParams are parameters of the policy for ICX issuance.
+// Amount is used for IssuePolicyFixed and Cap is used for
+// IssuePolicyCapped.
+type IssuePolicyParams struct {
+	Type   string
+	Amount *big.Int
+	Cap    *big.Int
+}
+
+func NewIssuePolicyParams() *IssuePolicyParams {
+	return &IssuePolicyParams{
+		Type:   IssuePolicyIISS,
+		Amount: new(big.Int),
+		Cap:    new(big.Int),
+	}
+}
+
+func newIssuePolicyParamsFromBytes(bs []byte) (*IssuePolicyParams, error) {
+	if bs == nil {
+		return NewIssuePolicyParams(), nil
+	}
+	p := &IssuePolicyParams{}
+	if _, err := codec.BC.UnmarshalFromBytes(bs, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *IssuePolicyParams) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(p.Type, p.Amount, p.Cap)
+}
+
+func (p *IssuePolicyParams) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&p.Type, &p.Amount, &p.Cap)
+}
+
+func (p *IssuePolicyParams) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(p)
+}
+
+func (p *IssuePolicyParams) Validate() error {
+	switch p.Type {
+	case IssuePolicyIISS, IssuePolicyNone:
+	case IssuePolicyFixed:
+		if p.Amount == nil || p.Amount.Sign() < 0 {
+			return errors.IllegalArgumentError.Errorf("InvalidAmount(amount=%v)", p.Amount)
+		}
+	case IssuePolicyCapped:
+		if p.Cap == nil || p.Cap.Sign() <= 0 {
+			return errors.IllegalArgumentError.Errorf("InvalidCap(cap=%v)", p.Cap)
+		}
+	default:
+		return errors.IllegalArgumentError.Errorf("UnknownIssuePolicy(type=%s)", p.Type)
+	}
+	return nil
+}
+
+func (p *IssuePolicyParams) Equal(p2 *IssuePolicyParams) bool {
+	return p.Type == p2.Type &&
+		p.Amount.Cmp(p2.Amount) == 0 &&
+		p.Cap.Cmp(p2.Cap) == 0
+}
+
+func (p *IssuePolicyParams) ToJSON() map[string]interface{} {
+	jso := map[string]interface{}{
+		"type": p.Type,
+	}
+	switch p.Type {
+	case IssuePolicyFixed:
+		jso["amount"] = p.Amount
+	case IssuePolicyCapped:
+		jso["cap"] = p.Cap
+	}
+	return jso
+}
+
+func (p *IssuePolicyParams) Format(f fmt.State, c rune) {
+	switch c {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "IssuePolicyParams{Type=%s Amount=%s Cap=%s}", p.Type, p.Amount, p.Cap)
+		} else {
+			fmt.Fprintf(f, "IssuePolicyParams{%s %s %s}", p.Type, p.Amount, p.Cap)
+		}
+	}
+}
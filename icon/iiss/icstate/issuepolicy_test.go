@@ -0,0 +1,58 @@
+package icstate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuePolicyParams_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     *IssuePolicyParams
+		valid bool
+	}{
+		{"Default", NewIssuePolicyParams(), true},
+		{"None", &IssuePolicyParams{Type: IssuePolicyNone}, true},
+		{"Fixed", &IssuePolicyParams{Type: IssuePolicyFixed, Amount: big.NewInt(10)}, true},
+		{"FixedZero", &IssuePolicyParams{Type: IssuePolicyFixed, Amount: new(big.Int)}, true},
+		{"FixedNegative", &IssuePolicyParams{Type: IssuePolicyFixed, Amount: big.NewInt(-1)}, false},
+		{"FixedNil", &IssuePolicyParams{Type: IssuePolicyFixed}, false},
+		{"Capped", &IssuePolicyParams{Type: IssuePolicyCapped, Cap: big.NewInt(10)}, true},
+		{"CappedZero", &IssuePolicyParams{Type: IssuePolicyCapped, Cap: new(big.Int)}, false},
+		{"Unknown", &IssuePolicyParams{Type: "unknown"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestState_IssuePolicy(t *testing.T) {
+	s := newDummyState(false)
+
+	p := s.GetIssuePolicy()
+	assert.True(t, p.Equal(NewIssuePolicyParams()))
+
+	assert.Error(t, s.SetIssuePolicy(&IssuePolicyParams{Type: "unknown"}))
+
+	p = NewIssuePolicyParams()
+	p.Type = IssuePolicyCapped
+	p.Cap = big.NewInt(1_000_000)
+	assert.NoError(t, s.SetIssuePolicy(p))
+
+	s = flushAndNewState(s, false)
+	p2 := s.GetIssuePolicy()
+	assert.True(t, p.Equal(p2))
+	jso := p2.ToJSON()
+	assert.Equal(t, IssuePolicyCapped, jso["type"])
+	assert.Equal(t, 0, p.Cap.Cmp(jso["cap"].(*big.Int)))
+	assert.NotContains(t, jso, "amount")
+}
@@ -51,6 +51,12 @@ const (
 	VarDelegationSlotMax                     = "delegation_slot_max"
 	DictNetworkScores                        = "network_scores"
 	VarNonVotePenaltySlashRatio              = "nonvote_penalty_slashRatio"
+	VarIssuePolicy                           = "issue_policy"
+	VarDoubleSignPenaltySlashRatio           = "doublesign_penalty_slashRatio"
+	VarUnjailPeriod                          = "unjail_period"
+	VarUnjailFee                             = "unjail_fee"
+	VarCommissionChangeDelay                 = "commission_change_delay"
+	VarMaxCommissionChange                   = "max_commission_change"
 )
 
 const (
@@ -280,6 +286,19 @@ func (s *State) SetRewardFund(rc *RewardFund) error {
 	return setValue(s.store, VarRewardFund, rc.Bytes())
 }
 
+func (s *State) GetIssuePolicy() *IssuePolicyParams {
+	bs := getValue(s.store, VarIssuePolicy).Bytes()
+	p, _ := newIssuePolicyParamsFromBytes(bs)
+	return p
+}
+
+func (s *State) SetIssuePolicy(p *IssuePolicyParams) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	return setValue(s.store, VarIssuePolicy, p.Bytes())
+}
+
 func (s *State) GetUnbondingMax() int64 {
 	return getValue(s.store, VarUnbondingMax).Int64()
 }
@@ -355,6 +374,87 @@ func (s *State) SetNonVotePenaltySlashRatio(value int) error {
 	return setValue(s.store, VarNonVotePenaltySlashRatio, value)
 }
 
+func (s *State) GetDoubleSignPenaltySlashRatio() int {
+	return int(getValue(s.store, VarDoubleSignPenaltySlashRatio).Int64())
+}
+
+func (s *State) SetDoubleSignPenaltySlashRatio(value int) error {
+	if value < 0 || value > 100 {
+		return errors.IllegalArgumentError.New("Invalid range")
+	}
+	return setValue(s.store, VarDoubleSignPenaltySlashRatio, value)
+}
+
+// GetUnjailPeriod returns the number of blocks that a jailed P-Rep has to wait
+// before requesting unjail
+func (s *State) GetUnjailPeriod() int64 {
+	varDB := containerdb.NewVarDB(
+		s.store,
+		containerdb.ToKey(containerdb.HashBuilder, scoredb.VarDBPrefix, VarUnjailPeriod),
+	)
+	if varDB.Bytes() == nil {
+		return icmodule.DefaultUnjailPeriod
+	}
+	return varDB.Int64()
+}
+
+func (s *State) SetUnjailPeriod(value int64) error {
+	if value < 0 {
+		return errors.IllegalArgumentError.New("Invalid range")
+	}
+	return setValue(s.store, VarUnjailPeriod, value)
+}
+
+// GetUnjailFee returns the amount of ICX in loop burned for requesting unjail
+func (s *State) GetUnjailFee() *big.Int {
+	value := getValue(s.store, VarUnjailFee).BigInt()
+	if value == nil {
+		value = icmodule.BigIntUnjailFee
+	}
+	return value
+}
+
+func (s *State) SetUnjailFee(value *big.Int) error {
+	if value == nil || value.Sign() < 0 {
+		return errors.IllegalArgumentError.New("Invalid range")
+	}
+	return setValue(s.store, VarUnjailFee, value)
+}
+
+// GetCommissionChangeDelay returns the number of terms to wait until a new
+// commission rate of a P-Rep is applied
+func (s *State) GetCommissionChangeDelay() int64 {
+	varDB := getValue(s.store, VarCommissionChangeDelay)
+	if varDB.Bytes() == nil {
+		return icmodule.DefaultCommissionChangeDelay
+	}
+	return varDB.Int64()
+}
+
+func (s *State) SetCommissionChangeDelay(value int64) error {
+	if value < 1 {
+		return errors.IllegalArgumentError.New("Invalid range")
+	}
+	return setValue(s.store, VarCommissionChangeDelay, value)
+}
+
+// GetMaxCommissionChange returns the maximum change of the commission rate
+// of a P-Rep at once in basis point
+func (s *State) GetMaxCommissionChange() int64 {
+	varDB := getValue(s.store, VarMaxCommissionChange)
+	if varDB.Bytes() == nil {
+		return icmodule.DefaultMaxCommissionChange
+	}
+	return varDB.Int64()
+}
+
+func (s *State) SetMaxCommissionChange(value int64) error {
+	if value < 0 || value > icmodule.MaxCommissionRate {
+		return errors.IllegalArgumentError.New("Invalid range")
+	}
+	return setValue(s.store, VarMaxCommissionChange, value)
+}
+
 func (s *State) GetNetworkInfoInJSON() (map[string]interface{}, error) {
 	br := s.GetBondRequirement()
 	jso := make(map[string]interface{})
@@ -25,6 +25,7 @@ import (
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/trie/trie_manager"
+	"github.com/icon-project/goloop/icon/icmodule"
 	"github.com/icon-project/goloop/icon/iiss/icobject"
 )
 
@@ -74,6 +75,11 @@ func Test_networkValue(t *testing.T) {
 	// test for SetUnbondingPeriodMultiplier
 	t.Run("SetUnbondingPeriodMultiplier", func(t *testing.T) { setUnbondingPeriodMultiplier(t, s) })
 
+	// test for SetUnjailPeriod and SetUnjailFee
+	t.Run("SetUnjailConfig", func(t *testing.T) { setUnjailConfigTest(t, s) })
+
+	// test for SetCommissionChangeDelay and SetMaxCommissionChange
+	t.Run("SetCommissionConfig", func(t *testing.T) { setCommissionConfigTest(t, s) })
 }
 
 func setTermPeriodTest(t *testing.T, s *State) {
@@ -219,3 +225,33 @@ func setUnbondingPeriodMultiplier(t *testing.T, s *State) {
 	actual = s.GetUnbondingPeriodMultiplier()
 	assert.Equal(t, p, actual)
 }
+
+func setUnjailConfigTest(t *testing.T, s *State) {
+	assert.Equal(t, int64(icmodule.DefaultUnjailPeriod), s.GetUnjailPeriod())
+	assert.Equal(t, 0, s.GetUnjailFee().Cmp(icmodule.BigIntUnjailFee))
+
+	assert.Error(t, s.SetUnjailPeriod(-1))
+	assert.Equal(t, int64(icmodule.DefaultUnjailPeriod), s.GetUnjailPeriod())
+	assert.NoError(t, s.SetUnjailPeriod(0))
+	assert.Equal(t, int64(0), s.GetUnjailPeriod())
+
+	assert.Error(t, s.SetUnjailFee(big.NewInt(-1)))
+	assert.Equal(t, 0, s.GetUnjailFee().Cmp(icmodule.BigIntUnjailFee))
+	fee := big.NewInt(1000)
+	assert.NoError(t, s.SetUnjailFee(fee))
+	assert.Equal(t, 0, s.GetUnjailFee().Cmp(fee))
+}
+
+func setCommissionConfigTest(t *testing.T, s *State) {
+	assert.Equal(t, int64(icmodule.DefaultCommissionChangeDelay), s.GetCommissionChangeDelay())
+	assert.Equal(t, int64(icmodule.DefaultMaxCommissionChange), s.GetMaxCommissionChange())
+
+	assert.Error(t, s.SetCommissionChangeDelay(0))
+	assert.NoError(t, s.SetCommissionChangeDelay(3))
+	assert.Equal(t, int64(3), s.GetCommissionChangeDelay())
+
+	assert.Error(t, s.SetMaxCommissionChange(-1))
+	assert.Error(t, s.SetMaxCommissionChange(icmodule.MaxCommissionRate+1))
+	assert.NoError(t, s.SetMaxCommissionChange(0))
+	assert.Equal(t, int64(0), s.GetMaxCommissionChange())
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/state"
+)
+
+const (
+	// MaxNodeKeyRecords is the number of recent node key registrations kept
+	// for each P-Rep.
+	MaxNodeKeyRecords = 100
+
+	// BlockSigningDSA is the DSA of node keys signing blocks and votes.
+	BlockSigningDSA = "ecdsa/secp256k1"
+)
+
+var nodeKeyHistoryDictPrefix = containerdb.ToKey(
+	containerdb.HashBuilder,
+	scoredb.DictDBPrefix,
+	"node_key_history",
+)
+
+var nodeKeyRotationsVarKey = containerdb.ToKey(
+	containerdb.HashBuilder,
+	scoredb.VarDBPrefix,
+	"node_key_rotations",
+)
+
+// NodeKeyRecord is a public key of DSA registered with the proof of
+// possession by a P-Rep at BlockHeight.
+type NodeKeyRecord struct {
+	BlockHeight int64
+	DSA         string
+	PubKey      []byte
+}
+
+func (r *NodeKeyRecord) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"blockHeight": r.BlockHeight,
+		"dsa":         r.DSA,
+		"pubKey":      r.PubKey,
+	}
+}
+
+var nodeKeyHistorySchema = &containerdb.Schema[[]*NodeKeyRecord]{}
+
+func (s *State) nodeKeyHistoryDB() *containerdb.TypedDictDB[[]*NodeKeyRecord] {
+	return containerdb.NewTypedDictDB(s.store, nodeKeyHistoryDictPrefix, nodeKeyHistorySchema)
+}
+
+func (s *State) GetNodeKeyHistory(owner module.Address) ([]*NodeKeyRecord, error) {
+	records, err := s.nodeKeyHistoryDB().Get(owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "InvalidNodeKeyHistory")
+	}
+	return records, nil
+}
+
+// AddNodeKeyRecord appends the record to the node key history of the P-Rep.
+// Records exceeding MaxNodeKeyRecords are dropped from the oldest one.
+func (s *State) AddNodeKeyRecord(owner module.Address, record *NodeKeyRecord) error {
+	records, err := s.GetNodeKeyHistory(owner)
+	if err != nil {
+		return err
+	}
+	records = append(records, record)
+	if len(records) > MaxNodeKeyRecords {
+		records = records[len(records)-MaxNodeKeyRecords:]
+	}
+	return s.nodeKeyHistoryDB().Set(owner, records)
+}
+
+// GetRegisteredNodeKey returns the public key of DSA registered lastly by the
+// P-Rep, or nil if there is no registration for it.
+func (s *State) GetRegisteredNodeKey(owner module.Address, dsa string) ([]byte, error) {
+	records, err := s.GetNodeKeyHistory(owner)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].DSA == dsa {
+			return records[i].PubKey, nil
+		}
+	}
+	return nil, nil
+}
+
+// NodeKeyRotation is a request of a P-Rep at BlockHeight to change its
+// block signing key to PubKey, whose address is Node. It takes effect at
+// the end of the term.
+type NodeKeyRotation struct {
+	Owner       *common.Address
+	Node        *common.Address
+	PubKey      []byte
+	BlockHeight int64
+}
+
+func (r *NodeKeyRotation) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"node":        r.Node,
+		"pubKey":      r.PubKey,
+		"blockHeight": r.BlockHeight,
+	}
+}
+
+var nodeKeyRotationsSchema = &containerdb.Schema[[]*NodeKeyRotation]{}
+
+func (s *State) nodeKeyRotationsDB() *containerdb.TypedVarDB[[]*NodeKeyRotation] {
+	return containerdb.NewTypedVarDB(s.store, nodeKeyRotationsVarKey, nodeKeyRotationsSchema)
+}
+
+// GetNodeKeyRotations returns the rotations requested in the current term
+// in the order of requests.
+func (s *State) GetNodeKeyRotations() ([]*NodeKeyRotation, error) {
+	rotations, err := s.nodeKeyRotationsDB().Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "InvalidNodeKeyRotations")
+	}
+	return rotations, nil
+}
+
+func (s *State) setNodeKeyRotations(rotations []*NodeKeyRotation) error {
+	if len(rotations) == 0 {
+		return s.nodeKeyRotationsDB().Delete()
+	}
+	return s.nodeKeyRotationsDB().Set(rotations)
+}
+
+// GetNodeKeyRotation returns the rotation requested by the P-Rep, or nil if
+// there is no request.
+func (s *State) GetNodeKeyRotation(owner module.Address) (*NodeKeyRotation, error) {
+	rotations, err := s.GetNodeKeyRotations()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rotations {
+		if r.Owner.Equal(owner) {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// SetNodeKeyRotation adds the rotation. It replaces the previous request of
+// the P-Rep in the same term.
+func (s *State) SetNodeKeyRotation(rotation *NodeKeyRotation) error {
+	rotations, err := s.GetNodeKeyRotations()
+	if err != nil {
+		return err
+	}
+	for i, r := range rotations {
+		if r.Owner.Equal(rotation.Owner) {
+			rotations = append(rotations[:i], rotations[i+1:]...)
+			break
+		}
+	}
+	return s.setNodeKeyRotations(append(rotations, rotation))
+}
+
+// IsNodeAvailable returns whether the owner can use the node address.
+func (s *State) IsNodeAvailable(node, owner module.Address) bool {
+	if o := s.nodeOwnerCache.get(node, nil); o != nil {
+		return o.Equal(owner)
+	}
+	if !node.Equal(owner) {
+		ps := s.GetPRepStatusByOwner(node, false)
+		if ps != nil && ps.Status() != NotReady {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyNodeKeyRotations changes node addresses of P-Reps as requested and
+// records them in the node key histories. Requests of P-Reps not active
+// anymore or for the node address taken by others are dropped.
+// Aliases from old node addresses to owners are kept, so votes signed with
+// old keys are still attributed to the P-Reps.
+func (s *State) ApplyNodeKeyRotations(blockHeight int64, bc state.BTPContext) error {
+	rotations, err := s.GetNodeKeyRotations()
+	if err != nil || len(rotations) == 0 {
+		return err
+	}
+	for _, r := range rotations {
+		ps := s.GetPRepStatusByOwner(r.Owner, false)
+		if ps == nil || !ps.IsActive() {
+			continue
+		}
+		if !r.Node.Equal(s.GetNodeByOwner(r.Owner)) {
+			if !s.IsNodeAvailable(r.Node, r.Owner) {
+				continue
+			}
+			if _, err = s.SetPRep(blockHeight, r.Owner, &PRepInfo{Node: r.Node}); err != nil {
+				return err
+			}
+		}
+		if bc != nil {
+			ps.SetDSAMask(bc.GetPublicKeyMask(r.Node))
+		}
+		if err = s.AddNodeKeyRecord(r.Owner, &NodeKeyRecord{
+			BlockHeight: blockHeight,
+			DSA:         BlockSigningDSA,
+			PubKey:      r.PubKey,
+		}); err != nil {
+			return err
+		}
+	}
+	return s.setNodeKeyRotations(nil)
+}
@@ -0,0 +1,116 @@
+package icstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestState_NodeKeyHistory(t *testing.T) {
+	s := newDummyState(false)
+	owner := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	const dsa1 = "ecdsa/secp256k1"
+	const dsa2 = "bls12-381"
+
+	records, err := s.GetNodeKeyHistory(owner)
+	assert.NoError(t, err)
+	assert.Len(t, records, 0)
+
+	key, err := s.GetRegisteredNodeKey(owner, dsa1)
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+
+	for i := 0; i < MaxNodeKeyRecords; i++ {
+		assert.NoError(t, s.AddNodeKeyRecord(owner, &NodeKeyRecord{
+			BlockHeight: int64(100 + i),
+			DSA:         dsa1,
+			PubKey:      []byte{byte(i)},
+		}))
+	}
+	assert.NoError(t, s.AddNodeKeyRecord(owner, &NodeKeyRecord{
+		BlockHeight: 200,
+		DSA:         dsa2,
+		PubKey:      []byte{0xff},
+	}))
+
+	s = flushAndNewState(s, false)
+	records, err = s.GetNodeKeyHistory(owner)
+	assert.NoError(t, err)
+	assert.Len(t, records, MaxNodeKeyRecords)
+	assert.EqualValues(t, 101, records[0].BlockHeight)
+
+	last := records[len(records)-1]
+	jso := last.ToJSON()
+	assert.EqualValues(t, 200, jso["blockHeight"])
+	assert.Equal(t, dsa2, jso["dsa"])
+
+	key, err = s.GetRegisteredNodeKey(owner, dsa1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{byte(MaxNodeKeyRecords - 1)}, key)
+	key, err = s.GetRegisteredNodeKey(owner, dsa2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xff}, key)
+}
+
+func TestState_NodeKeyRotation(t *testing.T) {
+	s := newDummyState(false)
+	owner1 := newDummyAddress(1)
+	owner2 := newDummyAddress(2)
+	for i, owner := range []module.Address{owner1, owner2} {
+		assert.NoError(t, s.RegisterPRep(owner, newDummyPRepInfo(i), icmodule.BigIntInitialIRep, 0))
+	}
+	node1 := common.AddressToPtr(newDummyAddress(11))
+	node2 := common.AddressToPtr(newDummyAddress(12))
+	rotation := func(owner module.Address, node *common.Address, h int64) *NodeKeyRotation {
+		return &NodeKeyRotation{
+			Owner:       common.AddressToPtr(owner),
+			Node:        node,
+			PubKey:      node.Bytes(),
+			BlockHeight: h,
+		}
+	}
+
+	assert.True(t, s.IsNodeAvailable(node1, owner2))
+	assert.False(t, s.IsNodeAvailable(owner1, owner2))
+
+	assert.NoError(t, s.SetNodeKeyRotation(rotation(owner1, node1, 10)))
+	assert.NoError(t, s.SetNodeKeyRotation(rotation(owner2, node1, 11)))
+	// the latest request replaces the previous one
+	assert.NoError(t, s.SetNodeKeyRotation(rotation(owner2, node2, 12)))
+	// not a P-Rep
+	assert.NoError(t, s.SetNodeKeyRotation(rotation(newDummyAddress(3), common.AddressToPtr(newDummyAddress(13)), 13)))
+
+	s = flushAndNewState(s, false)
+	rotations, err := s.GetNodeKeyRotations()
+	assert.NoError(t, err)
+	assert.Len(t, rotations, 3)
+	r, err := s.GetNodeKeyRotation(owner2)
+	assert.NoError(t, err)
+	assert.True(t, r.Node.Equal(node2))
+	assert.EqualValues(t, 12, r.ToJSON()["blockHeight"])
+
+	assert.NoError(t, s.ApplyNodeKeyRotations(100, nil))
+	assert.True(t, s.GetNodeByOwner(owner1).Equal(node1))
+	assert.True(t, s.GetNodeByOwner(owner2).Equal(node2))
+	assert.True(t, s.GetOwnerByNode(node1).Equal(owner1))
+	assert.False(t, s.IsNodeAvailable(node1, owner2))
+
+	rotations, err = s.GetNodeKeyRotations()
+	assert.NoError(t, err)
+	assert.Len(t, rotations, 0)
+
+	records, err := s.GetNodeKeyHistory(owner1)
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.EqualValues(t, 100, records[0].BlockHeight)
+		assert.Equal(t, BlockSigningDSA, records[0].DSA)
+		assert.Equal(t, node1.Bytes(), records[0].PubKey)
+	}
+	records, err = s.GetNodeKeyHistory(newDummyAddress(3))
+	assert.NoError(t, err)
+	assert.Len(t, records, 0)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+// MaxPenaltyRecords is the number of recent penalties kept for each P-Rep.
+const MaxPenaltyRecords = 100
+
+var penaltyHistoryDictPrefix = containerdb.ToKey(
+	containerdb.HashBuilder,
+	scoredb.DictDBPrefix,
+	"penalty_history",
+)
+
+// PenaltyRecord is a penalty imposed on a P-Rep at BlockHeight.
+// EvidenceHeight is the height of the misbehavior for PenaltyDoubleSign.
+// Slashed is the amount of bonds slashed by the penalty.
+type PenaltyRecord struct {
+	BlockHeight    int64
+	Type           int
+	EvidenceHeight int64
+	Slashed        *big.Int
+}
+
+func (r *PenaltyRecord) ToJSON() map[string]interface{} {
+	jso := map[string]interface{}{
+		"blockHeight": r.BlockHeight,
+		"type":        int64(r.Type),
+		"slashed":     r.Slashed,
+	}
+	if icmodule.PenaltyType(r.Type) == icmodule.PenaltyDoubleSign {
+		jso["evidenceHeight"] = r.EvidenceHeight
+	}
+	return jso
+}
+
+var penaltyHistorySchema = &containerdb.Schema[[]*PenaltyRecord]{}
+
+func (s *State) penaltyHistoryDB() *containerdb.TypedDictDB[[]*PenaltyRecord] {
+	return containerdb.NewTypedDictDB(s.store, penaltyHistoryDictPrefix, penaltyHistorySchema)
+}
+
+func (s *State) GetPenaltyHistory(owner module.Address) ([]*PenaltyRecord, error) {
+	records, err := s.penaltyHistoryDB().Get(owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "InvalidPenaltyHistory")
+	}
+	return records, nil
+}
+
+// AddPenaltyRecord appends the record to the penalty history of the P-Rep.
+// Records exceeding MaxPenaltyRecords are dropped from the oldest one.
+func (s *State) AddPenaltyRecord(owner module.Address, record *PenaltyRecord) error {
+	records, err := s.GetPenaltyHistory(owner)
+	if err != nil {
+		return err
+	}
+	records = append(records, record)
+	if len(records) > MaxPenaltyRecords {
+		records = records[len(records)-MaxPenaltyRecords:]
+	}
+	return s.penaltyHistoryDB().Set(owner, records)
+}
+
+// HasDoubleSignPenalty returns whether the P-Rep is already penalized for
+// double sign at the height.
+func (s *State) HasDoubleSignPenalty(owner module.Address, height int64) (bool, error) {
+	records, err := s.GetPenaltyHistory(owner)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range records {
+		if icmodule.PenaltyType(r.Type) == icmodule.PenaltyDoubleSign && r.EvidenceHeight == height {
+			return true, nil
+		}
+	}
+	return false, nil
+}
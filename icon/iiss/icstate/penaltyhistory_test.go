@@ -0,0 +1,58 @@
+package icstate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/icon/icmodule"
+)
+
+func TestState_PenaltyHistory(t *testing.T) {
+	s := newDummyState(false)
+	owner := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+
+	records, err := s.GetPenaltyHistory(owner)
+	assert.NoError(t, err)
+	assert.Len(t, records, 0)
+
+	ok, err := s.HasDoubleSignPenalty(owner, 10)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	for i := 0; i < MaxPenaltyRecords; i++ {
+		assert.NoError(t, s.AddPenaltyRecord(owner, &PenaltyRecord{
+			BlockHeight: int64(100 + i),
+			Type:        int(icmodule.PenaltyBlockValidation),
+			Slashed:     new(big.Int),
+		}))
+	}
+	assert.NoError(t, s.AddPenaltyRecord(owner, &PenaltyRecord{
+		BlockHeight:    200,
+		Type:           int(icmodule.PenaltyDoubleSign),
+		EvidenceHeight: 10,
+		Slashed:        big.NewInt(1000),
+	}))
+
+	s = flushAndNewState(s, false)
+	records, err = s.GetPenaltyHistory(owner)
+	assert.NoError(t, err)
+	assert.Len(t, records, MaxPenaltyRecords)
+	assert.EqualValues(t, 101, records[0].BlockHeight)
+
+	last := records[len(records)-1]
+	assert.EqualValues(t, 200, last.BlockHeight)
+	assert.EqualValues(t, 1000, last.Slashed.Int64())
+	jso := last.ToJSON()
+	assert.EqualValues(t, 10, jso["evidenceHeight"])
+	assert.NotContains(t, records[0].ToJSON(), "evidenceHeight")
+
+	ok, err = s.HasDoubleSignPenalty(owner, 10)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = s.HasDoubleSignPenalty(owner, 11)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -43,6 +43,13 @@ func (p *PRep) ToJSON(blockHeight int64, bondRequirement int64, activeDSAMask in
 	pb := p.getPRepBaseState()
 	jso := icutils.MergeMaps(pb.ToJSON(p.owner), p.PRepStatusState.ToJSON(blockHeight, bondRequirement, activeDSAMask))
 	jso["address"] = p.owner
+	if c := pb.Commission(); c != nil {
+		var term int64
+		if ts := p.state.GetTermSnapshot(); ts != nil {
+			term = int64(ts.Sequence())
+		}
+		jso = icutils.MergeMaps(jso, c.ToJSON(term))
+	}
 	return jso
 }
 
@@ -103,6 +110,7 @@ type PRepSetEntry interface {
 	Bonded() *big.Int
 	Owner() module.Address
 	HasPubKey() bool
+	IsJailed() bool
 }
 
 type prepSetEntry struct {
@@ -142,6 +150,10 @@ func (p *prepSetEntry) HasPubKey() bool {
 	return p.pubKey
 }
 
+func (p *prepSetEntry) IsJailed() bool {
+	return p.prep.IsJailed()
+}
+
 func NewPRepSetEntry(prep *PRep, pubKey bool) *prepSetEntry {
 	return &prepSetEntry{
 		prep:   prep,
@@ -165,8 +177,7 @@ func (p *prepSetImpl) OnTermEnd(revision, mainPRepCount, subPRepCount, extraMain
 
 	var newGrade Grade
 	for i, entry := range p.entries {
-		if revision >= icmodule.RevisionBTP2 &&
-			(entry.Power(br).Sign() == 0 || entry.HasPubKey() == false) {
+		if revision >= icmodule.RevisionBTP2 && !isElectable(entry, br) {
 			newGrade = GradeCandidate
 		} else if i < mainPRepCount {
 			newGrade = GradeMain
@@ -262,10 +273,10 @@ func (p *prepSetImpl) Sort(mainPRepCount, subPRepCount, extraMainPRepCount int,
 			p.sort(br, nil)
 			p.sortForExtraMainPRep(mainPRepCount, subPRepCount, extraMainPRepCount, br)
 		} else {
-			p.sort(br, cmpPubKey)
+			p.sort(br, cmpElectable)
 			var electable int
 			p.visitAll(func(idx int, e1 PRepSetEntry) bool {
-				if e1.Power(br).Sign() > 0 && e1.HasPubKey() {
+				if isElectable(e1, br) {
 					electable += 1
 					return true
 				} else {
@@ -307,6 +318,24 @@ func cmpPubKey(e0, e1 PRepSetEntry) int {
 	return 0
 }
 
+// cmpElectable puts P-Reps with public keys and not in jail first
+func cmpElectable(e0, e1 PRepSetEntry) int {
+	el0 := e0.HasPubKey() && !e0.IsJailed()
+	el1 := e1.HasPubKey() && !e1.IsJailed()
+	if el0 != el1 {
+		if el0 {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// isElectable returns true if the P-Rep can be a main or sub P-Rep since RevisionBTP2
+func isElectable(e PRepSetEntry, br int64) bool {
+	return e.Power(br).Sign() > 0 && e.HasPubKey() && !e.IsJailed()
+}
+
 func lessByPower(e0, e1 PRepSetEntry, br int64, cmp func(i, j PRepSetEntry) int) bool {
 	if cmp != nil {
 		ret := cmp(e0, e1)
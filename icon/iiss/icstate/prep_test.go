@@ -111,6 +111,10 @@ func (d *dummyPRepSetEntry) HasPubKey() bool {
 	return d.pubKey
 }
 
+func (d *dummyPRepSetEntry) IsJailed() bool {
+	return d.prep.IsJailed()
+}
+
 func newDummyPRepSetEntry(
 	prep *PRep, grade Grade, power, delegated, bonded int, pubKey bool,
 ) *dummyPRepSetEntry {
@@ -321,6 +325,41 @@ func TestPRepSet_Sort_OnTermEnd(t *testing.T) {
 	}
 }
 
+func TestPRepSet_Sort_OnTermEnd_Jailed(t *testing.T) {
+	br := int64(5)
+	rev := icmodule.RevisionBTP2
+	preps := make([]*PRep, 4)
+	entries := make([]PRepSetEntry, 4)
+	for i := range preps {
+		preps[i] = newDummyPRep(i + 1)
+		entries[i] = newDummyPRepSetEntry(preps[i], GradeCandidate, i+1, i+1, i+1, true)
+	}
+	// preps[3] has the largest power but it's in jail
+	preps[3].Jail(100)
+	// preps[2] requested unjail, so it's released on this term end
+	preps[2].Jail(100)
+	assert.NoError(t, preps[2].Unjail())
+	assert.Error(t, preps[2].Unjail())
+
+	prepSet := NewPRepSet(entries)
+	prepSet.Sort(1, 2, 0, br, rev)
+	assert.NoError(t, prepSet.OnTermEnd(rev, 1, 2, 0, 0, br))
+	assert.Equal(t, 1, prepSet.GetPRepSize(GradeMain))
+	assert.Equal(t, 2, prepSet.GetPRepSize(GradeSub))
+
+	expected := []*PRep{preps[2], preps[1], preps[0], preps[3]}
+	grades := []Grade{GradeMain, GradeSub, GradeSub, GradeCandidate}
+	for i, e := range expected {
+		entry := prepSet.GetByIndex(i)
+		assert.True(t, e.Owner().Equal(entry.Owner()))
+		assert.Equal(t, grades[i], entry.PRep().Grade())
+	}
+	assert.Equal(t, JailNone, preps[2].JailState())
+	assert.Zero(t, preps[2].JailHeight())
+	assert.Equal(t, JailInJail, preps[3].JailState())
+	assert.Equal(t, int64(100), preps[3].JailHeight())
+}
+
 func TestPRepSet_SortForQuery(t *testing.T) {
 	br := int64(5)
 	prep1 := newDummyPRep(1)
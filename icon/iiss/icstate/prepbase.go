@@ -19,6 +19,7 @@ package icstate
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
 	"strings"
 
@@ -172,6 +173,7 @@ type PRepBaseData struct {
 	irep       *big.Int
 	irepHeight int64
 	bonderList BonderList
+	commission *Commission
 }
 
 func (p *PRepBaseData) Name() string {
@@ -190,6 +192,10 @@ func (p *PRepBaseData) IRepHeight() int64 {
 	return p.irepHeight
 }
 
+func (p *PRepBaseData) Commission() *Commission {
+	return p.commission
+}
+
 func (p *PRepBaseData) equal(p2 *PRepBaseData) bool {
 	if p == p2 {
 		return true
@@ -204,7 +210,8 @@ func (p *PRepBaseData) equal(p2 *PRepBaseData) bool {
 		common.AddressEqual(p.node, p2.node) &&
 		p.irep.Cmp(p2.irep) == 0 &&
 		p.irepHeight == p2.irepHeight &&
-		p.bonderList.Equal(p2.bonderList)
+		p.bonderList.Equal(p2.bonderList) &&
+		p.commission.Equal(p2.commission)
 }
 
 func (p *PRepBaseData) GetNode(owner module.Address) module.Address {
@@ -225,7 +232,8 @@ func (p *PRepBaseData) IsEmpty() bool {
 		p.node == nil &&
 		p.irep.Sign() == 0 &&
 		p.irepHeight == 0 &&
-		p.bonderList.IsEmpty()
+		p.bonderList.IsEmpty() &&
+		p.commission == nil
 }
 
 func (p *PRepBaseData) BonderList() BonderList {
@@ -311,7 +319,7 @@ func (p *PRepBaseSnapshot) Version() int {
 }
 
 func (p *PRepBaseSnapshot) RLPEncodeFields(e codec.Encoder) error {
-	return e.EncodeMulti(
+	if err := e.EncodeMulti(
 		p.name,
 		p.country,
 		p.city,
@@ -323,11 +331,17 @@ func (p *PRepBaseSnapshot) RLPEncodeFields(e codec.Encoder) error {
 		p.irep,
 		p.irepHeight,
 		p.bonderList,
-	)
+	); err != nil {
+		return err
+	}
+	if p.commission == nil {
+		return nil
+	}
+	return e.Encode(p.commission)
 }
 
 func (p *PRepBaseSnapshot) RLPDecodeFields(d codec.Decoder) error {
-	return d.DecodeAll(
+	if n, err := d.DecodeMulti(
 		&p.name,
 		&p.country,
 		&p.city,
@@ -339,7 +353,12 @@ func (p *PRepBaseSnapshot) RLPDecodeFields(d codec.Decoder) error {
 		&p.irep,
 		&p.irepHeight,
 		&p.bonderList,
-	)
+		&p.commission,
+	); err == nil || (n >= 11 && err == io.EOF) {
+		return nil
+	} else {
+		return err
+	}
 }
 
 func (p *PRepBaseSnapshot) Equal(object icobject.Impl) bool {
@@ -430,6 +449,18 @@ func (p *PRepBaseState) SetBonderList(bonderList BonderList) {
 	p.setDirty()
 }
 
+// SetCommissionRate schedules the commission rate to be effective at
+// term+delay. See Commission.Schedule for the restrictions.
+func (p *PRepBaseState) SetCommissionRate(rate, term, delay, maxChange int64) (*Commission, error) {
+	c, err := p.commission.Schedule(rate, term, delay, maxChange)
+	if err != nil {
+		return nil, err
+	}
+	p.commission = c
+	p.setDirty()
+	return c, nil
+}
+
 func (p *PRepBaseState) Reset(snapshot *PRepBaseSnapshot) *PRepBaseState {
 	if p.last != snapshot {
 		p.last = snapshot
@@ -111,6 +111,30 @@ func (vs VoteState) String() string {
 	}
 }
 
+// JailState is the state of a P-Rep jailed by penalties since RevisionJail.
+// A jailed P-Rep can't be elected as a main or sub P-Rep. It turns into
+// JailUnjailing by an unjail request, and it's released on the next term end.
+type JailState int
+
+const (
+	JailNone JailState = iota
+	JailInJail
+	JailUnjailing
+)
+
+func (js JailState) String() string {
+	switch js {
+	case JailNone:
+		return "N"
+	case JailInJail:
+		return "J"
+	case JailUnjailing:
+		return "U"
+	default:
+		return "X"
+	}
+}
+
 type prepStatusData struct {
 	grade        Grade
 	status       Status
@@ -123,6 +147,8 @@ type prepStatusData struct {
 	lastState    VoteState
 	lastHeight   int64
 	dsaMask      int64
+	jailState    JailState
+	jailHeight   int64
 
 	effectiveDelegated *big.Int
 }
@@ -152,6 +178,20 @@ func (ps *prepStatusData) GetVPenaltyCount() int {
 	return bits.OnesCount32(ps.vPenaltyMask)
 }
 
+// IsJailed returns true if this PRep is jailed and can't be elected
+func (ps *prepStatusData) IsJailed() bool {
+	return ps.jailState == JailInJail
+}
+
+func (ps *prepStatusData) JailState() JailState {
+	return ps.jailState
+}
+
+// JailHeight returns the block height when this PRep got jailed
+func (ps *prepStatusData) JailHeight() int64 {
+	return ps.jailHeight
+}
+
 func (ps *prepStatusData) LastState() VoteState {
 	return ps.lastState
 }
@@ -272,7 +312,9 @@ func (ps *prepStatusData) equal(other *prepStatusData) bool {
 		ps.vPenaltyMask == other.vPenaltyMask &&
 		ps.lastState == other.lastState &&
 		ps.lastHeight == other.lastHeight &&
-		ps.dsaMask == other.dsaMask
+		ps.dsaMask == other.dsaMask &&
+		ps.jailState == other.jailState &&
+		ps.jailHeight == other.jailHeight
 }
 
 func (ps *prepStatusData) clone() prepStatusData {
@@ -288,6 +330,8 @@ func (ps *prepStatusData) clone() prepStatusData {
 		lastState:    ps.lastState,
 		lastHeight:   ps.lastHeight,
 		dsaMask:      ps.dsaMask,
+		jailState:    ps.jailState,
+		jailHeight:   ps.jailHeight,
 
 		effectiveDelegated: ps.effectiveDelegated,
 	}
@@ -308,6 +352,10 @@ func (ps *prepStatusData) ToJSON(blockHeight int64, bondRequirement int64, dsaMa
 	if dsaMask != 0 {
 		jso["hasPublicKey"] = (ps.GetDSAMask() & dsaMask) == dsaMask
 	}
+	if ps.jailState != JailNone {
+		jso["jailState"] = int(ps.jailState)
+		jso["jailHeight"] = ps.jailHeight
+	}
 	return jso
 }
 
@@ -349,12 +397,13 @@ func (ps *prepStatusData) IsEmpty() bool {
 		ps.lastState == None &&
 		ps.lastHeight == 0 &&
 		ps.status == NotReady &&
-		ps.dsaMask == 0
+		ps.dsaMask == 0 &&
+		ps.jailState == JailNone
 }
 
 func (ps *prepStatusData) String() string {
 	return fmt.Sprintf(
-		"st=%s grade=%s ls=%s lh=%d vf=%d vt=%d vpc=%d vfco=%d dd=%s bd=%s vote=%s ed=%d dm=%d",
+		"st=%s grade=%s ls=%s lh=%d vf=%d vt=%d vpc=%d vfco=%d dd=%s bd=%s vote=%s ed=%d dm=%d js=%s jh=%d",
 		ps.status,
 		ps.grade,
 		ps.lastState,
@@ -368,6 +417,8 @@ func (ps *prepStatusData) String() string {
 		ps.getVoted(),
 		ps.effectiveDelegated,
 		ps.dsaMask,
+		ps.jailState,
+		ps.jailHeight,
 	)
 }
 
@@ -379,9 +430,10 @@ func (ps *prepStatusData) Format(f fmt.State, c rune) {
 			format = "PRepStatus{" +
 				"status=%s grade=%s lastState=%s lastHeight=%d " +
 				"vFail=%d vTotal=%d vPenaltyCount=%d vFailCont=%d " +
-				"delegated=%s bonded=%s effectiveDelegated=%d dsaMask=%d}"
+				"delegated=%s bonded=%s effectiveDelegated=%d dsaMask=%d " +
+				"jailState=%s jailHeight=%d}"
 		} else {
-			format = "PRepStatus{%s %s %s %d %d %d %d %d %s %s %d %d}"
+			format = "PRepStatus{%s %s %s %d %d %d %d %d %s %s %d %d %s %d}"
 		}
 		_, _ = fmt.Fprintf(
 			f, format,
@@ -397,6 +449,8 @@ func (ps *prepStatusData) Format(f fmt.State, c rune) {
 			ps.bonded,
 			ps.effectiveDelegated,
 			ps.dsaMask,
+			ps.jailState,
+			ps.jailHeight,
 		)
 	case 's':
 		_, _ = fmt.Fprint(f, ps.String())
@@ -425,7 +479,9 @@ func (ps *PRepStatusSnapshot) RLPDecodeFields(decoder codec.Decoder) error {
 		&ps.lastState,
 		&ps.lastHeight,
 		&ps.dsaMask,
-	); err == nil || (n == 10 && err == io.EOF) {
+		&ps.jailState,
+		&ps.jailHeight,
+	); err == nil || (n >= 10 && err == io.EOF) {
 		return nil
 	} else {
 		return err
@@ -447,10 +503,13 @@ func (ps *PRepStatusSnapshot) RLPEncodeFields(encoder codec.Encoder) error {
 	); err != nil {
 		return err
 	}
-	if ps.dsaMask == 0 {
-		return nil
+	if ps.jailState == JailNone {
+		if ps.dsaMask == 0 {
+			return nil
+		}
+		return encoder.Encode(ps.dsaMask)
 	}
-	return encoder.Encode(ps.dsaMask)
+	return encoder.EncodeMulti(ps.dsaMask, ps.jailState, ps.jailHeight)
 }
 
 func (ps *PRepStatusSnapshot) Equal(o icobject.Impl) bool {
@@ -662,8 +721,34 @@ func (ps *PRepStatusState) OnPenaltyImposed(blockHeight int64) error {
 	return nil
 }
 
+// Jail makes this PRep unable to be elected until it's released by unjail.
+// Jailing a jailed PRep again resets the waiting period for unjail.
+func (ps *PRepStatusState) Jail(blockHeight int64) {
+	ps.jailState = JailInJail
+	ps.jailHeight = blockHeight
+	ps.setDirty()
+}
+
+// Unjail accepts the unjail request of this PRep. It's released on the next term end.
+func (ps *PRepStatusState) Unjail() error {
+	if ps.jailState != JailInJail {
+		return errors.InvalidStateError.Errorf("NotInJail(state=%s)", ps.jailState)
+	}
+	ps.jailState = JailUnjailing
+	ps.setDirty()
+	return nil
+}
+
+func (ps *PRepStatusState) releaseJail() {
+	if ps.jailState == JailUnjailing {
+		ps.jailState = JailNone
+		ps.jailHeight = 0
+	}
+}
+
 func (ps *PRepStatusState) OnTermEnd(newGrade Grade, limit int) error {
 	ps.resetVFailCont()
+	ps.releaseJail()
 	if newGrade == GradeMain {
 		ps.onMainPRepIn(limit)
 	} else {
@@ -717,3 +717,36 @@ func TestPrepStatusData_ToJSON(t *testing.T) {
 	assert.True(t, ok)
 	assert.Zero(t, power.Sign())
 }
+
+func TestPRepStatus_Jail(t *testing.T) {
+	database := icobject.AttachObjectFactory(db.NewMapDB(), NewObjectImpl)
+	ps := NewPRepStatus()
+	assert.NoError(t, ps.Activate())
+	assert.False(t, ps.IsJailed())
+	assert.Error(t, ps.Unjail())
+	_, ok := ps.ToJSON(100, 5, 0)["jailState"]
+	assert.False(t, ok)
+
+	ps.Jail(100)
+	assert.True(t, ps.IsJailed())
+	jso := ps.ToJSON(100, 5, 0)
+	assert.Equal(t, int(JailInJail), jso["jailState"])
+	assert.Equal(t, int64(100), jso["jailHeight"])
+
+	// jail state is kept in serialized data
+	o1 := icobject.New(TypePRepStatus, ps.GetSnapshot())
+	o2 := new(icobject.Object)
+	assert.NoError(t, o2.Reset(database, o1.Bytes()))
+	ss := ToPRepStatus(o2)
+	assert.True(t, ps.GetSnapshot().Equal(ss))
+	assert.Equal(t, JailInJail, ss.JailState())
+	assert.Equal(t, int64(100), ss.JailHeight())
+
+	assert.NoError(t, ps.Unjail())
+	assert.False(t, ps.IsJailed())
+	assert.Equal(t, JailUnjailing, ps.JailState())
+
+	assert.NoError(t, ps.OnTermEnd(GradeCandidate, 30))
+	assert.Equal(t, JailNone, ps.JailState())
+	assert.Zero(t, ps.JailHeight())
+}
@@ -0,0 +1,370 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/iiss/icobject"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+type ProposalType int
+
+// Values are same as the ones of network proposals of the governance SCORE
+const (
+	ProposalTypeRevision ProposalType = iota + 1
+	ProposalTypeMaliciousScore
+	ProposalTypePRepDisqualification
+	ProposalTypeStepPrice
+)
+
+func (t ProposalType) String() string {
+	switch t {
+	case ProposalTypeRevision:
+		return "Revision"
+	case ProposalTypeMaliciousScore:
+		return "MaliciousScore"
+	case ProposalTypePRepDisqualification:
+		return "PRepDisqualification"
+	case ProposalTypeStepPrice:
+		return "StepPrice"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(t))
+	}
+}
+
+type ProposalStatus int
+
+const (
+	ProposalVoting ProposalStatus = iota
+	ProposalApproved
+	ProposalDisapproved
+	ProposalCanceled
+	ProposalApplied
+	ProposalExpired
+	ProposalFailed
+)
+
+func (s ProposalStatus) String() string {
+	switch s {
+	case ProposalVoting:
+		return "Voting"
+	case ProposalApproved:
+		return "Approved"
+	case ProposalDisapproved:
+		return "Disapproved"
+	case ProposalCanceled:
+		return "Canceled"
+	case ProposalApplied:
+		return "Applied"
+	case ProposalExpired:
+		return "Expired"
+	case ProposalFailed:
+		return "Failed"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(s))
+	}
+}
+
+const (
+	MaliciousScoreFreeze   = 0
+	MaliciousScoreUnfreeze = 1
+)
+
+// ProposalCall is the call to the chain SCORE for applying a proposal.
+type ProposalCall struct {
+	Method string
+	Params map[string]interface{}
+}
+
+type revisionValue struct {
+	Revision common.HexInt64 `json:"revision"`
+}
+
+type maliciousScoreValue struct {
+	Address common.Address  `json:"address"`
+	Type    common.HexInt64 `json:"type"`
+}
+
+type prepDisqualificationValue struct {
+	Address common.Address `json:"address"`
+}
+
+type stepPriceValue struct {
+	Value common.HexInt `json:"value"`
+}
+
+func decodeProposalValue(value []byte, v interface{}) error {
+	jd := json.NewDecoder(bytes.NewBuffer(value))
+	jd.DisallowUnknownFields()
+	if err := jd.Decode(v); err != nil {
+		return errors.IllegalArgumentError.Wrap(err, "InvalidProposalValue")
+	}
+	return nil
+}
+
+// NewProposalCall returns the call to apply the proposal of the type with
+// JSON encoded value. It also validates the value for the type.
+func NewProposalCall(t ProposalType, value []byte) (*ProposalCall, error) {
+	switch t {
+	case ProposalTypeRevision:
+		var v revisionValue
+		if err := decodeProposalValue(value, &v); err != nil {
+			return nil, err
+		}
+		return &ProposalCall{"setRevision", map[string]interface{}{"code": &v.Revision}}, nil
+	case ProposalTypeMaliciousScore:
+		var v maliciousScoreValue
+		if err := decodeProposalValue(value, &v); err != nil {
+			return nil, err
+		}
+		if !v.Address.IsContract() {
+			return nil, errors.IllegalArgumentError.Errorf("NotContract(address=%s)", &v.Address)
+		}
+		params := map[string]interface{}{"address": &v.Address}
+		switch v.Type.Value {
+		case MaliciousScoreFreeze:
+			return &ProposalCall{"blockScore", params}, nil
+		case MaliciousScoreUnfreeze:
+			return &ProposalCall{"unblockScore", params}, nil
+		default:
+			return nil, errors.IllegalArgumentError.Errorf("InvalidMaliciousScoreType(type=%d)", v.Type.Value)
+		}
+	case ProposalTypePRepDisqualification:
+		var v prepDisqualificationValue
+		if err := decodeProposalValue(value, &v); err != nil {
+			return nil, err
+		}
+		if v.Address.IsContract() {
+			return nil, errors.IllegalArgumentError.Errorf("NotEOA(address=%s)", &v.Address)
+		}
+		return &ProposalCall{"disqualifyPRep", map[string]interface{}{"address": &v.Address}}, nil
+	case ProposalTypeStepPrice:
+		var v stepPriceValue
+		if err := decodeProposalValue(value, &v); err != nil {
+			return nil, err
+		}
+		if v.Value.Sign() < 0 {
+			return nil, errors.IllegalArgumentError.Errorf("NegativeStepPrice(value=%s)", &v.Value)
+		}
+		return &ProposalCall{"setStepPrice", map[string]interface{}{"price": &v.Value}}, nil
+	default:
+		return nil, errors.IllegalArgumentError.Errorf("InvalidProposalType(type=%d)", int(t))
+	}
+}
+
+// Proposal is a network proposal registered by a main P-Rep.
+// Voters are the main P-Reps on registration, and it's approved if more than
+// 2/3 of them agree. It's applied at ApplyHeight or at the next block of the
+// approval if ApplyHeight is already passed.
+type Proposal struct {
+	ID          []byte
+	Proposer    *common.Address
+	Type        int
+	Value       []byte
+	StartHeight int64
+	EndHeight   int64
+	ApplyHeight int64
+	Status      int
+	Voters      []*common.Address
+	Agree       []*common.Address
+	Disagree    []*common.Address
+}
+
+func (p *Proposal) ProposalType() ProposalType {
+	return ProposalType(p.Type)
+}
+
+func (p *Proposal) ProposalStatus() ProposalStatus {
+	return ProposalStatus(p.Status)
+}
+
+// StatusAt returns the status at the height. A proposal in voting is
+// expired after EndHeight.
+func (p *Proposal) StatusAt(height int64) ProposalStatus {
+	if p.ProposalStatus() == ProposalVoting && height > p.EndHeight {
+		return ProposalExpired
+	}
+	return p.ProposalStatus()
+}
+
+func containsAddress(addrs []*common.Address, addr module.Address) bool {
+	for _, a := range addrs {
+		if a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Proposal) IsVoter(addr module.Address) bool {
+	return containsAddress(p.Voters, addr)
+}
+
+func (p *Proposal) HasVoted(addr module.Address) bool {
+	return containsAddress(p.Agree, addr) || containsAddress(p.Disagree, addr)
+}
+
+// Vote records the vote and updates the status with the tally.
+func (p *Proposal) Vote(voter module.Address, agree bool) {
+	if agree {
+		p.Agree = append(p.Agree, common.AddressToPtr(voter))
+	} else {
+		p.Disagree = append(p.Disagree, common.AddressToPtr(voter))
+	}
+	voters := len(p.Voters)
+	if len(p.Agree)*3 > voters*2 {
+		p.Status = int(ProposalApproved)
+	} else if (voters-len(p.Disagree))*3 <= voters*2 {
+		p.Status = int(ProposalDisapproved)
+	}
+}
+
+func addressesToJSON(addrs []*common.Address) []interface{} {
+	jso := make([]interface{}, len(addrs))
+	for i, a := range addrs {
+		jso[i] = a
+	}
+	return jso
+}
+
+func (p *Proposal) ToJSON(height int64) map[string]interface{} {
+	var value interface{}
+	_ = json.Unmarshal(p.Value, &value)
+	return map[string]interface{}{
+		"id":          p.ID,
+		"proposer":    p.Proposer,
+		"type":        int64(p.Type),
+		"value":       value,
+		"startHeight": p.StartHeight,
+		"endHeight":   p.EndHeight,
+		"applyHeight": p.ApplyHeight,
+		"status":      int64(p.StatusAt(height)),
+		"vote": map[string]interface{}{
+			"voters":   int64(len(p.Voters)),
+			"agree":    addressesToJSON(p.Agree),
+			"disagree": addressesToJSON(p.Disagree),
+			"noVote":   int64(len(p.Voters) - len(p.Agree) - len(p.Disagree)),
+		},
+	}
+}
+
+var (
+	proposalDictPrefix = containerdb.ToKey(
+		containerdb.HashBuilder,
+		scoredb.DictDBPrefix,
+		"proposal",
+	)
+	proposalArrayPrefix = containerdb.ToKey(
+		containerdb.HashBuilder,
+		scoredb.ArrayDBPrefix,
+		"proposal_ids",
+	)
+	pendingProposalArrayPrefix = containerdb.ToKey(
+		containerdb.HashBuilder,
+		scoredb.ArrayDBPrefix,
+		"proposal_pending",
+	)
+)
+
+func (s *State) GetProposal(id []byte) (*Proposal, error) {
+	dict := containerdb.NewDictDB(s.store, 1, proposalDictPrefix)
+	value := dict.Get(id)
+	if value == nil {
+		return nil, nil
+	}
+	p := new(Proposal)
+	if _, err := codec.BC.UnmarshalFromBytes(value.Bytes(), p); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidProposal")
+	}
+	return p, nil
+}
+
+func (s *State) SetProposal(p *Proposal) error {
+	bs, err := codec.BC.MarshalToBytes(p)
+	if err != nil {
+		return err
+	}
+	dict := containerdb.NewDictDB(s.store, 1, proposalDictPrefix)
+	return dict.Set(p.ID, bs)
+}
+
+// AddProposal stores a new proposal and adds it to the proposal list and
+// the pending list.
+func (s *State) AddProposal(p *Proposal) error {
+	if old, err := s.GetProposal(p.ID); err != nil {
+		return err
+	} else if old != nil {
+		return errors.IllegalArgumentError.Errorf("DuplicateProposal(id=%#x)", p.ID)
+	}
+	if err := s.SetProposal(p); err != nil {
+		return err
+	}
+	o := icobject.NewBytesObject(p.ID)
+	if err := containerdb.NewArrayDB(s.store, proposalArrayPrefix).Put(o); err != nil {
+		return err
+	}
+	return containerdb.NewArrayDB(s.store, pendingProposalArrayPrefix).Put(o)
+}
+
+func getProposalIDs(arr *containerdb.ArrayDB) [][]byte {
+	size := arr.Size()
+	ids := make([][]byte, size)
+	for i := 0; i < size; i++ {
+		ids[i] = arr.Get(i).Bytes()
+	}
+	return ids
+}
+
+// GetProposalIDs returns IDs of all proposals in registration order.
+func (s *State) GetProposalIDs() [][]byte {
+	return getProposalIDs(containerdb.NewArrayDB(s.store, proposalArrayPrefix))
+}
+
+// GetPendingProposalIDs returns IDs of proposals which are neither applied
+// nor closed in registration order.
+func (s *State) GetPendingProposalIDs() [][]byte {
+	return getProposalIDs(containerdb.NewArrayDB(s.store, pendingProposalArrayPrefix))
+}
+
+// RemovePendingProposal removes the proposal from the pending list keeping
+// the order of the others.
+func (s *State) RemovePendingProposal(id []byte) error {
+	arr := containerdb.NewArrayDB(s.store, pendingProposalArrayPrefix)
+	ids := getProposalIDs(arr)
+	for i, pid := range ids {
+		if !bytes.Equal(pid, id) {
+			continue
+		}
+		for j := i; j < len(ids)-1; j++ {
+			if err := arr.Set(j, icobject.NewBytesObject(ids[j+1])); err != nil {
+				return err
+			}
+		}
+		arr.Pop()
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+)
+
+func TestNewProposalCall(t *testing.T) {
+	tests := []struct {
+		pt     ProposalType
+		value  string
+		method string
+	}{
+		{ProposalTypeRevision, `{"revision":"0x15"}`, "setRevision"},
+		{ProposalTypeRevision, `{"revision":"0x15","extra":"0x1"}`, ""},
+		{ProposalTypeMaliciousScore, `{"address":"cx0000000000000000000000000000000000000001","type":"0x0"}`, "blockScore"},
+		{ProposalTypeMaliciousScore, `{"address":"cx0000000000000000000000000000000000000001","type":"0x1"}`, "unblockScore"},
+		{ProposalTypeMaliciousScore, `{"address":"cx0000000000000000000000000000000000000001","type":"0x2"}`, ""},
+		{ProposalTypeMaliciousScore, `{"address":"hx0000000000000000000000000000000000000001","type":"0x0"}`, ""},
+		{ProposalTypePRepDisqualification, `{"address":"hx0000000000000000000000000000000000000001"}`, "disqualifyPRep"},
+		{ProposalTypePRepDisqualification, `{"address":"cx0000000000000000000000000000000000000001"}`, ""},
+		{ProposalTypeStepPrice, `{"value":"0x2e90edd00"}`, "setStepPrice"},
+		{ProposalTypeStepPrice, `{"value":"-0x1"}`, ""},
+		{ProposalTypeStepPrice, `invalid`, ""},
+		{ProposalType(0), `{}`, ""},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d_%s", i, tt.pt), func(t *testing.T) {
+			call, err := NewProposalCall(tt.pt, []byte(tt.value))
+			if tt.method == "" {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.method, call.Method)
+		})
+	}
+}
+
+func newTestProposal(id string, voters int) *Proposal {
+	p := &Proposal{
+		ID:          []byte(id),
+		Proposer:    common.MustNewAddressFromString("hx1"),
+		Type:        int(ProposalTypeRevision),
+		Value:       []byte(`{"revision":"0x15"}`),
+		StartHeight: 10,
+		EndHeight:   20,
+		ApplyHeight: 30,
+	}
+	for i := 0; i < voters; i++ {
+		p.Voters = append(p.Voters, common.MustNewAddressFromString(fmt.Sprintf("hx%d", i+1)))
+	}
+	return p
+}
+
+func TestProposal_Vote(t *testing.T) {
+	p := newTestProposal("p1", 4)
+	assert.False(t, p.HasVoted(p.Voters[0]))
+	assert.False(t, p.IsVoter(common.MustNewAddressFromString("hx5")))
+
+	p.Vote(p.Voters[0], true)
+	p.Vote(p.Voters[1], true)
+	assert.True(t, p.HasVoted(p.Voters[0]))
+	assert.Equal(t, ProposalVoting, p.ProposalStatus())
+	p.Vote(p.Voters[2], true)
+	assert.Equal(t, ProposalApproved, p.ProposalStatus())
+
+	p = newTestProposal("p2", 4)
+	p.Vote(p.Voters[0], false)
+	assert.Equal(t, ProposalVoting, p.ProposalStatus())
+	p.Vote(p.Voters[1], false)
+	assert.Equal(t, ProposalDisapproved, p.ProposalStatus())
+
+	jso := p.ToJSON(15)
+	vote := jso["vote"].(map[string]interface{})
+	assert.EqualValues(t, 4, vote["voters"])
+	assert.EqualValues(t, 2, vote["noVote"])
+	assert.Len(t, vote["disagree"], 2)
+}
+
+func TestProposal_StatusAt(t *testing.T) {
+	p := newTestProposal("p1", 1)
+	assert.Equal(t, ProposalVoting, p.StatusAt(20))
+	assert.Equal(t, ProposalExpired, p.StatusAt(21))
+	p.Vote(p.Voters[0], true)
+	assert.Equal(t, ProposalApproved, p.StatusAt(21))
+}
+
+func TestState_Proposal(t *testing.T) {
+	s := newDummyState(false)
+
+	p1 := newTestProposal("p1", 3)
+	p2 := newTestProposal("p2", 3)
+	p3 := newTestProposal("p3", 3)
+	assert.NoError(t, s.AddProposal(p1))
+	assert.NoError(t, s.AddProposal(p2))
+	assert.NoError(t, s.AddProposal(p3))
+	assert.Error(t, s.AddProposal(p1))
+
+	p1.Vote(p1.Voters[0], true)
+	assert.NoError(t, s.SetProposal(p1))
+	p, err := s.GetProposal(p1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, p1, p)
+
+	p, err = s.GetProposal([]byte("unknown"))
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+
+	assert.NoError(t, s.RemovePendingProposal(p2.ID))
+	assert.Equal(t, [][]byte{p1.ID, p3.ID}, s.GetPendingProposalIDs())
+	assert.Equal(t, [][]byte{p1.ID, p2.ID, p3.ID}, s.GetProposalIDs())
+}
@@ -476,6 +476,12 @@ func (term *TermState) SetRrep(rrep *big.Int) {
 	term.rrep = rrep
 }
 
+func (term *TermState) SetIglobal(iglobal *big.Int) {
+	rf := term.rewardFund.Clone()
+	rf.Iglobal = iglobal
+	term.rewardFund = rf
+}
+
 func NewNextTerm(state *State, totalSupply *big.Int, revision int) *TermState {
 	ts := state.GetTermSnapshot()
 	if ts == nil {
@@ -23,6 +23,7 @@ import (
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/containerdb"
 	"github.com/icon-project/goloop/icon/iiss/icobject"
+	"github.com/icon-project/goloop/icon/iiss/icutils"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/scoredb"
 )
@@ -178,6 +179,15 @@ func (t *TimerSnapshot) Equal(object icobject.Impl) bool {
 type TimerState struct {
 	snapshot *TimerSnapshot
 	timerData
+
+	// index maps an address to its position in addresses. It's built on demand
+	// to avoid scanning all addresses for each job on a crowded timer.
+	index map[string]int
+
+	// deleted is the number of deleted addresses left as nil in addresses.
+	// They're removed keeping the order on making the snapshot, so deletion
+	// doesn't shift following addresses each time.
+	deleted int
 }
 
 func (t *TimerState) Reset(ts *TimerSnapshot) *TimerState {
@@ -186,9 +196,60 @@ func (t *TimerState) Reset(ts *TimerSnapshot) *TimerState {
 	}
 	t.snapshot = ts
 	t.timerData = ts.timerData.clone()
+	t.index = nil
+	t.deleted = 0
 	return t
 }
 
+func (t *TimerState) buildIndex() {
+	if t.index != nil {
+		return
+	}
+	t.index = make(map[string]int, len(t.addresses))
+	for i, a := range t.addresses {
+		if a != nil {
+			t.index[icutils.ToKey(a)] = i
+		}
+	}
+}
+
+func (t *TimerState) IndexOf(addr module.Address) int {
+	t.buildIndex()
+	if idx, ok := t.index[icutils.ToKey(addr)]; ok {
+		return idx
+	}
+	return -1
+}
+
+func (t *TimerState) Contains(addr module.Address) bool {
+	return t.IndexOf(addr) >= 0
+}
+
+func (t *TimerState) IsEmpty() bool {
+	return len(t.addresses) == t.deleted
+}
+
+func (t *TimerState) Iterator() TimerIterator {
+	t.compact()
+	return t.timerData.Iterator()
+}
+
+// compact removes deleted addresses keeping the order of others.
+func (t *TimerState) compact() {
+	if t.deleted == 0 {
+		return
+	}
+	addrs := make([]*common.Address, 0, len(t.addresses)-t.deleted)
+	for _, a := range t.addresses {
+		if a != nil {
+			addrs = append(addrs, a)
+		}
+	}
+	t.addresses = addrs
+	t.deleted = 0
+	t.index = nil
+}
+
 func (t *TimerState) setDirty() {
 	if t.snapshot != nil {
 		t.snapshot = nil
@@ -197,6 +258,7 @@ func (t *TimerState) setDirty() {
 
 func (t *TimerState) GetSnapshot() *TimerSnapshot {
 	if t.snapshot == nil {
+		t.compact()
 		t.snapshot = &TimerSnapshot{timerData: t.timerData.clone()}
 	}
 	return t.snapshot
@@ -205,13 +267,9 @@ func (t *TimerState) GetSnapshot() *TimerSnapshot {
 func (t *TimerState) Delete(address module.Address) {
 	idx := t.IndexOf(address)
 	if idx >= 0 {
-		l := len(t.addresses)
-		if idx+1 < l {
-			copy(t.addresses[idx:], t.addresses[idx+1:])
-		} else {
-			t.addresses[idx] = nil
-		}
-		t.addresses = t.addresses[0 : l-1]
+		t.addresses[idx] = nil
+		t.deleted += 1
+		delete(t.index, icutils.ToKey(address))
 		t.setDirty()
 	}
 }
@@ -220,6 +278,7 @@ func (t *TimerState) Add(address module.Address) {
 	if t.Contains(address) {
 		return
 	}
+	t.index[icutils.ToKey(address)] = len(t.addresses)
 	t.addresses = append(t.addresses, common.AddressToPtr(address))
 	t.setDirty()
 }
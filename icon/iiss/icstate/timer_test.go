@@ -24,6 +24,7 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/icon/iiss/icobject"
+	"github.com/icon-project/goloop/module"
 )
 
 func TestTimerSnapshot_Bytes(t *testing.T) {
@@ -97,3 +98,61 @@ func TestTimer_Delete(t *testing.T) {
 	timer.Delete(tc1[3])
 	assert.False(t, timer.Contains(tc1[3]))
 }
+
+func TestTimer_Index(t *testing.T) {
+	timer := newTimer()
+	addrs := []*common.Address{
+		common.NewAccountAddress([]byte("1")),
+		common.NewAccountAddress([]byte("2")),
+		common.NewAccountAddress([]byte("3")),
+	}
+	for _, a := range addrs {
+		timer.Add(a)
+	}
+	timer.Add(addrs[1])
+	assert.Equal(t, 3, len(timer.addresses))
+
+	// deleted one is left until the snapshot, so others are not shifted
+	timer.Delete(addrs[0])
+	assert.Equal(t, -1, timer.IndexOf(addrs[0]))
+	assert.Equal(t, 1, timer.IndexOf(addrs[1]))
+	assert.Equal(t, 2, timer.IndexOf(addrs[2]))
+	assert.False(t, timer.IsEmpty())
+
+	timer.Add(addrs[0])
+	assert.Equal(t, 3, timer.IndexOf(addrs[0]))
+
+	// the snapshot keeps the order of remaining ones
+	tss := timer.GetSnapshot()
+	assert.Equal(t, []*common.Address{addrs[1], addrs[2], addrs[0]}, tss.addresses)
+	assert.Equal(t, 0, timer.IndexOf(addrs[1]))
+	assert.Equal(t, 2, timer.IndexOf(addrs[0]))
+
+	// index is rebuilt from the snapshot
+	timer2 := NewTimerWithSnapshot(timer.GetSnapshot())
+	for i, a := range timer.addresses {
+		assert.Equal(t, i, timer2.IndexOf(a))
+	}
+}
+
+func TestTimer_DeleteAll(t *testing.T) {
+	timer := newTimer()
+	addrs := []*common.Address{
+		common.NewAccountAddress([]byte("1")),
+		common.NewAccountAddress([]byte("2")),
+	}
+	for _, a := range addrs {
+		timer.Add(a)
+	}
+	timer.Delete(addrs[1])
+	var got []module.Address
+	for itr := timer.Iterator(); itr.Has(); itr.Next() {
+		a, _ := itr.Get()
+		got = append(got, a)
+	}
+	assert.Equal(t, []module.Address{addrs[0]}, got)
+
+	timer.Delete(addrs[0])
+	assert.True(t, timer.IsEmpty())
+	assert.True(t, timer.GetSnapshot().IsEmpty())
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+type TreasuryEntryType int
+
+const (
+	TreasuryIssued TreasuryEntryType = iota
+	TreasuryFee
+	TreasuryBurned
+	TreasurySlashed
+)
+
+var (
+	treasuryAccountKey = containerdb.ToKey(
+		containerdb.HashBuilder,
+		scoredb.VarDBPrefix,
+		"treasury_account",
+	)
+	treasuryAccountDictPrefix = containerdb.ToKey(
+		containerdb.HashBuilder,
+		scoredb.DictDBPrefix,
+		"treasury_account_term",
+	)
+)
+
+// TreasuryAccount is the accounting of ICX issued to the treasury, paid to
+// the treasury as transaction fees and burned. Slashed is the part of Burned
+// which is slashed from bonders.
+type TreasuryAccount struct {
+	Issued  *big.Int
+	Fee     *big.Int
+	Burned  *big.Int
+	Slashed *big.Int
+}
+
+func NewTreasuryAccount() *TreasuryAccount {
+	return &TreasuryAccount{
+		Issued:  new(big.Int),
+		Fee:     new(big.Int),
+		Burned:  new(big.Int),
+		Slashed: new(big.Int),
+	}
+}
+
+func (a *TreasuryAccount) add(t TreasuryEntryType, amount *big.Int) error {
+	var v *big.Int
+	switch t {
+	case TreasuryIssued:
+		v = a.Issued
+	case TreasuryFee:
+		v = a.Fee
+	case TreasuryBurned:
+		v = a.Burned
+	case TreasurySlashed:
+		v = a.Slashed
+	default:
+		return errors.IllegalArgumentError.Errorf("InvalidTreasuryEntryType(type=%d)", int(t))
+	}
+	v.Add(v, amount)
+	return nil
+}
+
+func (a *TreasuryAccount) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"issued":  a.Issued,
+		"fee":     a.Fee,
+		"burned":  a.Burned,
+		"slashed": a.Slashed,
+	}
+}
+
+func decodeTreasuryAccount(bs []byte) (*TreasuryAccount, error) {
+	a := NewTreasuryAccount()
+	if len(bs) == 0 {
+		return a, nil
+	}
+	if _, err := codec.BC.UnmarshalFromBytes(bs, a); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidTreasuryAccount")
+	}
+	return a, nil
+}
+
+// GetTreasuryAccount returns the cumulative accounting since it's enabled.
+func (s *State) GetTreasuryAccount() (*TreasuryAccount, error) {
+	return decodeTreasuryAccount(containerdb.NewVarDB(s.store, treasuryAccountKey).Bytes())
+}
+
+// GetTreasuryAccountOfTerm returns the accounting of the term with the
+// sequence.
+func (s *State) GetTreasuryAccountOfTerm(sequence int64) (*TreasuryAccount, error) {
+	dict := containerdb.NewDictDB(s.store, 1, treasuryAccountDictPrefix)
+	value := dict.Get(sequence)
+	if value == nil {
+		return NewTreasuryAccount(), nil
+	}
+	return decodeTreasuryAccount(value.Bytes())
+}
+
+// AddTreasuryEntry adds the amount to both of the cumulative accounting and
+// the accounting of the term with the sequence.
+func (s *State) AddTreasuryEntry(sequence int64, t TreasuryEntryType, amount *big.Int) error {
+	if amount.Sign() < 0 {
+		return errors.IllegalArgumentError.Errorf("NegativeAmount(amount=%s)", amount)
+	}
+
+	total, err := s.GetTreasuryAccount()
+	if err != nil {
+		return err
+	}
+	if err = total.add(t, amount); err != nil {
+		return err
+	}
+	bs, err := codec.BC.MarshalToBytes(total)
+	if err != nil {
+		return err
+	}
+	if err = containerdb.NewVarDB(s.store, treasuryAccountKey).Set(bs); err != nil {
+		return err
+	}
+
+	account, err := s.GetTreasuryAccountOfTerm(sequence)
+	if err != nil {
+		return err
+	}
+	if err = account.add(t, amount); err != nil {
+		return err
+	}
+	if bs, err = codec.BC.MarshalToBytes(account); err != nil {
+		return err
+	}
+	dict := containerdb.NewDictDB(s.store, 1, treasuryAccountDictPrefix)
+	return dict.Set(sequence, bs)
+}
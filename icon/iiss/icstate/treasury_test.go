@@ -0,0 +1,63 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_TreasuryAccount(t *testing.T) {
+	s := newDummyState(false)
+
+	total, err := s.GetTreasuryAccount()
+	assert.NoError(t, err)
+	assert.Zero(t, total.Issued.Sign())
+
+	assert.NoError(t, s.AddTreasuryEntry(1, TreasuryIssued, big.NewInt(100)))
+	assert.NoError(t, s.AddTreasuryEntry(1, TreasuryFee, big.NewInt(10)))
+	assert.NoError(t, s.AddTreasuryEntry(2, TreasuryIssued, big.NewInt(200)))
+	assert.NoError(t, s.AddTreasuryEntry(2, TreasuryBurned, big.NewInt(30)))
+	assert.NoError(t, s.AddTreasuryEntry(2, TreasurySlashed, big.NewInt(20)))
+	assert.Error(t, s.AddTreasuryEntry(2, TreasuryFee, big.NewInt(-1)))
+	assert.Error(t, s.AddTreasuryEntry(2, TreasuryEntryType(10), big.NewInt(1)))
+
+	total, err = s.GetTreasuryAccount()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), total.Issued.Int64())
+	assert.Equal(t, int64(10), total.Fee.Int64())
+	assert.Equal(t, int64(30), total.Burned.Int64())
+	assert.Equal(t, int64(20), total.Slashed.Int64())
+
+	account, err := s.GetTreasuryAccountOfTerm(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), account.Issued.Int64())
+	assert.Equal(t, int64(10), account.Fee.Int64())
+	assert.Zero(t, account.Burned.Sign())
+
+	account, err = s.GetTreasuryAccountOfTerm(2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200), account.Issued.Int64())
+	assert.Zero(t, account.Fee.Sign())
+	assert.Equal(t, int64(20), account.Slashed.Int64())
+
+	account, err = s.GetTreasuryAccountOfTerm(3)
+	assert.NoError(t, err)
+	assert.Equal(t, NewTreasuryAccount(), account)
+}
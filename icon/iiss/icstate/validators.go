@@ -441,6 +441,9 @@ func (s *State) chooseNewMainPRep(prepSnapshots PRepSnapshots, startIdx int) (mo
 		case GradeMain:
 			return nil, -1
 		case GradeSub:
+			if ps.IsJailed() {
+				continue
+			}
 			return owner, i + 1
 		}
 	}
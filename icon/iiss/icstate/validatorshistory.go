@@ -0,0 +1,113 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package icstate
+
+import (
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+var validatorsHistoryKey = containerdb.ToKey(
+	containerdb.HashBuilder,
+	scoredb.VarDBPrefix,
+	"validators_history",
+)
+
+// ValidatorsRecord is the list of validator nodes signing blocks from Height
+// until the height of the next record.
+type ValidatorsRecord struct {
+	Height int64
+	Nodes  []*common.Address
+}
+
+func (r *ValidatorsRecord) Contains(node module.Address) bool {
+	for _, n := range r.Nodes {
+		if n.Equal(node) {
+			return true
+		}
+	}
+	return false
+}
+
+var validatorsHistorySchema = &containerdb.Schema[[]*ValidatorsRecord]{}
+
+func (s *State) validatorsHistoryDB() *containerdb.TypedVarDB[[]*ValidatorsRecord] {
+	return containerdb.NewTypedVarDB(s.store, validatorsHistoryKey, validatorsHistorySchema)
+}
+
+func (s *State) getValidatorsHistory() ([]*ValidatorsRecord, error) {
+	records, err := s.validatorsHistoryDB().Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "InvalidValidatorsHistory")
+	}
+	return records, nil
+}
+
+// HasValidatorsHistory returns whether any validators are recorded.
+func (s *State) HasValidatorsHistory() (bool, error) {
+	records, err := s.getValidatorsHistory()
+	if err != nil {
+		return false, err
+	}
+	return len(records) > 0, nil
+}
+
+// AddValidatorsRecord records validators signing blocks from the height.
+// Records which are not needed to get validators from minHeight are dropped.
+func (s *State) AddValidatorsRecord(height int64, nodes []module.Address, minHeight int64) error {
+	records, err := s.getValidatorsHistory()
+	if err != nil {
+		return err
+	}
+	for len(records) > 0 && records[len(records)-1].Height >= height {
+		records = records[:len(records)-1]
+	}
+	record := &ValidatorsRecord{
+		Height: height,
+		Nodes:  make([]*common.Address, len(nodes)),
+	}
+	for i, node := range nodes {
+		record.Nodes[i] = common.AddressToPtr(node)
+	}
+	records = append(records, record)
+
+	start := 0
+	for i := 1; i < len(records); i++ {
+		if records[i].Height <= minHeight {
+			start = i
+		}
+	}
+	return s.validatorsHistoryDB().Set(records[start:])
+}
+
+// GetValidatorsAt returns the record of validators signing the block at the
+// height. It returns nil if validators at the height are not recorded.
+func (s *State) GetValidatorsAt(height int64) (*ValidatorsRecord, error) {
+	records, err := s.getValidatorsHistory()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Height <= height {
+			return records[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,65 @@
+package icstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestState_ValidatorsHistory(t *testing.T) {
+	s := newDummyState(false)
+	n1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	n2 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	n3 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000003")
+
+	ok, err := s.HasValidatorsHistory()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	r, err := s.GetValidatorsAt(10)
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	assert.NoError(t, s.AddValidatorsRecord(10, []module.Address{n1, n2}, 0))
+	assert.NoError(t, s.AddValidatorsRecord(20, []module.Address{n1, n3}, 0))
+	s = flushAndNewState(s, false)
+
+	ok, err = s.HasValidatorsHistory()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	r, err = s.GetValidatorsAt(9)
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	r, err = s.GetValidatorsAt(19)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, r.Height)
+	assert.True(t, r.Contains(n2))
+	assert.False(t, r.Contains(n3))
+
+	r, err = s.GetValidatorsAt(20)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 20, r.Height)
+	assert.True(t, r.Contains(n1))
+	assert.True(t, r.Contains(n3))
+	assert.False(t, r.Contains(n2))
+
+	// the record at 20 is still needed for validators at 25
+	assert.NoError(t, s.AddValidatorsRecord(30, []module.Address{n2}, 25))
+	r, err = s.GetValidatorsAt(25)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 20, r.Height)
+	r, err = s.GetValidatorsAt(19)
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	// a record at the same height replaces the previous one
+	assert.NoError(t, s.AddValidatorsRecord(30, []module.Address{n3}, 25))
+	r, err = s.GetValidatorsAt(30)
+	assert.NoError(t, err)
+	assert.True(t, r.Contains(n3))
+	assert.False(t, r.Contains(n2))
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/icon/iiss/icstate"
+)
+
+// IssuePolicy decides reward variables of each term. The ICX issued for
+// each block and the IScore credited by the calculator are derived from the
+// same variables of the term, so that credited IScore is always backed by
+// issued ICX.
+type IssuePolicy interface {
+	// ApplyToTerm adjusts reward variables of the next term with the total
+	// supply at the beginning of the term.
+	ApplyToTerm(term *icstate.TermState, totalSupply *big.Int)
+}
+
+// NewIssuePolicy returns IssuePolicy for the parameters. The IISS formula
+// is used for unknown policies.
+//
+// Fixed and capped policies are applied only to the terms of IISS 3.x,
+// which issue ICX with Iglobal. For the terms of IISS 2.x, they use the
+// IISS formula, but capped policy stops rewards after the total supply
+// reaches the cap.
+func NewIssuePolicy(p *icstate.IssuePolicyParams) IssuePolicy {
+	switch p.Type {
+	case icstate.IssuePolicyFixed:
+		return &fixedIssuePolicy{amount: p.Amount}
+	case icstate.IssuePolicyCapped:
+		return &cappedIssuePolicy{cap: p.Cap}
+	case icstate.IssuePolicyNone:
+		return noneIssuePolicy{}
+	default:
+		return iissIssuePolicy{}
+	}
+}
+
+// setRewardPerBlock sets Iglobal of the term for the reward of each block.
+// Iglobal is the reward for MonthBlock blocks, and the reward of each block
+// is rounded up, so it uses the multiple of MonthBlock.
+func setRewardPerBlock(term *icstate.TermState, reward *big.Int) {
+	term.SetIglobal(new(big.Int).Mul(reward, big.NewInt(MonthBlock)))
+}
+
+func disableRewards(term *icstate.TermState) {
+	term.SetIrep(new(big.Int))
+	term.SetRrep(new(big.Int))
+	term.SetIglobal(new(big.Int))
+}
+
+type iissIssuePolicy struct{}
+
+func (iissIssuePolicy) ApplyToTerm(_ *icstate.TermState, _ *big.Int) {
+	// use values of the network
+}
+
+type noneIssuePolicy struct{}
+
+func (noneIssuePolicy) ApplyToTerm(term *icstate.TermState, _ *big.Int) {
+	disableRewards(term)
+}
+
+type fixedIssuePolicy struct {
+	amount *big.Int
+}
+
+func (p *fixedIssuePolicy) ApplyToTerm(term *icstate.TermState, _ *big.Int) {
+	if term.GetIISSVersion() != icstate.IISSVersion3 {
+		return
+	}
+	setRewardPerBlock(term, p.amount)
+}
+
+type cappedIssuePolicy struct {
+	cap *big.Int
+}
+
+func (p *cappedIssuePolicy) ApplyToTerm(term *icstate.TermState, totalSupply *big.Int) {
+	remains := new(big.Int).Sub(p.cap, totalSupply)
+	if remains.Sign() <= 0 {
+		disableRewards(term)
+		return
+	}
+	if term.GetIISSVersion() != icstate.IISSVersion3 {
+		return
+	}
+	maxReward := new(big.Int).Div(remains, big.NewInt(term.Period()))
+	if term.Iglobal().Cmp(new(big.Int).Mul(maxReward, big.NewInt(MonthBlock))) > 0 {
+		setRewardPerBlock(term, maxReward)
+	}
+}
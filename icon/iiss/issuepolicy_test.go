@@ -0,0 +1,219 @@
+package iiss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icreward"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
+	"github.com/icon-project/goloop/module"
+)
+
+func newTermForIssuePolicy(t *testing.T, revision int, period int64) *icstate.TermState {
+	s := icstate.NewStateFromSnapshot(icstate.NewSnapshot(db.NewMapDB(), nil), false, nil)
+	assert.NoError(t, s.SetTermPeriod(period))
+	assert.NoError(t, s.SetIRep(big.NewInt(1000)))
+	rf := icstate.NewRewardFund()
+	rf.Iglobal = big.NewInt(MonthBlock * 100)
+	rf.Iprep = big.NewInt(50)
+	rf.Ivoter = big.NewInt(50)
+	assert.NoError(t, s.SetRewardFund(rf))
+	term := icstate.GenesisTerm(s, 1, revision)
+	term.SetIrep(big.NewInt(1000))
+	term.SetRrep(big.NewInt(10))
+	return term
+}
+
+func TestIssuePolicy_ApplyToTerm(t *testing.T) {
+	const period = 100
+	tests := []struct {
+		name        string
+		params      *icstate.IssuePolicyParams
+		totalSupply int64
+		reward      int64
+	}{
+		{"IISS", icstate.NewIssuePolicyParams(), 0, 100},
+		{"None", &icstate.IssuePolicyParams{Type: icstate.IssuePolicyNone}, 0, 0},
+		{"Fixed",
+			&icstate.IssuePolicyParams{Type: icstate.IssuePolicyFixed, Amount: big.NewInt(10)},
+			0, 10},
+		{"FixedZero",
+			&icstate.IssuePolicyParams{Type: icstate.IssuePolicyFixed, Amount: new(big.Int)},
+			0, 0},
+		{"CappedUnderCap",
+			&icstate.IssuePolicyParams{Type: icstate.IssuePolicyCapped, Cap: big.NewInt(1_000_000)},
+			0, 100},
+		{"CappedNearCap",
+			&icstate.IssuePolicyParams{Type: icstate.IssuePolicyCapped, Cap: big.NewInt(1_000_000)},
+			1_000_000 - 5*period - 7, 5},
+		{"CappedReached",
+			&icstate.IssuePolicyParams{Type: icstate.IssuePolicyCapped, Cap: big.NewInt(1_000_000)},
+			1_000_000, 0},
+		{"CappedExceeded",
+			&icstate.IssuePolicyParams{Type: icstate.IssuePolicyCapped, Cap: big.NewInt(1_000_000)},
+			2_000_000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := newTermForIssuePolicy(t, icmodule.RevisionBTP2, period)
+			NewIssuePolicy(tt.params).ApplyToTerm(term, big.NewInt(tt.totalSupply))
+			ts := term.GetSnapshot()
+			reward := rewardV2(ts)
+			assert.EqualValues(t, tt.reward, reward.Int64())
+
+			// the calculator credits rewards with Iglobal of the term
+			assert.Zero(t, new(big.Int).Mod(ts.Iglobal(), big.NewInt(MonthBlock)).Sign())
+			issued := new(big.Int).Mul(reward, big.NewInt(period))
+			credited := new(big.Int).Div(
+				new(big.Int).Mul(ts.Iglobal(), big.NewInt(period)), big.NewInt(MonthBlock))
+			assert.Zero(t, issued.Cmp(credited))
+		})
+	}
+}
+
+func TestIssuePolicy_ApplyToTermIISS2(t *testing.T) {
+	const period = 100
+	none := &icstate.IssuePolicyParams{Type: icstate.IssuePolicyNone}
+	term := newTermForIssuePolicy(t, icmodule.RevisionDecentralize, period)
+	NewIssuePolicy(none).ApplyToTerm(term, big.NewInt(0))
+	prep, reward := rewardV1(term.GetSnapshot(), big.NewInt(1_000_000))
+	assert.Zero(t, reward.Sign())
+	assert.Zero(t, prep.GetIRep().Sign())
+	assert.Zero(t, prep.GetRRep().Sign())
+
+	fixed := &icstate.IssuePolicyParams{Type: icstate.IssuePolicyFixed, Amount: big.NewInt(10)}
+	term = newTermForIssuePolicy(t, icmodule.RevisionDecentralize, period)
+	NewIssuePolicy(fixed).ApplyToTerm(term, big.NewInt(0))
+	assert.EqualValues(t, 1000, term.Irep().Int64())
+	assert.EqualValues(t, 10, term.Rrep().Int64())
+
+	capped := &icstate.IssuePolicyParams{Type: icstate.IssuePolicyCapped, Cap: big.NewInt(1000)}
+	term = newTermForIssuePolicy(t, icmodule.RevisionDecentralize, period)
+	NewIssuePolicy(capped).ApplyToTerm(term, big.NewInt(1000))
+	assert.Zero(t, term.Irep().Sign())
+	assert.Zero(t, term.Rrep().Sign())
+}
+
+type claimContext struct {
+	icmodule.CallContext
+	from     module.Address
+	treasury module.Address
+	balances map[string]*big.Int
+}
+
+func (cc *claimContext) Revision() module.Revision {
+	return icmodule.ValueToRevision(icmodule.RevisionBTP2)
+}
+
+func (cc *claimContext) From() module.Address {
+	return cc.from
+}
+
+func (cc *claimContext) Treasury() module.Address {
+	return cc.treasury
+}
+
+func (cc *claimContext) balanceOf(addr module.Address) *big.Int {
+	if b, ok := cc.balances[addr.String()]; ok {
+		return b
+	}
+	return new(big.Int)
+}
+
+func (cc *claimContext) Transfer(from, to module.Address, amount *big.Int, _ module.OpType) error {
+	fb := cc.balanceOf(from)
+	if fb.Cmp(amount) < 0 {
+		return errors.InvalidStateError.Errorf("NotEnoughBalance(%s<%s)", fb, amount)
+	}
+	cc.balances[from.String()] = new(big.Int).Sub(fb, amount)
+	cc.balances[to.String()] = new(big.Int).Add(cc.balanceOf(to), amount)
+	return nil
+}
+
+func (cc *claimContext) OnEvent(_ module.Address, _, _ [][]byte) {}
+
+func TestIssuePolicy_Claim(t *testing.T) {
+	const period = 100
+	totalVoting := big.NewInt(1_000_000)
+	tests := []struct {
+		name        string
+		params      *icstate.IssuePolicyParams
+		totalSupply int64
+		issued      int64
+	}{
+		{"IISS", icstate.NewIssuePolicyParams(), 0, 100 * period},
+		{"None", &icstate.IssuePolicyParams{Type: icstate.IssuePolicyNone}, 0, 0},
+		{"Fixed",
+			&icstate.IssuePolicyParams{Type: icstate.IssuePolicyFixed, Amount: big.NewInt(10)},
+			0, 10 * period},
+		{"CappedNearCap",
+			&icstate.IssuePolicyParams{Type: icstate.IssuePolicyCapped, Cap: big.NewInt(1_000_000)},
+			1_000_000 - 5*period - 7, 5 * period},
+		{"CappedReached",
+			&icstate.IssuePolicyParams{Type: icstate.IssuePolicyCapped, Cap: big.NewInt(1_000_000)},
+			1_000_000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := NewExtensionSnapshot(db.NewMapDB(), nil).NewState(false).(*ExtensionStateImpl)
+			assert.NoError(t, es.State.SetTermPeriod(period))
+			rf := icstate.NewRewardFund()
+			rf.Iglobal = big.NewInt(MonthBlock * 100)
+			rf.Iprep = big.NewInt(50)
+			rf.Ivoter = big.NewInt(50)
+			assert.NoError(t, es.State.SetRewardFund(rf))
+			assert.NoError(t, es.State.SetIssuePolicy(tt.params))
+			term := icstate.GenesisTerm(es.State, 1, icmodule.RevisionBTP2)
+			term.SetIsDecentralized(true)
+			assert.NoError(t, es.State.SetTermSnapshot(term.GetSnapshot()))
+
+			totalSupply := big.NewInt(tt.totalSupply)
+			assert.NoError(t, es.moveOnToNextTerm(nil, totalSupply, icmodule.RevisionBTP2, 0))
+			assert.NoError(t, es.setNewFront())
+
+			// ICX issued to the treasury during the term
+			_, result := GetIssueData(es)
+			issued := new(big.Int).Mul(result.GetIssue(), big.NewInt(period))
+			assert.EqualValues(t, tt.issued, issued.Int64())
+
+			// IScore credited by the calculator with the same term
+			global, err := es.Front.GetGlobal()
+			assert.NoError(t, err)
+			multiplier, divider := varForVotedReward(global)
+			votedReward := new(big.Int).Mul(multiplier, big.NewInt(period))
+			votedReward.Div(votedReward, divider)
+			multiplier, divider = varForVotingReward(global, totalVoting)
+			votingReward := new(big.Int)
+			if divider.Sign() != 0 {
+				votingReward.Mul(multiplier, totalVoting)
+				votingReward.Mul(votingReward, big.NewInt(period))
+				votingReward.Div(votingReward, divider)
+			}
+
+			prep := common.MustNewAddressFromString("hx1")
+			voter := common.MustNewAddressFromString("hx2")
+			assert.NoError(t, es.Reward.SetIScore(prep, icreward.NewIScore(votedReward)))
+			assert.NoError(t, es.Reward.SetIScore(voter, icreward.NewIScore(votingReward)))
+
+			cc := &claimContext{
+				treasury: common.MustNewAddressFromString("hx1000"),
+				balances: make(map[string]*big.Int),
+			}
+			cc.balances[cc.treasury.String()] = new(big.Int).Set(issued)
+			for _, addr := range []module.Address{prep, voter} {
+				cc.from = addr
+				assert.NoError(t, es.ClaimIScore(cc))
+			}
+
+			// Iprep + Ivoter is 100, so claims consume exactly the issued ICX
+			claimed := new(big.Int).Add(cc.balanceOf(prep), cc.balanceOf(voter))
+			assert.Zero(t, claimed.Cmp(issued), "claimed=%s issued=%s", claimed, issued)
+		})
+	}
+}
@@ -235,18 +235,26 @@ func GetIssueData(es *ExtensionStateImpl) (*IssuePRepJSON, *IssueResultJSON) {
 	}
 	term := es.State.GetTermSnapshot()
 	issueInfo, _ := es.State.GetIssue()
+	var prep *IssuePRepJSON
+	var reward *big.Int
 	if term.GetIISSVersion() == icstate.IISSVersion2 {
-		return getIssueDataV1(es, term, es.State.GetTotalDelegation())
+		prep, reward = rewardV1(term, es.State.GetTotalDelegation())
 	} else {
-		return nil, getIssueDataV2(issueInfo, term)
+		reward = rewardV2(term)
 	}
+	issue, byOverIssued, byFee := calcIssueAmount(reward, issueInfo)
+	result := &IssueResultJSON{
+		ByFee:           icutils.BigInt2HexInt(byFee),
+		ByOverIssuedICX: icutils.BigInt2HexInt(byOverIssued),
+		Issue:           icutils.BigInt2HexInt(issue),
+	}
+	return prep, result
 }
 
-func getIssueDataV1(
-	es *ExtensionStateImpl,
+func rewardV1(
 	term *icstate.TermSnapshot,
 	totalDelegated *big.Int,
-) (*IssuePRepJSON, *IssueResultJSON) {
+) (*IssuePRepJSON, *big.Int) {
 	irep := term.Irep()
 	rrep := term.Rrep()
 	mainPRepCount := term.MainPRepCount()
@@ -262,18 +270,10 @@ func getIssueDataV1(
 		TotalDelegation: icutils.BigInt2HexInt(totalDelegated),
 		Value:           icutils.BigInt2HexInt(reward),
 	}
-
-	i, _ := es.State.GetIssue()
-	issue, byOverIssued, byFee := calcIssueAmount(reward, i)
-	result := &IssueResultJSON{
-		ByFee:           icutils.BigInt2HexInt(byFee),
-		ByOverIssuedICX: icutils.BigInt2HexInt(byOverIssued),
-		Issue:           icutils.BigInt2HexInt(issue),
-	}
-	return prep, result
+	return prep, reward
 }
 
-func getIssueDataV2(issueInfo *icstate.Issue, term *icstate.TermSnapshot) *IssueResultJSON {
+func rewardV2(term *icstate.TermSnapshot) *big.Int {
 	var reward, remains *big.Int
 	if term.Revision() < icmodule.RevisionFixIGlobal {
 		reward, remains = new(big.Int).DivMod(term.Iglobal(), big.NewInt(term.Period()), new(big.Int))
@@ -283,11 +283,5 @@ func getIssueDataV2(issueInfo *icstate.Issue, term *icstate.TermSnapshot) *Issue
 	if remains.Sign() == 1 {
 		reward.Add(reward, intconv.BigIntOne)
 	}
-	issue, byOverIssued, byFee := calcIssueAmount(reward, issueInfo)
-	result := &IssueResultJSON{
-		ByFee:           icutils.BigInt2HexInt(byFee),
-		ByOverIssuedICX: icutils.BigInt2HexInt(byOverIssued),
-		Issue:           icutils.BigInt2HexInt(issue),
-	}
-	return result
+	return reward
 }
@@ -17,14 +17,17 @@
 package iiss
 
 import (
+	"bytes"
 	"math/big"
 
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/icon/icmodule"
 	"github.com/icon-project/goloop/icon/iiss/icstage"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
 	"github.com/icon-project/goloop/icon/iiss/icutils"
 	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
 	"github.com/icon-project/goloop/service/state"
 )
 
@@ -59,20 +62,182 @@ func (es *ExtensionStateImpl) handlePenalty(cc icmodule.CallContext, owner modul
 
 	// Slashing
 	revision := cc.Revision().Value()
+	slashed := new(big.Int)
 	if es.State.CheckConsistentValidationPenalty(revision, ps) {
 		slashRatio := es.State.GetConsistentValidationPenaltySlashRatio()
-		if err = es.slash(cc, owner, slashRatio); err != nil {
+		if slashed, err = es.slash(cc, owner, slashRatio); err != nil {
 			return err
 		}
+		es.jail(cc, owner, ps)
+	}
+	if err = es.recordPenalty(cc, owner, icmodule.PenaltyBlockValidation, 0, slashed); err != nil {
+		return err
 	}
 
 	// Record event for reward calculation
 	return es.addEventEnable(blockHeight, owner, icstage.ESDisableTemp)
 }
 
-func (es *ExtensionStateImpl) slash(cc icmodule.CallContext, owner module.Address, ratio int) error {
+// HandleDoubleSign imposes the penalty for double sign of the node at the
+// height. The evidence is accepted only within a term period, and only once
+// for each height. The node must be one of the validators of the block at
+// the height, and one of blockIDs, IDs of blocks voted by the node, must be
+// the ID of the block at the height, so votes for other networks are not
+// accepted.
+func (es *ExtensionStateImpl) HandleDoubleSign(
+	cc icmodule.CallContext, node module.Address, height int64, blockIDs [][]byte,
+) error {
+	blockHeight := cc.BlockHeight()
+	if height >= blockHeight || blockHeight-height > es.State.GetTermPeriod() {
+		return scoreresult.InvalidParameterError.Errorf(
+			"InvalidEvidenceHeight(height=%d,blockHeight=%d)", height, blockHeight)
+	}
+	id := es.State.GetBlockID(height)
+	if id == nil {
+		return scoreresult.InvalidParameterError.Errorf(
+			"UnknownBlock(height=%d)", height)
+	}
+	voted := false
+	for _, bid := range blockIDs {
+		if bytes.Equal(bid, id) {
+			voted = true
+			break
+		}
+	}
+	if !voted {
+		return scoreresult.InvalidParameterError.Errorf(
+			"NoVoteForBlock(height=%d,id=%#x)", height, id)
+	}
+	validators, err := es.State.GetValidatorsAt(height)
+	if err != nil {
+		return err
+	}
+	if validators == nil {
+		return scoreresult.InvalidParameterError.Errorf(
+			"UnknownValidators(height=%d)", height)
+	}
+	if !validators.Contains(node) {
+		return scoreresult.InvalidParameterError.Errorf(
+			"NotValidator(node=%s,height=%d)", node, height)
+	}
+	owner := es.State.GetOwnerByNode(node)
+	ps := es.State.GetPRepStatusByOwner(owner, false)
+	if ps == nil || !ps.IsActive() {
+		return scoreresult.InvalidParameterError.Errorf("NotActivePRep(node=%s)", node)
+	}
+	if penalized, err := es.State.HasDoubleSignPenalty(owner, height); err != nil {
+		return err
+	} else if penalized {
+		return scoreresult.InvalidParameterError.Errorf(
+			"AlreadyPenalized(owner=%s,height=%d)", owner, height)
+	}
+
+	if !ps.IsAlreadyPenalized() {
+		if err := es.State.ImposePenalty(owner, ps, blockHeight); err != nil {
+			return err
+		}
+		if err := es.addEventEnable(blockHeight, owner, icstage.ESDisableTemp); err != nil {
+			return err
+		}
+	}
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte("PenaltyImposed(Address,int,int)"), owner.Bytes()},
+		[][]byte{
+			intconv.Int64ToBytes(int64(ps.Status())),
+			intconv.Int64ToBytes(int64(icmodule.PenaltyDoubleSign)),
+		},
+	)
+	slashed, err := es.slash(cc, owner, es.State.GetDoubleSignPenaltySlashRatio())
+	if err != nil {
+		return err
+	}
+	es.jail(cc, owner, ps)
+	return es.recordPenalty(cc, owner, icmodule.PenaltyDoubleSign, height, slashed)
+}
+
+// jail jails the P-Rep since RevisionJail, so it's not elected on term end
+// until it's released by unjail request.
+func (es *ExtensionStateImpl) jail(cc icmodule.CallContext, owner module.Address, ps *icstate.PRepStatusState) {
+	if cc.Revision().Value() < icmodule.RevisionJail {
+		return
+	}
+	blockHeight := cc.BlockHeight()
+	ps.Jail(blockHeight)
+
+	// Record PRepJailed eventlog with the height from which unjail is allowed
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte("PRepJailed(Address,int)"), owner.Bytes()},
+		[][]byte{intconv.Int64ToBytes(blockHeight + es.State.GetUnjailPeriod())},
+	)
+}
+
+// RequestUnjail handles the unjail request of the P-Rep owned by the sender.
+// The request is allowed after the unjail period from jailing, and value
+// should be the same as the unjail fee, which is burned.
+// The P-Rep is released on the next term end.
+func (es *ExtensionStateImpl) RequestUnjail(cc icmodule.CallContext, value *big.Int) error {
+	owner := cc.From()
+	ps := es.State.GetPRepStatusByOwner(owner, false)
+	if ps == nil || !ps.IsActive() {
+		return scoreresult.InvalidParameterError.Errorf("NotActivePRep(owner=%s)", owner)
+	}
+	if !ps.IsJailed() {
+		return scoreresult.InvalidRequestError.Errorf(
+			"NotInJail(owner=%s,state=%s)", owner, ps.JailState())
+	}
+	blockHeight := cc.BlockHeight()
+	unjailHeight := ps.JailHeight() + es.State.GetUnjailPeriod()
+	if blockHeight < unjailHeight {
+		return scoreresult.InvalidRequestError.Errorf(
+			"UnjailNotAllowedYet(blockHeight=%d,unjailHeight=%d)", blockHeight, unjailHeight)
+	}
+	fee := es.State.GetUnjailFee()
+	if value.Cmp(fee) != 0 {
+		return scoreresult.InvalidParameterError.Errorf(
+			"InvalidUnjailFee(value=%v,fee=%v)", value, fee)
+	}
+
+	// Subtract unjail fee from SystemAddress and burn it
+	if err := cc.Withdraw(state.SystemAddress, fee, module.Burn); err != nil {
+		return err
+	}
+	if err := cc.HandleBurn(owner, fee); err != nil {
+		return scoreresult.UnknownFailureError.Wrapf(
+			err, "Failed to burn unjailFee: from=%v fee=%v", owner, fee)
+	}
+	jailHeight := ps.JailHeight()
+	if err := ps.Unjail(); err != nil {
+		return err
+	}
+
+	// Record PRepUnjailed eventlog with the height when the P-Rep got jailed
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte("PRepUnjailed(Address,int)"), owner.Bytes()},
+		[][]byte{intconv.Int64ToBytes(jailHeight)},
+	)
+	return nil
+}
+
+// recordPenalty adds the penalty to the penalty history of the P-Rep.
+func (es *ExtensionStateImpl) recordPenalty(
+	cc icmodule.CallContext, owner module.Address, pt icmodule.PenaltyType, height int64, slashed *big.Int,
+) error {
+	if cc.Revision().Value() < icmodule.RevisionPenaltyHistory {
+		return nil
+	}
+	return es.State.AddPenaltyRecord(owner, &icstate.PenaltyRecord{
+		BlockHeight:    cc.BlockHeight(),
+		Type:           int(pt),
+		EvidenceHeight: height,
+		Slashed:        slashed,
+	})
+}
+
+// slash slashes bonds of the P-Rep by the ratio, and returns the amount of
+// slashed stakes.
+func (es *ExtensionStateImpl) slash(cc icmodule.CallContext, owner module.Address, ratio int) (*big.Int, error) {
 	if ratio < 0 || 100 < ratio {
-		return errors.Errorf("Invalid slash ratio %d", ratio)
+		return nil, errors.Errorf("Invalid slash ratio %d", ratio)
 	}
 
 	logger := cc.FrameLogger()
@@ -80,7 +245,7 @@ func (es *ExtensionStateImpl) slash(cc icmodule.CallContext, owner module.Addres
 
 	pb := es.State.GetPRepBaseByOwner(owner, false)
 	if pb == nil {
-		return errors.Errorf("PRep not found: %s", owner)
+		return nil, errors.Errorf("PRep not found: %s", owner)
 	}
 	bonders := pb.BonderList()
 	slashedBondSum := new(big.Int)
@@ -97,6 +262,10 @@ func (es *ExtensionStateImpl) slash(cc icmodule.CallContext, owner module.Addres
 		account := es.State.GetAccountState(bonder)
 
 		if ratio > 0 {
+			oldBonds := account.Bonds()
+			oldUnbond := account.Unbond()
+			oldStake := account.Stake()
+
 			// bond
 			slashedBond = account.SlashBond(owner, ratio)
 			slashedBondSum.Add(slashedBondSum, slashedBond)
@@ -108,14 +277,14 @@ func (es *ExtensionStateImpl) slash(cc icmodule.CallContext, owner module.Addres
 				if timer != nil {
 					timer.Delete(owner)
 				} else {
-					return errors.Errorf("timer doesn't exist for height %d", expire)
+					return nil, errors.Errorf("timer doesn't exist for height %d", expire)
 				}
 			}
 
 			// stake
 			slashedStake.Add(slashedBond, slashedUnbond)
 			if err := account.SlashStake(slashedStake); err != nil {
-				return err
+				return nil, err
 			}
 			slashedStakeSum.Add(slashedStakeSum, slashedStake)
 
@@ -124,8 +293,11 @@ func (es *ExtensionStateImpl) slash(cc icmodule.CallContext, owner module.Addres
 				icutils.ToKey(owner): new(big.Int).Neg(slashedBond),
 			}
 			if err := es.AddEventBond(cc.BlockHeight(), bonder, delta); err != nil {
-				return err
+				return nil, err
 			}
+			onBondChanged(cc, bonder, oldBonds, account.Bonds())
+			onUnbondChanged(cc, bonder, oldUnbond, account.Unbond())
+			onStakeChanged(cc, bonder, oldStake, account.Stake())
 		}
 
 		// Record Slashed eventlog
@@ -145,16 +317,19 @@ func (es *ExtensionStateImpl) slash(cc icmodule.CallContext, owner module.Addres
 	oldTotalStake := es.State.GetTotalStake()
 	newTotalStake := new(big.Int).Sub(oldTotalStake, slashedStakeSum)
 	if err := es.State.SetTotalStake(newTotalStake); err != nil {
-		return err
+		return nil, err
 	}
 	if err := es.State.ReducePRepBonded(owner, slashedBondSum); err != nil {
-		return err
+		return nil, err
 	}
 	err := cc.HandleBurn(state.SystemAddress, slashedStakeSum)
+	if err == nil {
+		err = es.AddTreasuryEntry(cc, icstate.TreasurySlashed, slashedStakeSum)
+	}
 
 	logger.TSystemf(
 		"IISS slash end owner=%s slashedBondSum=%v slashedStakeSum=%v oldTotalStake=%v newTotalStake=%v",
 		owner, slashedBondSum, slashedStakeSum, oldTotalStake, newTotalStake,
 	)
-	return err
+	return slashedStakeSum, err
 }
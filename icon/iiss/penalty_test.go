@@ -0,0 +1,72 @@
+package iiss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
+)
+
+type penaltyContext struct {
+	icmodule.CallContext
+	blockHeight int64
+}
+
+func (cc *penaltyContext) BlockHeight() int64 {
+	return cc.blockHeight
+}
+
+func TestExtensionState_HandleDoubleSignValidators(t *testing.T) {
+	es := NewExtensionSnapshot(db.NewMapDB(), nil).NewState(false).(*ExtensionStateImpl)
+	assert.NoError(t, es.State.SetTermPeriod(100))
+	o1 := common.MustNewAddressFromString("hx1")
+	o2 := common.MustNewAddressFromString("hx2")
+	pss := icstate.PRepSnapshots{
+		icstate.NewPRepSnapshot(o1, big.NewInt(100)),
+		icstate.NewPRepSnapshot(o2, big.NewInt(100)),
+	}
+	// only o1 is the validator
+	vss := icstate.NewValidatorsSnapshotWithPRepSnapshot(pss, es.State, 1)
+
+	// validators are recorded from two blocks later, and only once if they're
+	// not updated
+	assert.NoError(t, es.recordValidators(10, vss, false))
+	assert.NoError(t, es.recordValidators(11, vss, false))
+	r, err := es.State.GetValidatorsAt(100)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 12, r.Height)
+
+	for h := int64(11); h <= 12; h++ {
+		assert.NoError(t, es.State.AddBlockID(h, []byte{byte(h)}, 0))
+	}
+	cc := &penaltyContext{blockHeight: 20}
+	err = es.HandleDoubleSign(cc, o1, 11, [][]byte{{11}, {99}})
+	assert.ErrorContains(t, err, "UnknownValidators")
+
+	err = es.HandleDoubleSign(cc, o2, 12, [][]byte{{12}, {99}})
+	assert.ErrorContains(t, err, "NotValidator")
+
+	// the validator passes the check, but it's not an active P-Rep
+	err = es.HandleDoubleSign(cc, o1, 12, [][]byte{{99}, {12}})
+	assert.ErrorContains(t, err, "NotActivePRep")
+}
+
+func TestExtensionState_HandleDoubleSignOtherNetwork(t *testing.T) {
+	es := NewExtensionSnapshot(db.NewMapDB(), nil).NewState(false).(*ExtensionStateImpl)
+	assert.NoError(t, es.State.SetTermPeriod(100))
+	node := common.MustNewAddressFromString("hx1")
+	assert.NoError(t, es.State.AddBlockID(12, []byte("block12"), 0))
+	cc := &penaltyContext{blockHeight: 20}
+
+	// conflicting votes of the node for blocks of another network
+	err := es.HandleDoubleSign(cc, node, 12, [][]byte{[]byte("other1"), []byte("other2")})
+	assert.ErrorContains(t, err, "NoVoteForBlock")
+
+	err = es.HandleDoubleSign(cc, node, 13, [][]byte{[]byte("other1"), []byte("block12")})
+	assert.ErrorContains(t, err, "UnknownBlock")
+}
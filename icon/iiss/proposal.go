@@ -0,0 +1,257 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// ProposalVotingPeriod is the number of blocks for voting on a proposal.
+const ProposalVotingPeriod = icmodule.DayBlock * 14
+
+const (
+	sigProposalRegistered = "ProposalRegistered(bytes,Address,int)"
+	sigProposalVoted      = "ProposalVoted(bytes,Address,bool)"
+	sigProposalStatus     = "ProposalStatusChanged(bytes,int)"
+)
+
+func (es *ExtensionStateImpl) getMainPReps() []*common.Address {
+	term := es.State.GetTermSnapshot()
+	if term == nil || !term.IsDecentralized() {
+		return nil
+	}
+	mainPRepCount := term.MainPRepCount()
+	preps := make([]*common.Address, 0, mainPRepCount)
+	for i := 0; i < term.GetPRepSnapshotCount() && len(preps) < mainPRepCount; i++ {
+		owner := term.GetPRepSnapshotByIndex(i).Owner()
+		ps := es.State.GetPRepStatusByOwner(owner, false)
+		if ps != nil && ps.Grade() == icstate.GradeMain {
+			preps = append(preps, common.AddressToPtr(owner))
+		}
+	}
+	return preps
+}
+
+func containsAddress(addrs []*common.Address, addr module.Address) bool {
+	for _, a := range addrs {
+		if a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func boolToBytes(v bool) []byte {
+	if v {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+func onProposalStatusChanged(cc icmodule.CallContext, p *icstate.Proposal) {
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte(sigProposalStatus), p.ID},
+		[][]byte{intconv.Int64ToBytes(int64(p.Status))},
+	)
+}
+
+// RegisterProposal registers a network proposal of the sender, which must be
+// a main P-Rep. The transaction hash is used as its ID.
+func (es *ExtensionStateImpl) RegisterProposal(
+	cc icmodule.CallContext, pt icstate.ProposalType, value []byte, applyHeight int64,
+) ([]byte, error) {
+	from := cc.From()
+	voters := es.getMainPReps()
+	if !containsAddress(voters, from) {
+		return nil, scoreresult.AccessDeniedError.Errorf("NotMainPRep(address=%s)", from)
+	}
+	if _, err := icstate.NewProposalCall(pt, value); err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrap(err, "InvalidProposal")
+	}
+	blockHeight := cc.BlockHeight()
+	if applyHeight <= blockHeight {
+		return nil, scoreresult.InvalidParameterError.Errorf(
+			"InvalidApplyHeight(applyHeight=%d,blockHeight=%d)", applyHeight, blockHeight)
+	}
+	p := &icstate.Proposal{
+		ID:          cc.TransactionID(),
+		Proposer:    common.AddressToPtr(from),
+		Type:        int(pt),
+		Value:       value,
+		StartHeight: blockHeight,
+		EndHeight:   blockHeight + ProposalVotingPeriod,
+		ApplyHeight: applyHeight,
+		Status:      int(icstate.ProposalVoting),
+		Voters:      voters,
+	}
+	if err := es.State.AddProposal(p); err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrap(err, "InvalidProposal")
+	}
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte(sigProposalRegistered), p.ID, from.Bytes()},
+		[][]byte{intconv.Int64ToBytes(int64(pt))},
+	)
+	return p.ID, nil
+}
+
+func (es *ExtensionStateImpl) getVotingProposal(cc icmodule.CallContext, id []byte) (*icstate.Proposal, error) {
+	p, err := es.State.GetProposal(id)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, scoreresult.InvalidParameterError.Errorf("ProposalNotFound(id=%#x)", id)
+	}
+	if status := p.StatusAt(cc.BlockHeight()); status != icstate.ProposalVoting {
+		return nil, scoreresult.InvalidRequestError.Errorf(
+			"NotInVoting(id=%#x,status=%s)", id, status)
+	}
+	return p, nil
+}
+
+// VoteProposal records the vote of the sender, which must be one of the
+// voters of the proposal. Each voter can vote only once.
+func (es *ExtensionStateImpl) VoteProposal(cc icmodule.CallContext, id []byte, agree bool) error {
+	p, err := es.getVotingProposal(cc, id)
+	if err != nil {
+		return err
+	}
+	from := cc.From()
+	if !p.IsVoter(from) {
+		return scoreresult.AccessDeniedError.Errorf("NotVoter(address=%s)", from)
+	}
+	if p.HasVoted(from) {
+		return scoreresult.InvalidRequestError.Errorf("AlreadyVoted(address=%s)", from)
+	}
+	p.Vote(from, agree)
+	if err = es.State.SetProposal(p); err != nil {
+		return err
+	}
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte(sigProposalVoted), p.ID, from.Bytes()},
+		[][]byte{boolToBytes(agree)},
+	)
+	switch p.ProposalStatus() {
+	case icstate.ProposalApproved:
+		onProposalStatusChanged(cc, p)
+	case icstate.ProposalDisapproved:
+		onProposalStatusChanged(cc, p)
+		return es.State.RemovePendingProposal(p.ID)
+	}
+	return nil
+}
+
+// CancelProposal cancels the proposal in voting. Only the proposer can
+// cancel it.
+func (es *ExtensionStateImpl) CancelProposal(cc icmodule.CallContext, id []byte) error {
+	p, err := es.getVotingProposal(cc, id)
+	if err != nil {
+		return err
+	}
+	if from := cc.From(); !p.Proposer.Equal(from) {
+		return scoreresult.AccessDeniedError.Errorf("NotProposer(address=%s)", from)
+	}
+	p.Status = int(icstate.ProposalCanceled)
+	if err = es.State.SetProposal(p); err != nil {
+		return err
+	}
+	onProposalStatusChanged(cc, p)
+	return es.State.RemovePendingProposal(p.ID)
+}
+
+func (es *ExtensionStateImpl) GetProposalInJSON(id []byte, blockHeight int64) (map[string]interface{}, error) {
+	p, err := es.State.GetProposal(id)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, scoreresult.InvalidParameterError.Errorf("ProposalNotFound(id=%#x)", id)
+	}
+	return p.ToJSON(blockHeight), nil
+}
+
+// GetProposalsInJSON returns the proposals in registration order. If status
+// is not negative, only the proposals in the status are returned.
+func (es *ExtensionStateImpl) GetProposalsInJSON(status int, blockHeight int64) ([]interface{}, error) {
+	proposals := make([]interface{}, 0)
+	for _, id := range es.State.GetProposalIDs() {
+		p, err := es.State.GetProposal(id)
+		if err != nil {
+			return nil, err
+		}
+		if status >= 0 && p.StatusAt(blockHeight) != icstate.ProposalStatus(status) {
+			continue
+		}
+		proposals = append(proposals, p.ToJSON(blockHeight))
+	}
+	return proposals, nil
+}
+
+// handleProposals applies approved proposals whose apply height is reached,
+// and closes expired ones. Pending proposals are handled in registration
+// order, so the result is deterministic.
+func (es *ExtensionStateImpl) handleProposals(cc icmodule.CallContext) error {
+	blockHeight := cc.BlockHeight()
+	for _, id := range es.State.GetPendingProposalIDs() {
+		p, err := es.State.GetProposal(id)
+		if err != nil {
+			return err
+		}
+		switch p.StatusAt(blockHeight) {
+		case icstate.ProposalApproved:
+			if blockHeight < p.ApplyHeight {
+				continue
+			}
+			p.Status = int(es.applyProposal(cc, p))
+		case icstate.ProposalExpired:
+			p.Status = int(icstate.ProposalExpired)
+		default:
+			continue
+		}
+		if err = es.State.SetProposal(p); err != nil {
+			return err
+		}
+		onProposalStatusChanged(cc, p)
+		if err = es.State.RemovePendingProposal(p.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (es *ExtensionStateImpl) applyProposal(cc icmodule.CallContext, p *icstate.Proposal) icstate.ProposalStatus {
+	call, err := icstate.NewProposalCall(p.ProposalType(), p.Value)
+	if err == nil {
+		err = cc.CallSystemByGovernance(call.Method, call.Params)
+	}
+	if err != nil {
+		es.logger.Warnf("Failed to apply proposal id=%#x type=%s err=%+v", p.ID, p.ProposalType(), err)
+		return icstate.ProposalFailed
+	}
+	es.logger.Infof("Proposal applied id=%#x type=%s", p.ID, p.ProposalType())
+	return icstate.ProposalApplied
+}
+
+func isProposalEnabled(cc icmodule.CallContext) bool {
+	return cc.Revision().Value() >= icmodule.RevisionNetworkProposal
+}
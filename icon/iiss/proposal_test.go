@@ -0,0 +1,263 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
+	"github.com/icon-project/goloop/module"
+)
+
+type proposalCall struct {
+	method string
+	params interface{}
+}
+
+type proposalContext struct {
+	icmodule.CallContext
+	from        module.Address
+	blockHeight int64
+	txID        []byte
+	events      []string
+	calls       []proposalCall
+	callErr     error
+}
+
+func (cc *proposalContext) From() module.Address {
+	return cc.from
+}
+
+func (cc *proposalContext) BlockHeight() int64 {
+	return cc.blockHeight
+}
+
+func (cc *proposalContext) TransactionID() []byte {
+	return cc.txID
+}
+
+func (cc *proposalContext) OnEvent(addr module.Address, indexed, data [][]byte) {
+	cc.events = append(cc.events, string(indexed[0]))
+}
+
+func (cc *proposalContext) CallSystemByGovernance(method string, params interface{}) error {
+	cc.calls = append(cc.calls, proposalCall{method, params})
+	return cc.callErr
+}
+
+var proposalTestPReps = []module.Address{
+	common.MustNewAddressFromString("hx1"),
+	common.MustNewAddressFromString("hx2"),
+	common.MustNewAddressFromString("hx3"),
+	common.MustNewAddressFromString("hx4"),
+	common.MustNewAddressFromString("hx5"),
+}
+
+// newProposalTestState returns the state with main P-Reps of
+// proposalTestPReps except the last one, which is a sub P-Rep.
+func newProposalTestState(t *testing.T) *ExtensionStateImpl {
+	es := NewExtensionSnapshot(db.NewMapDB(), nil).NewState(false).(*ExtensionStateImpl)
+	pss := make(icstate.PRepSnapshots, 0, len(proposalTestPReps))
+	for i, owner := range proposalTestPReps {
+		pss = append(pss, icstate.NewPRepSnapshot(owner, big.NewInt(100)))
+		grade := icstate.GradeMain
+		if i == len(proposalTestPReps)-1 {
+			grade = icstate.GradeSub
+		}
+		ps := es.State.GetPRepStatusByOwner(owner, true)
+		assert.NoError(t, ps.OnTermEnd(grade, 0))
+	}
+	term := icstate.GenesisTerm(es.State, 1, icmodule.RevisionNetworkProposal)
+	term.SetIsDecentralized(true)
+	term.SetMainPRepCount(len(proposalTestPReps) - 1)
+	term.SetPRepSnapshots(pss)
+	assert.NoError(t, es.State.SetTermSnapshot(term.GetSnapshot()))
+	return es
+}
+
+func registerTestProposal(t *testing.T, es *ExtensionStateImpl, id string, applyHeight int64) []byte {
+	cc := &proposalContext{from: proposalTestPReps[0], blockHeight: 10, txID: []byte(id)}
+	pid, err := es.RegisterProposal(cc, icstate.ProposalTypeStepPrice,
+		[]byte(`{"value":"0x2e90edd00"}`), applyHeight)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{sigProposalRegistered}, cc.events)
+	return pid
+}
+
+func voteTestProposal(es *ExtensionStateImpl, id []byte, voter int, agree bool, height int64) (*proposalContext, error) {
+	cc := &proposalContext{from: proposalTestPReps[voter], blockHeight: height}
+	return cc, es.VoteProposal(cc, id, agree)
+}
+
+func proposalStatusOf(t *testing.T, es *ExtensionStateImpl, id []byte) icstate.ProposalStatus {
+	p, err := es.State.GetProposal(id)
+	assert.NoError(t, err)
+	return p.ProposalStatus()
+}
+
+func TestExtensionState_RegisterProposal(t *testing.T) {
+	es := newProposalTestState(t)
+	value := []byte(`{"value":"0x2e90edd00"}`)
+
+	// only main P-Reps can register
+	cc := &proposalContext{from: proposalTestPReps[4], blockHeight: 10, txID: []byte("tx1")}
+	_, err := es.RegisterProposal(cc, icstate.ProposalTypeStepPrice, value, 100)
+	assert.ErrorContains(t, err, "NotMainPRep")
+
+	cc = &proposalContext{from: proposalTestPReps[0], blockHeight: 10, txID: []byte("tx1")}
+	_, err = es.RegisterProposal(cc, icstate.ProposalTypeStepPrice, []byte(`{"value":"-0x1"}`), 100)
+	assert.ErrorContains(t, err, "InvalidProposal")
+	_, err = es.RegisterProposal(cc, icstate.ProposalTypeStepPrice, value, 10)
+	assert.ErrorContains(t, err, "InvalidApplyHeight")
+
+	id := registerTestProposal(t, es, "tx1", 100)
+	p, err := es.State.GetProposal(id)
+	assert.NoError(t, err)
+	assert.Len(t, p.Voters, 4)
+	assert.EqualValues(t, 10+ProposalVotingPeriod, p.EndHeight)
+	assert.Equal(t, [][]byte{id}, es.State.GetPendingProposalIDs())
+}
+
+func TestExtensionState_ProposalApproval(t *testing.T) {
+	es := newProposalTestState(t)
+	id := registerTestProposal(t, es, "tx1", 100)
+
+	cc, err := voteTestProposal(es, id, 0, true, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{sigProposalVoted}, cc.events)
+	assert.Equal(t, icstate.ProposalVoting, proposalStatusOf(t, es, id))
+
+	// double voting
+	_, err = voteTestProposal(es, id, 0, false, 12)
+	assert.ErrorContains(t, err, "AlreadyVoted")
+
+	// sub P-Rep isn't a voter
+	_, err = voteTestProposal(es, id, 4, true, 12)
+	assert.ErrorContains(t, err, "NotVoter")
+
+	// more than 2/3 of voters shall agree
+	_, err = voteTestProposal(es, id, 1, true, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, icstate.ProposalVoting, proposalStatusOf(t, es, id))
+	cc, err = voteTestProposal(es, id, 2, true, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{sigProposalVoted, sigProposalStatus}, cc.events)
+	assert.Equal(t, icstate.ProposalApproved, proposalStatusOf(t, es, id))
+
+	// the rest can't vote after approval
+	_, err = voteTestProposal(es, id, 3, true, 13)
+	assert.ErrorContains(t, err, "NotInVoting")
+
+	// it's applied at the apply height
+	hc := &proposalContext{blockHeight: 99}
+	assert.NoError(t, es.handleProposals(hc))
+	assert.Len(t, hc.calls, 0)
+	assert.Equal(t, icstate.ProposalApproved, proposalStatusOf(t, es, id))
+
+	hc = &proposalContext{blockHeight: 100}
+	assert.NoError(t, es.handleProposals(hc))
+	if assert.Len(t, hc.calls, 1) {
+		assert.Equal(t, "setStepPrice", hc.calls[0].method)
+	}
+	assert.Equal(t, []string{sigProposalStatus}, hc.events)
+	assert.Equal(t, icstate.ProposalApplied, proposalStatusOf(t, es, id))
+	assert.Len(t, es.State.GetPendingProposalIDs(), 0)
+}
+
+func TestExtensionState_ProposalRejection(t *testing.T) {
+	es := newProposalTestState(t)
+	id := registerTestProposal(t, es, "tx1", 100)
+
+	_, err := voteTestProposal(es, id, 0, false, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, icstate.ProposalVoting, proposalStatusOf(t, es, id))
+
+	// it can't be approved if more than 1/3 of voters disagree
+	cc, err := voteTestProposal(es, id, 1, false, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{sigProposalVoted, sigProposalStatus}, cc.events)
+	assert.Equal(t, icstate.ProposalDisapproved, proposalStatusOf(t, es, id))
+	assert.Len(t, es.State.GetPendingProposalIDs(), 0)
+
+	hc := &proposalContext{blockHeight: 100}
+	assert.NoError(t, es.handleProposals(hc))
+	assert.Len(t, hc.calls, 0)
+}
+
+func TestExtensionState_ProposalExpiry(t *testing.T) {
+	es := newProposalTestState(t)
+	id := registerTestProposal(t, es, "tx1", 100)
+	end := int64(10 + ProposalVotingPeriod)
+
+	_, err := voteTestProposal(es, id, 0, true, 11)
+	assert.NoError(t, err)
+
+	hc := &proposalContext{blockHeight: end}
+	assert.NoError(t, es.handleProposals(hc))
+	assert.Equal(t, icstate.ProposalVoting, proposalStatusOf(t, es, id))
+
+	// no votes after the end height
+	_, err = voteTestProposal(es, id, 1, true, end+1)
+	assert.ErrorContains(t, err, "NotInVoting")
+
+	hc = &proposalContext{blockHeight: end + 1}
+	assert.NoError(t, es.handleProposals(hc))
+	assert.Len(t, hc.calls, 0)
+	assert.Equal(t, []string{sigProposalStatus}, hc.events)
+	assert.Equal(t, icstate.ProposalExpired, proposalStatusOf(t, es, id))
+	assert.Len(t, es.State.GetPendingProposalIDs(), 0)
+}
+
+func TestExtensionState_ProposalApplyFailure(t *testing.T) {
+	es := newProposalTestState(t)
+	id := registerTestProposal(t, es, "tx1", 100)
+	for voter := 0; voter < 3; voter++ {
+		_, err := voteTestProposal(es, id, voter, true, 11)
+		assert.NoError(t, err)
+	}
+
+	// failure of the call doesn't fail the block
+	hc := &proposalContext{blockHeight: 100, callErr: errors.InvalidStateError.New("Failure")}
+	assert.NoError(t, es.handleProposals(hc))
+	assert.Len(t, hc.calls, 1)
+	assert.Equal(t, []string{sigProposalStatus}, hc.events)
+	assert.Equal(t, icstate.ProposalFailed, proposalStatusOf(t, es, id))
+	assert.Len(t, es.State.GetPendingProposalIDs(), 0)
+}
+
+func TestExtensionState_CancelProposal(t *testing.T) {
+	es := newProposalTestState(t)
+	id := registerTestProposal(t, es, "tx1", 100)
+
+	cc := &proposalContext{from: proposalTestPReps[1], blockHeight: 11}
+	assert.ErrorContains(t, es.CancelProposal(cc, id), "NotProposer")
+
+	cc = &proposalContext{from: proposalTestPReps[0], blockHeight: 11}
+	assert.NoError(t, es.CancelProposal(cc, id))
+	assert.Equal(t, icstate.ProposalCanceled, proposalStatusOf(t, es, id))
+	assert.Len(t, es.State.GetPendingProposalIDs(), 0)
+
+	_, err := voteTestProposal(es, id, 1, true, 12)
+	assert.ErrorContains(t, err, "NotInVoting")
+}
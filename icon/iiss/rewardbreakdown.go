@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/icdb"
+	"github.com/icon-project/goloop/icon/iiss/icutils"
+	"github.com/icon-project/goloop/module"
+)
+
+// MaxRewardBreakdowns is the number of recent terms kept for each address.
+const MaxRewardBreakdowns = 64
+
+// RewardBreakdown is the I-Score reward given to an address by the
+// calculation of the term starting at StartHeight.
+type RewardBreakdown struct {
+	StartHeight  int64
+	BlockProduce *big.Int
+	Voted        *big.Int
+	Delegating   *big.Int
+	Bonding      *big.Int
+}
+
+func newRewardBreakdown(startHeight int64) *RewardBreakdown {
+	return &RewardBreakdown{
+		StartHeight:  startHeight,
+		BlockProduce: new(big.Int),
+		Voted:        new(big.Int),
+		Delegating:   new(big.Int),
+		Bonding:      new(big.Int),
+	}
+}
+
+func (rb *RewardBreakdown) add(reward *big.Int, t RewardType) {
+	switch t {
+	case TypeBlockProduce:
+		rb.BlockProduce.Add(rb.BlockProduce, reward)
+	case TypeVoted:
+		rb.Voted.Add(rb.Voted, reward)
+	case TypeVoting, TypeDelegating:
+		rb.Delegating.Add(rb.Delegating, reward)
+	case TypeBonding:
+		rb.Bonding.Add(rb.Bonding, reward)
+	}
+}
+
+func (rb *RewardBreakdown) Total() *big.Int {
+	total := new(big.Int).Add(rb.BlockProduce, rb.Voted)
+	total.Add(total, rb.Delegating)
+	return total.Add(total, rb.Bonding)
+}
+
+func (rb *RewardBreakdown) ToJSON() map[string]interface{} {
+	total := rb.Total()
+	return map[string]interface{}{
+		"startBlockHeight": rb.StartHeight,
+		"blockProduce":     rb.BlockProduce,
+		"voted":            rb.Voted,
+		"delegating":       rb.Delegating,
+		"bonding":          rb.Bonding,
+		"iscore":           total,
+		"estimatedICX":     icutils.IScoreToICX(total),
+	}
+}
+
+// LoadRewardBreakdowns returns the reward breakdowns of recent terms for the
+// address ordered by start height.
+func LoadRewardBreakdowns(dbase db.Database, addr module.Address) ([]*RewardBreakdown, error) {
+	bk, err := dbase.GetBucket(icdb.RewardBreakdown)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := bk.Get(addr.Bytes())
+	if err != nil || len(bs) == 0 {
+		return nil, err
+	}
+	var rbs []*RewardBreakdown
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &rbs); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidRewardBreakdown")
+	}
+	return rbs, nil
+}
+
+// StoreRewardBreakdown appends the breakdown to the ones of the address.
+// It replaces the breakdown for the same term, and it drops the oldest
+// ones exceeding MaxRewardBreakdowns.
+func StoreRewardBreakdown(dbase db.Database, addr module.Address, rb *RewardBreakdown) error {
+	rbs, err := LoadRewardBreakdowns(dbase, addr)
+	if err != nil {
+		return err
+	}
+	for len(rbs) > 0 && rbs[len(rbs)-1].StartHeight >= rb.StartHeight {
+		rbs = rbs[:len(rbs)-1]
+	}
+	rbs = append(rbs, rb)
+	if len(rbs) > MaxRewardBreakdowns {
+		rbs = rbs[len(rbs)-MaxRewardBreakdowns:]
+	}
+	bs, err := codec.BC.MarshalToBytes(rbs)
+	if err != nil {
+		return err
+	}
+	bk, err := dbase.GetBucket(icdb.RewardBreakdown)
+	if err != nil {
+		return err
+	}
+	return bk.Set(addr.Bytes(), bs)
+}
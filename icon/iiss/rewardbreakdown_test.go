@@ -0,0 +1,53 @@
+package iiss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestRewardBreakdown_StoreAndLoad(t *testing.T) {
+	database := db.NewMapDB()
+	addr := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+
+	rbs, err := LoadRewardBreakdowns(database, addr)
+	assert.NoError(t, err)
+	assert.Len(t, rbs, 0)
+
+	for i := 0; i < MaxRewardBreakdowns+2; i++ {
+		rb := newRewardBreakdown(int64(100 * (i + 1)))
+		rb.add(big.NewInt(1), TypeBlockProduce)
+		rb.add(big.NewInt(2), TypeVoted)
+		rb.add(big.NewInt(3), TypeDelegating)
+		rb.add(big.NewInt(4), TypeVoting)
+		rb.add(big.NewInt(int64(i)), TypeBonding)
+		assert.NoError(t, StoreRewardBreakdown(database, addr, rb))
+	}
+
+	rbs, err = LoadRewardBreakdowns(database, addr)
+	assert.NoError(t, err)
+	assert.Len(t, rbs, MaxRewardBreakdowns)
+	assert.EqualValues(t, 300, rbs[0].StartHeight)
+	last := rbs[len(rbs)-1]
+	assert.EqualValues(t, 100*(MaxRewardBreakdowns+2), last.StartHeight)
+	assert.EqualValues(t, 1, last.BlockProduce.Int64())
+	assert.EqualValues(t, 2, last.Voted.Int64())
+	assert.EqualValues(t, 7, last.Delegating.Int64())
+	assert.EqualValues(t, MaxRewardBreakdowns+1, last.Bonding.Int64())
+	assert.EqualValues(t, 10+MaxRewardBreakdowns+1, last.Total().Int64())
+
+	// recalculation of the term replaces the old one
+	rb := newRewardBreakdown(last.StartHeight)
+	rb.add(big.NewInt(5), TypeVoted)
+	assert.NoError(t, StoreRewardBreakdown(database, addr, rb))
+
+	rbs, err = LoadRewardBreakdowns(database, addr)
+	assert.NoError(t, err)
+	assert.Len(t, rbs, MaxRewardBreakdowns)
+	last = rbs[len(rbs)-1]
+	assert.EqualValues(t, 5, last.Total().Int64())
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/icon/icdb"
+)
+
+// MaxRewardLedgerPage is the maximum number of entries returned at once.
+const MaxRewardLedgerPage = 1000
+
+var rewardLedgerTermsKey = []byte("T")
+
+func rewardLedgerKey(startHeight int64) []byte {
+	return append([]byte("L"), intconv.Int64ToBytes(startHeight)...)
+}
+
+// RewardLedgerEntry is the reward breakdown of Address for a term.
+type RewardLedgerEntry struct {
+	Address   *common.Address
+	Breakdown *RewardBreakdown
+}
+
+func (e *RewardLedgerEntry) ToJSON() map[string]interface{} {
+	jso := e.Breakdown.ToJSON()
+	jso["address"] = e.Address
+	return jso
+}
+
+// LoadRewardLedgerTerms returns start heights of the terms with the ledger
+// in ascending order.
+func LoadRewardLedgerTerms(dbase db.Database) ([]int64, error) {
+	bk, err := dbase.GetBucket(icdb.RewardLedger)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := bk.Get(rewardLedgerTermsKey)
+	if err != nil || len(bs) == 0 {
+		return nil, err
+	}
+	var terms []int64
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &terms); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidRewardLedgerTerms")
+	}
+	return terms, nil
+}
+
+// LoadRewardLedger returns the entries of the term starting at startHeight
+// ordered by address. It returns nil if there is no ledger for the term.
+func LoadRewardLedger(dbase db.Database, startHeight int64) ([]*RewardLedgerEntry, error) {
+	bk, err := dbase.GetBucket(icdb.RewardLedger)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := bk.Get(rewardLedgerKey(startHeight))
+	if err != nil || len(bs) == 0 {
+		return nil, err
+	}
+	var entries []*RewardLedgerEntry
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &entries); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidRewardLedger")
+	}
+	return entries, nil
+}
+
+// StoreRewardLedger writes the entries of the term starting at startHeight.
+// Ledgers of the terms exceeding MaxRewardBreakdowns are removed from the
+// oldest one.
+func StoreRewardLedger(dbase db.Database, startHeight int64, entries []*RewardLedgerEntry) error {
+	terms, err := LoadRewardLedgerTerms(dbase)
+	if err != nil {
+		return err
+	}
+	bk, err := dbase.GetBucket(icdb.RewardLedger)
+	if err != nil {
+		return err
+	}
+	for len(terms) > 0 && terms[len(terms)-1] >= startHeight {
+		if err = bk.Delete(rewardLedgerKey(terms[len(terms)-1])); err != nil {
+			return err
+		}
+		terms = terms[:len(terms)-1]
+	}
+	terms = append(terms, startHeight)
+	for len(terms) > MaxRewardBreakdowns {
+		if err = bk.Delete(rewardLedgerKey(terms[0])); err != nil {
+			return err
+		}
+		terms = terms[1:]
+	}
+
+	bs, err := codec.BC.MarshalToBytes(entries)
+	if err != nil {
+		return err
+	}
+	if err = bk.Set(rewardLedgerKey(startHeight), bs); err != nil {
+		return err
+	}
+	bs, err = codec.BC.MarshalToBytes(terms)
+	if err != nil {
+		return err
+	}
+	return bk.Set(rewardLedgerTermsKey, bs)
+}
@@ -0,0 +1,62 @@
+package iiss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestRewardLedger_StoreAndLoad(t *testing.T) {
+	database := db.NewMapDB()
+	addr1 := common.MustNewAddressFromString("hx1")
+	addr2 := common.MustNewAddressFromString("hx2")
+
+	terms, err := LoadRewardLedgerTerms(database)
+	assert.NoError(t, err)
+	assert.Len(t, terms, 0)
+
+	for i := 0; i < MaxRewardBreakdowns+2; i++ {
+		height := int64(100 * (i + 1))
+		rb1 := newRewardBreakdown(height)
+		rb1.add(big.NewInt(int64(i)), TypeVoted)
+		rb2 := newRewardBreakdown(height)
+		rb2.add(big.NewInt(2), TypeDelegating)
+		entries := []*RewardLedgerEntry{{addr1, rb1}, {addr2, rb2}}
+		assert.NoError(t, StoreRewardLedger(database, height, entries))
+	}
+
+	terms, err = LoadRewardLedgerTerms(database)
+	assert.NoError(t, err)
+	assert.Len(t, terms, MaxRewardBreakdowns)
+	assert.EqualValues(t, 300, terms[0])
+
+	// ledgers of old terms are removed
+	entries, err := LoadRewardLedger(database, 100)
+	assert.NoError(t, err)
+	assert.Nil(t, entries)
+
+	last := terms[len(terms)-1]
+	entries, err = LoadRewardLedger(database, last)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.True(t, addr1.Equal(entries[0].Address))
+	assert.EqualValues(t, MaxRewardBreakdowns+1, entries[0].Breakdown.Voted.Int64())
+	jso := entries[1].ToJSON()
+	assert.Equal(t, addr2, jso["address"])
+	assert.EqualValues(t, 2, jso["iscore"].(*big.Int).Int64())
+
+	// recalculation of the term replaces the old one
+	rb := newRewardBreakdown(last)
+	rb.add(big.NewInt(5), TypeVoted)
+	assert.NoError(t, StoreRewardLedger(database, last, []*RewardLedgerEntry{{addr2, rb}}))
+	entries, err = LoadRewardLedger(database, last)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	terms, err = LoadRewardLedgerTerms(database)
+	assert.NoError(t, err)
+	assert.Len(t, terms, MaxRewardBreakdowns)
+}
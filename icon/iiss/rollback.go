@@ -0,0 +1,62 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/icon/icdb"
+)
+
+// RollbackTo removes the data of the calculations started after the height,
+// which are kept out of the extension state. The data of the others are
+// consistent with the extension state at the height.
+func RollbackTo(dbase db.Database, height int64) error {
+	cp, err := loadCalculatorCheckpoint(dbase)
+	if err != nil {
+		return err
+	}
+	if cp != nil && cp.StartHeight > height {
+		if err = storeCalculatorCheckpoint(dbase, nil); err != nil {
+			return err
+		}
+	}
+
+	terms, err := LoadRewardLedgerTerms(dbase)
+	if err != nil || len(terms) == 0 {
+		return err
+	}
+	bk, err := dbase.GetBucket(icdb.RewardLedger)
+	if err != nil {
+		return err
+	}
+	last := len(terms)
+	for last > 0 && terms[last-1] > height {
+		if err = bk.Delete(rewardLedgerKey(terms[last-1])); err != nil {
+			return err
+		}
+		last--
+	}
+	if last == len(terms) {
+		return nil
+	}
+	bs, err := codec.BC.MarshalToBytes(terms[:last])
+	if err != nil {
+		return err
+	}
+	return bk.Set(rewardLedgerTermsKey, bs)
+}
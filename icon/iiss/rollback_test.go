@@ -0,0 +1,47 @@
+package iiss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestRollbackTo(t *testing.T) {
+	database := db.NewMapDB()
+	addr := common.MustNewAddressFromString("hx1")
+	for _, height := range []int64{100, 200, 300} {
+		rb := newRewardBreakdown(height)
+		entries := []*RewardLedgerEntry{{addr, rb}}
+		assert.NoError(t, StoreRewardLedger(database, height, entries))
+	}
+	assert.NoError(t, storeCalculatorCheckpoint(database, &calculatorCheckpoint{
+		StartHeight: 300,
+		Phase:       PhaseVoted,
+	}))
+
+	// nothing to remove
+	assert.NoError(t, RollbackTo(database, 300))
+	terms, err := LoadRewardLedgerTerms(database)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{100, 200, 300}, terms)
+	cp, err := loadCalculatorCheckpoint(database)
+	assert.NoError(t, err)
+	assert.NotNil(t, cp)
+
+	assert.NoError(t, RollbackTo(database, 250))
+	terms, err = LoadRewardLedgerTerms(database)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{100, 200}, terms)
+	entries, err := LoadRewardLedger(database, 300)
+	assert.NoError(t, err)
+	assert.Nil(t, entries)
+	entries, err = LoadRewardLedger(database, 200)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	cp, err = loadCalculatorCheckpoint(database)
+	assert.NoError(t, err)
+	assert.Nil(t, cp)
+}
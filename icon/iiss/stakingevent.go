@@ -0,0 +1,129 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
+	"github.com/icon-project/goloop/icon/iiss/icutils"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// Staking events are recorded since RevisionStakingEvents with the amounts before and
+// after the change, so that clients don't need to compare the account
+// information of each block.
+const (
+	sigStakeChanged      = "StakeChanged(Address,int,int)"
+	sigDelegationChanged = "DelegationChanged(Address,Address,int,int)"
+	sigBondChanged       = "BondChanged(Address,Address,int,int)"
+	sigUnbondChanged     = "UnbondChanged(Address,int,int)"
+)
+
+func isStakingEventEnabled(cc icmodule.CallContext) bool {
+	return cc.Revision().Value() >= icmodule.RevisionStakingEvents
+}
+
+func onStakeChanged(cc icmodule.CallContext, owner module.Address, oldStake, newStake *big.Int) {
+	if !isStakingEventEnabled(cc) || oldStake.Cmp(newStake) == 0 {
+		return
+	}
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte(sigStakeChanged), owner.Bytes()},
+		[][]byte{intconv.BigIntToBytes(oldStake), intconv.BigIntToBytes(newStake)},
+	)
+}
+
+func onUnbondChanged(cc icmodule.CallContext, owner module.Address, oldUnbond, newUnbond *big.Int) {
+	if !isStakingEventEnabled(cc) || oldUnbond.Cmp(newUnbond) == 0 {
+		return
+	}
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte(sigUnbondChanged), owner.Bytes()},
+		[][]byte{intconv.BigIntToBytes(oldUnbond), intconv.BigIntToBytes(newUnbond)},
+	)
+}
+
+func onDelegationChanged(cc icmodule.CallContext, from module.Address, oldDs, newDs icstate.Delegations) {
+	if !isStakingEventEnabled(cc) {
+		return
+	}
+	onVotingChanged(cc, sigDelegationChanged, from, delegationsToVotings(oldDs), delegationsToVotings(newDs))
+}
+
+func onBondChanged(cc icmodule.CallContext, from module.Address, oldBonds, newBonds icstate.Bonds) {
+	if !isStakingEventEnabled(cc) {
+		return
+	}
+	onVotingChanged(cc, sigBondChanged, from, bondsToVotings(oldBonds), bondsToVotings(newBonds))
+}
+
+// onVotingChanged records the eventlog for each P-Rep whose voting amount is
+// changed. Eventlogs are ordered by old votings followed by new ones, so the
+// order is deterministic.
+func onVotingChanged(cc icmodule.CallContext, sig string, from module.Address, olds, news []icstate.Voting) {
+	newAmounts := make(map[string]*big.Int, len(news))
+	for _, v := range news {
+		newAmounts[icutils.ToKey(v.To())] = v.Amount()
+	}
+	oldKeys := make(map[string]bool, len(olds))
+	for _, v := range olds {
+		key := icutils.ToKey(v.To())
+		oldKeys[key] = true
+		newAmount, ok := newAmounts[key]
+		if !ok {
+			newAmount = new(big.Int)
+		}
+		onVotingChangedEvent(cc, sig, from, v.To(), v.Amount(), newAmount)
+	}
+	for _, v := range news {
+		if !oldKeys[icutils.ToKey(v.To())] {
+			onVotingChangedEvent(cc, sig, from, v.To(), new(big.Int), v.Amount())
+		}
+	}
+}
+
+func onVotingChangedEvent(
+	cc icmodule.CallContext, sig string, from, to module.Address, oldAmount, newAmount *big.Int,
+) {
+	if oldAmount.Cmp(newAmount) == 0 {
+		return
+	}
+	cc.OnEvent(state.SystemAddress,
+		[][]byte{[]byte(sig), from.Bytes(), to.Bytes()},
+		[][]byte{intconv.BigIntToBytes(oldAmount), intconv.BigIntToBytes(newAmount)},
+	)
+}
+
+func delegationsToVotings(ds icstate.Delegations) []icstate.Voting {
+	votings := make([]icstate.Voting, 0, len(ds))
+	for _, d := range ds {
+		votings = append(votings, d)
+	}
+	return votings
+}
+
+func bondsToVotings(bonds icstate.Bonds) []icstate.Voting {
+	votings := make([]icstate.Voting, 0, len(bonds))
+	for _, b := range bonds {
+		votings = append(votings, b)
+	}
+	return votings
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
+	"github.com/icon-project/goloop/module"
+)
+
+type eventRecorder struct {
+	icmodule.CallContext
+	revision int
+	events   [][][]byte
+}
+
+func (cc *eventRecorder) Revision() module.Revision {
+	return icmodule.ValueToRevision(cc.revision)
+}
+
+func (cc *eventRecorder) OnEvent(_ module.Address, indexed, data [][]byte) {
+	cc.events = append(cc.events, append(indexed, data...))
+}
+
+func TestOnDelegationChanged(t *testing.T) {
+	from := common.MustNewAddressFromString("hx1")
+	p1 := common.MustNewAddressFromString("hx11")
+	p2 := common.MustNewAddressFromString("hx12")
+	p3 := common.MustNewAddressFromString("hx13")
+	oldDs := icstate.Delegations{
+		icstate.NewDelegation(p1, big.NewInt(100)),
+		icstate.NewDelegation(p2, big.NewInt(200)),
+	}
+	newDs := icstate.Delegations{
+		icstate.NewDelegation(p3, big.NewInt(300)),
+		icstate.NewDelegation(p2, big.NewInt(200)),
+	}
+
+	cc := &eventRecorder{revision: icmodule.RevisionBTP2 - 1}
+	onDelegationChanged(cc, from, oldDs, newDs)
+	assert.Empty(t, cc.events)
+
+	cc.revision = icmodule.RevisionBTP2
+	onDelegationChanged(cc, from, oldDs, newDs)
+	// p1 is removed and p3 is added. p2 is not changed
+	assert.Equal(t, 2, len(cc.events))
+	expected := []struct {
+		to       module.Address
+		old, new int64
+	}{
+		{p1, 100, 0},
+		{p3, 0, 300},
+	}
+	for i, e := range expected {
+		ev := cc.events[i]
+		assert.Equal(t, sigDelegationChanged, string(ev[0]))
+		assert.Equal(t, from.Bytes(), ev[1])
+		assert.Equal(t, e.to.Bytes(), ev[2])
+		assert.Zero(t, intconv.BigIntSetBytes(new(big.Int), ev[3]).Cmp(big.NewInt(e.old)))
+		assert.Zero(t, intconv.BigIntSetBytes(new(big.Int), ev[4]).Cmp(big.NewInt(e.new)))
+	}
+}
+
+func TestOnStakeChanged(t *testing.T) {
+	owner := common.MustNewAddressFromString("hx1")
+	cc := &eventRecorder{revision: icmodule.RevisionBTP2}
+
+	onStakeChanged(cc, owner, big.NewInt(10), big.NewInt(10))
+	assert.Empty(t, cc.events)
+
+	onStakeChanged(cc, owner, big.NewInt(10), big.NewInt(5))
+	assert.Equal(t, 1, len(cc.events))
+	ev := cc.events[0]
+	assert.Equal(t, sigStakeChanged, string(ev[0]))
+	assert.Equal(t, owner.Bytes(), ev[1])
+	assert.Zero(t, intconv.BigIntSetBytes(new(big.Int), ev[2]).Cmp(big.NewInt(10)))
+	assert.Zero(t, intconv.BigIntSetBytes(new(big.Int), ev[3]).Cmp(big.NewInt(5)))
+}
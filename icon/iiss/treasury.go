@@ -0,0 +1,71 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iiss
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/icon/iiss/icstate"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// AddTreasuryEntry records the amount to the treasury accounting of the
+// current term. Accounting is enabled from RevisionTreasury.
+func (es *ExtensionStateImpl) AddTreasuryEntry(
+	wc icmodule.WorldContext, t icstate.TreasuryEntryType, amount *big.Int,
+) error {
+	if wc.Revision().Value() < icmodule.RevisionTreasury || amount == nil || amount.Sign() == 0 {
+		return nil
+	}
+	term := es.State.GetTermSnapshot()
+	if term == nil {
+		return nil
+	}
+	return es.State.AddTreasuryEntry(int64(term.Sequence()), t, amount)
+}
+
+// GetTreasuryAccountInJSON returns the cumulative accounting and the one of
+// the term with the sequence. If sequence is negative, the current term is
+// used.
+func (es *ExtensionStateImpl) GetTreasuryAccountInJSON(
+	wc icmodule.WorldContext, sequence int64,
+) (map[string]interface{}, error) {
+	if sequence < 0 {
+		term := es.State.GetTermSnapshot()
+		if term == nil {
+			return nil, scoreresult.InvalidRequestError.New("TermNotFound")
+		}
+		sequence = int64(term.Sequence())
+	}
+	total, err := es.State.GetTreasuryAccount()
+	if err != nil {
+		return nil, err
+	}
+	account, err := es.State.GetTreasuryAccountOfTerm(sequence)
+	if err != nil {
+		return nil, err
+	}
+	termJSON := account.ToJSON()
+	termJSON["sequence"] = sequence
+	return map[string]interface{}{
+		"blockHeight": wc.BlockHeight(),
+		"totalSupply": wc.GetTotalSupply(),
+		"total":       total.ToJSON(),
+		"term":        termJSON,
+	}, nil
+}
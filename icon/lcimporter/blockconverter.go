@@ -80,6 +80,10 @@ type GetTPSer interface {
 	GetTPS() float32
 }
 
+type GetLastBlocker interface {
+	GetLastBlock() (blockv0.Block, error)
+}
+
 func NewBlockConverter(c module.Chain, plt base.Platform, pm eeproxy.Manager, cs Store, data string) (*BlockConverter, error) {
 	svc, err := NewService(c, plt, pm, data)
 	if err != nil {
@@ -625,6 +629,19 @@ func (e *BlockConverter) doExecute(
 	return nil
 }
 
+// GetSourceHeight returns the height of the last block in the store.
+func (e *BlockConverter) GetSourceHeight() (int64, error) {
+	glb, ok := e.cs.(GetLastBlocker)
+	if !ok {
+		return -1, errors.UnsupportedError.New("LastBlockUnavailable")
+	}
+	blk, err := glb.GetLastBlock()
+	if err != nil {
+		return -1, err
+	}
+	return blk.Height(), nil
+}
+
 func (e *BlockConverter) GetBlockVotes(h int64) (*blockv0.BlockVoteList, error) {
 	return e.cs.GetVotesByHeight(int(h))
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/common/wallet"
 	"github.com/icon-project/goloop/icon/blockv0"
 	"github.com/icon-project/goloop/icon/ictest"
@@ -92,6 +93,22 @@ func (c *testChain) TransactionTimeout() time.Duration {
 	return time.Second * 5
 }
 
+func (c *testChain) RecordInternalCalls() bool {
+	return false
+}
+
+func (c *testChain) RecordAddressIndex() bool {
+	return false
+}
+
+func (c *testChain) RecordTokenIndex() bool {
+	return false
+}
+
+func (c *testChain) SyncServerThrottle() *throttle.Throttle {
+	return nil
+}
+
 func (c *testChain) Regulator() module.Regulator {
 	return c.regulator
 }
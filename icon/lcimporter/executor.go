@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"time"
 
+	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
@@ -31,6 +33,7 @@ import (
 	"github.com/icon-project/goloop/icon/icdb"
 	"github.com/icon-project/goloop/icon/merkle/hexary"
 	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/metric"
 )
 
 const (
@@ -42,6 +45,17 @@ const (
 	terminationMark = -1
 )
 
+const (
+	KeyCheckpoint = "executor.checkpoint"
+)
+
+// checkpoint is the last consistent state of the executor. Hashes added to
+// the accumulator after the checkpoint are rolled back on restart.
+type checkpoint struct {
+	Height int64
+	AccLen int64
+}
+
 type OnBlockTransactions func([]*BlockTransaction, error)
 type Canceler func()
 
@@ -51,6 +65,16 @@ type IBlockConverter interface {
 	Term()
 }
 
+// ITransitionExecutor is the part of the executor used by transitions.
+type ITransitionExecutor interface {
+	GetTransactions(from, to int64, callback OnBlockTransactions) (Canceler, error)
+	SyncTransactions(txs []*BlockTransaction) error
+	GetMerkleHeader(height int64) (*hexary.MerkleHeader, error)
+	FinalizeTransactions(to int64) error
+	FinalizeBlocks(height int64) (*hexary.MerkleHeader, *blockv0.BlockVoteList, error)
+	Metric() *metric.LCImportMetric
+}
+
 type consumeID *int
 
 type Executor struct {
@@ -71,6 +95,9 @@ type Executor struct {
 	bc       IBlockConverter
 
 	acc hexary.Accumulator
+
+	progress progress
+	metric   *metric.LCImportMetric
 }
 
 func (e *Executor) candidateInLock(from int64) ([]*BlockTransaction, error) {
@@ -134,6 +161,7 @@ type txWaiter struct {
 	cb       OnBlockTransactions
 	from, to int64
 	txs      []*BlockTransaction
+	start    time.Time
 }
 
 func (w *txWaiter) addAndCheck(tx *BlockTransaction) bool {
@@ -141,6 +169,7 @@ func (w *txWaiter) addAndCheck(tx *BlockTransaction) bool {
 		w.txs = append(w.txs, tx)
 		w.from += 1
 		if tx.Height == w.to {
+			w.ex.metric.OnGetTransactions(time.Since(w.start))
 			go w.cb(w.txs, nil)
 			return true
 		}
@@ -178,10 +207,11 @@ func (e *Executor) GetTransactions(from, to int64, callback OnBlockTransactions)
 		return nil, errors.IllegalArgumentError.Errorf("InvalidRequest(from=%d,to=%d)", from, to)
 	}
 	w := &txWaiter{
-		ex:   e,
-		cb:   callback,
-		from: from,
-		to:   to,
+		ex:    e,
+		cb:    callback,
+		from:  from,
+		to:    to,
+		start: time.Now(),
 	}
 	if from < e.start {
 		if err := e.rebaseInLock(from, -1, nil); err != nil {
@@ -277,6 +307,50 @@ func (e *Executor) FinalizeTransactions(to int64) error {
 		}
 		txe, _ = txe.Next(), e.txs.Remove(txe)
 	}
+	if accLen > to+1 {
+		accLen = to + 1
+	}
+	if err := e.setCheckpoint(&checkpoint{to, accLen}); err != nil {
+		return err
+	}
+	e.progress.onFinalized(e.start - 1)
+	return nil
+}
+
+func (e *Executor) setCheckpoint(cp *checkpoint) error {
+	bs, err := codec.BC.MarshalToBytes(cp)
+	if err != nil {
+		return err
+	}
+	return e.chainBucket.Set([]byte(KeyCheckpoint), bs)
+}
+
+func (e *Executor) getCheckpoint() (*checkpoint, error) {
+	bs, err := e.chainBucket.Get([]byte(KeyCheckpoint))
+	if err != nil || len(bs) == 0 {
+		return nil, err
+	}
+	cp := new(checkpoint)
+	if _, err := codec.BC.UnmarshalFromBytes(bs, cp); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidCheckpoint")
+	}
+	return cp, nil
+}
+
+// rollback rewinds the accumulator to the last checkpoint. Hashes added
+// after the checkpoint may be left by the canceled or failed import.
+func (e *Executor) rollback() error {
+	cp, err := e.getCheckpoint()
+	if err != nil || cp == nil {
+		return err
+	}
+	if accLen := e.acc.Len(); accLen > cp.AccLen {
+		e.log.Infof("Rollback accumulator to checkpoint height=%d size=%d from size=%d",
+			cp.Height, cp.AccLen, accLen)
+		if err := e.acc.SetLen(cp.AccLen); err != nil {
+			return errors.Wrapf(err, "FailToRollback(height=%d,size=%d)", cp.Height, cp.AccLen)
+		}
+	}
 	return nil
 }
 
@@ -338,6 +412,7 @@ func (e *Executor) addTransaction(id consumeID, tx *BlockTransaction) bool {
 		e.log.Tracef("addTransaction height=%d", tx.Height)
 		e.txs.PushBack(tx)
 		e.end = tx.Height+1
+		e.progress.onExecuted(tx.Height)
 		if w := e.waiter ; w != nil {
 			if ok := w.addAndCheck(tx); ok {
 				e.waiter = nil
@@ -461,6 +536,22 @@ func (e *Executor) getMerkleHeaderInLock(height int64) (*hexary.MerkleHeader, er
 	return e.acc.GetMerkleHeader(), nil
 }
 
+// Inspect returns the progress of the import.
+func (e *Executor) Metric() *metric.LCImportMetric {
+	return e.metric
+}
+
+func (e *Executor) Inspect() map[string]interface{} {
+	shg, _ := e.bc.(SourceHeightGetter)
+	source := e.progress.sourceHeight(shg)
+
+	e.lock.Lock()
+	accLen := e.acc.Len()
+	e.lock.Unlock()
+
+	return e.progress.toJSON(source, accLen)
+}
+
 func (e *Executor) FinalizeBlocks(height int64) (*hexary.MerkleHeader, *blockv0.BlockVoteList, error) {
 	e.lock.Lock()
 	defer e.lock.Unlock()
@@ -469,10 +560,12 @@ func (e *Executor) FinalizeBlocks(height int64) (*hexary.MerkleHeader, *blockv0.
 		return nil, nil, errors.InvalidStateError.Errorf("InvalidAccumulatorState(height=%d,size=%d)",
 			height, size)
 	}
+	start := time.Now()
 	mh, err :=  e.acc.Finalize()
 	if err != nil {
 		return nil, nil, err
 	}
+	e.metric.OnFinalize(time.Since(start))
 	votes, err := e.bc.GetBlockVotes(height)
 	if err != nil {
 		return nil, nil, err
@@ -512,11 +605,16 @@ func NewExecutorWithBC(rdb, idb db.Database, logger log.Logger, bc IBlockConvert
 
 		bc: bc,
 
-		acc:   acc,
-		start: math.MaxInt64,
-		end:   math.MaxInt64,
+		acc:    acc,
+		start:  math.MaxInt64,
+		end:    math.MaxInt64,
+		metric: metric.NewLCImportMetric(metric.DefaultMetricContext()),
 	}
 	ex.txs.Init()
+	ex.progress.init()
+	if err := ex.rollback(); err != nil {
+		return nil, err
+	}
 	return ex, nil
 }
 
@@ -538,5 +636,10 @@ func NewExecutor(chain module.Chain, dbase db.Database, cfg *Config) (*Executor,
 		return nil, err
 	}
 
-	return NewExecutorWithBC(rdb, idb, logger, bc)
+	ex, err := NewExecutorWithBC(rdb, idb, logger, bc)
+	if err != nil {
+		return nil, err
+	}
+	ex.metric = metric.NewLCImportMetric(chain.MetricContext())
+	return ex, nil
 }
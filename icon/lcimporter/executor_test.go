@@ -591,5 +591,34 @@ func TestExecutor_LastBlock(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, ErrAfterLastBlock))
 
+	cp, err := ex.getCheckpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, &checkpoint{9, 10}, cp)
+
 	ex.Term()
-}
\ No newline at end of file
+}
+func TestExecutor_Rollback(t *testing.T) {
+	rdb := db.NewMapDB()
+	idb := db.NewMapDB()
+	logger := log.GlobalLogger()
+	txs := buildTestTxs(0, 19, "OK")
+
+	// accumulator has hashes after the checkpoint
+	acc, err := newAccumulator(rdb, idb)
+	assert.NoError(t, err)
+	applyTxsOnAcc(t, acc, txs)
+
+	ex, err := NewExecutorWithBC(rdb, idb, logger, newTestBlockConverter(rdb))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 20, ex.acc.Len())
+	assert.NoError(t, ex.setCheckpoint(&checkpoint{9, 10}))
+
+	// restart rewinds the accumulator to the checkpoint
+	ex, err = NewExecutorWithBC(rdb, idb, logger, newTestBlockConverter(rdb))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, ex.acc.Len())
+
+	acc2 := newTestAcc(t)
+	applyTxsOnAcc(t, acc2, txs[:10])
+	assert.Equal(t, acc2.GetMerkleHeader(), ex.acc.GetMerkleHeader())
+}
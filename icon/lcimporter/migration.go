@@ -0,0 +1,222 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcimporter
+
+import (
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/iiss/icutils"
+	"github.com/icon-project/goloop/module"
+)
+
+// BlockTransactions is the list of transactions of a block in the source
+// chain to be applied to the target chain.
+type BlockTransactions struct {
+	Height       int64
+	Timestamp    int64
+	Transactions []module.Transaction
+}
+
+// MigrationSource is the store of the source chain for migration.
+type MigrationSource interface {
+	GetBlockTransactions(height int64) (*BlockTransactions, error)
+}
+
+type loopchainSource struct {
+	cs Store
+}
+
+func (s *loopchainSource) GetBlockTransactions(height int64) (*BlockTransactions, error) {
+	blk, err := s.cs.GetBlockByHeight(int(height))
+	if err != nil {
+		return nil, err
+	}
+	return &BlockTransactions{
+		Height:       blk.Height(),
+		Timestamp:    blk.Timestamp(),
+		Transactions: blk.NormalTransactions(),
+	}, nil
+}
+
+// NewLoopchainSource returns the source reading blocks of the loopchain
+// store.
+func NewLoopchainSource(cs Store) MigrationSource {
+	return &loopchainSource{cs}
+}
+
+// BlockGetter returns blocks of a goloop chain. module.BlockManager
+// implements it.
+type BlockGetter interface {
+	GetBlockByHeight(height int64) (module.Block, error)
+}
+
+type goloopSource struct {
+	bg BlockGetter
+}
+
+func (s *goloopSource) GetBlockTransactions(height int64) (*BlockTransactions, error) {
+	blk, err := s.bg.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	var txs []module.Transaction
+	for itr := blk.NormalTransactions().Iterator(); itr.Has(); itr.Next() {
+		tx, _, err := itr.Get()
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return &BlockTransactions{
+		Height:       blk.Height(),
+		Timestamp:    blk.Timestamp(),
+		Transactions: txs,
+	}, nil
+}
+
+// NewGoloopSource returns the source reading blocks of a goloop chain.
+func NewGoloopSource(bg BlockGetter) MigrationSource {
+	return &goloopSource{bg}
+}
+
+// TransactionFilter selects transactions of the source chain to migrate.
+type TransactionFilter interface {
+	Accept(height int64, tx module.Transaction) (bool, error)
+}
+
+type TransactionFilterFunc func(height int64, tx module.Transaction) (bool, error)
+
+func (f TransactionFilterFunc) Accept(height int64, tx module.Transaction) (bool, error) {
+	return f(height, tx)
+}
+
+// FilterByAddresses returns the filter accepting transactions sent from or
+// to one of the addresses.
+func FilterByAddresses(addrs ...module.Address) TransactionFilter {
+	set := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		set[icutils.ToKey(addr)] = true
+	}
+	return TransactionFilterFunc(func(height int64, tx module.Transaction) (bool, error) {
+		if from := tx.From(); from != nil && set[icutils.ToKey(from)] {
+			return true, nil
+		}
+		if txo, ok := tx.(interface{ To() module.Address }); ok {
+			if to := txo.To(); to != nil && set[icutils.ToKey(to)] {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// TransactionTransformer converts transactions of the source chain for the
+// target chain, for example, by signing them again for the network. It may
+// return nil to drop the transaction.
+type TransactionTransformer interface {
+	Transform(height int64, tx module.Transaction) (module.Transaction, error)
+}
+
+type TransactionTransformerFunc func(height int64, tx module.Transaction) (module.Transaction, error)
+
+func (f TransactionTransformerFunc) Transform(height int64, tx module.Transaction) (module.Transaction, error) {
+	return f(height, tx)
+}
+
+// Migrator produces transactions for the target chain from blocks of the
+// source chain. Transactions accepted by all filters are converted by
+// transformers in the order they're added.
+//
+// Unlike BlockConverter, it doesn't verify results of the source chain, as
+// they don't match results on the target chain for the subset of
+// transactions.
+type Migrator struct {
+	src          MigrationSource
+	filters      []TransactionFilter
+	transformers []TransactionTransformer
+}
+
+func NewMigrator(src MigrationSource) *Migrator {
+	return &Migrator{src: src}
+}
+
+func (m *Migrator) AddFilter(f TransactionFilter) *Migrator {
+	m.filters = append(m.filters, f)
+	return m
+}
+
+func (m *Migrator) AddTransformer(t TransactionTransformer) *Migrator {
+	m.transformers = append(m.transformers, t)
+	return m
+}
+
+func (m *Migrator) convertTransaction(height int64, tx module.Transaction) (module.Transaction, error) {
+	for _, f := range m.filters {
+		if ok, err := f.Accept(height, tx); err != nil || !ok {
+			return nil, err
+		}
+	}
+	for _, t := range m.transformers {
+		var err error
+		if tx, err = t.Transform(height, tx); err != nil || tx == nil {
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+// Convert returns transactions for the target chain from the block at the
+// height.
+func (m *Migrator) Convert(height int64) (*BlockTransactions, error) {
+	bt, err := m.src.GetBlockTransactions(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "FailureInGetBlock(height=%d)", height)
+	}
+	txs := make([]module.Transaction, 0, len(bt.Transactions))
+	for idx, tx := range bt.Transactions {
+		ntx, err := m.convertTransaction(height, tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "FailureInConvert(height=%d,idx=%d)", height, idx)
+		}
+		if ntx != nil {
+			txs = append(txs, ntx)
+		}
+	}
+	return &BlockTransactions{
+		Height:       bt.Height,
+		Timestamp:    bt.Timestamp,
+		Transactions: txs,
+	}, nil
+}
+
+// Migrate converts blocks from the height from to the height to, and calls
+// cb with the result of each block in order. Blocks without transactions
+// for the target chain are also passed to keep the heights.
+func (m *Migrator) Migrate(from, to int64, cb func(bt *BlockTransactions) error) error {
+	if from < 0 || to < from {
+		return errors.IllegalArgumentError.Errorf("InvalidArgument(from=%d,to=%d)", from, to)
+	}
+	for height := from; height <= to; height++ {
+		bt, err := m.Convert(height)
+		if err != nil {
+			return err
+		}
+		if err = cb(bt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
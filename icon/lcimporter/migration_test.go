@@ -0,0 +1,166 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcimporter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/lcimporter"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+func migrate(t *testing.T, m *lcimporter.Migrator, from, to int64) []*lcimporter.BlockTransactions {
+	var bts []*lcimporter.BlockTransactions
+	err := m.Migrate(from, to, func(bt *lcimporter.BlockTransactions) error {
+		bts = append(bts, bt)
+		return nil
+	})
+	assert.NoError(t, err)
+	return bts
+}
+
+func TestMigrator_LoopchainSource(t *testing.T) {
+	dbase := db.NewMapDB()
+	store, err := newTestStore(dbase)
+	assert.NoError(t, err)
+	src := lcimporter.NewLoopchainSource(store)
+
+	bts := migrate(t, lcimporter.NewMigrator(src), 0, 1)
+	assert.Len(t, bts, 2)
+	assert.EqualValues(t, 1, bts[1].Height)
+	assert.EqualValues(t, 1516819217223222, bts[1].Timestamp)
+	assert.Len(t, bts[0].Transactions, 1)
+	assert.Len(t, bts[1].Transactions, 1)
+
+	// filter by the recipient of the transfer in the block 1
+	to := common.MustNewAddressFromString("hx49a23bd156932485471f582897bf1bec5f875751")
+	m := lcimporter.NewMigrator(src).AddFilter(lcimporter.FilterByAddresses(to))
+	bts = migrate(t, m, 0, 1)
+	assert.Len(t, bts, 2)
+	assert.Len(t, bts[0].Transactions, 0)
+	assert.Len(t, bts[1].Transactions, 1)
+	assert.Equal(t, store.blocks[1].NormalTransactions()[0].ID(), bts[1].Transactions[0].ID())
+
+	// filter by the sender
+	from := common.MustNewAddressFromString("hx54f7853dc6481b670caf69c5a27c7c8fe5be8269")
+	m = lcimporter.NewMigrator(src).AddFilter(lcimporter.FilterByAddresses(from))
+	bts = migrate(t, m, 1, 1)
+	assert.Len(t, bts[0].Transactions, 1)
+
+	// transformers are applied in order, and nil drops the transaction
+	var heights []int64
+	m = lcimporter.NewMigrator(src).
+		AddTransformer(lcimporter.TransactionTransformerFunc(
+			func(height int64, tx module.Transaction) (module.Transaction, error) {
+				heights = append(heights, height)
+				return tx, nil
+			})).
+		AddTransformer(lcimporter.TransactionTransformerFunc(
+			func(height int64, tx module.Transaction) (module.Transaction, error) {
+				if height == 0 {
+					return nil, nil
+				}
+				return tx, nil
+			}))
+	bts = migrate(t, m, 0, 1)
+	assert.Equal(t, []int64{0, 1}, heights)
+	assert.Len(t, bts[0].Transactions, 0)
+	assert.Len(t, bts[1].Transactions, 1)
+
+	// errors of transformers stop migration
+	m = lcimporter.NewMigrator(src).
+		AddTransformer(lcimporter.TransactionTransformerFunc(
+			func(height int64, tx module.Transaction) (module.Transaction, error) {
+				return nil, errors.InvalidStateError.New("TestError")
+			}))
+	err = m.Migrate(0, 1, func(bt *lcimporter.BlockTransactions) error {
+		return nil
+	})
+	assert.True(t, errors.InvalidStateError.Equals(err))
+
+	// missing blocks in the source
+	err = lcimporter.NewMigrator(src).Migrate(1, 2, func(bt *lcimporter.BlockTransactions) error {
+		return nil
+	})
+	assert.True(t, errors.NotFoundError.Equals(err))
+
+	err = lcimporter.NewMigrator(src).Migrate(1, 0, func(bt *lcimporter.BlockTransactions) error {
+		return nil
+	})
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+}
+
+type testGoloopBlock struct {
+	module.Block
+	height int64
+	txs    module.TransactionList
+}
+
+func (b *testGoloopBlock) Height() int64 {
+	return b.height
+}
+
+func (b *testGoloopBlock) Timestamp() int64 {
+	return b.height * 1000
+}
+
+func (b *testGoloopBlock) NormalTransactions() module.TransactionList {
+	return b.txs
+}
+
+type testBlockGetter map[int64]module.Block
+
+func (bg testBlockGetter) GetBlockByHeight(height int64) (module.Block, error) {
+	if blk, ok := bg[height]; ok {
+		return blk, nil
+	}
+	return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+}
+
+func TestMigrator_GoloopSource(t *testing.T) {
+	dbase := db.NewMapDB()
+	store, err := newTestStore(dbase)
+	assert.NoError(t, err)
+	txs := store.blocks[1].NormalTransactions()
+	bg := testBlockGetter{
+		10: &testGoloopBlock{
+			height: 10,
+			txs:    transaction.NewTransactionListFromSlice(dbase, txs),
+		},
+		11: &testGoloopBlock{
+			height: 11,
+			txs:    transaction.NewTransactionListFromSlice(dbase, nil),
+		},
+	}
+	m := lcimporter.NewMigrator(lcimporter.NewGoloopSource(bg)).
+		AddFilter(lcimporter.TransactionFilterFunc(
+			func(height int64, tx module.Transaction) (bool, error) {
+				return height == 10, nil
+			}))
+	bts := migrate(t, m, 10, 11)
+	assert.Len(t, bts, 2)
+	assert.EqualValues(t, 10000, bts[0].Timestamp)
+	assert.Len(t, bts[0].Transactions, 1)
+	assert.Equal(t, txs[0].ID(), bts[0].Transactions[0].ID())
+	assert.Len(t, bts[1].Transactions, 0)
+}
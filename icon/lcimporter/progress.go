@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcimporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	ShortProgressWindow = 100
+	LongProgressWindow  = 1000
+
+	SourceHeightRefreshInterval = time.Minute
+)
+
+// SourceHeightGetter is implemented by the block converter which can tell
+// the height of the last block in the legacy store.
+type SourceHeightGetter interface {
+	GetSourceHeight() (int64, error)
+}
+
+// progress keeps the progress of the import for reporting.
+type progress struct {
+	lock sync.Mutex
+
+	executed  int64
+	finalized int64
+	shortBPS  BPSMeasure
+	longBPS   BPSMeasure
+
+	source     int64
+	sourceTime time.Time
+}
+
+func (p *progress) init() {
+	p.executed = -1
+	p.finalized = -1
+	p.source = -1
+	p.shortBPS.Init(ShortProgressWindow)
+	p.longBPS.Init(LongProgressWindow)
+}
+
+func (p *progress) onExecuted(height int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if height > p.executed {
+		p.shortBPS.OnBlock()
+		p.longBPS.OnBlock()
+	}
+	p.executed = height
+}
+
+func (p *progress) onFinalized(height int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.finalized = height
+}
+
+// sourceHeight returns the height of the last block in the store. It's
+// refreshed every SourceHeightRefreshInterval.
+func (p *progress) sourceHeight(shg SourceHeightGetter) int64 {
+	p.lock.Lock()
+	source, sourceTime := p.source, p.sourceTime
+	p.lock.Unlock()
+
+	if shg == nil || time.Since(sourceTime) < SourceHeightRefreshInterval {
+		return source
+	}
+	if height, err := shg.GetSourceHeight(); err == nil {
+		source = height
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.source, p.sourceTime = source, time.Now()
+	return source
+}
+
+func (p *progress) toJSON(source, accLen int64) map[string]interface{} {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	shortBPS := p.shortBPS.GetBPS()
+	longBPS := p.longBPS.GetBPS()
+	jso := map[string]interface{}{
+		"executedHeight":  p.executed,
+		"finalizedHeight": p.finalized,
+		"accumulatorSize": accLen,
+		"blocksPerSecond": map[string]interface{}{
+			"recent100":  shortBPS,
+			"recent1000": longBPS,
+		},
+	}
+	if source >= 0 {
+		jso["sourceHeight"] = source
+		if longBPS > 0 && source >= p.executed {
+			eta := time.Duration(float64(source-p.executed) / float64(longBPS) * float64(time.Second))
+			jso["estimatedTime"] = eta.Round(time.Second).String()
+		}
+	}
+	return jso
+}
+
+// Inspect returns the progress of the importer if the chain is importing
+// the legacy database.
+func Inspect(c module.Chain, informal bool) map[string]interface{} {
+	if sm, ok := c.ServiceManager().(*ServiceManager); ok {
+		return sm.Inspect(informal)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcimporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSourceHeightGetter struct {
+	height int64
+	calls  int
+}
+
+func (g *testSourceHeightGetter) GetSourceHeight() (int64, error) {
+	g.calls += 1
+	return g.height, nil
+}
+
+func TestProgress(t *testing.T) {
+	var p progress
+	p.init()
+
+	jso := p.toJSON(-1, 0)
+	assert.EqualValues(t, -1, jso["executedHeight"])
+	assert.EqualValues(t, -1, jso["finalizedHeight"])
+	assert.NotContains(t, jso, "sourceHeight")
+
+	for h := int64(0); h < 10; h++ {
+		p.onExecuted(h)
+	}
+	p.onFinalized(5)
+
+	shg := &testSourceHeightGetter{height: 100}
+	assert.EqualValues(t, 100, p.sourceHeight(shg))
+	shg.height = 200
+	assert.EqualValues(t, 100, p.sourceHeight(shg))
+	assert.Equal(t, 1, shg.calls)
+	assert.EqualValues(t, 100, p.sourceHeight(nil))
+
+	jso = p.toJSON(100, 6)
+	assert.EqualValues(t, 9, jso["executedHeight"])
+	assert.EqualValues(t, 5, jso["finalizedHeight"])
+	assert.EqualValues(t, 6, jso["accumulatorSize"])
+	assert.EqualValues(t, 100, jso["sourceHeight"])
+	assert.Contains(t, jso, "estimatedTime")
+}
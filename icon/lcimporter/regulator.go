@@ -49,6 +49,14 @@ func (r *regulatorImpl) SetBlockInterval(i time.Duration, d time.Duration) {
 	// do nothing
 }
 
+func (r *regulatorImpl) SetIntervalPolicy(max time.Duration, threshold int) {
+	// do nothing
+}
+
+func (r *regulatorImpl) OnTxPoolUpdated(count int) {
+	// do nothing
+}
+
 func NewRegulator() module.Regulator {
 	return &regulatorImpl{}
 }
@@ -137,7 +137,7 @@ func NewService(c module.Chain, plt base.Platform, pm eeproxy.Manager, baseDir s
 			BaseDir: baseDir,
 			pm:      pm,
 		},
-		syncMan: sync2.NewSyncManager(c.Database(), c.NetworkManager(), plt, c.Logger()),
+		syncMan: sync2.NewSyncManager(c.Database(), c.NetworkManager(), plt, c.SyncServerThrottle(), c.Logger()),
 	}, nil
 }
 
@@ -204,6 +204,10 @@ func (sm *ServiceManager) SendTransaction(result []byte, height int64, tx interf
 	return nil, errors.ErrInvalidState
 }
 
+func (sm *ServiceManager) SendTransactionBundle(result []byte, height int64, txs []interface{}, sig []byte) ([][]byte, error) {
+	return nil, errors.ErrInvalidState
+}
+
 func (sm *ServiceManager) SendPatch(patch module.Patch) error {
 	return errors.ErrInvalidState
 }
@@ -258,6 +262,26 @@ func (sm *ServiceManager) GetNextBlockVersion(result []byte) int {
 	return module.BlockVersion2
 }
 
+func (sm *ServiceManager) GetRevision(result []byte) int {
+	return 0
+}
+
+func (sm *ServiceManager) GetVRFProposerSelection(result []byte) bool {
+	return false
+}
+
+func (sm *ServiceManager) GetValidatorWeights(result []byte, validators module.ValidatorList) []*big.Int {
+	return nil
+}
+
+func (sm *ServiceManager) GetStorageEntries(result []byte, addr module.Address, prefix, start []byte, limit int) ([]module.StorageEntry, []byte, error) {
+	return nil, nil, errors.ErrInvalidState
+}
+
+func (sm *ServiceManager) InspectTransaction(result []byte, height int64, tx interface{}) (*module.TransactionInspection, error) {
+	return nil, errors.ErrInvalidState
+}
+
 func (sm *ServiceManager) HasTransaction(id []byte) bool {
 	return false
 }
@@ -373,10 +397,26 @@ func (sm *ServiceManager) GetStatus() string {
 	}
 }
 
+func (sm *ServiceManager) Inspect(informal bool) map[string]interface{} {
+	sm.lock.Lock()
+	next, last, finished := sm.next, sm.last, sm.finishedInLock()
+	sm.lock.Unlock()
+
+	jso := sm.ex.Inspect()
+	jso["nextHeight"] = next
+	jso["lastHeight"] = last
+	jso["finished"] = finished
+	return jso
+}
+
 func (sm *ServiceManager) GetSCOREStatus(result []byte, addr module.Address) (module.SCOREStatus, error) {
 	return nil, common.ErrInvalidState
 }
 
+func (sm *ServiceManager) GetFeeSharingInfo(result []byte, addr module.Address) (module.FeeSharingInfo, error) {
+	return nil, common.ErrInvalidState
+}
+
 func NewServiceManagerWithExecutor(chain module.Chain, ex *Executor, ps BlockV1ProofStorage, vs []*common.Address, cb ImportCallback) (*ServiceManager, error) {
 	logger := chain.Logger()
 	dbase := chain.Database()
@@ -21,6 +21,7 @@ import (
 	"sync"
 
 	"github.com/icon-project/goloop/btp"
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/containerdb"
 	"github.com/icon-project/goloop/common/db"
@@ -35,6 +36,10 @@ import (
 	"github.com/icon-project/goloop/service/txresult"
 )
 
+// TransactionsPerCheck is the number of transactions requested to the
+// executor at once on checking transactions of the block.
+const TransactionsPerCheck = 500
+
 type transitionID struct{ int }
 
 type transitionState int
@@ -51,7 +56,7 @@ const (
 
 type transitionBase struct {
 	sm  *ServiceManager
-	ex  *Executor
+	ex  ITransitionExecutor
 	log log.Logger
 }
 
@@ -79,6 +84,7 @@ type transition struct {
 	*transitionBase
 
 	pid    *transitionID
+	id     *transitionID
 	parent *transition
 
 	lock  sync.Mutex
@@ -162,15 +168,13 @@ func (t *transition) getLastHeight() int64 {
 	return scoredb.NewVarDB(store, VarLastBlockHeight).Int64()
 }
 
+var merkleHeaderSchema = &containerdb.Schema[*hexary.MerkleHeader]{}
+
 func (t *transition) getMerkleHeader() *hexary.MerkleHeader {
 	bsn := containerdb.NewBytesStoreSnapshotFromRaw(t.worldSnapshot)
 	store := containerdb.NewBytesStoreStateWithSnapshot(bsn)
-	bs := scoredb.NewVarDB(store, VarCurrentMerkle).Bytes()
-	if len(bs) == 0 {
-		return nil
-	}
-	mh := new(hexary.MerkleHeader)
-	if _, err := codec.BC.UnmarshalFromBytes(bs, mh); err != nil {
+	mh, err := scoredb.NewTypedVarDB(store, merkleHeaderSchema, VarCurrentMerkle).Get()
+	if err != nil {
 		return nil
 	}
 	return mh
@@ -184,8 +188,9 @@ func (t *transition) setResult(txs int, next int64, last int64, mh *hexary.Merkl
 		ws := trie_manager.NewMutableFromImmutable(t.parent.worldSnapshot)
 		store := containerdb.NewBytesStoreStateFromRaw(ws)
 		scoredb.NewVarDB(store, VarNextBlockHeight).Set(next)
-		mhBytes := codec.BC.MustMarshalToBytes(mh)
-		scoredb.NewVarDB(store, VarCurrentMerkle).Set(mhBytes)
+		if err := scoredb.NewTypedVarDB(store, merkleHeaderSchema, VarCurrentMerkle).Set(mh); err != nil {
+			t.log.Panicf("Fail to store merkle header err=%+v", err)
+		}
 		if last > 0 {
 			scoredb.NewVarDB(store, VarLastBlockHeight).Set(last)
 		}
@@ -195,6 +200,7 @@ func (t *transition) setResult(txs int, next int64, last int64, mh *hexary.Merkl
 		t.worldSnapshot = t.parent.worldSnapshot
 	}
 	t.receipts = makeReceiptList(t.sm.db, txs, t.sm.defaultReceipt)
+	t.ex.Metric().OnTransition(txs)
 	if vl != nil {
 		t.nextValidators = vl
 	} else {
@@ -272,7 +278,7 @@ func (t *transition) onTransactions(txs []*BlockTransaction, err error) {
 	}
 }
 
-func (t *transition) checkTransactions(txs []*BlockTransaction) error {
+func (t *transition) requestTransactions(txs []*BlockTransaction) (Canceler, error) {
 	from := txs[0].Height
 	to := txs[len(txs)-1].Height
 	if logServiceManager {
@@ -281,29 +287,74 @@ func (t *transition) checkTransactions(txs []*BlockTransaction) error {
 	cancel, err := t.ex.GetTransactions(from, to, t.onTransactions)
 	if err != nil {
 		t.log.Warnf("FailToGetTransaction(from=%d,to=%d,err=%+v)", from, to, err)
+		return nil, err
+	}
+	return cancel, nil
+}
+
+func windowOfTransactions(txs []*BlockTransaction, idx int) []*BlockTransaction {
+	if end := idx + TransactionsPerCheck; end < len(txs) {
+		return txs[idx:end]
+	}
+	return txs[idx:]
+}
+
+// checkTransactions compares transactions with the ones of the executor.
+// They are requested in windows of TransactionsPerCheck, and the next window
+// is requested before comparing the received one. So it compares while the
+// executor gathers the next window, and keeps at most two of them.
+func (t *transition) checkTransactions(txs []*BlockTransaction) error {
+	idx := 0
+	window := windowOfTransactions(txs, idx)
+	cancel, err := t.requestTransactions(window)
+	if err != nil {
 		return err
 	}
+	for {
+		result := <-t.chn
+		if err, ok := result.(error); ok {
+			if err == ErrCanceled {
+				cancel()
+			}
+			t.log.Warnf("FailToGetTransaction(from=%d,to=%d,err=%+v)",
+				window[0].Height, window[len(window)-1].Height, err)
+			return err
+		}
+		rtxs := result.([]*BlockTransaction)
 
-	result := <-t.chn
-	if err, ok := result.(error); ok {
-		if err == ErrCanceled {
-			cancel()
+		var next []*BlockTransaction
+		if idx+len(window) < len(txs) {
+			next = windowOfTransactions(txs, idx+len(window))
+			if cancel, err = t.requestTransactions(next); err != nil {
+				return err
+			}
 		}
-		t.log.Warnf("FailToGetTransaction(from=%d,to=%d,err=%+v)", from, to, err)
-		return err
+		if err := compareTransactions(idx, window, rtxs); err != nil {
+			if next != nil {
+				cancel()
+			}
+			return err
+		}
+		if next == nil {
+			return nil
+		}
+		idx, window = idx+len(window), next
 	}
-	rtxs := result.([]*BlockTransaction)
+}
 
+// compareTransactions compares transactions of the window starting at idx
+// with the ones from the executor.
+func compareTransactions(idx int, txs, rtxs []*BlockTransaction) error {
 	// check length
 	if len(rtxs) != len(txs) {
 		return errors.InvalidStateError.Errorf("DifferentLength(rtxs=%d,txs=%d)", len(rtxs), len(txs))
 	}
 
 	// compare each transactions
-	for idx, tx := range txs {
-		rtx := rtxs[idx]
+	for i, tx := range txs {
+		rtx := rtxs[i]
 		if !tx.Equal(rtx) {
-			return errors.InvalidStateError.Errorf("DifferentTx(idx=%d,exp=%+v,real=%+v)", idx, tx, rtx)
+			return errors.InvalidStateError.Errorf("DifferentTx(idx=%d,exp=%+v,real=%+v)", idx+i, tx, rtx)
 		}
 	}
 	return nil
@@ -328,6 +379,7 @@ func (t *transition) doSync(cb module.TransitionCallback) (ret error) {
 	if len(txs) < 1 {
 		return errors.CriticalFormatError.New("NoTransactions")
 	}
+	t.ex.Metric().OnSync()
 
 	if logServiceManager {
 		t.log.Warnf("T_%p.SyncTransactions(from=%d,to=%d)",
@@ -342,7 +394,7 @@ func (t *transition) doSync(cb module.TransitionCallback) (ret error) {
 	}
 
 	from := txs[0].Height
-	mh, err := t.ex.getMerkleHeaderInLock(from)
+	mh, err := t.ex.GetMerkleHeader(from)
 	if err != nil {
 		return err
 	}
@@ -425,6 +477,38 @@ func (t *transition) BlockInfo() module.BlockInfo {
 	return t.bi
 }
 
+func (t *transition) getParent() *transition {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.parent
+}
+
+// hasSameParent returns true if transitions are on the same parent or
+// parents with the same result. The parent is released on finalization,
+// then results of transitions are compared instead.
+func (t *transition) hasSameParent(t2 *transition) bool {
+	if t.pid != nil && t.pid == t2.pid {
+		return true
+	}
+	p1, p2 := t.getParent(), t2.getParent()
+	if p1 != nil && p2 != nil {
+		if p1 == p2 {
+			return true
+		}
+		r1 := p1.Result()
+		return r1 != nil && bytes.Equal(r1, p2.Result())
+	}
+	r1 := t.Result()
+	return r1 != nil && bytes.Equal(r1, t2.Result())
+}
+
+func transactionListEqual(l1, l2 module.TransactionList) bool {
+	if l1 == nil || l2 == nil {
+		return l1 == l2
+	}
+	return l1.Equal(l2)
+}
+
 func (t *transition) Equal(t2 module.Transition) bool {
 	tr2, _ := t2.(*transition)
 	if t == tr2 {
@@ -433,11 +517,9 @@ func (t *transition) Equal(t2 module.Transition) bool {
 	if t == nil || tr2 == nil {
 		return false
 	}
-	if t.pid == tr2.pid {
-		return true
-	}
-	// TODO implement
-	return false
+	return common.BlockInfoEqual(t.bi, tr2.bi) &&
+		transactionListEqual(t.txs, tr2.txs) &&
+		t.hasSameParent(tr2)
 }
 
 func (t *transition) finalizeTransactions() error {
@@ -450,7 +532,9 @@ func (t *transition) finalizeTransactions() error {
 func (t *transition) finalizeResult() (ret error) {
 	defer func() {
 		if ret == nil {
+			t.lock.Lock()
 			t.parent = nil
+			t.lock.Unlock()
 		}
 	}()
 	if err := t.worldSnapshot.(trie.Snapshot).Flush(); err != nil {
@@ -491,7 +575,7 @@ func (t *transition) finalizeResult() (ret error) {
 	return nil
 }
 
-func createInitialTransition(dbase db.Database, result []byte, nvl module.ValidatorList, sm *ServiceManager, ex *Executor) *transition {
+func createInitialTransition(dbase db.Database, result []byte, nvl module.ValidatorList, sm *ServiceManager, ex ITransitionExecutor) *transition {
 	r := new(transitionResult).SetBytes(result)
 	return &transition{
 		transitionBase: &transitionBase{
@@ -500,7 +584,7 @@ func createInitialTransition(dbase db.Database, result []byte, nvl module.Valida
 			log: sm.log,
 		},
 
-		pid: new(transitionID),
+		id: new(transitionID),
 
 		state:          stepComplete,
 		worldSnapshot:  trie_manager.NewImmutable(dbase, r.State),
@@ -519,7 +603,8 @@ func createTransition(parent *transition, bi module.BlockInfo, txs module.Transa
 	tr := &transition{
 		transitionBase: parent.transitionBase,
 
-		pid:    new(transitionID),
+		pid:    parent.id,
+		id:     new(transitionID),
 		parent: parent,
 
 		state: state,
@@ -533,7 +618,8 @@ func createSyncTransition(tr *transition) *transition {
 	return &transition{
 		transitionBase: tr.transitionBase,
 
-		pid:    new(transitionID),
+		pid:    tr.pid,
+		id:     new(transitionID),
 		parent: tr.parent,
 
 		state: stepNeedSync,
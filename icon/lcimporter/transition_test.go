@@ -0,0 +1,424 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcimporter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/icon/blockv0"
+	"github.com/icon-project/goloop/icon/merkle/hexary"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+// testTransitionExecutor is the executor for transitions keeping
+// transactions in memory. Requested transactions not added yet are sent
+// on addTxs.
+type testTransitionExecutor struct {
+	lock      sync.Mutex
+	txs       map[int64]*BlockTransaction
+	waiter    *testTxWaiter
+	synced    []*BlockTransaction
+	finalized int64
+	votes     *blockv0.BlockVoteList
+	metric    *metric.LCImportMetric
+}
+
+type testTxWaiter struct {
+	from, to int64
+	cb       OnBlockTransactions
+}
+
+func (e *testTransitionExecutor) collectInLock(from, to int64) []*BlockTransaction {
+	txs := make([]*BlockTransaction, 0, int(to-from+1))
+	for h := from; h <= to; h++ {
+		tx, ok := e.txs[h]
+		if !ok {
+			return nil
+		}
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func (e *testTransitionExecutor) notifyInLock() {
+	if w := e.waiter; w != nil {
+		if txs := e.collectInLock(w.from, w.to); txs != nil {
+			e.waiter = nil
+			go w.cb(txs, nil)
+		}
+	}
+}
+
+func (e *testTransitionExecutor) addTxs(txs []*BlockTransaction) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for _, tx := range txs {
+		e.txs[tx.Height] = tx
+	}
+	e.notifyInLock()
+}
+
+func (e *testTransitionExecutor) hasWaiter() bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.waiter != nil
+}
+
+func (e *testTransitionExecutor) GetTransactions(from, to int64, callback OnBlockTransactions) (Canceler, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if to < from {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidRequest(from=%d,to=%d)", from, to)
+	}
+	if w := e.waiter; w != nil {
+		go w.cb(nil, errors.ErrInterrupted)
+	}
+	w := &testTxWaiter{from: from, to: to, cb: callback}
+	e.waiter = w
+	e.notifyInLock()
+	return func() {
+		e.lock.Lock()
+		defer e.lock.Unlock()
+		if e.waiter == w {
+			e.waiter = nil
+			go w.cb(nil, errors.InterruptedError.New("Canceled"))
+		}
+	}, nil
+}
+
+func (e *testTransitionExecutor) SyncTransactions(txs []*BlockTransaction) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.synced = append(e.synced, txs...)
+	for _, tx := range txs {
+		e.txs[tx.Height] = tx
+	}
+	return nil
+}
+
+func (e *testTransitionExecutor) GetMerkleHeader(height int64) (*hexary.MerkleHeader, error) {
+	return &hexary.MerkleHeader{
+		RootHash: crypto.SHA3Sum256([]byte(fmt.Sprintf("ROOT[%d]", height))),
+		Leaves:   height,
+	}, nil
+}
+
+func (e *testTransitionExecutor) FinalizeTransactions(to int64) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.finalized = to
+	return nil
+}
+
+func (e *testTransitionExecutor) FinalizeBlocks(height int64) (*hexary.MerkleHeader, *blockv0.BlockVoteList, error) {
+	e.lock.Lock()
+	e.finalized = height
+	e.lock.Unlock()
+
+	mh, err := e.GetMerkleHeader(height + 1)
+	return mh, e.votes, err
+}
+
+func (e *testTransitionExecutor) Metric() *metric.LCImportMetric {
+	return e.metric
+}
+
+func newTestTransitionExecutor() *testTransitionExecutor {
+	return &testTransitionExecutor{
+		txs:       make(map[int64]*BlockTransaction),
+		finalized: -1,
+		votes:     new(blockv0.BlockVoteList),
+		metric:    metric.NewLCImportMetric(context.Background()),
+	}
+}
+
+func newTestTransactionList(dbase db.Database, txs []*BlockTransaction) module.TransactionList {
+	wtxs := make([]module.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		wtxs = append(wtxs, transaction.Wrap(tx))
+	}
+	return transaction.NewTransactionListFromSlice(dbase, wtxs)
+}
+
+func TestTransition_Equal(t *testing.T) {
+	idb := db.NewMapDB()
+	sm := &ServiceManager{log: log.GlobalLogger()}
+
+	txs1 := transaction.NewTransactionListFromSlice(idb, []module.Transaction{
+		buildTestTx(1, "OK"),
+	})
+	txs2 := transaction.NewTransactionListFromSlice(idb, []module.Transaction{
+		buildTestTx(1, "OTHER"),
+	})
+	bi1 := common.NewBlockInfo(1, 10)
+	bi2 := common.NewBlockInfo(1, 20)
+
+	tr0 := createInitialTransition(idb, nil, nil, sm, nil)
+	assert.True(t, tr0.Equal(tr0))
+	assert.False(t, tr0.Equal(nil))
+
+	// proposed and imported on the same parent
+	proposed := createTransition(tr0, bi1, txs1, true)
+	imported := createTransition(tr0, bi1, txs1, false)
+	assert.True(t, proposed.Equal(imported))
+	assert.True(t, imported.Equal(proposed))
+	assert.True(t, proposed.Equal(createSyncTransition(imported)))
+
+	assert.False(t, proposed.Equal(createTransition(tr0, bi2, txs1, false)))
+	assert.False(t, proposed.Equal(createTransition(tr0, bi1, txs2, false)))
+
+	// parents with the same result
+	tr0b := createInitialTransition(idb, nil, nil, sm, nil)
+	assert.True(t, tr0.Equal(tr0b))
+	assert.True(t, proposed.Equal(createTransition(tr0b, bi1, txs1, false)))
+
+	// parents with unknown results
+	tr1 := createTransition(tr0, bi1, txs1, false)
+	tr1b := createTransition(tr0, bi1, txs1, false)
+	assert.True(t, tr1.Equal(tr1b))
+	assert.False(t, createTransition(tr1, bi2, txs1, false).Equal(
+		createTransition(tr1b, bi2, txs1, false)))
+}
+
+func newTestTransitionForCheck(ex *Executor) *transition {
+	sm := &ServiceManager{log: log.GlobalLogger()}
+	tr := createInitialTransition(db.NewMapDB(), nil, nil, sm, ex)
+	tr.state = stepExecuting
+	tr.chn = make(chan interface{}, 2)
+	return tr
+}
+
+func TestTransition_CheckTransactions(t *testing.T) {
+	rdb := db.NewMapDB()
+	idb := db.NewMapDB()
+	logger := log.GlobalLogger()
+	bc := newTestBlockConverter(rdb)
+	ex, err := NewExecutorWithBC(rdb, idb, logger, bc)
+	assert.NoError(t, err)
+	assert.NoError(t, ex.Start())
+	assert.NoError(t, ex.FinalizeTransactions(-1))
+
+	size := int64(TransactionsPerCheck*2 + 10)
+	txs := buildTestTxs(0, size-1, "OK")
+	toBC := make(chan string, 1)
+	go func() {
+		req := <-bc.channel
+		req.sendTxs(txs[:TransactionsPerCheck+1])
+		assert.Equal(t, "send_remain", <-toBC)
+		req.sendTxs(txs[TransactionsPerCheck+1:])
+		assert.Equal(t, "quit", <-toBC)
+		req.interrupt()
+	}()
+
+	t.Log("cancel while it waits for the second window")
+	tr := newTestTransitionForCheck(ex)
+	done := make(chan error, 1)
+	go func() {
+		done <- tr.checkTransactions(txs)
+	}()
+	time.Sleep(delayForConfirm)
+	assert.True(t, tr.cancel())
+	assert.ErrorIs(t, <-done, ErrCanceled)
+
+	toBC <- "send_remain"
+
+	t.Log("check all windows")
+	tr = newTestTransitionForCheck(ex)
+	assert.NoError(t, tr.checkTransactions(txs))
+
+	t.Log("different transaction in the last window")
+	otxs := append([]*BlockTransaction{}, txs...)
+	otxs[size-1] = buildTestTx(size-1, "OTHER")
+	tr = newTestTransitionForCheck(ex)
+	err = tr.checkTransactions(otxs)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "DifferentTx(idx=1009")
+
+	toBC <- "quit"
+	time.Sleep(delayForConfirm)
+	ex.Term()
+}
+
+func newTestServiceManagerForTransition(t *testing.T, ps BlockV1ProofStorage) (*ServiceManager, db.Database) {
+	idb := db.NewMapDB()
+	chain := &testChain{
+		log: log.GlobalLogger(),
+		idb: idb,
+	}
+	vls := []*common.Address{
+		common.MustNewAddressFromString("hx01"),
+	}
+	sm, err := NewServiceManagerWithExecutor(chain, nil, ps, vls, nil)
+	assert.NoError(t, err)
+	return sm, idb
+}
+
+func executeTransition(t *testing.T, tr *transition) (validate, execute error) {
+	tcb := testTransitionCallback(make(chan error, 2))
+	_, err := tr.Execute(tcb)
+	assert.NoError(t, err)
+	if validate = <-tcb; validate != nil {
+		return validate, nil
+	}
+	return nil, <-tcb
+}
+
+func executeGenesisTransition(t *testing.T, sm *ServiceManager, idb db.Database, ex ITransitionExecutor) *transition {
+	tr0 := createInitialTransition(idb, nil, nil, sm, ex)
+	gtxs := newTestTransactionList(idb, []*BlockTransaction{buildTestTx(0, "GENESIS")})
+	tr1 := createTransition(tr0, common.NewBlockInfo(0, 0), gtxs, false)
+	verr, eerr := executeTransition(t, tr1)
+	assert.NoError(t, verr)
+	assert.NoError(t, eerr)
+	assert.Equal(t, sm.getInitialValidators().Hash(), tr1.NextValidators().Hash())
+	return tr1
+}
+
+func TestTransition_Execute(t *testing.T) {
+	ex := newTestTransitionExecutor()
+	sm, idb := newTestServiceManagerForTransition(t, &testProofStorage{})
+	tr1 := executeGenesisTransition(t, sm, idb, ex)
+
+	t.Log("execute with checking transactions")
+	txs := buildTestTxs(0, 4, "OK")
+	ex.addTxs(txs)
+	tr2 := createTransition(tr1, common.NewBlockInfo(1, 10), newTestTransactionList(idb, txs), false)
+	verr, eerr := executeTransition(t, tr2)
+	assert.NoError(t, verr)
+	assert.NoError(t, eerr)
+	assert.NotNil(t, tr2.Result())
+	assert.EqualValues(t, 5, tr2.getNextHeight())
+	mh, _ := ex.GetMerkleHeader(0)
+	assert.Equal(t, mh, tr2.getMerkleHeader())
+
+	t.Log("execute with different transactions")
+	otxs := buildTestTxs(0, 4, "OTHER")
+	tr2x := createTransition(tr1, common.NewBlockInfo(1, 10), newTestTransactionList(idb, otxs), false)
+	verr, _ = executeTransition(t, tr2x)
+	assert.Error(t, verr)
+	assert.Nil(t, tr2x.Result())
+
+	t.Log("execute proposed without checking")
+	txs2 := buildTestTxs(5, 9, "OK")
+	tr3 := createTransition(tr2, common.NewBlockInfo(2, 20), newTestTransactionList(idb, txs2), true)
+	verr, eerr = executeTransition(t, tr3)
+	assert.NoError(t, verr)
+	assert.NoError(t, eerr)
+	assert.False(t, ex.hasWaiter())
+	assert.EqualValues(t, 10, tr3.getNextHeight())
+
+	assert.NoError(t, sm.Finalize(tr2, module.FinalizeResult))
+	assert.EqualValues(t, 4, ex.finalized)
+}
+
+func TestTransition_CancelWhileExecuting(t *testing.T) {
+	ex := newTestTransitionExecutor()
+	sm, idb := newTestServiceManagerForTransition(t, &testProofStorage{})
+
+	tr0 := createInitialTransition(idb, nil, nil, sm, ex)
+	txs := buildTestTxs(0, 4, "OK")
+	tr1 := createTransition(tr0, common.NewBlockInfo(1, 10), newTestTransactionList(idb, txs), false)
+
+	tcb := testTransitionCallback(make(chan error, 2))
+	cancel, err := tr1.Execute(tcb)
+	assert.NoError(t, err)
+	time.Sleep(delayForConfirm)
+	assert.True(t, ex.hasWaiter())
+
+	assert.True(t, cancel())
+	assert.ErrorIs(t, <-tcb, ErrCanceled)
+	assert.False(t, ex.hasWaiter())
+	assert.Nil(t, tr1.Result())
+
+	// transactions arriving after cancel are ignored
+	ex.addTxs(txs)
+	select {
+	case err := <-tcb:
+		assert.Failf(t, "unexpected callback", "err=%v", err)
+	case <-time.After(delayForConfirm):
+	}
+}
+
+func TestTransition_Sync(t *testing.T) {
+	ex := newTestTransitionExecutor()
+	sm, idb := newTestServiceManagerForTransition(t, &testProofStorage{})
+
+	tr0 := createInitialTransition(idb, nil, nil, sm, ex)
+	ex.addTxs(buildTestTxs(0, 4, "OK"))
+	txs := buildTestTxs(0, 4, "OTHER")
+	tr1 := createTransition(tr0, common.NewBlockInfo(1, 10), newTestTransactionList(idb, txs), false)
+
+	verr, _ := executeTransition(t, tr1)
+	assert.Error(t, verr)
+
+	tr1s := createSyncTransition(tr1)
+	assert.True(t, tr1.Equal(tr1s))
+	verr, eerr := executeTransition(t, tr1s)
+	assert.NoError(t, verr)
+	assert.NoError(t, eerr)
+	assert.Equal(t, txs, ex.synced)
+	assert.NotNil(t, tr1s.Result())
+}
+
+func TestTransition_FinalizeLastBlock(t *testing.T) {
+	ex := newTestTransitionExecutor()
+	ps := &testProofStorage{}
+	sm, idb := newTestServiceManagerForTransition(t, ps)
+	tr0 := executeGenesisTransition(t, sm, idb, ex)
+
+	txs := buildTestTxs(0, 4, "OK")
+	tr1 := createTransition(tr0, common.NewBlockInfo(1, 10), newTestTransactionList(idb, txs), true)
+	verr, eerr := executeTransition(t, tr1)
+	assert.NoError(t, verr)
+	assert.NoError(t, eerr)
+	assert.NoError(t, sm.Finalize(tr1, module.FinalizeResult))
+
+	mh, _ := ex.GetMerkleHeader(5)
+	last := []*BlockTransaction{{
+		Height: mh.Leaves,
+		Result: mh.RootHash,
+	}}
+	tr2 := createTransition(tr1, common.NewBlockInfo(2, 20), newTestTransactionList(idb, last), false)
+	verr, eerr = executeTransition(t, tr2)
+	assert.NoError(t, verr)
+	assert.NoError(t, eerr)
+	assert.EqualValues(t, 4, tr2.getLastHeight())
+	assert.EqualValues(t, 6, tr2.getNextHeight())
+
+	assert.NoError(t, sm.Finalize(tr2, module.FinalizeResult))
+	assert.EqualValues(t, 4, ex.finalized)
+	assert.Equal(t, mh.RootHash, ps.Root)
+	assert.Equal(t, mh.Leaves, ps.Size)
+	assert.EqualValues(t, 6, sm.GetImportedBlocks())
+}
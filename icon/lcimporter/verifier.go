@@ -0,0 +1,169 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcimporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/trie/cache"
+	"github.com/icon-project/goloop/icon/blockv0/lcstore"
+	"github.com/icon-project/goloop/icon/merkle/hexary"
+	"github.com/icon-project/goloop/module"
+)
+
+// VerifyReport is the result of the verification of the legacy blocks.
+// AccumulatorRoot is the root of block hashes from the first block to
+// Height, which is the merkle root to be used for the block v1 proof.
+type VerifyReport struct {
+	Height          int64           `json:"height"`
+	Blocks          int64           `json:"blocks"`
+	Transactions    int64           `json:"transactions"`
+	AccumulatorRoot common.HexBytes `json:"accumulatorRoot,omitempty"`
+	AccumulatorSize int64           `json:"accumulatorSize"`
+	Elapsed         string          `json:"elapsed"`
+	Error           string          `json:"error,omitempty"`
+}
+
+// Verifier executes and verifies the legacy blocks without making blocks
+// for the target chain. As BlockConverter does for the import, each block
+// is checked with its previous block, and the receipts, logs bloom and
+// validators of each block are compared with the ones in the store.
+type Verifier struct {
+	bc  *BlockConverter
+	acc hexary.Accumulator
+	log log.Logger
+
+	lock   sync.Mutex
+	report VerifyReport
+}
+
+// NewVerifier returns a verifier using dbase as the temporal database.
+// The database should be empty, and it's of no use after the verification.
+func NewVerifier(chain module.Chain, dbase db.Database, cfg *Config) (*Verifier, error) {
+	logger := chain.Logger()
+	rdb := cache.AttachManager(dbase, "", 5, 0, 0)
+	chain = NewChain(chain, rdb)
+	store, err := lcstore.OpenStore(cfg.StoreURI, cfg.MaxRPS)
+	if err != nil {
+		return nil, err
+	}
+	cs := lcstore.NewForwardCache(store, logger, &cfg.CacheConfig)
+	cs.SetReceiptParameter(rdb, module.LatestRevision)
+	svc := &BasicService{
+		Chain:   chain,
+		Plt:     cfg.Platform,
+		BaseDir: cfg.BaseDir,
+		pm:      cfg.ProxyMgr,
+	}
+	bc, err := NewBlockConverterWithService(chain, cfg.Platform, cs, cfg.BaseDir, svc)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifierWithConverter(bc, rdb, logger)
+}
+
+// NewVerifierWithConverter returns a verifier executing blocks with the
+// block converter. dbase is used for the accumulator of block hashes.
+func NewVerifierWithConverter(bc *BlockConverter, dbase db.Database, logger log.Logger) (*Verifier, error) {
+	acc, err := newAccumulator(dbase, dbase)
+	if err != nil {
+		return nil, errors.Wrap(err, "FailInAccumulator")
+	}
+	return &Verifier{
+		bc:     bc,
+		acc:    acc,
+		log:    logger,
+		report: VerifyReport{Height: -1},
+	}, nil
+}
+
+// Run verifies blocks from the first one to the height. If to is negative,
+// it verifies all blocks until the last block of ICON 1.
+func (v *Verifier) Run(to int64) (*VerifyReport, error) {
+	start := time.Now()
+	err := v.run(to)
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.report.AccumulatorSize > 0 {
+		v.report.AccumulatorRoot = v.acc.GetMerkleHeader().RootHash
+	}
+	v.report.Elapsed = time.Since(start).Round(time.Second).String()
+	if err != nil {
+		v.report.Error = err.Error()
+	}
+	report := v.report
+	return &report, err
+}
+
+func (v *Verifier) run(to int64) error {
+	chn, err := v.bc.Start(-1, to)
+	if err != nil {
+		return err
+	}
+	for obj := range chn {
+		switch obj := obj.(type) {
+		case *BlockTransaction:
+			if err := v.add(obj); err != nil {
+				v.bc.Term()
+				return err
+			}
+		case error:
+			if errors.Is(obj, ErrAfterLastBlock) {
+				return nil
+			}
+			return obj
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) add(btx *BlockTransaction) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if btx.Height != v.acc.Len() {
+		return errors.InvalidStateError.Errorf("InvalidBlockHeight(height=%d,exp=%d)",
+			btx.Height, v.acc.Len())
+	}
+	if err := v.acc.Add(btx.BlockHash); err != nil {
+		return err
+	}
+	v.report.Height = btx.Height
+	v.report.Blocks += 1
+	v.report.Transactions += int64(btx.TXCount)
+	v.report.AccumulatorSize = v.acc.Len()
+	return nil
+}
+
+// Height returns the height of the last verified block.
+func (v *Verifier) Height() int64 {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.report.Height
+}
+
+// Stop stops the verification.
+func (v *Verifier) Stop() {
+	v.bc.Term()
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lcimporter_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/icon/ictest"
+	"github.com/icon-project/goloop/icon/lcimporter"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+func TestVerifier_Match(t_ *testing.T) {
+	t := newBlockConverterTest(t_)
+	v, err := lcimporter.NewVerifierWithConverter(t.BlockConverter, db.NewMapDB(), t.chain.Logger())
+	assert.NoError(t, err)
+	assert.EqualValues(t, -1, v.Height())
+
+	report, err := v.Run(1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, report.Height)
+	assert.EqualValues(t, 2, report.Blocks)
+	assert.EqualValues(t, 2, report.Transactions)
+	assert.EqualValues(t, 2, report.AccumulatorSize)
+	assert.NotEmpty(t, report.AccumulatorRoot)
+	assert.Empty(t, report.Error)
+	assert.EqualValues(t, 1, v.Height())
+}
+
+func TestVerifier_Mismatch(t_ *testing.T) {
+	dbase := db.NewMapDB()
+	s, err := newTestStore(dbase)
+	assert.NoError(t_, err)
+
+	// receipt of the genesis transaction with different steps
+	id, _ := hex.DecodeString("5aa2453a84ba2fb1e3394b9e3471f5dcebc6225fc311a97ca505728153b9d246")
+	rct, err := txresult.NewReceiptFromJSON(dbase, module.LatestRevision, []byte(`{
+		"cumulativeStepUsed": "0x1",
+		"stepUsed": "0x1",
+		"stepPrice": "0x0",
+		"status": "0x1"
+	}`))
+	assert.NoError(t_, err)
+	s.receipts[string(id)] = rct
+
+	t := newBlockConverterTest2(t_, dbase, s, ictest.NewPlatform())
+	v, err := lcimporter.NewVerifierWithConverter(t.BlockConverter, db.NewMapDB(), t.chain.Logger())
+	assert.NoError(t, err)
+
+	// the genesis is verified, but the block executing its transaction fails
+	report, err := v.Run(1)
+	assert.Error(t, err)
+	assert.Contains(t, report.Error, "ReceiptComparisonFailure")
+	assert.EqualValues(t, 0, report.Height)
+	assert.EqualValues(t, 1, report.Blocks)
+	assert.EqualValues(t, 0, v.Height())
+}
@@ -40,6 +40,14 @@ type Accumulator interface {
 
 	// Finalize finalizes node data
 	Finalize() (header *MerkleHeader, err error)
+
+	// Export exports the accumulator of to leaves for the accumulator of
+	// from leaves.
+	Export(from, to int64) (*AccumulatorExport, error)
+
+	// Import extends the accumulator with the export. Len() shall be same
+	// as From of the export.
+	Import(ex *AccumulatorExport) error
 }
 
 type accumulatorData struct {
@@ -97,14 +105,25 @@ func (ba *accumulator) SetLen(l int64) error {
 	if l == ba.data.Len {
 		return nil
 	}
+	roots, err := ba.rootsForLen(l)
+	if err != nil {
+		return err
+	}
+	ba.data = accumulatorData{ l, roots }
+	return ba.accumulatorBucket.Set(db.Raw(ba.accumulatorDataKey), &ba.data)
+}
+
+// rootsForLen returns roots of the accumulator for the length. l shall be
+// in (0, Len()).
+func (ba *accumulator) rootsForLen(l int64) ([]*node, error) {
 	hd, err := ba.Finalize()
 	mt, err := NewMerkleTree(ba.treeBucket, hd, 0)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	proof, err := mt.Prove(l-1, 0)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	lvl := LevelFromLen(l)
 	if powerOf16(uint64(l)) {
@@ -126,13 +145,12 @@ func (ba *accumulator) SetLen(l int64) error {
 		copied := append([]byte(nil), proof[len(proof)-1-i]...)
 		roots[i], err = newNodeFromBytes(copied)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		roots[i].SetLen(int(d%16))
 		d = d/16
 	}
-	ba.data = accumulatorData{ l, roots }
-	return ba.accumulatorBucket.Set(db.Raw(ba.accumulatorDataKey), &ba.data)
+	return roots, nil
 }
 
 func (ba *accumulator) add(i int, hash []byte) error {
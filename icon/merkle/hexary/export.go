@@ -0,0 +1,206 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hexary
+
+import (
+	"bytes"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+// AccumulatorExport is the exported accumulator for the leaves from From to
+// Header.Leaves. Nodes are the full nodes of the merkle tree which are not
+// included in the accumulator of From leaves, and Roots are the nodes of
+// each level for extending the accumulator.
+type AccumulatorExport struct {
+	From   int64
+	Header MerkleHeader
+	Roots  [][]byte
+	Nodes  [][]byte
+}
+
+// walkFullNodes calls cb for the full nodes in [0, to) of the tree except
+// the ones in [0, from), which are already in the accumulator of from leaves.
+// Children are visited before their parent.
+func walkFullNodes(ndb *nodeDB, hash []byte, start, span, from, to int64, cb func(n *node) error) error {
+	if start >= to || start+span <= from {
+		return nil
+	}
+	n, err := ndb.Get(hash)
+	if err != nil {
+		return err
+	}
+	if span > maxChildren {
+		cs := span / maxChildren
+		for i := 0; i < n.Len(); i++ {
+			err := walkFullNodes(ndb, n.Get(i), start+int64(i)*cs, cs, from, to, cb)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if start+span <= to {
+		return cb(n)
+	}
+	return nil
+}
+
+func (ba *accumulator) walkFullNodes(from, to int64, cb func(n *node) error) error {
+	if to < maxChildren {
+		return nil
+	}
+	hd, err := ba.Finalize()
+	if err != nil {
+		return err
+	}
+	span := int64(1) << (4 * uint(LevelFromLen(hd.Leaves)))
+	ndb := newCachedNodeDB(ba.treeBucket, 0)
+	return walkFullNodes(ndb, hd.RootHash, 0, span, from, to, cb)
+}
+
+func (ba *accumulator) rootsOrCurrent(l int64) ([]*node, error) {
+	if l == ba.data.Len {
+		roots := make([]*node, len(ba.data.Roots))
+		for i, r := range ba.data.Roots {
+			roots[i] = &node{bytes: append([]byte(nil), r.bytes...)}
+		}
+		return roots, nil
+	}
+	return ba.rootsForLen(l)
+}
+
+// Export exports the accumulator of to leaves. The accumulator of from
+// leaves can be extended with it by Import.
+func (ba *accumulator) Export(from, to int64) (*AccumulatorExport, error) {
+	if from < 0 || from > to || to > ba.data.Len {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidRange(from=%d,to=%d,len=%d)", from, to, ba.data.Len)
+	}
+	ex := &AccumulatorExport{
+		From:   from,
+		Header: MerkleHeader{Leaves: to},
+	}
+	if to == 0 {
+		return ex, nil
+	}
+	roots, err := ba.rootsOrCurrent(to)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range roots {
+		ex.Roots = append(ex.Roots, r.Bytes())
+	}
+	ex.Header = *(&accumulator{data: accumulatorData{to, roots}}).GetMerkleHeader()
+	err = ba.walkFullNodes(from, to, func(n *node) error {
+		ex.Nodes = append(ex.Nodes, append([]byte(nil), n.Bytes()...))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ex, nil
+}
+
+func equalRoots(r1, r2 []*node) bool {
+	if len(r1) != len(r2) {
+		return false
+	}
+	for i := range r1 {
+		if !bytes.Equal(r1[i].Bytes(), r2[i].Bytes()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Import extends the accumulator with the exported one. Len() of the
+// accumulator shall be same as From of the export. It returns wrapped
+// ErrVerify if the export doesn't match the header or doesn't extend
+// the accumulator.
+func (ba *accumulator) Import(ex *AccumulatorExport) error {
+	if ex.From != ba.data.Len || ex.Header.Leaves < ex.From {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidImport(from=%d,leaves=%d,len=%d)", ex.From, ex.Header.Leaves, ba.data.Len)
+	}
+	roots := make([]*node, len(ex.Roots))
+	for i, bs := range ex.Roots {
+		r, err := newNodeFromBytes(append([]byte(nil), bs...))
+		if err != nil {
+			return errors.Wrapf(ErrVerify, "InvalidRoot(idx=%d)", i)
+		}
+		roots[i] = r
+	}
+	data := accumulatorData{ex.Header.Leaves, roots}
+	hd := (&accumulator{data: data}).GetMerkleHeader()
+	if !bytes.Equal(hd.RootHash, ex.Header.RootHash) {
+		return errors.Wrapf(ErrVerify, "InvalidRootHash(exp=%#x,calc=%#x)",
+			ex.Header.RootHash, hd.RootHash)
+	}
+	for _, bs := range ex.Nodes {
+		n, err := newNodeFromBytes(bs)
+		if err != nil {
+			return errors.Wrap(ErrVerify, "InvalidNode")
+		}
+		if err := ba.treeBucket.Set(n.Hash(), n.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	old := ba.data
+	ba.data = data
+	err := ba.verifyImport(old)
+	if err != nil {
+		ba.data = old
+		return err
+	}
+	return ba.accumulatorBucket.Set(db.Raw(ba.accumulatorDataKey), &ba.data)
+}
+
+// verifyImport checks whether all full nodes are available and the
+// accumulator extends the old one.
+func (ba *accumulator) verifyImport(old accumulatorData) error {
+	if err := ba.walkFullNodes(0, ba.data.Len, func(n *node) error {
+		return nil
+	}); err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return errors.Wrap(ErrVerify, "MissingNode")
+		}
+		return err
+	}
+	if old.Len == 0 || old.Len == ba.data.Len {
+		if old.Len != 0 && !equalRoots(old.Roots, ba.data.Roots) {
+			return errors.Wrap(ErrVerify, "DifferentRoots")
+		}
+		return nil
+	}
+	roots, err := ba.rootsForLen(old.Len)
+	if err != nil {
+		return err
+	}
+	if !equalRoots(trimEmptyRoots(old.Roots), trimEmptyRoots(roots)) {
+		return errors.Wrapf(ErrVerify, "NotExtending(len=%d)", old.Len)
+	}
+	return nil
+}
+
+func trimEmptyRoots(roots []*node) []*node {
+	for len(roots) > 0 && roots[len(roots)-1].Empty() {
+		roots = roots[:len(roots)-1]
+	}
+	return roots
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hexary_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/merkle/hexary"
+)
+
+func addLeaves(t *testing.T, hac hexary.Accumulator, from, to int) {
+	for i := from; i < to; i++ {
+		err := hac.Add(crypto.SHA3Sum256(codec.MustMarshalToBytes(i)))
+		assert.NoError(t, err)
+	}
+}
+
+func TestAccumulator_ExportImport(t *testing.T) {
+	src := newAccumulator(t, nil)
+	addLeaves(t, src, 0, 300)
+
+	for _, tc := range []struct{ from, to int }{
+		{0, 0}, {0, 1}, {0, 16}, {0, 17}, {0, 256}, {0, 300},
+		{1, 16}, {16, 17}, {100, 256}, {100, 300}, {256, 300}, {300, 300},
+	} {
+		ex, err := src.Export(int64(tc.from), int64(tc.to))
+		assert.NoError(t, err)
+
+		exp := newAccumulator(t, nil)
+		addLeaves(t, exp, 0, tc.to)
+		assert.Equal(t, exp.GetMerkleHeader(), &ex.Header, "from=%d to=%d", tc.from, tc.to)
+
+		dst := newAccumulator(t, nil)
+		addLeaves(t, dst, 0, tc.from)
+		assert.NoError(t, dst.Import(ex), "from=%d to=%d", tc.from, tc.to)
+		assert.Equal(t, exp.GetMerkleHeader(), dst.GetMerkleHeader())
+
+		// imported accumulator can be extended and rewound
+		addLeaves(t, dst, tc.to, 320)
+		assert.Equal(t, src.GetMerkleHeader().Leaves+20, dst.GetMerkleHeader().Leaves)
+		assert.NoError(t, dst.SetLen(int64(tc.to)))
+		assert.Equal(t, exp.GetMerkleHeader(), dst.GetMerkleHeader())
+		if tc.to > 0 {
+			assert.NoError(t, dst.SetLen(int64(tc.to-1)))
+			assert.NoError(t, exp.SetLen(int64(tc.to-1)))
+			assert.Equal(t, exp.GetMerkleHeader(), dst.GetMerkleHeader())
+		}
+	}
+
+	_, err := src.Export(10, 9)
+	assert.Error(t, err)
+	_, err = src.Export(0, 301)
+	assert.Error(t, err)
+}
+
+func TestAccumulator_ImportInvalid(t *testing.T) {
+	src := newAccumulator(t, nil)
+	addLeaves(t, src, 0, 300)
+	ex, err := src.Export(100, 300)
+	assert.NoError(t, err)
+
+	// length is different
+	dst := newAccumulator(t, nil)
+	addLeaves(t, dst, 0, 99)
+	assert.Error(t, dst.Import(ex))
+
+	// leaves are different
+	dst = newAccumulator(t, nil)
+	addLeaves(t, dst, 1, 101)
+	err = dst.Import(ex)
+	assert.True(t, errors.Is(err, hexary.ErrVerify))
+	assert.EqualValues(t, 100, dst.Len())
+
+	// nodes are missing
+	dst = newAccumulator(t, nil)
+	addLeaves(t, dst, 0, 100)
+	ex2 := *ex
+	ex2.Nodes = ex.Nodes[1:]
+	err = dst.Import(&ex2)
+	assert.True(t, errors.Is(err, hexary.ErrVerify))
+
+	// roots don't match the header
+	ex2 = *ex
+	ex2.Header.RootHash = crypto.SHA3Sum256([]byte("invalid"))
+	err = dst.Import(&ex2)
+	assert.True(t, errors.Is(err, hexary.ErrVerify))
+	assert.EqualValues(t, 100, dst.Len())
+}
@@ -22,6 +22,7 @@ import (
 	"math/big"
 	"os"
 	"path"
+	"sync"
 
 	"github.com/icon-project/goloop/block"
 	"github.com/icon-project/goloop/chain/base"
@@ -52,6 +53,10 @@ import (
 type platform struct {
 	calculator iiss.CalculatorHolder
 	base       string
+
+	roleLock    sync.Mutex
+	roleHandler base.NodeRoleHandler
+	roleTerm    int
 }
 
 func (p *platform) NewContractManager(dbase db.Database, dir string, logger log.Logger) (contract.ContractManager, error) {
@@ -117,6 +122,78 @@ func (p *platform) NewBaseTransaction(wc state.WorldContext) (module.Transaction
 func (p *platform) OnExtensionSnapshotFinalization(ess state.ExtensionSnapshot, logger log.Logger) {
 	// Start background calculator if it's not started.
 	p.calculator.Start(ess, logger)
+	p.reportElectedNodes(ess)
+}
+
+// Inspect returns the progress of the reward calculation.
+func (p *platform) Inspect(informal bool) map[string]interface{} {
+	c := p.calculator.Get()
+	if c == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"calculator": c.Progress(),
+	}
+}
+
+func (p *platform) SetNodeRoleHandler(h base.NodeRoleHandler) {
+	p.roleLock.Lock()
+	defer p.roleLock.Unlock()
+
+	p.roleHandler = h
+	p.roleTerm = -1
+}
+
+// electedNodesGetter is implemented by iiss.ExtensionSnapshotImpl.
+type electedNodesGetter interface {
+	GetElectedNodes() (int, []module.Address, []module.Address)
+}
+
+// reportElectedNodes notifies main and sub P-Reps to the handler whenever
+// a new term begins.
+func (p *platform) reportElectedNodes(ess state.ExtensionSnapshot) {
+	p.roleLock.Lock()
+	defer p.roleLock.Unlock()
+
+	if p.roleHandler == nil {
+		return
+	}
+	if ess == nil {
+		return
+	}
+	essi, ok := ess.(electedNodesGetter)
+	if !ok {
+		return
+	}
+	seq, main, sub := essi.GetElectedNodes()
+	if seq < 0 || seq == p.roleTerm {
+		return
+	}
+	p.roleTerm = seq
+	p.roleHandler(main, sub)
+}
+
+// nodePowersGetter is implemented by iiss.ExtensionSnapshotImpl.
+type nodePowersGetter interface {
+	GetPowersOfNodes(nodes []module.Address) []*big.Int
+}
+
+// GetValidatorWeights returns the powers of P-Reps for the validators, so
+// proposers are selected in proportion to their bonded delegation.
+func (p *platform) GetValidatorWeights(wss state.WorldSnapshot, validators module.ValidatorList) ([]*big.Int, error) {
+	essi, ok := wss.GetExtensionSnapshot().(nodePowersGetter)
+	if !ok {
+		return nil, nil
+	}
+	nodes := make([]module.Address, validators.Len())
+	for i := range nodes {
+		v, ok := validators.Get(i)
+		if !ok {
+			return nil, errors.InvalidStateError.Errorf("InvalidValidatorIndex(idx=%d)", i)
+		}
+		nodes[i] = v.Address()
+	}
+	return essi.GetPowersOfNodes(nodes), nil
 }
 
 func checkBaseTX(txs module.TransactionList) bool {
@@ -198,6 +275,12 @@ func (p *platform) OnTransactionEnd(wc state.WorldContext, logger log.Logger, rc
 	return es.OnTransactionEnd(wc.BlockHeight(), success)
 }
 
+func (p *platform) OnRollback(dbase db.Database, height int64) error {
+	// the calculator may be running for the blocks rolled back
+	p.calculator.Start(nil, nil)
+	return iiss.RollbackTo(dbase, height)
+}
+
 func (p *platform) Term() {
 	// Terminate
 }
@@ -23,10 +23,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/wallet"
 	"github.com/icon-project/goloop/icon/blockv0"
+	"github.com/icon-project/goloop/icon/iiss"
 	"github.com/icon-project/goloop/icon/lcimporter"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/state"
 )
 
 func TestPlatform_BlockV1Proof(t *testing.T) {
@@ -52,4 +56,70 @@ func TestPlatform_BlockV1Proof(t *testing.T) {
 	assert.Equal(t, votes.Hash(), votes2.Hash())
 	assert.Equal(t, root, mh2.RootHash)
 	assert.Equal(t, height, mh2.Leaves)
-}
\ No newline at end of file
+}
+
+type testElectedNodes struct {
+	state.ExtensionSnapshot
+	seq  int
+	main []module.Address
+	sub  []module.Address
+}
+
+func (s *testElectedNodes) GetElectedNodes() (int, []module.Address, []module.Address) {
+	return s.seq, s.main, s.sub
+}
+
+func TestPlatform_ReportElectedNodes(t *testing.T) {
+	addr1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	addr2 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	addr3 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000003")
+
+	type report struct {
+		main []module.Address
+		sub  []module.Address
+	}
+	var reports []report
+	handler := func(main, sub []module.Address) {
+		reports = append(reports, report{main, sub})
+	}
+	p := new(platform)
+
+	tests := []struct {
+		name    string
+		handler bool
+		ess     state.ExtensionSnapshot
+		want    *report
+	}{
+		{"NoHandler", false,
+			&testElectedNodes{seq: 1, main: []module.Address{addr1}}, nil},
+		{"NoTerm", true, &testElectedNodes{seq: -1}, nil},
+		{"NotSupported", true, &struct{ state.ExtensionSnapshot }{}, nil},
+		{"NilSnapshot", true, nil, nil},
+		{"NilExtensionSnapshot", true, (*iiss.ExtensionSnapshotImpl)(nil), nil},
+		{"FirstTerm", false,
+			&testElectedNodes{seq: 1, main: []module.Address{addr1}, sub: []module.Address{addr2}},
+			&report{[]module.Address{addr1}, []module.Address{addr2}}},
+		{"SameTerm", false,
+			&testElectedNodes{seq: 1, main: []module.Address{addr2}}, nil},
+		{"NextTerm", false,
+			&testElectedNodes{seq: 2, main: []module.Address{addr2}, sub: []module.Address{addr1, addr3}},
+			&report{[]module.Address{addr2}, []module.Address{addr1, addr3}}},
+		{"NewHandler", true,
+			&testElectedNodes{seq: 2, main: []module.Address{addr2}},
+			&report{[]module.Address{addr2}, nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.handler {
+				p.SetNodeRoleHandler(handler)
+			}
+			reports = nil
+			p.reportElectedNodes(tt.ess)
+			if tt.want == nil {
+				assert.Empty(t, reports)
+			} else {
+				assert.Equal(t, []report{*tt.want}, reports)
+			}
+		})
+	}
+}
@@ -132,6 +132,19 @@ public final class Context {
         return 0L;
     }
 
+    /**
+     * Returns the random seed of the current block.
+     * It's the VRF output of the proposer of the block, so it's verifiable with
+     * the public key of the proposer. The proposer knows it before proposing
+     * the block, but can't choose it.
+     * It's available since the revision enabling VRF proposer selection.
+     *
+     * @return the seed of the current block or {@code null} if it's not available
+     */
+    public static byte[] getBlockSeed() {
+        return null;
+    }
+
     //===================
     // Storage
     //===================
@@ -81,6 +81,7 @@ public class EEProxy extends Proxy {
     public static class Info {
         public static final String BLOCK_TIMESTAMP = "B.timestamp";
         public static final String BLOCK_HEIGHT = "B.height";
+        public static final String BLOCK_SEED = "B.seed";
         public static final String TX_HASH = "T.hash";
         public static final String TX_INDEX = "T.index";
         public static final String TX_FROM = "T.from";
@@ -42,6 +42,7 @@ public class ExternalState implements IExternalState {
     private final int option;
     private final long blockHeight;
     private final long blockTimestamp;
+    private final byte[] blockSeed;
     private final Address owner;
     private final String codePath;
     private final FileIO fileIO;
@@ -54,7 +55,7 @@ public class ExternalState implements IExternalState {
 
     ExternalState(EEProxy proxy, int option, String codePath,
                   FileIO fileIO, byte[] contractID, BigInteger blockHeight,
-                  BigInteger blockTimestamp, Address owner,
+                  BigInteger blockTimestamp, byte[] blockSeed, Address owner,
                   Map<String, BigInteger> stepCosts, long revision, int nextHash,
                   byte[] graphHash) {
         this.proxy = proxy;
@@ -64,6 +65,7 @@ public class ExternalState implements IExternalState {
         this.contractID = contractID;
         this.blockHeight = blockHeight.longValue();
         this.blockTimestamp = blockTimestamp.longValue();
+        this.blockSeed = blockSeed;
         this.owner = owner; // owner cannot be null
         this.stepCost = new StepCost(stepCosts);
         this.revision = revision;
@@ -209,6 +211,12 @@ public class ExternalState implements IExternalState {
         return blockTimestamp;
     }
 
+    @Override
+    public byte[] getBlockSeed() {
+        logger.trace("[getBlockSeed] ret={}", Bytes.toHexString(blockSeed));
+        return blockSeed;
+    }
+
     @Override
     public Address getOwner() {
         logger.trace("[getOwner] ret={}", owner);
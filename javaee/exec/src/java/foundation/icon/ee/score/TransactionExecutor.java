@@ -127,6 +127,7 @@ public class TransactionExecutor {
         boolean isInstall = CMD_INSTALL.equals(method);
         BigInteger blockHeight = (BigInteger) info.get(EEProxy.Info.BLOCK_HEIGHT);
         BigInteger blockTimestamp = (BigInteger) info.get(EEProxy.Info.BLOCK_TIMESTAMP);
+        byte[] blockSeed = (byte[]) info.get(EEProxy.Info.BLOCK_SEED);
         BigInteger nonce = (BigInteger) info.get(EEProxy.Info.TX_NONCE);
         byte[] txHash = (byte[]) info.get(EEProxy.Info.TX_HASH);
         int txIndex = ((BigInteger) info.get(EEProxy.Info.TX_INDEX)).intValue();
@@ -138,7 +139,7 @@ public class TransactionExecutor {
         long revision = ((BigInteger) info.get(EEProxy.Info.REVISION)).longValue();
 
         ExternalState kernel = new ExternalState(proxy, option, code,
-                fileIO, contractID, blockHeight, blockTimestamp, owner,
+                fileIO, contractID, blockHeight, blockTimestamp, blockSeed, owner,
                 stepCosts, revision, nextHash, graphHash);
         Transaction tx = new Transaction(from, to, value, nonce,
                 limit.longValue(), method, params, txHash, txIndex, txTimestamp,
@@ -189,6 +190,7 @@ public class TransactionExecutor {
         logger.trace("    txNonce={}", info.get(EEProxy.Info.TX_NONCE));
         logger.trace("    blockHeight={}", info.get(EEProxy.Info.BLOCK_HEIGHT));
         logger.trace("    blockTimestamp={}", info.get(EEProxy.Info.BLOCK_TIMESTAMP));
+        logger.trace("    blockSeed=0x{}", Bytes.toHexString((byte[]) info.get(EEProxy.Info.BLOCK_SEED)));
         logger.trace("    contractOwner={}", info.get(EEProxy.Info.CONTRACT_OWNER));
         logger.trace("    stepCosts={}", info.get(EEProxy.Info.STEP_COSTS));
     }
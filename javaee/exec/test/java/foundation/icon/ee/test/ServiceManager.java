@@ -52,6 +52,7 @@ public class ServiceManager implements Agent {
         context = new Context(newExternalAddress());
         info.put(Info.BLOCK_TIMESTAMP, BigInteger.valueOf(1000000));
         info.put(Info.BLOCK_HEIGHT, BigInteger.valueOf(10));
+        info.put(Info.BLOCK_SEED, Arrays.copyOf(new byte[]{3, 4}, 32));
         info.put(Info.TX_HASH, Arrays.copyOf(new byte[]{1, 2}, 32));
         info.put(Info.TX_INDEX, BigInteger.valueOf(1));
         info.put(Info.TX_FROM, context.getOrigin());
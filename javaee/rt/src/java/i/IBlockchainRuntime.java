@@ -80,6 +80,13 @@ public interface IBlockchainRuntime {
      */
     long avm_getBlockHeight();
 
+    /**
+     * Block seed.
+     *
+     * @return The random seed of the current block.
+     */
+    ByteArray avm_getBlockSeed();
+
     //================
     // Storage
     //================
@@ -61,6 +61,7 @@ public class BlockchainRuntimeImpl implements IBlockchainRuntime {
     private p.score.Address originCache;
     private p.score.Address ownerCache;
     private ByteArray transactionHashCache;
+    private ByteArray blockSeedCache;
     private s.java.math.BigInteger valueCache;
     private s.java.math.BigInteger nonceCache;
 
@@ -159,6 +160,17 @@ public class BlockchainRuntimeImpl implements IBlockchainRuntime {
         return externalState.getBlockHeight();
     }
 
+    @Override
+    public ByteArray avm_getBlockSeed() {
+        if (null == this.blockSeedCache) {
+            byte[] seed = externalState.getBlockSeed();
+            if (null != seed) {
+                this.blockSeedCache = new ByteArray(seed);
+            }
+        }
+        return this.blockSeedCache;
+    }
+
     @Override
     public s.java.math.BigInteger avm_getBalance(p.score.Address address) {
         Objects.requireNonNull(address, "Address can't be NULL");
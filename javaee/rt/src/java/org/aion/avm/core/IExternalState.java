@@ -145,6 +145,13 @@ public interface IExternalState {
      */
     long getBlockTimestamp();
 
+    /**
+     * Returns the random seed of the current block.
+     *
+     * @return the current block seed, or null if it's not available.
+     */
+    byte[] getBlockSeed();
+
     /**
      * Returns the address of the contract owner
      *
@@ -79,6 +79,11 @@ public final class Context extends Object {
         return blockchainRuntime.avm_getBlockHeight();
     }
 
+    public static ByteArray avm_getBlockSeed() {
+        IInstrumentation.attachedThreadInstrumentation.get().chargeEnergy(RuntimeMethodFeeSchedule.BlockchainRuntime_avm_getBlockSeed);
+        return blockchainRuntime.avm_getBlockSeed();
+    }
+
     public static BigInteger avm_getBalance(Address address) {
         IInstrumentation.attachedThreadInstrumentation.get().chargeEnergy(RuntimeMethodFeeSchedule.BlockchainRuntime_avm_getBalance);
         return blockchainRuntime.avm_getBalance(address);
@@ -164,6 +164,10 @@ type BlockManager interface {
 	// ExportBlocks exports blocks assuring specified block ranges.
 	ExportBlocks(from, to int64, dst db.Database, on ProgressCallback) error
 
+	// ExportHeaders exports block headers with votes and validators of
+	// specified block ranges. Results and transactions are not exported.
+	ExportHeaders(from, to int64, dst db.Database, on ProgressCallback) error
+
 	// ExportGenesis exports genesis to the writer based on the block.
 	ExportGenesis(blk BlockData, votes CommitVoteSet, writer GenesisStorageWriter) error
 
@@ -259,4 +259,8 @@ type DSAModule interface {
 
 	// Verify verifies format of public key.
 	Verify(pubKey []byte) error
+
+	// VerifyProofOfPossession verifies that proof is the signature for msg
+	// made with the private key of pubKey.
+	VerifyProofOfPossession(pubKey, msg, proof []byte) error
 }
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 )
 
 type BaseWallet interface {
@@ -20,6 +23,93 @@ type Wallet interface {
 	Address() Address
 }
 
+// VRFProver is implemented by the wallet which can prove VRF outputs with
+// its key. Validators need it to propose blocks with VRF proposer selection.
+type VRFProver interface {
+	ProveVRF(alpha []byte) ([]byte, error)
+}
+
+// SyncAnchor is the block trusted by the configuration of the chain.
+// Blocks lower than the anchor are imported without verifying signatures
+// of transactions and proofs, and they are trusted if the chain reaches
+// the anchor.
+type SyncAnchor struct {
+	Height int64
+	// BlockID is the ID of the block at the height.
+	BlockID []byte
+	// ValidatorsHash is the hash of next validators of the block.
+	ValidatorsHash []byte
+}
+
+// Kinds of historical queries declared by HistoryCapability.
+const (
+	// HistoryState is for queries of the state at the height, like calls
+	// and balances.
+	HistoryState = "state"
+	// HistoryTrace is for traces of transactions.
+	HistoryTrace = "trace"
+	// HistoryReceipt is for receipts, event logs and proofs of them.
+	HistoryReceipt = "receipt"
+)
+
+// HistoryCapability declares historical queries served by the node.
+// Nil HistoryCapability serves all of them as an archive.
+type HistoryCapability struct {
+	// Depth is the number of the latest blocks served for all queries.
+	Depth int64
+	// Queries are kinds of queries served for blocks older than them.
+	Queries []string
+}
+
+// NewHistoryCapability returns the capability with the depth and comma
+// separated kinds of queries. It returns nil for zero or negative depth.
+func NewHistoryCapability(depth int64, queries string) (*HistoryCapability, error) {
+	hc := &HistoryCapability{Depth: depth}
+	for _, q := range strings.Split(queries, ",") {
+		q = strings.TrimSpace(q)
+		switch q {
+		case "":
+			continue
+		case HistoryState, HistoryTrace, HistoryReceipt:
+			hc.Queries = append(hc.Queries, q)
+		default:
+			return nil, errors.IllegalArgumentError.Errorf("UnknownHistoryQuery(query=%s)", q)
+		}
+	}
+	if depth <= 0 {
+		return nil, nil
+	}
+	return hc, nil
+}
+
+// Serves returns whether the query for the block at the height is served
+// while the last block is at last.
+func (hc *HistoryCapability) Serves(query string, height, last int64) bool {
+	if hc == nil || height > last-hc.Depth {
+		return true
+	}
+	for _, q := range hc.Queries {
+		if q == query {
+			return true
+		}
+	}
+	return false
+}
+
+// ChainTaskStatus is the status of a maintenance task of the chain.
+// Current and Total are the progress of the task if it's reported.
+type ChainTaskStatus struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	Detail    string     `json:"detail,omitempty"`
+	Current   int64      `json:"current,omitempty"`
+	Total     int64      `json:"total,omitempty"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
 type Chain interface {
 	Database() db.Database
 	DoDBTask(func(database db.Database))
@@ -35,9 +125,37 @@ type Chain interface {
 	DefaultWaitTimeout() time.Duration
 	MaxWaitTimeout() time.Duration
 	TransactionTimeout() time.Duration
+	// TxTimestampWindow returns the window of timestamps for transactions
+	// accepted by the pool. Zero means the threshold of the network.
+	TxTimestampWindow() time.Duration
+	// TxPoolTTL returns how long a transaction may stay in the pool.
+	// Zero means no limit.
+	TxPoolTTL() time.Duration
+	// TxPoolDir returns the directory to persist transactions in the pool,
+	// or empty string if they are not persisted.
+	TxPoolDir() string
+	// PriorityTxRatio returns the percentage of transactions in a block
+	// reserved for transactions to the system and the governance.
+	// Zero means no reservation.
+	PriorityTxRatio() int
+	// Sequencer returns the account allowed to submit transaction bundles
+	// to be proposed verbatim, or nil if it's not configured.
+	Sequencer() Address
 	ChildrenLimit() int
 	NephewsLimit() int
 	ValidateTxOnSend() bool
+	RecordInternalCalls() bool
+	RecordAddressIndex() bool
+	RecordTokenIndex() bool
+	// SyncServerThrottle returns the throttle shared by servers of state
+	// sync and block sync for other peers, or nil for no limit.
+	SyncServerThrottle() *throttle.Throttle
+	// SyncAnchor returns the trusted block for fast bootstrap, or nil
+	// if it's not configured.
+	SyncAnchor() *SyncAnchor
+	// HistoryCapability returns historical queries served by the node,
+	// or nil if it serves all of them as an archive.
+	HistoryCapability() *HistoryCapability
 	Genesis() []byte
 	GenesisStorage() GenesisStorage
 	CommitVoteSetDecoder() CommitVoteSetDecoder
@@ -53,9 +171,20 @@ type Chain interface {
 	Start() error
 	Stop() error
 	Import(src string, height int64) error
-	Prune(gs string, dbt string, height int64) error
-	Backup(file string, extra []string) error
+	// Prune rewrites the database to keep blocks from the height with
+	// the latest state. If headers is true, it also keeps headers of
+	// the blocks before the height.
+	Prune(gs string, dbt string, height int64, headers bool) error
+	// Rollback rolls back the chain to the height keeping the database,
+	// so blocks after the height are removed.
+	Rollback(height int64) error
+	// Backup makes a backup of the chain to the file. If base is not empty,
+	// it makes an incremental backup including only changed files after
+	// the base backup.
+	Backup(file string, base string, extra []string) error
 	RunTask(task string, params json.RawMessage) error
+	// Tasks returns status of recent maintenance tasks, the latest first.
+	Tasks() []*ChainTaskStatus
 	Term() error
 	State() (string, int64, error)
 	IsStarted() bool
@@ -82,6 +211,8 @@ type Regulator interface {
 	MinCommitTimeout() time.Duration
 	OnTxExecution(count int, ed time.Duration, fd time.Duration)
 	SetBlockInterval(i time.Duration, d time.Duration)
+	SetIntervalPolicy(max time.Duration, threshold int)
+	OnTxPoolUpdated(count int)
 }
 
 type GenesisType int
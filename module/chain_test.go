@@ -0,0 +1,48 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryCapability(t *testing.T) {
+	hc, err := NewHistoryCapability(0, "state")
+	assert.NoError(t, err)
+	assert.Nil(t, hc)
+	assert.True(t, hc.Serves(HistoryTrace, 1, 100))
+
+	_, err = NewHistoryCapability(10, "state,blocks")
+	assert.Error(t, err)
+
+	hc, err = NewHistoryCapability(10, " receipt, state ")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{HistoryReceipt, HistoryState}, hc.Queries)
+
+	// the latest blocks are served for all queries
+	assert.True(t, hc.Serves(HistoryTrace, 91, 100))
+	assert.False(t, hc.Serves(HistoryTrace, 90, 100))
+	assert.True(t, hc.Serves(HistoryState, 1, 100))
+	assert.True(t, hc.Serves(HistoryReceipt, 1, 100))
+
+	hc, err = NewHistoryCapability(1, "")
+	assert.NoError(t, err)
+	assert.True(t, hc.Serves(HistoryState, 100, 100))
+	assert.False(t, hc.Serves(HistoryState, 99, 100))
+}
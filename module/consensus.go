@@ -4,6 +4,10 @@ type ConsensusStatus struct {
 	Height   int64
 	Round    int32
 	Proposer bool
+
+	// HaltReason is the reason why the consensus is halted, or nil if
+	// it's not halted.
+	HaltReason error
 }
 
 const (
@@ -31,6 +31,23 @@ type Reactor interface {
 	OnLeave(id PeerID)
 }
 
+// Payload is the payload of the received packet in the pooled buffer. The
+// bytes are valid until the last reference is released.
+type Payload interface {
+	Bytes() []byte
+	Retain()
+	Release()
+}
+
+// PayloadReactor is the Reactor receiving payloads without copying. It's
+// called with OnReceivePayload instead of OnReceive, and the reactor shall
+// release the payload after using the bytes. The bytes shall not be kept
+// by the reactor after it.
+type PayloadReactor interface {
+	Reactor
+	OnReceivePayload(pi ProtocolInfo, p Payload, id PeerID) (bool, error)
+}
+
 type ProtocolHandler interface {
 	Broadcast(pi ProtocolInfo, b []byte, bt BroadcastType) error
 	Multicast(pi ProtocolInfo, b []byte, role Role) error
@@ -36,6 +36,13 @@ const (
 	PurgeEnumCache
 	ContractSetEvent
 	FixMapValues
+	ColdAccessCharge
+	RevertReasonInReceipt
+	TxExpiration
+	DeployWithSalt
+	VRFProposerSelection
+	FeeStepsInReceipt
+	PendingAuditList
 	LastRevisionBit
 )
 
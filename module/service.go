@@ -87,6 +87,7 @@ const (
 	StatusStackOverflow
 	StatusSkipTransaction
 	StatusInvalidPackage
+	StatusIllegalReentrancy
 	StatusReverted Status = 32
 
 	StatusLimitRev5 Status = 99
@@ -127,6 +128,8 @@ func (s Status) String() string {
 		return "SkipTransaction"
 	case StatusInvalidPackage:
 		return "InvalidPackage"
+	case StatusIllegalReentrancy:
+		return "IllegalReentrancy"
 	default:
 		if s >= StatusReverted {
 			return fmt.Sprintf("Reverted(%d)", s-StatusReverted)
@@ -236,12 +239,37 @@ type Transition interface {
 
 type APIInfo interface {
 	ToJSON(JSONVersion) (interface{}, error)
+	// Signatures returns signatures of external methods and events.
+	Signatures() (methods []string, events []string)
 }
 
 type SCOREStatus interface {
 	ToJSON(height int64, version JSONVersion) (interface{}, error)
 }
 
+type FeeSharingInfo interface {
+	ToJSON(height int64, version JSONVersion) (interface{}, error)
+}
+
+// StorageEntry is a raw key and value pair in the storage of an account.
+type StorageEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+// TransactionInspection is the result of inspecting a transaction without
+// submitting it.
+type TransactionInspection struct {
+	Transaction Transaction
+	// Signer is the address recovered from the signature, or nil on failure.
+	Signer Address
+	// MinimumStep is the step limit required at least, or nil if the
+	// transaction doesn't use steps.
+	MinimumStep *big.Int
+	// Errors are failures of validation for the transaction pool.
+	Errors []error
+}
+
 // Options for finalize
 const (
 	FinalizeNormalTransaction = 1 << iota
@@ -326,6 +354,11 @@ type ServiceManager interface {
 	// SendTransaction adds transaction to a transaction pool.
 	SendTransaction(result []byte, height int64, tx interface{}) ([]byte, error)
 
+	// SendTransactionBundle adds the ordered bundle of transactions signed
+	// by the sequencer of the chain. The bundle is proposed verbatim after
+	// validation. It returns IDs of the transactions.
+	SendTransactionBundle(result []byte, height int64, txs []interface{}, sig []byte) ([][]byte, error)
+
 	// SendPatch sends a patch
 	SendPatch(patch Patch) error
 
@@ -353,6 +386,10 @@ type ServiceManager interface {
 	// GetSCOREStatus returns status of the contract
 	GetSCOREStatus(result []byte, addr Address) (SCOREStatus, error)
 
+	// GetFeeSharingInfo returns fee sharing configuration and deposits
+	// of the contract
+	GetFeeSharingInfo(result []byte, addr Address) (FeeSharingInfo, error)
+
 	// GetMembers returns network member list
 	GetMembers(result []byte) (MemberList, error)
 
@@ -365,6 +402,28 @@ type ServiceManager interface {
 	// GetNextBlockVersion returns version of next block
 	GetNextBlockVersion(result []byte) int
 
+	// GetRevision returns revision of the platform for the result
+	GetRevision(result []byte) int
+
+	// GetVRFProposerSelection returns true if proposers are selected with
+	// VRF outputs of the previous proposers instead of taking turns.
+	GetVRFProposerSelection(result []byte) bool
+
+	// GetValidatorWeights returns the weights of the validators for
+	// selecting proposers with VRF (ex: stakes), or nil if they have the
+	// same weight.
+	GetValidatorWeights(result []byte, validators ValidatorList) []*big.Int
+
+	// GetStorageEntries returns at most limit entries of the storage of
+	// the account with the prefix from the start key in key order. It also
+	// returns the key of the next entry, or nil if there is no more.
+	GetStorageEntries(result []byte, addr Address, prefix, start []byte, limit int) ([]StorageEntry, []byte, error)
+
+	// InspectTransaction parses the transaction and validates it as
+	// SendTransaction does without submitting it. It returns an error only
+	// if it fails to parse the transaction.
+	InspectTransaction(result []byte, height int64, tx interface{}) (*TransactionInspection, error)
+
 	// BTPSectionFromResult returns BTPSection for the result
 	BTPSectionFromResult(result []byte) (BTPSection, error)
 
@@ -380,6 +439,10 @@ type ServiceManager interface {
 
 	BTPNetworkTypeIDsFromResult(result []byte) ([]int64, error)
 
+	// BTPNetworkIDsFromResult returns IDs of all networks including closed
+	// ones for the result
+	BTPNetworkIDsFromResult(result []byte) ([]int64, error)
+
 	// HasTransaction returns whether it has specified transaction in the pool
 	HasTransaction(id []byte) bool
 
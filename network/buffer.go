@@ -0,0 +1,69 @@
+package network
+
+import (
+	"math/bits"
+	"sync"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/common/log"
+)
+
+const (
+	payloadBufferMinBits = 8
+	payloadBufferMaxBits = 20 // for DefaultPacketPayloadMax
+)
+
+// payloadBufferPools keeps buffers by size class, and the buffer in the
+// pool of class n has capacity of 1<<n bytes.
+var payloadBufferPools [payloadBufferMaxBits + 1]sync.Pool
+
+// payloadBuffer is the reference counted buffer for payloads of received
+// packets. It returns to the pool when the last reference is released
+// unless it's escaped, which means that the bytes may be used by others
+// not counted, so it's left to the garbage collector.
+type payloadBuffer struct {
+	b       []byte
+	refs    int32
+	escaped int32
+}
+
+func sizeClassOf(n int) int {
+	c := bits.Len(uint(n - 1))
+	if c < payloadBufferMinBits {
+		c = payloadBufferMinBits
+	}
+	return c
+}
+
+func getPayloadBuffer(n int) *payloadBuffer {
+	c := sizeClassOf(n)
+	if c > payloadBufferMaxBits {
+		return &payloadBuffer{b: make([]byte, n), refs: 1, escaped: 1}
+	}
+	if v := payloadBufferPools[c].Get(); v != nil {
+		buf := v.(*payloadBuffer)
+		buf.b = buf.b[:n]
+		buf.refs = 1
+		buf.escaped = 0
+		return buf
+	}
+	return &payloadBuffer{b: make([]byte, n, 1<<c), refs: 1}
+}
+
+func (b *payloadBuffer) retain() {
+	atomic.AddInt32(&b.refs, 1)
+}
+
+func (b *payloadBuffer) release() {
+	refs := atomic.AddInt32(&b.refs, -1)
+	if refs < 0 {
+		log.Panicf("payloadBuffer released more than retained refs=%d", refs)
+	}
+	if refs == 0 && atomic.LoadInt32(&b.escaped) == 0 {
+		payloadBufferPools[sizeClassOf(cap(b.b))].Put(b)
+	}
+}
+
+func (b *payloadBuffer) escape() {
+	atomic.StoreInt32(&b.escaped, 1)
+}
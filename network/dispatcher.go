@@ -0,0 +1,190 @@
+package network
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	receiveBatchSize     = 16
+	receiveYieldInterval = time.Millisecond
+)
+
+// receiveDispatcher dispatches received packets to reactors with separate
+// worker pools by priority of protocols, so packets of lower priority
+// (ex. transactions) can't delay ones of higher priority (ex. votes).
+// Packets of a protocol are handled by one worker at a time in order.
+type receiveDispatcher struct {
+	mtx     sync.Mutex
+	pools   map[uint8]*receivePool
+	pending [DefaultSendQueueMaxPriority + 1]int32
+}
+
+func newReceiveDispatcher() *receiveDispatcher {
+	return &receiveDispatcher{
+		pools: make(map[uint8]*receivePool),
+	}
+}
+
+// poolFor returns the pool for the priority, and starts workers of it if
+// it's not started.
+func (d *receiveDispatcher) poolFor(priority uint8) *receivePool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if p, ok := d.pools[priority]; ok {
+		return p
+	}
+	p := newReceivePool(d, priority)
+	for i := 0; i < DefaultReceiveWorkers; i++ {
+		go p.workRoutine()
+	}
+	d.pools[priority] = p
+	return p
+}
+
+func (d *receiveDispatcher) onPush(priority uint8) {
+	atomic.AddInt32(&d.pending[priority], 1)
+}
+
+func (d *receiveDispatcher) onPop(priority uint8) {
+	atomic.AddInt32(&d.pending[priority], -1)
+}
+
+func (d *receiveDispatcher) hasPendingOver(priority uint8) bool {
+	for i := uint8(1); i < priority; i++ {
+		if atomic.LoadInt32(&d.pending[i]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// yield waits for packets of higher priority to be taken by their workers,
+// but not longer than DefaultReceiveYieldTimeout to avoid starvation.
+func (d *receiveDispatcher) yield(priority uint8) {
+	if !d.hasPendingOver(priority) {
+		return
+	}
+	deadline := time.Now().Add(DefaultReceiveYieldTimeout)
+	for d.hasPendingOver(priority) && time.Now().Before(deadline) {
+		time.Sleep(receiveYieldInterval)
+	}
+}
+
+// Term stops all workers. Packets left in queues are not handled.
+func (d *receiveDispatcher) Term() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	for k, p := range d.pools {
+		p.close()
+		delete(d.pools, k)
+	}
+	for i := range d.pending {
+		atomic.StoreInt32(&d.pending[i], 0)
+	}
+}
+
+type receivePool struct {
+	d        *receiveDispatcher
+	priority uint8
+
+	mtx    sync.Mutex
+	cond   *sync.Cond
+	ready  []*protocolHandler
+	closed bool
+}
+
+func newReceivePool(d *receiveDispatcher, priority uint8) *receivePool {
+	p := &receivePool{
+		d:        d,
+		priority: priority,
+	}
+	p.cond = sync.NewCond(&p.mtx)
+	return p
+}
+
+// schedule marks the handler ready to be handled by a worker if it's not
+// marked already.
+func (p *receivePool) schedule(ph *protocolHandler) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.closed || ph.scheduled {
+		return
+	}
+	ph.scheduled = true
+	p.ready = append(p.ready, ph)
+	p.cond.Signal()
+}
+
+func (p *receivePool) next() *protocolHandler {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for len(p.ready) == 0 && !p.closed {
+		p.cond.Wait()
+	}
+	if p.closed {
+		return nil
+	}
+	ph := p.ready[0]
+	p.ready[0] = nil
+	p.ready = p.ready[1:]
+	return ph
+}
+
+// done puts the handler back to the ready list if it has more packets, so
+// other handlers in the pool get their turns.
+func (p *receivePool) done(ph *protocolHandler) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !p.closed && ph.receiveQueue.Available() < DefaultReceiveQueueSize {
+		p.ready = append(p.ready, ph)
+		p.cond.Signal()
+	} else {
+		ph.scheduled = false
+	}
+}
+
+func (p *receivePool) close() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.closed = true
+	p.ready = nil
+	p.cond.Broadcast()
+}
+
+func (p *receivePool) size() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return len(p.ready)
+}
+
+func (p *receivePool) workRoutine() {
+	for {
+		ph := p.next()
+		if ph == nil {
+			return
+		}
+		for i := 0; i < receiveBatchSize; i++ {
+			ctx := ph.receiveQueue.Pop()
+			if ctx == nil {
+				break
+			}
+			p.d.onPop(p.priority)
+			if ph.isTerminated() {
+				ph.dropReceived(ctx)
+				continue
+			}
+			p.d.yield(p.priority)
+			ph.handleReceived(ctx)
+		}
+		p.done(ph)
+	}
+}
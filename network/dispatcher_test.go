@@ -0,0 +1,95 @@
+package network
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+type dispatcherTestReactor struct {
+	mtx      sync.Mutex
+	received [][]byte
+	delay    time.Duration
+	ch       chan []byte
+}
+
+func (r *dispatcherTestReactor) OnReceive(pi module.ProtocolInfo, b []byte, id module.PeerID) (bool, error) {
+	time.Sleep(r.delay)
+	r.mtx.Lock()
+	r.received = append(r.received, b)
+	r.mtx.Unlock()
+	if r.ch != nil {
+		r.ch <- b
+	}
+	return false, nil
+}
+
+func (r *dispatcherTestReactor) OnFailure(err error, pi module.ProtocolInfo, b []byte) {}
+
+func (r *dispatcherTestReactor) OnJoin(id module.PeerID) {}
+
+func (r *dispatcherTestReactor) OnLeave(id module.PeerID) {}
+
+func Test_receiveDispatcher_yield(t *testing.T) {
+	d := newReceiveDispatcher()
+	d.onPush(2)
+	start := time.Now()
+	d.yield(4)
+	assert.True(t, time.Since(start) >= DefaultReceiveYieldTimeout)
+
+	start = time.Now()
+	d.yield(2)
+	assert.True(t, time.Since(start) < DefaultReceiveYieldTimeout)
+
+	d.onPop(2)
+	start = time.Now()
+	d.yield(4)
+	assert.True(t, time.Since(start) < DefaultReceiveYieldTimeout)
+}
+
+func Test_receiveDispatcher_dispatch(t *testing.T) {
+	m := &manager{dispatcher: newReceiveDispatcher()}
+	defer m.dispatcher.Term()
+	pi := module.ProtocolInfo(0x0100)
+	p := &Peer{id: generatePeerID()}
+
+	low := &dispatcherTestReactor{delay: time.Millisecond}
+	lph := newProtocolHandler(m, module.ProtocolInfo(0x0200), []module.ProtocolInfo{pi},
+		low, "low", 4, module.NotRegisteredProtocolPolicyNone, log.GlobalLogger())
+	defer lph.Term()
+	high := &dispatcherTestReactor{ch: make(chan []byte, 1)}
+	hph := newProtocolHandler(m, module.ProtocolInfo(0x0300), []module.ProtocolInfo{pi},
+		high, "high", 2, module.NotRegisteredProtocolPolicyNone, log.GlobalLogger())
+	defer hph.Term()
+
+	const n = 200
+	for i := 0; i < n; i++ {
+		lph.onPacket(NewPacket(lph.protocol, pi, []byte{byte(i)}), p)
+	}
+
+	// packets of higher priority are not delayed by flood of lower ones
+	start := time.Now()
+	hph.onPacket(NewPacket(hph.protocol, pi, []byte{0xff}), p)
+	select {
+	case b := <-high.ch:
+		assert.Equal(t, []byte{0xff}, b)
+		assert.True(t, time.Since(start) < 100*time.Millisecond)
+	case <-time.After(time.Second):
+		assert.Fail(t, "packet of higher priority is not handled")
+	}
+
+	// packets of a protocol are handled in order
+	assert.Eventually(t, func() bool {
+		low.mtx.Lock()
+		defer low.mtx.Unlock()
+		return len(low.received) == n
+	}, 5*time.Second, 10*time.Millisecond)
+	for i, b := range low.received {
+		assert.Equal(t, []byte{byte(i)}, b)
+	}
+}
@@ -105,6 +105,7 @@ func protocolHandlerToMap(ph *protocolHandler) map[string]interface{} {
 		m["subProtocols"] = strings.Join(sarr, ",")
 
 		m["receiveQueue"] = ph.receiveQueue.Available()
+		m["receivePool"] = ph.pool.size()
 		m["eventQueue"] = ph.eventQueue.Available()
 		m["sendQueue"] = ph.m.p2p.sendQueue.Available(int(ph.protocol.ID()))
 	}
@@ -20,6 +20,7 @@ type manager struct {
 	roleByDest map[byte]module.Role
 	//
 	protocolHandlers map[uint16]*protocolHandler
+	dispatcher       *receiveDispatcher
 
 	mtx sync.RWMutex
 
@@ -48,6 +49,7 @@ func NewManager(c module.Chain, nt module.NetworkTransport, trustSeeds string, r
 		destByRole:       make(map[module.Role]byte),
 		roleByDest:       make(map[byte]module.Role),
 		protocolHandlers: make(map[uint16]*protocolHandler),
+		dispatcher:       newReceiveDispatcher(),
 		pd:               t.pd,
 		cn:               t.cn,
 		logger:           networkLogger,
@@ -109,6 +111,7 @@ func (m *manager) Term() {
 		ph.Term()
 		m.cn.removeProtocol(m.channel, ph.protocol)
 	}
+	m.dispatcher.Term()
 
 	for _, pi := range m.p2p.supportedProtocols() {
 		m.cn.removeProtocol(m.channel, pi)
@@ -23,8 +23,12 @@ const (
 	DefaultTransportNet         = "tcp4"
 	DefaultDialTimeout          = 5 * time.Second
 	DefaultReceiveQueueSize     = 1000
+	DefaultReceiveWorkers       = 2
+	DefaultReceiveYieldTimeout  = 10 * time.Millisecond
 	DefaultPacketBufferSize     = 4096 //bufio.defaultBufSize=4096
 	DefaultPacketPayloadMax     = 1024 * 1024
+	DefaultDecodeMaxLength      = 10000
+	DefaultDecodeMaxDepth       = 8
 	DefaultPacketPoolNumBucket  = 20
 	DefaultPacketPoolBucketLen  = 500
 	DefaultDiscoveryPeriod      = 2 * time.Second
@@ -32,6 +36,8 @@ const (
 	DefaultMinSeed              = 1
 	DefaultAlternateSendPeriod  = 1 * time.Second
 	DefaultSendTimeout          = 5 * time.Second
+	DefaultPeerWatchdogTimeout  = 30 * time.Second
+	DefaultPeerDrainTimeout     = 2 * time.Second
 	DefaultSendQueueMaxPriority = 7
 	DefaultSendQueueSize        = 1000
 	DefaultEventQueueSize       = 100
@@ -72,6 +78,7 @@ var (
 	p2pProtoConnResp  = module.ProtocolInfo(0x0A00)
 	p2pProtoRttReq    = module.ProtocolInfo(0x0B00)
 	p2pProtoRttResp   = module.ProtocolInfo(0x0C00)
+	p2pProtoDrain     = module.ProtocolInfo(0x0D00)
 )
 
 type PeerToPeer struct {
@@ -216,6 +223,9 @@ func (p2p *PeerToPeer) Stop() {
 	p2p.logger.Debugln("Stop", "try close p2p.stopCh")
 	close(p2p.stopCh)
 
+	p2p.logger.Debugln("Stop", "drain Peers")
+	p2p.drainPeers(p2p.getPeers(false), "stopCh")
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -451,6 +461,8 @@ func (p2p *PeerToPeer) onPacket(pkt *Packet, p *Peer) {
 				p2p.handleP2PConnectionRequest(pkt, p)
 			case p2pProtoConnResp:
 				p2p.handleP2PConnectionResponse(pkt, p)
+			case p2pProtoDrain:
+				p2p.handleDrain(pkt, p)
 			default:
 				p.CloseByError(ErrNotRegisteredProtocol)
 			}
@@ -508,7 +520,7 @@ func (p2p *PeerToPeer) encodeMsgpack(v interface{}) []byte {
 }
 
 func (p2p *PeerToPeer) decodeMsgpack(b []byte, v interface{}) error {
-	_, err := codec.MP.UnmarshalFromBytes(b, v)
+	_, err := mpCodec.UnmarshalFromBytes(b, v)
 	return err
 }
 
@@ -530,6 +542,10 @@ type RttMessage struct {
 	Average time.Duration
 }
 
+type DrainMessage struct {
+	Reason string
+}
+
 func (p2p *PeerToPeer) applyPeerRole(p *Peer) {
 	r := p.Role()
 	if r.Has(p2pRoleSeed) {
@@ -575,7 +591,7 @@ func (p2p *PeerToPeer) onAllowedPeerIDSetUpdate(s *PeerIDSet, r PeerRoleFlag) {
 		for _, p := range peers {
 			if !s.Contains(p.ID()) {
 				p2p.onEvent(p2pEventNotAllowed, p)
-				p.CloseByError(fmt.Errorf("onUpdate not allowed connection"))
+				go p2p.drainPeer(p, "onUpdate not allowed connection")
 			}
 		}
 	default:
@@ -839,6 +855,38 @@ func (p2p *PeerToPeer) handleRttResponse(pkt *Packet, p *Peer) {
 	}
 }
 
+// drainPeer tells the peer to stop sending, and closes the connection after
+// flushing packets in the queue.
+func (p2p *PeerToPeer) drainPeer(p *Peer, reason string) {
+	m := &DrainMessage{Reason: reason}
+	pkt := newPacket(p2pProtoControl, p2pProtoDrain, p2p.encodeMsgpack(m), p2p.ID())
+	pkt.destPeer = p.ID()
+	p.drain(pkt, reason, DefaultPeerDrainTimeout)
+}
+
+func (p2p *PeerToPeer) drainPeers(peers []*Peer, reason string) {
+	var wg sync.WaitGroup
+	for _, p := range peers {
+		wg.Add(1)
+		go func(p *Peer) {
+			defer wg.Done()
+			p2p.drainPeer(p, reason)
+		}(p)
+	}
+	wg.Wait()
+}
+
+func (p2p *PeerToPeer) handleDrain(pkt *Packet, p *Peer) {
+	dm := &DrainMessage{}
+	err := p2p.decodeMsgpack(pkt.payload, dm)
+	if err != nil {
+		p2p.logger.Infoln("handleDrain", err, p)
+		return
+	}
+	p2p.logger.Debugln("handleDrain", dm, p)
+	p.setRemoteDrain()
+}
+
 func (p2p *PeerToPeer) sendToPeers(ctx context.Context, peers *PeerSet) {
 	pkt := ctx.Value(p2pContextKeyPacket).(*Packet)
 	for _, p := range peers.GetByProtocol(pkt.protocol) {
@@ -1534,7 +1582,7 @@ func (p2p *PeerToPeer) discoverFriends() {
 			}
 		} else {
 			p2p.logger.Debugln("discoverFriends", "not allowed connection", p.id)
-			p.Close("discoverFriends not allowed connection")
+			go p2p.drainPeer(p, "discoverFriends not allowed connection")
 		}
 	}
 
@@ -1566,7 +1614,7 @@ func (p2p *PeerToPeer) discoverParents(pr PeerRoleFlag) (complete bool) {
 	for _, p := range ps {
 		if !(pr == p2pRoleSeed && p2p.isTrustSeed(p)) {
 			p2p.logger.Debugln("discoverParents", "not allowed connection", p.id)
-			p.Close("discoverParents not allowed connection")
+			go p2p.drainPeer(p, "discoverParents not allowed connection")
 		}
 	}
 
@@ -1632,7 +1680,7 @@ func (p2p *PeerToPeer) discoverUncles(ur PeerRoleFlag) (complete bool) {
 	for _, p := range ps {
 		if !(ur == p2pRoleSeed && p2p.isTrustSeed(p)) {
 			p2p.logger.Debugln("discoverUncles", "not allowed connection", p.id)
-			p.Close("discoverUncles not allowed connection")
+			go p2p.drainPeer(p, "discoverUncles not allowed connection")
 		}
 	}
 
@@ -42,6 +42,7 @@ type Packet struct {
 	priority  uint8
 	timestamp time.Time
 	forceSend bool
+	buf       *payloadBuffer
 	mtx       sync.RWMutex
 }
 
@@ -134,24 +135,55 @@ func (p *Packet) Len() int64 {
 	return int64(len(p.header)) + int64(len(p.payload)) + int64(len(p.footer)) + int64(len(p.ext))
 }
 
-func (p *Packet) _read(r io.Reader, n int) ([]byte, int, error) {
-	if n < 0 {
-		return nil, 0, fmt.Errorf("invalid n:%d", n)
+// Bytes returns the payload of the packet.
+func (p *Packet) Bytes() []byte {
+	return p.payload
+}
+
+// Retain adds a reference to the payload of the received packet, then it
+// shall be released by Release.
+func (p *Packet) Retain() {
+	if p.buf != nil {
+		p.buf.retain()
 	}
-	b := make([]byte, n)
-	if n == 0 {
-		return b, 0, nil
+}
+
+// Release releases a reference to the payload of the received packet. The
+// payload may be reused for other packets after releasing all references.
+func (p *Packet) Release() {
+	if p.buf != nil {
+		p.buf.release()
+	}
+}
+
+// escape prevents the payload from being reused, because it's given to
+// others which don't release it.
+func (p *Packet) escape() {
+	if p.buf != nil {
+		p.buf.escape()
 	}
+}
+
+func (p *Packet) _readFull(r io.Reader, b []byte) (int, error) {
 	rn := 0
-	for {
+	for rn < len(b) {
 		tn, err := r.Read(b[rn:])
 		if rn += tn; err != nil {
-			return nil, rn, err
-		}
-		if rn >= n {
-			break
+			return rn, err
 		}
 	}
+	return rn, nil
+}
+
+func (p *Packet) _read(r io.Reader, n int) ([]byte, int, error) {
+	if n < 0 {
+		return nil, 0, fmt.Errorf("invalid n:%d", n)
+	}
+	b := make([]byte, n)
+	rn, err := p._readFull(r, b)
+	if err != nil {
+		return nil, rn, err
+	}
 	return b, rn, nil
 }
 
@@ -252,7 +284,13 @@ func (p *Packet) ReadFrom(r io.Reader) (n int64, err error) {
 		return
 	}
 
-	p.payload, tn, err = p._read(r, int(p.lengthOfPayload))
+	if p.lengthOfPayload > 0 {
+		p.buf = getPayloadBuffer(int(p.lengthOfPayload))
+		p.payload = p.buf.b
+	} else {
+		p.payload = []byte{}
+	}
+	tn, err = p._readFull(r, p.payload)
 	if n += int64(tn); err != nil {
 		return
 	}
@@ -359,6 +397,7 @@ func (pr *PacketReader) ReadPacket() (pkt *Packet, e error) {
 	pkt = &Packet{}
 	_, err := pkt.ReadFrom(pr)
 	if err != nil {
+		pkt.Release()
 		e = err
 		return
 	}
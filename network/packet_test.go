@@ -51,6 +51,7 @@ func Test_packet_PacketReadWriter(t *testing.T) {
 	assert.NoError(t, prw.WritePacket(pkt), "WritePacket fail")
 	rpkt, err := prw.ReadPacket()
 	rpkt.timestamp = pkt.timestamp
+	rpkt.buf = nil
 	assert.NoError(t, err, "ReadPacket fail")
 	assert.Equal(t, pkt, rpkt, "ReadPacket")
 	rpkt, err = prw.ReadPacket()
@@ -61,6 +62,69 @@ func Test_packet_PacketReadWriter(t *testing.T) {
 	assert.Error(t, err, "ReadPacket must fail(io.EOF) after Reset")
 }
 
+func Test_packet_Release(t *testing.T) {
+	prw := NewPacketReadWriter()
+	payload := bytes.Repeat([]byte{0x01}, 1000)
+	pkt := newPacket(packetTestProtocolInfo, packetTestProtocolInfo, payload, generatePeerID())
+	assert.NoError(t, prw.WritePacket(pkt))
+
+	rpkt, err := prw.rd.ReadPacket()
+	assert.NoError(t, err)
+	assert.Equal(t, payload, rpkt.Bytes())
+	assert.Equal(t, 1<<10, cap(rpkt.buf.b))
+
+	rpkt.Retain()
+	rpkt.Release()
+	assert.EqualValues(t, 1, rpkt.buf.refs)
+	rpkt.Release()
+	assert.EqualValues(t, 0, rpkt.buf.refs)
+	assert.Panics(t, func() {
+		rpkt.Release()
+	})
+
+	// packets without pooled buffers are just ignored
+	pkt.Retain()
+	pkt.Release()
+}
+
+func writeTestPackets(b *testing.B, n int, size int) *bytes.Buffer {
+	buf := bytes.NewBuffer(nil)
+	pw := NewPacketWriter(buf)
+	pkt := newPacket(packetTestProtocolInfo, packetTestProtocolInfo, make([]byte, size), generatePeerID())
+	for i := 0; i < n; i++ {
+		if err := pw.WritePacket(pkt); err != nil {
+			b.FailNow()
+		}
+	}
+	return buf
+}
+
+func benchmarkPacketReader(b *testing.B, size int, release bool) {
+	src := writeTestPackets(b, b.N, size)
+	pr := NewPacketReader(src)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pkt, err := pr.ReadPacket()
+		if err != nil {
+			b.FailNow()
+		}
+		if release {
+			pkt.Release()
+		} else {
+			pkt.escape()
+		}
+	}
+}
+
+func Benchmark_packet_PacketReader_Release(b *testing.B) {
+	benchmarkPacketReader(b, 16*1024, true)
+}
+
+func Benchmark_packet_PacketReader_Escape(b *testing.B) {
+	benchmarkPacketReader(b, 16*1024, false)
+}
+
 func FuzzPacketReadFrom(f *testing.F) {
 	f.Fuzz(func(t *testing.T, data []byte) {
 		buf := bytes.NewBuffer(data)
@@ -69,3 +133,20 @@ func FuzzPacketReadFrom(f *testing.F) {
 		assert.LessOrEqual(t, n, int64(len(data)))
 	})
 }
+
+func FuzzPacketReader(f *testing.F) {
+	prw := NewPacketReadWriter()
+	pkt := newPacket(packetTestProtocolInfo, packetTestProtocolInfo, []byte("test"), generatePeerID())
+	_ = prw.WritePacket(pkt)
+	f.Add(prw.b.Bytes())
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pr := NewPacketReader(bytes.NewBuffer(data))
+		for {
+			pkt, err := pr.ReadPacket()
+			if err != nil {
+				break
+			}
+			assert.LessOrEqual(t, len(pkt.payload), len(data))
+		}
+	})
+}
@@ -12,7 +12,10 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/chaos"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/watchdog"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/metric"
 )
@@ -38,6 +41,9 @@ type Peer struct {
 	closeInfoMtx sync.RWMutex
 	sendMtx      sync.Mutex
 	once         sync.Once
+	draining     int32
+	drained      chan struct{}
+	remoteDrain  int32
 
 	//properties
 	id            module.PeerID
@@ -87,6 +93,7 @@ func newPeer(conn net.Conn, cbFunc packetCbFunc, in bool, dial NetAddress, l log
 		timestamp:   time.Now(),
 		pool:        NewTimestampPool(DefaultPeerPoolExpireSecond + 1),
 		close:       make(chan error),
+		drained:     make(chan struct{}),
 		closeReason: make([]string, 0),
 		closeErr:    make([]error, 0),
 		onError:     defaultOnError,
@@ -391,7 +398,22 @@ func (p *Peer) isTemporaryError(err error) bool {
 }
 
 //receive from bufio.Reader, unmarshalling and peerToPeer.onPacket
+// stalled returns the handler of the watchdog closing the peer if the
+// watchdog is configured to recover.
+func (p *Peer) stalled(routine string) func() {
+	return func() {
+		if !watchdog.Default().Recover() {
+			return
+		}
+		p.logger.Warnf("Peer[%s] close by watchdog routine=%s", p.ConnString(), routine)
+		p.CloseByError(fmt.Errorf("%s stalled", routine))
+	}
+}
+
 func (p *Peer) receiveRoutine() {
+	hb := watchdog.Default().Register("Peer.receiveRoutine "+p.ConnString(),
+		DefaultPeerWatchdogTimeout, p.stalled("receiveRoutine"))
+	defer hb.Close()
 	defer func() {
 		if err := recover(); err != nil {
 			p.logger.Warnf("Peer[%s].receiveRoutine recover from %+v\n %s", p.ConnString(), err, string(debug.Stack()))
@@ -401,7 +423,9 @@ func (p *Peer) receiveRoutine() {
 		}
 	}()
 	for {
+		hb.Idle()
 		pkt, err := p.reader.ReadPacket()
+		hb.Beat()
 		if err != nil {
 			r := p.isTemporaryError(err)
 			p.logger.Tracef("Peer.receiveRoutine Error isTemporary:{%v} error:{%+v} peer:%s", r, err, p.String())
@@ -428,6 +452,8 @@ func (p *Peer) receiveRoutine() {
 		} else {
 			p.logger.Infof("Peer[%s].onPacket in nil, Drop %s", p.ConnString(), pkt.String())
 		}
+		// callbacks retain the packet if they use it later
+		pkt.Release()
 	}
 }
 
@@ -435,6 +461,9 @@ func (p *Peer) sendDirect(pkt *Packet) error {
 	defer p.sendMtx.Unlock()
 	p.sendMtx.Lock()
 
+	if err := chaos.Inject(chaos.PointPacketSend); err != nil {
+		return err
+	}
 	if err := p.conn.SetWriteDeadline(time.Now().Add(DefaultSendTimeout)); err != nil {
 		return err
 	} else if err := p.writer.WritePacket(pkt); err != nil {
@@ -449,12 +478,17 @@ func (p *Peer) sendRoutine() {
 	// }()
 	secondTick := time.NewTicker(time.Second)
 	defer secondTick.Stop()
+	hb := watchdog.Default().Register("Peer.sendRoutine "+p.ConnString(),
+		DefaultPeerWatchdogTimeout, p.stalled("sendRoutine"))
+	defer hb.Close()
 Loop:
 	for {
+		hb.Idle()
 		select {
 		case <-p.close:
 			break Loop
 		case <-p.q.Wait():
+			hb.Beat()
 			for {
 				ctx := p.q.Pop()
 				if ctx == nil {
@@ -472,6 +506,10 @@ Loop:
 				}
 				p.pool.Put(pkt.hashOfPacket)
 				p.getMetric().OnSend(pkt.dest, pkt.ttl, pkt.extendInfo.hint(), pkt.protocol.Uint16(), pkt.lengthOfPayload)
+				if pkt.protocol == p2pProtoControl && pkt.subProtocol == p2pProtoDrain {
+					close(p.drained)
+				}
+				hb.Beat()
 			}
 		case <-secondTick.C:
 			p.pool.RemoveBefore(DefaultPeerPoolExpireSecond)
@@ -495,7 +533,7 @@ func (p *Peer) isDuplicatedToSend(pkt *Packet) bool {
 }
 
 func (p *Peer) send(ctx context.Context) error {
-	if p == nil || p.IsClosed() {
+	if p == nil || p.IsClosed() || p.IsDraining() {
 		return ErrNotAvailable
 	}
 	c := ctx.Value(p2pContextKeyCounter).(*Counter)
@@ -522,6 +560,38 @@ func (p *Peer) sendPacket(pkt *Packet) error {
 	return p.send(ctx)
 }
 
+// IsDraining returns whether the connection is being drained by either side,
+// so no more packets are sent to the peer.
+func (p *Peer) IsDraining() bool {
+	return atomic.LoadInt32(&p.draining) == 1 || atomic.LoadInt32(&p.remoteDrain) == 1
+}
+
+func (p *Peer) setRemoteDrain() {
+	atomic.StoreInt32(&p.remoteDrain, 1)
+}
+
+// drain stops sending new packets to the peer, and sends the drain packet
+// after packets in the queue, then closes the connection after the drain
+// packet is sent or the timeout.
+func (p *Peer) drain(pkt *Packet, reason string, timeout time.Duration) {
+	if p == nil || p.IsClosed() || !atomic.CompareAndSwapInt32(&p.draining, 0, 1) {
+		return
+	}
+	ctx := context.WithValue(context.Background(), p2pContextKeyPacket, pkt)
+	ctx = context.WithValue(ctx, p2pContextKeyCounter, &Counter{})
+	if p.q.Push(ctx, DefaultSendQueueMaxPriority) {
+		timer := time.NewTimer(timeout)
+		select {
+		case <-p.drained:
+		case <-p.close:
+		case <-timer.C:
+			p.logger.Debugf("Peer[%s].drain timeout", p.ConnString())
+		}
+		timer.Stop()
+	}
+	p.Close(reason)
+}
+
 func (p *Peer) setMetric(nm *metric.NetworkMetric) {
 	p.metricMtx.Lock()
 	defer p.metricMtx.Unlock()
@@ -604,18 +674,32 @@ func (na NetAddress) Validate() error {
 }
 
 type PeerRTT struct {
-	last time.Duration
-	avg  time.Duration
-	st   time.Time
-	et   time.Time
-	t    *time.Timer
-	mtx  sync.RWMutex
+	last  time.Duration
+	avg   time.Duration
+	st    time.Time
+	et    time.Time
+	t     *common.Timer
+	clock common.Clock
+	mtx   sync.RWMutex
 }
 
 func NewPeerRTT() *PeerRTT {
 	return &PeerRTT{}
 }
 
+// NewPeerRTTWithClock returns PeerRTT measuring with the clock. PeerRTT
+// without the clock uses the system clock.
+func NewPeerRTTWithClock(cl common.Clock) *PeerRTT {
+	return &PeerRTT{clock: cl}
+}
+
+func (r *PeerRTT) getClock() common.Clock {
+	if r.clock == nil {
+		return &common.GoTimeClock{}
+	}
+	return r.clock
+}
+
 func (r *PeerRTT) Start() {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
@@ -625,7 +709,7 @@ func (r *PeerRTT) Start() {
 		r.t.Stop()
 		r.t = nil
 	}
-	r.st = time.Now()
+	r.st = r.getClock().Now()
 }
 
 func (r *PeerRTT) StartWithAfterFunc(to time.Duration, f func()) {
@@ -637,9 +721,10 @@ func (r *PeerRTT) StartWithAfterFunc(to time.Duration, f func()) {
 		r.t.Stop()
 		r.t = nil
 	}
-	r.t = time.AfterFunc(to, f)
+	t := r.getClock().AfterFunc(to, f)
+	r.t = &t
 
-	r.st = time.Now()
+	r.st = r.getClock().Now()
 }
 
 func (r *PeerRTT) Stop() time.Duration {
@@ -651,7 +736,7 @@ func (r *PeerRTT) Stop() time.Duration {
 		r.t = nil
 	}
 
-	r.et = time.Now()
+	r.et = r.getClock().Now()
 	r.last = r.et.Sub(r.st)
 
 	//exponential weighted moving average model
@@ -1,11 +1,17 @@
 package network
 
 import (
+	"net"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/test/clock"
 )
 
 func Test_PeerRTT(t *testing.T) {
@@ -65,3 +71,70 @@ func Test_PeerRoleFlag(t *testing.T) {
 
 	assert.Equal(t, PeerRoleFlag(p2pRoleRootSeed), pr)
 }
+
+func Test_PeerRTTWithClock(t *testing.T) {
+	cl := &clock.Clock{}
+	r := NewPeerRTTWithClock(cl)
+	r.Start()
+	cl.PassTime(100 * time.Millisecond)
+	assert.Equal(t, 100*time.Millisecond, r.Stop())
+
+	fired := false
+	r.StartWithAfterFunc(50*time.Millisecond, func() {
+		fired = true
+	})
+	cl.PassTime(49 * time.Millisecond)
+	assert.False(t, fired)
+	cl.PassTime(time.Millisecond)
+	assert.True(t, fired)
+	assert.Equal(t, 50*time.Millisecond, r.Stop())
+	last, avg := r.Value()
+	assert.Equal(t, 50*time.Millisecond, last)
+	assert.Equal(t, time.Duration(0.875*float64(100*time.Millisecond)+0.125*float64(50*time.Millisecond)), avg)
+}
+
+func Test_PeerDrain(t *testing.T) {
+	c1, c2 := net.Pipe()
+	received := make(chan module.ProtocolInfo, 10)
+	noop := func(pkt *Packet, p *Peer) {}
+	sender := newPeer(c1, noop, false, "", log.New())
+	receiver := newPeer(c2, func(pkt *Packet, p *Peer) {
+		if pkt.subProtocol == p2pProtoDrain {
+			p.setRemoteDrain()
+		}
+		received <- pkt.subProtocol
+	}, true, "", log.New())
+	defer receiver.Close("test")
+	sender.setID(generatePeerID())
+	receiver.setID(generatePeerID())
+	nm := metric.NewNetworkMetric(metric.DefaultMetricContext())
+	sender.setMetric(nm)
+	receiver.setMetric(nm)
+
+	src := generatePeerID()
+	for i := 0; i < 3; i++ {
+		pkt := newPacket(p2pProtoControl, p2pProtoRttReq, []byte{byte(i)}, src)
+		assert.NoError(t, sender.sendPacket(pkt))
+	}
+	pkt := newPacket(p2pProtoControl, p2pProtoDrain, []byte{}, src)
+	sender.drain(pkt, "test", time.Second)
+	assert.True(t, sender.IsClosed())
+	assert.Equal(t, ErrNotAvailable, sender.sendPacket(pkt))
+
+	// drain packet is sent after queued ones
+	for i := 0; i < 4; i++ {
+		select {
+		case pi := <-received:
+			if i < 3 {
+				assert.Equal(t, p2pProtoRttReq, pi)
+			} else {
+				assert.Equal(t, p2pProtoDrain, pi)
+			}
+		case <-time.After(time.Second):
+			assert.Fail(t, "timeout")
+			return
+		}
+	}
+	assert.True(t, receiver.IsDraining())
+	assert.Equal(t, ErrNotAvailable, receiver.sendPacket(pkt))
+}
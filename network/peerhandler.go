@@ -7,6 +7,13 @@ import (
 	"github.com/icon-project/goloop/module"
 )
 
+// mpCodec decodes messages from peers within the limits.
+var mpCodec = codec.MP.WithLimits(codec.Limits{
+	MaxBytes:  DefaultPacketPayloadMax,
+	MaxLength: DefaultDecodeMaxLength,
+	MaxDepth:  DefaultDecodeMaxDepth,
+})
+
 type PeerHandler interface {
 	onPeer(p *Peer)
 	onPacket(pkt *Packet, p *Peer)
@@ -92,7 +99,7 @@ func (ph *peerHandler) decodePeerPacket(p *Peer, buf interface{}, pkt *Packet) b
 }
 
 func (ph *peerHandler) decode(b []byte, v interface{}) error {
-	if remain, err := codec.MP.UnmarshalFromBytes(b, v); err == nil {
+	if remain, err := mpCodec.UnmarshalFromBytes(b, v); err == nil {
 		if len(remain) > 0 {
 			return errors.Errorf("ExtraBytes(size=%d)", len(remain))
 		}
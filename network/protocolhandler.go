@@ -19,6 +19,8 @@ type protocolHandler struct {
 	receiveQueue Queue
 	eventQueue   Queue
 	failureQueue Queue
+	pool         *receivePool
+	scheduled    bool // guarded by pool.mtx
 	//log
 	logger log.Logger
 
@@ -47,6 +49,7 @@ func newProtocolHandler(
 		receiveQueue: NewQueue(DefaultReceiveQueueSize),
 		eventQueue:   NewQueue(DefaultEventQueueSize),
 		failureQueue: NewQueue(DefaultFailureQueueSize),
+		pool:         m.dispatcher.poolFor(priority),
 		logger:       phLogger,
 	}
 	for _, sp := range spiList {
@@ -59,7 +62,6 @@ func newProtocolHandler(
 
 	ph.run = make(chan bool)
 
-	go ph.receiveRoutine()
 	go ph.eventRoutine()
 	go ph.failureRoutine()
 	return ph
@@ -71,6 +73,15 @@ func (ph *protocolHandler) IsRun() bool {
 	return ph.run != nil
 }
 
+func (ph *protocolHandler) isTerminated() bool {
+	select {
+	case <-ph.run:
+		return true
+	default:
+		return false
+	}
+}
+
 func (ph *protocolHandler) Init() error {
 	return nil
 }
@@ -139,36 +150,36 @@ func (ph *protocolHandler) getSubProtocols() []module.ProtocolInfo {
 	return spis
 }
 
-func (ph *protocolHandler) receiveRoutine() {
-Loop:
-	for {
-		select {
-		case <-ph.run:
-			break Loop
-		case <-ph.receiveQueue.Wait():
-			for {
-				ctx := ph.receiveQueue.Pop()
-				if ctx == nil {
-					break
-				}
-				pkt := ctx.Value(p2pContextKeyPacket).(*Packet)
-				p := ctx.Value(p2pContextKeyPeer).(*Peer)
-				r := ph.getReactor()
-				isRelay, err := r.OnReceive(pkt.subProtocol, pkt.payload, p.ID())
-				if err != nil {
-					//ph.logger.Debugln("receiveRoutine", err)
-				}
+func (ph *protocolHandler) handleReceived(ctx context.Context) {
+	pkt := ctx.Value(p2pContextKeyPacket).(*Packet)
+	p := ctx.Value(p2pContextKeyPeer).(*Peer)
+	defer pkt.Release()
+
+	var isRelay bool
+	var err error
+	if r, ok := ph.getReactor().(module.PayloadReactor); ok {
+		pkt.Retain()
+		isRelay, err = r.OnReceivePayload(pkt.subProtocol, pkt, p.ID())
+	} else {
+		pkt.escape()
+		isRelay, err = ph.getReactor().OnReceive(pkt.subProtocol, pkt.payload, p.ID())
+	}
+	if err != nil {
+		//ph.logger.Debugln("handleReceived", err)
+	}
 
-				if isRelay && pkt.ttl == byte(module.BROADCAST_ALL) && pkt.dest != p2pDestPeer {
-					if err := ph.m.relay(pkt); err != nil {
-						ph.onFailure(err, pkt, nil)
-					}
-				}
-			}
+	if isRelay && pkt.ttl == byte(module.BROADCAST_ALL) && pkt.dest != p2pDestPeer {
+		pkt.escape()
+		if err := ph.m.relay(pkt); err != nil {
+			ph.onFailure(err, pkt, nil)
 		}
 	}
 }
 
+func (ph *protocolHandler) dropReceived(ctx context.Context) {
+	ctx.Value(p2pContextKeyPacket).(*Packet).Release()
+}
+
 //callback from PeerToPeer.onPacket() in Peer.onReceiveRoutine
 func (ph *protocolHandler) onPacket(pkt *Packet, p *Peer) {
 	if !ph.IsRun() {
@@ -192,9 +203,14 @@ func (ph *protocolHandler) onPacket(pkt *Packet, p *Peer) {
 	if ok {
 		ctx := context.WithValue(context.Background(), p2pContextKeyPacket, pkt)
 		ctx = context.WithValue(ctx, p2pContextKeyPeer, p)
+		pkt.Retain()
 		if ok = ph.receiveQueue.Push(ctx); !ok {
+			pkt.Release()
 			ph.logger.Infoln("onPacket", "receiveQueue Push failure", ph.name, pkt.protocol, pkt.subProtocol, p.ID())
+			return
 		}
+		ph.m.dispatcher.onPush(ph.priority)
+		ph.pool.schedule(ph)
 	}
 }
 
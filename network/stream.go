@@ -22,6 +22,12 @@ type streamMessage struct {
 	// TODO: NAcks to reduce retransmitted traffics
 }
 
+var streamCodec = codec.BC.WithLimits(codec.Limits{
+	MaxBytes:  DefaultPacketPayloadMax,
+	MaxLength: DefaultDecodeMaxLength,
+	MaxDepth:  DefaultDecodeMaxDepth,
+})
+
 type streamReactor struct {
 	sync.Mutex
 	clock       common.Clock
@@ -87,7 +93,7 @@ func (r *streamReactor) OnReceive(pi module.ProtocolInfo, b []byte, id module.Pe
 			return true
 		}
 		sm := &streamMessage{}
-		_, e := codec.UnmarshalFromBytes(b, sm)
+		_, e := streamCodec.UnmarshalFromBytes(b, sm)
 		if e != nil {
 			err = e
 			return true
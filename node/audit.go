@@ -0,0 +1,364 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+)
+
+const (
+	AuditLogFileName = "audit.log"
+
+	// AuditUserKey is the key of the context for the user authenticated
+	// for the request.
+	AuditUserKey = "auditUser"
+	// AuditUserCLI is the user for the requests through the CLI socket.
+	AuditUserCLI = "cli"
+
+	auditMaxBodySize   = 64 * 1024
+	auditMaxRecordSize = 1024 * 1024
+	auditDefaultLimit  = 100
+)
+
+// AuditRecord is a record of the admin API call. Hash is SHA3-256 of the
+// JSON of the record without Hash, and it includes the hash of the
+// previous record as Prev, so any modification breaks the chain.
+type AuditRecord struct {
+	Seq       int64             `json:"seq"`
+	Timestamp int64             `json:"timestamp"`
+	User      string            `json:"user"`
+	Remote    string            `json:"remote,omitempty"`
+	Method    string            `json:"method"`
+	Path      string            `json:"path"`
+	Params    map[string]string `json:"params,omitempty"`
+	Body      string            `json:"body,omitempty"`
+	Status    int               `json:"status"`
+	Error     string            `json:"error,omitempty"`
+	Prev      common.HexBytes   `json:"prev"`
+	Hash      common.HexBytes   `json:"hash,omitempty"`
+}
+
+func (r *AuditRecord) computeHash() ([]byte, error) {
+	c := *r
+	c.Hash = nil
+	bs, err := json.Marshal(&c)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.SHA3Sum256(bs), nil
+}
+
+type AuditVerifyResult struct {
+	Count int64           `json:"count"`
+	Last  common.HexBytes `json:"last"`
+	Error string          `json:"error,omitempty"`
+}
+
+// AuditLog is an append-only log of the admin API calls.
+type AuditLog struct {
+	lock   sync.Mutex
+	file   string
+	f      *os.File
+	seq    int64
+	last   []byte
+	logger log.Logger
+}
+
+func readAuditRecords(r io.Reader, cb func(rec *AuditRecord) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), auditMaxRecordSize)
+	line := 0
+	for sc.Scan() {
+		line += 1
+		rec := new(AuditRecord)
+		if err := json.Unmarshal(sc.Bytes(), rec); err != nil {
+			return errors.InvalidStateError.Wrapf(err, "InvalidAuditRecord(line=%d)", line)
+		}
+		if err := cb(rec); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+func (l *AuditLog) open() error {
+	if f, err := os.Open(l.file); err == nil {
+		err = readAuditRecords(f, func(rec *AuditRecord) error {
+			l.seq = rec.Seq
+			l.last = rec.Hash
+			return nil
+		})
+		f.Close()
+		if err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	f, err := os.OpenFile(l.file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	l.f = f
+	return nil
+}
+
+func (l *AuditLog) Append(rec *AuditRecord) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.f == nil {
+		return errors.InvalidStateError.New("AuditLogClosed")
+	}
+	rec.Seq = l.seq + 1
+	rec.Prev = l.last
+	hash, err := rec.computeHash()
+	if err != nil {
+		return err
+	}
+	rec.Hash = hash
+	bs, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := l.f.Write(append(bs, '\n')); err != nil {
+		return err
+	}
+	if err := l.f.Sync(); err != nil {
+		return err
+	}
+	l.seq = rec.Seq
+	l.last = rec.Hash
+	return nil
+}
+
+// Records returns records from the sequence number up to the limit.
+func (l *AuditLog) Records(from int64, limit int) ([]*AuditRecord, error) {
+	f, err := os.Open(l.file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records := make([]*AuditRecord, 0)
+	errStop := errors.New("Stop")
+	err = readAuditRecords(f, func(rec *AuditRecord) error {
+		if rec.Seq < from {
+			return nil
+		}
+		records = append(records, rec)
+		if len(records) >= limit {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && err != errStop {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Verify checks sequence numbers, hashes and links of all records.
+func (l *AuditLog) Verify() (*AuditVerifyResult, error) {
+	f, err := os.Open(l.file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	result := new(AuditVerifyResult)
+	err = readAuditRecords(f, func(rec *AuditRecord) error {
+		if rec.Seq != result.Count+1 {
+			return errors.InvalidStateError.Errorf(
+				"InvalidSequence(seq=%d,expected=%d)", rec.Seq, result.Count+1)
+		}
+		if !bytes.Equal(rec.Prev, result.Last) {
+			return errors.InvalidStateError.Errorf(
+				"InvalidPrev(seq=%d,prev=%s,expected=%s)", rec.Seq, rec.Prev, result.Last)
+		}
+		hash, err := rec.computeHash()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(rec.Hash, hash) {
+			return errors.InvalidStateError.Errorf(
+				"InvalidHash(seq=%d,hash=%s,expected=%s)", rec.Seq, rec.Hash, common.HexBytes(hash))
+		}
+		result.Count = rec.Seq
+		result.Last = rec.Hash
+		return nil
+	})
+	if err != nil {
+		if errors.InvalidStateError.Equals(err) {
+			result.Error = err.Error()
+			return result, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (l *AuditLog) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// auditBody returns the body of the request for the record, and restores
+// the body for the handler. Large body is recorded with its hash.
+func auditBody(ctx echo.Context) string {
+	req := ctx.Request()
+	ct := req.Header.Get(echo.HeaderContentType)
+	switch {
+	case strings.HasPrefix(ct, echo.MIMEApplicationJSON):
+		if req.Body == nil {
+			return ""
+		}
+		bs, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(bs))
+		if err != nil {
+			return ""
+		}
+		if len(bs) > auditMaxBodySize {
+			return fmt.Sprintf("sha3:%x", crypto.SHA3Sum256(bs))
+		}
+		buf := bytes.NewBuffer(nil)
+		if err := json.Compact(buf, bs); err != nil {
+			return string(bs)
+		}
+		return buf.String()
+	case strings.HasPrefix(ct, echo.MIMEMultipartForm):
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			return ""
+		}
+		fields := make(map[string]string)
+		for k, v := range form.Value {
+			fields[k] = strings.Join(v, ",")
+		}
+		for k, fhs := range form.File {
+			for _, fh := range fhs {
+				fields[k] = fmt.Sprintf("file(name=%s,size=%d)", fh.Filename, fh.Size)
+			}
+		}
+		bs, _ := json.Marshal(fields)
+		return string(bs)
+	default:
+		return ""
+	}
+}
+
+func newAuditRecord(ctx echo.Context) *AuditRecord {
+	req := ctx.Request()
+	rec := &AuditRecord{
+		Timestamp: common.UnixMicroFromTime(time.Now()),
+		Remote:    ctx.RealIP(),
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Body:      auditBody(ctx),
+	}
+	params := make(map[string]string)
+	for i, name := range ctx.ParamNames() {
+		params[name] = ctx.ParamValues()[i]
+	}
+	for k, v := range req.URL.Query() {
+		params[k] = strings.Join(v, ",")
+	}
+	if len(params) > 0 {
+		rec.Params = params
+	}
+	return rec
+}
+
+// MiddlewareFunc returns the middleware recording the requests. If user
+// is empty, it uses the user set by the authentication with AuditUserKey.
+func (l *AuditLog) MiddlewareFunc(user string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			rec := newAuditRecord(ctx)
+			err := next(ctx)
+
+			rec.User = user
+			if rec.User == "" {
+				if v, ok := ctx.Get(AuditUserKey).(string); ok {
+					rec.User = v
+				}
+			}
+			if err != nil {
+				if he, ok := err.(*echo.HTTPError); ok {
+					rec.Status = he.Code
+					rec.Error = fmt.Sprint(he.Message)
+				} else {
+					rec.Status = http.StatusInternalServerError
+					rec.Error = err.Error()
+				}
+			} else {
+				rec.Status = ctx.Response().Status
+			}
+			if aerr := l.Append(rec); aerr != nil {
+				l.logger.Errorf("Fail to append audit record err=%+v", aerr)
+			}
+			return err
+		}
+	}
+}
+
+func newAuditLog(file string, logger log.Logger) (*AuditLog, error) {
+	l := &AuditLog{
+		file:   file,
+		logger: logger,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (n *Node) GetAuditRecords(from int64, limit int) ([]*AuditRecord, error) {
+	if limit <= 0 {
+		limit = auditDefaultLimit
+	}
+	return n.audit.Records(from, limit)
+}
+
+func (n *Node) VerifyAuditLog() (*AuditVerifyResult, error) {
+	return n.audit.Verify()
+}
@@ -0,0 +1,109 @@
+package node
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+)
+
+func TestAuditLog_AppendAndVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := path.Join(dir, AuditLogFileName)
+	l, err := newAuditLog(file, log.GlobalLogger())
+	assert.NoError(t, err)
+	for _, p := range []string{"/chain/0x1/stop", "/chain/0x1/reset"} {
+		assert.NoError(t, l.Append(&AuditRecord{
+			User: AuditUserCLI, Method: http.MethodPost, Path: p, Status: http.StatusOK,
+		}))
+	}
+	assert.NoError(t, l.Close())
+
+	// it continues the chain after reopening
+	l, err = newAuditLog(file, log.GlobalLogger())
+	assert.NoError(t, err)
+	assert.NoError(t, l.Append(&AuditRecord{
+		User: AuditUserCLI, Method: http.MethodPost, Path: "/chain/0x1/start", Status: http.StatusOK,
+	}))
+
+	records, err := l.Records(2, 10)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.EqualValues(t, 2, records[0].Seq)
+	assert.Equal(t, records[0].Hash, records[1].Prev)
+
+	result, err := l.Verify()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, result.Count)
+	assert.Equal(t, records[1].Hash, result.Last)
+	assert.Empty(t, result.Error)
+	assert.NoError(t, l.Close())
+
+	// modification of a record breaks the chain
+	bs, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	bs = []byte(strings.Replace(string(bs), "reset", "prune", 1))
+	assert.NoError(t, ioutil.WriteFile(file, bs, 0600))
+
+	result, err = l.Verify()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, result.Count)
+	assert.Contains(t, result.Error, "InvalidHash")
+}
+
+func TestAuditLog_Middleware(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := newAuditLog(path.Join(dir, AuditLogFileName), log.GlobalLogger())
+	assert.NoError(t, err)
+	defer l.Close()
+
+	e := echo.New()
+	g := e.Group(UrlChain, l.MiddlewareFunc(AuditUserCLI))
+	var body string
+	g.POST("/:"+ParamCID+"/configure", func(ctx echo.Context) error {
+		bs, err := ioutil.ReadAll(ctx.Request().Body)
+		if err != nil {
+			return err
+		}
+		body = string(bs)
+		return ctx.String(http.StatusOK, "OK")
+	})
+	g.POST("/:"+ParamCID+"/stop", func(ctx echo.Context) error {
+		return echo.NewHTTPError(http.StatusConflict, "AlreadyStopped")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, UrlChain+"/0x1/configure",
+		strings.NewReader(`{ "key": "seedAddress", "value": "a:1" }`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	req = httptest.NewRequest(http.MethodPost, UrlChain+"/0x1/stop", nil)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	// handler can read the body, which is recorded also
+	assert.Equal(t, `{ "key": "seedAddress", "value": "a:1" }`, body)
+
+	records, err := l.Records(0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, AuditUserCLI, records[0].User)
+	assert.Equal(t, "/chain/0x1/configure", records[0].Path)
+	assert.Equal(t, "0x1", records[0].Params[ParamCID])
+	assert.Equal(t, `{"key":"seedAddress","value":"a:1"}`, records[0].Body)
+	assert.Equal(t, http.StatusOK, records[0].Status)
+	assert.Equal(t, http.StatusConflict, records[1].Status)
+	assert.Equal(t, "AlreadyStopped", records[1].Error)
+}
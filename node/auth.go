@@ -145,6 +145,7 @@ func (a *Auth) validator(s string, ctx echo.Context) (b bool, err error) {
 	if id, ok := a.addrs[addr]; ok {
 		if ts := a.users[id]; ts < timestamp {
 			a.users[id] = timestamp
+			ctx.Set(AuditUserKey, id)
 			log.Traceln("valid signature", ts, timestamp)
 			return true, nil
 		}
@@ -0,0 +1,249 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package node
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/icon-project/goloop/chain"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+// MaxBackupDepth is the limit of the number of incremental backups
+// chained to a full backup.
+const MaxBackupDepth = 256
+
+// backupSet is a full backup with its incremental backups. The last one
+// is the target backup of the set.
+type backupSet struct {
+	readers []*zip.ReadCloser
+	info    *chain.BackupInfo
+	files   []*zip.File
+}
+
+func (s *backupSet) Close() {
+	for _, r := range s.readers {
+		r.Close()
+	}
+	s.readers = nil
+}
+
+// Depth returns number of incremental backups in the set.
+func (s *backupSet) Depth() int {
+	return len(s.readers) - 1
+}
+
+func openBackupSet(dir, name string) (ret *backupSet, rerr error) {
+	s := new(backupSet)
+	defer func() {
+		if rerr != nil {
+			s.Close()
+		}
+	}()
+
+	var infos []*chain.BackupInfo
+	for next := name; next != ""; {
+		if len(s.readers) > MaxBackupDepth {
+			return nil, errors.IllegalArgumentError.Errorf(
+				"TooDeepBackupChain(backup=%s)", name)
+		}
+		zr, err := zip.OpenReader(path.Join(dir, next))
+		if err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err,
+				"ZipOpenFailure(backup=%s)", next)
+		}
+		s.readers = append([]*zip.ReadCloser{zr}, s.readers...)
+		info, err := chain.ReadBackupInfo(&zr.Reader)
+		if err != nil {
+			return nil, errors.IllegalArgumentError.Wrapf(err,
+				"InvalidBackupInfo(backup=%s)", next)
+		}
+		if info.Codec != codec.BC.Name() {
+			return nil, errors.IllegalArgumentError.Errorf(
+				"IncompatibleCodec(backup=%s,codec=%s,system=%s)",
+				next, info.Codec, codec.BC.Name())
+		}
+		if len(infos) > 0 {
+			last := infos[0]
+			if last.CID != info.CID || last.NID != info.NID {
+				return nil, errors.IllegalArgumentError.Errorf(
+					"InvalidBaseBackup(base=%s,cid=%s,nid=%s)",
+					next, info.CID.String(), info.NID.String())
+			}
+		}
+		infos = append([]*chain.BackupInfo{info}, infos...)
+		next = info.Base
+	}
+	s.info = infos[len(infos)-1]
+
+	target := s.readers[len(s.readers)-1]
+	manifest, err := chain.ReadBackupManifest(&target.Reader)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err,
+			"InvalidManifest(backup=%s)", name)
+	}
+	if manifest == nil {
+		if len(s.readers) > 1 {
+			return nil, errors.IllegalArgumentError.Errorf(
+				"NoManifestInIncrementalBackup(backup=%s)", name)
+		}
+		s.files = target.File
+		return s, nil
+	}
+
+	entries := make(map[string]*zip.File)
+	for _, zr := range s.readers {
+		for _, f := range zr.File {
+			if f.Name == chain.BackupManifestFile {
+				continue
+			}
+			entries[f.Name] = f
+		}
+	}
+	names := make([]string, 0, len(manifest.Files))
+	for n := range manifest.Files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		f, ok := entries[n]
+		if !ok {
+			return nil, errors.IllegalArgumentError.Errorf(
+				"MissingFileInBackup(backup=%s,file=%s)", name, n)
+		}
+		if int64(f.UncompressedSize64) != manifest.Files[n].Size {
+			return nil, errors.IllegalArgumentError.Errorf(
+				"InvalidFileSize(backup=%s,file=%s)", name, n)
+		}
+		s.files = append(s.files, f)
+	}
+	return s, nil
+}
+
+type BackupVerifyResult struct {
+	Name  string `json:"name"`
+	Depth int    `json:"depth"`
+	Files int    `json:"files"`
+	Size  int64  `json:"size"`
+	Error string `json:"error,omitempty"`
+}
+
+func verifyZipFile(f *zip.File) (int64, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	// zip.File.Open returns reader verifying the checksum on EOF.
+	return io.Copy(ioutil.Discard, rc)
+}
+
+// verifyBackup checks that all the files for restoring the backup are
+// available and readable without checksum errors.
+func verifyBackup(dir, name string) (*BackupVerifyResult, error) {
+	s, err := openBackupSet(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	result := &BackupVerifyResult{
+		Name:  name,
+		Depth: s.Depth(),
+	}
+	for _, f := range s.files {
+		if sz, err := verifyZipFile(f); err != nil {
+			result.Error = errors.Wrapf(err, "FailToVerify(file=%s)", f.Name).Error()
+			return result, nil
+		} else {
+			result.Files += 1
+			result.Size += sz
+		}
+	}
+	return result, nil
+}
+
+// applyBackupRetention removes old backups of the chain except latest
+// `keep` backups and their bases.
+func applyBackupRetention(dir string, backups []BackupInfo, keep int) ([]string, error) {
+	if keep <= 0 || len(backups) <= keep {
+		return nil, nil
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name < backups[j].Name
+	})
+	bases := make(map[string]string)
+	for _, b := range backups {
+		bases[b.Name] = b.Base
+	}
+	kept := make(map[string]bool)
+	for _, b := range backups[len(backups)-keep:] {
+		for n := b.Name; n != "" && !kept[n]; n = bases[n] {
+			kept[n] = true
+		}
+	}
+	var removed []string
+	for _, b := range backups {
+		if kept[b.Name] {
+			continue
+		}
+		if err := os.Remove(path.Join(dir, b.Name)); err != nil {
+			return removed, err
+		}
+		removed = append(removed, b.Name)
+	}
+	return removed, nil
+}
+
+func latestBackupOf(backups []BackupInfo, cid int) *BackupInfo {
+	var latest *BackupInfo
+	for i := range backups {
+		b := &backups[i]
+		if int(b.CID.Value) != cid || strings.HasPrefix(b.Name, chain.TemporalBackupFile) {
+			continue
+		}
+		if latest == nil || latest.Name < b.Name {
+			latest = b
+		}
+	}
+	return latest
+}
+
+// depthOfBackup returns number of incremental backups chained to the full
+// backup. It returns -1 if it fails to find any backup in the chain.
+func depthOfBackup(backups []BackupInfo, name string) int {
+	bases := make(map[string]string)
+	for _, b := range backups {
+		bases[b.Name] = b.Base
+	}
+	depth := -1
+	for n := name; n != ""; depth++ {
+		base, ok := bases[n]
+		if !ok || depth > MaxBackupDepth {
+			return -1
+		}
+		n = base
+	}
+	return depth
+}
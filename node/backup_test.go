@@ -0,0 +1,113 @@
+package node
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/chain"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+)
+
+func writeTestBackup(t *testing.T, dir, name, base string, files map[string]string, all map[string]string) {
+	fd, err := os.Create(path.Join(dir, name))
+	assert.NoError(t, err)
+	defer fd.Close()
+
+	zw := zip.NewWriter(fd)
+	info := &chain.BackupInfo{
+		NID:     common.HexInt32{Value: 1},
+		CID:     common.HexInt32{Value: 1},
+		Channel: "test",
+		Codec:   codec.BC.Name(),
+		Base:    base,
+	}
+	bs, _ := json.Marshal(info)
+	assert.NoError(t, zw.SetComment(string(bs)))
+	for n, v := range files {
+		w, err := zw.Create(n)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(v))
+		assert.NoError(t, err)
+	}
+	manifest := &chain.BackupManifest{Files: map[string]chain.BackupFileInfo{}}
+	for n, v := range all {
+		manifest.Files[n] = chain.BackupFileInfo{Size: int64(len(v))}
+	}
+	w, err := zw.Create(chain.BackupManifestFile)
+	assert.NoError(t, err)
+	bs, _ = json.Marshal(manifest)
+	_, err = w.Write(bs)
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+}
+
+func TestBackupSet_Incremental(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	full := map[string]string{"db/a": "aaaa", "db/b": "bb"}
+	writeTestBackup(t, dir, "b1.zip", "", full, full)
+
+	all2 := map[string]string{"db/a": "aaaa", "db/c": "ccc"}
+	writeTestBackup(t, dir, "b2.zip", "b1.zip", map[string]string{"db/c": "ccc"}, all2)
+
+	bs, err := openBackupSet(dir, "b2.zip")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, bs.Depth())
+	assert.Equal(t, "b1.zip", bs.info.Base)
+	var names []string
+	for _, f := range bs.files {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"db/a", "db/c"}, names)
+	bs.Close()
+
+	r, err := verifyBackup(dir, "b2.zip")
+	assert.NoError(t, err)
+	assert.Empty(t, r.Error)
+	assert.Equal(t, 2, r.Files)
+	assert.EqualValues(t, 7, r.Size)
+
+	// missing file in the chain
+	writeTestBackup(t, dir, "b3.zip", "b2.zip", nil, map[string]string{"db/d": "d"})
+	_, err = openBackupSet(dir, "b3.zip")
+	assert.Error(t, err)
+
+	// missing base
+	assert.NoError(t, os.Remove(path.Join(dir, "b1.zip")))
+	_, err = openBackupSet(dir, "b2.zip")
+	assert.Error(t, err)
+}
+
+func TestBackup_Retention(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backups := []BackupInfo{
+		{Name: "b1.zip"},
+		{Name: "b2.zip"},
+		{Name: "b3.zip", BackupInfo: chain.BackupInfo{Base: "b2.zip"}},
+		{Name: "b4.zip", BackupInfo: chain.BackupInfo{Base: "b3.zip"}},
+	}
+	for _, b := range backups {
+		assert.NoError(t, ioutil.WriteFile(path.Join(dir, b.Name), []byte{}, 0644))
+	}
+	assert.Equal(t, 2, depthOfBackup(backups, "b4.zip"))
+	assert.Equal(t, 0, depthOfBackup(backups, "b1.zip"))
+	assert.Equal(t, -1, depthOfBackup(backups, "b5.zip"))
+
+	removed, err := applyBackupRetention(dir, backups, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b1.zip"}, removed)
+	_, err = os.Stat(path.Join(dir, "b2.zip"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,368 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package node
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+)
+
+const (
+	BackupScheduleFileName = "backup_schedule.json"
+
+	backupPollInterval = 200 * time.Millisecond
+	backupStopTimeout  = 5 * time.Minute
+)
+
+type BackupSchedule struct {
+	// Interval between backups in time.Duration format (ex: "12h").
+	Interval string `json:"interval"`
+	// Incremental makes backups including only changed files after the
+	// previous backup.
+	Incremental bool `json:"incremental,omitempty"`
+	// FullEvery is the number of incremental backups before the next
+	// full backup. Zero means it makes full backup only at first.
+	FullEvery int `json:"fullEvery,omitempty"`
+	// Retention is the number of backups to keep for the chain. Zero means
+	// it doesn't remove any backups.
+	Retention int `json:"retention,omitempty"`
+	// Verify the backup after it's done.
+	Verify bool `json:"verify,omitempty"`
+}
+
+func (s *BackupSchedule) Validate() (time.Duration, error) {
+	d, err := time.ParseDuration(s.Interval)
+	if err != nil {
+		return 0, errors.IllegalArgumentError.Wrapf(err,
+			"InvalidInterval(interval=%s)", s.Interval)
+	}
+	if d < time.Minute {
+		return 0, errors.IllegalArgumentError.Errorf(
+			"TooShortInterval(interval=%s)", s.Interval)
+	}
+	if s.FullEvery < 0 || s.Retention < 0 {
+		return 0, errors.IllegalArgumentError.Errorf(
+			"NegativeValue(fullEvery=%d,retention=%d)", s.FullEvery, s.Retention)
+	}
+	return d, nil
+}
+
+type BackupScheduleView struct {
+	BackupSchedule
+	Next      time.Time `json:"next"`
+	Running   bool      `json:"running"`
+	Last      string    `json:"last,omitempty"`
+	LastTime  time.Time `json:"lastTime,omitempty"`
+	LastError string    `json:"lastError,omitempty"`
+}
+
+type backupScheduleEntry struct {
+	BackupSchedule
+	cid      int
+	interval time.Duration
+	timer    *time.Timer
+	next     time.Time
+	running  bool
+	last     string
+	lastTime time.Time
+	lastErr  error
+}
+
+// BackupScheduler makes backups of chains periodically. Like manual
+// backups, each run stops the chain while it copies the database, so the
+// chain is down for the time of the backup. Incremental backups shorten
+// the downtime as only changed files are copied.
+type BackupScheduler struct {
+	lock    sync.Mutex
+	node    *Node
+	file    string
+	entries map[int]*backupScheduleEntry
+	logger  log.Logger
+}
+
+func (s *BackupScheduler) load() error {
+	bs, err := ioutil.ReadFile(s.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	schedules := make(map[string]*BackupSchedule)
+	if err := json.Unmarshal(bs, &schedules); err != nil {
+		return err
+	}
+	for key, schedule := range schedules {
+		cid, err := strconv.ParseInt(key, 0, 32)
+		if err != nil {
+			return errors.Wrapf(err, "InvalidCID(cid=%s)", key)
+		}
+		if err := s._set(int(cid), schedule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BackupScheduler) _save() error {
+	schedules := make(map[string]*BackupSchedule)
+	for cid, e := range s.entries {
+		schedules["0x"+strconv.FormatInt(int64(cid), 16)] = &e.BackupSchedule
+	}
+	bs, err := json.Marshal(schedules)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.file, bs, 0644)
+}
+
+func (s *BackupScheduler) _set(cid int, schedule *BackupSchedule) error {
+	d, err := schedule.Validate()
+	if err != nil {
+		return err
+	}
+	if e, ok := s.entries[cid]; ok {
+		e.timer.Stop()
+	}
+	e := &backupScheduleEntry{
+		BackupSchedule: *schedule,
+		cid:            cid,
+		interval:       d,
+		next:           time.Now().Add(d),
+	}
+	e.timer = time.AfterFunc(d, func() {
+		s.onTime(e)
+	})
+	s.entries[cid] = e
+	return nil
+}
+
+func (s *BackupScheduler) Set(cid int, schedule *BackupSchedule) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s._set(cid, schedule); err != nil {
+		return err
+	}
+	return s._save()
+}
+
+func (s *BackupScheduler) Remove(cid int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.entries[cid]
+	if !ok {
+		return errors.NotFoundError.Errorf("NoBackupSchedule(cid=%#x)", cid)
+	}
+	e.timer.Stop()
+	delete(s.entries, cid)
+	return s._save()
+}
+
+func (s *BackupScheduler) Get(cid int) *BackupScheduleView {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.entries[cid]
+	if !ok {
+		return nil
+	}
+	return &BackupScheduleView{
+		BackupSchedule: e.BackupSchedule,
+		Next:           e.next,
+		Running:        e.running,
+		Last:           e.last,
+		LastTime:       e.lastTime,
+		LastError:      errors.ToString(e.lastErr),
+	}
+}
+
+func (s *BackupScheduler) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, e := range s.entries {
+		e.timer.Stop()
+	}
+}
+
+func (s *BackupScheduler) onTime(e *backupScheduleEntry) {
+	s.lock.Lock()
+	if s.entries[e.cid] != e {
+		s.lock.Unlock()
+		return
+	}
+	e.running = true
+	schedule := e.BackupSchedule
+	s.lock.Unlock()
+
+	name, err := s.node.runScheduledBackup(e.cid, &schedule)
+	if err != nil {
+		s.logger.Warnf("Scheduled backup failed cid=%#x err=%+v", e.cid, err)
+	} else {
+		s.logger.Infof("Scheduled backup done cid=%#x name=%s", e.cid, name)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e.running = false
+	e.last = name
+	e.lastTime = time.Now()
+	e.lastErr = err
+	if s.entries[e.cid] == e {
+		e.next = time.Now().Add(e.interval)
+		e.timer.Reset(e.interval)
+	}
+}
+
+func newBackupScheduler(n *Node, file string) *BackupScheduler {
+	return &BackupScheduler{
+		node:    n,
+		file:    file,
+		entries: make(map[int]*backupScheduleEntry),
+		logger:  n.logger,
+	}
+}
+
+func waitChain(c *Chain, timeout time.Duration, cond func(c *Chain) bool) error {
+	after := time.After(timeout)
+	for !cond(c) {
+		select {
+		case <-after:
+			return errors.TimeoutError.Errorf("TimeoutOnWaitingChain(cid=%#x)", c.CID())
+		case <-time.After(backupPollInterval):
+		}
+	}
+	return nil
+}
+
+func isChainStopped(c *Chain) bool {
+	return c.IsStopped()
+}
+
+// runScheduledBackup makes a backup of the chain. If the chain is running,
+// it stops the chain for the backup and starts it again.
+func (n *Node) runScheduledBackup(cid int, s *BackupSchedule) (string, error) {
+	c := n.GetChain(cid)
+	if c == nil {
+		return "", errors.NotFoundError.Errorf("NoChain(cid=%#x)", cid)
+	}
+	started := c.IsStarted()
+	if started {
+		if err := n.StopChain(cid); err != nil {
+			return "", err
+		}
+		if err := waitChain(c, backupStopTimeout, isChainStopped); err != nil {
+			return "", err
+		}
+		defer func() {
+			if err := n.StartChain(cid); err != nil {
+				n.logger.Errorf("Fail to restart chain after backup cid=%#x err=%+v",
+					cid, err)
+			}
+		}()
+	} else if !c.IsStopped() {
+		return "", errors.InvalidStateError.Errorf("ChainIsBusy(cid=%#x)", cid)
+	}
+
+	incremental := false
+	if s.Incremental {
+		backups, err := n.GetBackups()
+		if err != nil {
+			return "", err
+		}
+		if latest := latestBackupOf(backups, cid); latest != nil {
+			depth := depthOfBackup(backups, latest.Name)
+			incremental = depth >= 0 && (s.FullEvery == 0 || depth < s.FullEvery)
+		}
+	}
+
+	name, err := n.BackupChain(cid, false, incremental)
+	if err != nil {
+		return "", err
+	}
+	// backup task doesn't take so much time to start, so it waits for
+	// the task to be done with the same timeout.
+	if err := waitChain(c, backupStopTimeout*12, isChainStopped); err != nil {
+		return name, err
+	}
+	if _, _, err := c.State(); err != nil {
+		return name, err
+	}
+	if err := n.StopChain(cid); err != nil {
+		return name, err
+	}
+
+	if s.Verify {
+		if r, err := n.VerifyBackup(name); err != nil {
+			return name, err
+		} else if r.Error != "" {
+			return name, errors.InvalidStateError.Errorf(
+				"BackupVerificationFailure(name=%s,err=%s)", name, r.Error)
+		}
+	}
+	if s.Retention > 0 {
+		if err := n.applyBackupRetention(cid, s.Retention); err != nil {
+			return name, err
+		}
+	}
+	return name, nil
+}
+
+func (n *Node) applyBackupRetention(cid int, keep int) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	backups, err := n._getBackups()
+	if err != nil {
+		return err
+	}
+	var targets []BackupInfo
+	for _, b := range backups {
+		if int(b.CID.Value) == cid {
+			targets = append(targets, b)
+		}
+	}
+	removed, err := applyBackupRetention(n.cfg.ResolveAbsolute(n.cfg.BackupDir), targets, keep)
+	for _, name := range removed {
+		n.logger.Infof("Remove old backup cid=%#x name=%s", cid, name)
+	}
+	return err
+}
+
+func (n *Node) GetBackupSchedule(cid int) *BackupScheduleView {
+	return n.bks.Get(cid)
+}
+
+func (n *Node) SetBackupSchedule(cid int, s *BackupSchedule) error {
+	if c := n.GetChain(cid); c == nil {
+		return errors.NotFoundError.Errorf("NoChain(cid=%#x)", cid)
+	}
+	return n.bks.Set(cid, s)
+}
+
+func (n *Node) RemoveBackupSchedule(cid int) error {
+	return n.bks.Remove(cid)
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package node
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+)
+
+const (
+	ChainLogFileName = "chain_log.json"
+
+	// ChainLogDefaultFileName is the name of the log file in the chain
+	// directory, used if the writer doesn't have the filename.
+	ChainLogDefaultFileName = "chain.log"
+)
+
+// ChainLogs keeps the log routing of the chains, and stores them in the
+// file for applying them again on restart.
+type ChainLogs struct {
+	lock    sync.Mutex
+	node    *Node
+	file    string
+	configs map[int]*log.ChainLogConfig
+}
+
+func (s *ChainLogs) load() error {
+	bs, err := ioutil.ReadFile(s.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	configs := make(map[string]*log.ChainLogConfig)
+	if err := json.Unmarshal(bs, &configs); err != nil {
+		return err
+	}
+	for key, cfg := range configs {
+		cid, err := strconv.ParseInt(key, 0, 32)
+		if err != nil {
+			return errors.Wrapf(err, "InvalidCID(cid=%s)", key)
+		}
+		if err := s._set(int(cid), cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ChainLogs) _save() error {
+	configs := make(map[string]*log.ChainLogConfig)
+	for cid, cfg := range s.configs {
+		configs["0x"+strconv.FormatInt(int64(cid), 16)] = cfg
+	}
+	bs, err := json.Marshal(configs)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.file, bs, 0644)
+}
+
+// resolve returns the configuration with the absolute filename for the
+// writer.
+func (s *ChainLogs) resolve(cid int, cfg *log.ChainLogConfig) (*log.ChainLogConfig, error) {
+	if cfg.Writer == nil {
+		return cfg, nil
+	}
+	resolved := *cfg
+	w := *cfg.Writer
+	if w.Filename == "" {
+		c := s.node.GetChain(cid)
+		if c == nil {
+			return nil, errors.NotFoundError.Errorf("NoChain(cid=%#x)", cid)
+		}
+		w.Filename = path.Join(c.cfg.AbsBaseDir(), ChainLogDefaultFileName)
+	} else {
+		w.Filename = s.node.cfg.ResolveAbsolute(w.Filename)
+	}
+	resolved.Writer = &w
+	return &resolved, nil
+}
+
+func (s *ChainLogs) _set(cid int, cfg *log.ChainLogConfig) error {
+	if cfg.Writer == nil && len(cfg.Forwarders) == 0 {
+		return errors.IllegalArgumentError.New("NoWriterAndForwarders")
+	}
+	resolved, err := s.resolve(cid, cfg)
+	if err != nil {
+		return err
+	}
+	if err := log.SetChainLog(cid, resolved); err != nil {
+		return errors.IllegalArgumentError.Wrapf(err,
+			"InvalidChainLog(cid=%#x)", cid)
+	}
+	s.configs[cid] = cfg
+	return nil
+}
+
+func (s *ChainLogs) Set(cid int, cfg *log.ChainLogConfig) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s._set(cid, cfg); err != nil {
+		return err
+	}
+	return s._save()
+}
+
+func (s *ChainLogs) Remove(cid int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.configs[cid]; !ok {
+		return errors.NotFoundError.Errorf("NoChainLog(cid=%#x)", cid)
+	}
+	log.RemoveChainLog(cid)
+	delete(s.configs, cid)
+	return s._save()
+}
+
+func (s *ChainLogs) Get(cid int) *log.ChainLogConfig {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.configs[cid]
+}
+
+func newChainLogs(n *Node, file string) *ChainLogs {
+	return &ChainLogs{
+		node:    n,
+		file:    file,
+		configs: make(map[int]*log.ChainLogConfig),
+	}
+}
+
+func (n *Node) GetChainLog(cid int) *log.ChainLogConfig {
+	return n.cls.Get(cid)
+}
+
+func (n *Node) SetChainLog(cid int, cfg *log.ChainLogConfig) error {
+	if c := n.GetChain(cid); c == nil {
+		return errors.NotFoundError.Errorf("NoChain(cid=%#x)", cid)
+	}
+	return n.cls.Set(cid, cfg)
+}
+
+func (n *Node) RemoveChainLog(cid int) error {
+	return n.cls.Remove(cid)
+}
@@ -31,6 +31,7 @@ type StaticConfig struct {
 
 	AuthSkipIfEmptyUsers bool `json:"auth_skip_if_empty_users,omitempty"`
 	NIDForP2P            bool `json:"nid_for_p2p,omitempty"`
+	WatchdogRecover      bool `json:"watchdog_recover,omitempty"`
 
 	BaseDir  string `json:"node_dir"`
 	FilePath string `json:"-"` // absolute path
@@ -108,6 +109,7 @@ const (
 
 type RuntimeConfig struct {
 	EEInstances       int    `json:"eeInstances"`
+	EEMaxInstances    int    `json:"eeMaxInstances,omitempty"`
 	RPCDefaultChannel string `json:"rpcDefaultChannel"`
 	RPCIncludeDebug   bool   `json:"rpcIncludeDebug"`
 	RPCRosetta        bool   `json:"rpcRosetta"`
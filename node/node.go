@@ -15,8 +15,11 @@ import (
 
 	"github.com/icon-project/goloop/chain"
 	"github.com/icon-project/goloop/chain/gs"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/watchdog"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/network"
 	"github.com/icon-project/goloop/server"
@@ -35,10 +38,13 @@ type Node struct {
 	srv  *server.Manager
 	pm   eeproxy.Manager
 	rsm  RestoreManager
+	bks  *BackupScheduler
+	cls  *ChainLogs
 	cfg  StaticConfig
 	rcfg *RuntimeConfig
 
 	logger log.Logger
+	audit  *AuditLog
 
 	mtx sync.RWMutex
 
@@ -342,6 +348,7 @@ func (n *Node) Start() {
 }
 
 func (n *Node) Stop() {
+	n.bks.Stop()
 	if err := n.nt.Close(); err != nil {
 		log.Panicf("fail to P2P close err=%+v", err)
 	}
@@ -376,6 +383,21 @@ func (n *Node) JoinChain(
 		return nil, errors.Wrap(err, "fail to get NID for genesis")
 	}
 
+	if p.SyncAnchor != nil {
+		if err := p.SyncAnchor.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := module.NewHistoryCapability(p.HistoryDepth, p.HistoryQueries); err != nil {
+		return nil, err
+	}
+
+	if p.ReceiptCompression != "" && !db.IsCompression(p.ReceiptCompression) {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidReceiptCompression(%s)", p.ReceiptCompression)
+	}
+
 	channel := chain.GetChannel(p.Channel, nid)
 
 	if err := n._canAdd(cid, nid, channel, false); err != nil {
@@ -391,29 +413,47 @@ func (n *Node) JoinChain(
 	cfgFile, _ := filepath.Abs(path.Join(chainDir, ChainConfigFileName))
 
 	cfg := &chain.Config{
-		NID:              nid,
-		DBType:           p.DBType,
-		Platform:         p.Platform,
-		Channel:          channel,
-		SecureSuites:     p.SecureSuites,
-		SecureAeads:      p.SecureAeads,
-		SeedAddr:         p.SeedAddr,
-		Role:             p.Role,
-		GenesisStorage:   genesisStorage,
-		ConcurrencyLevel: p.ConcurrencyLevel,
-		NormalTxPoolSize: p.NormalTxPoolSize,
-		PatchTxPoolSize:  p.PatchTxPoolSize,
-		MaxBlockTxBytes:  p.MaxBlockTxBytes,
-		NodeCache:        p.NodeCache,
-		DefWaitTimeout:   p.DefWaitTimeout,
-		MaxWaitTimeout:   p.MaxWaitTimeout,
-		TxTimeout:        p.TxTimeout,
-		AutoStart:        p.AutoStart,
-		FilePath:         cfgFile,
-		NIDForP2P:        n.cfg.NIDForP2P,
-		ChildrenLimit:    p.ChildrenLimit,
-		NephewsLimit:     p.NephewsLimit,
-		ValidateTxOnSend: p.ValidateTxOnSend,
+		NID:                 nid,
+		DBType:              p.DBType,
+		Platform:            p.Platform,
+		Channel:             channel,
+		SecureSuites:        p.SecureSuites,
+		SecureAeads:         p.SecureAeads,
+		SeedAddr:            p.SeedAddr,
+		Role:                p.Role,
+		GenesisStorage:      genesisStorage,
+		ConcurrencyLevel:    p.ConcurrencyLevel,
+		NormalTxPoolSize:    p.NormalTxPoolSize,
+		PatchTxPoolSize:     p.PatchTxPoolSize,
+		MaxBlockTxBytes:     p.MaxBlockTxBytes,
+		NodeCache:           p.NodeCache,
+		DefWaitTimeout:      p.DefWaitTimeout,
+		MaxWaitTimeout:      p.MaxWaitTimeout,
+		TxTimeout:           p.TxTimeout,
+		AutoStart:           p.AutoStart,
+		FilePath:            cfgFile,
+		NIDForP2P:           n.cfg.NIDForP2P,
+		ChildrenLimit:       p.ChildrenLimit,
+		NephewsLimit:        p.NephewsLimit,
+		ValidateTxOnSend:    p.ValidateTxOnSend,
+		AutoRole:            p.AutoRole,
+		RecordInternalCalls: p.RecordInternalCalls,
+		RecordAddressIndex:  p.RecordAddressIndex,
+		RecordTokenIndex:    p.RecordTokenIndex,
+		TxTimestampWindow:   p.TxTimestampWindow,
+		TxPoolTTL:           p.TxPoolTTL,
+		TxPoolPersist:       p.TxPoolPersist,
+		PriorityTxRatio:     p.PriorityTxRatio,
+		SyncServerLimit:     p.SyncServerLimit,
+		SyncServerBandwidth: p.SyncServerBandwidth,
+		Sequencer:           p.Sequencer,
+		ReceiptCompression:  p.ReceiptCompression,
+		ReplicaDBDir:        p.ReplicaDBDir,
+		ReplicaInterval:     p.ReplicaInterval,
+		HistoryDepth:        p.HistoryDepth,
+		HistoryQueries:      p.HistoryQueries,
+		WatchdogRestart:     p.WatchdogRestart,
+		SyncAnchor:          p.SyncAnchor,
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -447,6 +487,16 @@ func (n *Node) LeaveChain(cid int) error {
 	if err != nil {
 		return err
 	}
+	if n.bks.Get(cid) != nil {
+		if err := n.bks.Remove(cid); err != nil {
+			n.logger.Warnf("Fail to remove backup schedule cid=%#x err=%+v", cid, err)
+		}
+	}
+	if n.cls.Get(cid) != nil {
+		if err := n.cls.Remove(cid); err != nil {
+			n.logger.Warnf("Fail to remove chain log cid=%#x err=%+v", cid, err)
+		}
+	}
 
 	chainPath := c.cfg.AbsBaseDir()
 	if err := os.RemoveAll(chainPath); err != nil {
@@ -517,7 +567,9 @@ func (n *Node) ImportChain(cid int, s string, height int64) error {
 	return c.Import(s, height)
 }
 
-func (n *Node) PruneChain(cid int, dbt string, height int64) error {
+// PruneChain prunes the database of the chain. If keep is positive, then
+// it keeps the number of latest blocks regardless of the height.
+func (n *Node) PruneChain(cid int, dbt string, height int64, keep int64, headers bool) error {
 	defer n.mtx.RUnlock()
 	n.mtx.RLock()
 
@@ -525,12 +577,55 @@ func (n *Node) PruneChain(cid int, dbt string, height int64) error {
 	if err != nil {
 		return err
 	}
+	if keep > 0 {
+		if !c.IsStopped() {
+			return errors.InvalidStateError.Errorf("ChainIsNotStopped(cid=%#x)", cid)
+		}
+		_, last, _ := c.State()
+		height = last - keep + 1
+	}
 	chainDir := c.cfg.AbsBaseDir()
 	gs := path.Join(chainDir, ChainGenesisZipFileName)
-	return c.Prune(gs, dbt, height)
+	return c.Prune(gs, dbt, height, headers)
 }
 
-func (n *Node) BackupChain(cid int, manual bool) (string, error) {
+// RollbackChain rolls back the stopped chain to the height.
+func (n *Node) RollbackChain(cid int, height int64) error {
+	defer n.mtx.RUnlock()
+	n.mtx.RLock()
+
+	c, err := n._get(cid)
+	if err != nil {
+		return err
+	}
+	return c.Rollback(height)
+}
+
+// SendTransactionBundle submits the bundle of the transactions signed by the
+// sequencer of the chain. It returns IDs of the transactions.
+func (n *Node) SendTransactionBundle(cid int, txs []json.RawMessage, sig []byte) ([][]byte, error) {
+	n.mtx.RLock()
+	c, err := n._get(cid)
+	n.mtx.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	bm, sm := c.BlockManager(), c.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, errors.InvalidStateError.Errorf("ChainIsNotStarted(cid=%#x)", cid)
+	}
+	blk, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, err
+	}
+	txis := make([]interface{}, len(txs))
+	for i, tx := range txs {
+		txis[i] = []byte(tx)
+	}
+	return sm.SendTransactionBundle(blk.Result(), blk.Height()+1, txis, sig)
+}
+
+func (n *Node) BackupChain(cid int, manual bool, incremental bool) (string, error) {
 	defer n.mtx.RUnlock()
 	n.mtx.RLock()
 
@@ -540,18 +635,28 @@ func (n *Node) BackupChain(cid int, manual bool) (string, error) {
 	}
 
 	if manual {
-		return "manual", c.Backup("", nil)
+		return "manual", c.Backup("", "", nil)
 	}
 	backupDir := n.cfg.ResolveAbsolute(n.cfg.BackupDir)
 	if err := os.MkdirAll(backupDir, 0700); err != nil {
 		return "", errors.InvalidStateError.Wrapf(err,
 			"Fail to make backup directory=%s", backupDir)
 	}
+	var base string
+	if incremental {
+		backups, err := n._getBackups()
+		if err != nil {
+			return "", err
+		}
+		if latest := latestBackupOf(backups, c.CID()); latest != nil {
+			base = path.Join(backupDir, latest.Name)
+		}
+	}
 	now := time.Now()
 	name := fmt.Sprintf("%#x_%#x_%s_%s.zip", c.CID(), c.NID(), c.Channel(),
 		now.Format("20060102-150405"))
 	file := path.Join(backupDir, name)
-	return name, c.Backup(file, []string{ChainGenesisZipFileName, ChainConfigFileName})
+	return name, c.Backup(file, base, []string{ChainGenesisZipFileName, ChainConfigFileName})
 }
 
 type BackupInfo struct {
@@ -564,9 +669,16 @@ func (n *Node) GetBackups() ([]BackupInfo, error) {
 	n.mtx.Lock()
 	defer n.mtx.Unlock()
 
+	return n._getBackups()
+}
+
+func (n *Node) _getBackups() ([]BackupInfo, error) {
 	backupDir := n.cfg.ResolveAbsolute(n.cfg.BackupDir)
 	fis, err := ioutil.ReadDir(backupDir)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return []BackupInfo{}, nil
+		}
 		return nil, err
 	}
 	infos := make([]BackupInfo, 0, len(fis))
@@ -589,6 +701,21 @@ func (n *Node) GetBackups() ([]BackupInfo, error) {
 	return infos, nil
 }
 
+// VerifyBackup checks whether the backup can be restored. On incremental
+// backup, it also checks its bases.
+func (n *Node) VerifyBackup(name string) (*BackupVerifyResult, error) {
+	backupDir := func() string {
+		n.mtx.RLock()
+		defer n.mtx.RUnlock()
+
+		return n.cfg.ResolveAbsolute(n.cfg.BackupDir)
+	}()
+	if name != path.Base(name) || strings.HasPrefix(name, chain.TemporalBackupFile) {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidBackupName(name=%s)", name)
+	}
+	return verifyBackup(backupDir, name)
+}
+
 type RestoreView struct {
 	State     string `json:"state"`
 	Name      string `json:"name,omitempty"`
@@ -648,15 +775,19 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 		switch key {
 		case "seedAddress":
 			c.cfg.SeedAddr = value
-			c.NetworkManager().SetTrustSeeds(c.cfg.SeedAddr)
+			if nm := c.NetworkManager(); nm != nil {
+				nm.SetTrustSeeds(c.cfg.SeedAddr)
+			}
 		case "role":
 			if uintVal, err := strconv.ParseUint(value, 0, 32); err != nil {
 				return errors.Wrapf(err, "invalid value type")
 			} else {
 				c.cfg.Role = uint(uintVal)
 			}
-			pr := network.PeerRoleFlag(c.cfg.Role)
-			c.NetworkManager().SetInitialRoles(pr.ToRoles()...)
+			if nm := c.NetworkManager(); nm != nil {
+				pr := network.PeerRoleFlag(c.cfg.Role)
+				nm.SetInitialRoles(pr.ToRoles()...)
+			}
 		case "autoStart":
 			if as, err := strconv.ParseBool(value); err != nil {
 				return err
@@ -699,6 +830,11 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 				return errors.Errorf("InvalidNodeCacheOption(%s)", value)
 			}
 			c.cfg.NodeCache = value
+		case "receiptCompression":
+			if !db.IsCompression(value) {
+				return errors.Errorf("InvalidReceiptCompression(%s)", value)
+			}
+			c.cfg.ReceiptCompression = value
 		case "defaultWaitTimeout":
 			if intVal, err := strconv.ParseInt(value, 0, 64); err != nil {
 				return errors.Wrapf(err, "invalid value type")
@@ -767,6 +903,102 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 			} else {
 				c.cfg.ValidateTxOnSend = bc
 			}
+		case "autoRole":
+			if bc, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
+			} else {
+				c.cfg.AutoRole = bc
+			}
+		case "recordInternalCalls":
+			if bc, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
+			} else {
+				c.cfg.RecordInternalCalls = bc
+			}
+		case "recordAddressIndex":
+			if bc, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
+			} else {
+				c.cfg.RecordAddressIndex = bc
+			}
+		case "recordTokenIndex":
+			if bc, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
+			} else {
+				c.cfg.RecordTokenIndex = bc
+			}
+		case "txTimestampWindow":
+			if intVal, err := strconv.ParseInt(value, 0, 64); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.TxTimestampWindow = intVal
+			}
+		case "txPoolTTL":
+			if intVal, err := strconv.ParseInt(value, 0, 64); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.TxPoolTTL = intVal
+			}
+		case "txPoolPersist":
+			if bc, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
+			} else {
+				c.cfg.TxPoolPersist = bc
+			}
+		case "priorityTxRatio":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else if intVal < 0 || intVal > 100 {
+				return errors.Errorf("InvalidRatio(ratio=%d)", intVal)
+			} else {
+				c.cfg.PriorityTxRatio = intVal
+			}
+		case "syncServerLimit":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.SyncServerLimit = intVal
+			}
+		case "syncServerBandwidth":
+			if intVal, err := strconv.ParseInt(value, 0, 64); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.SyncServerBandwidth = intVal
+			}
+		case "replicaDBDir":
+			c.cfg.ReplicaDBDir = value
+		case "replicaInterval":
+			if intVal, err := strconv.ParseInt(value, 0, 64); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.ReplicaInterval = intVal
+			}
+		case "historyDepth":
+			if intVal, err := strconv.ParseInt(value, 0, 64); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.HistoryDepth = intVal
+			}
+		case "historyQueries":
+			if _, err := module.NewHistoryCapability(c.cfg.HistoryDepth, value); err != nil {
+				return err
+			}
+			c.cfg.HistoryQueries = value
+		case "watchdogRestart":
+			if bc, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
+			} else {
+				c.cfg.WatchdogRestart = bc
+			}
+		case "sequencer":
+			if len(value) > 0 {
+				if addr, err := common.NewAddressFromString(value); err != nil {
+					return errors.Wrapf(err, "InvalidValueType(exp=address,val=%s)", value)
+				} else if addr.IsContract() {
+					return errors.Errorf("InvalidSequencer(addr=%s)", value)
+				}
+			}
+			c.cfg.Sequencer = value
 		default:
 			return errors.Errorf("not found key %s", key)
 		}
@@ -862,6 +1094,15 @@ func (n *Node) Configure(key string, value string) error {
 		if err := n.pm.SetInstances(n.rcfg.EEInstances, n.rcfg.EEInstances, n.rcfg.EEInstances); err != nil {
 			return err
 		}
+	case "eeMaxInstances":
+		if intVal, err := strconv.Atoi(value); err != nil {
+			return errors.Wrapf(err, "invalid value type")
+		} else {
+			n.rcfg.EEMaxInstances = intVal
+		}
+		if err := n.pm.SetMaxInstances(n.rcfg.EEMaxInstances); err != nil {
+			return err
+		}
 	case "rpcDefaultChannel":
 		n.rcfg.RPCDefaultChannel = value
 		n.srv.SetDefaultChannel(n.rcfg.RPCDefaultChannel)
@@ -910,6 +1151,7 @@ func NewNode(
 	metric.Initialize(w)
 
 	cfg.FillEmpty(w.Address())
+	watchdog.Default().SetRecover(cfg.WatchdogRecover)
 	nodeDir := cfg.ResolveAbsolute(cfg.BaseDir)
 	if err := os.MkdirAll(nodeDir, 0700); err != nil {
 		log.Panicf("Fail to create directory %s err=%+v", cfg.BaseDir, err)
@@ -948,6 +1190,9 @@ func NewNode(
 	if err := pm.SetInstances(rcfg.EEInstances, rcfg.EEInstances, rcfg.EEInstances); err != nil {
 		log.Panicf("fail to EEManager.SetInstances err=%+v", err)
 	}
+	if err := pm.SetMaxInstances(rcfg.EEMaxInstances); err != nil {
+		log.Panicf("fail to EEManager.SetMaxInstances err=%+v", err)
+	}
 	go func() {
 		if err := pm.Loop(); err != nil {
 			log.Panic(err)
@@ -1001,6 +1246,18 @@ func NewNode(
 		}
 	}
 
+	n.bks = newBackupScheduler(n, path.Join(nodeDir, BackupScheduleFileName))
+	if err := n.bks.load(); err != nil {
+		log.Panicf("Fail to load backup schedules err=%+v", err)
+	}
+	n.cls = newChainLogs(n, path.Join(nodeDir, ChainLogFileName))
+	if err := n.cls.load(); err != nil {
+		log.Panicf("Fail to load chain logs err=%+v", err)
+	}
+	if n.audit, err = newAuditLog(path.Join(nodeDir, AuditLogFileName), l); err != nil {
+		log.Panicf("Fail to open audit log err=%+v", err)
+	}
+
 	RegisterRest(n)
 	return n
 }
@@ -20,6 +20,8 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/icon/lcimporter"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/network"
 	"github.com/icon-project/goloop/server"
@@ -37,6 +39,7 @@ const (
 	ParamID     = "id"
 	UrlUserRes  = "/:" + ParamID
 	TaskID      = "task"
+	ParamName   = "name"
 
 	UrlDB    = "/db"
 	ParamBK  = "bucket"
@@ -73,6 +76,15 @@ type ChainView struct {
 	State     string          `json:"state"`
 	Height    int64           `json:"height"`
 	LastError string          `json:"lastError"`
+	History   *HistoryView    `json:"history"`
+}
+
+// HistoryView shows historical queries served by the chain, so they can be
+// routed to the nodes serving them.
+type HistoryView struct {
+	Archive bool     `json:"archive"`
+	Depth   int64    `json:"depth,omitempty"`
+	Queries []string `json:"queries"`
 }
 
 type ChainInspectView struct {
@@ -84,25 +96,44 @@ type ChainInspectView struct {
 }
 
 type ChainConfig struct {
-	DBType           string `json:"dbType"`
-	Platform         string `json:"platform"`
-	SeedAddr         string `json:"seedAddress"`
-	Role             uint   `json:"role"`
-	ConcurrencyLevel int    `json:"concurrencyLevel,omitempty"`
-	NormalTxPoolSize int    `json:"normalTxPool,omitempty"`
-	PatchTxPoolSize  int    `json:"patchTxPool,omitempty"`
-	MaxBlockTxBytes  int    `json:"maxBlockTxBytes,omitempty"`
-	NodeCache        string `json:"nodeCache,omitempty"`
-	Channel          string `json:"channel"`
-	SecureSuites     string `json:"secureSuites"`
-	SecureAeads      string `json:"secureAeads"`
-	DefWaitTimeout   int64  `json:"defaultWaitTimeout"`
-	MaxWaitTimeout   int64  `json:"maxWaitTimeout"`
-	TxTimeout        int64  `json:"txTimeout"`
-	AutoStart        bool   `json:"autoStart"`
-	ChildrenLimit    *int   `json:"childrenLimit,omitempty"`
-	NephewsLimit     *int   `json:"nephewsLimit,omitempty"`
-	ValidateTxOnSend bool   `json:"validateTxOnSend,omitempty"`
+	DBType              string `json:"dbType"`
+	Platform            string `json:"platform"`
+	SeedAddr            string `json:"seedAddress"`
+	Role                uint   `json:"role"`
+	ConcurrencyLevel    int    `json:"concurrencyLevel,omitempty"`
+	NormalTxPoolSize    int    `json:"normalTxPool,omitempty"`
+	PatchTxPoolSize     int    `json:"patchTxPool,omitempty"`
+	MaxBlockTxBytes     int    `json:"maxBlockTxBytes,omitempty"`
+	NodeCache           string `json:"nodeCache,omitempty"`
+	Channel             string `json:"channel"`
+	SecureSuites        string `json:"secureSuites"`
+	SecureAeads         string `json:"secureAeads"`
+	DefWaitTimeout      int64  `json:"defaultWaitTimeout"`
+	MaxWaitTimeout      int64  `json:"maxWaitTimeout"`
+	TxTimeout           int64  `json:"txTimeout"`
+	AutoStart           bool   `json:"autoStart"`
+	ChildrenLimit       *int   `json:"childrenLimit,omitempty"`
+	NephewsLimit        *int   `json:"nephewsLimit,omitempty"`
+	ValidateTxOnSend    bool   `json:"validateTxOnSend,omitempty"`
+	AutoRole            bool   `json:"autoRole,omitempty"`
+	RecordInternalCalls bool   `json:"recordInternalCalls,omitempty"`
+	RecordAddressIndex  bool   `json:"recordAddressIndex,omitempty"`
+	RecordTokenIndex    bool   `json:"recordTokenIndex,omitempty"`
+	TxTimestampWindow   int64  `json:"txTimestampWindow,omitempty"`
+	TxPoolTTL           int64  `json:"txPoolTTL,omitempty"`
+	TxPoolPersist       bool   `json:"txPoolPersist,omitempty"`
+	PriorityTxRatio     int    `json:"priorityTxRatio,omitempty"`
+	SyncServerLimit     int    `json:"syncServerLimit,omitempty"`
+	SyncServerBandwidth int64  `json:"syncServerBandwidth,omitempty"`
+	Sequencer           string `json:"sequencer,omitempty"`
+	ReceiptCompression  string `json:"receiptCompression,omitempty"`
+	ReplicaDBDir        string `json:"replicaDBDir,omitempty"`
+	ReplicaInterval     int64  `json:"replicaInterval,omitempty"`
+	HistoryDepth        int64  `json:"historyDepth,omitempty"`
+	HistoryQueries      string `json:"historyQueries,omitempty"`
+	WatchdogRestart     bool   `json:"watchdogRestart,omitempty"`
+
+	SyncAnchor *chain.SyncAnchor `json:"syncAnchor,omitempty"`
 }
 
 type ChainResetParam struct {
@@ -116,12 +147,24 @@ type ChainImportParam struct {
 }
 
 type ChainPruneParam struct {
-	DBType string `json:"dbType,omitempty"`
-	Height int64  `json:"height"`
+	DBType  string `json:"dbType,omitempty"`
+	Height  int64  `json:"height"`
+	Keep    int64  `json:"keep,omitempty"`
+	Headers bool   `json:"headers,omitempty"`
+}
+
+type ChainRollbackParam struct {
+	Height int64 `json:"height"`
+}
+
+type ChainBundleParam struct {
+	Transactions []json.RawMessage `json:"transactions"`
+	Signature    common.HexBytes   `json:"signature"`
 }
 
 type ChainBackupParam struct {
-	Manual bool `json:"manual,omitempty"`
+	Manual      bool `json:"manual,omitempty"`
+	Incremental bool `json:"incremental,omitempty"`
 }
 
 type ConfigureParam struct {
@@ -142,6 +185,7 @@ func NewChainView(c *Chain) *ChainView {
 		Channel: c.Channel(),
 		State:   state,
 		Height:  height,
+		History: NewHistoryView(c.HistoryCapability()),
 	}
 	if lastErr != nil {
 		v.LastError = lastErr.Error()
@@ -149,6 +193,23 @@ func NewChainView(c *Chain) *ChainView {
 	return v
 }
 
+func NewHistoryView(hc *module.HistoryCapability) *HistoryView {
+	if hc == nil {
+		return &HistoryView{
+			Archive: true,
+			Queries: []string{
+				module.HistoryState, module.HistoryTrace, module.HistoryReceipt,
+			},
+		}
+	}
+	v := &HistoryView{
+		Depth:   hc.Depth,
+		Queries: make([]string, 0, len(hc.Queries)),
+	}
+	v.Queries = append(v.Queries, hc.Queries...)
+	return v
+}
+
 type InspectFunc func(c module.Chain, informal bool) map[string]interface{}
 
 var (
@@ -166,25 +227,43 @@ func NewChainInspectView(c *Chain) *ChainInspectView {
 
 func NewChainConfig(cfg *chain.Config) *ChainConfig {
 	v := &ChainConfig{
-		DBType:           cfg.DBType,
-		Platform:         cfg.Platform,
-		SeedAddr:         cfg.SeedAddr,
-		Role:             cfg.Role,
-		ConcurrencyLevel: cfg.ConcurrencyLevel,
-		NormalTxPoolSize: cfg.NormalTxPoolSize,
-		PatchTxPoolSize:  cfg.PatchTxPoolSize,
-		MaxBlockTxBytes:  cfg.MaxBlockTxBytes,
-		NodeCache:        cfg.NodeCache,
-		Channel:          cfg.Channel,
-		SecureSuites:     cfg.SecureSuites,
-		SecureAeads:      cfg.SecureAeads,
-		DefWaitTimeout:   cfg.DefWaitTimeout,
-		MaxWaitTimeout:   cfg.MaxWaitTimeout,
-		TxTimeout:        cfg.TxTimeout,
-		AutoStart:        cfg.AutoStart,
-		ChildrenLimit:    cfg.ChildrenLimit,
-		NephewsLimit:     cfg.NephewsLimit,
-		ValidateTxOnSend: cfg.ValidateTxOnSend,
+		DBType:              cfg.DBType,
+		Platform:            cfg.Platform,
+		SeedAddr:            cfg.SeedAddr,
+		Role:                cfg.Role,
+		ConcurrencyLevel:    cfg.ConcurrencyLevel,
+		NormalTxPoolSize:    cfg.NormalTxPoolSize,
+		PatchTxPoolSize:     cfg.PatchTxPoolSize,
+		MaxBlockTxBytes:     cfg.MaxBlockTxBytes,
+		NodeCache:           cfg.NodeCache,
+		Channel:             cfg.Channel,
+		SecureSuites:        cfg.SecureSuites,
+		SecureAeads:         cfg.SecureAeads,
+		DefWaitTimeout:      cfg.DefWaitTimeout,
+		MaxWaitTimeout:      cfg.MaxWaitTimeout,
+		TxTimeout:           cfg.TxTimeout,
+		AutoStart:           cfg.AutoStart,
+		ChildrenLimit:       cfg.ChildrenLimit,
+		NephewsLimit:        cfg.NephewsLimit,
+		ValidateTxOnSend:    cfg.ValidateTxOnSend,
+		AutoRole:            cfg.AutoRole,
+		RecordInternalCalls: cfg.RecordInternalCalls,
+		RecordAddressIndex:  cfg.RecordAddressIndex,
+		RecordTokenIndex:    cfg.RecordTokenIndex,
+		TxTimestampWindow:   cfg.TxTimestampWindow,
+		TxPoolTTL:           cfg.TxPoolTTL,
+		TxPoolPersist:       cfg.TxPoolPersist,
+		PriorityTxRatio:     cfg.PriorityTxRatio,
+		SyncServerLimit:     cfg.SyncServerLimit,
+		SyncServerBandwidth: cfg.SyncServerBandwidth,
+		Sequencer:           cfg.Sequencer,
+		ReceiptCompression:  cfg.ReceiptCompression,
+		ReplicaDBDir:        cfg.ReplicaDBDir,
+		ReplicaInterval:     cfg.ReplicaInterval,
+		HistoryDepth:        cfg.HistoryDepth,
+		HistoryQueries:      cfg.HistoryQueries,
+		WatchdogRestart:     cfg.WatchdogRestart,
+		SyncAnchor:          cfg.SyncAnchor,
 	}
 	return v
 }
@@ -203,19 +282,22 @@ func RegisterRest(n *Node) {
 		a: NewAuth(path.Join(n.cfg.ResolveAbsolute(n.cfg.BaseDir), "auth.json"), server.UrlAdmin),
 	}
 	r.a.SkipIfEmptyUsers = n.cfg.AuthSkipIfEmptyUsers
-	ag := n.srv.AdminEchoGroup(r.a.MiddlewareFunc())
+	// audit is the outer one to record requests failed on authentication
+	ag := n.srv.AdminEchoGroup(n.audit.MiddlewareFunc(""), r.a.MiddlewareFunc())
 	r.RegisterChainHandlers(ag.Group(UrlChain))
 	r.RegisterSystemHandlers(ag.Group(UrlSystem))
 
-	r.RegisterChainHandlers(n.cliSrv.e.Group(UrlChain))
-	r.RegisterSystemHandlers(n.cliSrv.e.Group(UrlSystem))
-	r.RegisterUserHandlers(n.cliSrv.e.Group(UrlUser))
-	r.RegisterStatsHandlers(n.cliSrv.e.Group(UrlStats))
-	r.RegisterDBHandlers(n.cliSrv.e.Group(UrlDB))
+	am := n.audit.MiddlewareFunc(AuditUserCLI)
+	r.RegisterChainHandlers(n.cliSrv.e.Group(UrlChain, am))
+	r.RegisterSystemHandlers(n.cliSrv.e.Group(UrlSystem, am))
+	r.RegisterUserHandlers(n.cliSrv.e.Group(UrlUser, am))
+	r.RegisterStatsHandlers(n.cliSrv.e.Group(UrlStats, am))
+	r.RegisterDBHandlers(n.cliSrv.e.Group(UrlDB, am))
 
 	_ = RegisterInspectFunc("metrics", metric.Inspect)
 	_ = RegisterInspectFunc("network", network.Inspect)
 	_ = RegisterInspectFunc("service", service.Inspect)
+	_ = RegisterInspectFunc("importer", lcimporter.Inspect)
 
 	// json rpc
 	n.srv.RegisterAPIHandler(n.cliSrv.e.Group("/api"))
@@ -236,13 +318,23 @@ func (r *Rest) RegisterChainHandlers(g *echo.Group) {
 	g.POST(UrlChainRes+"/verify", r.VerifyChain, r.ChainInjector)
 	g.POST(UrlChainRes+"/import", r.ImportChain, r.ChainInjector)
 	g.POST(UrlChainRes+"/prune", r.PruneChain, r.ChainInjector)
+	g.POST(UrlChainRes+"/rollback", r.RollbackChain, r.ChainInjector)
+	g.POST(UrlChainRes+"/bundle", r.SendTransactionBundle, r.ChainInjector)
 	g.POST(UrlChainRes+"/backup", r.BackupChain, r.ChainInjector)
+	g.GET(UrlChainRes+"/backup/schedule", r.GetBackupSchedule, r.ChainInjector)
+	g.POST(UrlChainRes+"/backup/schedule", r.SetBackupSchedule, r.ChainInjector)
+	g.DELETE(UrlChainRes+"/backup/schedule", r.RemoveBackupSchedule, r.ChainInjector)
+	g.GET(UrlChainRes+"/log", r.GetChainLog, r.ChainInjector)
+	g.POST(UrlChainRes+"/log", r.SetChainLog, r.ChainInjector)
+	g.DELETE(UrlChainRes+"/log", r.RemoveChainLog, r.ChainInjector)
 	route := g.GET(UrlChainRes+"/genesis", r.GetChainGenesis, r.ChainInjector)
 	if r.a != nil {
 		r.a.SetSkip(route, false)
 	}
 	g.GET(UrlChainRes+"/configure", r.GetChainConfig, r.ChainInjector)
 	g.POST(UrlChainRes+"/configure", r.ConfigureChain, r.ChainInjector)
+	g.GET(UrlChainRes+"/state", r.ExportChainState, r.ChainInjector)
+	g.GET(UrlChainRes+"/tasks", r.GetChainTasks, r.ChainInjector)
 	g.POST(UrlChainRes+"/:"+TaskID, r.RunChainTask, r.ChainInjector)
 }
 
@@ -341,6 +433,11 @@ func (r *Rest) GetChain(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, v)
 }
 
+func (r *Rest) GetChainTasks(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	return ctx.JSON(http.StatusOK, c.Tasks())
+}
+
 func (r *Rest) LeaveChain(ctx echo.Context) error {
 	c := ctx.Get("chain").(*Chain)
 	if err := r.n.LeaveChain(c.CID()); err != nil {
@@ -406,28 +503,141 @@ func (r *Rest) PruneChain(ctx echo.Context) error {
 	if err := ctx.Bind(param); err != nil {
 		return echo.ErrBadRequest
 	}
-	if param.Height < 1 {
+	if (param.Height < 1) == (param.Keep < 1) {
+		return echo.ErrBadRequest
+	}
+	if err := r.n.PruneChain(c.CID(), param.DBType, param.Height, param.Keep, param.Headers); err != nil {
+		return err
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
+func (r *Rest) RollbackChain(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	param := &ChainRollbackParam{}
+	if err := ctx.Bind(param); err != nil {
+		return echo.ErrBadRequest
+	}
+	if param.Height < 0 {
 		return echo.ErrBadRequest
 	}
-	if err := r.n.PruneChain(c.CID(), param.DBType, param.Height); err != nil {
+	if err := r.n.RollbackChain(c.CID(), param.Height); err != nil {
 		return err
 	}
 	return ctx.String(http.StatusOK, "OK")
 }
 
+func (r *Rest) SendTransactionBundle(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	param := &ChainBundleParam{}
+	if err := ctx.Bind(param); err != nil {
+		return echo.ErrBadRequest
+	}
+	if len(param.Transactions) == 0 || len(param.Signature) == 0 {
+		return echo.ErrBadRequest
+	}
+	ids, err := r.n.SendTransactionBundle(c.CID(), param.Transactions, param.Signature)
+	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) || service.InvalidTransactionError.Equals(err) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+	hashes := make([]common.HexBytes, len(ids))
+	for i, id := range ids {
+		hashes[i] = id
+	}
+	return ctx.JSON(http.StatusOK, hashes)
+}
+
 func (r *Rest) BackupChain(ctx echo.Context) error {
 	c := ctx.Get("chain").(*Chain)
 	param := &ChainBackupParam{}
 	if err := ctx.Bind(param); err != nil {
 		return echo.ErrBadRequest
 	}
-	if name, err := r.n.BackupChain(c.CID(), param.Manual); err != nil {
+	if param.Manual && param.Incremental {
+		return echo.ErrBadRequest
+	}
+	if name, err := r.n.BackupChain(c.CID(), param.Manual, param.Incremental); err != nil {
 		return err
 	} else {
 		return ctx.String(http.StatusOK, name)
 	}
 }
 
+func (r *Rest) GetBackupSchedule(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	v := r.n.GetBackupSchedule(c.CID())
+	if v == nil {
+		return ctx.String(http.StatusNotFound,
+			fmt.Sprintf("BackupSchedule(cid=%#x) not found", c.CID()))
+	}
+	return ctx.JSON(http.StatusOK, v)
+}
+
+func (r *Rest) SetBackupSchedule(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	param := &BackupSchedule{}
+	if err := ctx.Bind(param); err != nil {
+		return echo.ErrBadRequest
+	}
+	if err := r.n.SetBackupSchedule(c.CID(), param); err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return ctx.String(http.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
+func (r *Rest) RemoveBackupSchedule(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	if err := r.n.RemoveBackupSchedule(c.CID()); err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return ctx.String(http.StatusNotFound, err.Error())
+		}
+		return err
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
+func (r *Rest) GetChainLog(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	v := r.n.GetChainLog(c.CID())
+	if v == nil {
+		return ctx.String(http.StatusNotFound,
+			fmt.Sprintf("ChainLog(cid=%#x) not found", c.CID()))
+	}
+	return ctx.JSON(http.StatusOK, v)
+}
+
+func (r *Rest) SetChainLog(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	param := &log.ChainLogConfig{}
+	if err := ctx.Bind(param); err != nil {
+		return echo.ErrBadRequest
+	}
+	if err := r.n.SetChainLog(c.CID(), param); err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return ctx.String(http.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
+func (r *Rest) RemoveChainLog(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	if err := r.n.RemoveChainLog(c.CID()); err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return ctx.String(http.StatusNotFound, err.Error())
+		}
+		return err
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
 func (r *Rest) GetChainGenesis(ctx echo.Context) error {
 	c := ctx.Get("chain").(*Chain)
 	gsFile := path.Join(c.cfg.AbsBaseDir(), ChainGenesisZipFileName)
@@ -451,6 +661,51 @@ func (r *Rest) ConfigureChain(ctx echo.Context) error {
 	return ctx.String(http.StatusOK, "OK")
 }
 
+func (r *Rest) ExportChainState(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	height, err := strconv.ParseInt(ctx.QueryParam("height"), 0, 64)
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+	size := DefaultStateExportChunkSize
+	if param := ctx.QueryParam("chunk_size"); param != "" {
+		if size, err = strconv.Atoi(param); err != nil || size < 1 {
+			return echo.ErrBadRequest
+		}
+	}
+
+	// errors before the first chunk are returned as usual, and the ones
+	// after are notified by the absence of the summary at the end.
+	resp := ctx.Response()
+	enc := json.NewEncoder(resp)
+	summary, err := r.n.ExportChainState(c.CID(), height, size, func(chunk *StateChunk) error {
+		if !resp.Committed {
+			resp.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+			resp.WriteHeader(http.StatusOK)
+		}
+		if err := enc.Encode(chunk); err != nil {
+			return err
+		}
+		resp.Flush()
+		return nil
+	})
+	if err != nil {
+		if resp.Committed {
+			if EqualsSyscallErrno(err, syscall.EPIPE) {
+				return nil
+			}
+			r.n.logger.Warnf("fail to export state cid=%#x height=%d err=%+v",
+				c.CID(), height, err)
+			return nil
+		}
+		if errors.NotFoundError.Equals(err) {
+			return ctx.String(http.StatusNotFound, err.Error())
+		}
+		return err
+	}
+	return enc.Encode(summary)
+}
+
 func (r *Rest) RunChainTask(ctx echo.Context) error {
 	c := ctx.Get("chain").(*Chain)
 	task := ctx.Param(TaskID)
@@ -474,6 +729,8 @@ func (r *Rest) RegisterSystemHandlers(g *echo.Group) {
 	g.POST("/configure", r.ConfigureSystem)
 	r.RegistryBackupHandlers(g.Group("/backup"))
 	r.RegistryRestoreHandlers(g.Group("/restore"))
+	g.GET("/audit", r.GetAuditRecords)
+	g.GET("/audit/verify", r.VerifyAuditLog)
 }
 
 func (r *Rest) GetSystem(ctx echo.Context) error {
@@ -511,8 +768,41 @@ func (r *Rest) ConfigureSystem(ctx echo.Context) error {
 	return ctx.String(http.StatusOK, "OK")
 }
 
+func (r *Rest) GetAuditRecords(ctx echo.Context) error {
+	var from int64
+	var limit int
+	if s := ctx.QueryParam("from"); s != "" {
+		v, err := strconv.ParseInt(s, 0, 64)
+		if err != nil {
+			return ctx.String(http.StatusBadRequest, fmt.Sprintf("InvalidFrom(from=%s)", s))
+		}
+		from = v
+	}
+	if s := ctx.QueryParam("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return ctx.String(http.StatusBadRequest, fmt.Sprintf("InvalidLimit(limit=%s)", s))
+		}
+		limit = v
+	}
+	records, err := r.n.GetAuditRecords(from, limit)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, records)
+}
+
+func (r *Rest) VerifyAuditLog(ctx echo.Context) error {
+	result, err := r.n.VerifyAuditLog()
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
 func (r *Rest) RegistryBackupHandlers(g *echo.Group) {
 	g.GET("", r.GetBackups)
+	g.GET("/:"+ParamName+"/verify", r.VerifyBackup)
 }
 
 func (r *Rest) GetBackups(ctx echo.Context) error {
@@ -523,6 +813,17 @@ func (r *Rest) GetBackups(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, backups)
 }
 
+func (r *Rest) VerifyBackup(ctx echo.Context) error {
+	result, err := r.n.VerifyBackup(ctx.Param(ParamName))
+	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return ctx.String(http.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
 func (r *Rest) RegistryRestoreHandlers(g *echo.Group) {
 	g.POST("", r.RestoreBackup)
 	g.GET("", r.GetRestore)
@@ -25,8 +25,6 @@ import (
 	"path"
 	"sync"
 
-	"github.com/icon-project/goloop/chain"
-	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/errors"
 )
 
@@ -103,35 +101,23 @@ func (m *RestoreManager) Start(node *Node, file string, baseDir string, overwrit
 		}
 	}()
 
-	zr, err := zip.OpenReader(file)
+	bs, err := openBackupSet(path.Dir(file), path.Base(file))
 	if err != nil {
-		return errors.IllegalArgumentError.Wrapf(err,
-			"ZipOpenFailure(backup=%s)", file)
+		return err
 	}
 	defer func() {
 		if ret != nil {
-			zr.Close()
+			bs.Close()
 		}
 	}()
-
-	info, err := chain.ReadBackupInfo(&zr.Reader)
-	if err != nil {
-		return errors.IllegalArgumentError.Wrap(err,
-			"InvalidBackupInfo")
-	}
-
-	if info.Codec != codec.BC.Name() {
-		return errors.IllegalArgumentError.Errorf(
-			"IncompatibleCodec(backup=%s,system=%s)",
-			info.Codec, codec.BC.Name())
-	}
+	info := bs.info
 
 	if err := node.CanAdd(int(info.CID.Value), int(info.NID.Value), info.Channel, overwrite); err != nil {
 		return err
 	}
 
 	go func() {
-		if err := m._restore(node, zr, tmpDir, overwrite); err != nil {
+		if err := m._restore(node, bs, tmpDir, overwrite); err != nil {
 			node.logger.Debugf("Restore failed err=%+v", err)
 			if errors.InterruptedError.Equals(err) {
 				m._setState(RestoreNone, nil)
@@ -147,7 +133,7 @@ func (m *RestoreManager) Start(node *Node, file string, baseDir string, overwrit
 	m.overwrite = overwrite
 	m.state = RestoreStarted
 	m.current = 0
-	m.total = len(zr.File)
+	m.total = len(bs.files)
 	return nil
 }
 
@@ -235,15 +221,15 @@ func zipExtract(file *zip.File, tmpDir string) (ret error) {
 	return err
 }
 
-func (m *RestoreManager) _restore(node *Node, zr *zip.ReadCloser, tmpDir string, overwrite bool) (ret error) {
+func (m *RestoreManager) _restore(node *Node, bs *backupSet, tmpDir string, overwrite bool) (ret error) {
 	defer func() {
 		if ret != nil {
 			os.RemoveAll(tmpDir)
 		}
 	}()
-	defer zr.Close()
+	defer bs.Close()
 
-	for idx, file := range zr.File {
+	for idx, file := range bs.files {
 		if err := zipExtract(file, tmpDir); err != nil {
 			return err
 		}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package node
+
+import (
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/service"
+	"github.com/icon-project/goloop/service/state"
+)
+
+const DefaultStateExportChunkSize = 1000
+
+type StateRecord struct {
+	Type  state.StateRecordType `json:"type"`
+	Key   common.HexBytes       `json:"key,omitempty"`
+	Value common.HexBytes       `json:"value"`
+}
+
+// StateChunk is a line of the state export. The last line of the export
+// is StateExportSummary.
+type StateChunk struct {
+	Index    int64           `json:"index"`
+	PrevHash common.HexBytes `json:"prevHash,omitempty"`
+	Hash     common.HexBytes `json:"hash"`
+	Records  []*StateRecord  `json:"records"`
+}
+
+type StateExportSummary struct {
+	Height  int64           `json:"height"`
+	Chunks  int64           `json:"chunks"`
+	Records int64           `json:"records"`
+	Hash    common.HexBytes `json:"hash"`
+}
+
+func NewStateChunk(c *state.StateChunk) *StateChunk {
+	records := make([]*StateRecord, len(c.Records))
+	for i, r := range c.Records {
+		records[i] = &StateRecord{r.Type, r.Key, r.Value}
+	}
+	return &StateChunk{
+		Index:    c.Index,
+		PrevHash: c.PrevHash,
+		Hash:     c.Hash(),
+		Records:  records,
+	}
+}
+
+// ExportChainState exports the world state of the chain at the height.
+// It calls cb for each chunk of size records, and returns the summary.
+func (n *Node) ExportChainState(cid int, height int64, size int, cb func(c *StateChunk) error) (*StateExportSummary, error) {
+	n.mtx.RLock()
+	c, err := n._get(cid)
+	n.mtx.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	bm := c.BlockManager()
+	if bm == nil {
+		return nil, errors.InvalidStateError.Errorf("ChainIsNotStarted(cid=%#x)", cid)
+	}
+	blk, err := bm.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		size = DefaultStateExportChunkSize
+	}
+	summary := &StateExportSummary{Height: height}
+	err = service.ExportState(c.Database(), blk.Result(), blk.NextValidators(), size,
+		func(sc *state.StateChunk) error {
+			chunk := NewStateChunk(sc)
+			summary.Chunks++
+			summary.Records += int64(len(chunk.Records))
+			summary.Hash = chunk.Hash
+			return cb(chunk)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/scoreresult"
 )
@@ -72,6 +73,8 @@ func (c ErrorCode) String() string {
 		return "Timeout"
 	case ErrorCodeSystemTimeout:
 		return "SystemTimeout"
+	case ErrorCodePruned:
+		return "Pruned"
 	default:
 		switch {
 		case c < ErrorCodeServer && c > ErrorCodeServer-1000:
@@ -104,6 +107,7 @@ const (
 	ErrorLackOfResource     ErrorCode = -31005
 	ErrorCodeTimeout        ErrorCode = -31006
 	ErrorCodeSystemTimeout  ErrorCode = -31007
+	ErrorCodePruned         ErrorCode = -31008
 )
 
 type Error struct {
@@ -117,6 +121,25 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("JSONRPCError(code=%d, message=%q, data=%s)", e.Code, e.Message, bs)
 }
 
+// ErrorCode returns the code of common errors for the error from the server,
+// so the client can match it by the code.
+func (e *Error) ErrorCode() errors.Code {
+	switch c := e.Code; {
+	case c == ErrorCodeJsonParse || c == ErrorCodeInvalidRequest || c == ErrorCodeInvalidParams:
+		return errors.IllegalArgumentError
+	case c == ErrorCodeMethodNotFound:
+		return errors.UnsupportedError
+	case c == ErrorCodeNotFound:
+		return errors.NotFoundError
+	case c == ErrorCodeTimeout || c == ErrorCodeSystemTimeout:
+		return errors.TimeoutError
+	case c < ErrorCodeScore && c > ErrorCodeScore-1000:
+		return errors.Code(ErrorCodeScore - c)
+	default:
+		return errors.UnknownError
+	}
+}
+
 func firstOf(message ...interface{}) interface{} {
 	if len(message) > 0 {
 		return message[0]
@@ -20,6 +20,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
 )
 
 func TestErrorCode_String(t *testing.T) {
@@ -44,3 +47,25 @@ func TestErrorCode_String(t *testing.T) {
 		})
 	}
 }
+
+func TestError_ErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		c    ErrorCode
+		want errors.Code
+	}{
+		{"InvalidParams", ErrorCodeInvalidParams, errors.IllegalArgumentError},
+		{"MethodNotFound", ErrorCodeMethodNotFound, errors.UnsupportedError},
+		{"NotFound", ErrorCodeNotFound, errors.NotFoundError},
+		{"SystemTimeout", ErrorCodeSystemTimeout, errors.TimeoutError},
+		{"SCOREError(1)", ErrorCodeScore - 1, errors.Code(module.StatusUnknownFailure)},
+		{"SCOREError(32)", ErrorCodeScore - 32, errors.Code(module.StatusReverted)},
+		{"SystemError", ErrorCodeSystem, errors.UnknownError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error = tt.c.New("test")
+			assert.Equal(t, tt.want, errors.CodeOf(err))
+		})
+	}
+}
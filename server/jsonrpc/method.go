@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"encoding/json"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -52,6 +53,18 @@ func (mr *MethodRepository) GetMethod(method string) Handler {
 	return mr.methods[method]
 }
 
+func (mr *MethodRepository) Methods() []string {
+	defer mr.mtx.RUnlock()
+	mr.mtx.RLock()
+
+	methods := make([]string, 0, len(mr.methods))
+	for method := range mr.methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
 func (mr *MethodRepository) SetAllowedNotification(method string) {
 	defer mr.mtx.Unlock()
 	mr.mtx.Lock()
@@ -214,3 +214,16 @@ func noArgs(ctx *Context, params *Params) (interface{}, error) {
 	}
 	return "noArgs", nil
 }
+
+func TestMethodRepository_Methods(t *testing.T) {
+	mr := NewMethodRepository(nil)
+	assert.Empty(t, mr.Methods())
+
+	handler := func(ctx *Context, params *Params) (interface{}, error) {
+		return nil, nil
+	}
+	mr.RegisterMethod("method_b", handler)
+	mr.RegisterMethod("method_a", handler)
+	mr.RegisterMethod("", handler)
+	assert.Equal(t, []string{"method_a", "method_b"}, mr.Methods())
+}
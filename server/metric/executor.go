@@ -0,0 +1,65 @@
+package metric
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	msEEInstances  = stats.Int64("executor_instances", "Active Executor Instances", stats.UnitDimensionless)
+	msEELimit      = stats.Int64("executor_limit", "Target Number of Executors", stats.UnitDimensionless)
+	msEEWaiting    = stats.Int64("executor_waiting", "Requests Waiting for Executor", stats.UnitDimensionless)
+	msEESaturation = stats.Int64("executor_saturation", "Percentage of Assigned Executors", stats.UnitDimensionless)
+	msEEScale      = stats.Int64("executor_scale", "Scaling Executors", stats.UnitDimensionless)
+	msEEUnhealthy  = stats.Int64("executor_unhealthy", "Unhealthy Executors", stats.UnitDimensionless)
+	mkEEType       = NewMetricKey("ee_type")
+	executorMks    = []tag.Key{mkEEType}
+)
+
+func RegisterExecutor() {
+	RegisterMetricView(msEEInstances, view.LastValue(), executorMks)
+	RegisterMetricView(msEELimit, view.LastValue(), executorMks)
+	RegisterMetricView(msEEWaiting, view.LastValue(), executorMks)
+	RegisterMetricView(msEESaturation, view.LastValue(), executorMks)
+	RegisterMetricView(msEEScale, view.Count(), executorMks)
+	RegisterMetricView(msEEUnhealthy, view.Count(), executorMks)
+}
+
+type ExecutorMetric struct {
+	context context.Context
+}
+
+func (m *ExecutorMetric) OnInstances(t string, n int) {
+	ctx := GetMetricContext(m.context, &mkEEType, t)
+	stats.Record(ctx, msEEInstances.M(int64(n)))
+}
+
+func (m *ExecutorMetric) OnUnhealthy(t string) {
+	ctx := GetMetricContext(m.context, &mkEEType, t)
+	stats.Record(ctx, msEEUnhealthy.M(1))
+}
+
+func (m *ExecutorMetric) OnUsage(limit, assigned, waiting int) {
+	saturation := int64(100)
+	if limit > 0 {
+		saturation = int64(assigned * 100 / limit)
+	}
+	stats.Record(m.context,
+		msEELimit.M(int64(limit)),
+		msEEWaiting.M(int64(waiting)),
+		msEESaturation.M(saturation),
+	)
+}
+
+func (m *ExecutorMetric) OnScale(limit int) {
+	stats.Record(m.context, msEEScale.M(int64(limit)))
+}
+
+func NewExecutorMetric(ctx context.Context) *ExecutorMetric {
+	return &ExecutorMetric{
+		context: ctx,
+	}
+}
@@ -37,6 +37,7 @@ var (
 		"icx_getTotalSupply":       msRetrieve,
 		"icx_getTransactionResult": msRetrieve,
 		"icx_getTransactionByHash": msRetrieve,
+		"icx_getInternalCalls":     msRetrieve,
 		"icx_sendTransaction": {
 			stats.Int64("jsonrpc_send_transaction", "jsonrpc icx_sendTransaction method", "ns"),
 			stats.Int64("jsonrpc_send_transaction_avg", "moving average of jsonrpc icx_sendTransaction methods", "ns"),
@@ -52,18 +53,30 @@ var (
 			stats.Int64("jsonrpc_wait_transaction_result_avg", "moving average of jsonrpc icx_waitTransactionResult method", "ns"),
 			emptyMks,
 		},
-		"icx_getDataByHash":          msRetrieve,
-		"icx_getBlockHeaderByHeight": msRetrieve,
-		"icx_getVotesByHeight":       msRetrieve,
-		"icx_getProofForResult":      msRetrieve,
-		"icx_getProofForEvents":      msRetrieve,
-		"icx_getScoreStatus":         msRetrieve,
-		"btp_getNetworkInfo":         msRetrieve,
-		"btp_getNetworkTypeInfo":     msRetrieve,
-		"btp_getMessages":            msRetrieve,
-		"btp_getHeader":              msRetrieve,
-		"btp_getProof":               msRetrieve,
-		"btp_getSourceInformation":   msRetrieve,
+		"icx_getDataByHash":            msRetrieve,
+		"icx_getBlockHeaderByHeight":   msRetrieve,
+		"icx_getVotesByHeight":         msRetrieve,
+		"icx_getRoundInfo":             msRetrieve,
+		"icx_getAccountActivity":       msRetrieve,
+		"icx_getTransactionsByAddress": msRetrieve,
+		"icx_getTokenBalance":          msRetrieve,
+		"icx_getTokenBalances":         msRetrieve,
+		"icx_getTokenTransfers":        msRetrieve,
+		"icx_getProofForResult":        msRetrieve,
+		"icx_getProofForEvents":        msRetrieve,
+		"icx_getReceiptProof":          msRetrieve,
+		"icx_getScoreStatus":           msRetrieve,
+		"icx_getScoreInfo":             msRetrieve,
+		"icx_getFeeSharingInfo":        msRetrieve,
+		"icx_getRevisionInfo":          msRetrieve,
+		"btp_getNetworkInfo":           msRetrieve,
+		"btp_getNetworkTypeInfo":       msRetrieve,
+		"btp_getMessages":              msRetrieve,
+		"btp_getHeader":                msRetrieve,
+		"btp_getProof":                 msRetrieve,
+		"btp_getSourceInformation":     msRetrieve,
+		"btp_getNetworks":              msRetrieve,
+		"btp_getMessageProofs":         msRetrieve,
 		"debug_getTrace": {
 			stats.Int64("jsonrpc_get_trace", "jsonrpc debug_getTrace method", "ns"),
 			stats.Int64("jsonrpc_get_trace_avg", "moving average of jsonrpc debug_getTrace method", "ns"),
@@ -74,6 +87,13 @@ var (
 			stats.Int64("jsonrpc_estimate_step_avg", "moving average of jsonrpc debug_estimateStep method", "ns"),
 			emptyMks,
 		},
+		"debug_getScoreStorage":    msRetrieve,
+		"debug_inspectTransaction": msRetrieve,
+		"debug_estimateStepDetails": {
+			stats.Int64("jsonrpc_estimate_step_details", "jsonrpc debug_estimateStepDetails method", "ns"),
+			stats.Int64("jsonrpc_estimate_step_details_avg", "moving average of jsonrpc debug_estimateStepDetails method", "ns"),
+			emptyMks,
+		},
 		"rosetta_getTrace": {
 			stats.Int64("jsonrpc_rosetta_trace_", "jsonrpc rosetta_getTrace method", "ns"),
 			stats.Int64("jsonrpc_rosetta_trace_avg", "moving average of jsonrpc rosetta_getTTrace method", "ns"),
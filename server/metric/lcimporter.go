@@ -0,0 +1,51 @@
+package metric
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+)
+
+var (
+	msLCTransitionTxs = stats.Int64("lcimport_transition_txs", "Transactions per Transition", stats.UnitDimensionless)
+	msLCGetTxsLatency = stats.Int64("lcimport_gettxs_latency", "GetTransactions Latency", stats.UnitMilliseconds)
+	msLCFinalize      = stats.Int64("lcimport_finalize_duration", "Accumulator Finalize Duration", stats.UnitMilliseconds)
+	msLCSync          = stats.Int64("lcimport_sync", "Sync Transitions", stats.UnitDimensionless)
+)
+
+func RegisterLCImporter() {
+	RegisterMetricView(msLCTransitionTxs, view.LastValue(), nil)
+	RegisterMetricView(msLCTransitionTxs, view.Count(), nil)
+	RegisterMetricView(msLCTransitionTxs, view.Sum(), nil)
+	RegisterMetricView(msLCGetTxsLatency, view.LastValue(), nil)
+	RegisterMetricView(msLCFinalize, view.LastValue(), nil)
+	RegisterMetricView(msLCSync, view.Count(), nil)
+}
+
+type LCImportMetric struct {
+	context context.Context
+}
+
+func (m *LCImportMetric) OnTransition(txs int) {
+	stats.Record(m.context, msLCTransitionTxs.M(int64(txs)))
+}
+
+func (m *LCImportMetric) OnGetTransactions(d time.Duration) {
+	stats.Record(m.context, msLCGetTxsLatency.M(d.Milliseconds()))
+}
+
+func (m *LCImportMetric) OnFinalize(d time.Duration) {
+	stats.Record(m.context, msLCFinalize.M(d.Milliseconds()))
+}
+
+func (m *LCImportMetric) OnSync() {
+	stats.Record(m.context, msLCSync.M(1))
+}
+
+func NewLCImportMetric(ctx context.Context) *LCImportMetric {
+	return &LCImportMetric{
+		context: ctx,
+	}
+}
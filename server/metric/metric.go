@@ -189,6 +189,9 @@ func PrometheusExporter() *prometheus.Exporter {
 	RegisterNetwork()
 	RegisterTransaction()
 	RegisterJsonrpc()
+	RegisterExecutor()
+	RegisterLCImporter()
+	RegisterSignature()
 	return pe
 }
 
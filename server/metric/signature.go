@@ -0,0 +1,49 @@
+package metric
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+)
+
+var (
+	msSigVerifyQueue  = stats.Int64("sigverify_queue", "Batches Waiting for Signature Verifier", stats.UnitDimensionless)
+	msSigVerifyItems  = stats.Int64("sigverify_items", "Verified Signatures", stats.UnitDimensionless)
+	msPubKeyCacheHit  = stats.Int64("sigverify_pubkey_cache_hit", "Public Key Cache Hits", stats.UnitDimensionless)
+	msPubKeyCacheMiss = stats.Int64("sigverify_pubkey_cache_miss", "Public Key Cache Misses", stats.UnitDimensionless)
+)
+
+func RegisterSignature() {
+	RegisterMetricView(msSigVerifyQueue, view.LastValue(), nil)
+	RegisterMetricView(msSigVerifyItems, view.Count(), nil)
+	RegisterMetricView(msSigVerifyItems, view.Sum(), nil)
+	RegisterMetricView(msPubKeyCacheHit, view.Count(), nil)
+	RegisterMetricView(msPubKeyCacheMiss, view.Count(), nil)
+}
+
+type SigVerifyMetric struct {
+	context context.Context
+}
+
+func (m *SigVerifyMetric) OnQueue(n int) {
+	stats.Record(m.context, msSigVerifyQueue.M(int64(n)))
+}
+
+func (m *SigVerifyMetric) OnVerify(n int) {
+	stats.Record(m.context, msSigVerifyItems.M(int64(n)))
+}
+
+func (m *SigVerifyMetric) OnPublicKeyCache(hit bool) {
+	if hit {
+		stats.Record(m.context, msPubKeyCacheHit.M(1))
+	} else {
+		stats.Record(m.context, msPubKeyCacheMiss.M(1))
+	}
+}
+
+func NewSigVerifyMetric(ctx context.Context) *SigVerifyMetric {
+	return &SigVerifyMetric{
+		context: ctx,
+	}
+}
@@ -243,6 +243,8 @@ func (srv *Manager) RegisterAPIHandler(g *echo.Group) {
 	ws.GET("/v3/:channel/block", srv.wssm.RunBlockSession, ChainInjector(srv))
 	ws.GET("/v3/:channel/event", srv.wssm.RunEventSession, ChainInjector(srv))
 	ws.GET("/v3/:channel/btp", srv.wssm.RunBtpSession, ChainInjector(srv))
+	ws.GET("/v3/:channel/header", srv.wssm.RunHeaderSession, ChainInjector(srv))
+	ws.GET("/v3/:channel/transaction", srv.wssm.RunTransactionSession, ChainInjector(srv))
 }
 
 func (srv *Manager) RegisterMetricsHandler(g *echo.Group) {
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	"github.com/icon-project/goloop/server/metric"
@@ -42,16 +45,29 @@ func MethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository {
 	mr.RegisterMethod("icx_getTotalSupply", getTotalSupply)
 	mr.RegisterMethod("icx_getTransactionResult", getTransactionResult)
 	mr.RegisterMethod("icx_getTransactionByHash", getTransactionByHash)
+	mr.RegisterMethod("icx_getInternalCalls", getInternalCalls)
+	mr.RegisterMethod("icx_getTransactionsByAddress", getTransactionsByAddress)
+	mr.RegisterMethod("icx_getTokenBalance", getTokenBalance)
+	mr.RegisterMethod("icx_getTokenBalances", getTokenBalances)
+	mr.RegisterMethod("icx_getTokenTransfers", getTokenTransfers)
 	mr.RegisterMethod("icx_sendTransaction", sendTransaction)
 	mr.RegisterMethod("icx_sendTransactionAndWait", sendTransactionAndWait)
+	mr.RegisterMethod("icx_fillTransaction", fillTransaction)
 	mr.RegisterMethod("icx_waitTransactionResult", waitTransactionResult)
 
 	mr.RegisterMethod("icx_getDataByHash", getDataByHash)
 	mr.RegisterMethod("icx_getBlockHeaderByHeight", getBlockHeaderByHeight)
 	mr.RegisterMethod("icx_getVotesByHeight", getVotesByHeight)
+	mr.RegisterMethod("icx_getValidatorStats", getValidatorStats)
+	mr.RegisterMethod("icx_getRoundInfo", getRoundInfo)
+	mr.RegisterMethod("icx_getAccountActivity", getAccountActivity)
 	mr.RegisterMethod("icx_getProofForResult", getProofForResult)
 	mr.RegisterMethod("icx_getProofForEvents", getProofForEvents)
+	mr.RegisterMethod("icx_getReceiptProof", getReceiptProof)
 	mr.RegisterMethod("icx_getScoreStatus", getScoreStatus)
+	mr.RegisterMethod("icx_getScoreInfo", getScoreInfo)
+	mr.RegisterMethod("icx_getFeeSharingInfo", getFeeSharingInfo)
+	mr.RegisterMethod("icx_getRevisionInfo", getRevisionInfo)
 
 	mr.RegisterMethod("btp_getNetworkInfo", getBTPNetworkInfo)
 	mr.RegisterMethod("btp_getNetworkTypeInfo", getBTPNetworkTypeInfo)
@@ -59,6 +75,8 @@ func MethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository {
 	mr.RegisterMethod("btp_getHeader", getBTPHeader)
 	mr.RegisterMethod("btp_getProof", getBTPProof)
 	mr.RegisterMethod("btp_getSourceInformation", getBTPSourceInformation)
+	mr.RegisterMethod("btp_getNetworks", getBTPNetworks)
+	mr.RegisterMethod("btp_getMessageProofs", getBTPMessageProofs)
 
 	mr.SetAllowedNotification("icx_sendTransaction")
 	mr.SetAllowedNotification("icx_sendTransactionAndWait")
@@ -86,6 +104,44 @@ func fillTransactions(blockJson interface{}, b module.Block, v module.JSONVersio
 	return nil
 }
 
+// fillStepUsed fills the sum of steps used by transactions of the block,
+// which is the cumulative steps of the last receipt. It's omitted until the
+// block is executed, which is known by the result of the next block, or if
+// the receipts are not available. It should be called after
+// fillTransactions.
+func fillStepUsed(blockJson interface{}, bm module.BlockManager, sm module.ServiceManager, b module.Block) error {
+	result := blockJson.(map[string]interface{})
+	nblk, err := bm.GetBlockByHeight(b.Height() + 1)
+	if errors.NotFoundError.Equals(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	group := module.TransactionGroupNormal
+	count := len(result["confirmed_transaction_list"].([]interface{}))
+	if count == 0 {
+		group = module.TransactionGroupPatch
+		for it := b.PatchTransactions().Iterator(); it.Has(); it.Next() {
+			count += 1
+		}
+	}
+	if count == 0 {
+		result["step_used"] = new(big.Int)
+		return nil
+	}
+	rl, err := sm.ReceiptListFromResult(nblk.Result(), group)
+	if err != nil {
+		return nil
+	}
+	r, err := rl.Get(count - 1)
+	if err != nil {
+		return nil
+	}
+	result["step_used"] = r.CumulativeStepUsed()
+	return nil
+}
+
 type contextWithChain struct {
 	*jsonrpc.Context
 	debug bool
@@ -121,15 +177,16 @@ func (c *contextWithChain) AsRPCError(err error) error {
 	return jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
 }
 
-// CheckBaseHeight returns jsonrpc.ErrorCodeNotFound for lower height
-// than the base height in genesis.
+// CheckBaseHeight returns jsonrpc.ErrorCodeNotFound for negative height,
+// and jsonrpc.ErrorCodePruned for lower height than the base height in
+// genesis.
 func (c *contextWithChain) CheckBaseHeight(height int64) error {
 	if height < 0 {
 		return jsonrpc.ErrorCodeNotFound.Errorf("NegativeHeight(height=%d)", height)
 	}
 	base := c.chain.GenesisStorage().Height()
 	if height < base {
-		return jsonrpc.ErrorCodeNotFound.Errorf(
+		return jsonrpc.ErrorCodePruned.Errorf(
 			"PrunedBlock(height=%d,base=%d)", height, base)
 	}
 	return nil
@@ -180,6 +237,37 @@ func (c *contextWithBM) GetBlockByID(id []byte) (module.Block, error) {
 	return blk, nil
 }
 
+// CheckHistory returns jsonrpc.ErrorCodePruned if the node doesn't serve
+// the kind of query for the block at the height.
+func (c *contextWithBM) CheckHistory(query string, height int64) error {
+	hc := c.chain.HistoryCapability()
+	if hc == nil {
+		return nil
+	}
+	last, err := c.bm.GetLastBlock()
+	if err != nil {
+		return c.AsRPCError(err)
+	}
+	if !hc.Serves(query, height, last.Height()) {
+		return jsonrpc.ErrorCodePruned.Errorf(
+			"PrunedHistory(query=%s,height=%d,depth=%d)", query, height, hc.Depth)
+	}
+	return nil
+}
+
+// GetStateBlockByHeight returns the block for queries of the state at
+// the height. The last block is used if the height is empty.
+func (c *contextWithBM) GetStateBlockByHeight(height jsonrpc.HexInt) (module.Block, error) {
+	blk, err := c.GetBlockByHeight(height)
+	if err != nil || height == "" {
+		return blk, err
+	}
+	if err = c.CheckHistory(module.HistoryState, blk.Height()); err != nil {
+		return nil, err
+	}
+	return blk, nil
+}
+
 type contextWithSM struct {
 	contextWithBM
 	sm module.ServiceManager
@@ -213,7 +301,7 @@ func (c *contextWithCS) Init(ctx *jsonrpc.Context) error {
 }
 
 func getLastBlock(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithBM
+	var c contextWithSM
 	if err := c.Init(ctx); err != nil {
 		return nil, err
 	}
@@ -236,11 +324,14 @@ func getLastBlock(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, er
 	if err = fillTransactions(blockJson, blk, module.JSONVersion3); err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
 	}
+	if err = fillStepUsed(blockJson, c.bm, c.sm, blk); err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
 	return blockJson, nil
 }
 
 func getBlockByHeight(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithBM
+	var c contextWithSM
 	if err := c.Init(ctx); err != nil {
 		return nil, err
 	}
@@ -263,11 +354,14 @@ func getBlockByHeight(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}
 	if err = fillTransactions(blockJson, blk, module.JSONVersion3); err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
 	}
+	if err = fillStepUsed(blockJson, c.bm, c.sm, blk); err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
 	return blockJson, nil
 }
 
 func getBlockByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithBM
+	var c contextWithSM
 	if err := c.Init(ctx); err != nil {
 		return nil, err
 	}
@@ -290,6 +384,9 @@ func getBlockByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 	if err = fillTransactions(blockJson, blk, module.JSONVersion3); err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
 	}
+	if err = fillStepUsed(blockJson, c.bm, c.sm, blk); err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
 	return blockJson, nil
 }
 
@@ -304,7 +401,7 @@ func call(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	blk, err := c.GetBlockByHeight(param.Height)
+	blk, err := c.GetStateBlockByHeight(param.Height)
 	if err != nil {
 		return nil, err
 	}
@@ -336,7 +433,7 @@ func getBalance(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, erro
 	}
 
 	var balance common.HexInt
-	blk, err := c.GetBlockByHeight(param.Height)
+	blk, err := c.GetStateBlockByHeight(param.Height)
 	if err != nil {
 		return nil, err
 	}
@@ -360,7 +457,7 @@ func getScoreApi(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, err
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	b, err := c.GetBlockByHeight(param.Height)
+	b, err := c.GetStateBlockByHeight(param.Height)
 	if err != nil {
 		return nil, err
 	}
@@ -393,7 +490,7 @@ func getTotalSupply(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 		}
 	}
 
-	b, err := c.GetBlockByHeight(height)
+	b, err := c.GetStateBlockByHeight(height)
 	if err != nil {
 		return nil, err
 	}
@@ -407,6 +504,56 @@ func getTotalSupply(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 	return &tsValue, nil
 }
 
+func getRevisionInfo(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+	var param *HeightParam
+	var height jsonrpc.HexInt
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	} else {
+		if param != nil {
+			height = param.Height
+		}
+	}
+
+	b, err := c.GetStateBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	revision := c.sm.GetRevision(b.Result())
+	nextVersion := c.sm.GetNextBlockVersion(b.Result())
+	if revision < 0 || nextVersion < 0 {
+		return nil, jsonrpc.ErrorCodeSystem.Errorf("FailToGetRevision(height=%d)", b.Height())
+	}
+
+	// Changes effective from the next block. The revision for the next
+	// block is known only after the next block is made.
+	pending := make(map[string]interface{})
+	if nextVersion != b.Version() {
+		pending["blockVersion"] = intconv.FormatInt(int64(nextVersion))
+	}
+	if next, err := c.bm.GetBlockByHeight(b.Height() + 1); err == nil {
+		if rev := c.sm.GetRevision(next.Result()); rev >= 0 && rev != revision {
+			pending["revision"] = intconv.FormatInt(int64(rev))
+		}
+	}
+	changes := make([]interface{}, 0, 1)
+	if len(pending) > 0 {
+		pending["height"] = intconv.FormatInt(b.Height() + 1)
+		changes = append(changes, pending)
+	}
+	return map[string]interface{}{
+		"height":           intconv.FormatInt(b.Height()),
+		"revision":         intconv.FormatInt(int64(revision)),
+		"blockVersion":     intconv.FormatInt(int64(b.Version())),
+		"nextBlockVersion": intconv.FormatInt(int64(nextVersion)),
+		"pending":          changes,
+	}, nil
+}
+
 func getTransactionResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	var c contextWithSM
 	if err := c.Init(ctx); err != nil {
@@ -432,6 +579,9 @@ func getTransactionResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interfa
 	if err = c.CheckBaseHeight(blk.Height()); err != nil {
 		return nil, err
 	}
+	if err = c.CheckHistory(module.HistoryReceipt, blk.Height()); err != nil {
+		return nil, err
+	}
 	receipt, err := txInfo.GetReceipt()
 	if block.ResultNotFinalizedError.Equals(err) {
 		return nil, jsonrpc.ErrorCodeExecuting.New("Executing")
@@ -452,6 +602,57 @@ func getTransactionResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interfa
 	return result, nil
 }
 
+func getInternalCalls(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param TransactionHashParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	if !c.chain.RecordInternalCalls() {
+		return nil, jsonrpc.ErrorCodeMethodNotFound.New("NotEnabled(recordInternalCalls=false)")
+	}
+
+	txInfo, err := c.bm.GetTransactionInfo(param.Hash.Bytes())
+	if errors.NotFoundError.Equals(err) {
+		if c.sm.HasTransaction(param.Hash.Bytes()) {
+			return nil, jsonrpc.ErrorCodePending.New("Pending")
+		}
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, c.debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	if err = c.CheckBaseHeight(txInfo.Block().Height()); err != nil {
+		return nil, err
+	}
+	if err = c.CheckHistory(module.HistoryTrace, txInfo.Block().Height()); err != nil {
+		return nil, err
+	}
+	if _, err := txInfo.GetReceipt(); block.ResultNotFinalizedError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeExecuting.New("Executing")
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+
+	calls, err := txresult.LoadInternalCalls(c.chain.Database(), param.Hash.Bytes())
+	if err != nil && !errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	result := make([]interface{}, 0, len(calls))
+	for _, call := range calls {
+		js, err := call.ToJSON(module.JSONVersion3)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+		}
+		result = append(result, js)
+	}
+	return result, nil
+}
+
 func getTransactionByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	var c contextWithBM
 	if err := c.Init(ctx); err != nil {
@@ -613,211 +814,592 @@ func getVotesByHeight(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}
 	return votes.Bytes(), nil
 }
 
-func getProofForResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithSM
+func getValidatorStats(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithChain
 	if err := c.Init(ctx); err != nil {
 		return nil, err
 	}
 
-	var param ProofResultParam
+	var param ValidatorStatsParam
 	if err := params.Convert(&param); err != nil {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
-	var idx int
-	if v64, err := param.Index.ParseInt(int(unsafe.Sizeof(idx)) * 8); err != nil {
+	start, err := param.StartHeight.ParseInt(64)
+	if err != nil {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
-	} else {
-		idx = int(v64)
 	}
-
-	blk, err := c.GetBlockByID(param.BlockHash.Bytes())
+	end, err := param.EndHeight.ParseInt(64)
 	if err != nil {
-		return nil, err
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	receiptList, err := c.sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
-	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
-	}
-	proofs, err := receiptList.GetProof(idx)
+	stats, err := consensus.GetValidatorStats(c.chain, param.Address.Address(), start, end)
 	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
 		return nil, c.AsRPCError(err)
 	}
-
-	return proofs, nil
+	return stats.ToJSON(), nil
 }
 
-func getProofForEvents(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithSM
+func getTransactionsByAddress(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithChain
 	if err := c.Init(ctx); err != nil {
 		return nil, err
 	}
 
-	var param ProofEventsParam
+	var param TransactionsByAddressParam
 	if err := params.Convert(&param); err != nil {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
-	var idx int
-	if v64, err := param.Index.ParseInt(int(unsafe.Sizeof(idx)) * 8); err != nil {
-		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
-	} else {
-		idx = int(v64)
-	}
-	blk, err := c.GetBlockByID(param.BlockHash.Bytes())
-	if err != nil {
-		return nil, err
+
+	if !c.chain.RecordAddressIndex() {
+		return nil, jsonrpc.ErrorCodeMethodNotFound.New("NotEnabled(recordAddressIndex=false)")
 	}
 
-	receiptList, err := c.sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+	cursor, limit, err := parsePageParams(param.Cursor, param.Limit)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	receipt, err := receiptList.Get(idx)
+	txs, err := service.GetTransactionsByAddress(c.chain.Database(), param.Address.Address(), cursor, limit)
 	if err != nil {
-		err = errors.NotFoundError.Wrapf(err,
-			"fail to get a receipt for index=%d", idx)
-		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, c.debug)
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		return nil, c.AsRPCError(err)
 	}
-	var proofs [][][]byte
-	rProof, err := receiptList.GetProof(idx)
-	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	return txs.ToJSON(), nil
+}
+
+func parsePageParams(cursorParam, limitParam jsonrpc.HexInt) (int64, int, error) {
+	cursor := int64(-1)
+	if cursorParam != "" {
+		v, err := cursorParam.ParseInt(64)
+		if err != nil {
+			return 0, 0, err
+		}
+		cursor = v
 	}
-	proofs = append(proofs, rProof)
-	for _, ei := range param.Events {
-		proof, err := receipt.GetProofOfEvent(int(ei.Value()))
+	var limit int
+	if limitParam != "" {
+		v, err := limitParam.ParseInt(32)
 		if err != nil {
-			if errors.InvalidStateError.Equals(err) {
-				return nil, jsonrpc.ErrorCodeSystem.Errorf(
-					"unable to get proof from current receipt index=%d", idx)
-			}
-			if errors.NotFoundError.Equals(err) {
-				return nil, jsonrpc.ErrorCodeNotFound.Errorf(
-					"no proof for receipt index=%d, event index=%d", idx, ei.Value())
-			}
-			return nil, c.AsRPCError(err)
+			return 0, 0, err
 		}
-		proofs = append(proofs, proof)
+		limit = int(v)
 	}
-	return proofs, nil
+	return cursor, limit, nil
 }
 
-func getScoreStatus(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithSM
+func getTokenBalance(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithChain
 	if err := c.Init(ctx); err != nil {
 		return nil, err
 	}
-	var param ScoreAddressParam
+
+	var param TokenBalanceParam
 	if err := params.Convert(&param); err != nil {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	b, err := c.GetBlockByHeight(param.Height)
-	if err != nil {
-		return nil, err
+	if !c.chain.RecordTokenIndex() {
+		return nil, jsonrpc.ErrorCodeMethodNotFound.New("NotEnabled(recordTokenIndex=false)")
 	}
-	s, err := c.sm.GetSCOREStatus(b.Result(), param.Address.Address())
+
+	balance, err := service.GetTokenBalance(c.chain.Database(), param.Token.Address(), param.Address.Address())
 	if err != nil {
 		return nil, c.AsRPCError(err)
 	}
-	jso, err := s.ToJSON(b.Height(), module.JSONVersion3)
-	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
-	}
-	return jso, nil
+	return balance.ToJSON(), nil
 }
 
-func getBTPNetworkInfo(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithSM
+func getTokenBalances(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithChain
 	if err := c.Init(ctx); err != nil {
 		return nil, err
 	}
 
-	var param BTPQueryParam
+	var param TokenBalancesParam
 	if err := params.Convert(&param); err != nil {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	nid, err := param.Id.Int64()
-	if err != nil {
-		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	if !c.chain.RecordTokenIndex() {
+		return nil, jsonrpc.ErrorCodeMethodNotFound.New("NotEnabled(recordTokenIndex=false)")
 	}
 
-	blk, err := c.GetBlockByHeight(param.Height)
+	cursor, limit, err := parsePageParams(param.Cursor, param.Limit)
 	if err != nil {
-		return nil, err
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	blockResult := blk.Result()
-	nw, err := c.sm.BTPNetworkFromResult(blockResult, nid)
-	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
-	}
-	nt, err := c.sm.BTPNetworkTypeFromResult(blockResult, nw.NetworkTypeID())
+	balances, err := service.GetTokenBalances(c.chain.Database(), param.Address.Address(), cursor, limit)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		return nil, c.AsRPCError(err)
 	}
-	res := nw.ToJSON()
-	res["networkID"] = intconv.FormatInt(nid)
-	res["networkTypeName"] = nt.UID()
-	return res, nil
+	return balances.ToJSON(), nil
 }
 
-func getBTPNetworkTypeInfo(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithSM
+func getTokenTransfers(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithChain
 	if err := c.Init(ctx); err != nil {
 		return nil, err
 	}
 
-	var param BTPQueryParam
+	var param TokenTransfersParam
 	if err := params.Convert(&param); err != nil {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	ntid, err := param.Id.Int64()
-	if err != nil {
-		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	if !c.chain.RecordTokenIndex() {
+		return nil, jsonrpc.ErrorCodeMethodNotFound.New("NotEnabled(recordTokenIndex=false)")
 	}
 
-	blk, err := c.GetBlockByHeight(param.Height)
+	cursor, limit, err := parsePageParams(param.Cursor, param.Limit)
 	if err != nil {
-		return nil, err
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	blockResult := blk.Result()
-	nt, err := c.sm.BTPNetworkTypeFromResult(blockResult, ntid)
+	transfers, err := service.GetTokenTransfers(c.chain.Database(), param.Address.Address(), cursor, limit)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		return nil, c.AsRPCError(err)
 	}
-	res := nt.ToJSON()
-	res["networkTypeID"] = intconv.FormatInt(ntid)
-	return res, nil
+	return transfers.ToJSON(), nil
 }
 
-func getBTPMessages(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithSM
+func getAccountActivity(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithBM
 	if err := c.Init(ctx); err != nil {
 		return nil, err
 	}
 
-	var param BTPMessagesParam
+	var param AccountActivityParam
 	if err := params.Convert(&param); err != nil {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
-
-	nid, err := param.NetworkId.Int64()
-	if err != nil {
-		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	start := c.chain.GenesisStorage().Height()
+	if param.StartHeight != "" {
+		h, err := param.StartHeight.ParseInt(64)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		start = h
 	}
-
-	blk, err := c.GetBlockByHeight(param.Height)
+	blk, err := c.bm.GetLastBlock()
 	if err != nil {
-		return nil, err
+		return nil, c.AsRPCError(err)
+	}
+	end := blk.Height()
+	if param.EndHeight != "" {
+		h, err := param.EndHeight.ParseInt(64)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		if h < end {
+			end = h
+		}
+	}
+
+	activity, err := service.GetAccountActivity(c.chain.Database(), param.Address.Address(), start, end)
+	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		return nil, c.AsRPCError(err)
+	}
+	return activity.ToJSON(), nil
+}
+
+func getRoundInfo(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithChain
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+	cs := c.chain.Consensus()
+	if cs == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("AlreadyStopped")
+	}
+
+	var param BlockHeightParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	height, err := param.Height.ParseInt(64)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	if err = c.CheckBaseHeight(height); err != nil {
+		return nil, err
+	}
+
+	votes, err := cs.GetVotesByHeight(height)
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	ri, err := consensus.GetRoundInfo(c.chain, height, votes)
+	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		return nil, c.AsRPCError(err)
+	}
+	return ri.ToJSON(), nil
+}
+
+func getProofForResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param ProofResultParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	var idx int
+	if v64, err := param.Index.ParseInt(int(unsafe.Sizeof(idx)) * 8); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	} else {
+		idx = int(v64)
+	}
+
+	blk, err := c.GetBlockByID(param.BlockHash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if err = c.CheckHistory(module.HistoryReceipt, blk.Height()); err != nil {
+		return nil, err
+	}
+
+	receiptList, err := c.sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	proofs, err := receiptList.GetProof(idx)
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+
+	return proofs, nil
+}
+
+func getProofForEvents(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param ProofEventsParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	var idx int
+	if v64, err := param.Index.ParseInt(int(unsafe.Sizeof(idx)) * 8); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	} else {
+		idx = int(v64)
+	}
+	blk, err := c.GetBlockByID(param.BlockHash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if err = c.CheckHistory(module.HistoryReceipt, blk.Height()); err != nil {
+		return nil, err
+	}
+
+	receiptList, err := c.sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+
+	receipt, err := receiptList.Get(idx)
+	if err != nil {
+		err = errors.NotFoundError.Wrapf(err,
+			"fail to get a receipt for index=%d", idx)
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, c.debug)
+	}
+	var proofs [][][]byte
+	rProof, err := receiptList.GetProof(idx)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	proofs = append(proofs, rProof)
+	for _, ei := range param.Events {
+		proof, err := receipt.GetProofOfEvent(int(ei.Value()))
+		if err != nil {
+			if errors.InvalidStateError.Equals(err) {
+				return nil, jsonrpc.ErrorCodeSystem.Errorf(
+					"unable to get proof from current receipt index=%d", idx)
+			}
+			if errors.NotFoundError.Equals(err) {
+				return nil, jsonrpc.ErrorCodeNotFound.Errorf(
+					"no proof for receipt index=%d, event index=%d", idx, ei.Value())
+			}
+			return nil, c.AsRPCError(err)
+		}
+		proofs = append(proofs, proof)
+	}
+	return proofs, nil
+}
+
+func getReceiptProof(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param TransactionHashParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	txInfo, err := c.bm.GetTransactionInfo(param.Hash.Bytes())
+	if errors.NotFoundError.Equals(err) {
+		if c.sm.HasTransaction(param.Hash.Bytes()) {
+			return nil, jsonrpc.ErrorCodePending.New("Pending")
+		}
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, c.debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+
+	blk := txInfo.Block()
+	if err = c.CheckBaseHeight(blk.Height()); err != nil {
+		return nil, err
+	}
+	if err = c.CheckHistory(module.HistoryReceipt, blk.Height()); err != nil {
+		return nil, err
+	}
+	if txInfo.Group() != module.TransactionGroupNormal {
+		return nil, jsonrpc.ErrorCodeInvalidParams.New("PatchTransaction")
+	}
+
+	// receipts of the transactions are in the result of the next block
+	rblk, err := c.bm.GetBlockByHeight(blk.Height() + 1)
+	if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeExecuting.New("Executing")
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	receiptList, err := c.sm.ReceiptListFromResult(rblk.Result(), module.TransactionGroupNormal)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	proof, err := receiptList.GetProof(txInfo.Index())
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	buf := bytes.NewBuffer(nil)
+	if err = rblk.MarshalHeader(buf); err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+
+	proofJSON := make([]string, len(proof))
+	for i, p := range proof {
+		proofJSON[i] = "0x" + hex.EncodeToString(p)
+	}
+	return map[string]interface{}{
+		"txHash":       "0x" + hex.EncodeToString(param.Hash.Bytes()),
+		"blockHash":    "0x" + hex.EncodeToString(blk.ID()),
+		"blockHeight":  "0x" + strconv.FormatInt(blk.Height(), 16),
+		"txIndex":      "0x" + strconv.FormatInt(int64(txInfo.Index()), 16),
+		"resultHeader": "0x" + hex.EncodeToString(buf.Bytes()),
+		"proof":        proofJSON,
+	}, nil
+}
+
+func getScoreStatus(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+	var param ScoreAddressParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	b, err := c.GetStateBlockByHeight(param.Height)
+	if err != nil {
+		return nil, err
+	}
+	s, err := c.sm.GetSCOREStatus(b.Result(), param.Address.Address())
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	jso, err := s.ToJSON(b.Height(), module.JSONVersion3)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	return jso, nil
+}
+
+// getScoreInfo returns the status of the contract with signatures of its
+// external methods and events.
+func getScoreInfo(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+	var param ScoreAddressParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	b, err := c.GetStateBlockByHeight(param.Height)
+	if err != nil {
+		return nil, err
+	}
+	s, err := c.sm.GetSCOREStatus(b.Result(), param.Address.Address())
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	jso, err := s.ToJSON(b.Height(), module.JSONVersion3)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	ret, ok := jso.(map[string]interface{})
+	if !ok {
+		return nil, jsonrpc.ErrorCodeSystem.Errorf("InvalidStatus(type=%T)", jso)
+	}
+	ret["address"] = param.Address
+	info, err := c.sm.GetAPIInfo(b.Result(), param.Address.Address())
+	if err != nil {
+		if !service.NoActiveContractError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+		}
+		return ret, nil
+	}
+	ret["methods"], ret["events"] = info.Signatures()
+	return ret, nil
+}
+
+func getFeeSharingInfo(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+	var param ScoreAddressParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	b, err := c.GetStateBlockByHeight(param.Height)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := c.sm.GetFeeSharingInfo(b.Result(), param.Address.Address())
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	jso, err := fi.ToJSON(b.Height(), module.JSONVersion3)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	return jso, nil
+}
+
+func getBTPNetworkInfo(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param BTPQueryParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	nid, err := param.Id.Int64()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	blk, err := c.GetStateBlockByHeight(param.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	blockResult := blk.Result()
+	nw, err := c.sm.BTPNetworkFromResult(blockResult, nid)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	nt, err := c.sm.BTPNetworkTypeFromResult(blockResult, nw.NetworkTypeID())
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	res := nw.ToJSON()
+	res["networkID"] = intconv.FormatInt(nid)
+	res["networkTypeName"] = nt.UID()
+	return res, nil
+}
+
+func getBTPNetworkTypeInfo(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param BTPQueryParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	ntid, err := param.Id.Int64()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	blk, err := c.GetStateBlockByHeight(param.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	blockResult := blk.Result()
+	nt, err := c.sm.BTPNetworkTypeFromResult(blockResult, ntid)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	res := nt.ToJSON()
+	res["networkTypeID"] = intconv.FormatInt(ntid)
+	return res, nil
+}
+
+func getBTPMessages(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param BTPMessagesParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	nid, err := param.NetworkId.Int64()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	blk, err := c.GetBlockByHeight(param.Height)
+	if err != nil {
+		return nil, err
 	}
+	return getBTPMessagesOf(&c, blk.Result(), nid)
+}
 
+// getBTPMessagesOf returns base64 encoded BTP messages of the network
+// in the block of the result.
+func getBTPMessagesOf(c *contextWithSM, blockResult []byte, nid int64) ([]string, error) {
 	res := make([]string, 0)
-	blockResult := blk.Result()
 	bDigest, err := c.sm.BTPDigestFromResult(blockResult)
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
@@ -942,6 +1524,251 @@ func getBTPSourceInformation(ctx *jsonrpc.Context, _ *jsonrpc.Params) (interface
 	}, nil
 }
 
+func getBTPNetworks(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param BTPNetworksParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	var ntid int64
+	if param.NetworkTypeId != "" {
+		var err error
+		if ntid, err = param.NetworkTypeId.Int64(); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+	}
+
+	blk, err := c.GetStateBlockByHeight(param.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	blockResult := blk.Result()
+	nids, err := c.sm.BTPNetworkIDsFromResult(blockResult)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	types := make(map[int64]module.BTPNetworkType)
+	res := make([]interface{}, 0, len(nids))
+	for _, nid := range nids {
+		nw, err := c.sm.BTPNetworkFromResult(blockResult, nid)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+		}
+		if ntid != 0 && nw.NetworkTypeID() != ntid {
+			continue
+		}
+		nt, ok := types[nw.NetworkTypeID()]
+		if !ok {
+			nt, err = c.sm.BTPNetworkTypeFromResult(blockResult, nw.NetworkTypeID())
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+			}
+			types[nw.NetworkTypeID()] = nt
+		}
+		jso := nw.ToJSON()
+		jso["networkID"] = intconv.FormatInt(nid)
+		jso["networkTypeName"] = nt.UID()
+		if pc := nt.NextProofContext(); len(pc) == 0 {
+			jso["nextProofContext"] = nil
+		} else {
+			jso["nextProofContext"] = base64.StdEncoding.EncodeToString(pc)
+		}
+		res = append(res, jso)
+	}
+	return res, nil
+}
+
+const (
+	btpMessageProofsDefaultLimit = 10
+	btpMessageProofsMaxLimit     = 100
+)
+
+// btpMessageSource provides messages and proofs of a BTP network for
+// collecting BTP message proofs.
+type btpMessageSource interface {
+	// NextMessageSNAt returns next message SN of the network in the block of
+	// the height. It returns zero if the network doesn't exist at the height.
+	NextMessageSNAt(height int64) (int64, module.Block, error)
+	MessagesOf(blk module.Block) ([]string, error)
+	HeaderAndProofOf(blk module.Block) (module.BTPBlockHeader, []byte, error)
+}
+
+type btpMessageSourceWithSM struct {
+	c   *contextWithSM
+	cs  module.Consensus
+	nid int64
+}
+
+func (s *btpMessageSourceWithSM) NextMessageSNAt(height int64) (int64, module.Block, error) {
+	blk, err := s.c.bm.GetBlockByHeight(height)
+	if err != nil {
+		return 0, nil, s.c.AsRPCError(err)
+	}
+	nw, err := s.c.sm.BTPNetworkFromResult(blk.Result(), s.nid)
+	if errors.NotFoundError.Equals(err) {
+		return 0, blk, nil
+	} else if err != nil {
+		return 0, nil, jsonrpc.ErrorCodeSystem.Wrap(err, s.c.debug)
+	}
+	return nw.NextMessageSN(), blk, nil
+}
+
+func (s *btpMessageSourceWithSM) MessagesOf(blk module.Block) ([]string, error) {
+	return getBTPMessagesOf(s.c, blk.Result(), s.nid)
+}
+
+func (s *btpMessageSourceWithSM) HeaderAndProofOf(blk module.Block) (module.BTPBlockHeader, []byte, error) {
+	return s.cs.GetBTPBlockHeaderAndProof(blk, s.nid,
+		module.FlagBTPBlockHeader|module.FlagBTPBlockProof)
+}
+
+// btpFindMessageHeight returns the lowest height in [low, high] whose block
+// contains the message of the SN. The block of high shall contain messages
+// after the SN.
+func btpFindMessageHeight(src btpMessageSource, low, high, sn int64) (int64, error) {
+	for low < high {
+		mid := low + (high-low)/2
+		next, _, err := src.NextMessageSNAt(mid)
+		if err != nil {
+			return 0, err
+		}
+		if next > sn {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return low, nil
+}
+
+// btpMessageRange returns the range of SNs and the limit of proofs for the
+// parameter. The range is cut by next, the next message SN of the network.
+func btpMessageRange(param *BTPMessageProofsParam, next int64) (int64, int64, int64, error) {
+	from, err := param.From.Int64()
+	if err != nil || from < 0 {
+		return 0, 0, 0, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidFrom(from=%s)", param.From)
+	}
+	limit := int64(btpMessageProofsDefaultLimit)
+	if param.Limit != "" {
+		limit, err = param.Limit.Int64()
+		if err != nil || limit <= 0 || limit > btpMessageProofsMaxLimit {
+			return 0, 0, 0, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidLimit(limit=%s)", param.Limit)
+		}
+	}
+	to := next
+	if param.To != "" {
+		value, err := param.To.Int64()
+		if err != nil || value < from {
+			return 0, 0, 0, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidTo(to=%s)", param.To)
+		}
+		if value < to {
+			to = value
+		}
+	}
+	return from, to, limit, nil
+}
+
+// collectBTPMessageProofs returns proofs for messages in [from, to) from the
+// blocks in [low, last]. It returns at most limit proofs, and the SN for the
+// next query if there are more messages.
+func collectBTPMessageProofs(src btpMessageSource, low, last, from, to, limit int64) (map[string]interface{}, error) {
+	proofs := make([]interface{}, 0)
+	sn := from
+	for sn < to && int64(len(proofs)) < limit {
+		height, err := btpFindMessageHeight(src, low, last, sn)
+		if err != nil {
+			return nil, err
+		}
+		next, blk, err := src.NextMessageSNAt(height)
+		if err != nil {
+			return nil, err
+		}
+		msgs, err := src.MessagesOf(blk)
+		if err != nil {
+			return nil, err
+		}
+		first := next - int64(len(msgs))
+		if first > sn || next <= sn {
+			return nil, errors.NotFoundError.Errorf(
+				"fail to get BTP messages for sn=%d", sn)
+		}
+		header, proof, err := src.HeaderAndProofOf(blk)
+		if errors.NotFoundError.Equals(err) && height == last {
+			// votes for the last block may not be available yet.
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, map[string]interface{}{
+			"height":         intconv.FormatInt(height),
+			"firstMessageSN": intconv.FormatInt(first),
+			"header":         base64.StdEncoding.EncodeToString(header.HeaderBytes()),
+			"proof":          base64.StdEncoding.EncodeToString(proof),
+			"messages":       msgs,
+		})
+		sn = next
+		low = height + 1
+	}
+
+	res := map[string]interface{}{
+		"proofs": proofs,
+		"next":   nil,
+	}
+	if sn < to {
+		res["next"] = intconv.FormatInt(sn)
+	}
+	return res, nil
+}
+
+func getBTPMessageProofs(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+	cs := c.chain.Consensus()
+	if cs == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	var param BTPMessageProofsParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	nid, err := param.NetworkId.Int64()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	last, err := c.bm.GetLastBlock()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, c.debug)
+	}
+	nw, err := c.sm.BTPNetworkFromResult(last.Result(), nid)
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	from, to, limit, err := btpMessageRange(&param, nw.NextMessageSN())
+	if err != nil {
+		return nil, err
+	}
+
+	low := nw.StartHeight()
+	if base := c.chain.GenesisStorage().Height(); low < base {
+		low = base
+	}
+	src := &btpMessageSourceWithSM{c: &c, cs: cs, nid: nid}
+	res, err := collectBTPMessageProofs(src, low, last.Height(), from, to, limit)
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	return res, nil
+}
+
 // convert TransactionList to []Transaction
 func convertTransactionList(txs module.TransactionList, version module.JSONVersion) ([]interface{}, error) {
 	list := []interface{}{}
@@ -1112,10 +1939,135 @@ func DebugMethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository
 
 	mr.RegisterMethod("debug_getTrace", getTrace)
 	mr.RegisterMethod("debug_estimateStep", estimateStep)
+	mr.RegisterMethod("debug_estimateStepDetails", estimateStepDetails)
+	mr.RegisterMethod("debug_getScoreStorage", getScoreStorage)
+	mr.RegisterMethod("debug_inspectTransaction", inspectTransaction)
 
 	return mr
 }
 
+const (
+	configDefaultStorageEntries = 100
+	configMaxStorageEntries     = 1000
+)
+
+type storageEntry struct {
+	Key   common.HexBytes `json:"key"`
+	Value common.HexBytes `json:"value"`
+}
+
+type storageEntries struct {
+	Entries []storageEntry  `json:"entries"`
+	Next    common.HexBytes `json:"next,omitempty"`
+}
+
+// getScoreStorage returns raw key and value pairs in the storage of the
+// account. Use the returned next key as start to get the next page.
+func getScoreStorage(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param ScoreStorageParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	limit := configDefaultStorageEntries
+	if len(param.Limit) > 0 {
+		l, err := param.Limit.Int64()
+		if err != nil || l <= 0 || l > configMaxStorageEntries {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidLimit(limit=%s)", param.Limit)
+		}
+		limit = int(l)
+	}
+
+	b, err := c.GetStateBlockByHeight(param.Height)
+	if err != nil {
+		return nil, err
+	}
+	entries, next, err := c.sm.GetStorageEntries(b.Result(), param.Address.Address(),
+		param.Prefix, param.Start, limit)
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	res := &storageEntries{
+		Entries: make([]storageEntry, len(entries)),
+		Next:    next,
+	}
+	for i, e := range entries {
+		res.Entries[i] = storageEntry{e.Key, e.Value}
+	}
+	return res, nil
+}
+
+type transactionInspection struct {
+	TxHash      common.HexBytes `json:"txHash"`
+	Raw         common.HexBytes `json:"raw"`
+	Transaction interface{}     `json:"transaction"`
+	Signer      module.Address  `json:"signer,omitempty"`
+	MinStep     *common.HexInt  `json:"minStep,omitempty"`
+	Errors      []string        `json:"errors"`
+}
+
+func inspectTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param InspectTransactionParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	if (len(param.Raw) == 0) == (len(param.Transaction) == 0) {
+		return nil, jsonrpc.ErrorCodeInvalidParams.New("EitherRawOrTransaction")
+	}
+
+	blk, err := c.bm.GetLastBlock()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, c.debug)
+	}
+	var txi interface{}
+	if len(param.Raw) > 0 {
+		tx, err := c.sm.TransactionFromBytes(param.Raw, blk.Version())
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		txi = tx
+	} else {
+		txi = []byte(param.Transaction)
+	}
+	ti, err := c.sm.InspectTransaction(blk.Result(), blk.Height()+1, txi)
+	if err != nil {
+		if service.InvalidTransactionError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		return nil, c.AsRPCError(err)
+	}
+
+	tx := ti.Transaction
+	txJSON, err := tx.ToJSON(module.JSONVersion3)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+	}
+	res := &transactionInspection{
+		TxHash:      tx.ID(),
+		Raw:         tx.Bytes(),
+		Transaction: txJSON,
+		Signer:      ti.Signer,
+		Errors:      make([]string, len(ti.Errors)),
+	}
+	if ti.MinimumStep != nil {
+		res.MinStep = common.NewHexInt(0)
+		res.MinStep.Set(ti.MinimumStep)
+	}
+	for i, e := range ti.Errors {
+		res.Errors[i] = e.Error()
+	}
+	return res, nil
+}
+
 func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	var c contextWithSM
 	if err := c.Init(ctx); err != nil {
@@ -1145,6 +2097,9 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 	if err = c.CheckBaseHeight(blk.Height()); err != nil {
 		return nil, err
 	}
+	if err = c.CheckHistory(module.HistoryTrace, blk.Height()); err != nil {
+		return nil, err
+	}
 	_, err = txInfo.GetReceipt()
 	if block.ResultNotFinalizedError.Equals(err) {
 		return nil, jsonrpc.ErrorCodeExecuting.New("Executing")
@@ -1198,17 +2153,21 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 	}
 }
 
-func estimateStep(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
-	var c contextWithSM
-	if err := c.Init(ctx); err != nil {
-		return nil, err
-	}
-
+func executeForEstimate(c *contextWithSM, params *jsonrpc.Params) (*TransactionParamForEstimate, module.Receipt, error) {
 	var param TransactionParamForEstimate
 	if err := params.Convert(&param); err != nil {
-		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		return nil, nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	rct, err := executeTransactionOnLast(c, params.RawMessage())
+	if err != nil {
+		return nil, nil, err
 	}
+	return &param, rct, nil
+}
 
+// executeTransactionOnLast executes the transaction without stepLimit and
+// signature on the state of the last block, then returns the receipt.
+func executeTransactionOnLast(c *contextWithSM, tx []byte) (module.Receipt, error) {
 	// get last block
 	blk, err := c.bm.GetLastBlock()
 	if err != nil {
@@ -1227,7 +2186,7 @@ func estimateStep(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, er
 	rct, err := c.sm.ExecuteTransaction(
 		blk.Result(),
 		blk.NextValidators().Hash(),
-		params.RawMessage(),
+		tx,
 		bi,
 	)
 	if err != nil {
@@ -1241,11 +2200,133 @@ func estimateStep(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, er
 		}
 		return nil, jsonrpc.ErrScoreWithStatus(status)
 	}
+	return rct, nil
+}
+
+func estimateStep(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	_, rct, err := executeForEstimate(&c, params)
+	if err != nil {
+		return nil, err
+	}
 	steps := new(common.HexInt)
 	steps.Set(rct.StepUsed())
 	return steps, nil
 }
 
+// fillTransaction fills version, nid, timestamp and stepLimit of the
+// partial transaction if they are missing, so that it can be signed and
+// sent by the client.
+func fillTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param TransactionParamForFill
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	var tx map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(params.RawMessage()))
+	dec.UseNumber()
+	if err := dec.Decode(&tx); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	now := common.UnixMicroFromTime(time.Now())
+	err := fillTransactionFields(tx, &param, int64(c.chain.NID()), now,
+		func(tx map[string]interface{}) (*big.Int, error) {
+			bs, err := json.Marshal(tx)
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+			}
+			rct, err := executeTransactionOnLast(&c, bs)
+			if err != nil {
+				return nil, err
+			}
+			return rct.StepUsed(), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// fillTransactionFields fills missing fields of the transaction, and removes
+// the signature. The stepLimit is filled with the exact steps returned by
+// estimate without any margin, so the transaction may fail if the state is
+// changed before it's executed.
+func fillTransactionFields(
+	tx map[string]interface{}, param *TransactionParamForFill, nid, now int64,
+	estimate func(tx map[string]interface{}) (*big.Int, error),
+) error {
+	// signature is not valid any more after filling
+	delete(tx, "signature")
+
+	if param.Version == "" {
+		tx["version"] = VersionValue
+	}
+	if param.NetworkID == "" {
+		tx["nid"] = intconv.FormatInt(nid)
+	} else if v, err := param.NetworkID.Int64(); err != nil || v != nid {
+		return jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"InvalidNetworkID(nid=%s,expected=%#x)", param.NetworkID, nid)
+	}
+	if param.Timestamp == "" {
+		tx["timestamp"] = intconv.FormatInt(now)
+	}
+	if param.StepLimit == "" {
+		steps, err := estimate(tx)
+		if err != nil {
+			return err
+		}
+		tx["stepLimit"] = intconv.FormatBigInt(steps)
+	}
+	return nil
+}
+
+func estimateStepDetails(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	param, rct, err := executeForEstimate(&c, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var details []*txresult.FeePaymentDetail
+	if rctex, ok := rct.(txresult.Receipt); ok {
+		details = rctex.FeePaymentDetails()
+	}
+	if len(details) == 0 {
+		// no fee sharing, then the sender pays all with its balance.
+		details = []*txresult.FeePaymentDetail{{
+			Payer:    param.FromAddress.Address(),
+			Steps:    rct.StepUsed(),
+			FeeSteps: rct.StepUsed(),
+		}}
+	}
+	payments := make([]interface{}, 0, len(details))
+	for _, d := range details {
+		jso, err := d.ToJSON(module.JSONVersion3)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+		}
+		payments = append(payments, jso)
+	}
+	return map[string]interface{}{
+		"stepUsed":  intconv.FormatBigInt(rct.StepUsed()),
+		"stepPrice": intconv.FormatBigInt(rct.StepPrice()),
+		"payments":  payments,
+	}, nil
+}
+
 type MissingTransactionInfo interface {
 	ReplaceID(height int64, id []byte) []byte
 	GetLocationOf(id []byte) (int64, int, bool)
@@ -1274,6 +2355,9 @@ func getTraceForRosetta(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface
 	if err != nil {
 		return nil, err
 	}
+	if err = c.CheckHistory(module.HistoryTrace, blk.Height()); err != nil {
+		return nil, err
+	}
 
 	csi, err := c.bm.NewConsensusInfo(blk)
 	if err != nil {
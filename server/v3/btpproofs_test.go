@@ -0,0 +1,271 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v3
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+type btpTestHeader struct {
+	module.BTPBlockHeader
+	height int64
+}
+
+func (h *btpTestHeader) HeaderBytes() []byte {
+	return []byte(fmt.Sprint(h.height))
+}
+
+// btpTestSource has a network starting at the start height, and counts are
+// the numbers of messages in the blocks from the start height.
+type btpTestSource struct {
+	start   int64
+	counts  []int64
+	pruned  int64
+	missing map[int64]bool
+	noProof map[int64]bool
+	lowest  int64
+}
+
+func (s *btpTestSource) last() int64 {
+	return s.start + int64(len(s.counts)) - 1
+}
+
+func (s *btpTestSource) NextMessageSNAt(height int64) (int64, module.Block, error) {
+	if height < s.lowest || s.lowest == 0 {
+		s.lowest = height
+	}
+	if height < s.pruned || height > s.last() {
+		return 0, nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	blk := &stepBlock{height: height}
+	var next int64
+	for h := s.start; h <= height; h++ {
+		next += s.counts[h-s.start]
+	}
+	return next, blk, nil
+}
+
+func (s *btpTestSource) MessagesOf(blk module.Block) ([]string, error) {
+	height := blk.Height()
+	if height < s.start {
+		return []string{}, nil
+	}
+	count := s.counts[height-s.start]
+	if s.missing[height] && count > 0 {
+		count -= 1
+	}
+	msgs := make([]string, count)
+	for i := range msgs {
+		msgs[i] = fmt.Sprintf("msg%d-%d", height, i)
+	}
+	return msgs, nil
+}
+
+func (s *btpTestSource) HeaderAndProofOf(blk module.Block) (module.BTPBlockHeader, []byte, error) {
+	if s.noProof[blk.Height()] {
+		return nil, nil, errors.NotFoundError.New("NoVotes")
+	}
+	return &btpTestHeader{height: blk.Height()}, []byte("proof"), nil
+}
+
+func TestCollectBTPMessageProofs(t *testing.T) {
+	// SNs by height: 5:[0,1] 7:[2] 9:[3,4,5] 11:[6] 12:[7]
+	counts := []int64{2, 0, 1, 0, 3, 0, 1, 1}
+	type proof struct {
+		height int64
+		first  int64
+	}
+	tests := []struct {
+		name    string
+		src     *btpTestSource
+		from    int64
+		to      int64
+		limit   int64
+		proofs  []proof
+		next    interface{}
+		wantErr bool
+	}{
+		{
+			name:   "FirstBlock",
+			from:   0,
+			to:     2,
+			limit:  10,
+			proofs: []proof{{5, 0}},
+		},
+		{
+			name:   "MiddleOfBlock",
+			from:   4,
+			to:     7,
+			limit:  10,
+			proofs: []proof{{9, 3}, {11, 6}},
+		},
+		{
+			name:   "LastBlock",
+			from:   7,
+			to:     8,
+			limit:  10,
+			proofs: []proof{{12, 7}},
+		},
+		{
+			name:   "All",
+			from:   0,
+			to:     8,
+			limit:  10,
+			proofs: []proof{{5, 0}, {7, 2}, {9, 3}, {11, 6}, {12, 7}},
+		},
+		{
+			name:   "Pagination",
+			from:   0,
+			to:     8,
+			limit:  2,
+			proofs: []proof{{5, 0}, {7, 2}},
+			next:   intconv.FormatInt(3),
+		},
+		{
+			name:   "PaginationAtLimit",
+			from:   3,
+			to:     8,
+			limit:  3,
+			proofs: []proof{{9, 3}, {11, 6}, {12, 7}},
+		},
+		{
+			name:   "EmptyRange",
+			from:   8,
+			to:     8,
+			limit:  10,
+			proofs: []proof{},
+		},
+		{
+			name:   "NoVotesForLast",
+			src:    &btpTestSource{noProof: map[int64]bool{12: true}},
+			from:   6,
+			to:     8,
+			limit:  10,
+			proofs: []proof{{11, 6}},
+			next:   intconv.FormatInt(7),
+		},
+		{
+			name:    "NoVotesForMiddle",
+			src:     &btpTestSource{noProof: map[int64]bool{9: true}},
+			from:    3,
+			to:      8,
+			limit:   10,
+			wantErr: true,
+		},
+		{
+			name:    "Pruned",
+			src:     &btpTestSource{pruned: 8},
+			from:    0,
+			to:      8,
+			limit:   10,
+			wantErr: true,
+		},
+		{
+			name:   "PrunedBeforeRange",
+			src:    &btpTestSource{pruned: 8},
+			from:   6,
+			to:     8,
+			limit:  10,
+			proofs: []proof{{11, 6}, {12, 7}},
+		},
+		{
+			name:    "MissingMessages",
+			src:     &btpTestSource{missing: map[int64]bool{9: true}},
+			from:    3,
+			to:      8,
+			limit:   10,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := tt.src
+			if src == nil {
+				src = &btpTestSource{}
+			}
+			src.start = 5
+			src.counts = counts
+			low := src.start
+			if src.pruned > low {
+				low = src.pruned
+			}
+			res, err := collectBTPMessageProofs(src, low, src.last(), tt.from, tt.to, tt.limit)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.next, res["next"])
+			proofs := res["proofs"].([]interface{})
+			if assert.Len(t, proofs, len(tt.proofs)) {
+				for i, p := range tt.proofs {
+					jso := proofs[i].(map[string]interface{})
+					assert.Equal(t, intconv.FormatInt(p.height), jso["height"])
+					assert.Equal(t, intconv.FormatInt(p.first), jso["firstMessageSN"])
+					assert.Len(t, jso["messages"], int(counts[p.height-src.start]))
+				}
+			}
+			// blocks before the start of the network are not used
+			if src.lowest != 0 {
+				assert.True(t, src.lowest >= low)
+			}
+		})
+	}
+}
+
+func TestBTPMessageRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   BTPMessageProofsParam
+		next    int64
+		from    int64
+		to      int64
+		limit   int64
+		wantErr bool
+	}{
+		{"Default", BTPMessageProofsParam{From: "0x1"}, 10, 1, 10, btpMessageProofsDefaultLimit, false},
+		{"To", BTPMessageProofsParam{From: "0x1", To: "0x5", Limit: "0x2"}, 10, 1, 5, 2, false},
+		{"ToAfterNext", BTPMessageProofsParam{From: "0x1", To: "0x20"}, 10, 1, 10, btpMessageProofsDefaultLimit, false},
+		{"FromAfterTo", BTPMessageProofsParam{From: "0x5", To: "0x4"}, 10, 0, 0, 0, true},
+		{"NegativeFrom", BTPMessageProofsParam{From: "-0x1"}, 10, 0, 0, 0, true},
+		{"ZeroLimit", BTPMessageProofsParam{From: "0x1", Limit: "0x0"}, 10, 0, 0, 0, true},
+		{"TooLargeLimit", BTPMessageProofsParam{From: "0x1", Limit: "0x65"}, 10, 0, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, limit, err := btpMessageRange(&tt.param, tt.next)
+			if tt.wantErr {
+				if assert.Error(t, err) {
+					assert.Equal(t, jsonrpc.ErrorCodeInvalidParams, err.(*jsonrpc.Error).Code)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+			assert.Equal(t, tt.limit, limit)
+		})
+	}
+}
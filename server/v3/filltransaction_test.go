@@ -0,0 +1,104 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v3
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+func parseTransactionForFill(t *testing.T, s string) (map[string]interface{}, *TransactionParamForFill) {
+	var param TransactionParamForFill
+	assert.NoError(t, json.Unmarshal([]byte(s), &param))
+	var tx map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+	assert.NoError(t, dec.Decode(&tx))
+	return tx, &param
+}
+
+func TestFillTransactionFields(t *testing.T) {
+	var estimated map[string]interface{}
+	estimate := func(tx map[string]interface{}) (*big.Int, error) {
+		estimated = tx
+		return big.NewInt(0x1234), nil
+	}
+
+	// missing fields are filled, and the signature is removed
+	tx, param := parseTransactionForFill(t, `{
+		"from": "hxbe258ceb872e08851f1f59694dac2558708ece11",
+		"to": "hx5bfdb090f43a808005ffc27c25b213145e80b7cd",
+		"value": "0x10",
+		"signature": "c2lnbmF0dXJl"
+	}`)
+	assert.NoError(t, fillTransactionFields(tx, param, 3, 0x5fbd1fab1b6b0, estimate))
+	assert.Equal(t, VersionValue, tx["version"])
+	assert.Equal(t, "0x3", tx["nid"])
+	assert.Equal(t, "0x5fbd1fab1b6b0", tx["timestamp"])
+	assert.Equal(t, "0x1234", tx["stepLimit"])
+	assert.Equal(t, "0x10", tx["value"])
+	assert.NotContains(t, tx, "signature")
+	assert.NotContains(t, estimated, "signature")
+	assert.Contains(t, estimated, "timestamp")
+
+	// given fields are kept, and it doesn't estimate steps
+	estimated = nil
+	tx, param = parseTransactionForFill(t, `{
+		"version": "0x3",
+		"from": "hxbe258ceb872e08851f1f59694dac2558708ece11",
+		"to": "hx5bfdb090f43a808005ffc27c25b213145e80b7cd",
+		"stepLimit": "0x100",
+		"timestamp": "0x1",
+		"nid": "0x3"
+	}`)
+	assert.NoError(t, fillTransactionFields(tx, param, 3, 0x5fbd1fab1b6b0, estimate))
+	assert.Equal(t, "0x100", tx["stepLimit"])
+	assert.Equal(t, "0x1", tx["timestamp"])
+	assert.Equal(t, "0x3", tx["nid"])
+	assert.Nil(t, estimated)
+
+	// nid of other network
+	tx, param = parseTransactionForFill(t, `{
+		"from": "hxbe258ceb872e08851f1f59694dac2558708ece11",
+		"to": "hx5bfdb090f43a808005ffc27c25b213145e80b7cd",
+		"nid": "0x2"
+	}`)
+	err := fillTransactionFields(tx, param, 3, 0x5fbd1fab1b6b0, estimate)
+	if assert.Error(t, err) {
+		jerr, ok := err.(*jsonrpc.Error)
+		assert.True(t, ok)
+		assert.Equal(t, jsonrpc.ErrorCodeInvalidParams, jerr.Code)
+	}
+
+	// failure of estimation
+	tx, param = parseTransactionForFill(t, `{
+		"from": "hxbe258ceb872e08851f1f59694dac2558708ece11",
+		"to": "hx5bfdb090f43a808005ffc27c25b213145e80b7cd"
+	}`)
+	estimateErr := jsonrpc.ErrorCodeServer.New("ExecutionFailure")
+	err = fillTransactionFields(tx, param, 3, 0x5fbd1fab1b6b0,
+		func(tx map[string]interface{}) (*big.Int, error) {
+			return nil, estimateErr
+		})
+	assert.Equal(t, estimateErr, err)
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v3
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/metric"
+)
+
+type rpcTestBlock struct {
+	module.Block
+	height  int64
+	version int
+	result  []byte
+}
+
+func (b *rpcTestBlock) Height() int64  { return b.height }
+func (b *rpcTestBlock) Version() int   { return b.version }
+func (b *rpcTestBlock) Result() []byte { return b.result }
+
+type rpcTestBlockManager struct {
+	module.BlockManager
+	blocks []module.Block
+}
+
+func (bm *rpcTestBlockManager) GetLastBlock() (module.Block, error) {
+	return bm.blocks[len(bm.blocks)-1], nil
+}
+
+func (bm *rpcTestBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	if height < 0 || height >= int64(len(bm.blocks)) {
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	return bm.blocks[height], nil
+}
+
+type rpcTestGenesisStorage struct {
+	module.GenesisStorage
+}
+
+func (gs *rpcTestGenesisStorage) Height() int64 { return 0 }
+
+type rpcTestChain struct {
+	module.Chain
+	bm module.BlockManager
+	sm module.ServiceManager
+}
+
+func (c *rpcTestChain) BlockManager() module.BlockManager            { return c.bm }
+func (c *rpcTestChain) ServiceManager() module.ServiceManager        { return c.sm }
+func (c *rpcTestChain) GenesisStorage() module.GenesisStorage        { return &rpcTestGenesisStorage{} }
+func (c *rpcTestChain) HistoryCapability() *module.HistoryCapability { return nil }
+func (c *rpcTestChain) MetricContext() context.Context               { return metric.DefaultMetricContext() }
+
+var rpcTestMetric = metric.NewJsonrpcMetric(metric.DefaultJsonrpcDurationsExpire, metric.DefaultJsonrpcDurationsSize, false)
+
+// invokeRPC calls the method in the repository with the params, and returns
+// the result or the error of the response.
+func invokeRPC(t *testing.T, mr *jsonrpc.MethodRepository, c module.Chain, method, params string) (json.RawMessage, *jsonrpc.Error) {
+	req := `{"jsonrpc":"2.0","method":"` + method + `","params":` + params + `,"id":1}`
+	e := echo.New()
+	e.Validator = jsonrpc.NewValidator()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(req)), rec)
+	ctx.Set("includeDebug", false)
+	ctx.Set("chain", c)
+	ctx.Set("raw", json.RawMessage(req))
+	assert.NoError(t, mr.Handle(ctx))
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *jsonrpc.Error  `json:"error"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Result, resp.Error
+}
+
+type revisionTestServiceManager struct {
+	module.ServiceManager
+	revisions map[string]int
+	versions  map[string]int
+}
+
+func (sm *revisionTestServiceManager) GetRevision(result []byte) int {
+	if rev, ok := sm.revisions[string(result)]; ok {
+		return rev
+	}
+	return -1
+}
+
+func (sm *revisionTestServiceManager) GetNextBlockVersion(result []byte) int {
+	if v, ok := sm.versions[string(result)]; ok {
+		return v
+	}
+	return -1
+}
+
+func TestGetRevisionInfo(t *testing.T) {
+	c := &rpcTestChain{
+		bm: &rpcTestBlockManager{blocks: []module.Block{
+			&rpcTestBlock{height: 0, version: module.BlockVersion1, result: []byte("r0")},
+			&rpcTestBlock{height: 1, version: module.BlockVersion1, result: []byte("r1")},
+			&rpcTestBlock{height: 2, version: module.BlockVersion2, result: []byte("r2")},
+			&rpcTestBlock{height: 3, version: module.BlockVersion2, result: []byte("nostate")},
+		}},
+		sm: &revisionTestServiceManager{
+			revisions: map[string]int{"r0": 20, "r1": 20, "r2": 21},
+			versions:  map[string]int{"r0": module.BlockVersion1, "r1": module.BlockVersion2, "r2": module.BlockVersion2},
+		},
+	}
+	mr := MethodRepository(rpcTestMetric)
+
+	tests := []struct {
+		name    string
+		params  string
+		result  string
+		errCode jsonrpc.ErrorCode
+	}{
+		{
+			name:   "NoChanges",
+			params: `{"height":"0x0"}`,
+			result: `{"blockVersion":"0x1","height":"0x0","nextBlockVersion":"0x1","pending":[],"revision":"0x14"}`,
+		},
+		{
+			name:   "PendingChanges",
+			params: `{"height":"0x1"}`,
+			result: `{"blockVersion":"0x1","height":"0x1","nextBlockVersion":"0x2","pending":[{"blockVersion":"0x2","height":"0x2","revision":"0x15"}],"revision":"0x14"}`,
+		},
+		{
+			name:   "NoNextBlock",
+			params: `{"height":"0x2"}`,
+			result: `{"blockVersion":"0x2","height":"0x2","nextBlockVersion":"0x2","pending":[],"revision":"0x15"}`,
+		},
+		{
+			name:    "InvalidHeight",
+			params:  `{"height":"height"}`,
+			errCode: jsonrpc.ErrorCodeInvalidParams,
+		},
+		{
+			name:    "UnknownField",
+			params:  `{"address":"hx0000000000000000000000000000000000000001"}`,
+			errCode: jsonrpc.ErrorCodeInvalidParams,
+		},
+		{
+			name:    "UnknownHeight",
+			params:  `{"height":"0x10"}`,
+			errCode: jsonrpc.ErrorCodeNotFound,
+		},
+		{
+			name:    "NoState",
+			params:  `{}`,
+			errCode: jsonrpc.ErrorCodeSystem,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, rpcErr := invokeRPC(t, mr, c, "icx_getRevisionInfo", tt.params)
+			if tt.errCode != 0 {
+				if assert.NotNil(t, rpcErr) {
+					assert.Equal(t, tt.errCode, rpcErr.Code)
+				}
+				return
+			}
+			assert.Nil(t, rpcErr)
+			assert.JSONEq(t, tt.result, string(res))
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package v3
 
 import (
+	"encoding/json"
+
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/server/jsonrpc"
 )
@@ -43,6 +46,19 @@ type ScoreAddressParam struct {
 	Height  jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_int"`
 }
 
+type ScoreStorageParam struct {
+	Address jsonrpc.Address `json:"address" validate:"required,t_addr"`
+	Height  jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_int"`
+	Prefix  common.HexBytes `json:"prefix,omitempty"`
+	Start   common.HexBytes `json:"start,omitempty"`
+	Limit   jsonrpc.HexInt  `json:"limit,omitempty" validate:"optional,t_int"`
+}
+
+type InspectTransactionParam struct {
+	Raw         common.HexBytes `json:"raw,omitempty"`
+	Transaction json.RawMessage `json:"transaction,omitempty"`
+}
+
 type TransactionHashParam struct {
 	Hash jsonrpc.HexBytes `json:"txHash" validate:"required,t_hash"`
 }
@@ -55,8 +71,25 @@ type TransactionParamForEstimate struct {
 	Timestamp   jsonrpc.HexInt  `json:"timestamp" validate:"required,t_int"`
 	NetworkID   jsonrpc.HexInt  `json:"nid" validate:"required,t_int"`
 	Nonce       jsonrpc.HexInt  `json:"nonce,omitempty" validate:"optional,t_int"`
-	DataType    string          `json:"dataType,omitempty" validate:"optional,call|deploy|message|deposit"`
+	DataType    string          `json:"dataType,omitempty" validate:"optional,call|deploy|message|deposit|evm"`
+	Data        interface{}     `json:"data,omitempty"`
+	AccessList  interface{}     `json:"accessList,omitempty"`
+	Expiration  jsonrpc.HexInt  `json:"expiration,omitempty" validate:"optional,t_int"`
+}
+
+type TransactionParamForFill struct {
+	Version     jsonrpc.HexInt  `json:"version,omitempty" validate:"optional,t_int"`
+	FromAddress jsonrpc.Address `json:"from" validate:"required,t_addr_eoa"`
+	ToAddress   jsonrpc.Address `json:"to" validate:"required,t_addr"`
+	Value       jsonrpc.HexInt  `json:"value,omitempty" validate:"optional,t_int"`
+	StepLimit   jsonrpc.HexInt  `json:"stepLimit,omitempty" validate:"optional,t_int"`
+	Timestamp   jsonrpc.HexInt  `json:"timestamp,omitempty" validate:"optional,t_int"`
+	NetworkID   jsonrpc.HexInt  `json:"nid,omitempty" validate:"optional,t_int"`
+	Nonce       jsonrpc.HexInt  `json:"nonce,omitempty" validate:"optional,t_int"`
+	DataType    string          `json:"dataType,omitempty" validate:"optional,call|deploy|message|deposit|evm"`
 	Data        interface{}     `json:"data,omitempty"`
+	AccessList  interface{}     `json:"accessList,omitempty"`
+	Expiration  jsonrpc.HexInt  `json:"expiration,omitempty" validate:"optional,t_int"`
 }
 
 type TransactionParam struct {
@@ -69,8 +102,45 @@ type TransactionParam struct {
 	NetworkID   jsonrpc.HexInt  `json:"nid" validate:"required,t_int"`
 	Nonce       jsonrpc.HexInt  `json:"nonce,omitempty" validate:"optional,t_int"`
 	Signature   string          `json:"signature" validate:"required,t_sig"`
-	DataType    string          `json:"dataType,omitempty" validate:"optional,call|deploy|message|deposit"`
+	DataType    string          `json:"dataType,omitempty" validate:"optional,call|deploy|message|deposit|evm"`
 	Data        interface{}     `json:"data,omitempty"`
+	AccessList  interface{}     `json:"accessList,omitempty"`
+	Expiration  jsonrpc.HexInt  `json:"expiration,omitempty" validate:"optional,t_int"`
+}
+
+type ValidatorStatsParam struct {
+	Address     jsonrpc.Address `json:"address" validate:"required,t_addr_eoa"`
+	StartHeight jsonrpc.HexInt  `json:"startHeight" validate:"required,t_int"`
+	EndHeight   jsonrpc.HexInt  `json:"endHeight" validate:"required,t_int"`
+}
+
+type TransactionsByAddressParam struct {
+	Address jsonrpc.Address `json:"address" validate:"required,t_addr"`
+	Cursor  jsonrpc.HexInt  `json:"cursor,omitempty" validate:"optional,t_int"`
+	Limit   jsonrpc.HexInt  `json:"limit,omitempty" validate:"optional,t_int"`
+}
+
+type TokenBalanceParam struct {
+	Token   jsonrpc.Address `json:"token" validate:"required,t_addr_score"`
+	Address jsonrpc.Address `json:"address" validate:"required,t_addr"`
+}
+
+type TokenBalancesParam struct {
+	Address jsonrpc.Address `json:"address" validate:"required,t_addr"`
+	Cursor  jsonrpc.HexInt  `json:"cursor,omitempty" validate:"optional,t_int"`
+	Limit   jsonrpc.HexInt  `json:"limit,omitempty" validate:"optional,t_int"`
+}
+
+type TokenTransfersParam struct {
+	Address jsonrpc.Address `json:"address" validate:"required,t_addr"`
+	Cursor  jsonrpc.HexInt  `json:"cursor,omitempty" validate:"optional,t_int"`
+	Limit   jsonrpc.HexInt  `json:"limit,omitempty" validate:"optional,t_int"`
+}
+
+type AccountActivityParam struct {
+	Address     jsonrpc.Address `json:"address" validate:"required,t_addr"`
+	StartHeight jsonrpc.HexInt  `json:"startHeight,omitempty" validate:"optional,t_int"`
+	EndHeight   jsonrpc.HexInt  `json:"endHeight,omitempty" validate:"optional,t_int"`
 }
 
 type DataHashParam struct {
@@ -103,3 +173,15 @@ type BTPMessagesParam struct {
 	Height    jsonrpc.HexInt `json:"height" validate:"required,t_int"`
 	NetworkId jsonrpc.HexInt `json:"networkID" validate:"required,t_int"`
 }
+
+type BTPNetworksParam struct {
+	Height        jsonrpc.HexInt `json:"height,omitempty" validate:"optional,t_int"`
+	NetworkTypeId jsonrpc.HexInt `json:"networkTypeID,omitempty" validate:"optional,t_int"`
+}
+
+type BTPMessageProofsParam struct {
+	NetworkId jsonrpc.HexInt `json:"networkID" validate:"required,t_int"`
+	From      jsonrpc.HexInt `json:"from" validate:"required,t_int"`
+	To        jsonrpc.HexInt `json:"to,omitempty" validate:"optional,t_int"`
+	Limit     jsonrpc.HexInt `json:"limit,omitempty" validate:"optional,t_int"`
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v3
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+type storageTestServiceManager struct {
+	module.ServiceManager
+	result  []byte
+	account module.Address
+	entries []module.StorageEntry
+}
+
+func (sm *storageTestServiceManager) GetStorageEntries(
+	result []byte, addr module.Address, prefix, start []byte, limit int,
+) ([]module.StorageEntry, []byte, error) {
+	if !bytes.Equal(result, sm.result) {
+		return nil, nil, errors.NotFoundError.New("NoState")
+	}
+	if !addr.Equal(sm.account) {
+		return nil, nil, errors.NotFoundError.Errorf("NoAccount(addr=%s)", addr)
+	}
+	entries := []module.StorageEntry{}
+	for _, e := range sm.entries {
+		if !bytes.HasPrefix(e.Key, prefix) || bytes.Compare(e.Key, start) < 0 {
+			continue
+		}
+		if len(entries) == limit {
+			return entries, e.Key, nil
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil, nil
+}
+
+func TestGetScoreStorage(t *testing.T) {
+	score := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	c := &rpcTestChain{
+		bm: &rpcTestBlockManager{blocks: []module.Block{
+			&rpcTestBlock{height: 0, result: []byte("r0")},
+			&rpcTestBlock{height: 1, result: []byte("r1")},
+		}},
+		sm: &storageTestServiceManager{
+			result:  []byte("r1"),
+			account: score,
+			entries: []module.StorageEntry{
+				{Key: []byte{0x01, 0x01}, Value: []byte{0x10}},
+				{Key: []byte{0x01, 0x02}, Value: []byte{0x20}},
+				{Key: []byte{0x02, 0x01}, Value: []byte{0x30}},
+			},
+		},
+	}
+	mr := DebugMethodRepository(rpcTestMetric)
+
+	tests := []struct {
+		name    string
+		params  string
+		result  string
+		errCode jsonrpc.ErrorCode
+	}{
+		{
+			name:   "All",
+			params: `{"address":"` + score.String() + `"}`,
+			result: `{"entries":[{"key":"0x0101","value":"0x10"},{"key":"0x0102","value":"0x20"},{"key":"0x0201","value":"0x30"}]}`,
+		},
+		{
+			name:   "Prefix",
+			params: `{"address":"` + score.String() + `","prefix":"0x02"}`,
+			result: `{"entries":[{"key":"0x0201","value":"0x30"}]}`,
+		},
+		{
+			name:   "Pagination",
+			params: `{"address":"` + score.String() + `","limit":"0x1"}`,
+			result: `{"entries":[{"key":"0x0101","value":"0x10"}],"next":"0x0102"}`,
+		},
+		{
+			name:   "Start",
+			params: `{"address":"` + score.String() + `","start":"0x0102","limit":"0x1"}`,
+			result: `{"entries":[{"key":"0x0102","value":"0x20"}],"next":"0x0201"}`,
+		},
+		{
+			name:    "NoAddress",
+			params:  `{"height":"0x1"}`,
+			errCode: jsonrpc.ErrorCodeInvalidParams,
+		},
+		{
+			name:    "InvalidAddress",
+			params:  `{"address":"cx01"}`,
+			errCode: jsonrpc.ErrorCodeInvalidParams,
+		},
+		{
+			name:    "ZeroLimit",
+			params:  `{"address":"` + score.String() + `","limit":"0x0"}`,
+			errCode: jsonrpc.ErrorCodeInvalidParams,
+		},
+		{
+			name:    "TooLargeLimit",
+			params:  `{"address":"` + score.String() + `","limit":"0x3e9"}`,
+			errCode: jsonrpc.ErrorCodeInvalidParams,
+		},
+		{
+			name:    "UnknownAddress",
+			params:  `{"address":"cx0000000000000000000000000000000000000002"}`,
+			errCode: jsonrpc.ErrorCodeNotFound,
+		},
+		{
+			name:    "UnknownHeight",
+			params:  `{"address":"` + score.String() + `","height":"0x2"}`,
+			errCode: jsonrpc.ErrorCodeNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, rpcErr := invokeRPC(t, mr, c, "debug_getScoreStorage", tt.params)
+			if tt.errCode != 0 {
+				if assert.NotNil(t, rpcErr) {
+					assert.Equal(t, tt.errCode, rpcErr.Code)
+				}
+				return
+			}
+			assert.Nil(t, rpcErr)
+			assert.JSONEq(t, tt.result, string(res))
+		})
+	}
+}
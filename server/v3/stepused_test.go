@@ -0,0 +1,123 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v3
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+type stepBlock struct {
+	module.Block
+	height int64
+	result []byte
+}
+
+func (b *stepBlock) Height() int64 {
+	return b.height
+}
+
+func (b *stepBlock) Result() []byte {
+	return b.result
+}
+
+type stepBlockManager struct {
+	module.BlockManager
+	blocks map[int64]module.Block
+}
+
+func (bm *stepBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	if blk, ok := bm.blocks[height]; ok {
+		return blk, nil
+	}
+	return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+}
+
+type stepReceipt struct {
+	module.Receipt
+	cumulative int64
+}
+
+func (r *stepReceipt) CumulativeStepUsed() *big.Int {
+	return big.NewInt(r.cumulative)
+}
+
+type stepReceiptList struct {
+	module.ReceiptList
+	receipts []module.Receipt
+	gets     int
+}
+
+func (l *stepReceiptList) Get(i int) (module.Receipt, error) {
+	l.gets += 1
+	if i < 0 || i >= len(l.receipts) {
+		return nil, errors.NotFoundError.New("NoReceipt")
+	}
+	return l.receipts[i], nil
+}
+
+type stepServiceManager struct {
+	module.ServiceManager
+	results map[string]*stepReceiptList
+}
+
+func (sm *stepServiceManager) ReceiptListFromResult(result []byte, g module.TransactionGroup) (module.ReceiptList, error) {
+	if rl, ok := sm.results[string(result)]; ok && g == module.TransactionGroupNormal {
+		return rl, nil
+	}
+	return nil, errors.NotFoundError.New("NoResult")
+}
+
+func TestFillStepUsed(t *testing.T) {
+	rl := &stepReceiptList{
+		receipts: []module.Receipt{
+			&stepReceipt{cumulative: 100},
+			&stepReceipt{cumulative: 250},
+		},
+	}
+	bm := &stepBlockManager{
+		blocks: map[int64]module.Block{
+			11: &stepBlock{height: 11, result: []byte("r11")},
+			21: &stepBlock{height: 21, result: []byte("pruned")},
+		},
+	}
+	sm := &stepServiceManager{
+		results: map[string]*stepReceiptList{"r11": rl},
+	}
+	txs := []interface{}{"tx1", "tx2"}
+
+	// the last receipt has the total
+	blockJson := map[string]interface{}{"confirmed_transaction_list": txs}
+	assert.NoError(t, fillStepUsed(blockJson, bm, sm, &stepBlock{height: 10}))
+	assert.Equal(t, big.NewInt(250), blockJson["step_used"])
+	assert.Equal(t, 1, rl.gets)
+
+	// not executed yet
+	blockJson = map[string]interface{}{"confirmed_transaction_list": txs}
+	assert.NoError(t, fillStepUsed(blockJson, bm, sm, &stepBlock{height: 11}))
+	assert.NotContains(t, blockJson, "step_used")
+
+	// receipts are not available
+	blockJson = map[string]interface{}{"confirmed_transaction_list": txs}
+	assert.NoError(t, fillStepUsed(blockJson, bm, sm, &stepBlock{height: 20}))
+	assert.NotContains(t, blockJson, "step_used")
+}
@@ -21,6 +21,7 @@ func RegisterValidationRule(v *jsonrpc.Validator) {
 	v.RegisterValidation("deploy", isDeploy)
 	v.RegisterValidation("message", isMessage)
 	v.RegisterValidation("deposit", isDeposit)
+	v.RegisterValidation("evm", isEVM)
 
 	// validate : CallParam.Data, TransactionParam.Data
 	v.RegisterStructValidation(DataParamValidation, CallParam{}, TransactionParam{})
@@ -43,6 +44,10 @@ func isDeposit(fl validator.FieldLevel) bool {
 	return fl.Field().String() == contract.DataTypeDeposit
 }
 
+func isEVM(fl validator.FieldLevel) bool {
+	return fl.Field().String() == contract.DataTypeEVM
+}
+
 func DataParamValidation(sl validator.StructLevel) {
 	switch sl.Current().Interface().(type) {
 	case CallParam:
@@ -82,6 +87,10 @@ func DataParamValidation(sl validator.StructLevel) {
 				} else {
 					sl.ReportError(txParam.Data, "Data", "", "data", "")
 				}
+			case contract.DataTypeEVM:
+				if data, ok := txParam.Data.(string); !ok || !hexString.MatchString(data) {
+					sl.ReportError(txParam.Data, "Data", "", "data", "")
+				}
 			}
 		}
 	}
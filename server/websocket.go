@@ -214,6 +214,19 @@ func (wss *wsSession) RunLoop(ech chan<- error) {
 	}
 }
 
+// RunMessageLoop is like RunLoop, but it delivers messages from the client
+// to mch until done is closed.
+func (wss *wsSession) RunMessageLoop(ech chan<- error, mch chan<- []byte, done <-chan struct{}) {
+	wss.lock.Lock()
+	defer wss.lock.Unlock()
+
+	if wss.c != nil {
+		go messageLoop(wss.c, ech, mch, done)
+	} else {
+		ech <- errors.New("AlreadyClosed")
+	}
+}
+
 const DefaultWSMaxSession = 10
 
 type WSResponse struct {
@@ -248,3 +261,18 @@ func readLoop(c WebSocketConn, ech chan<- error) {
 		}
 	}
 }
+
+func messageLoop(c WebSocketConn, ech chan<- error, mch chan<- []byte, done <-chan struct{}) {
+	for {
+		_, msg, err := c.ReadMessage()
+		if err != nil {
+			ech <- err
+			break
+		}
+		select {
+		case mch <- msg:
+		case <-done:
+			return
+		}
+	}
+}
@@ -171,9 +171,14 @@ type testChain struct {
 	module.Chain
 	bm module.BlockManager
 	sm module.ServiceManager
+	cs module.Consensus
 	gs module.GenesisStorage
 }
 
+func (c *testChain) Consensus() module.Consensus {
+	return c.cs
+}
+
 func (c *testChain) BlockManager() module.BlockManager {
 	return c.bm
 }
@@ -286,6 +291,11 @@ func (b *testBlock) Result() []byte {
 	return []byte(b.result)
 }
 
+func (b *testBlock) MarshalHeader(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "header:%d", b.height)
+	return err
+}
+
 func (b *testBlock) LogsBloom() module.LogsBloom {
 	if b.lb != nil {
 		return b.lb
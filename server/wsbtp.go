@@ -81,6 +81,10 @@ loop:
 				}
 
 				cs := chain.Consensus()
+				if cs == nil {
+					wm.logger.Infof("no consensus for BTP blocks (height=%d)\n", h)
+					break loop
+				}
 				nw, err := sm.BTPNetworkFromResult(blk.Result(), br.NetworkId.Value)
 				if !nw.Open() {
 					wm.logger.Infof("network is closed (height=%d, err:%+v)\n", h, err)
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+// HeaderRequest requests headers of finalized blocks from the height. Relays
+// resume the stream with the height next to the last one they processed, so
+// no header is missed across reconnections.
+type HeaderRequest struct {
+	Height    common.HexInt64  `json:"height"`
+	NetworkID *common.HexInt64 `json:"networkID,omitempty"`
+	hn        HeaderNotification
+}
+
+// HeaderNotification has the header of the block and the hash of commit
+// votes for it without transactions. BTPHeader is the header of the BTP
+// block of the network in the block if the network is requested.
+type HeaderNotification struct {
+	Height    common.HexInt64 `json:"height"`
+	Hash      common.HexBytes `json:"hash"`
+	Header    string          `json:"header"`
+	VotesHash common.HexBytes `json:"votesHash"`
+	BTPHeader string          `json:"btpHeader,omitempty"`
+}
+
+func (wm *wsSessionManager) RunHeaderSession(ctx echo.Context) error {
+	var hr HeaderRequest
+	wss, err := wm.initSession(ctx, &hr)
+	if err != nil {
+		return err
+	}
+	defer wm.StopSession(wss)
+
+	bm := wss.chain.BlockManager()
+	cs := wss.chain.Consensus()
+	if bm == nil || cs == nil {
+		_ = wss.response(int(jsonrpc.ErrorCodeServer), "Stopped")
+		return nil
+	}
+
+	h := hr.Height.Value
+	if gh := wss.chain.GenesisStorage().Height(); gh > h {
+		_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams),
+			fmt.Sprintf("given height(%d) is lower than genesis height(%d)", h, gh))
+		return nil
+	}
+
+	_ = wss.response(0, "")
+
+	ech := make(chan error, 1)
+	wss.RunLoop(ech)
+
+	var bch <-chan module.Block
+	buf := bytes.NewBuffer(nil)
+loop:
+	for {
+		bch, err = bm.WaitForBlock(h)
+		if err != nil {
+			break loop
+		}
+		select {
+		case err = <-ech:
+			break loop
+		case blk, ok := <-bch:
+			if !ok {
+				break loop
+			}
+			buf.Reset()
+			if err = blk.MarshalHeader(buf); err != nil {
+				break loop
+			}
+			var votes module.CommitVoteSet
+			if votes, err = cs.GetVotesByHeight(h); err != nil {
+				break loop
+			}
+			hr.hn.Height = common.HexInt64{Value: h}
+			hr.hn.Hash = blk.ID()
+			hr.hn.Header = base64.StdEncoding.EncodeToString(buf.Bytes())
+			hr.hn.VotesHash = votes.Hash()
+			hr.hn.BTPHeader = ""
+			if hr.NetworkID != nil {
+				// blocks without BTP block of the network have no header
+				btpBlock, _, err := cs.GetBTPBlockHeaderAndProof(
+					blk, hr.NetworkID.Value, module.FlagBTPBlockHeader)
+				if err == nil {
+					hr.hn.BTPHeader = base64.StdEncoding.EncodeToString(btpBlock.HeaderBytes())
+				}
+			}
+			if err = wss.WriteJSON(&hr.hn); err != nil {
+				wm.logger.Infof("fail to write json HeaderNotification err:%+v\n", err)
+				break loop
+			}
+		}
+		h++
+	}
+	wm.logger.Warnf("%+v\n", err)
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+type testConsensus struct {
+	module.Consensus
+}
+
+func (cs *testConsensus) GetVotesByHeight(height int64) (module.CommitVoteSet, error) {
+	return &testCommitVoteSet{height: height}, nil
+}
+
+type testCommitVoteSet struct {
+	module.CommitVoteSet
+	height int64
+}
+
+func (vs *testCommitVoteSet) Hash() []byte {
+	return []byte(fmt.Sprintf("votes:%d", vs.height))
+}
+
+func TestWsSessionManager_RunHeaderSession(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+
+	connCh := make(chan *testWebSocketConn, 1)
+	upgrader := newTestWebsocketUpgrader(func(ctx echo.Context, conn *testWebSocketConn) {
+		err := conn.clientWriteJSON(map[string]interface{}{
+			"height": "0x2",
+		})
+		assert.NoError(t, err)
+		connCh <- conn
+	})
+	wm := newWSSessionManagerWithUpgrader(logger, 10, upgrader)
+
+	s1 := make(chan string)
+	chain := newTestChain(0,
+		func(h int64) (getBlockFunc, error) {
+			return func() module.Block {
+				_, _ = <-s1
+				return &testBlock{
+					height: h,
+					result: "empty",
+				}
+			}, nil
+		},
+		blockReceipts{},
+	)
+	chain.(*testChain).cs = &testConsensus{}
+	go wm.RunHeaderSession(newTestContext(chain))
+
+	conn := <-connCh
+	bs, err := conn.clientRead()
+	assert.NoError(t, err)
+	var res WSResponse
+	assert.NoError(t, json.Unmarshal(bs, &res))
+	assert.Equal(t, 0, res.Code)
+
+	for h := int64(2); h < 4; h++ {
+		s1 <- "OK"
+		bs, err := conn.clientRead()
+		assert.NoError(t, err)
+		var hn HeaderNotification
+		assert.NoError(t, json.Unmarshal(bs, &hn))
+		assert.Equal(t, HeaderNotification{
+			Height:    common.HexInt64{Value: h},
+			Hash:      testHeightToBlockID(h),
+			Header:    base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("header:%d", h))),
+			VotesHash: []byte(fmt.Sprintf("votes:%d", h)),
+		}, hn)
+	}
+
+	conn.Close()
+	close(s1)
+	wm.StopAllSessions()
+}
@@ -0,0 +1,327 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+// TransactionRequest registers hashes of transactions to be notified of.
+// Clients may send more requests in the session to register more hashes.
+// TTL is the duration of the registrations in milli-second.
+type TransactionRequest struct {
+	Hashes []common.HexBytes `json:"hashes"`
+	TTL    *common.HexInt64  `json:"ttl,omitempty"`
+}
+
+// Status of TransactionNotification. Registered transactions get included
+// first, then finalized or failed with their results. A registration ends
+// with finalized, failed, dropped or expired.
+const (
+	TxStatusIncluded  = "included"
+	TxStatusFinalized = "finalized"
+	TxStatusFailed    = "failed"
+	TxStatusDropped   = "dropped"
+	TxStatusExpired   = "expired"
+)
+
+const (
+	DefaultWSTxTTL        = 10 * time.Minute
+	MaxWSTxTTL            = time.Hour
+	MaxWSTxRegistrations  = 1000
+	wsTxExpireCheckPeriod = time.Second
+)
+
+type TransactionNotification struct {
+	Hash      common.HexBytes  `json:"hash"`
+	Status    string           `json:"status"`
+	Height    *common.HexInt64 `json:"height,omitempty"`
+	BlockHash common.HexBytes  `json:"blockHash,omitempty"`
+	TxIndex   *common.HexInt32 `json:"txIndex,omitempty"`
+}
+
+type txRegistration struct {
+	hash   []byte
+	expire time.Time
+	pooled bool
+
+	// location of the transaction if it's included
+	height    int64
+	blockHash []byte
+	index     int
+}
+
+type txSession struct {
+	*wsSession
+	bm   module.BlockManager
+	sm   module.ServiceManager
+	regs map[string]*txRegistration
+}
+
+// register adds registrations of the request, and returns new ones.
+func (ts *txSession) register(req *TransactionRequest) ([]*txRegistration, *jsonrpc.Error) {
+	ttl := DefaultWSTxTTL
+	if req.TTL != nil {
+		if req.TTL.Value <= 0 {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidTTL(ttl=%d)", req.TTL.Value)
+		}
+		ttl = time.Duration(req.TTL.Value) * time.Millisecond
+		if ttl > MaxWSTxTTL {
+			ttl = MaxWSTxTTL
+		}
+	}
+	expire := time.Now().Add(ttl)
+
+	var added []*txRegistration
+	requested := make(map[string]bool)
+	for _, hash := range req.Hashes {
+		if len(hash) != 32 {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidHash(hash=%s)", hash)
+		}
+		if _, ok := ts.regs[string(hash)]; !ok && !requested[string(hash)] {
+			added = append(added, &txRegistration{hash: hash, height: -1})
+		}
+		requested[string(hash)] = true
+	}
+	if len(ts.regs)+len(added) > MaxWSTxRegistrations {
+		return nil, jsonrpc.ErrorLackOfResource.Errorf(
+			"TooManyRegistrations(max=%d)", MaxWSTxRegistrations)
+	}
+	for _, r := range added {
+		ts.regs[string(r.hash)] = r
+	}
+	for _, hash := range req.Hashes {
+		ts.regs[string(hash)].expire = expire
+	}
+	return added, nil
+}
+
+func (ts *txSession) notify(r *txRegistration, status string) error {
+	n := &TransactionNotification{
+		Hash:   r.hash,
+		Status: status,
+	}
+	if r.height >= 0 {
+		n.Height = &common.HexInt64{Value: r.height}
+		n.BlockHash = r.blockHash
+		n.TxIndex = &common.HexInt32{Value: int32(r.index)}
+	}
+	return ts.WriteJSON(n)
+}
+
+func (ts *txSession) finish(r *txRegistration, status string) error {
+	delete(ts.regs, string(r.hash))
+	return ts.notify(r, status)
+}
+
+func (ts *txSession) include(r *txRegistration, blk module.Block, index int) error {
+	r.height = blk.Height()
+	r.blockHash = blk.ID()
+	r.index = index
+	return ts.notify(r, TxStatusIncluded)
+}
+
+// check looks up the transaction of the registration, and notifies the
+// changes of it.
+func (ts *txSession) check(r *txRegistration) error {
+	txInfo, err := ts.bm.GetTransactionInfo(r.hash)
+	if errors.NotFoundError.Equals(err) {
+		if ts.sm.HasTransaction(r.hash) {
+			r.pooled = true
+			return nil
+		}
+		if !r.pooled {
+			// it may be sent later
+			return nil
+		}
+		// it may be included after the lookup
+		txInfo, err = ts.bm.GetTransactionInfo(r.hash)
+		if errors.NotFoundError.Equals(err) {
+			return ts.finish(r, TxStatusDropped)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if r.height < 0 {
+		if err = ts.include(r, txInfo.Block(), txInfo.Index()); err != nil {
+			return err
+		}
+	}
+	rct, err := txInfo.GetReceipt()
+	if block.ResultNotFinalizedError.Equals(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if rct.Status() == module.StatusSuccess {
+		return ts.finish(r, TxStatusFinalized)
+	}
+	return ts.finish(r, TxStatusFailed)
+}
+
+func (ts *txSession) includeTransactions(blk module.Block, txs module.TransactionList) error {
+	if txs == nil {
+		return nil
+	}
+	for it := txs.Iterator(); it.Has(); it.Next() {
+		tx, index, err := it.Get()
+		if err != nil {
+			return err
+		}
+		if r, ok := ts.regs[string(tx.ID())]; ok && r.height < 0 {
+			if err = ts.include(r, blk, index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// onBlock notifies registered transactions included in the block, and
+// checks others for results and drops.
+func (ts *txSession) onBlock(blk module.Block) error {
+	if len(ts.regs) == 0 {
+		return nil
+	}
+	if err := ts.includeTransactions(blk, blk.NormalTransactions()); err != nil {
+		return err
+	}
+	if err := ts.includeTransactions(blk, blk.PatchTransactions()); err != nil {
+		return err
+	}
+	for _, r := range ts.regs {
+		if r.height < 0 && !r.pooled {
+			continue
+		}
+		if err := ts.check(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ts *txSession) expire(now time.Time) error {
+	for _, r := range ts.regs {
+		if now.After(r.expire) {
+			if err := ts.finish(r, TxStatusExpired); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ts *txSession) checkAll(regs []*txRegistration) error {
+	for _, r := range regs {
+		if err := ts.check(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleMessage handles more requests from the client in the session.
+func (ts *txSession) handleMessage(msg []byte) error {
+	var req TransactionRequest
+	jd := json.NewDecoder(bytes.NewBuffer(msg))
+	jd.DisallowUnknownFields()
+	if err := jd.Decode(&req); err != nil {
+		return ts.response(int(jsonrpc.ErrorCodeJsonParse), "bad transaction request")
+	}
+	added, rerr := ts.register(&req)
+	if rerr != nil {
+		return ts.response(int(rerr.Code), rerr.Message)
+	}
+	if err := ts.response(0, ""); err != nil {
+		return err
+	}
+	return ts.checkAll(added)
+}
+
+func (wm *wsSessionManager) RunTransactionSession(ctx echo.Context) error {
+	var req TransactionRequest
+	wss, err := wm.initSession(ctx, &req)
+	if err != nil {
+		return err
+	}
+	defer wm.StopSession(wss)
+
+	bm := wss.chain.BlockManager()
+	sm := wss.chain.ServiceManager()
+	if bm == nil || sm == nil {
+		_ = wss.response(int(jsonrpc.ErrorCodeServer), "Stopped")
+		return nil
+	}
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		_ = wss.response(int(jsonrpc.ErrorCodeServer), "Stopped")
+		return nil
+	}
+
+	ts := &txSession{
+		wsSession: wss,
+		bm:        bm,
+		sm:        sm,
+		regs:      make(map[string]*txRegistration),
+	}
+	added, rerr := ts.register(&req)
+	if rerr != nil {
+		_ = wss.response(int(rerr.Code), rerr.Message)
+		return nil
+	}
+	_ = wss.response(0, "")
+	if err = ts.checkAll(added); err != nil {
+		wm.logger.Infof("fail to notify transactions err:%+v\n", err)
+		return nil
+	}
+
+	ech := make(chan error, 1)
+	mch := make(chan []byte)
+	done := make(chan struct{})
+	defer close(done)
+	wss.RunMessageLoop(ech, mch, done)
+
+	ticker := time.NewTicker(wsTxExpireCheckPeriod)
+	defer ticker.Stop()
+
+	h := last.Height() + 1
+	var bch <-chan module.Block
+loop:
+	for {
+		if bch == nil {
+			if bch, err = bm.WaitForBlock(h); err != nil {
+				break loop
+			}
+		}
+		select {
+		case err = <-ech:
+			break loop
+		case msg := <-mch:
+			err = ts.handleMessage(msg)
+		case blk, ok := <-bch:
+			if !ok {
+				break loop
+			}
+			bch = nil
+			h++
+			err = ts.onBlock(blk)
+		case now := <-ticker.C:
+			err = ts.expire(now)
+		}
+		if err != nil {
+			wm.logger.Infof("fail to notify transactions err:%+v\n", err)
+			break loop
+		}
+	}
+	wm.logger.Warnf("%+v\n", err)
+	return nil
+}
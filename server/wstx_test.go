@@ -0,0 +1,257 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+type testTransaction struct {
+	module.Transaction
+	id []byte
+}
+
+func (tx *testTransaction) ID() []byte {
+	return tx.id
+}
+
+type testTransactionList struct {
+	module.TransactionList
+	txs []module.Transaction
+}
+
+type testTransactionIterator struct {
+	txs []module.Transaction
+	idx int
+}
+
+func (it *testTransactionIterator) Has() bool {
+	return it.idx < len(it.txs)
+}
+
+func (it *testTransactionIterator) Next() error {
+	it.idx++
+	return nil
+}
+
+func (it *testTransactionIterator) Get() (module.Transaction, int, error) {
+	return it.txs[it.idx], it.idx, nil
+}
+
+func (l *testTransactionList) Iterator() module.TransactionIterator {
+	return &testTransactionIterator{txs: l.txs}
+}
+
+type testTxBlock struct {
+	testBlock
+	txs []module.Transaction
+}
+
+func (b *testTxBlock) Height() int64 {
+	return b.height
+}
+
+func (b *testTxBlock) NormalTransactions() module.TransactionList {
+	return &testTransactionList{txs: b.txs}
+}
+
+func (b *testTxBlock) PatchTransactions() module.TransactionList {
+	return nil
+}
+
+type testTxReceipt struct {
+	module.Receipt
+	status module.Status
+}
+
+func (r *testTxReceipt) Status() module.Status {
+	return r.status
+}
+
+type testTxInfo struct {
+	module.TransactionInfo
+	blk     module.Block
+	index   int
+	receipt module.Receipt
+}
+
+func (ti *testTxInfo) Block() module.Block {
+	return ti.blk
+}
+
+func (ti *testTxInfo) Index() int {
+	return ti.index
+}
+
+func (ti *testTxInfo) GetReceipt() (module.Receipt, error) {
+	if ti.receipt == nil {
+		return nil, block.ResultNotFinalizedError.New("NotFinalized")
+	}
+	return ti.receipt, nil
+}
+
+type testTxBlockManager struct {
+	testBlockManager
+	lock  sync.Mutex
+	infos map[string]module.TransactionInfo
+}
+
+func (bm *testTxBlockManager) GetLastBlock() (module.Block, error) {
+	return &testTxBlock{testBlock: testBlock{height: 1}}, nil
+}
+
+func (bm *testTxBlockManager) GetTransactionInfo(id []byte) (module.TransactionInfo, error) {
+	bm.lock.Lock()
+	defer bm.lock.Unlock()
+	if ti, ok := bm.infos[string(id)]; ok {
+		return ti, nil
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (bm *testTxBlockManager) setInfo(id []byte, ti module.TransactionInfo) {
+	bm.lock.Lock()
+	defer bm.lock.Unlock()
+	bm.infos[string(id)] = ti
+}
+
+type testTxServiceManager struct {
+	testServiceManager
+	lock sync.Mutex
+	pool map[string]bool
+}
+
+func (sm *testTxServiceManager) HasTransaction(id []byte) bool {
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+	return sm.pool[string(id)]
+}
+
+func (sm *testTxServiceManager) setPooled(id []byte, pooled bool) {
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+	sm.pool[string(id)] = pooled
+}
+
+func testTxHash(b byte) common.HexBytes {
+	hash := make([]byte, 32)
+	hash[31] = b
+	return hash
+}
+
+func TestWsSessionManager_RunTransactionSession(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+
+	connCh := make(chan *testWebSocketConn, 1)
+	upgrader := newTestWebsocketUpgrader(func(ctx echo.Context, conn *testWebSocketConn) {
+		err := conn.clientWriteJSON(map[string]interface{}{
+			"hashes": []common.HexBytes{testTxHash(1), testTxHash(2), testTxHash(3)},
+		})
+		assert.NoError(t, err)
+		connCh <- conn
+	})
+	wm := newWSSessionManagerWithUpgrader(logger, 10, upgrader)
+
+	blocks := make(chan *testTxBlock)
+	bm := &testTxBlockManager{
+		testBlockManager: testBlockManager{
+			fetcher: func(h int64) (getBlockFunc, error) {
+				return func() module.Block {
+					return <-blocks
+				}, nil
+			},
+		},
+		infos: make(map[string]module.TransactionInfo),
+	}
+	sm := &testTxServiceManager{pool: make(map[string]bool)}
+	chain := &testChain{bm: bm, sm: sm, gs: &testGenesisStorage{}}
+
+	// 1 is in the pool, 2 is unknown, and 3 failed already
+	sm.setPooled(testTxHash(1), true)
+	blk1 := &testTxBlock{testBlock: testBlock{height: 1}}
+	bm.setInfo(testTxHash(3), &testTxInfo{
+		blk:     blk1,
+		receipt: &testTxReceipt{status: module.StatusOutOfStep},
+	})
+	go wm.RunTransactionSession(newTestContext(chain))
+
+	conn := <-connCh
+	readJSON := func(v interface{}) {
+		bs, err := conn.clientRead()
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(bs, v))
+	}
+	expect := func(hash common.HexBytes, status string, height int64) {
+		var tn TransactionNotification
+		readJSON(&tn)
+		assert.Equal(t, hash, tn.Hash)
+		assert.Equal(t, status, tn.Status)
+		if height >= 0 && assert.NotNil(t, tn.Height) {
+			assert.EqualValues(t, height, tn.Height.Value)
+			assert.Equal(t, common.HexBytes(testHeightToBlockID(height)), tn.BlockHash)
+		}
+	}
+	var res WSResponse
+	readJSON(&res)
+	assert.Equal(t, 0, res.Code)
+	expect(testTxHash(3), TxStatusIncluded, 1)
+	expect(testTxHash(3), TxStatusFailed, 1)
+
+	// 1 is included, then finalized with the next block
+	blk2 := &testTxBlock{
+		testBlock: testBlock{height: 2},
+		txs:       []module.Transaction{&testTransaction{id: testTxHash(1)}},
+	}
+	info1 := &testTxInfo{blk: blk2}
+	bm.setInfo(testTxHash(1), info1)
+	sm.setPooled(testTxHash(1), false)
+	blocks <- blk2
+	expect(testTxHash(1), TxStatusIncluded, 2)
+
+	bm.setInfo(testTxHash(1), &testTxInfo{
+		blk:     blk2,
+		receipt: &testTxReceipt{status: module.StatusSuccess},
+	})
+	blocks <- &testTxBlock{testBlock: testBlock{height: 3}}
+	expect(testTxHash(1), TxStatusFinalized, 2)
+
+	// more registrations in the session
+	sm.setPooled(testTxHash(4), true)
+	assert.NoError(t, conn.clientWriteJSON(map[string]interface{}{
+		"hashes": []common.HexBytes{testTxHash(4)},
+	}))
+	readJSON(&res)
+	assert.Equal(t, 0, res.Code)
+
+	sm.setPooled(testTxHash(4), false)
+	blocks <- &testTxBlock{testBlock: testBlock{height: 4}}
+	expect(testTxHash(4), TxStatusDropped, -1)
+
+	assert.NoError(t, conn.clientWriteJSON(map[string]interface{}{
+		"hashes": []common.HexBytes{{0x01}},
+	}))
+	readJSON(&res)
+	assert.NotEqual(t, 0, res.Code)
+
+	assert.NoError(t, conn.clientWriteJSON(map[string]interface{}{
+		"hashes": []common.HexBytes{testTxHash(5)},
+		"ttl":    "0x1",
+	}))
+	readJSON(&res)
+	assert.Equal(t, 0, res.Code)
+	expect(testTxHash(5), TxStatusExpired, -1)
+
+	conn.Close()
+	wm.StopAllSessions()
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"encoding/binary"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	// AccountBloomEpoch is the number of heights sharing a bloom filter.
+	AccountBloomEpoch = 10000
+
+	// MaxAccountBloomEpochs is the maximum number of epochs for a query.
+	MaxAccountBloomEpochs = 500
+
+	accountBloomBits   = 1 << 17
+	accountBloomHashes = 4
+)
+
+// accountBloom is the bloom filter of the accounts for the epoch. Start and
+// End are the range of heights recorded continuously, and other heights of
+// the epoch are not covered by the filter.
+type accountBloom struct {
+	Start int64
+	End   int64
+	Bits  []byte
+}
+
+func accountBloomKey(epoch int64) []byte {
+	return codec.BC.MustMarshalToBytes(epoch)
+}
+
+func accountBloomIndexes(addr []byte) [accountBloomHashes]uint32 {
+	var idx [accountBloomHashes]uint32
+	h := crypto.SHA3Sum256(addr)
+	for i := range idx {
+		idx[i] = binary.BigEndian.Uint32(h[i*4:]) % accountBloomBits
+	}
+	return idx
+}
+
+func (b *accountBloom) add(addr []byte) {
+	for _, i := range accountBloomIndexes(addr) {
+		b.Bits[i/8] |= 1 << (i % 8)
+	}
+}
+
+func (b *accountBloom) contains(addr []byte) bool {
+	for _, i := range accountBloomIndexes(addr) {
+		if b.Bits[i/8]&(1<<(i%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func getAccountBloom(bk db.Bucket, epoch int64) (*accountBloom, error) {
+	bs, err := bk.Get(accountBloomKey(epoch))
+	if err != nil || bs == nil {
+		return nil, err
+	}
+	b := new(accountBloom)
+	if _, err = codec.BC.UnmarshalFromBytes(bs, b); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidAccountBloom")
+	}
+	if len(b.Bits) != accountBloomBits/8 {
+		return nil, errors.CriticalFormatError.Errorf(
+			"InvalidAccountBloom(bits=%d)", len(b.Bits)*8)
+	}
+	return b, nil
+}
+
+// recordAccountActivity adds the accounts to the filter for the epoch of
+// the height. If heights before it are not recorded, the filter covers
+// from the height.
+func recordAccountActivity(dbase db.Database, height int64, accounts [][]byte) error {
+	bk, err := dbase.GetBucket(db.AccountBloomByEpoch)
+	if err != nil {
+		return err
+	}
+	epoch := height / AccountBloomEpoch
+	b, err := getAccountBloom(bk, epoch)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		b = &accountBloom{
+			Start: height,
+			Bits:  make([]byte, accountBloomBits/8),
+		}
+	} else if height != b.End+1 {
+		b.Start = height
+	}
+	b.End = height
+	for _, addr := range accounts {
+		b.add(addr)
+	}
+	bs, err := codec.BC.MarshalToBytes(b)
+	if err != nil {
+		return err
+	}
+	return bk.Set(accountBloomKey(epoch), bs)
+}
+
+type HeightRange struct {
+	Start int64
+	End   int64
+}
+
+func (r *HeightRange) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"start": intconv.FormatInt(r.Start),
+		"end":   intconv.FormatInt(r.End),
+	}
+}
+
+// AccountActivity is the ranges of heights where the account may be
+// involved in transactions. Heights not recorded by the node are
+// included always.
+type AccountActivity struct {
+	Address     module.Address
+	StartHeight int64
+	EndHeight   int64
+	Ranges      []*HeightRange
+}
+
+func (a *AccountActivity) addRange(start, end int64) {
+	if start < a.StartHeight {
+		start = a.StartHeight
+	}
+	if end > a.EndHeight {
+		end = a.EndHeight
+	}
+	if start > end {
+		return
+	}
+	if n := len(a.Ranges); n > 0 && a.Ranges[n-1].End+1 >= start {
+		a.Ranges[n-1].End = end
+		return
+	}
+	a.Ranges = append(a.Ranges, &HeightRange{Start: start, End: end})
+}
+
+func (a *AccountActivity) ToJSON() map[string]interface{} {
+	ranges := make([]interface{}, 0, len(a.Ranges))
+	for _, r := range a.Ranges {
+		ranges = append(ranges, r.ToJSON())
+	}
+	return map[string]interface{}{
+		"address":     a.Address,
+		"startHeight": intconv.FormatInt(a.StartHeight),
+		"endHeight":   intconv.FormatInt(a.EndHeight),
+		"ranges":      ranges,
+	}
+}
+
+// GetAccountActivity returns candidate ranges of heights from start to end
+// where the account was the sender or the receiver of transactions.
+func GetAccountActivity(dbase db.Database, addr module.Address, start, end int64) (*AccountActivity, error) {
+	if start < 0 || start > end {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidRange(start=%d,end=%d)", start, end)
+	}
+	if end/AccountBloomEpoch-start/AccountBloomEpoch >= MaxAccountBloomEpochs {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"TooLargeRange(start=%d,end=%d,max=%d)",
+			start, end, MaxAccountBloomEpochs*AccountBloomEpoch)
+	}
+	bk, err := dbase.GetBucket(db.AccountBloomByEpoch)
+	if err != nil {
+		return nil, err
+	}
+	activity := &AccountActivity{
+		Address:     addr,
+		StartHeight: start,
+		EndHeight:   end,
+		Ranges:      []*HeightRange{},
+	}
+	for epoch := start / AccountBloomEpoch; epoch <= end/AccountBloomEpoch; epoch++ {
+		first := epoch * AccountBloomEpoch
+		last := first + AccountBloomEpoch - 1
+		b, err := getAccountBloom(bk, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			activity.addRange(first, last)
+			continue
+		}
+		activity.addRange(first, b.Start-1)
+		if b.contains(addr.Bytes()) {
+			activity.addRange(b.Start, b.End)
+		}
+		activity.addRange(b.End+1, last)
+	}
+	return activity, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestAccountActivity(t *testing.T) {
+	dbase := db.NewMapDB()
+	addr1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	addr2 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000002")
+	addr3 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000003")
+
+	// epoch 0 is recorded from 100, epoch 1 and 3 are recorded fully,
+	// and epoch 2 is not recorded.
+	for h := int64(100); h < 2*AccountBloomEpoch; h++ {
+		var accounts [][]byte
+		if h == 150 {
+			accounts = [][]byte{addr1.Bytes(), addr2.Bytes()}
+		}
+		assert.NoError(t, recordAccountActivity(dbase, h, accounts))
+	}
+	for h := int64(3 * AccountBloomEpoch); h < 4*AccountBloomEpoch; h++ {
+		var accounts [][]byte
+		if h == 3*AccountBloomEpoch+1 {
+			accounts = [][]byte{addr2.Bytes()}
+		}
+		assert.NoError(t, recordAccountActivity(dbase, h, accounts))
+	}
+
+	end := int64(4*AccountBloomEpoch - 1)
+	a, err := GetAccountActivity(dbase, addr1, 0, end)
+	assert.NoError(t, err)
+	assert.Equal(t, []*HeightRange{
+		{0, AccountBloomEpoch - 1},
+		{2 * AccountBloomEpoch, 3*AccountBloomEpoch - 1},
+	}, a.Ranges)
+
+	a, err = GetAccountActivity(dbase, addr2, 0, end)
+	assert.NoError(t, err)
+	assert.Equal(t, []*HeightRange{
+		{0, AccountBloomEpoch - 1},
+		{2 * AccountBloomEpoch, end},
+	}, a.Ranges)
+
+	a, err = GetAccountActivity(dbase, addr3, 10, end)
+	assert.NoError(t, err)
+	assert.Equal(t, []*HeightRange{
+		{10, 99},
+		{2 * AccountBloomEpoch, 3*AccountBloomEpoch - 1},
+	}, a.Ranges)
+
+	// a gap makes the filter cover from the height after it
+	assert.NoError(t, recordAccountActivity(dbase, 4*AccountBloomEpoch+10, nil))
+	assert.NoError(t, recordAccountActivity(dbase, 4*AccountBloomEpoch+20, nil))
+	a, err = GetAccountActivity(dbase, addr3, 4*AccountBloomEpoch, 4*AccountBloomEpoch+30)
+	assert.NoError(t, err)
+	assert.Equal(t, []*HeightRange{
+		{4 * AccountBloomEpoch, 4*AccountBloomEpoch + 19},
+		{4*AccountBloomEpoch + 21, 4*AccountBloomEpoch + 30},
+	}, a.Ranges)
+
+	_, err = GetAccountActivity(dbase, addr1, 10, 5)
+	assert.Error(t, err)
+	_, err = GetAccountActivity(dbase, addr1, 0, MaxAccountBloomEpochs*AccountBloomEpoch)
+	assert.Error(t, err)
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"encoding/binary"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	AddressIndexDefaultLimit = 20
+	AddressIndexMaxLimit     = 100
+)
+
+// AddressTransaction is the locator of the transaction involving the
+// address. Index is the index in the group of the transaction, and seq is
+// the sequence number of it for the address.
+type AddressTransaction struct {
+	Height int64
+	Group  module.TransactionGroup
+	Index  int
+	TxHash []byte
+
+	seq int64
+}
+
+func (t *AddressTransaction) ToJSON() map[string]interface{} {
+	jso := map[string]interface{}{
+		"seq":     intconv.FormatInt(t.seq),
+		"height":  intconv.FormatInt(t.Height),
+		"txIndex": intconv.FormatInt(int64(t.Index)),
+		"txHash":  common.HexPre(t.TxHash),
+	}
+	if t.Group == module.TransactionGroupPatch {
+		jso["patch"] = "0x1"
+	}
+	return jso
+}
+
+// addressIndexEntry is the address involved in the transaction at the index.
+type addressIndexEntry struct {
+	addr []byte
+	tx   *AddressTransaction
+}
+
+func addressIndexCountKey(addr []byte) []byte {
+	return addr
+}
+
+func addressIndexKey(addr []byte, seq int64) []byte {
+	key := make([]byte, len(addr)+8)
+	copy(key, addr)
+	binary.BigEndian.PutUint64(key[len(addr):], uint64(seq))
+	return key
+}
+
+func getAddressIndexCount(bk db.Bucket, addr []byte) (int64, error) {
+	bs, err := bk.Get(addressIndexCountKey(addr))
+	if err != nil || bs == nil {
+		return 0, err
+	}
+	var cnt int64
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &cnt); err != nil {
+		return 0, errors.CriticalFormatError.Wrap(err, "InvalidAddressIndexCount")
+	}
+	return cnt, nil
+}
+
+func getAddressTransaction(bk db.Bucket, addr []byte, seq int64) (*AddressTransaction, error) {
+	bs, err := bk.Get(addressIndexKey(addr, seq))
+	if err != nil {
+		return nil, err
+	}
+	if bs == nil {
+		return nil, errors.NotFoundError.Errorf(
+			"AddressTransactionNotFound(addr=%#x,seq=%d)", addr, seq)
+	}
+	tx := &AddressTransaction{seq: seq}
+	if _, err := codec.BC.UnmarshalFromBytes(bs, tx); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidAddressTransaction")
+	}
+	return tx, nil
+}
+
+// storeAddressIndex appends the entries of the height to the index.
+// Entries of the height or higher ones recorded before (ex: the chain is
+// reset to the lower height) are replaced.
+func storeAddressIndex(dbase db.Database, height int64, entries []*addressIndexEntry) error {
+	bk, err := dbase.GetBucket(db.TransactionsByAddress)
+	if err != nil {
+		return err
+	}
+	counts := make(map[string]int64)
+	for _, e := range entries {
+		cnt, ok := counts[string(e.addr)]
+		if !ok {
+			if cnt, err = getAddressIndexCount(bk, e.addr); err != nil {
+				return err
+			}
+			for cnt > 0 {
+				last, err := getAddressTransaction(bk, e.addr, cnt-1)
+				if err != nil {
+					return err
+				}
+				if last.Height < height {
+					break
+				}
+				cnt -= 1
+			}
+		}
+		bs, err := codec.BC.MarshalToBytes(e.tx)
+		if err != nil {
+			return err
+		}
+		if err := bk.Set(addressIndexKey(e.addr, cnt), bs); err != nil {
+			return err
+		}
+		counts[string(e.addr)] = cnt + 1
+	}
+	for addr, cnt := range counts {
+		bs, err := codec.BC.MarshalToBytes(cnt)
+		if err != nil {
+			return err
+		}
+		if err := bk.Set(addressIndexCountKey([]byte(addr)), bs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddressTransactions is a page of the transactions involving the address
+// in descending order. Next is the cursor for the next page, or -1 if it's
+// the last page.
+type AddressTransactions struct {
+	Address      module.Address
+	Total        int64
+	Transactions []*AddressTransaction
+	Next         int64
+}
+
+func (a *AddressTransactions) ToJSON() map[string]interface{} {
+	txs := make([]interface{}, 0, len(a.Transactions))
+	for _, tx := range a.Transactions {
+		txs = append(txs, tx.ToJSON())
+	}
+	jso := map[string]interface{}{
+		"address":      a.Address,
+		"total":        intconv.FormatInt(a.Total),
+		"transactions": txs,
+	}
+	if a.Next >= 0 {
+		jso["next"] = intconv.FormatInt(a.Next)
+	}
+	return jso
+}
+
+// GetTransactionsByAddress returns up to limit transactions involving the
+// address from the cursor to the old ones. Negative cursor means the latest.
+func GetTransactionsByAddress(dbase db.Database, addr module.Address, cursor int64, limit int) (*AddressTransactions, error) {
+	if limit <= 0 {
+		limit = AddressIndexDefaultLimit
+	}
+	if limit > AddressIndexMaxLimit {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"TooLargeLimit(limit=%d,max=%d)", limit, AddressIndexMaxLimit)
+	}
+	bk, err := dbase.GetBucket(db.TransactionsByAddress)
+	if err != nil {
+		return nil, err
+	}
+	cnt, err := getAddressIndexCount(bk, addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if cursor >= cnt {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidCursor(cursor=%d,total=%d)", cursor, cnt)
+	}
+	if cursor < 0 {
+		cursor = cnt - 1
+	}
+	result := &AddressTransactions{
+		Address:      addr,
+		Total:        cnt,
+		Transactions: make([]*AddressTransaction, 0, limit),
+	}
+	seq := cursor
+	for ; seq >= 0 && len(result.Transactions) < limit; seq-- {
+		tx, err := getAddressTransaction(bk, addr.Bytes(), seq)
+		if err != nil {
+			return nil, err
+		}
+		result.Transactions = append(result.Transactions, tx)
+	}
+	result.Next = seq
+	return result, nil
+}
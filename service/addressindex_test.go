@@ -0,0 +1,100 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestAddressIndex(t *testing.T) {
+	dbase := db.NewMapDB()
+	addr1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	addr2 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000002")
+
+	for h := int64(1); h <= 5; h++ {
+		var entries []*addressIndexEntry
+		for i := 0; i < 2; i++ {
+			tx := &AddressTransaction{Height: h, Group: module.TransactionGroupNormal, Index: i, TxHash: []byte{byte(h), byte(i)}}
+			entries = append(entries, &addressIndexEntry{addr1.Bytes(), tx})
+			if h%2 == 0 {
+				entries = append(entries, &addressIndexEntry{addr2.Bytes(), tx})
+			}
+		}
+		assert.NoError(t, storeAddressIndex(dbase, h, entries))
+	}
+
+	txs, err := GetTransactionsByAddress(dbase, addr1, -1, 3)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, txs.Total)
+	assert.Len(t, txs.Transactions, 3)
+	assert.EqualValues(t, 5, txs.Transactions[0].Height)
+	assert.EqualValues(t, 1, txs.Transactions[0].Index)
+	assert.EqualValues(t, 4, txs.Transactions[2].Height)
+	assert.EqualValues(t, 6, txs.Next)
+
+	txs, err = GetTransactionsByAddress(dbase, addr1, 1, 3)
+	assert.NoError(t, err)
+	assert.Len(t, txs.Transactions, 2)
+	assert.Equal(t, []byte{1, 0}, txs.Transactions[1].TxHash)
+	assert.EqualValues(t, -1, txs.Next)
+	assert.NotContains(t, txs.ToJSON(), "next")
+
+	txs, err = GetTransactionsByAddress(dbase, addr2, -1, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, txs.Total)
+	assert.Len(t, txs.Transactions, 4)
+
+	// recording again from the lower height replaces higher ones
+	assert.NoError(t, storeAddressIndex(dbase, 4, []*addressIndexEntry{
+		{addr1.Bytes(), &AddressTransaction{Height: 4, Index: 0, TxHash: []byte{4, 9}}},
+	}))
+	txs, err = GetTransactionsByAddress(dbase, addr1, -1, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 7, txs.Total)
+	assert.Equal(t, []byte{4, 9}, txs.Transactions[0].TxHash)
+	assert.EqualValues(t, 3, txs.Transactions[1].Height)
+
+	// patch and normal transactions at the same index are distinguished
+	assert.NoError(t, storeAddressIndex(dbase, 6, []*addressIndexEntry{
+		{addr1.Bytes(), &AddressTransaction{Height: 6, Group: module.TransactionGroupPatch, Index: 0, TxHash: []byte{6, 8}}},
+		{addr1.Bytes(), &AddressTransaction{Height: 6, Group: module.TransactionGroupNormal, Index: 0, TxHash: []byte{6, 9}}},
+	}))
+	txs, err = GetTransactionsByAddress(dbase, addr1, -1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, module.TransactionGroupNormal, txs.Transactions[0].Group)
+	assert.NotContains(t, txs.Transactions[0].ToJSON(), "patch")
+	assert.Equal(t, module.TransactionGroupPatch, txs.Transactions[1].Group)
+	assert.Equal(t, "0x1", txs.Transactions[1].ToJSON()["patch"])
+	assert.Equal(t, txs.Transactions[0].Index, txs.Transactions[1].Index)
+
+	// no transactions
+	txs, err = GetTransactionsByAddress(dbase, common.MustNewAddressFromString("hx0000000000000000000000000000000000000003"), -1, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, txs.Total)
+	assert.Len(t, txs.Transactions, 0)
+
+	_, err = GetTransactionsByAddress(dbase, addr1, 9, 0)
+	assert.Error(t, err)
+	_, err = GetTransactionsByAddress(dbase, addr1, -1, AddressIndexMaxLimit+1)
+	assert.Error(t, err)
+}
@@ -52,6 +52,9 @@ type (
 		GetEventLogs(r txresult.Receipt)
 		GetBTPMessages(r txresult.Receipt)
 		EnterReadOnlyMode()
+		EnterContract(addr module.Address) error
+		WarmUp(addr module.Address, keys ...[]byte)
+		AccessStorage(addr module.Address, key []byte)
 		SetFrameCodeID(id []byte)
 		GetLastEIDOf(id []byte) int
 		NewExecution() int
@@ -65,6 +68,7 @@ type (
 		ClearRedeemLogs()
 		DoIOTask(func())
 		ResultFlags() ResultFlag
+		InternalCalls() []*txresult.InternalCall
 	}
 	callResultMessage struct {
 		status   error
@@ -103,6 +107,13 @@ type callContext struct {
 	waiter chan interface{}
 	calls  int64
 
+	// accessed accounts and storage keys in the transaction
+	accessed map[string]bool
+
+	// internal calls in the transaction (nil if it's not recorded)
+	internalCalls []*txresult.InternalCall
+	recordCalls   bool
+
 	timer   <-chan time.Time
 	ioStart *time.Time
 	ioTime  time.Duration
@@ -123,6 +134,9 @@ func NewCallContext(ctx Context, limit *big.Int, isQuery bool) CallContext {
 		nextFID: firstFID,
 		frame:   NewFrame(nil, nil, limit, isQuery, frameLogger),
 
+		accessed:    make(map[string]bool),
+		recordCalls: !isQuery && ctx.RecordInternalCalls(),
+
 		waiter: make(chan interface{}, 8),
 		log:    traceLogger,
 	}
@@ -151,6 +165,17 @@ func (cc *callContext) pushFrame(handler ContractHandler, limit *big.Int) *callF
 	frame.fid = cc.nextFID
 	cc.nextFID += 1
 	cc.frame = frame
+	if cc.recordCalls {
+		if ich, ok := handler.(interCallHandler); ok {
+			if call := ich.internalCall(); call != nil {
+				// frame for the transaction is at depth 1
+				call.Depth = frame.depth - 1
+				call.Status = module.StatusUnknownFailure
+				frame.internalCall = call
+				cc.internalCalls = append(cc.internalCalls, call)
+			}
+		}
+	}
 	return frame
 }
 
@@ -328,11 +353,18 @@ func (cc *callContext) waitResult(target *callFrame) (error, *codec.TypedObj, mo
 			switch msg := msg.(type) {
 			case *callResultMessage:
 				status := cc.validateStatus(msg.status)
-				cc.DeductSteps(msg.stepUsed)
-				if cc.handleResult(target, status, msg.result, msg.addr) {
+				result := msg.result
+				if !cc.DeductSteps(msg.stepUsed) && status == nil &&
+					cc.Revision().Has(module.ColdAccessCharge) {
+					// steps for cold access are charged while the executor
+					// is running, so the sum can exceed the limit.
+					status = scoreresult.OutOfStepError.New("OutOfStepForColdAccess")
+					result = nil
+				}
+				if cc.handleResult(target, status, result, msg.addr) {
 					continue
 				}
-				return status, msg.result, msg.addr
+				return status, result, msg.addr
 			case *callRequestMessage:
 				frame := cc.pushFrame(msg.handler, msg.stepLimit)
 				if done, status, result, addr := cc.runFrame(frame); done {
@@ -379,8 +411,8 @@ func (cc *callContext) cleanUpFrames(target *callFrame, err error) {
 }
 
 func (cc *callContext) handleResult(target *callFrame, status error, result *codec.TypedObj, addr module.Address) bool {
-	if code := errors.CodeOf(status); code == scoreresult.TimeoutError ||
-		code == errors.ExecutionFailError || errors.IsCriticalCode(code) {
+	if errors.IsCode(status, scoreresult.TimeoutError, errors.ExecutionFailError) ||
+		errors.IsCriticalCode(errors.CodeOf(status)) {
 		cc.cleanUpFrames(target, status)
 		return false
 	}
@@ -389,6 +421,9 @@ func (cc *callContext) handleResult(target *callFrame, status error, result *cod
 	if current == nil {
 		return false
 	}
+	if current.internalCall != nil {
+		current.internalCall.Status, _ = scoreresult.StatusOf(status)
+	}
 
 	if ach, ok := current.handler.(AsyncContractHandler); ok {
 		ach.Dispose()
@@ -562,6 +597,94 @@ func (cc *callContext) EnterReadOnlyMode() {
 	cc.frame.enterReadOnlyMode(cc)
 }
 
+// EnterContract checks the call policy for executing the contract in the
+// current frame. It records the address for checking reentrancy of
+// following calls.
+func (cc *callContext) EnterContract(addr module.Address) error {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	frame := cc.frame
+	frame.addr = addr
+	if cc.Revision().Has(module.ColdAccessCharge) && cc.markAccessed(accountAccessKey(addr)) {
+		if !cc.applyStepsInLock(state.StepTypeColdAccount, 1) {
+			return scoreresult.OutOfStepError.Errorf(
+				"OutOfStepForColdAccount(addr=%s)", addr)
+		}
+	}
+	policy := cc.CallPolicy()
+	if policy.MaxDepth > 0 && frame.depth > policy.MaxDepth {
+		return scoreresult.StackOverflowError.Errorf(
+			"CallDepthExceeded(depth=%d,max=%d)", frame.depth, policy.MaxDepth)
+	}
+	if policy.MaxReadOnlyDepth > 0 && frame.isReadOnly {
+		if depth := frame.readOnlyDepth(); depth > policy.MaxReadOnlyDepth {
+			return scoreresult.StackOverflowError.Errorf(
+				"ReadOnlyCallDepthExceeded(depth=%d,max=%d)",
+				depth, policy.MaxReadOnlyDepth)
+		}
+	}
+	switch policy.Reentrancy {
+	case state.ReentrancyReadOnly:
+		if !frame.isReadOnly && frame.isReentrant(addr) {
+			return scoreresult.IllegalReentrancyError.Errorf(
+				"ReentrantWriteCall(addr=%s)", addr)
+		}
+	case state.ReentrancyDeny:
+		if frame.isReentrant(addr) {
+			return scoreresult.IllegalReentrancyError.Errorf(
+				"ReentrantCall(addr=%s)", addr)
+		}
+	}
+	return nil
+}
+
+func accountAccessKey(addr module.Address) string {
+	return "a" + string(addr.Bytes())
+}
+
+func storageAccessKey(addr module.Address, key []byte) string {
+	return "s" + string(addr.Bytes()) + string(key)
+}
+
+// markAccessed marks the key as accessed, and returns true if it's the
+// first access in the transaction.
+func (cc *callContext) markAccessed(key string) bool {
+	if cc.accessed[key] {
+		return false
+	}
+	cc.accessed[key] = true
+	return true
+}
+
+// WarmUp marks the account and storage keys of it as accessed without
+// charging any steps.
+func (cc *callContext) WarmUp(addr module.Address, keys ...[]byte) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	cc.markAccessed(accountAccessKey(addr))
+	for _, key := range keys {
+		cc.markAccessed(storageAccessKey(addr, key))
+	}
+}
+
+// AccessStorage charges steps for the first access of the storage key
+// in the transaction. It doesn't return failure, because it's called
+// while the executor is running. Exceeding the limit is checked with
+// the result of the execution.
+func (cc *callContext) AccessStorage(addr module.Address, key []byte) {
+	if !cc.Revision().Has(module.ColdAccessCharge) {
+		return
+	}
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	if cc.markAccessed(storageAccessKey(addr, key)) {
+		cc.applyStepsInLock(state.StepTypeColdStorage, 1)
+	}
+}
+
 func (cc *callContext) SetFrameCodeID(id []byte) {
 	cc.lock.Lock()
 	defer cc.lock.Unlock()
@@ -628,3 +751,9 @@ func (cc *callContext) GetCustomLogs(name string, ot reflect.Type) CustomLogs {
 func (cc *callContext) ResultFlags() ResultFlag {
 	return cc.resultFlags
 }
+
+func (cc *callContext) InternalCalls() []*txresult.InternalCall {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	return cc.internalCalls
+}
@@ -126,6 +126,10 @@ func (c *dummyChain) TransactionTimeout() time.Duration {
 	return 5 * time.Second
 }
 
+func (c *dummyChain) RecordInternalCalls() bool {
+	return false
+}
+
 func newDummyChain() module.Chain {
 	return &dummyChain{}
 }
@@ -20,6 +20,8 @@ type eventLog struct {
 
 type callFrame struct {
 	parent      *callFrame
+	depth       int
+	addr        module.Address
 	fid         int
 	eid         int
 	code        string
@@ -34,6 +36,8 @@ type callFrame struct {
 	code2EID    map[string]int
 	logsMap     map[string]CustomLogs
 	feePayers   FeePayerInfo
+
+	internalCall *txresult.InternalCall
 }
 
 func NewFrame(p *callFrame, h ContractHandler, l *big.Int, ro bool, logger *trace.Logger) *callFrame {
@@ -47,6 +51,9 @@ func NewFrame(p *callFrame, h ContractHandler, l *big.Int, ro bool, logger *trac
 		fid:        baseFID,
 		log:        logger,
 	}
+	if p != nil {
+		frame.depth = p.depth + 1
+	}
 	frame.eventLogs.Init()
 	frame.btpMessages.Init()
 	return frame
@@ -133,6 +140,27 @@ func (f *callFrame) enterReadOnlyMode(cc *callContext) {
 	}
 }
 
+// readOnlyDepth returns the number of nested frames in read-only mode
+// including the frame.
+func (f *callFrame) readOnlyDepth() int {
+	depth := 0
+	for ptr := f; ptr != nil && ptr.parent != nil && ptr.isReadOnly; ptr = ptr.parent {
+		depth += 1
+	}
+	return depth
+}
+
+// isReentrant returns whether the contract of the address is already in
+// the call stack.
+func (f *callFrame) isReentrant(addr module.Address) bool {
+	for ptr := f.parent; ptr != nil; ptr = ptr.parent {
+		if ptr.addr != nil && ptr.addr.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *callFrame) getLastEIDOf(id []byte) int {
 	code := string(id)
 	for ptr := f; ptr != nil; ptr = ptr.parent {
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package contract
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+)
+
+func TestCallFrame_DepthAndReentrancy(t *testing.T) {
+	addr1 := common.MustNewAddressFromString("cx01")
+	addr2 := common.MustNewAddressFromString("cx02")
+
+	base := NewFrame(nil, nil, nil, false, nil)
+	f1 := NewFrame(base, nil, nil, false, nil)
+	f1.addr = addr1
+	f2 := NewFrame(f1, nil, nil, true, nil)
+	f2.addr = addr2
+	f3 := NewFrame(f2, nil, nil, false, nil)
+
+	assert.Equal(t, 0, base.depth)
+	assert.Equal(t, 1, f1.depth)
+	assert.Equal(t, 3, f3.depth)
+
+	assert.Equal(t, 0, f1.readOnlyDepth())
+	assert.Equal(t, 1, f2.readOnlyDepth())
+	assert.True(t, f3.isReadOnly)
+	assert.Equal(t, 2, f3.readOnlyDepth())
+
+	assert.False(t, f1.isReentrant(addr1))
+	assert.True(t, f3.isReentrant(addr1))
+	assert.True(t, f3.isReentrant(addr2))
+	assert.False(t, f2.isReentrant(addr2))
+	assert.False(t, f3.isReentrant(common.MustNewAddressFromString("cx03")))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/icon-project/goloop/service/scoreresult"
 	"github.com/icon-project/goloop/service/state"
 	"github.com/icon-project/goloop/service/trace"
+	"github.com/icon-project/goloop/service/txresult"
 )
 
 type DataCallJSON struct {
@@ -98,6 +99,14 @@ func newCallHandlerWithParams(ch *CommonHandler, method string,
 	}
 }
 
+func (h *CallHandler) internalCall() *txresult.InternalCall {
+	call := h.CommonHandler.internalCall()
+	if call != nil {
+		call.Method = h.name
+	}
+	return call
+}
+
 func (h *CallHandler) prepareWorldContextAndAccount(ctx Context) (state.WorldContext, state.AccountState) {
 	lq := []state.LockRequest{
 		{string(h.To.ID()), state.AccountWriteLock},
@@ -216,6 +225,9 @@ func (h *CallHandler) DoExecuteAsync(cc CallContext, ch eeproxy.CallContext, sto
 	if c == nil || c.Status() != state.CSActive {
 		return scoreresult.New(module.StatusContractNotFound, "NotAContractAccount")
 	}
+	if c.EEType() == state.EVMEE {
+		return scoreresult.InvalidRequestError.Errorf("EVMContract(addr=%s)", h.To)
+	}
 	cc.SetContractInfo(&state.ContractInfo{Owner: h.as.ContractOwner()})
 	h.codeID = c.CodeID()
 	// Before we set the codeID, it gets the last frame.
@@ -233,6 +245,9 @@ func (h *CallHandler) DoExecuteAsync(cc CallContext, ch eeproxy.CallContext, sto
 	if err := h.ensureMethodAndParams(c.EEType()); err != nil {
 		return err
 	}
+	if err := cc.EnterContract(h.To); err != nil {
+		return err
+	}
 
 	if isSystem {
 		return h.invokeSystemMethod(cc, c)
@@ -419,6 +434,7 @@ func (h *CallHandler) EEType() state.EEType {
 
 func (h *CallHandler) GetValue(key []byte) ([]byte, error) {
 	if h.store != nil {
+		h.cc.AccessStorage(h.To, key)
 		var value []byte
 		var err error
 		h.cc.DoIOTask(func() {
@@ -442,6 +458,7 @@ func (h *CallHandler) SetValue(key []byte, value []byte) ([]byte, error) {
 			"DeleteValueInQuery")
 	}
 	if h.store != nil {
+		h.cc.AccessStorage(h.To, key)
 		var old []byte
 		var err error
 		h.cc.DoIOTask(func() {
@@ -465,6 +482,7 @@ func (h *CallHandler) DeleteValue(key []byte) ([]byte, error) {
 			"DeleteValueInQuery")
 	}
 	if h.store != nil {
+		h.cc.AccessStorage(h.To, key)
 		var old []byte
 		var err error
 		h.cc.DoIOTask(func() {
@@ -30,6 +30,7 @@ type Context interface {
 	PatchDecoder() module.PatchDecoder
 	TraceInfo() *module.TraceInfo
 	ChainID() int
+	RecordInternalCalls() bool
 	GetProperty(name string) interface{}
 	SetProperty(name string, value interface{})
 	GetEnabledEETypes() state.EETypes
@@ -145,6 +146,10 @@ func (c *context) ChainID() int {
 	return c.chain.CID()
 }
 
+func (c *context) RecordInternalCalls() bool {
+	return c.chain.RecordInternalCalls()
+}
+
 func (c *context) TransactionTimeout() time.Duration {
 	return c.chain.TransactionTimeout()
 }
@@ -4,12 +4,14 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/eeproxy"
 	"github.com/icon-project/goloop/service/state"
 	"github.com/icon-project/goloop/service/trace"
+	"github.com/icon-project/goloop/service/txresult"
 )
 
 const (
@@ -21,6 +23,7 @@ const (
 	CTypeCall
 	CTypePatch
 	CTypeDeposit
+	CTypeEVM
 )
 
 type (
@@ -84,6 +87,28 @@ func (h *CommonHandler) ApplyStepsForInterCall(cc CallContext) error {
 	return nil
 }
 
+// interCallHandler is implemented by handlers which can be used for calls
+// made by contracts.
+type interCallHandler interface {
+	internalCall() *txresult.InternalCall
+}
+
+// internalCall returns a new record of the call if the handler is created
+// for a call made by a contract. Otherwise, it returns nil.
+func (h *CommonHandler) internalCall() *txresult.InternalCall {
+	if !h.isInterCall {
+		return nil
+	}
+	call := &txresult.InternalCall{
+		From: *common.AddressToPtr(h.From),
+		To:   *common.AddressToPtr(h.To),
+	}
+	if h.Value != nil {
+		call.Value.Set(h.Value)
+	}
+	return call
+}
+
 func (h *CommonHandler) SetTraceLogger(logger *trace.Logger) {
 	h.Log = logger
 }
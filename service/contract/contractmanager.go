@@ -79,6 +79,7 @@ const (
 	DataTypeDeploy  = "deploy"
 	DataTypeDeposit = "deposit"
 	DataTypePatch   = "patch"
+	DataTypeEVM     = "evm"
 )
 
 func IsCallableDataType(dt *string) bool {
@@ -200,6 +201,8 @@ func (cm *contractManager) GetHandler(from, to module.Address, value *big.Int, c
 		return newPatchHandler(ch, data)
 	case CTypeDeposit:
 		return newDepositHandler(ch, data)
+	case CTypeEVM:
+		return newEVMHandler(ch, data)
 	}
 	return handler, nil
 }
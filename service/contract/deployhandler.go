@@ -27,6 +27,8 @@ type DeployHandler struct {
 	content        *ContentBytes
 	contentType    string
 	params         []byte
+	salt           []byte
+	saltErr        error
 	preDefinedAddr module.Address
 }
 
@@ -84,6 +86,26 @@ type DeployData struct {
 	ContentType string          `json:"contentType"`
 	Content     *ContentBytes   `json:"content"`
 	Params      json.RawMessage `json:"params"`
+	Salt        json.RawMessage `json:"salt,omitempty"`
+}
+
+// SaltBytes returns the salt of the deploy data. It's nil if there is no
+// salt. The salt is used only with module.DeployWithSalt, so it's parsed
+// on execution instead of parsing the data.
+func (d *DeployData) SaltBytes() ([]byte, error) {
+	if len(d.Salt) == 0 || string(d.Salt) == "null" {
+		return nil, nil
+	}
+	var salt common.HexBytes
+	if err := json.Unmarshal(d.Salt, &salt); err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrapf(err,
+			"InvalidSalt(salt=%s)", d.Salt)
+	}
+	if len(salt) > common.AddressSaltBytes {
+		return nil, scoreresult.InvalidParameterError.Errorf(
+			"InvalidSalt(len=%d)", len(salt))
+	}
+	return salt, nil
 }
 
 func newDeployHandler(
@@ -95,12 +117,15 @@ func newDeployHandler(
 		return nil, err
 	}
 	eeType, _ := state.EETypeFromContentType(deploy.ContentType)
+	salt, saltErr := deploy.SaltBytes()
 	return &DeployHandler{
 		CommonHandler: ch,
 		content:       deploy.Content,
 		contentType:   deploy.ContentType,
 		eeType:        eeType,
 		params:        deploy.Params,
+		salt:          salt,
+		saltErr:       saltErr,
 	}, nil
 }
 
@@ -132,6 +157,15 @@ func newDeployHandlerWithTypedObj(
 		return nil, scoreresult.InvalidParameterError.New("InvalidDeployContentType")
 	}
 
+	var salt []byte
+	var saltErr error
+	if saltAny := data["salt"]; saltAny != nil {
+		salt, ok = saltAny.([]byte)
+		if !ok || len(salt) > common.AddressSaltBytes {
+			salt, saltErr = nil, scoreresult.InvalidParameterError.New("InvalidDeploySalt")
+		}
+	}
+
 	paramsAny := data["params"]
 	var params []byte
 	if paramsAny != nil {
@@ -156,6 +190,8 @@ func newDeployHandlerWithTypedObj(
 		content:       &ContentBytes{Bytes: content},
 		contentType:   contentType,
 		params:        params,
+		salt:          salt,
+		saltErr:       saltErr,
 		eeType:        eeType,
 	}, nil
 }
@@ -260,6 +296,19 @@ func (h *DeployHandler) ExecuteSync(cc CallContext) (err error, ro *codec.TypedO
 	return h.DoExecuteSync(cc)
 }
 
+// deploySaltFor returns the salt of the deploy data for the revision. The
+// salt was ignored before module.DeployWithSalt, so it returns nil without
+// checking the salt for the revision.
+func (h *DeployHandler) deploySaltFor(rev module.Revision) ([]byte, error) {
+	if !rev.Has(module.DeployWithSalt) {
+		return nil, nil
+	}
+	if h.saltErr != nil {
+		return nil, h.saltErr
+	}
+	return h.salt, nil
+}
+
 func (h *DeployHandler) DoExecuteSync(cc CallContext) (error, *codec.TypedObj, module.Address) {
 	sysAs := cc.GetAccountState(state.SystemID)
 
@@ -270,6 +319,11 @@ func (h *DeployHandler) DoExecuteSync(cc CallContext) (error, *codec.TypedObj, m
 	}
 	salt := cc.NextTransactionSalt()
 
+	deploySalt, err := h.deploySaltFor(cc.Revision())
+	if err != nil {
+		return err, nil, nil
+	}
+
 	var contractID []byte
 	var as state.AccountState
 	if h.To.Equal(state.SystemAddress) {
@@ -280,11 +334,21 @@ func (h *DeployHandler) DoExecuteSync(cc CallContext) (error, *codec.TypedObj, m
 					"TargetMustBeContract(to=%s)", h.preDefinedAddr), nil, nil
 			}
 			contractID = h.preDefinedAddr.ID()
+		} else if deploySalt != nil {
+			contractID = common.NewContractAddressWithSalt(h.From, deploySalt, h.content.GetBytes()).ID()
 		} else {
 			contractID = genContractAddr(h.From, txInfo.Timestamp, txInfo.Nonce, salt)
 		}
 		as = cc.GetAccountState(contractID)
+		if deploySalt != nil && as.IsContract() {
+			return scoreresult.AccessDeniedError.Errorf(
+				"ContractExists(addr=%s)", common.NewContractAddress(contractID)), nil, nil
+		}
 	} else { // deploy for update
+		if deploySalt != nil {
+			return scoreresult.InvalidParameterError.Errorf(
+				"SaltForUpdate(to=%s)", h.To), nil, nil
+		}
 		if !h.To.IsContract() {
 			return scoreresult.InvalidParameterError.Errorf(
 				"TargetMustBeContract(to=%s)", h.To), nil, nil
@@ -316,7 +380,7 @@ func (h *DeployHandler) DoExecuteSync(cc CallContext) (error, *codec.TypedObj, m
 		return scoreresult.ErrAccessDenied, nil, nil
 	}
 
-	if !state.ValidateEEType(h.eeType) {
+	if !state.ValidateEEType(h.eeType) || h.eeType == state.EVMEE {
 		return scoreresult.InvalidParameterError.Errorf("InvalidContentType(ct=%s)",
 			h.contentType), nil, nil
 	}
@@ -363,6 +427,9 @@ func (h *DeployHandler) DoExecuteSync(cc CallContext) (error, *codec.TypedObj, m
 		if err := h2a.Delete(oldTx); err != nil {
 			return err, nil, nil
 		}
+		if err := RemovePendingAudit(sysAs, cc.Revision(), oldTx); err != nil {
+			return err, nil, nil
+		}
 	}
 
 	if h.eeType.NeedAudit() == false || cc.AuditEnabled() == false ||
@@ -374,6 +441,8 @@ func (h *DeployHandler) DoExecuteSync(cc CallContext) (error, *codec.TypedObj, m
 		if status != nil {
 			return status, nil, nil
 		}
+	} else if err := addPendingAudit(sysAs, cc.Revision(), deployID); err != nil {
+		return err, nil, nil
 	}
 
 	return nil, common.MustEncodeAny(scoreAddr), scoreAddr
@@ -417,6 +486,9 @@ func (h *AcceptHandler) ExecuteSync(cc CallContext) (err error, obj *codec.Typed
 	}
 	scoreAddr := value.Address()
 	h2a.Delete(h.txHash)
+	if err := RemovePendingAudit(sysAs, cc.Revision(), h.txHash); err != nil {
+		return err, nil, nil
+	}
 	scoreAs := cc.GetAccountState(scoreAddr.ID())
 
 	next := scoreAs.NextContract()
@@ -0,0 +1,70 @@
+package contract
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/module"
+)
+
+func TestParseDeployData_Salt(t *testing.T) {
+	long := `"0x` + string(bytes.Repeat([]byte("00"), 33)) + `"`
+	tests := []struct {
+		name string
+		salt string
+		ok   bool
+		len  int
+	}{
+		{"NoSalt", "", true, 0},
+		{"Null", "null", true, 0},
+		{"Valid", `"0x1234"`, true, 2},
+		{"NotHex", `"salt"`, false, 0},
+		{"NotString", `12`, false, 0},
+		{"TooLong", long, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"contentType":"application/zip","content":"0x00"`
+			if tt.salt != "" {
+				data += `,"salt":` + tt.salt
+			}
+			data += "}"
+
+			// salt doesn't make parsing fail, because it was ignored
+			// before module.DeployWithSalt.
+			deploy, err := ParseDeployData([]byte(data))
+			assert.NoError(t, err)
+
+			salt, err := deploy.SaltBytes()
+			if !tt.ok {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, salt, tt.len)
+		})
+	}
+}
+
+func TestDeployHandler_deploySaltFor(t *testing.T) {
+	salt := []byte{0x12, 0x34}
+	invalid := &DeployHandler{saltErr: assert.AnError}
+	valid := &DeployHandler{salt: salt}
+
+	rev := module.Revision(0)
+	s, err := invalid.deploySaltFor(rev)
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+	s, err = valid.deploySaltFor(rev)
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+
+	rev = module.DeployWithSalt
+	_, err = invalid.deploySaltFor(rev)
+	assert.Error(t, err)
+	s, err = valid.deploySaltFor(rev)
+	assert.NoError(t, err)
+	assert.Equal(t, salt, s)
+}
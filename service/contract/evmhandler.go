@@ -0,0 +1,417 @@
+package contract
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/evm"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/state"
+)
+
+const (
+	EVMLogEventName = "EVMLog"
+)
+
+// EVMLogSignature returns the signature of event logs generated by LOG
+// operations with the given number of topics. Topics are indexed, and the
+// last parameter is the data.
+func EVMLogSignature(topics int) string {
+	types := make([]string, topics+1)
+	for i := range types {
+		types[i] = "bytes"
+	}
+	return EVMLogEventName + "(" + strings.Join(types, ",") + ")"
+}
+
+// ParseEVMData returns input bytes of the transaction data for EVM.
+// It's a hex string of bytes prefixed with "0x".
+func ParseEVMData(data []byte) ([]byte, error) {
+	var input common.HexBytes
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrapf(err,
+			"InvalidEVMData(data=%s)", strconv.Quote(string(data)))
+	}
+	return input, nil
+}
+
+// EVMHandler executes EVM byte codes. If the target is SystemAddress, then
+// the input is used as init code of a new contract, and the returned code
+// is deployed as the code of the contract.
+//
+// EVM addresses are IDs of addresses. An ID is mapped to the contract
+// address if the account has a contract, otherwise to the EOA address.
+// Contracts created by transactions get addresses of other contracts, but
+// contracts created by CREATE and CREATE2 get addresses derived as Ethereum
+// does. The nonce for CREATE is kept in the storage of the creator.
+type EVMHandler struct {
+	*CommonHandler
+	input []byte
+
+	readOnly bool
+	// creating is set for contracts created by CREATE and CREATE2.
+	creating bool
+	// codeAddr is the contract providing the code for DELEGATECALL and
+	// CALLCODE. The code runs in the context of the target.
+	codeAddr module.Address
+
+	// set in ExecuteSync()
+	cc    CallContext
+	as    state.AccountState
+	depth int
+}
+
+func newEVMHandler(ch *CommonHandler, data []byte) (*EVMHandler, error) {
+	input, err := ParseEVMData(data)
+	if err != nil {
+		return nil, err
+	}
+	return &EVMHandler{
+		CommonHandler: ch,
+		input:         input,
+	}, nil
+}
+
+func (h *EVMHandler) Prepare(ctx Context) (state.WorldContext, error) {
+	lq := []state.LockRequest{
+		{ID: state.WorldIDStr, Lock: state.AccountWriteLock},
+	}
+	return ctx.GetFuture(lq), nil
+}
+
+func (h *EVMHandler) ExecuteSync(cc CallContext) (err error, ro *codec.TypedObj, addr module.Address) {
+	h.Log.TSystemf("EVM start from=%s to=%s value=%s input=%#x",
+		h.From, h.To, h.Value, h.input)
+	defer func() {
+		if err != nil {
+			h.Log.TSystemf("EVM done status=%v", err)
+		} else {
+			h.Log.TSystemf("EVM done status=%s steps=%s", module.StatusSuccess, cc.StepUsed())
+		}
+	}()
+
+	if !cc.EVMEnabled() || !cc.GetEnabledEETypes().Contains(state.EVMEE) {
+		return scoreresult.InvalidRequestError.New("EVMNotEnabled"), nil, nil
+	}
+	if h.readOnly {
+		cc.EnterReadOnlyMode()
+	}
+	h.cc = cc
+	if h.creating || h.To.Equal(state.SystemAddress) {
+		return h.create(cc)
+	}
+	return h.call(cc)
+}
+
+func (h *EVMHandler) transfer(cc CallContext) error {
+	if h.Value == nil || h.Value.Sign() == 0 {
+		return nil
+	}
+	if h.Value.Sign() < 0 {
+		return scoreresult.InvalidParameterError.Errorf(
+			"InvalidValue(value=%s)", h.Value)
+	}
+	if cc.ReadOnlyMode() {
+		return scoreresult.AccessDeniedError.New("TransferIsNotAllowed")
+	}
+	as1 := cc.GetAccountState(h.From.ID())
+	bal1 := as1.GetBalance()
+	if bal1.Cmp(h.Value) < 0 {
+		return scoreresult.ErrOutOfBalance
+	}
+	as1.SetBalance(new(big.Int).Sub(bal1, h.Value))
+	as2 := cc.GetAccountState(h.To.ID())
+	as2.SetBalance(new(big.Int).Add(as2.GetBalance(), h.Value))
+	h.Log.OnBalanceChange(module.Transfer, h.From, h.To, h.Value)
+	return nil
+}
+
+func (h *EVMHandler) create(cc CallContext) (error, *codec.TypedObj, module.Address) {
+	if h.isInterCall && !h.creating {
+		return scoreresult.InvalidRequestError.New("CreateInContract"), nil, nil
+	}
+	if cc.ReadOnlyMode() {
+		return scoreresult.AccessDeniedError.New("CreateInReadOnly"), nil, nil
+	}
+	txInfo := cc.TransactionInfo()
+	if txInfo == nil {
+		return scoreresult.UnknownFailureError.New("InvalidTransactionInfo"), nil, nil
+	}
+	if cc.DeployerWhiteListEnabled() && !cc.IsDeployer(txInfo.From.String()) {
+		h.Log.TSystemf("EVM not in whitelist from=%s", txInfo.From)
+		return scoreresult.ErrAccessDenied, nil, nil
+	}
+	salt := cc.NextTransactionSalt()
+	var contractID []byte
+	if h.creating {
+		contractID = h.To.ID()
+	} else {
+		contractID = genContractAddr(h.From, txInfo.Timestamp, txInfo.Nonce, salt)
+	}
+	scoreAddr := common.NewContractAddress(contractID)
+	h.as = cc.GetAccountState(contractID)
+	if !h.as.InitContractAccount(h.From) {
+		return scoreresult.AccessDeniedError.Errorf(
+			"ContractExists(addr=%s)", scoreAddr), nil, nil
+	}
+	h.To = scoreAddr
+	if err := h.transfer(cc); err != nil {
+		return err, nil, nil
+	}
+
+	code, err := h.execute(cc, h.input, nil)
+	if err != nil {
+		return err, common.MustEncodeAny(code), nil
+	}
+	if len(code) == 0 {
+		return scoreresult.InvalidParameterError.New("EmptyContractCode"), nil, nil
+	}
+	if !cc.ApplySteps(state.StepTypeContractCreate, 1) ||
+		!cc.ApplySteps(state.StepTypeContractSet, len(code)) {
+		return scoreresult.ErrOutOfStep, nil, nil
+	}
+	deployID := getIDWithSalt(txInfo.Hash, salt)
+	if _, err := h.as.DeployContract(code, state.EVMEE, state.CTAppEVM, nil, deployID); err != nil {
+		return err, nil, nil
+	}
+	if err := h.as.AcceptContract(deployID, deployID); err != nil {
+		return err, nil, nil
+	}
+	if cc.Revision().Has(module.ContractSetEvent) {
+		next := h.as.Contract()
+		cc.OnEvent(state.SystemAddress, [][]byte{
+			[]byte("ContractSet(Address,Address,str,bytes)"),
+			scoreAddr.Bytes(),
+			h.From.Bytes(),
+		}, [][]byte{
+			[]byte(next.EEType()),
+			next.CodeHash(),
+		})
+	}
+	return nil, common.MustEncodeAny(scoreAddr), scoreAddr
+}
+
+func (h *EVMHandler) call(cc CallContext) (error, *codec.TypedObj, module.Address) {
+	h.as = cc.GetAccountState(h.To.ID())
+	codeAddr := h.codeAddr
+	if codeAddr == nil {
+		if err := h.transfer(cc); err != nil {
+			return err, nil, nil
+		}
+		if !h.To.IsContract() {
+			return nil, nil, nil
+		}
+		codeAddr = h.To
+	}
+	c := cc.GetAccountState(codeAddr.ID()).ActiveContract()
+	if c == nil || c.EEType() != state.EVMEE {
+		return scoreresult.ContractNotFoundError.Errorf(
+			"NotEVMContract(addr=%s)", codeAddr), nil, nil
+	}
+	code, err := c.Code()
+	if err != nil {
+		return err, nil, nil
+	}
+	cc.SetFrameCodeID(c.CodeID())
+	if err := cc.EnterContract(codeAddr); err != nil {
+		return err, nil, nil
+	}
+	ret, err := h.execute(cc, code, h.input)
+	return err, common.MustEncodeAny(ret), nil
+}
+
+func (h *EVMHandler) execute(cc CallContext, code, input []byte) ([]byte, error) {
+	var gas uint64 = math.MaxUint64
+	if avail := cc.StepAvailable(); avail.IsUint64() {
+		gas = avail.Uint64()
+	}
+	origin := h.From
+	if txInfo := cc.TransactionInfo(); txInfo != nil {
+		origin = txInfo.From
+	}
+	env := &evm.Environment{
+		Address:     h.To.ID(),
+		Caller:      h.From.ID(),
+		Origin:      origin.ID(),
+		Value:       h.valueOrZero(),
+		Input:       input,
+		Code:        code,
+		ReadOnly:    cc.ReadOnlyMode(),
+		Depth:       h.depth,
+		BlockNumber: cc.BlockHeight(),
+		Timestamp:   cc.BlockTimeStamp(),
+		ChainID:     big.NewInt(int64(cc.ChainID())),
+		GasPrice:    cc.StepPrice(),
+		GasLimit:    gas,
+	}
+	ret, left, err := evm.Execute(env, h, gas)
+	cc.DeductSteps(new(big.Int).SetUint64(gas - left))
+	return ret, err
+}
+
+func (h *EVMHandler) valueOrZero() *big.Int {
+	if h.Value == nil {
+		return new(big.Int)
+	}
+	return h.Value
+}
+
+func (h *EVMHandler) addressOf(id []byte) module.Address {
+	if as := h.cc.GetAccountState(id); as.IsContract() {
+		return common.NewContractAddress(id)
+	}
+	return common.NewAccountAddress(id)
+}
+
+func (h *EVMHandler) GetStorage(key []byte) ([]byte, error) {
+	return h.as.GetValue(key)
+}
+
+func (h *EVMHandler) SetStorage(key []byte, value []byte) error {
+	if value == nil {
+		_, err := h.as.DeleteValue(key)
+		return err
+	}
+	_, err := h.as.SetValue(key, value)
+	return err
+}
+
+func (h *EVMHandler) GetBalance(id []byte) *big.Int {
+	return h.cc.GetAccountState(id).GetBalance()
+}
+
+func (h *EVMHandler) AddLog(topics [][]byte, data []byte) error {
+	indexed := make([][]byte, 0, len(topics)+1)
+	indexed = append(indexed, []byte(EVMLogSignature(len(topics))))
+	indexed = append(indexed, topics...)
+	h.cc.OnEvent(h.To, indexed, [][]byte{data})
+	return nil
+}
+
+// Call handles CALL, STATICCALL, DELEGATECALL and CALLCODE operations.
+// Contracts for other EE types can't be called, but coins can be transferred
+// to accounts.
+func (h *EVMHandler) Call(kind evm.CallKind, to []byte, value *big.Int, input []byte, gas uint64) ([]byte, uint64, error) {
+	addr := h.addressOf(to)
+	var handler ContractHandler
+	if kind == evm.CallDelegate || kind == evm.CallCode {
+		// CALLCODE doesn't transfer the value to itself, but it requires
+		// the balance as CALL does.
+		if kind == evm.CallCode && h.as.GetBalance().Cmp(value) < 0 {
+			return nil, gas, scoreresult.ErrOutOfBalance
+		}
+		if !addr.IsContract() {
+			return nil, gas, nil
+		}
+		from := h.To
+		if kind == evm.CallDelegate {
+			from = h.From
+		}
+		handler = &EVMHandler{
+			CommonHandler: NewCommonHandler(from, h.To, value, true, h.Log),
+			input:         input,
+			codeAddr:      addr,
+			depth:         h.depth + 1,
+		}
+		return h.callHandler(handler, gas)
+	}
+	ch := NewCommonHandler(h.To, addr, value, true, h.Log)
+	if addr.IsContract() {
+		handler = &EVMHandler{
+			CommonHandler: ch,
+			input:         input,
+			readOnly:      kind == evm.CallStatic,
+			depth:         h.depth + 1,
+		}
+	} else {
+		if value.Sign() == 0 {
+			return nil, gas, nil
+		}
+		if kind == evm.CallStatic {
+			return nil, 0, scoreresult.AccessDeniedError.New("TransferInReadOnly")
+		}
+		handler = newTransferHandler(ch)
+	}
+	return h.callHandler(handler, gas)
+}
+
+func (h *EVMHandler) callHandler(handler ContractHandler, gas uint64) ([]byte, uint64, error) {
+	status, used, result, _ := h.cc.Call(handler, new(big.Int).SetUint64(gas))
+	var left uint64
+	if used.IsUint64() && used.Uint64() < gas {
+		left = gas - used.Uint64()
+	}
+	var ret []byte
+	if result != nil {
+		if obj, err := common.DecodeAny(result); err == nil {
+			ret, _ = obj.([]byte)
+		}
+	}
+	return ret, left, status
+}
+
+// GetCode returns the code of the contract if it's an EVM contract.
+func (h *EVMHandler) GetCode(id []byte) ([]byte, error) {
+	as := h.cc.GetAccountState(id)
+	if !as.IsContract() {
+		return nil, nil
+	}
+	c := as.ActiveContract()
+	if c == nil || c.EEType() != state.EVMEE {
+		return nil, nil
+	}
+	return c.Code()
+}
+
+// Create handles CREATE and CREATE2 operations.
+func (h *EVMHandler) Create(value *big.Int, code []byte, salt []byte, gas uint64) ([]byte, []byte, uint64, error) {
+	var id []byte
+	if salt != nil {
+		id = evm.CreateAddress2(h.To.ID(), salt, code)
+	} else {
+		nonce, err := h.nextNonce()
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		id = evm.CreateAddress(h.To.ID(), nonce)
+	}
+	handler := &EVMHandler{
+		CommonHandler: NewCommonHandler(h.To, common.NewContractAddress(id), value, true, h.Log),
+		input:         code,
+		creating:      true,
+		depth:         h.depth + 1,
+	}
+	ret, left, err := h.callHandler(handler, gas)
+	if err != nil {
+		return nil, ret, left, err
+	}
+	return id, nil, left, nil
+}
+
+// evmNonceKey is the storage key of the nonce for CREATE. It never
+// conflicts with keys of EVM storage, which are always 32 bytes.
+var evmNonceKey = []byte("evm.nonce")
+
+// nextNonce returns the nonce for the next contract created by CREATE.
+// As Ethereum does, it starts from 1.
+func (h *EVMHandler) nextNonce() (uint64, error) {
+	bs, err := h.as.GetValue(evmNonceKey)
+	if err != nil {
+		return 0, err
+	}
+	nonce := uint64(1)
+	if bs != nil {
+		nonce = intconv.BytesToUint64(bs)
+	}
+	if _, err := h.as.SetValue(evmNonceKey, intconv.Uint64ToBytes(nonce+1)); err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
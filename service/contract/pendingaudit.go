@@ -0,0 +1,67 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package contract
+
+import (
+	"bytes"
+
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// PendingAuditsOf returns the list of deploy IDs waiting for the audit.
+func PendingAuditsOf(as containerdb.BytesStoreState) *containerdb.ArrayDB {
+	return scoredb.NewArrayDB(as, state.VarPendingAudits)
+}
+
+// tracksPendingAudits returns whether pending audits are listed.
+// With module.PendingAuditList, every audit is listed regardless of the
+// deployment policy. Before it, audits are listed only while the
+// deployment policy is configured for the chain.
+func tracksPendingAudits(as containerdb.BytesStoreState, rev module.Revision) bool {
+	if rev.Has(module.PendingAuditList) {
+		return true
+	}
+	return scoredb.NewVarDB(as, state.VarDeploymentPolicy).String() != ""
+}
+
+func addPendingAudit(as containerdb.BytesStoreState, rev module.Revision, id []byte) error {
+	if !tracksPendingAudits(as, rev) {
+		return nil
+	}
+	return PendingAuditsOf(as).Put(id)
+}
+
+// RemovePendingAudit removes the deploy ID from the pending audits.
+func RemovePendingAudit(as containerdb.BytesStoreState, rev module.Revision, id []byte) error {
+	if !tracksPendingAudits(as, rev) {
+		return nil
+	}
+	db := PendingAuditsOf(as)
+	for i := 0; i < db.Size(); i++ {
+		if bytes.Equal(db.Get(i).Bytes(), id) {
+			last := db.Pop()
+			if i < db.Size() {
+				return db.Set(i, last.Bytes())
+			}
+			return nil
+		}
+	}
+	return nil
+}
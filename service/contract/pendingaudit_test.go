@@ -0,0 +1,79 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/state"
+)
+
+func pendingAuditsIn(as state.AccountState) []string {
+	var ids []string
+	audits := PendingAuditsOf(as)
+	for i := 0; i < audits.Size(); i++ {
+		ids = append(ids, string(audits.Get(i).Bytes()))
+	}
+	return ids
+}
+
+func TestPendingAudits(t *testing.T) {
+	ws := state.NewWorldState(db.NewMapDB(), nil, nil, nil, nil)
+	as := ws.GetAccountState(state.SystemID)
+	var rev module.Revision
+
+	// not listed without the policy before the revision
+	assert.NoError(t, addPendingAudit(as, rev, []byte("a")))
+	assert.NoError(t, RemovePendingAudit(as, rev, []byte("a")))
+	assert.Empty(t, pendingAuditsIn(as))
+
+	err := scoredb.NewVarDB(as, state.VarDeploymentPolicy).Set(state.DeploymentPolicyAudit)
+	assert.NoError(t, err)
+	for _, id := range []string{"a", "b", "c", "d"} {
+		assert.NoError(t, addPendingAudit(as, rev, []byte(id)))
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, pendingAuditsIn(as))
+
+	// the last one fills the hole
+	assert.NoError(t, RemovePendingAudit(as, rev, []byte("b")))
+	assert.Equal(t, []string{"a", "d", "c"}, pendingAuditsIn(as))
+
+	// the last one
+	assert.NoError(t, RemovePendingAudit(as, rev, []byte("c")))
+	assert.Equal(t, []string{"a", "d"}, pendingAuditsIn(as))
+
+	// unknown one
+	assert.NoError(t, RemovePendingAudit(as, rev, []byte("x")))
+	assert.Equal(t, []string{"a", "d"}, pendingAuditsIn(as))
+
+	assert.NoError(t, RemovePendingAudit(as, rev, []byte("a")))
+	assert.NoError(t, RemovePendingAudit(as, rev, []byte("d")))
+	assert.Empty(t, pendingAuditsIn(as))
+}
+
+func TestPendingAudits_BeforePolicy(t *testing.T) {
+	ws := state.NewWorldState(db.NewMapDB(), nil, nil, nil, nil)
+	as := ws.GetAccountState(state.SystemID)
+	rev := module.PendingAuditList
+
+	// audits are listed without the policy
+	assert.NoError(t, addPendingAudit(as, rev, []byte("a")))
+	assert.NoError(t, addPendingAudit(as, rev, []byte("b")))
+	assert.Equal(t, []string{"a", "b"}, pendingAuditsIn(as))
+
+	// and they are kept after the policy is configured
+	err := scoredb.NewVarDB(as, state.VarDeploymentPolicy).Set(state.DeploymentPolicyAudit)
+	assert.NoError(t, err)
+	assert.NoError(t, addPendingAudit(as, rev, []byte("c")))
+	assert.Equal(t, []string{"a", "b", "c"}, pendingAuditsIn(as))
+
+	// and after the policy is changed
+	err = scoredb.NewVarDB(as, state.VarDeploymentPolicy).Set(state.DeploymentPolicyOpen)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, pendingAuditsIn(as))
+	assert.NoError(t, RemovePendingAudit(as, rev, []byte("a")))
+	assert.Equal(t, []string{"c", "b"}, pendingAuditsIn(as))
+}
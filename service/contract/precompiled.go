@@ -0,0 +1,212 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package contract
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreapi"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// PrecompiledMethod is a method of the precompiled system SCORE with its
+// step costs.
+type PrecompiledMethod struct {
+	scoreapi.Method
+	// Steps is the steps charged for each call.
+	Steps int64
+	// StepsPerWord is the steps charged for each 32 bytes of bytes type
+	// parameters.
+	StepsPerWord int64
+}
+
+// PrecompiledModule describes a system SCORE implemented in Go, which is
+// installed at the fixed address.
+type PrecompiledModule struct {
+	ID      string
+	Address module.Address
+	Methods []PrecompiledMethod
+	Events  []scoreapi.Method
+	New     func(base *PrecompiledBase) (SystemScore, error)
+}
+
+func (m *PrecompiledModule) getMethod(name string) *PrecompiledMethod {
+	for i := range m.Methods {
+		if m.Methods[i].Type == scoreapi.Function && m.Methods[i].Name == name {
+			return &m.Methods[i]
+		}
+	}
+	return nil
+}
+
+// APIInfo returns API information built from the methods and the events.
+func (m *PrecompiledModule) APIInfo() *scoreapi.Info {
+	methods := make([]*scoreapi.Method, 0, len(m.Methods)+len(m.Events))
+	for i := range m.Methods {
+		methods = append(methods, &m.Methods[i].Method)
+	}
+	for i := range m.Events {
+		methods = append(methods, &m.Events[i])
+	}
+	return scoreapi.NewInfo(methods)
+}
+
+// PrecompiledBase provides common features for precompiled system SCOREs.
+// Implementation embeds it to get default Install, Update and GetAPI along
+// with step charging declared in the method table.
+type PrecompiledBase struct {
+	module *PrecompiledModule
+	cc     CallContext
+	from   module.Address
+	value  *big.Int
+}
+
+func (b *PrecompiledBase) CallContext() CallContext {
+	return b.cc
+}
+
+func (b *PrecompiledBase) From() module.Address {
+	return b.from
+}
+
+func (b *PrecompiledBase) Value() *big.Int {
+	return b.value
+}
+
+func (b *PrecompiledBase) Address() module.Address {
+	return b.module.Address
+}
+
+func (b *PrecompiledBase) Install(param []byte) error {
+	return nil
+}
+
+func (b *PrecompiledBase) Update(param []byte) error {
+	return nil
+}
+
+func (b *PrecompiledBase) GetAPI() *scoreapi.Info {
+	return b.module.APIInfo()
+}
+
+// ChargeMethodSteps charges steps of the method for the parameters.
+// It's called by Invoke before calling the method.
+func (b *PrecompiledBase) ChargeMethodSteps(method string, params []interface{}) error {
+	m := b.module.getMethod(method)
+	if m == nil {
+		return scoreresult.ErrMethodNotFound
+	}
+	steps := m.Steps
+	if m.StepsPerWord > 0 {
+		for _, p := range params {
+			if bs, ok := p.([]byte); ok {
+				steps += m.StepsPerWord * int64((len(bs)+31)/32)
+			}
+		}
+	}
+	if steps <= 0 {
+		return nil
+	}
+	if !b.cc.DeductSteps(big.NewInt(steps)) {
+		return scoreresult.OutOfStepError.Errorf(
+			"OutOfStepFor(%s.%s)", b.module.ID, method)
+	}
+	return nil
+}
+
+// EmitEvent emits the event declared in the module with the address of
+// the SCORE. The first item of indexed should be the signature of the event.
+func (b *PrecompiledBase) EmitEvent(indexed, data [][]byte) error {
+	if b.cc.ReadOnlyMode() {
+		return scoreresult.AccessDeniedError.New("EventInReadOnlyMode")
+	}
+	if err := b.module.APIInfo().CheckEventData(indexed, data); err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidEventData")
+	}
+	b.cc.OnEvent(b.module.Address, indexed, data)
+	return nil
+}
+
+type methodStepCharger interface {
+	ChargeMethodSteps(method string, params []interface{}) error
+}
+
+var precompiledModules = map[string]*PrecompiledModule{}
+
+// RegisterPrecompiled registers the precompiled system SCORE. It panics on
+// the duplicate ID or address.
+func RegisterPrecompiled(m *PrecompiledModule) {
+	if m.ID == CID_CHAIN || m.Address == nil || m.New == nil {
+		log.Panicf("InvalidPrecompiledModule(id=%s)", m.ID)
+	}
+	if _, ok := systemScoreModules[m.ID]; ok {
+		log.Panicf("DuplicatePrecompiledID(id=%s)", m.ID)
+	}
+	for _, pm := range precompiledModules {
+		if pm.Address.Equal(m.Address) {
+			log.Panicf("DuplicatePrecompiledAddress(id=%s,addr=%s)", m.ID, m.Address)
+		}
+	}
+	precompiledModules[m.ID] = m
+	RegisterSystemScore(m.ID, &SystemScoreModule{
+		New: func(cid string, cc CallContext, from module.Address, value *big.Int) (SystemScore, error) {
+			return m.New(&PrecompiledBase{
+				module: m,
+				cc:     cc,
+				from:   from,
+				value:  value,
+			})
+		},
+	})
+}
+
+// GetPrecompiled returns the registered precompiled system SCORE.
+func GetPrecompiled(id string) *PrecompiledModule {
+	return precompiledModules[id]
+}
+
+// PrecompiledModules returns all registered precompiled system SCOREs
+// ordered by ID.
+func PrecompiledModules() []*PrecompiledModule {
+	modules := make([]*PrecompiledModule, 0, len(precompiledModules))
+	for _, m := range precompiledModules {
+		modules = append(modules, m)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].ID < modules[j].ID
+	})
+	return modules
+}
+
+// InstallPrecompiled deploys the precompiled system SCORE at its address.
+func InstallPrecompiled(cc CallContext, id string, owner module.Address) error {
+	m := GetPrecompiled(id)
+	if m == nil {
+		return scoreresult.ContractNotFoundError.Errorf(
+			"PrecompiledNotFound(id=%s)", id)
+	}
+	as := cc.GetAccountState(m.Address.ID())
+	if as.IsContract() {
+		return errors.InvalidStateError.Errorf(
+			"AlreadyInstalled(id=%s,addr=%s)", id, m.Address)
+	}
+	return DeployAndInstallSystemSCORE(cc, id, owner, m.Address, nil, cc.TransactionID())
+}
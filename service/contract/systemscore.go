@@ -465,6 +465,12 @@ func Invoke(score SystemScore, method string, paramObj *codec.TypedObj) (status
 		objects[i] = oValue
 	}
 
+	if charger, ok := score.(methodStepCharger); ok {
+		if err := charger.ChargeMethodSteps(method, params); err != nil {
+			return err, nil, steps
+		}
+	}
+
 	r := m.Call(objects)
 	rLen := len(r)
 
@@ -0,0 +1,155 @@
+package eeproxy
+
+import (
+	"time"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const (
+	monitorInterval = time.Second
+
+	// number of idle intervals before stopping an executor
+	scaleDownIdleTicks = 30
+)
+
+// HealthChecker is implemented by an Engine which can check the health of
+// its instances. While autoscaling is enabled, the manager stops unhealthy
+// instances in ready state, then the engine starts new ones.
+type HealthChecker interface {
+	IsHealthy(uid string) bool
+}
+
+// SetMaxInstances enables autoscaling of executors if max is larger than
+// the number of executors configured by SetInstances. The manager adds
+// executors while there are requests waiting for an executor, and removes
+// them one by one after they are idle for a while. Use zero to disable it.
+// The monitor for autoscaling and health checks runs only while it's enabled.
+func (em *executorManager) SetMaxInstances(max int) error {
+	em.lock.Lock()
+	defer em.lock.Unlock()
+
+	if max < 0 {
+		return errors.IllegalArgumentError.Errorf("InvalidMaxInstances(max=%d)", max)
+	}
+	em.maxInstances = max
+	em.idleTicks = 0
+	if max > 0 && em.stop == nil {
+		em.stop = make(chan struct{})
+		go em.monitor(em.stop)
+	} else if max == 0 && em.stop != nil {
+		close(em.stop)
+		em.stop = nil
+	}
+	if em.executorLimit > em.minInstances && em.executorLimit > max {
+		limit := max
+		if limit < em.minInstances {
+			limit = em.minInstances
+		}
+		return em.setLimitInLock(limit)
+	}
+	return nil
+}
+
+func (em *executorManager) monitor(stop <-chan struct{}) {
+	ticker := time.NewTicker(monitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			em.onMonitor()
+		}
+	}
+}
+
+// autoScalingInLock returns whether it adds executors over the ones
+// configured by SetInstances.
+func (em *executorManager) autoScalingInLock() bool {
+	return em.maxInstances > em.minInstances
+}
+
+func (em *executorManager) onMonitor() {
+	em.lock.Lock()
+	defer em.lock.Unlock()
+
+	var waiting, assigned int
+	for i := range em.executorStates {
+		waiting += em.executorStates[i].waiting
+		assigned += em.executorStates[i].assigned
+	}
+	if em.autoScalingInLock() {
+		em.checkHealthInLock()
+		em.autoScaleInLock(waiting, assigned)
+	}
+
+	em.metric.OnUsage(em.executorLimit, assigned, waiting)
+	for _, e := range em.engines {
+		em.metric.OnInstances(e.engine.Type(), e.active)
+	}
+}
+
+func (em *executorManager) checkHealthInLock() {
+	for _, e := range em.engines {
+		checker, ok := e.engine.(HealthChecker)
+		if !ok {
+			continue
+		}
+		for p := e.ready; p != nil; {
+			next := p.next
+			if !checker.IsHealthy(p.uid) {
+				em.log.Warnf("Stop unhealthy proxy=%s-%s", p.scoreType, p.uid)
+				em.metric.OnUnhealthy(e.engine.Type())
+				p.detach()
+				p.close()
+				e.active -= 1
+				if _, err := e.engine.Kill(p.uid); err != nil {
+					em.log.Warnf("Fail to kill proxy=%s-%s err=%+v",
+						p.scoreType, p.uid, err)
+				}
+			}
+			p = next
+		}
+	}
+}
+
+func (em *executorManager) autoScaleInLock(waiting, assigned int) {
+	if !em.autoScalingInLock() {
+		return
+	}
+	if waiting > 0 {
+		em.idleTicks = 0
+		if em.executorLimit < em.maxInstances {
+			limit := em.executorLimit + waiting
+			if limit > em.maxInstances {
+				limit = em.maxInstances
+			}
+			em.log.Infof("Scale up executors (waiting=%d,limit=%d->%d)",
+				waiting, em.executorLimit, limit)
+			if err := em.setLimitInLock(limit); err != nil {
+				em.log.Warnf("Fail to scale up executors err=%+v", err)
+				return
+			}
+			em.metric.OnScale(limit)
+		}
+		return
+	}
+	if em.executorLimit <= em.minInstances || assigned >= em.executorLimit {
+		em.idleTicks = 0
+		return
+	}
+	em.idleTicks += 1
+	if em.idleTicks < scaleDownIdleTicks {
+		return
+	}
+	em.idleTicks = 0
+	limit := em.executorLimit - 1
+	em.log.Infof("Scale down executors (assigned=%d,limit=%d->%d)",
+		assigned, em.executorLimit, limit)
+	if err := em.setLimitInLock(limit); err != nil {
+		em.log.Warnf("Fail to scale down executors err=%+v", err)
+		return
+	}
+	em.metric.OnScale(limit)
+}
@@ -0,0 +1,204 @@
+package eeproxy
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/ipc"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/watchdog"
+	"github.com/icon-project/goloop/server/metric"
+)
+
+type testEngine struct {
+	instances int
+	unhealthy map[string]bool
+	killed    []string
+}
+
+func (e *testEngine) Type() string {
+	return "test"
+}
+
+func (e *testEngine) Init(net, addr string) error {
+	return nil
+}
+
+func (e *testEngine) SetInstances(n int) error {
+	e.instances = n
+	return nil
+}
+
+func (e *testEngine) OnAttach(uid string) bool {
+	return true
+}
+
+func (e *testEngine) OnEnd(uid string) bool {
+	return true
+}
+
+func (e *testEngine) Kill(uid string) (bool, error) {
+	e.killed = append(e.killed, uid)
+	return true, nil
+}
+
+func (e *testEngine) OnConnect(conn ipc.Connection, version uint16) error {
+	return nil
+}
+
+func (e *testEngine) OnClose(conn ipc.Connection) bool {
+	return true
+}
+
+func (e *testEngine) IsHealthy(uid string) bool {
+	return !e.unhealthy[uid]
+}
+
+func newTestExecutorManager(t *testing.T) (*executorManager, *testEngine) {
+	te := &testEngine{unhealthy: make(map[string]bool)}
+	em := &executorManager{
+		engines: map[string]*engine{te.Type(): {engine: te}},
+		metric:  metric.NewExecutorMetric(metric.DefaultMetricContext()),
+		log:     log.New(),
+	}
+	for i := range em.executorStates {
+		em.executorStates[i].waiter = sync.NewCond(&em.lock)
+	}
+	t.Cleanup(func() {
+		em.lock.Lock()
+		defer em.lock.Unlock()
+		if em.stop != nil {
+			close(em.stop)
+			em.stop = nil
+		}
+	})
+	assert.NoError(t, em.SetInstances(2, 1, 1))
+	return em, te
+}
+
+// addTestProxy adds a ready proxy to the manager.
+func (em *executorManager) addTestProxy(t *testing.T, uid string) *proxy {
+	w := watchdog.New(watchdog.DefaultCheckInterval, log.New())
+	t.Cleanup(w.Term)
+	p, err := newProxy(em, &testConnection{}, log.New(), "test", 0, uid)
+	assert.NoError(t, err)
+	p.hb.Close()
+	p.hb = w.Register("test", time.Minute, p.stalled)
+	return p
+}
+
+func readyOf(e *engine) []string {
+	var uids []string
+	for p := e.ready; p != nil; p = p.next {
+		uids = append(uids, p.uid)
+	}
+	return uids
+}
+
+func TestExecutorManager_SetMaxInstances(t *testing.T) {
+	em, te := newTestExecutorManager(t)
+	assert.Nil(t, em.stop)
+
+	assert.Error(t, em.SetMaxInstances(-1))
+	assert.Nil(t, em.stop)
+
+	assert.NoError(t, em.SetMaxInstances(4))
+	assert.NotNil(t, em.stop)
+	assert.Equal(t, 2, em.executorLimit)
+
+	em.lock.Lock()
+	em.autoScaleInLock(2, 2)
+	em.lock.Unlock()
+	assert.Equal(t, 4, em.executorLimit)
+
+	// lower max reduces the limit
+	assert.NoError(t, em.SetMaxInstances(3))
+	assert.Equal(t, 3, em.executorLimit)
+	assert.Equal(t, 3, te.instances)
+	assert.Equal(t, 2, em.executorStates[ForTransaction].limit)
+	assert.Equal(t, 2, em.executorStates[ForQuery].limit)
+
+	// disabling stops the monitor and restores the configured limit
+	assert.NoError(t, em.SetMaxInstances(0))
+	assert.Nil(t, em.stop)
+	assert.Equal(t, 2, em.executorLimit)
+	assert.Equal(t, 2, te.instances)
+	assert.Equal(t, 1, em.executorStates[ForTransaction].limit)
+}
+
+func TestExecutorManager_AutoScaleInLock(t *testing.T) {
+	em, _ := newTestExecutorManager(t)
+	em.lock.Lock()
+	defer em.lock.Unlock()
+
+	// disabled
+	em.autoScaleInLock(3, 2)
+	assert.Equal(t, 2, em.executorLimit)
+
+	em.maxInstances = 4
+
+	// scale up as many as waiting requests up to max
+	em.autoScaleInLock(1, 2)
+	assert.Equal(t, 3, em.executorLimit)
+	assert.Equal(t, 2, em.executorStates[ForTransaction].limit)
+	em.autoScaleInLock(5, 3)
+	assert.Equal(t, 4, em.executorLimit)
+	em.autoScaleInLock(1, 4)
+	assert.Equal(t, 4, em.executorLimit)
+
+	// busy executors reset idle ticks
+	for i := 0; i < scaleDownIdleTicks-1; i++ {
+		em.autoScaleInLock(0, 1)
+	}
+	em.autoScaleInLock(0, 4)
+	assert.Equal(t, 0, em.idleTicks)
+	assert.Equal(t, 4, em.executorLimit)
+
+	// scale down one by one after idle ticks
+	for i := 0; i < scaleDownIdleTicks-1; i++ {
+		em.autoScaleInLock(0, 1)
+	}
+	assert.Equal(t, 4, em.executorLimit)
+	em.autoScaleInLock(0, 1)
+	assert.Equal(t, 3, em.executorLimit)
+	assert.Equal(t, 2, em.executorStates[ForTransaction].limit)
+
+	// not below the configured one
+	for i := 0; i < 3*scaleDownIdleTicks; i++ {
+		em.autoScaleInLock(0, 0)
+	}
+	assert.Equal(t, 2, em.executorLimit)
+}
+
+func TestExecutorManager_CheckHealthInLock(t *testing.T) {
+	em, te := newTestExecutorManager(t)
+	e := em.engines[te.Type()]
+	pa := em.addTestProxy(t, "12345678-a")
+	pb := em.addTestProxy(t, "12345678-b")
+	te.unhealthy[pb.uid] = true
+
+	// no health checks without autoscaling
+	em.onMonitor()
+	assert.Empty(t, te.killed)
+	assert.Equal(t, []string{pb.uid, pa.uid}, readyOf(e))
+
+	em.maxInstances = 4
+	em.onMonitor()
+	assert.Equal(t, []string{pb.uid}, te.killed)
+	assert.Equal(t, []string{pa.uid}, readyOf(e))
+	assert.Equal(t, 1, e.active)
+	assert.Equal(t, stateClosed, pb.state)
+	assert.NotEqual(t, stateClosed, pa.state)
+
+	// proxies in use aren't checked
+	te.unhealthy[pa.uid] = true
+	pa.detach()
+	pa.attachTo(&e.using)
+	em.lock.Lock()
+	em.checkHealthInLock()
+	em.lock.Unlock()
+	assert.Equal(t, []string{pb.uid}, te.killed)
+}
@@ -134,6 +134,17 @@ func (e *javaExecutionEngine) OnAttach(uid string) bool {
 	return false
 }
 
+func (e *javaExecutionEngine) IsHealthy(uid string) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.managerProxy == nil {
+		return false
+	}
+	is, ok := e.instances[uid]
+	return ok && is.status == instanceOnline
+}
+
 // restart EE
 func (e *javaExecutionEngine) Kill(uid string) (bool, error) {
 	e.logger.Debugf("Kill uid(%s)\n", uid)
@@ -7,6 +7,7 @@ import (
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/ipc"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/server/metric"
 )
 
 type RequestPriority int
@@ -29,6 +30,7 @@ const (
 type Manager interface {
 	GetExecutor(pr RequestPriority) *Executor
 	SetInstances(total, tx, query int) error
+	SetMaxInstances(max int) error
 	Loop() error
 	Close() error
 }
@@ -96,7 +98,15 @@ type executorManager struct {
 	executorLimit  int
 	executorStates [numberOfPriorities]executorState
 
-	log log.Logger
+	// configured by SetInstances() and SetMaxInstances() for autoscaling
+	minInstances int
+	maxInstances int
+	baseLimits   [numberOfPriorities]int
+	idleTicks    int
+	stop         chan struct{}
+
+	metric *metric.ExecutorMetric
+	log    log.Logger
 }
 
 func (em *executorManager) onReady(p *proxy) error {
@@ -179,6 +189,13 @@ func (em *executorManager) OnClose(c ipc.Connection) {
 }
 
 func (em *executorManager) Close() error {
+	em.lock.Lock()
+	if em.stop != nil {
+		close(em.stop)
+		em.stop = nil
+	}
+	em.lock.Unlock()
+
 	if err := em.server.Close(); err != nil {
 		return err
 	}
@@ -235,6 +252,25 @@ func (em *executorManager) SetInstances(total, tx, query int) error {
 	em.lock.Lock()
 	defer em.lock.Unlock()
 
+	em.minInstances = total
+	em.baseLimits[ForTransaction] = tx
+	em.baseLimits[ForQuery] = query
+
+	limit := total
+	if em.maxInstances > total && em.executorLimit > total {
+		limit = em.executorLimit
+		if limit > em.maxInstances {
+			limit = em.maxInstances
+		}
+	}
+	return em.setLimitInLock(limit)
+}
+
+// setLimitInLock sets the number of executors. Limits for priorities are
+// increased or decreased as much as the number of executors changes from
+// the configured one. Ready executors over the limit are stopped, and used
+// ones are stopped on release.
+func (em *executorManager) setLimitInLock(total int) error {
 	for _, e := range em.engines {
 		if err := e.engine.SetInstances(total); err != nil {
 			return err
@@ -242,8 +278,10 @@ func (em *executorManager) SetInstances(total, tx, query int) error {
 	}
 
 	em.executorLimit = total
-	em.executorStates[ForTransaction].limit = tx
-	em.executorStates[ForQuery].limit = query
+	delta := total - em.minInstances
+	for i := range em.executorStates {
+		em.executorStates[i].limit = em.baseLimits[i] + delta
+	}
 
 	for _, e := range em.engines {
 		for e.ready != nil && e.active > em.executorLimit {
@@ -256,6 +294,11 @@ func (em *executorManager) SetInstances(total, tx, query int) error {
 			e.active -= 1
 		}
 	}
+	for i := range em.executorStates {
+		if s := &em.executorStates[i]; s.waiting > 0 {
+			s.waiter.Broadcast()
+		}
+	}
 	return nil
 }
 
@@ -297,6 +340,7 @@ func NewManager(net, addr string, l log.Logger, engines ...Engine) (Manager, err
 	srv.SetHandler(em)
 	em.server = srv
 	em.log = l.WithFields(log.Fields{log.FieldKeyModule: "EEP"})
+	em.metric = metric.NewExecutorMetric(metric.DefaultMetricContext())
 
 	for i := 0; i < len(em.executorStates); i++ {
 		em.executorStates[i].waiter = sync.NewCond(&em.lock)
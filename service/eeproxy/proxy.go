@@ -3,6 +3,7 @@ package eeproxy
 import (
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -13,10 +14,17 @@ import (
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/ipc"
+	"github.com/icon-project/goloop/common/watchdog"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/scoreapi"
+	"github.com/icon-project/goloop/service/scoreresult"
 )
 
+// ConfigWatchdogTimeout is the time for the executor to be considered as
+// stalled if it doesn't send any message while it's executing. It shall be
+// longer than timeouts of transactions and queries.
+const ConfigWatchdogTimeout = time.Minute
+
 type Message uint
 
 const (
@@ -93,9 +101,10 @@ type proxyManager interface {
 }
 
 type callFrame struct {
-	addr module.Address
-	ctx  CallContext
-	log  *trace.Logger
+	addr  module.Address
+	ctx   CallContext
+	log   *trace.Logger
+	limit *big.Int
 
 	prev *callFrame
 }
@@ -112,6 +121,8 @@ type proxy struct {
 	scoreType string
 
 	log *trace.Logger
+	wd  *watchdog.Watchdog
+	hb  *watchdog.Heartbeat
 
 	frame *callFrame
 
@@ -271,12 +282,14 @@ func (p *proxy) Invoke(
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	p.frame = &callFrame{
-		addr: to,
-		ctx:  ctx,
-		log:  p.log,
-		prev: p.frame,
+		addr:  to,
+		ctx:   ctx,
+		log:   p.log,
+		limit: new(big.Int).Set(limit),
+		prev:  p.frame,
 	}
 	p.log = logger
+	p.hb.Beat()
 	return p.conn.Send(msgINVOKE, &m)
 }
 
@@ -294,6 +307,7 @@ func (p *proxy) GetAPI(ctx CallContext, code string) error {
 		prev: p.frame,
 	}
 	p.log = logger
+	p.hb.Beat()
 	return p.conn.Send(msgGETAPI, code)
 }
 
@@ -355,6 +369,7 @@ func (p *proxy) SendResult(ctx CallContext, status error, steps *big.Int, result
 	}
 	m.EID = eid
 	m.PrevEID = last
+	p.hb.Beat()
 	return p.conn.Send(msgRESULT, &m)
 }
 
@@ -366,6 +381,9 @@ func (p *proxy) popFrame() *callFrame {
 		frame := p.frame
 		p.log = frame.log
 		p.frame = frame.prev
+		if p.frame == nil {
+			p.hb.Idle()
+		}
 		return frame
 	}
 	return nil
@@ -393,6 +411,7 @@ func (p *proxy) tryToBeReadyInLock() bool {
 }
 
 func (p *proxy) HandleMessage(c ipc.Connection, msg uint, data []byte) error {
+	p.hb.Beat()
 	switch msg {
 	case msgRESULT:
 		var m resultMessage
@@ -415,10 +434,21 @@ func (p *proxy) HandleMessage(c ipc.Connection, msg uint, data []byte) error {
 			result = m.Result
 		} else {
 			msg := common.DecodeAsString(m.Result, "")
-			status = m.Status.New(msg)
+			status = errors.NewRemote(m.Status, msg)
+			result = nil
+		}
+		steps := &m.StepUsed.Int
+		if frame.limit != nil && steps.Cmp(frame.limit) > 0 {
+			// The executor should stop the execution on reaching the limit.
+			// Regardless of when it stops, it's handled as out of step
+			// with the limit for all nodes to get the same result.
+			p.log.Warnf("Proxy[%p].OnResult steps=%v exceeds limit=%v",
+				p, steps, frame.limit)
+			status = scoreresult.ErrOutOfStep
 			result = nil
+			steps = frame.limit
 		}
-		frame.ctx.OnResult(status, statusFlag, &m.StepUsed.Int, result)
+		frame.ctx.OnResult(status, statusFlag, steps, result)
 
 		return p.tryToBeReady()
 	case msgGETVALUE:
@@ -514,7 +544,7 @@ func (p *proxy) HandleMessage(c ipc.Connection, msg uint, data []byte) error {
 
 		var status error
 		if m.Status != errors.Success {
-			status = m.Status.New(module.Status(m.Status).String())
+			status = errors.NewRemote(m.Status, module.Status(m.Status).String())
 		}
 		frame.ctx.OnAPI(status, m.Info)
 		return p.tryToBeReady()
@@ -616,6 +646,7 @@ func (p *proxy) close() error {
 
 	if p.state != stateClosed {
 		p.state = stateClosed
+		p.hb.Close()
 		p.conn.Send(msgCLOSE, nil)
 		return p.conn.Close()
 	}
@@ -637,6 +668,7 @@ func (p *proxy) OnClose() {
 	if p.state != stateClosed {
 		p.state = stateClosed
 	}
+	p.hb.Close()
 }
 
 func (p *proxy) Kill() error {
@@ -648,6 +680,18 @@ func (p *proxy) Kill() error {
 	return p.mgr.kill(p.uid)
 }
 
+// stalled kills the executor not responding while it's executing if the
+// watchdog is configured to recover, then the manager starts new one.
+func (p *proxy) stalled() {
+	if !p.wd.Recover() {
+		return
+	}
+	p.log.Warnf("Proxy[%p].stalled kill by watchdog", p)
+	if err := p.Kill(); err != nil {
+		p.log.Warnf("Proxy[%p].stalled fail to kill err=%+v", p, err)
+	}
+}
+
 func (p *proxy) detach() bool {
 	if p.pprev == nil {
 		return false
@@ -683,7 +727,10 @@ func newProxy(m proxyManager, c ipc.Connection, l log.Logger, t string, v uint16
 		version:   v,
 		uid:       uid,
 		state:     stateIdle,
+		wd:        watchdog.Default(),
 	}
+	p.hb = p.wd.Register("Proxy.Executor "+uid,
+		ConfigWatchdogTimeout, p.stalled)
 	c.SetHandler(msgRESULT, p)
 	c.SetHandler(msgGETVALUE, p)
 	c.SetHandler(msgSETVALUE, p)
@@ -701,6 +748,7 @@ func newProxy(m proxyManager, c ipc.Connection, l log.Logger, t string, v uint16
 
 	if err := m.onReady(p); err != nil {
 		p.state = stateStopped
+		p.hb.Close()
 		return nil, err
 	}
 	p.state = stateReady
@@ -0,0 +1,195 @@
+package eeproxy
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/ipc"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/watchdog"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+type testConnection struct {
+	ipc.Connection
+	sent []uint
+}
+
+func (c *testConnection) SetHandler(msg uint, handler ipc.MessageHandler) {
+}
+
+func (c *testConnection) Send(msg uint, data interface{}) error {
+	c.sent = append(c.sent, msg)
+	return nil
+}
+
+func (c *testConnection) Close() error {
+	return nil
+}
+
+type testProxyManager struct {
+	killed chan string
+}
+
+func (m *testProxyManager) onReady(p *proxy) error {
+	return nil
+}
+
+func (m *testProxyManager) kill(u string) error {
+	m.killed <- u
+	return nil
+}
+
+type testCallContext struct {
+	CallContext
+	status error
+	steps  *big.Int
+	result *codec.TypedObj
+}
+
+func (ctx *testCallContext) GetInfo() *codec.TypedObj {
+	return nil
+}
+
+func (ctx *testCallContext) OnResult(status error, flag int, steps *big.Int, result *codec.TypedObj) {
+	ctx.status = status
+	ctx.steps = steps
+	ctx.result = result
+}
+
+func (ctx *testCallContext) Logger() log.Logger {
+	return log.New()
+}
+
+func newTestProxy(t *testing.T, w *watchdog.Watchdog) (*proxy, *testProxyManager) {
+	m := &testProxyManager{killed: make(chan string, 1)}
+	p, err := newProxy(m, &testConnection{}, log.New(), "java", 0, "12345678-test")
+	assert.NoError(t, err)
+	p.hb.Close()
+	p.wd = w
+	p.hb = w.Register("test", 50*time.Millisecond, p.stalled)
+	return p, m
+}
+
+func TestProxy_Stalled(t *testing.T) {
+	w := watchdog.New(10*time.Millisecond, log.New())
+	defer w.Term()
+
+	// without recovery, it's only logged
+	p, m := newTestProxy(t, w)
+	assert.NoError(t, p.GetAPI(&testCallContext{}, "code"))
+	select {
+	case uid := <-m.killed:
+		assert.Fail(t, "killed without recovery", uid)
+	case <-time.After(200 * time.Millisecond):
+	}
+	assert.Equal(t, stateReady, p.state)
+	p.hb.Close()
+
+	w.SetRecover(true)
+
+	// idle executor isn't killed
+	p, m = newTestProxy(t, w)
+	assert.NoError(t, p.GetAPI(&testCallContext{}, "code"))
+	assert.NotNil(t, p.popFrame())
+	select {
+	case uid := <-m.killed:
+		assert.Fail(t, "killed while idle", uid)
+	case <-time.After(200 * time.Millisecond):
+	}
+	p.hb.Close()
+
+	// executor not responding is killed
+	p, m = newTestProxy(t, w)
+	assert.NoError(t, p.GetAPI(&testCallContext{}, "code"))
+	select {
+	case uid := <-m.killed:
+		assert.Equal(t, "12345678-test", uid)
+	case <-time.After(time.Second):
+		assert.Fail(t, "not killed")
+	}
+	assert.Equal(t, stateStopped, p.state)
+	p.hb.Close()
+}
+
+func TestProxy_ResultOverLimit(t *testing.T) {
+	w := watchdog.New(10*time.Millisecond, log.New())
+	defer w.Term()
+
+	addr := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	limit := big.NewInt(1000)
+	tests := []struct {
+		name   string
+		status errors.Code
+		steps  int64
+		code   errors.Code
+		used   int64
+	}{
+		{"UnderLimit", errors.Success, 999, errors.Success, 999},
+		{"OnLimit", errors.Success, 1000, errors.Success, 1000},
+		{"OverLimit", errors.Success, 1001, scoreresult.OutOfStepError, 1000},
+		{"FailureOnLimit", scoreresult.RevertedError, 1000, scoreresult.RevertedError, 1000},
+		{"FailureOverLimit", scoreresult.RevertedError, 2000, scoreresult.OutOfStepError, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := newTestProxy(t, w)
+			defer p.hb.Close()
+			ctx := &testCallContext{}
+			assert.NoError(t, p.Invoke(ctx, "code", false, addr, addr,
+				new(big.Int), limit, "method", nil, nil, 0, nil))
+
+			var m resultMessage
+			m.Status = tt.status
+			m.StepUsed.SetInt64(tt.steps)
+			if tt.status == errors.Success {
+				m.Result = common.MustEncodeAny("result")
+			} else {
+				m.Result = common.MustEncodeAny("failure")
+			}
+			bs, err := codec.MP.MarshalToBytes(&m)
+			assert.NoError(t, err)
+			assert.NoError(t, p.HandleMessage(nil, msgRESULT, bs))
+
+			assert.Equal(t, tt.code, errors.CodeOf(ctx.status))
+			assert.Equal(t, tt.used, ctx.steps.Int64())
+			if tt.code == errors.Success {
+				assert.Equal(t, "result", common.DecodeAsString(ctx.result, ""))
+			} else {
+				assert.Nil(t, ctx.result)
+			}
+		})
+	}
+}
+
+func TestProxy_ResultUserFailure(t *testing.T) {
+	w := watchdog.New(10*time.Millisecond, log.New())
+	defer w.Term()
+
+	addr := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	p, _ := newTestProxy(t, w)
+	defer p.hb.Close()
+	ctx := &testCallContext{}
+	assert.NoError(t, p.Invoke(ctx, "code", false, addr, addr,
+		new(big.Int), big.NewInt(1000), "method", nil, nil, 0, nil))
+
+	// the message of the user defined failure is kept for the receipt
+	var m resultMessage
+	m.Status = errors.Code(module.StatusReverted + 1)
+	m.StepUsed.SetInt64(100)
+	m.Result = common.MustEncodeAny("NotEnoughToken")
+	bs, err := codec.MP.MarshalToBytes(&m)
+	assert.NoError(t, err)
+	assert.NoError(t, p.HandleMessage(nil, msgRESULT, bs))
+
+	s, _ := scoreresult.StatusOf(ctx.status)
+	assert.Equal(t, module.StatusReverted+1, s)
+	assert.Equal(t, "NotEnoughToken", ctx.status.Error())
+}
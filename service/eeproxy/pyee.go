@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"sync"
+	"syscall"
 
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/errors"
@@ -111,6 +112,17 @@ func (e *pythonExecutionEngine) OnAttach(uid string) bool {
 	return false
 }
 
+func (e *pythonExecutionEngine) IsHealthy(uid string) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	is, ok := e.instances[uid]
+	if !ok || is.status != instanceOnline || is.cmd.Process == nil {
+		return false
+	}
+	return is.cmd.Process.Signal(syscall.Signal(0)) == nil
+}
+
 func (e *pythonExecutionEngine) OnEnd(uid string) bool {
 	return true
 }
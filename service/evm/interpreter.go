@@ -0,0 +1,874 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package evm implements an interpreter of EVM byte codes. SELFDESTRUCT
+// isn't supported, and it fails with UnsupportedOpcode.
+package evm
+
+import (
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+const (
+	AddressLength = 20
+	WordLength    = 32
+
+	maxStackSize = 1024
+	maxCallDepth = 1024
+
+	// maxMemorySize limits memory expansion to prevent overflow of gas
+	// calculation.
+	maxMemorySize = 1 << 32
+)
+
+var (
+	ErrExecutionReverted = scoreresult.NewBase(module.StatusReverted, "ExecutionReverted")
+)
+
+var (
+	tt256   = new(big.Int).Lsh(big.NewInt(1), 256)
+	tt256m1 = new(big.Int).Sub(tt256, big.NewInt(1))
+	tt255   = new(big.Int).Lsh(big.NewInt(1), 255)
+)
+
+type CallKind int
+
+const (
+	CallNormal CallKind = iota
+	CallStatic
+	// CallDelegate runs the code of the target with the storage, the caller
+	// and the value of the current contract.
+	CallDelegate
+	// CallCode runs the code of the target with the storage of the current
+	// contract. The value isn't transferred.
+	CallCode
+)
+
+// Host provides the state of the world to the interpreter.
+type Host interface {
+	GetStorage(key []byte) ([]byte, error)
+	// SetStorage sets the value for the key. It deletes the value if the
+	// value is nil.
+	SetStorage(key []byte, value []byte) error
+	GetBalance(addr []byte) *big.Int
+	AddLog(topics [][]byte, data []byte) error
+	Call(kind CallKind, to []byte, value *big.Int, input []byte, gas uint64) (ret []byte, gasLeft uint64, err error)
+	// GetCode returns the EVM code of the contract. It returns nil for
+	// accounts without EVM code.
+	GetCode(addr []byte) ([]byte, error)
+	// Create creates a contract with the init code. The address is derived
+	// by CreateAddress, or by CreateAddress2 if the salt isn't nil. On
+	// failure, ret is the data given by REVERT.
+	Create(value *big.Int, code []byte, salt []byte, gas uint64) (addr []byte, ret []byte, gasLeft uint64, err error)
+}
+
+// Environment is the environment for the execution of the code.
+type Environment struct {
+	Address []byte
+	Caller  []byte
+	Origin  []byte
+	Value   *big.Int
+	Input   []byte
+	Code    []byte
+
+	ReadOnly bool
+	Depth    int
+
+	BlockNumber int64
+	Timestamp   int64
+	ChainID     *big.Int
+	GasPrice    *big.Int
+	GasLimit    uint64
+}
+
+type interpreter struct {
+	env  *Environment
+	host Host
+
+	gas        uint64
+	pc         uint64
+	stack      []*big.Int
+	memory     []byte
+	returnData []byte
+	jumpDests  []bool
+}
+
+// Execute runs the code in the environment. It returns remaining gas with
+// the result. On ErrExecutionReverted, the result is the data given by
+// REVERT. It consumes all gas on other failures.
+func Execute(env *Environment, host Host, gas uint64) ([]byte, uint64, error) {
+	in := &interpreter{
+		env:       env,
+		host:      host,
+		gas:       gas,
+		stack:     make([]*big.Int, 0, 16),
+		jumpDests: analyzeJumpDests(env.Code),
+	}
+	ret, err := in.run()
+	if err != nil && err != ErrExecutionReverted {
+		return nil, 0, err
+	}
+	return ret, in.gas, err
+}
+
+func analyzeJumpDests(code []byte) []bool {
+	dests := make([]bool, len(code))
+	for pc := 0; pc < len(code); pc++ {
+		op := OpCode(code[pc])
+		if op == JUMPDEST {
+			dests[pc] = true
+		} else if op >= PUSH1 && op <= PUSH32 {
+			pc += int(op-PUSH1) + 1
+		}
+	}
+	return dests
+}
+
+func u256(x *big.Int) *big.Int {
+	return x.And(x, tt256m1)
+}
+
+func s256(x *big.Int) *big.Int {
+	if x.Cmp(tt255) < 0 {
+		return x
+	}
+	return new(big.Int).Sub(x, tt256)
+}
+
+func toWord(x *big.Int) []byte {
+	w := make([]byte, WordLength)
+	x.FillBytes(w)
+	return w
+}
+
+func toAddress(x *big.Int) []byte {
+	return toWord(x)[WordLength-AddressLength:]
+}
+
+func toWordCount(size uint64) uint64 {
+	return (size + WordLength - 1) / WordLength
+}
+
+func (in *interpreter) useGas(gas uint64) error {
+	if in.gas < gas {
+		in.gas = 0
+		return scoreresult.ErrOutOfStep
+	}
+	in.gas -= gas
+	return nil
+}
+
+func (in *interpreter) push(x *big.Int) error {
+	if len(in.stack) >= maxStackSize {
+		return scoreresult.StackOverflowError.New("EVMStackOverflow")
+	}
+	in.stack = append(in.stack, x)
+	return nil
+}
+
+func (in *interpreter) pop(n int) ([]*big.Int, error) {
+	if len(in.stack) < n {
+		return nil, scoreresult.InvalidInstanceError.Errorf(
+			"EVMStackUnderflow(pc=%d)", in.pc)
+	}
+	idx := len(in.stack) - n
+	items := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		items[i] = in.stack[len(in.stack)-1-i]
+	}
+	in.stack = in.stack[:idx]
+	return items, nil
+}
+
+func memoryGas(size uint64) uint64 {
+	words := toWordCount(size)
+	return words*gasMemoryWord + words*words/gasQuadCoeffDiv
+}
+
+// expandMemory charges gas for expanding memory to cover the region and
+// returns the region as integers.
+func (in *interpreter) expandMemory(offset, size *big.Int) (uint64, uint64, error) {
+	if size.Sign() == 0 {
+		return 0, 0, nil
+	}
+	if !offset.IsUint64() || !size.IsUint64() ||
+		offset.Uint64()+size.Uint64() > maxMemorySize {
+		return 0, 0, scoreresult.ErrOutOfStep
+	}
+	off, sz := offset.Uint64(), size.Uint64()
+	if end := off + sz; end > uint64(len(in.memory)) {
+		newSize := toWordCount(end) * WordLength
+		if err := in.useGas(memoryGas(newSize) - memoryGas(uint64(len(in.memory)))); err != nil {
+			return 0, 0, err
+		}
+		mem := make([]byte, newSize)
+		copy(mem, in.memory)
+		in.memory = mem
+	}
+	return off, sz, nil
+}
+
+func (in *interpreter) memoryRegion(offset, size *big.Int) ([]byte, error) {
+	off, sz, err := in.expandMemory(offset, size)
+	if err != nil {
+		return nil, err
+	}
+	return in.memory[off : off+sz], nil
+}
+
+// copyData copies data from the source at the offset to the region of the
+// memory. Bytes out of the source are filled with zero.
+func (in *interpreter) copyData(mOff, dOff, size *big.Int, src []byte) error {
+	region, err := in.memoryRegion(mOff, size)
+	if err != nil {
+		return err
+	}
+	if err := in.useGas(toWordCount(uint64(len(region))) * gasCopyWord); err != nil {
+		return err
+	}
+	copyPadded(region, src, dOff)
+	return nil
+}
+
+func copyPadded(dst []byte, src []byte, offset *big.Int) {
+	for i := range dst {
+		dst[i] = 0
+	}
+	if !offset.IsUint64() || offset.Uint64() >= uint64(len(src)) {
+		return
+	}
+	copy(dst, src[offset.Uint64():])
+}
+
+func (in *interpreter) run() ([]byte, error) {
+	code := in.env.Code
+	for in.pc < uint64(len(code)) {
+		op := OpCode(code[in.pc])
+		if err := in.useGas(constGas(op)); err != nil {
+			return nil, err
+		}
+		ret, done, err := in.step(op)
+		if err != nil || done {
+			return ret, err
+		}
+		in.pc++
+	}
+	return nil, nil
+}
+
+func (in *interpreter) step(op OpCode) ([]byte, bool, error) {
+	switch {
+	case op == PUSH0:
+		return nil, false, in.push(new(big.Int))
+	case op >= PUSH1 && op <= PUSH32:
+		n := uint64(op-PUSH1) + 1
+		data := make([]byte, n)
+		code := in.env.Code
+		if start := in.pc + 1; start < uint64(len(code)) {
+			copy(data, code[start:])
+		}
+		in.pc += n
+		return nil, false, in.push(new(big.Int).SetBytes(data))
+	case op >= DUP1 && op <= DUP16:
+		n := int(op-DUP1) + 1
+		if len(in.stack) < n {
+			return nil, false, scoreresult.InvalidInstanceError.Errorf(
+				"EVMStackUnderflow(pc=%d)", in.pc)
+		}
+		return nil, false, in.push(new(big.Int).Set(in.stack[len(in.stack)-n]))
+	case op >= SWAP1 && op <= SWAP16:
+		n := int(op-SWAP1) + 1
+		if len(in.stack) < n+1 {
+			return nil, false, scoreresult.InvalidInstanceError.Errorf(
+				"EVMStackUnderflow(pc=%d)", in.pc)
+		}
+		top := len(in.stack) - 1
+		in.stack[top], in.stack[top-n] = in.stack[top-n], in.stack[top]
+		return nil, false, nil
+	case op >= LOG0 && op <= LOG4:
+		return nil, false, in.opLog(int(op - LOG0))
+	}
+
+	switch op {
+	case STOP:
+		return nil, true, nil
+	case ADD, MUL, SUB, DIV, SDIV, MOD, SMOD, EXP, SIGNEXTEND,
+		LT, GT, SLT, SGT, EQ, AND, OR, XOR, BYTE, SHL, SHR, SAR:
+		return nil, false, in.opBinary(op)
+	case ADDMOD, MULMOD:
+		args, err := in.pop(3)
+		if err != nil {
+			return nil, false, err
+		}
+		r := new(big.Int)
+		if args[2].Sign() != 0 {
+			if op == ADDMOD {
+				r.Add(args[0], args[1])
+			} else {
+				r.Mul(args[0], args[1])
+			}
+			r.Mod(r, args[2])
+		}
+		return nil, false, in.push(r)
+	case ISZERO, NOT:
+		args, err := in.pop(1)
+		if err != nil {
+			return nil, false, err
+		}
+		r := new(big.Int)
+		if op == ISZERO {
+			if args[0].Sign() == 0 {
+				r.SetInt64(1)
+			}
+		} else {
+			r.Sub(tt256m1, args[0])
+		}
+		return nil, false, in.push(r)
+	case SHA3:
+		args, err := in.pop(2)
+		if err != nil {
+			return nil, false, err
+		}
+		data, err := in.memoryRegion(args[0], args[1])
+		if err != nil {
+			return nil, false, err
+		}
+		if err := in.useGas(toWordCount(uint64(len(data))) * gasSha3Word); err != nil {
+			return nil, false, err
+		}
+		return nil, false, in.push(new(big.Int).SetBytes(Keccak256(data)))
+	case ADDRESS:
+		return nil, false, in.push(new(big.Int).SetBytes(in.env.Address))
+	case BALANCE:
+		args, err := in.pop(1)
+		if err != nil {
+			return nil, false, err
+		}
+		return nil, false, in.push(new(big.Int).Set(in.host.GetBalance(toAddress(args[0]))))
+	case EXTCODESIZE, EXTCODEHASH:
+		args, err := in.pop(1)
+		if err != nil {
+			return nil, false, err
+		}
+		code, err := in.host.GetCode(toAddress(args[0]))
+		if err != nil {
+			return nil, false, err
+		}
+		if op == EXTCODESIZE {
+			return nil, false, in.push(big.NewInt(int64(len(code))))
+		}
+		if code == nil {
+			return nil, false, in.push(new(big.Int))
+		}
+		return nil, false, in.push(new(big.Int).SetBytes(Keccak256(code)))
+	case EXTCODECOPY:
+		args, err := in.pop(4)
+		if err != nil {
+			return nil, false, err
+		}
+		code, err := in.host.GetCode(toAddress(args[0]))
+		if err != nil {
+			return nil, false, err
+		}
+		return nil, false, in.copyData(args[1], args[2], args[3], code)
+	case SELFBALANCE:
+		return nil, false, in.push(new(big.Int).Set(in.host.GetBalance(in.env.Address)))
+	case ORIGIN:
+		return nil, false, in.push(new(big.Int).SetBytes(in.env.Origin))
+	case CALLER:
+		return nil, false, in.push(new(big.Int).SetBytes(in.env.Caller))
+	case CALLVALUE:
+		return nil, false, in.push(new(big.Int).Set(in.env.Value))
+	case CALLDATALOAD:
+		args, err := in.pop(1)
+		if err != nil {
+			return nil, false, err
+		}
+		word := make([]byte, WordLength)
+		copyPadded(word, in.env.Input, args[0])
+		return nil, false, in.push(new(big.Int).SetBytes(word))
+	case CALLDATASIZE:
+		return nil, false, in.push(big.NewInt(int64(len(in.env.Input))))
+	case CALLDATACOPY, CODECOPY, RETURNDATACOPY:
+		args, err := in.pop(3)
+		if err != nil {
+			return nil, false, err
+		}
+		var src []byte
+		switch op {
+		case CALLDATACOPY:
+			src = in.env.Input
+		case CODECOPY:
+			src = in.env.Code
+		default:
+			end := new(big.Int).Add(args[1], args[2])
+			if end.Cmp(big.NewInt(int64(len(in.returnData)))) > 0 {
+				return nil, false, scoreresult.InvalidParameterError.New(
+					"ReturnDataOutOfBounds")
+			}
+			src = in.returnData
+		}
+		return nil, false, in.copyData(args[0], args[1], args[2], src)
+	case CODESIZE:
+		return nil, false, in.push(big.NewInt(int64(len(in.env.Code))))
+	case RETURNDATASIZE:
+		return nil, false, in.push(big.NewInt(int64(len(in.returnData))))
+	case GASPRICE:
+		return nil, false, in.push(bigOrZero(in.env.GasPrice))
+	case BLOCKHASH:
+		// hashes of previous blocks aren't available in the world state.
+		if _, err := in.pop(1); err != nil {
+			return nil, false, err
+		}
+		return nil, false, in.push(new(big.Int))
+	case COINBASE, DIFFICULTY, BASEFEE:
+		return nil, false, in.push(new(big.Int))
+	case TIMESTAMP:
+		// block timestamp is in microseconds
+		return nil, false, in.push(big.NewInt(in.env.Timestamp / 1000000))
+	case NUMBER:
+		return nil, false, in.push(big.NewInt(in.env.BlockNumber))
+	case GASLIMIT:
+		return nil, false, in.push(new(big.Int).SetUint64(in.env.GasLimit))
+	case CHAINID:
+		return nil, false, in.push(bigOrZero(in.env.ChainID))
+	case POP:
+		_, err := in.pop(1)
+		return nil, false, err
+	case MLOAD:
+		args, err := in.pop(1)
+		if err != nil {
+			return nil, false, err
+		}
+		data, err := in.memoryRegion(args[0], big.NewInt(WordLength))
+		if err != nil {
+			return nil, false, err
+		}
+		return nil, false, in.push(new(big.Int).SetBytes(data))
+	case MSTORE, MSTORE8:
+		args, err := in.pop(2)
+		if err != nil {
+			return nil, false, err
+		}
+		if op == MSTORE {
+			data, err := in.memoryRegion(args[0], big.NewInt(WordLength))
+			if err != nil {
+				return nil, false, err
+			}
+			copy(data, toWord(args[1]))
+		} else {
+			data, err := in.memoryRegion(args[0], big.NewInt(1))
+			if err != nil {
+				return nil, false, err
+			}
+			data[0] = byte(args[1].Uint64() & 0xff)
+		}
+		return nil, false, nil
+	case SLOAD:
+		args, err := in.pop(1)
+		if err != nil {
+			return nil, false, err
+		}
+		value, err := in.host.GetStorage(toWord(args[0]))
+		if err != nil {
+			return nil, false, err
+		}
+		return nil, false, in.push(new(big.Int).SetBytes(value))
+	case SSTORE:
+		return nil, false, in.opSStore()
+	case JUMP:
+		args, err := in.pop(1)
+		if err != nil {
+			return nil, false, err
+		}
+		return nil, false, in.jump(args[0])
+	case JUMPI:
+		args, err := in.pop(2)
+		if err != nil {
+			return nil, false, err
+		}
+		if args[1].Sign() != 0 {
+			return nil, false, in.jump(args[0])
+		}
+		return nil, false, nil
+	case PC:
+		return nil, false, in.push(new(big.Int).SetUint64(in.pc))
+	case MSIZE:
+		return nil, false, in.push(big.NewInt(int64(len(in.memory))))
+	case GAS:
+		return nil, false, in.push(new(big.Int).SetUint64(in.gas))
+	case JUMPDEST:
+		return nil, false, nil
+	case CALL, STATICCALL, DELEGATECALL, CALLCODE:
+		return nil, false, in.opCall(op)
+	case CREATE, CREATE2:
+		return nil, false, in.opCreate(op)
+	case RETURN, REVERT:
+		args, err := in.pop(2)
+		if err != nil {
+			return nil, false, err
+		}
+		data, err := in.memoryRegion(args[0], args[1])
+		if err != nil {
+			return nil, false, err
+		}
+		ret := make([]byte, len(data))
+		copy(ret, data)
+		if op == REVERT {
+			return ret, true, ErrExecutionReverted
+		}
+		return ret, true, nil
+	case INVALID:
+		return nil, false, scoreresult.InvalidInstanceError.Errorf(
+			"InvalidOpcode(pc=%d)", in.pc)
+	case SELFDESTRUCT:
+		return nil, false, scoreresult.InvalidInstanceError.Errorf(
+			"UnsupportedOpcode(op=%#02x,pc=%d)", byte(op), in.pc)
+	default:
+		return nil, false, scoreresult.InvalidInstanceError.Errorf(
+			"UndefinedOpcode(op=%#02x,pc=%d)", byte(op), in.pc)
+	}
+}
+
+func bigOrZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(x)
+}
+
+func (in *interpreter) jump(dest *big.Int) error {
+	if !dest.IsUint64() || dest.Uint64() >= uint64(len(in.jumpDests)) ||
+		!in.jumpDests[dest.Uint64()] {
+		return scoreresult.InvalidInstanceError.Errorf(
+			"InvalidJump(dest=%s,pc=%d)", dest, in.pc)
+	}
+	// pc is increased after the operation
+	in.pc = dest.Uint64() - 1
+	return nil
+}
+
+func (in *interpreter) opBinary(op OpCode) error {
+	args, err := in.pop(2)
+	if err != nil {
+		return err
+	}
+	x, y := args[0], args[1]
+	r := new(big.Int)
+	switch op {
+	case ADD:
+		u256(r.Add(x, y))
+	case MUL:
+		u256(r.Mul(x, y))
+	case SUB:
+		u256(r.Sub(x, y))
+	case DIV:
+		if y.Sign() != 0 {
+			r.Div(x, y)
+		}
+	case SDIV:
+		if y.Sign() != 0 {
+			sx, sy := s256(x), s256(y)
+			r.Quo(sx, sy)
+			u256(r)
+		}
+	case MOD:
+		if y.Sign() != 0 {
+			r.Mod(x, y)
+		}
+	case SMOD:
+		if y.Sign() != 0 {
+			sx, sy := s256(x), s256(y)
+			r.Rem(sx, sy)
+			u256(r)
+		}
+	case EXP:
+		if err := in.useGas(uint64((y.BitLen()+7)/8) * gasExpByte); err != nil {
+			return err
+		}
+		r.Exp(x, y, tt256)
+	case SIGNEXTEND:
+		if x.Cmp(big.NewInt(31)) < 0 {
+			bit := uint(x.Uint64()*8 + 7)
+			mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bit), big.NewInt(1))
+			if y.Bit(int(bit)) == 1 {
+				r.Or(y, new(big.Int).Sub(tt256m1, mask))
+			} else {
+				r.And(y, mask)
+			}
+		} else {
+			r.Set(y)
+		}
+	case LT:
+		r.SetInt64(boolToInt(x.Cmp(y) < 0))
+	case GT:
+		r.SetInt64(boolToInt(x.Cmp(y) > 0))
+	case SLT:
+		r.SetInt64(boolToInt(s256(x).Cmp(s256(y)) < 0))
+	case SGT:
+		r.SetInt64(boolToInt(s256(x).Cmp(s256(y)) > 0))
+	case EQ:
+		r.SetInt64(boolToInt(x.Cmp(y) == 0))
+	case AND:
+		r.And(x, y)
+	case OR:
+		r.Or(x, y)
+	case XOR:
+		r.Xor(x, y)
+	case BYTE:
+		if x.Cmp(big.NewInt(WordLength)) < 0 {
+			r.SetInt64(int64(toWord(y)[x.Uint64()]))
+		}
+	case SHL:
+		if x.Cmp(big.NewInt(256)) < 0 {
+			u256(r.Lsh(y, uint(x.Uint64())))
+		}
+	case SHR:
+		if x.Cmp(big.NewInt(256)) < 0 {
+			r.Rsh(y, uint(x.Uint64()))
+		}
+	case SAR:
+		sy := s256(y)
+		if x.Cmp(big.NewInt(256)) < 0 {
+			r.Rsh(sy, uint(x.Uint64()))
+		} else if sy.Sign() < 0 {
+			r.SetInt64(-1)
+		}
+		u256(r)
+	}
+	return in.push(r)
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (in *interpreter) opSStore() error {
+	if in.env.ReadOnly {
+		return scoreresult.AccessDeniedError.New("SStoreInReadOnly")
+	}
+	args, err := in.pop(2)
+	if err != nil {
+		return err
+	}
+	key := toWord(args[0])
+	old, err := in.host.GetStorage(key)
+	if err != nil {
+		return err
+	}
+	gas := uint64(gasSStoreReset)
+	if len(old) == 0 && args[1].Sign() != 0 {
+		gas = gasSStoreSet
+	}
+	if err := in.useGas(gas); err != nil {
+		return err
+	}
+	if args[1].Sign() == 0 {
+		return in.host.SetStorage(key, nil)
+	}
+	return in.host.SetStorage(key, args[1].Bytes())
+}
+
+func (in *interpreter) opLog(n int) error {
+	if in.env.ReadOnly {
+		return scoreresult.AccessDeniedError.New("LogInReadOnly")
+	}
+	args, err := in.pop(2 + n)
+	if err != nil {
+		return err
+	}
+	data, err := in.memoryRegion(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	if err := in.useGas(uint64(n)*gasLogTopic + uint64(len(data))*gasLogByte); err != nil {
+		return err
+	}
+	topics := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		topics[i] = toWord(args[2+i])
+	}
+	logData := make([]byte, len(data))
+	copy(logData, data)
+	return in.host.AddLog(topics, logData)
+}
+
+func (in *interpreter) opCall(op OpCode) error {
+	var gasArg, to, value, inOff, inSize, outOff, outSize *big.Int
+	if op == CALL || op == CALLCODE {
+		args, err := in.pop(7)
+		if err != nil {
+			return err
+		}
+		gasArg, to, value = args[0], args[1], args[2]
+		inOff, inSize, outOff, outSize = args[3], args[4], args[5], args[6]
+	} else {
+		args, err := in.pop(6)
+		if err != nil {
+			return err
+		}
+		gasArg, to, value = args[0], args[1], new(big.Int)
+		inOff, inSize, outOff, outSize = args[2], args[3], args[4], args[5]
+	}
+	if op == CALL && value.Sign() != 0 && in.env.ReadOnly {
+		return scoreresult.AccessDeniedError.New("TransferInReadOnly")
+	}
+	input, err := in.memoryRegion(inOff, inSize)
+	if err != nil {
+		return err
+	}
+	if _, _, err := in.expandMemory(outOff, outSize); err != nil {
+		return err
+	}
+	if value.Sign() != 0 {
+		if err := in.useGas(gasCallValue); err != nil {
+			return err
+		}
+	}
+
+	// all but one 64th of the remaining gas can be forwarded
+	gas := in.gas - in.gas/64
+	if gasArg.IsUint64() && gasArg.Uint64() < gas {
+		gas = gasArg.Uint64()
+	}
+	if err := in.useGas(gas); err != nil {
+		return err
+	}
+	if value.Sign() != 0 {
+		gas += gasCallStipend
+	}
+
+	var kind CallKind
+	switch {
+	case op == DELEGATECALL:
+		// it keeps the value of the current call without transfer.
+		kind, value = CallDelegate, bigOrZero(in.env.Value)
+	case op == CALLCODE:
+		kind = CallCode
+	case op == STATICCALL || in.env.ReadOnly:
+		kind = CallStatic
+	default:
+		kind = CallNormal
+	}
+	var ret []byte
+	var gasLeft uint64
+	if in.env.Depth >= maxCallDepth {
+		err = scoreresult.StackOverflowError.New("EVMCallDepthExceeded")
+		gasLeft = gas
+	} else {
+		ret, gasLeft, err = in.host.Call(kind, toAddress(to), value,
+			append([]byte(nil), input...), gas)
+	}
+	in.gas += gasLeft
+	in.returnData = ret
+
+	if sz := outSize.Uint64(); sz > 0 {
+		out := in.memory[outOff.Uint64() : outOff.Uint64()+sz]
+		copy(out, ret)
+	}
+	if err != nil {
+		return in.push(new(big.Int))
+	}
+	return in.push(big.NewInt(1))
+}
+
+func (in *interpreter) opCreate(op OpCode) error {
+	n := 3
+	if op == CREATE2 {
+		n = 4
+	}
+	args, err := in.pop(n)
+	if err != nil {
+		return err
+	}
+	value := args[0]
+	var salt []byte
+	if op == CREATE2 {
+		salt = toWord(args[3])
+	}
+	if in.env.ReadOnly {
+		return scoreresult.AccessDeniedError.New("CreateInReadOnly")
+	}
+	code, err := in.memoryRegion(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	if op == CREATE2 {
+		// the init code is hashed for the address
+		if err := in.useGas(toWordCount(uint64(len(code))) * gasSha3Word); err != nil {
+			return err
+		}
+	}
+
+	gas := in.gas - in.gas/64
+	if err := in.useGas(gas); err != nil {
+		return err
+	}
+	var addr, ret []byte
+	var gasLeft uint64
+	if in.env.Depth >= maxCallDepth {
+		err = scoreresult.StackOverflowError.New("EVMCallDepthExceeded")
+		gasLeft = gas
+	} else {
+		addr, ret, gasLeft, err = in.host.Create(value,
+			append([]byte(nil), code...), salt, gas)
+	}
+	in.gas += gasLeft
+	if err != nil {
+		in.returnData = ret
+		return in.push(new(big.Int))
+	}
+	in.returnData = nil
+	return in.push(new(big.Int).SetBytes(addr))
+}
+
+// CreateAddress returns the address of the contract created by CREATE.
+// It's the last 20 bytes of the hash of RLP encoded creator and nonce.
+func CreateAddress(creator []byte, nonce uint64) []byte {
+	var n []byte
+	for v := nonce; v > 0; v >>= 8 {
+		n = append([]byte{byte(v)}, n...)
+	}
+	if len(n) != 1 || n[0] >= 0x80 {
+		n = append([]byte{0x80 + byte(len(n))}, n...)
+	}
+	data := make([]byte, 0, 2+AddressLength+len(n))
+	data = append(data, 0xc0+byte(1+AddressLength+len(n)), 0x80+AddressLength)
+	data = append(data, creator...)
+	data = append(data, n...)
+	return Keccak256(data)[WordLength-AddressLength:]
+}
+
+// CreateAddress2 returns the address of the contract created by CREATE2.
+func CreateAddress2(creator []byte, salt []byte, code []byte) []byte {
+	return Keccak256([]byte{0xff}, creator, salt, Keccak256(code))[WordLength-AddressLength:]
+}
+
+func Keccak256(data ...[]byte) []byte {
+	d := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		d.Write(b)
+	}
+	return d.Sum(nil)
+}
@@ -0,0 +1,293 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+type testLog struct {
+	topics [][]byte
+	data   []byte
+}
+
+type testCall struct {
+	kind  CallKind
+	to    []byte
+	value *big.Int
+}
+
+type testHost struct {
+	storage map[string][]byte
+	codes   map[string][]byte
+	logs    []testLog
+	calls   []testCall
+	creates [][]byte
+}
+
+func newTestHost() *testHost {
+	return &testHost{
+		storage: make(map[string][]byte),
+		codes:   make(map[string][]byte),
+	}
+}
+
+func (h *testHost) GetStorage(key []byte) ([]byte, error) {
+	return h.storage[string(key)], nil
+}
+
+func (h *testHost) SetStorage(key []byte, value []byte) error {
+	if value == nil {
+		delete(h.storage, string(key))
+	} else {
+		h.storage[string(key)] = value
+	}
+	return nil
+}
+
+func (h *testHost) GetBalance(addr []byte) *big.Int {
+	return big.NewInt(0)
+}
+
+func (h *testHost) AddLog(topics [][]byte, data []byte) error {
+	h.logs = append(h.logs, testLog{topics, data})
+	return nil
+}
+
+func (h *testHost) Call(kind CallKind, to []byte, value *big.Int, input []byte, gas uint64) ([]byte, uint64, error) {
+	h.calls = append(h.calls, testCall{kind, to, value})
+	return nil, gas, nil
+}
+
+func (h *testHost) GetCode(addr []byte) ([]byte, error) {
+	return h.codes[string(addr)], nil
+}
+
+func (h *testHost) Create(value *big.Int, code []byte, salt []byte, gas uint64) ([]byte, []byte, uint64, error) {
+	h.creates = append(h.creates, code)
+	if salt != nil {
+		return CreateAddress2(make([]byte, AddressLength), salt, code), nil, gas, nil
+	}
+	return CreateAddress(make([]byte, AddressLength), uint64(len(h.creates))), nil, gas, nil
+}
+
+func mustDecodeHex(s string) []byte {
+	bs, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+func execute(t *testing.T, host Host, code string, gas uint64) ([]byte, uint64, error) {
+	env := &Environment{
+		Address: make([]byte, AddressLength),
+		Caller:  make([]byte, AddressLength),
+		Origin:  make([]byte, AddressLength),
+		Value:   new(big.Int),
+		Code:    mustDecodeHex(code),
+	}
+	return Execute(env, host, gas)
+}
+
+func TestExecute_ArithmeticAndReturn(t *testing.T) {
+	// PUSH1 2, PUSH1 3, ADD, PUSH1 0, MSTORE, PUSH1 32, PUSH1 0, RETURN
+	host := newTestHost()
+	ret, left, err := execute(t, host, "600260030160005260206000f3", 100000)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(5), new(big.Int).SetBytes(ret))
+	assert.True(t, left < 100000)
+}
+
+func TestExecute_SubWraps(t *testing.T) {
+	// PUSH1 1, PUSH1 0, SUB => 0 - 1 = 2^256-1
+	host := newTestHost()
+	ret, _, err := execute(t, host, "600160000360005260206000f3", 100000)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, new(big.Int).SetBytes(ret).Cmp(tt256m1))
+}
+
+func TestExecute_Storage(t *testing.T) {
+	// PUSH1 0x2a, PUSH1 1, SSTORE, PUSH1 1, SLOAD, PUSH1 0, MSTORE,
+	// PUSH1 32, PUSH1 0, RETURN
+	host := newTestHost()
+	ret, _, err := execute(t, host, "602a60015560015460005260206000f3", 100000)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), new(big.Int).SetBytes(ret))
+
+	key := make([]byte, WordLength)
+	key[WordLength-1] = 1
+	assert.Equal(t, []byte{0x2a}, host.storage[string(key)])
+
+	// PUSH1 0, PUSH1 1, SSTORE
+	_, _, err = execute(t, host, "6000600155", 100000)
+	assert.NoError(t, err)
+	assert.Len(t, host.storage, 0)
+}
+
+func TestExecute_Revert(t *testing.T) {
+	// PUSH1 0x7, PUSH1 0, MSTORE, PUSH1 32, PUSH1 0, REVERT
+	host := newTestHost()
+	ret, left, err := execute(t, host, "600760005260206000fd", 100000)
+	assert.Equal(t, ErrExecutionReverted, err)
+	assert.Equal(t, big.NewInt(7), new(big.Int).SetBytes(ret))
+	assert.True(t, left > 0)
+}
+
+func TestExecute_Log(t *testing.T) {
+	// PUSH1 0xff, PUSH1 0, MSTORE8, PUSH1 0xaa, PUSH1 1, PUSH1 0, LOG1
+	host := newTestHost()
+	_, _, err := execute(t, host, "60ff60005360aa60016000a1", 100000)
+	assert.NoError(t, err)
+	assert.Len(t, host.logs, 1)
+	assert.Equal(t, []byte{0xff}, host.logs[0].data)
+	assert.Len(t, host.logs[0].topics, 1)
+	assert.Equal(t, big.NewInt(0xaa), new(big.Int).SetBytes(host.logs[0].topics[0]))
+}
+
+func TestExecute_Failures(t *testing.T) {
+	host := newTestHost()
+
+	// infinite loop : JUMPDEST, PUSH1 0, JUMP
+	_, left, err := execute(t, host, "5b600056", 1000)
+	assert.True(t, errors.Is(err, scoreresult.ErrOutOfStep))
+	assert.EqualValues(t, 0, left)
+
+	// jump into push data : PUSH1 3, JUMP, PUSH1 0x5b
+	_, _, err = execute(t, host, "600356605b", 1000)
+	assert.Error(t, err)
+	assert.Equal(t, scoreresult.InvalidInstanceError, errors.CodeOf(err))
+
+	// stack underflow : ADD
+	_, _, err = execute(t, host, "01", 1000)
+	assert.Error(t, err)
+
+	// unsupported : PUSH1 0, SELFDESTRUCT
+	_, _, err = execute(t, host, "6000ff", 1000)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "UnsupportedOpcode")
+
+	// create in read-only : PUSH1 0, PUSH1 0, PUSH1 0, CREATE
+	env := &Environment{
+		Address:  make([]byte, AddressLength),
+		Value:    new(big.Int),
+		Code:     mustDecodeHex("600060006000f0"),
+		ReadOnly: true,
+	}
+	_, _, err = Execute(env, host, 100000)
+	assert.Equal(t, scoreresult.AccessDeniedError, errors.CodeOf(err))
+	assert.Len(t, host.creates, 0)
+}
+
+func TestExecute_Create(t *testing.T) {
+	host := newTestHost()
+
+	// PUSH1 0, PUSH1 0, PUSH1 0, CREATE, PUSH1 0, MSTORE,
+	// PUSH1 32, PUSH1 0, RETURN
+	ret, _, err := execute(t, host, "600060006000f060005260206000f3", 100000)
+	assert.NoError(t, err)
+	assert.Equal(t, CreateAddress(make([]byte, AddressLength), 1), ret[WordLength-AddressLength:])
+
+	// PUSH1 7, PUSH1 0, PUSH1 0, PUSH1 0, CREATE2, PUSH1 0, MSTORE,
+	// PUSH1 32, PUSH1 0, RETURN
+	ret, _, err = execute(t, host, "6007600060006000f560005260206000f3", 100000)
+	assert.NoError(t, err)
+	salt := make([]byte, WordLength)
+	salt[WordLength-1] = 7
+	assert.Equal(t, CreateAddress2(make([]byte, AddressLength), salt, []byte{}), ret[WordLength-AddressLength:])
+	assert.Len(t, host.creates, 2)
+
+	// not enough gas for CREATE
+	_, _, err = execute(t, host, "600060006000f0", 30000)
+	assert.True(t, errors.Is(err, scoreresult.ErrOutOfStep))
+}
+
+func TestExecute_DelegateCallAndCallCode(t *testing.T) {
+	host := newTestHost()
+	env := &Environment{
+		Address: make([]byte, AddressLength),
+		Value:   big.NewInt(5),
+		// PUSH1 0, PUSH1 0, PUSH1 0, PUSH1 0, PUSH1 0x11, GAS, DELEGATECALL,
+		// PUSH1 0, PUSH1 0, PUSH1 0, PUSH1 0, PUSH1 3, PUSH1 0x12, GAS, CALLCODE,
+		// ADD, PUSH1 0, MSTORE, PUSH1 32, PUSH1 0, RETURN
+		Code: mustDecodeHex("600060006000600060115af4" +
+			"6000600060006000600360125af2" +
+			"0160005260206000f3"),
+	}
+	ret, _, err := Execute(env, host, 100000)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), new(big.Int).SetBytes(ret))
+
+	assert.Len(t, host.calls, 2)
+	assert.Equal(t, CallDelegate, host.calls[0].kind)
+	assert.Equal(t, toAddress(big.NewInt(0x11)), host.calls[0].to)
+	assert.Equal(t, big.NewInt(5), host.calls[0].value)
+	assert.Equal(t, CallCode, host.calls[1].kind)
+	assert.Equal(t, toAddress(big.NewInt(0x12)), host.calls[1].to)
+	assert.Equal(t, big.NewInt(3), host.calls[1].value)
+}
+
+func TestExecute_ExtCode(t *testing.T) {
+	host := newTestHost()
+	code := mustDecodeHex("6001600101")
+	host.codes[string(toAddress(big.NewInt(0x11)))] = code
+
+	// PUSH1 0x11, EXTCODESIZE, PUSH1 0, MSTORE, PUSH1 32, PUSH1 0, RETURN
+	ret, _, err := execute(t, host, "60113b60005260206000f3", 100000)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(int64(len(code))), new(big.Int).SetBytes(ret))
+
+	// PUSH1 0x11, EXTCODEHASH, PUSH1 0, MSTORE, PUSH1 32, PUSH1 0, RETURN
+	ret, _, err = execute(t, host, "60113f60005260206000f3", 100000)
+	assert.NoError(t, err)
+	assert.Equal(t, Keccak256(code), ret)
+
+	// PUSH1 0x12, EXTCODEHASH, ... for an account without code
+	ret, _, err = execute(t, host, "60123f60005260206000f3", 100000)
+	assert.NoError(t, err)
+	assert.Equal(t, make([]byte, WordLength), ret)
+
+	// PUSH1 32, PUSH1 0, PUSH1 0, PUSH1 0x11, EXTCODECOPY,
+	// PUSH1 32, PUSH1 0, RETURN
+	ret, _, err = execute(t, host, "60206000600060113c60206000f3", 100000)
+	assert.NoError(t, err)
+	expected := make([]byte, WordLength)
+	copy(expected, code)
+	assert.Equal(t, expected, ret)
+}
+
+func TestCreateAddress(t *testing.T) {
+	creator := mustDecodeHex("6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	assert.Equal(t, mustDecodeHex("cd234a471b72ba2f1ccf0a70fcaba648a5eecd8d"),
+		CreateAddress(creator, 0))
+	assert.Equal(t, mustDecodeHex("343c43a37d37dff08ae8c4a11544c718abb4fcf8"),
+		CreateAddress(creator, 1))
+
+	// examples of EIP-1014
+	assert.Equal(t, mustDecodeHex("4d1a2e2bb4f88f0250f26ffff098b0b30b26bf38"),
+		CreateAddress2(make([]byte, AddressLength), make([]byte, WordLength), []byte{0}))
+	assert.Equal(t, mustDecodeHex("b928f69bb1d91cd65274e3c79d8986362984fda3"),
+		CreateAddress2(mustDecodeHex("deadbeef00000000000000000000000000000000"),
+			make([]byte, WordLength), []byte{0}))
+}
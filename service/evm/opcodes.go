@@ -0,0 +1,194 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm
+
+type OpCode byte
+
+const (
+	STOP       OpCode = 0x00
+	ADD        OpCode = 0x01
+	MUL        OpCode = 0x02
+	SUB        OpCode = 0x03
+	DIV        OpCode = 0x04
+	SDIV       OpCode = 0x05
+	MOD        OpCode = 0x06
+	SMOD       OpCode = 0x07
+	ADDMOD     OpCode = 0x08
+	MULMOD     OpCode = 0x09
+	EXP        OpCode = 0x0a
+	SIGNEXTEND OpCode = 0x0b
+
+	LT     OpCode = 0x10
+	GT     OpCode = 0x11
+	SLT    OpCode = 0x12
+	SGT    OpCode = 0x13
+	EQ     OpCode = 0x14
+	ISZERO OpCode = 0x15
+	AND    OpCode = 0x16
+	OR     OpCode = 0x17
+	XOR    OpCode = 0x18
+	NOT    OpCode = 0x19
+	BYTE   OpCode = 0x1a
+	SHL    OpCode = 0x1b
+	SHR    OpCode = 0x1c
+	SAR    OpCode = 0x1d
+
+	SHA3 OpCode = 0x20
+
+	ADDRESS        OpCode = 0x30
+	BALANCE        OpCode = 0x31
+	ORIGIN         OpCode = 0x32
+	CALLER         OpCode = 0x33
+	CALLVALUE      OpCode = 0x34
+	CALLDATALOAD   OpCode = 0x35
+	CALLDATASIZE   OpCode = 0x36
+	CALLDATACOPY   OpCode = 0x37
+	CODESIZE       OpCode = 0x38
+	CODECOPY       OpCode = 0x39
+	GASPRICE       OpCode = 0x3a
+	EXTCODESIZE    OpCode = 0x3b
+	EXTCODECOPY    OpCode = 0x3c
+	RETURNDATASIZE OpCode = 0x3d
+	RETURNDATACOPY OpCode = 0x3e
+	EXTCODEHASH    OpCode = 0x3f
+
+	BLOCKHASH   OpCode = 0x40
+	COINBASE    OpCode = 0x41
+	TIMESTAMP   OpCode = 0x42
+	NUMBER      OpCode = 0x43
+	DIFFICULTY  OpCode = 0x44
+	GASLIMIT    OpCode = 0x45
+	CHAINID     OpCode = 0x46
+	SELFBALANCE OpCode = 0x47
+	BASEFEE     OpCode = 0x48
+
+	POP      OpCode = 0x50
+	MLOAD    OpCode = 0x51
+	MSTORE   OpCode = 0x52
+	MSTORE8  OpCode = 0x53
+	SLOAD    OpCode = 0x54
+	SSTORE   OpCode = 0x55
+	JUMP     OpCode = 0x56
+	JUMPI    OpCode = 0x57
+	PC       OpCode = 0x58
+	MSIZE    OpCode = 0x59
+	GAS      OpCode = 0x5a
+	JUMPDEST OpCode = 0x5b
+	PUSH0    OpCode = 0x5f
+	PUSH1    OpCode = 0x60
+	PUSH32   OpCode = 0x7f
+	DUP1     OpCode = 0x80
+	DUP16    OpCode = 0x8f
+	SWAP1    OpCode = 0x90
+	SWAP16   OpCode = 0x9f
+	LOG0     OpCode = 0xa0
+	LOG4     OpCode = 0xa4
+
+	CREATE       OpCode = 0xf0
+	CALL         OpCode = 0xf1
+	CALLCODE     OpCode = 0xf2
+	RETURN       OpCode = 0xf3
+	DELEGATECALL OpCode = 0xf4
+	CREATE2      OpCode = 0xf5
+	STATICCALL   OpCode = 0xfa
+	REVERT       OpCode = 0xfd
+	INVALID      OpCode = 0xfe
+	SELFDESTRUCT OpCode = 0xff
+)
+
+// gas costs of operations
+const (
+	gasZero    = 0
+	gasBase    = 2
+	gasVeryLow = 3
+	gasLow     = 5
+	gasMid     = 8
+	gasHigh    = 10
+
+	gasJumpDest     = 1
+	gasExp          = 10
+	gasExpByte      = 50
+	gasSha3         = 30
+	gasSha3Word     = 6
+	gasCopyWord     = 3
+	gasBalance      = 700
+	gasBlockHash    = 20
+	gasSLoad        = 800
+	gasSStoreSet    = 20000
+	gasSStoreReset  = 5000
+	gasLog          = 375
+	gasLogTopic     = 375
+	gasLogByte      = 8
+	gasMemoryWord   = 3
+	gasQuadCoeffDiv = 512
+	gasCall         = 700
+	gasCallValue    = 9000
+	gasCallStipend  = 2300
+	gasCreate       = 32000
+	gasExtCode      = 700
+)
+
+// constGas returns the constant part of the gas for the operation. Dynamic
+// parts are charged by the operations.
+func constGas(op OpCode) uint64 {
+	switch {
+	case op >= PUSH1 && op <= PUSH32,
+		op >= DUP1 && op <= DUP16,
+		op >= SWAP1 && op <= SWAP16:
+		return gasVeryLow
+	case op >= LOG0 && op <= LOG4:
+		return gasLog
+	}
+	switch op {
+	case STOP, RETURN, REVERT:
+		return gasZero
+	case ADDRESS, ORIGIN, CALLER, CALLVALUE, CALLDATASIZE, CODESIZE,
+		GASPRICE, RETURNDATASIZE, COINBASE, TIMESTAMP, NUMBER, DIFFICULTY,
+		GASLIMIT, CHAINID, BASEFEE, POP, PC, MSIZE, GAS, PUSH0:
+		return gasBase
+	case ADD, SUB, NOT, LT, GT, SLT, SGT, EQ, ISZERO, AND, OR, XOR, BYTE,
+		SHL, SHR, SAR, CALLDATALOAD, MLOAD, MSTORE, MSTORE8,
+		CALLDATACOPY, CODECOPY, RETURNDATACOPY:
+		return gasVeryLow
+	case MUL, DIV, SDIV, MOD, SMOD, SIGNEXTEND, SELFBALANCE:
+		return gasLow
+	case ADDMOD, MULMOD, JUMP:
+		return gasMid
+	case JUMPI:
+		return gasHigh
+	case JUMPDEST:
+		return gasJumpDest
+	case EXP:
+		return gasExp
+	case SHA3:
+		return gasSha3
+	case BALANCE:
+		return gasBalance
+	case BLOCKHASH:
+		return gasBlockHash
+	case SLOAD:
+		return gasSLoad
+	case EXTCODESIZE, EXTCODECOPY, EXTCODEHASH:
+		return gasExtCode
+	case CALL, STATICCALL, CALLCODE, DELEGATECALL:
+		return gasCall
+	case CREATE, CREATE2:
+		return gasCreate
+	default:
+		return gasZero
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"github.com/icon-project/goloop/chain/base"
 	"github.com/icon-project/goloop/module"
 )
 
@@ -19,6 +20,11 @@ func Inspect(c module.Chain, informal bool) map[string]interface{} {
 	m["normalTxPool"] = inspectTxPool(mgr.tm.normalTxPool)
 	m["patchTxPool"] = inspectTxPool(mgr.tm.patchTxPool)
 	m["resultCache"] = inspectResultCache(mgr.trc)
+	if pi, ok := mgr.plt.(base.PlatformInspector); ok {
+		if pm := pi.Inspect(informal); pm != nil {
+			m["platform"] = pm
+		}
+	}
 	return m
 }
 
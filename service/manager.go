@@ -1,15 +1,19 @@
 package service
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"os"
+	"path"
 	"sync/atomic"
 	"time"
 
 	"github.com/icon-project/goloop/btp"
 	"github.com/icon-project/goloop/chain/base"
 	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/merkle"
 	"github.com/icon-project/goloop/network"
 	"github.com/icon-project/goloop/service/scoreresult"
@@ -42,6 +46,7 @@ type manager struct {
 	// Currently, it doesn't allow another branch, so add tx pool here.
 	tim TXIDManager
 	tm  *TransactionManager
+	tv  *TxVerifier
 
 	patchMetric  *metric.TxMetric
 	normalMetric *metric.TxMetric
@@ -76,6 +81,7 @@ func NewManager(chain module.Chain, nm module.NetworkManager,
 		return nil, err
 	}
 	tsc := NewTimestampChecker()
+	tsc.SetWindow(chain.TxTimestampWindow())
 	droppedTxSlotSize := chain.NormalTxPoolSize()
 	if droppedTxSlotSize > ConfigMaxDroppedTxSlotSize {
 		droppedTxSlotSize = ConfigMaxDroppedTxSlotSize
@@ -88,8 +94,13 @@ func NewManager(chain module.Chain, nm module.NetworkManager,
 	}
 	pTxPool := NewTransactionPool(module.TransactionGroupPatch, chain.PatchTxPoolSize(), tim, pMetric, logger)
 	nTxPool := NewTransactionPool(module.TransactionGroupNormal, chain.NormalTxPoolSize(), tim, nMetric, logger)
+	nTxPool.SetTTL(chain.TxPoolTTL())
+	nTxPool.SetPriorityRatio(chain.PriorityTxRatio())
 	tm := NewTransactionManager(chain.NID(), tsc, pTxPool, nTxPool, tim, logger)
-	syncm := ssync.NewSyncManager(chain.Database(), chain.NetworkManager(), plt, logger)
+	tm.SetRegulator(chain.Regulator())
+	tv := NewTxVerifier(ConfigTxVerifierCacheSize)
+	tm.SetVerifier(tv)
+	syncm := ssync.NewSyncManager(chain.Database(), chain.NetworkManager(), plt, chain.SyncServerThrottle(), logger)
 
 	mgr := &manager{
 		patchMetric:  pMetric,
@@ -108,6 +119,7 @@ func NewManager(chain module.Chain, nm module.NetworkManager,
 		log: logger,
 		tsc: tsc,
 		tim: tim,
+		tv:  tv,
 	}
 	if nm != nil {
 		mgr.txReactor = NewTransactionReactor(nm, tm)
@@ -116,6 +128,7 @@ func NewManager(chain module.Chain, nm module.NetworkManager,
 }
 
 func (m *manager) Start() {
+	m.restoreTransactions()
 	if m.txReactor != nil {
 		m.txReactor.Start(m.chain.Wallet())
 		m.syncer.Start()
@@ -127,12 +140,50 @@ func (m *manager) Term() {
 		m.txReactor.Stop()
 		m.syncer.Term()
 	}
+	m.tm.patchTxPool.CloseJournal()
+	m.tm.normalTxPool.CloseJournal()
 	m.chain = nil
 	m.cm = nil
 	m.eem = nil
 	m.db = nil
 }
 
+// restoreTransactions adds transactions persisted in the journals to the
+// pools after validation, then the journals record transactions of the
+// pools. Invalid ones are dropped from the journals.
+func (m *manager) restoreTransactions() {
+	dir := m.chain.TxPoolDir()
+	if dir == "" {
+		return
+	}
+	if err := os.MkdirAll(dir, txJournalDirPermission); err != nil {
+		m.log.Warnf("Fail to make directory for journals dir=%s err=%+v", dir, err)
+		return
+	}
+	for _, tp := range []*TransactionPool{m.tm.patchTxPool, m.tm.normalTxPool} {
+		name := txJournalNameOf(tp.group)
+		j, entries, err := openTxJournal(path.Join(dir, name))
+		if err != nil {
+			m.log.Warnf("Fail to open journal name=%s err=%+v", name, err)
+			continue
+		}
+		restored := 0
+		for _, e := range entries {
+			if e.tx.Group() != tp.group {
+				continue
+			}
+			if err := m.tm.Add(e.tx, e.direct, false); err != nil {
+				m.log.Debugf("DROP RESTORED TX: id=%#x reason=%v", e.tx.ID(), err)
+				continue
+			}
+			restored += 1
+		}
+		tp.SetJournal(j)
+		m.log.Infof("Restored transactions name=%s restored=%d recorded=%d",
+			name, restored, len(entries))
+	}
+}
+
 // ProposeTransition proposes a Transition following the parent Transition.
 // parent transition should have a valid result.
 // Returned Transition always passes validation.
@@ -143,16 +194,24 @@ func (m *manager) ProposeTransition(parent module.Transition, bi module.BlockInf
 		return nil, err
 	}
 
-	ws, _ := state.WorldStateFromSnapshot(pt.worldSnapshot)
+	ws := state.NewWorldOverlayState(pt.worldSnapshot)
 	wc := state.NewWorldContext(ws, bi, csi, m.plt)
 
 	baseTx, err := m.plt.NewBaseTransaction(wc)
 	if err != nil {
 		return nil, err
 	}
-	maxTxCount := m.chain.Regulator().MaxTxCount()
-	txSizeInBlock := m.chain.MaxBlockTxBytes()
-	normalTxs, _ := m.tm.Candidate(module.TransactionGroupNormal, wc, txSizeInBlock, maxTxCount)
+	normalTxs, err := m.tm.Bundle(wc)
+	if err != nil {
+		m.log.Warnf("drop bundle err=%+v", err)
+		ws = state.NewWorldOverlayState(pt.worldSnapshot)
+		wc = state.NewWorldContext(ws, bi, csi, m.plt)
+	}
+	if normalTxs == nil {
+		maxTxCount := m.chain.Regulator().MaxTxCount()
+		txSizeInBlock := m.chain.MaxBlockTxBytes()
+		normalTxs, _ = m.tm.Candidate(module.TransactionGroupNormal, wc, txSizeInBlock, maxTxCount)
+	}
 	if baseTx != nil {
 		normalTxs = append([]module.Transaction{baseTx}, normalTxs...)
 	}
@@ -174,7 +233,7 @@ func (m *manager) ProposeTransition(parent module.Transition, bi module.BlockInf
 func (m *manager) CreateInitialTransition(result []byte,
 	valList module.ValidatorList,
 ) (module.Transition, error) {
-	return newInitTransition(m.db, result, valList, m.cm, m.eem, m.chain, m.log, m.plt, m.tsc, m.tim)
+	return newInitTransition(m.db, result, valList, m.cm, m.eem, m.chain, m.log, m.plt, m.tsc, m.tim, m.tv)
 }
 
 // CreateTransition creates a Transition following parent Transition with txs
@@ -296,6 +355,7 @@ func (m *manager) Finalize(t module.Transition, opt int) error {
 			if err := tst.finalizeResult(false, keepParent); err != nil {
 				return err
 			}
+			m.trc.OnFinalize(tst.Result())
 			m.tm.NotifyFinalized(tst.patchTransactions, tst.patchReceipts, tst.normalTransactions, tst.normalReceipts)
 			now := time.Now()
 			m.patchMetric.OnFinalize(tst.patchTransactions.Hash(), now)
@@ -463,6 +523,89 @@ func (m *manager) SendTransaction(result []byte, height int64, txi interface{})
 	return newTx.ID(), nil
 }
 
+func (m *manager) SendTransactionBundle(result []byte, height int64, txs []interface{}, sig []byte) ([][]byte, error) {
+	sequencer := m.chain.Sequencer()
+	if sequencer == nil {
+		return nil, errors.InvalidStateError.New("NoSequencer")
+	}
+	if len(txs) == 0 {
+		return nil, errors.IllegalArgumentError.New("EmptyBundle")
+	}
+	bundle := make([]transaction.Transaction, 0, len(txs))
+	ids := make([][]byte, 0, len(txs))
+	size := 0
+	for _, txi := range txs {
+		tx, err := newTransaction(txi)
+		if err != nil {
+			return nil, err
+		}
+		if tx.Group() != module.TransactionGroupNormal {
+			return nil, errors.IllegalArgumentError.Errorf(
+				"InvalidTxGroup(id=%#x,group=%d)", tx.ID(), tx.Group())
+		}
+		if err := m.tm.VerifyTx(tx); err != nil {
+			return nil, err
+		}
+		if err := m.preValidateTx(result, height, tx); err != nil {
+			return nil, err
+		}
+		size += len(tx.Bytes())
+		bundle = append(bundle, tx)
+		ids = append(ids, tx.ID())
+	}
+	if size > m.chain.MaxBlockTxBytes() {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"TooBigBundle(size=%d,max=%d)", size, m.chain.MaxBlockTxBytes())
+	}
+	s, err := crypto.ParseSignature(sig)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidSignature")
+	}
+	pk, err := s.RecoverPublicKey(transaction.BundleHash(ids))
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidSignature")
+	}
+	if signer := common.NewAccountAddressFromPublicKey(pk); !signer.Equal(sequencer) {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidSigner(signer=%s,sequencer=%s)", signer, sequencer)
+	}
+	if err := m.tm.AddBundle(bundle); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (m *manager) InspectTransaction(result []byte, height int64, txi interface{}) (*module.TransactionInspection, error) {
+	tx, err := newTransaction(txi)
+	if err != nil {
+		return nil, err
+	}
+	ti := &module.TransactionInspection{Transaction: tx}
+	if signer, err := transaction.SignerOf(tx); err == nil {
+		ti.Signer = signer
+	}
+	if err := m.tm.VerifyTx(tx); err != nil {
+		ti.Errors = append(ti.Errors, err)
+	} else if err := m.tm.tim.CheckTXForAdd(tx); err != nil {
+		ti.Errors = append(ti.Errors, err)
+	}
+
+	wc, err := m.trc.GetWorldContext(result, nil)
+	if err != nil {
+		return nil, err
+	}
+	wcw := &worldContextWrapper{wc, height}
+	if minStep, err := transaction.MinimumStepOf(wcw, tx); err != nil {
+		ti.Errors = append(ti.Errors, err)
+	} else {
+		ti.MinimumStep = minStep
+	}
+	if err := tx.PreValidate(wcw, false); err != nil {
+		ti.Errors = append(ti.Errors, err)
+	}
+	return ti, nil
+}
+
 func (m *manager) Call(resultHash []byte,
 	vl module.ValidatorList, js []byte, bi module.BlockInfo,
 ) (interface{}, error) {
@@ -480,11 +623,8 @@ func (m *manager) Call(resultHash []byte,
 		return nil, InvalidQueryError.New("InvalidDataType")
 	}
 
-	var wc state.WorldContext
-	if wss, err := m.trc.GetWorldSnapshot(resultHash, vl.Hash()); err == nil {
-		ws := state.NewReadOnlyWorldState(wss)
-		wc = state.NewWorldContext(ws, bi, nil, m.plt)
-	} else {
+	wc, err := m.trc.GetQueryContext(resultHash, vl.Hash(), bi)
+	if err != nil {
 		return nil, err
 	}
 
@@ -602,6 +742,7 @@ func contractToJSON(c state.ContractSnapshot, version module.JSONVersion) interf
 		ret["auditTxHash"] = fmt.Sprintf("%#x", txHash)
 	}
 	ret["type"] = c.EEType()
+	ret["contentType"] = c.ContentType()
 	ret["codeHash"] = fmt.Sprintf("%#x", c.CodeHash())
 	return ret
 }
@@ -664,6 +805,52 @@ func (m *manager) GetSCOREStatus(result []byte, addr module.Address) (module.SCO
 	}, nil
 }
 
+type feeSharingInfo struct {
+	ass       state.AccountSnapshot
+	sysConfig int64
+}
+
+func (s *feeSharingInfo) ToJSON(height int64, version module.JSONVersion) (interface{}, error) {
+	ret := make(map[string]interface{})
+	ret["feeSharingEnabled"] = "0x0"
+	if (s.sysConfig & state.SysConfigFeeSharing) != 0 {
+		ret["feeSharingEnabled"] = "0x1"
+	}
+	ret["useSystemDeposit"] = "0x0"
+	if s.ass.UseSystemDeposit() {
+		ret["useSystemDeposit"] = "0x1"
+	}
+	dc := dummyDepositContext{height: height}
+	if di, err := s.ass.GetDepositInfo(dc, version); err != nil {
+		return nil, scoreresult.New(module.StatusUnknownFailure, "FailOnDepositInfo")
+	} else if di != nil {
+		ret["depositInfo"] = di
+	}
+	return ret, nil
+}
+
+func (m *manager) GetFeeSharingInfo(result []byte, addr module.Address) (module.FeeSharingInfo, error) {
+	if !addr.IsContract() {
+		return nil, errors.IllegalArgumentError.Errorf("Given Address(%s) isn't contract", addr)
+	}
+	wss, err := m.trc.GetWorldSnapshot(result, nil)
+	if err != nil {
+		return nil, err
+	}
+	ass := wss.GetAccountSnapshot(addr.ID())
+	if ass == nil || !ass.IsContract() {
+		return nil, errors.NotFoundError.Errorf("NoValidContract(addr=%s)", addr)
+	}
+	var sysConfig int64
+	if sas := wss.GetAccountSnapshot(state.SystemID); sas != nil {
+		sysConfig = scoredb.NewVarDB(scoredb.NewStateStoreWith(sas), state.VarServiceConfig).Int64()
+	}
+	return &feeSharingInfo{
+		ass:       ass,
+		sysConfig: sysConfig,
+	}, nil
+}
+
 func (m *manager) GetMembers(result []byte) (module.MemberList, error) {
 	wss, err := m.trc.GetWorldSnapshot(result, nil)
 	if err != nil {
@@ -711,6 +898,81 @@ func (m *manager) GetNextBlockVersion(result []byte) int {
 	return v
 }
 
+func (m *manager) GetRevision(result []byte) int {
+	if result == nil {
+		return 0
+	}
+	as, err := m.getSystemByteStoreState(result)
+	if err != nil {
+		return -1
+	}
+	return int(scoredb.NewVarDB(as, state.VarRevision).Int64())
+}
+
+func (m *manager) GetVRFProposerSelection(result []byte) bool {
+	rev := m.GetRevision(result)
+	if rev <= 0 {
+		return false
+	}
+	return m.plt.ToRevision(rev).Has(module.VRFProposerSelection)
+}
+
+func (m *manager) GetValidatorWeights(result []byte, validators module.ValidatorList) []*big.Int {
+	wg, ok := m.plt.(base.ValidatorWeightsGetter)
+	if !ok {
+		return nil
+	}
+	wss, err := m.trc.GetWorldSnapshot(result, nil)
+	if err != nil {
+		m.log.Warnf("fail to get world snapshot err=%+v", err)
+		return nil
+	}
+	weights, err := wg.GetValidatorWeights(wss, validators)
+	if err != nil {
+		m.log.Warnf("fail to get validator weights err=%+v", err)
+		return nil
+	}
+	return weights
+}
+
+func (m *manager) GetStorageEntries(
+	result []byte, addr module.Address, prefix, start []byte, limit int,
+) ([]module.StorageEntry, []byte, error) {
+	if limit <= 0 {
+		return nil, nil, errors.IllegalArgumentError.Errorf("InvalidLimit(limit=%d)", limit)
+	}
+	wss, err := m.trc.GetWorldSnapshot(result, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	ass := wss.GetAccountSnapshot(addr.ID())
+	if ass == nil {
+		return nil, nil, errors.NotFoundError.Errorf("NoAccount(addr=%s)", addr)
+	}
+	entries := []module.StorageEntry{}
+	store := ass.Store()
+	if store == nil {
+		return entries, nil, nil
+	}
+	for itr := store.Filter(prefix); itr.Has(); err = itr.Next() {
+		if err != nil {
+			return nil, nil, err
+		}
+		value, key, err := itr.Get()
+		if err != nil {
+			return nil, nil, err
+		}
+		if bytes.Compare(key, start) < 0 {
+			continue
+		}
+		if len(entries) == limit {
+			return entries, key, nil
+		}
+		entries = append(entries, module.StorageEntry{Key: key, Value: value})
+	}
+	return entries, nil, nil
+}
+
 func (m *manager) BTPNetworkFromResult(result []byte, nid int64) (module.BTPNetwork, error) {
 	as, err := m.getSystemByteStoreState(result)
 	if err != nil {
@@ -750,6 +1012,19 @@ func (m *manager) BTPNetworkTypeIDsFromResult(result []byte) ([]int64, error) {
 	return ntids, nil
 }
 
+func (m *manager) BTPNetworkIDsFromResult(result []byte) ([]int64, error) {
+	as, err := m.getSystemByteStoreState(result)
+	if err != nil {
+		return nil, err
+	}
+	btpContext := state.NewBTPContext(nil, as)
+	nids, err := btpContext.GetNetworkIDs()
+	if err != nil {
+		return nil, err
+	}
+	return nids, nil
+}
+
 func (m *manager) BTPDigestFromResult(result []byte) (module.BTPDigest, error) {
 	wss, err := m.trc.GetWorldSnapshot(result, nil)
 	if err != nil {
@@ -806,7 +1081,7 @@ func (m *manager) WaitForTransaction(
 	cb func(),
 ) bool {
 	pt := parent.(*transition)
-	ws, _ := state.WorldStateFromSnapshot(pt.worldSnapshot)
+	ws := state.NewWorldOverlayState(pt.worldSnapshot)
 	wc := state.NewWorldContext(ws, bi, nil, m.plt)
 
 	return m.tm.Wait(wc, cb)
@@ -853,17 +1128,12 @@ func (m *manager) ExecuteTransaction(result []byte, vh []byte, js []byte, bi mod
 	}
 	defer txh.Dispose()
 
-	var wc state.WorldContext
-	wss, err := m.trc.GetWorldSnapshot(result, vh)
-	if err == nil {
-		ws, err := state.WorldStateFromSnapshot(wss)
-		if err != nil {
-			return nil, err
-		}
-		wc = state.NewWorldContext(ws, bi, nil, m.plt)
-	} else {
+	qc, err := m.trc.GetQueryContext(result, vh, bi)
+	if err != nil {
 		return nil, err
 	}
+	wss := qc.GetSnapshot()
+	wc := qc.BlockChanged(state.NewWorldOverlayState(wss), bi)
 	ctx := contract.NewContext(wc, m.cm, m.eem, m.chain, m.log, nil, eeproxy.ForQuery)
 	ctx.SetTransactionInfo(&state.TransactionInfo{
 		Group:     module.TransactionGroupNormal,
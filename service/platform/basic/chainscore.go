@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/icon-project/goloop/btp/bmv"
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/intconv"
@@ -576,6 +577,166 @@ var chainMethods = []*chainMethod{
 		},
 		nil,
 	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "setDeploymentPolicy",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"policy", scoreapi.String, nil, nil},
+		},
+		nil,
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getDeploymentPolicy",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.String,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "setCallPolicy",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"name", scoreapi.String, nil, nil},
+			{"value", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getCallPolicy",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "scheduleStepPrice",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"price", scoreapi.Integer, nil, nil},
+			{"height", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "scheduleStepCost",
+		scoreapi.FlagExternal, 3,
+		[]scoreapi.Parameter{
+			{"type", scoreapi.String, nil, nil},
+			{"cost", scoreapi.Integer, nil, nil},
+			{"height", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "cancelStepSchedule",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"height", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getStepSchedule",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.List,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "installPrecompiled",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.String, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Address,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getPrecompiled",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.List,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getPendingAudits",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.List,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "setBlockIntervalPolicy",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"maxInterval", scoreapi.Integer, nil, nil},
+			{"txThreshold", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getBlockIntervalPolicy",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getBTPVerifierTypes",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.List,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "openBTPVerifier",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"uid", scoreapi.String, nil, nil},
+			{"config", scoreapi.Bytes, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Integer,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "closeBTPVerifier",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "getBTPVerifier",
+		scoreapi.FlagReadOnly | scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, Revision9, 0},
+	{scoreapi.Method{
+		scoreapi.Function, "verifyBTPMessage",
+		scoreapi.FlagExternal, 3,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"height", scoreapi.Integer, nil, nil},
+			{"message", scoreapi.Bytes, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.List,
+		},
+	}, Revision9, 0},
 }
 
 func (s *ChainScore) GetAPI() *scoreapi.Info {
@@ -608,12 +769,17 @@ type Chain struct {
 	MemberList         []*common.Address `json:"memberList"`
 	BlockInterval      *common.HexInt64  `json:"blockInterval"`
 	CommitTimeout      *common.HexInt64  `json:"commitTimeout"`
+	MaxBlockInterval   *common.HexInt64  `json:"maxBlockInterval,omitempty"`
+	TxPoolThreshold    *common.HexInt64  `json:"txPoolThreshold,omitempty"`
 	TimestampThreshold *common.HexInt64  `json:"timestampThreshold"`
 	RoundLimitFactor   *common.HexInt64  `json:"roundLimitFactor"`
 	MinimizeBlockGen   *common.HexInt16  `json:"minimizeBlockGen"`
 	DepositTerm        *common.HexInt64  `json:"depositTerm"`
 	DepositIssueRate   *common.HexInt64  `json:"depositIssueRate"`
 	FeeSharingEnabled  *common.HexInt16  `json:"feeSharingEnabled"`
+	EVMEnabled         *common.HexInt16  `json:"evmEnabled,omitempty"`
+	DeploymentPolicy   string            `json:"deploymentPolicy,omitempty"`
+	Precompiled        []string          `json:"precompiled,omitempty"`
 }
 
 func (s *ChainScore) Install(param []byte) error {
@@ -655,6 +821,19 @@ func (s *ChainScore) Install(param []byte) error {
 			confValue |= state.SysConfigFeeSharing
 		}
 	}
+	if chain.EVMEnabled != nil && chain.EVMEnabled.Value != 0 {
+		confValue |= state.SysConfigEVM
+	}
+	if chain.DeploymentPolicy != "" {
+		if conf, err := state.SysConfigForDeploymentPolicy(int64(confValue), chain.DeploymentPolicy); err != nil {
+			return scoreresult.IllegalFormatError.Wrap(err, "InvalidDeploymentPolicy")
+		} else {
+			confValue = int(conf)
+		}
+		if err := scoredb.NewVarDB(as, state.VarDeploymentPolicy).Set(chain.DeploymentPolicy); err != nil {
+			return err
+		}
+	}
 	if err := scoredb.NewVarDB(as, state.VarServiceConfig).Set(confValue); err != nil {
 		return err
 	}
@@ -673,6 +852,19 @@ func (s *ChainScore) Install(param []byte) error {
 		}
 	}
 
+	if chain.MaxBlockInterval != nil || chain.TxPoolThreshold != nil {
+		var maxInterval, txThreshold int64
+		if chain.MaxBlockInterval != nil {
+			maxInterval = chain.MaxBlockInterval.Value
+		}
+		if chain.TxPoolThreshold != nil {
+			txThreshold = chain.TxPoolThreshold.Value
+		}
+		if err := setBlockIntervalPolicy(as, maxInterval, txThreshold); err != nil {
+			return scoreresult.IllegalFormatError.Wrap(err, "InvalidBlockIntervalPolicy")
+		}
+	}
+
 	if chain.TimestampThreshold != nil {
 		tsThreshold := chain.TimestampThreshold.Value
 		if err := scoredb.NewVarDB(as, state.VarTimestampThreshold).Set(tsThreshold); err != nil {
@@ -723,7 +915,11 @@ func (s *ChainScore) Install(param []byte) error {
 		if err := json.Unmarshal(*price.StepLimit, &stepLimitsMap); err != nil {
 			return scoreresult.Errorf(module.StatusIllegalFormat, "Failed to unmarshal. err(%+v)\n", err)
 		}
-		for _, k := range state.AllStepLimitTypes {
+		limitTypes := state.AllStepLimitTypes
+		if _, ok := stepLimitsMap[state.StepLimitTypeBlock]; ok {
+			limitTypes = append(limitTypes[:len(limitTypes):len(limitTypes)], state.StepLimitTypeBlock)
+		}
+		for _, k := range limitTypes {
 			cost := stepLimitsMap[k]
 			if err := stepLimitTypes.Put(k); err != nil {
 				return err
@@ -825,6 +1021,12 @@ func (s *ChainScore) Install(param []byte) error {
 				"All Validators must be included in the members")
 		}
 	}
+
+	for _, id := range chain.Precompiled {
+		if err := contract.InstallPrecompiled(s.cc, id, state.SystemAddress); err != nil {
+			return err
+		}
+	}
 	s.handleRevisionChange(as, Revision1, revision)
 	return nil
 }
@@ -978,6 +1180,9 @@ func (s *ChainScore) Ex_rejectScore(txHash []byte) error {
 	if err := h2a.Delete(txHash); err != nil {
 		return err
 	}
+	if err := contract.RemovePendingAudit(sysAs, s.cc.Revision(), txHash); err != nil {
+		return err
+	}
 	return scoreAs.RejectContract(txHash, auditTxHash)
 }
 
@@ -1030,14 +1235,70 @@ func (s *ChainScore) Ex_setStepCost(costType string, cost *common.HexInt) error
 		return err
 	}
 	as := s.cc.GetAccountState(state.SystemID)
-	stepCostDB := scoredb.NewDictDB(as, state.VarStepCosts, 1)
-	if stepCostDB.Get(costType) == nil {
-		stepTypes := scoredb.NewArrayDB(as, state.VarStepTypes)
-		if err := stepTypes.Put(costType); err != nil {
-			return err
-		}
+	return state.StoreStepCost(as, costType, cost.Value())
+}
+
+func (s *ChainScore) scheduleStepChange(change *state.StepChange) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	if change.Height <= s.cc.BlockHeight() {
+		return scoreresult.InvalidParameterError.Errorf(
+			"InvalidHeight(height=%d,current=%d)", change.Height, s.cc.BlockHeight())
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	if err := state.ScheduleStepChange(as, change); err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidStepChange")
+	}
+	return nil
+}
+
+func (s *ChainScore) Ex_scheduleStepPrice(price *common.HexInt, height *common.HexInt) error {
+	return s.scheduleStepChange(&state.StepChange{
+		Height: height.Int64(),
+		Value:  price.Value(),
+	})
+}
+
+func (s *ChainScore) Ex_scheduleStepCost(costType string, cost *common.HexInt, height *common.HexInt) error {
+	if len(costType) == 0 {
+		return scoreresult.InvalidParameterError.New("EmptyStepType")
+	}
+	return s.scheduleStepChange(&state.StepChange{
+		Height: height.Int64(),
+		Type:   costType,
+		Value:  cost.Value(),
+	})
+}
+
+func (s *ChainScore) Ex_cancelStepSchedule(height *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
 	}
-	return stepCostDB.Set(costType, cost)
+	as := s.cc.GetAccountState(state.SystemID)
+	if n, err := state.CancelStepChanges(as, height.Int64()); err != nil {
+		return err
+	} else if n == 0 {
+		return scoreresult.InvalidParameterError.Errorf(
+			"NoScheduledChanges(height=%d)", height.Int64())
+	}
+	return nil
+}
+
+func (s *ChainScore) Ex_getStepSchedule() ([]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	changes, err := state.LoadStepSchedule(as)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, 0, len(changes))
+	for _, c := range changes {
+		result = append(result, c.ToJSON())
+	}
+	return result, nil
 }
 
 func (s *ChainScore) Ex_setMaxStepLimit(contextType string, cost *common.HexInt) error {
@@ -1468,6 +1729,39 @@ func (s *ChainScore) Ex_setRoundLimitFactor(f *common.HexInt) error {
 	return factor.Set(f)
 }
 
+func setBlockIntervalPolicy(as state.AccountState, maxInterval, txThreshold int64) error {
+	if maxInterval < 0 || txThreshold < 0 {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidBlockIntervalPolicy(max=%d,threshold=%d)", maxInterval, txThreshold)
+	}
+	if err := scoredb.NewVarDB(as, state.VarMaxBlockInterval).Set(maxInterval); err != nil {
+		return err
+	}
+	return scoredb.NewVarDB(as, state.VarTxPoolThreshold).Set(txThreshold)
+}
+
+func (s *ChainScore) Ex_setBlockIntervalPolicy(maxInterval *common.HexInt, txThreshold *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	if err := setBlockIntervalPolicy(as, maxInterval.Int64(), txThreshold.Int64()); err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidBlockIntervalPolicy")
+	}
+	return nil
+}
+
+func (s *ChainScore) Ex_getBlockIntervalPolicy() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	return map[string]interface{}{
+		"maxInterval": scoredb.NewVarDB(as, state.VarMaxBlockInterval).Int64(),
+		"txThreshold": scoredb.NewVarDB(as, state.VarTxPoolThreshold).Int64(),
+	}, nil
+}
+
 func (s *ChainScore) Ex_getMinimizeBlockGen() (bool, error) {
 	if err := s.tryChargeCall(); err != nil {
 		return false, err
@@ -1523,6 +1817,122 @@ func (s *ChainScore) Ex_getSystemDepositUsage() (*big.Int, error) {
 	return usage, nil
 }
 
+func (s *ChainScore) Ex_setCallPolicy(name string, value *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	if err := state.StoreCallPolicy(as, name, value.Int64()); err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidCallPolicy")
+	}
+	return nil
+}
+
+func (s *ChainScore) Ex_getCallPolicy() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	policy := state.LoadCallPolicy(as)
+	return policy.ToJSON(), nil
+}
+
+func (s *ChainScore) Ex_installPrecompiled(id string) (module.Address, error) {
+	if err := s.checkGovernance(true); err != nil {
+		return nil, err
+	}
+	if err := contract.InstallPrecompiled(s.cc, id, state.SystemAddress); err != nil {
+		return nil, err
+	}
+	return contract.GetPrecompiled(id).Address, nil
+}
+
+// Ex_getPrecompiled returns precompiled system SCOREs supported by the node
+// with the flag whether it's installed or not.
+func (s *ChainScore) Ex_getPrecompiled() ([]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	modules := contract.PrecompiledModules()
+	result := make([]interface{}, 0, len(modules))
+	for _, m := range modules {
+		as := s.cc.GetAccountState(m.Address.ID())
+		result = append(result, map[string]interface{}{
+			"id":        m.ID,
+			"address":   m.Address,
+			"installed": as.IsContract(),
+		})
+	}
+	return result, nil
+}
+
+func (s *ChainScore) Ex_setDeploymentPolicy(policy string) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	confValue := scoredb.NewVarDB(as, state.VarServiceConfig).Int64()
+	confValue, err := state.SysConfigForDeploymentPolicy(confValue, policy)
+	if err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidPolicy")
+	}
+	if err := scoredb.NewVarDB(as, state.VarDeploymentPolicy).Set(policy); err != nil {
+		return err
+	}
+	return scoredb.NewVarDB(as, state.VarServiceConfig).Set(confValue)
+}
+
+// Ex_getDeploymentPolicy returns the deployment policy. If the policy is not
+// configured, then it's derived from the service configuration.
+func (s *ChainScore) Ex_getDeploymentPolicy() (string, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return "", err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	if policy := scoredb.NewVarDB(as, state.VarDeploymentPolicy).String(); policy != "" {
+		return policy, nil
+	}
+	confValue := scoredb.NewVarDB(as, state.VarServiceConfig).Int64()
+	if (confValue & state.SysConfigDeployerWhiteList) != 0 {
+		return state.DeploymentPolicyAllowlist, nil
+	} else if (confValue & state.SysConfigAudit) != 0 {
+		return state.DeploymentPolicyAudit, nil
+	}
+	return state.DeploymentPolicyOpen, nil
+}
+
+func (s *ChainScore) Ex_getPendingAudits() ([]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	db := contract.PendingAuditsOf(as)
+	h2a := scoredb.NewDictDB(as, state.VarTxHashToAddress, 1)
+	audits := make([]interface{}, 0, db.Size())
+	for i := 0; i < db.Size(); i++ {
+		deployID := db.Get(i).Bytes()
+		v := h2a.Get(deployID)
+		if v == nil {
+			continue
+		}
+		scoreAddr := v.Address()
+		if scoreAddr == nil {
+			continue
+		}
+		audit := map[string]interface{}{
+			"deployTxHash": deployID,
+			"address":      scoreAddr,
+		}
+		if sas := s.cc.GetAccountState(scoreAddr.ID()); sas != nil {
+			if owner := sas.ContractOwner(); owner != nil {
+				audit["owner"] = owner
+			}
+		}
+		audits = append(audits, audit)
+	}
+	return audits, nil
+}
+
 func (s *ChainScore) Ex_getBTPNetworkTypeID(name string) (int64, error) {
 	if err := s.tryChargeCall(); err != nil {
 		return 0, err
@@ -1657,3 +2067,99 @@ func (s *ChainScore) newBTPContext() state.BTPContext {
 	store := s.cc.GetAccountState(state.SystemID)
 	return state.NewBTPContext(s.cc, store)
 }
+
+func (s *ChainScore) Ex_getBTPVerifierTypes() ([]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	uids := bmv.UIDs()
+	result := make([]interface{}, len(uids))
+	for i, uid := range uids {
+		result[i] = uid
+	}
+	return result, nil
+}
+
+func (s *ChainScore) Ex_openBTPVerifier(uid string, config []byte) (int64, error) {
+	if err := s.checkGovernance(true); err != nil {
+		return 0, err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	id, err := bmv.Open(as, uid, config)
+	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return 0, scoreresult.InvalidParameterError.Wrap(err, "InvalidVerifier")
+		}
+		return 0, err
+	}
+	s.cc.OnEvent(state.SystemAddress,
+		[][]byte{
+			[]byte("BTPVerifierOpened(int,str)"),
+			intconv.Int64ToBytes(id),
+			[]byte(uid),
+		},
+		nil,
+	)
+	return id, nil
+}
+
+func (s *ChainScore) Ex_closeBTPVerifier(id *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	if err := bmv.Close(as, id.Int64()); err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return scoreresult.New(StatusNotFound, err.Error())
+		}
+		return err
+	}
+	s.cc.OnEvent(state.SystemAddress,
+		[][]byte{
+			[]byte("BTPVerifierClosed(int)"),
+			intconv.Int64ToBytes(id.Int64()),
+		},
+		nil,
+	)
+	return nil
+}
+
+func (s *ChainScore) Ex_getBTPVerifier(id *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	i, err := bmv.GetInstance(as, id.Int64())
+	if err != nil {
+		return nil, err
+	}
+	if i == nil {
+		return nil, scoreresult.New(StatusNotFound, "VerifierNotFound")
+	}
+	return map[string]interface{}{
+		"uid":    i.UID,
+		"config": i.Config,
+	}, nil
+}
+
+// Ex_verifyBTPMessage verifies the relay message from the source chain with
+// the verifier instance, and returns BTP messages in it. The state of the
+// instance is updated only if the message is valid.
+func (s *ChainScore) Ex_verifyBTPMessage(id *common.HexInt, height *common.HexInt, message []byte) ([]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	msgs, err := bmv.Verify(as, id.Int64(), height.Int64(), message)
+	if err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return nil, scoreresult.New(StatusNotFound, err.Error())
+		}
+		return nil, scoreresult.InvalidParameterError.Wrap(err, "InvalidRelayMessage")
+	}
+	result := make([]interface{}, len(msgs))
+	for i, msg := range msgs {
+		result[i] = msg
+	}
+	return result, nil
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package basic
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/btp/bmv"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/state"
+)
+
+type testCallContext struct {
+	contract.CallContext
+	ws     state.WorldState
+	calls  int
+	events [][][]byte
+}
+
+func (cc *testCallContext) GetAccountState(id []byte) state.AccountState {
+	return cc.ws.GetAccountState(id)
+}
+
+func (cc *testCallContext) OnEvent(addr module.Address, indexed, data [][]byte) {
+	cc.events = append(cc.events, indexed)
+}
+
+func (cc *testCallContext) ApplyCallSteps() error {
+	cc.calls += 1
+	return nil
+}
+
+func newTestChainScore(gov bool) (*ChainScore, *testCallContext) {
+	cc := &testCallContext{
+		ws: state.NewWorldState(db.NewMapDB(), nil, nil, nil, nil),
+	}
+	return &ChainScore{gov: gov, cc: cc, log: log.New()}, cc
+}
+
+func TestChainScore_DeploymentPolicy(t *testing.T) {
+	s, cc := newTestChainScore(true)
+	as := cc.GetAccountState(state.SystemID)
+	conf := scoredb.NewVarDB(as, state.VarServiceConfig)
+
+	// derived from the service configuration without the policy
+	for _, tt := range []struct {
+		conf   int64
+		policy string
+	}{
+		{0, state.DeploymentPolicyOpen},
+		{state.SysConfigAudit, state.DeploymentPolicyAudit},
+		{state.SysConfigDeployerWhiteList, state.DeploymentPolicyAllowlist},
+		{state.SysConfigAudit | state.SysConfigDeployerWhiteList, state.DeploymentPolicyAllowlist},
+	} {
+		assert.NoError(t, conf.Set(tt.conf))
+		policy, err := s.Ex_getDeploymentPolicy()
+		assert.NoError(t, err)
+		assert.Equal(t, tt.policy, policy)
+	}
+
+	assert.NoError(t, conf.Set(state.SysConfigFee|state.SysConfigAudit))
+	for _, tt := range []struct {
+		policy string
+		conf   int64
+	}{
+		{state.DeploymentPolicyAllowlist, state.SysConfigFee | state.SysConfigDeployerWhiteList},
+		{state.DeploymentPolicyOpen, state.SysConfigFee},
+		{state.DeploymentPolicyAudit, state.SysConfigFee | state.SysConfigAudit},
+	} {
+		assert.NoError(t, s.Ex_setDeploymentPolicy(tt.policy))
+		policy, err := s.Ex_getDeploymentPolicy()
+		assert.NoError(t, err)
+		assert.Equal(t, tt.policy, policy)
+		assert.Equal(t, tt.conf, conf.Int64())
+	}
+
+	err := s.Ex_setDeploymentPolicy("invalid")
+	assert.True(t, scoreresult.InvalidParameterError.Equals(err))
+	policy, err := s.Ex_getDeploymentPolicy()
+	assert.NoError(t, err)
+	assert.Equal(t, state.DeploymentPolicyAudit, policy)
+	assert.Zero(t, cc.calls)
+
+	// only the governance can set it
+	s2 := &ChainScore{cc: cc, log: log.New()}
+	err = s2.Ex_setDeploymentPolicy(state.DeploymentPolicyOpen)
+	assert.True(t, scoreresult.AccessDeniedError.Equals(err))
+	assert.Equal(t, 1, cc.calls)
+	policy, err = s2.Ex_getDeploymentPolicy()
+	assert.NoError(t, err)
+	assert.Equal(t, state.DeploymentPolicyAudit, policy)
+	assert.Equal(t, 2, cc.calls)
+}
+
+func TestChainScore_GetPendingAudits(t *testing.T) {
+	s, cc := newTestChainScore(false)
+	as := cc.GetAccountState(state.SystemID)
+
+	audits, err := s.Ex_getPendingAudits()
+	assert.NoError(t, err)
+	assert.Empty(t, audits)
+	assert.Equal(t, 1, cc.calls)
+
+	score1 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	score2 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000002")
+	owner := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	assert.True(t, cc.GetAccountState(score1.ID()).InitContractAccount(owner))
+
+	// audits are listed regardless of the deployment policy
+	h2a := scoredb.NewDictDB(as, state.VarTxHashToAddress, 1)
+	pending := scoredb.NewArrayDB(as, state.VarPendingAudits)
+	for _, item := range []struct {
+		id   string
+		addr module.Address
+	}{
+		{"tx1", score1},
+		{"tx2", nil},
+		{"tx3", score2},
+	} {
+		if item.addr != nil {
+			assert.NoError(t, h2a.Set([]byte(item.id), item.addr))
+		}
+		assert.NoError(t, pending.Put([]byte(item.id)))
+	}
+
+	audits, err = s.Ex_getPendingAudits()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"deployTxHash": []byte("tx1"),
+			"address":      score1,
+			"owner":        owner,
+		},
+		map[string]interface{}{
+			"deployTxHash": []byte("tx3"),
+			"address":      score2,
+		},
+	}, audits)
+
+	assert.NoError(t, scoredb.NewVarDB(as, state.VarDeploymentPolicy).Set(state.DeploymentPolicyAudit))
+	audits2, err := s.Ex_getPendingAudits()
+	assert.NoError(t, err)
+	assert.Equal(t, audits, audits2)
+}
+
+const testVerifierUID = "basic-test"
+
+func init() {
+	// verifier accepting messages of increasing heights, and splitting them
+	// by the separator in the configuration.
+	bmv.Register(testVerifierUID, func(config []byte) (bmv.Verifier, error) {
+		if len(config) == 0 {
+			return nil, errors.IllegalArgumentError.New("EmptySeparator")
+		}
+		return testVerifierFunc(func(store bmv.Store, height int64, msg []byte) ([][]byte, error) {
+			if bs := store.Get([]byte("height")); bs != nil && int64(bs[0]) >= height {
+				return nil, errors.InvalidStateError.New("InvalidHeight")
+			}
+			if err := store.Set([]byte("height"), []byte{byte(height)}); err != nil {
+				return nil, err
+			}
+			return bytes.Split(msg, config), nil
+		}), nil
+	})
+}
+
+type testVerifierFunc func(store bmv.Store, height int64, msg []byte) ([][]byte, error)
+
+func (f testVerifierFunc) Verify(store bmv.Store, height int64, msg []byte) ([][]byte, error) {
+	return f(store, height, msg)
+}
+
+func assertStatus(t *testing.T, expected module.Status, err error) {
+	status, ok := scoreresult.StatusOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, expected, status)
+}
+
+func TestChainScore_BTPVerifier(t *testing.T) {
+	s, cc := newTestChainScore(true)
+
+	uids, err := s.Ex_getBTPVerifierTypes()
+	assert.NoError(t, err)
+	assert.Contains(t, uids, testVerifierUID)
+
+	_, err = s.Ex_openBTPVerifier("unknown", []byte(","))
+	assert.True(t, scoreresult.InvalidParameterError.Equals(err))
+	_, err = s.Ex_openBTPVerifier(testVerifierUID, nil)
+	assert.True(t, scoreresult.InvalidParameterError.Equals(err))
+
+	id, err := s.Ex_openBTPVerifier(testVerifierUID, []byte(","))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, id)
+	assert.Len(t, cc.events, 1)
+	assert.Equal(t, []byte("BTPVerifierOpened(int,str)"), cc.events[0][0])
+
+	info, err := s.Ex_getBTPVerifier(common.NewHexInt(id))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"uid":    testVerifierUID,
+		"config": []byte(","),
+	}, info)
+
+	// only the governance can open or close verifiers
+	s2 := &ChainScore{cc: cc, log: log.New()}
+	_, err = s2.Ex_openBTPVerifier(testVerifierUID, []byte(","))
+	assert.True(t, scoreresult.AccessDeniedError.Equals(err))
+	err = s2.Ex_closeBTPVerifier(common.NewHexInt(id))
+	assert.True(t, scoreresult.AccessDeniedError.Equals(err))
+
+	// anyone can relay messages
+	msgs, err := s2.Ex_verifyBTPMessage(common.NewHexInt(id), common.NewHexInt(10), []byte("a,b"))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{[]byte("a"), []byte("b")}, msgs)
+	_, err = s2.Ex_verifyBTPMessage(common.NewHexInt(id), common.NewHexInt(10), []byte("c"))
+	assert.True(t, scoreresult.InvalidParameterError.Equals(err))
+	_, err = s2.Ex_verifyBTPMessage(common.NewHexInt(id+1), common.NewHexInt(11), []byte("c"))
+	assertStatus(t, StatusNotFound, err)
+
+	assert.NoError(t, s.Ex_closeBTPVerifier(common.NewHexInt(id)))
+	assert.Len(t, cc.events, 2)
+	assert.Equal(t, []byte("BTPVerifierClosed(int)"), cc.events[1][0])
+	err = s.Ex_closeBTPVerifier(common.NewHexInt(id))
+	assertStatus(t, StatusNotFound, err)
+	_, err = s.Ex_getBTPVerifier(common.NewHexInt(id))
+	assertStatus(t, StatusNotFound, err)
+	_, err = s2.Ex_verifyBTPMessage(common.NewHexInt(id), common.NewHexInt(11), []byte("c"))
+	assertStatus(t, StatusNotFound, err)
+}
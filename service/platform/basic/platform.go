@@ -26,6 +26,7 @@ import (
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/contract"
+	_ "github.com/icon-project/goloop/service/precompiled"
 	"github.com/icon-project/goloop/service/state"
 	"github.com/icon-project/goloop/service/txresult"
 )
@@ -78,6 +79,14 @@ func (t *platform) OnValidateTransactions(wc state.WorldContext, patches, txs mo
 }
 
 func (t *platform) OnExecutionBegin(wc state.WorldContext, logger log.Logger) error {
+	as := wc.GetAccountState(state.SystemID)
+	n, err := state.ApplyStepSchedule(as, wc.BlockHeight())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		logger.Infof("Apply scheduled step changes height=%d count=%d", wc.BlockHeight(), n)
+	}
 	return nil
 }
 
@@ -57,7 +57,21 @@ var revisionFlags = []module.Revision{
 	// Revision 8
 	module.UseCompactAPIInfo,
 	// Revision 9
-	module.MultipleFeePayers,
+	//
+	// It's not released yet, and features of the next release are bundled
+	// in it intentionally, so they're enabled together by setRevision. To
+	// release one of them separately, move the flag to a new revision.
+	//  - MultipleFeePayers: fee proportions set by SCOREs of inner calls
+	//  - ColdAccessCharge: access list and cold/warm access step pricing
+	//  - RevertReasonInReceipt: messages of reverts in receipts
+	//  - TxExpiration: expiration height of transactions
+	//  - DeployWithSalt: deterministic addresses of contracts deployed with salt
+	//  - VRFProposerSelection: proposers selected by the VRF output
+	//  - FeeStepsInReceipt: steps paid by fee payers without virtual steps
+	//  - PendingAuditList: pending audits listed regardless of the policy
+	module.MultipleFeePayers | module.ColdAccessCharge | module.RevertReasonInReceipt |
+		module.TxExpiration | module.DeployWithSalt | module.VRFProposerSelection |
+		module.FeeStepsInReceipt | module.PendingAuditList,
 }
 
 func init() {
@@ -0,0 +1,221 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package precompiled
+
+import (
+	"bytes"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/icon-project/goloop/btp/ntm"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/scoreapi"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+const (
+	CID_CRYPTO = "crypto"
+)
+
+var CryptoAddress = common.MustNewAddressFromString("cx0000000000000000000000000000000000000100")
+
+const (
+	hashLen       = 32
+	proofNodeSize = 1 + hashLen
+)
+
+// proof node directions for verifyMerkleProof
+const (
+	proofDirLeft  = byte(module.DirLeft)
+	proofDirRight = byte(module.DirRight)
+)
+
+var cryptoMethods = []contract.PrecompiledMethod{
+	{Method: scoreapi.Method{
+		Type:    scoreapi.Function,
+		Name:    "sha3_256",
+		Flags:   scoreapi.FlagReadOnly | scoreapi.FlagExternal,
+		Indexed: 1,
+		Inputs: []scoreapi.Parameter{
+			{Name: "data", Type: scoreapi.Bytes},
+		},
+		Outputs: []scoreapi.DataType{
+			scoreapi.Bytes,
+		},
+	}, Steps: 100, StepsPerWord: 10},
+	{Method: scoreapi.Method{
+		Type:    scoreapi.Function,
+		Name:    "sha256",
+		Flags:   scoreapi.FlagReadOnly | scoreapi.FlagExternal,
+		Indexed: 1,
+		Inputs: []scoreapi.Parameter{
+			{Name: "data", Type: scoreapi.Bytes},
+		},
+		Outputs: []scoreapi.DataType{
+			scoreapi.Bytes,
+		},
+	}, Steps: 100, StepsPerWord: 10},
+	{Method: scoreapi.Method{
+		Type:    scoreapi.Function,
+		Name:    "keccak256",
+		Flags:   scoreapi.FlagReadOnly | scoreapi.FlagExternal,
+		Indexed: 1,
+		Inputs: []scoreapi.Parameter{
+			{Name: "data", Type: scoreapi.Bytes},
+		},
+		Outputs: []scoreapi.DataType{
+			scoreapi.Bytes,
+		},
+	}, Steps: 100, StepsPerWord: 10},
+	{Method: scoreapi.Method{
+		Type:    scoreapi.Function,
+		Name:    "recoverKey",
+		Flags:   scoreapi.FlagReadOnly | scoreapi.FlagExternal,
+		Indexed: 3,
+		Inputs: []scoreapi.Parameter{
+			{Name: "msgHash", Type: scoreapi.Bytes},
+			{Name: "signature", Type: scoreapi.Bytes},
+			{Name: "compressed", Type: scoreapi.Bool},
+		},
+		Outputs: []scoreapi.DataType{
+			scoreapi.Bytes,
+		},
+	}, Steps: 3000, StepsPerWord: 0},
+	{Method: scoreapi.Method{
+		Type:    scoreapi.Function,
+		Name:    "verifySignature",
+		Flags:   scoreapi.FlagReadOnly | scoreapi.FlagExternal,
+		Indexed: 3,
+		Inputs: []scoreapi.Parameter{
+			{Name: "msgHash", Type: scoreapi.Bytes},
+			{Name: "signature", Type: scoreapi.Bytes},
+			{Name: "publicKey", Type: scoreapi.Bytes},
+		},
+		Outputs: []scoreapi.DataType{
+			scoreapi.Bool,
+		},
+	}, Steps: 3000, StepsPerWord: 0},
+	{Method: scoreapi.Method{
+		Type:    scoreapi.Function,
+		Name:    "verifyMerkleProof",
+		Flags:   scoreapi.FlagReadOnly | scoreapi.FlagExternal,
+		Indexed: 4,
+		Inputs: []scoreapi.Parameter{
+			{Name: "hashType", Type: scoreapi.String},
+			{Name: "root", Type: scoreapi.Bytes},
+			{Name: "leaf", Type: scoreapi.Bytes},
+			{Name: "proof", Type: scoreapi.Bytes},
+		},
+		Outputs: []scoreapi.DataType{
+			scoreapi.Bool,
+		},
+	}, Steps: 100, StepsPerWord: 30},
+}
+
+// CryptoScore provides cryptographic primitives for contracts.
+type CryptoScore struct {
+	*contract.PrecompiledBase
+}
+
+func (s *CryptoScore) Ex_sha3_256(data []byte) ([]byte, error) {
+	return crypto.SHA3Sum256(data), nil
+}
+
+func (s *CryptoScore) Ex_sha256(data []byte) ([]byte, error) {
+	return crypto.SHASum256(data), nil
+}
+
+func (s *CryptoScore) Ex_keccak256(data []byte) ([]byte, error) {
+	d := sha3.NewLegacyKeccak256()
+	d.Write(data)
+	return d.Sum(nil), nil
+}
+
+func (s *CryptoScore) Ex_recoverKey(msgHash []byte, signature []byte, compressed bool) ([]byte, error) {
+	sig, err := crypto.ParseSignature(signature)
+	if err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrap(err, "InvalidSignature")
+	}
+	pubKey, err := sig.RecoverPublicKey(msgHash)
+	if err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrap(err, "FailToRecoverKey")
+	}
+	if compressed {
+		return pubKey.SerializeCompressed(), nil
+	}
+	return pubKey.SerializeUncompressed(), nil
+}
+
+func (s *CryptoScore) Ex_verifySignature(msgHash []byte, signature []byte, publicKey []byte) (bool, error) {
+	sig, err := crypto.ParseSignature(signature)
+	if err != nil {
+		return false, nil
+	}
+	pubKey, err := crypto.ParsePublicKey(publicKey)
+	if err != nil {
+		return false, nil
+	}
+	return sig.Verify(msgHash, pubKey), nil
+}
+
+// Ex_verifyMerkleProof verifies the leaf hash with the merkle root built by
+// the network type module for hashType. Each node of the proof is 1 byte
+// direction(0:left, 1:right) of the sibling followed by 32 bytes hash of it.
+// Nodes passed up without hashing should be omitted.
+func (s *CryptoScore) Ex_verifyMerkleProof(hashType string, root []byte, leaf []byte, proof []byte) (bool, error) {
+	mod := ntm.ForUID(hashType)
+	if mod == nil {
+		return false, scoreresult.InvalidParameterError.Errorf(
+			"UnknownHashType(type=%s)", hashType)
+	}
+	if len(proof)%proofNodeSize != 0 {
+		return false, scoreresult.InvalidParameterError.Errorf(
+			"InvalidProofSize(size=%d)", len(proof))
+	}
+	hash := leaf
+	buf := make([]byte, 0, hashLen*2)
+	for ; len(proof) > 0; proof = proof[proofNodeSize:] {
+		sibling := proof[1:proofNodeSize]
+		switch proof[0] {
+		case proofDirLeft:
+			buf = append(append(buf[:0], sibling...), hash...)
+		case proofDirRight:
+			buf = append(append(buf[:0], hash...), sibling...)
+		default:
+			return false, scoreresult.InvalidParameterError.Errorf(
+				"InvalidProofDirection(dir=%d)", proof[0])
+		}
+		hash = mod.Hash(buf)
+	}
+	return bytes.Equal(hash, root), nil
+}
+
+func newCryptoScore(base *contract.PrecompiledBase) (contract.SystemScore, error) {
+	return &CryptoScore{base}, nil
+}
+
+func init() {
+	contract.RegisterPrecompiled(&contract.PrecompiledModule{
+		ID:      CID_CRYPTO,
+		Address: CryptoAddress,
+		Methods: cryptoMethods,
+		New:     newCryptoScore,
+	})
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package precompiled
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/btp/ntm"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+)
+
+type hashList [][]byte
+
+func (l hashList) Len() int {
+	return len(l)
+}
+
+func (l hashList) Get(i int) []byte {
+	return l[i]
+}
+
+func TestCryptoScore_Hash(t *testing.T) {
+	s := &CryptoScore{}
+	msg := []byte("abc")
+
+	exp, _ := hex.DecodeString("4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45")
+	h, err := s.Ex_keccak256(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, exp, h)
+
+	h, err = s.Ex_sha3_256(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.SHA3Sum256(msg), h)
+
+	h, err = s.Ex_sha256(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.SHASum256(msg), h)
+}
+
+func TestCryptoScore_Signature(t *testing.T) {
+	s := &CryptoScore{}
+	sk, pk := crypto.GenerateKeyPair()
+	msgHash := crypto.SHA3Sum256([]byte("test message"))
+	sig, err := crypto.NewSignature(msgHash, sk)
+	assert.NoError(t, err)
+	sigBytes, err := sig.SerializeRSV()
+	assert.NoError(t, err)
+
+	key, err := s.Ex_recoverKey(msgHash, sigBytes, true)
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SerializeCompressed(), key)
+	key, err = s.Ex_recoverKey(msgHash, sigBytes, false)
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SerializeUncompressed(), key)
+
+	_, err = s.Ex_recoverKey(msgHash, sigBytes[1:], true)
+	assert.Error(t, err)
+
+	ok, err := s.Ex_verifySignature(msgHash, sigBytes, pk.SerializeCompressed())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, pk2 := crypto.GenerateKeyPair()
+	ok, err = s.Ex_verifySignature(msgHash, sigBytes, pk2.SerializeCompressed())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestCryptoScore_VerifyMerkleProof(t *testing.T) {
+	ntm.InitIconModule()
+	s := &CryptoScore{}
+	for _, uid := range []string{"eth", "icon"} {
+		mod := ntm.ForUID(uid)
+		var leaves hashList
+		for i := 0; i < 5; i++ {
+			leaves = append(leaves, mod.Hash([]byte{byte(i)}))
+		}
+		root := mod.MerkleRoot(leaves)
+		for idx := range leaves {
+			var proof []byte
+			for _, node := range mod.MerkleProof(leaves, idx) {
+				if node.Value == nil {
+					continue
+				}
+				proof = append(proof, byte(node.Dir))
+				proof = append(proof, node.Value...)
+			}
+			ok, err := s.Ex_verifyMerkleProof(uid, root, leaves[idx], proof)
+			assert.NoError(t, err)
+			assert.True(t, ok, "uid=%s idx=%d", uid, idx)
+
+			ok, err = s.Ex_verifyMerkleProof(uid, root, leaves[(idx+1)%len(leaves)], proof)
+			assert.NoError(t, err)
+			assert.False(t, ok, "uid=%s idx=%d", uid, idx)
+		}
+	}
+
+	_, err := s.Ex_verifyMerkleProof("unknown", nil, nil, nil)
+	assert.Error(t, err)
+	_, err = s.Ex_verifyMerkleProof("eth", nil, nil, []byte{byte(module.DirLeft)})
+	assert.Error(t, err)
+}
+
+func TestCryptoScore_Registered(t *testing.T) {
+	m := contract.GetPrecompiled(CID_CRYPTO)
+	assert.NotNil(t, m)
+	assert.True(t, m.Address.Equal(CryptoAddress))
+
+	score, err := m.New(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, contract.CheckMethod(score, m.APIInfo()))
+}
@@ -110,6 +110,19 @@ func (info *Info) ToJSON(v module.JSONVersion) (interface{}, error) {
 	return jso, nil
 }
 
+func (info *Info) Signatures() ([]string, []string) {
+	methods := make([]string, 0, len(info.methods))
+	events := make([]string, 0)
+	for _, method := range info.methods {
+		if method.IsEvent() {
+			events = append(events, method.Signature())
+		} else if method.IsExternal() && !method.IsFallback() {
+			methods = append(methods, method.Signature())
+		}
+	}
+	return methods, events
+}
+
 func (info *Info) String() string {
 	if info == nil {
 		return "nil"
@@ -129,3 +129,10 @@ func TestInfo_CheckEventData(t *testing.T) {
 		})
 	}
 }
+
+func TestInfo_Signatures(t *testing.T) {
+	info := NewInfo(testMethods)
+	methods, events := info.Signatures()
+	assert.Equal(t, []string{"transfer(Address)"}, methods)
+	assert.Equal(t, []string{"Transfer(Address,Address,int)"}, events)
+}
@@ -39,6 +39,21 @@ func NewVarDB(store containerdb.BytesStoreState, keys ...interface{}) *container
 	return containerdb.NewVarDB(store, key)
 }
 
+func NewTypedArrayDB[T any](store containerdb.BytesStoreState, schema *containerdb.Schema[T], keys ...interface{}) *containerdb.TypedArrayDB[T] {
+	key := containerdb.ToKey(containerdb.HashBuilder, ArrayDBPrefix).Append(keys...)
+	return containerdb.NewTypedArrayDB(store, key, schema)
+}
+
+func NewTypedDictDB[T any](store containerdb.BytesStoreState, name string, schema *containerdb.Schema[T], keys ...interface{}) *containerdb.TypedDictDB[T] {
+	key := containerdb.ToKey(containerdb.HashBuilder, DictDBPrefix, name).Append(keys...)
+	return containerdb.NewTypedDictDB(store, key, schema)
+}
+
+func NewTypedVarDB[T any](store containerdb.BytesStoreState, schema *containerdb.Schema[T], keys ...interface{}) *containerdb.TypedVarDB[T] {
+	key := containerdb.ToKey(containerdb.HashBuilder, VarDBPrefix).Append(keys...)
+	return containerdb.NewTypedVarDB(store, key, schema)
+}
+
 func NewStateStoreWith(s containerdb.BytesStoreSnapshot) containerdb.BytesStoreState {
 	return containerdb.NewBytesStoreStateWithSnapshot(s)
 }
@@ -77,6 +77,7 @@ const (
 	StackOverflowError
 	SkipTransactionError
 	InvalidPackageError
+	IllegalReentrancyError
 	RevertedError = errors.CodeSCORE + errors.Code(module.StatusReverted)
 )
 
@@ -101,5 +102,6 @@ var (
 	ErrStackOverflow          = errors.NewBase(StackOverflowError, "StackOverflow")
 	ErrSkipTransaction        = errors.NewBase(SkipTransactionError, "SkipTransaction")
 	ErrInvalidPackage         = errors.NewBase(InvalidPackageError, "InvalidPackage")
+	ErrIllegalReentrancy      = errors.NewBase(IllegalReentrancyError, "IllegalReentrancy")
 	ErrReverted               = errors.NewBase(RevertedError, "Reverted")
 )
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sigverify
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/server/metric"
+)
+
+const (
+	minBatchSize       = 4
+	queueSizePerWorker = 4
+)
+
+// job is the set of items requested by Run.
+type job struct {
+	verify  func(i int) error
+	errs    []error
+	remains int32
+	done    chan struct{}
+}
+
+// batch is the range of items of the job verified by a worker at once.
+type batch struct {
+	job  *job
+	from int
+	to   int
+}
+
+func (b *batch) run() {
+	j := b.job
+	for i := b.from; i < b.to; i++ {
+		j.errs[i] = j.verify(i)
+	}
+	if atomic.AddInt32(&j.remains, -1) == 0 {
+		close(j.done)
+	}
+}
+
+// Pool verifies signatures with workers in parallel. Items are split into
+// batches for the workers. Signatures of secp256k1 used for transactions and
+// votes are recoverable ECDSA ones, which can't be verified algebraically as
+// a batch, so a batch is the unit of scheduling for them.
+type Pool struct {
+	batches chan *batch
+	workers int
+	queued  int32
+	metric  *metric.SigVerifyMetric
+}
+
+func NewPool(workers int, m *metric.SigVerifyMetric) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &Pool{
+		batches: make(chan *batch, workers*queueSizePerWorker),
+		workers: workers,
+		metric:  m,
+	}
+	for i := 0; i < workers; i++ {
+		go p.workRoutine()
+	}
+	return p
+}
+
+func (p *Pool) workRoutine() {
+	for b := range p.batches {
+		p.onQueue(-1)
+		b.run()
+	}
+}
+
+func (p *Pool) onQueue(delta int32) {
+	n := atomic.AddInt32(&p.queued, delta)
+	if p.metric != nil {
+		p.metric.OnQueue(int(n))
+	}
+}
+
+// Run calls verify for items from 0 to n-1 in parallel, and returns errors
+// of them in the order. It blocks until all items are verified. The caller
+// verifies queued batches while it waits, so it's safe to call Run from the
+// verify function.
+func (p *Pool) Run(n int, verify func(i int) error) []error {
+	if n <= 0 {
+		return nil
+	}
+	size := (n + p.workers - 1) / p.workers
+	if size < minBatchSize {
+		size = minBatchSize
+	}
+	j := &job{
+		verify:  verify,
+		errs:    make([]error, n),
+		remains: int32((n + size - 1) / size),
+		done:    make(chan struct{}),
+	}
+	for from := 0; from < n; from += size {
+		to := from + size
+		if to > n {
+			to = n
+		}
+		b := &batch{job: j, from: from, to: to}
+		p.onQueue(1)
+		select {
+		case p.batches <- b:
+		default:
+			p.onQueue(-1)
+			b.run()
+		}
+	}
+	for {
+		select {
+		case <-j.done:
+			if p.metric != nil {
+				p.metric.OnVerify(n)
+			}
+			return j.errs
+		case b := <-p.batches:
+			p.onQueue(-1)
+			b.run()
+		}
+	}
+}
+
+var (
+	defaultMetric   = metric.NewSigVerifyMetric(metric.DefaultMetricContext())
+	defaultPool     *Pool
+	defaultPoolOnce sync.Once
+)
+
+// Default returns the pool shared in the process. The number of workers is
+// GOMAXPROCS on the first call.
+func Default() *Pool {
+	defaultPoolOnce.Do(func() {
+		defaultPool = NewPool(runtime.GOMAXPROCS(0), defaultMetric)
+	})
+	return defaultPool
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sigverify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+func TestPool_Run(t *testing.T) {
+	p := NewPool(3, nil)
+	assert.Nil(t, p.Run(0, nil))
+
+	const n = 100
+	hashes := make([][]byte, n)
+	sigs := make([]*crypto.Signature, n)
+	pks := make([]*crypto.PublicKey, n)
+	for i := range hashes {
+		sk, pk := crypto.GenerateKeyPair()
+		hashes[i] = crypto.SHA3Sum256([]byte{byte(i)})
+		sig, err := crypto.NewSignature(hashes[i], sk)
+		assert.NoError(t, err)
+		sigs[i] = sig
+		pks[i] = pk
+	}
+	// wrong hash for the signature in the middle
+	hashes[50] = crypto.SHA3Sum256([]byte("wrong"))
+
+	errs := p.Run(n, func(i int) error {
+		pk, err := sigs[i].RecoverPublicKey(hashes[i])
+		if err != nil {
+			return err
+		}
+		if !pk.Equal(pks[i]) {
+			return errors.New("InvalidSignature")
+		}
+		return nil
+	})
+	assert.Len(t, errs, n)
+	for i, err := range errs {
+		if i == 50 {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+	assert.EqualValues(t, 0, p.queued)
+}
+
+func TestPool_RunNested(t *testing.T) {
+	p := NewPool(1, nil)
+	errs := p.Run(64, func(i int) error {
+		return p.Run(64, func(j int) error {
+			return nil
+		})[0]
+	})
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+}
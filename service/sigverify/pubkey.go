@@ -0,0 +1,92 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sigverify
+
+import (
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/cache"
+	"github.com/icon-project/goloop/common/crypto"
+)
+
+const (
+	ConfigPublicKeyCacheSize = 40000
+)
+
+// PublicKeyCache keeps public keys recovered from signatures by the hash and
+// the signature. The same signature is recovered on admission of the
+// transaction, on import of the block, and on verification of votes, and
+// recovering takes most of the time to import large blocks.
+type PublicKeyCache struct {
+	cache  *cache.LRUCache
+	metric signatureMetric
+}
+
+type signatureMetric interface {
+	OnPublicKeyCache(hit bool)
+}
+
+func NewPublicKeyCache(size int, m signatureMetric) *PublicKeyCache {
+	return &PublicKeyCache{
+		cache:  cache.NewLRUCache(size, nil),
+		metric: m,
+	}
+}
+
+func keyOf(sig *crypto.Signature, hash []byte) (string, bool) {
+	bs, err := sig.SerializeVRS()
+	if err != nil {
+		return "", false
+	}
+	return string(hash) + string(bs), true
+}
+
+// RecoverPublicKey returns the public key recovered from the signature of
+// the hash. Failures are not cached.
+func (c *PublicKeyCache) RecoverPublicKey(sig common.Signature, hash []byte) (*crypto.PublicKey, error) {
+	if sig.Signature == nil {
+		return sig.RecoverPublicKey(hash)
+	}
+	key, ok := keyOf(sig.Signature, hash)
+	if !ok {
+		return sig.RecoverPublicKey(hash)
+	}
+	if v, err := c.cache.Get(key); err == nil {
+		c.onPublicKeyCache(true)
+		return v.(*crypto.PublicKey), nil
+	}
+	c.onPublicKeyCache(false)
+	pk, err := sig.RecoverPublicKey(hash)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Put(key, pk)
+	return pk, nil
+}
+
+func (c *PublicKeyCache) onPublicKeyCache(hit bool) {
+	if c.metric != nil {
+		c.metric.OnPublicKeyCache(hit)
+	}
+}
+
+// RecoverPublicKey recovers the public key with the cache shared in the
+// process.
+func RecoverPublicKey(sig common.Signature, hash []byte) (*crypto.PublicKey, error) {
+	return defaultPublicKeyCache.RecoverPublicKey(sig, hash)
+}
+
+var defaultPublicKeyCache = NewPublicKeyCache(ConfigPublicKeyCacheSize, defaultMetric)
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sigverify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+)
+
+type testSignatureMetric struct {
+	hits   int
+	misses int
+}
+
+func (m *testSignatureMetric) OnPublicKeyCache(hit bool) {
+	if hit {
+		m.hits += 1
+	} else {
+		m.misses += 1
+	}
+}
+
+func TestPublicKeyCache_RecoverPublicKey(t *testing.T) {
+	m := new(testSignatureMetric)
+	c := NewPublicKeyCache(1, m)
+
+	sk, pk := crypto.GenerateKeyPair()
+	hash1 := crypto.SHA3Sum256([]byte("hash1"))
+	hash2 := crypto.SHA3Sum256([]byte("hash2"))
+	sig, err := crypto.NewSignature(hash1, sk)
+	assert.NoError(t, err)
+	s := common.Signature{Signature: sig}
+
+	rpk, err := c.RecoverPublicKey(s, hash1)
+	assert.NoError(t, err)
+	assert.True(t, pk.Equal(rpk))
+	rpk, err = c.RecoverPublicKey(s, hash1)
+	assert.NoError(t, err)
+	assert.True(t, pk.Equal(rpk))
+	assert.Equal(t, 1, m.hits)
+	assert.Equal(t, 1, m.misses)
+
+	// same signature for another hash isn't the cached one
+	rpk, err = c.RecoverPublicKey(s, hash2)
+	if err == nil {
+		assert.False(t, pk.Equal(rpk))
+	}
+	assert.Equal(t, 2, m.misses)
+
+	_, err = c.RecoverPublicKey(common.Signature{}, hash1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const (
+	MaxAccessListItems = 256
+)
+
+// AccessListEntry is an account and storage keys of the account to be
+// accessed by the transaction.
+type AccessListEntry struct {
+	Address common.Address    `json:"address"`
+	Keys    []common.HexBytes `json:"keys,omitempty"`
+}
+
+// AccessList is a list of accounts and storage keys declared in the
+// transaction. They are charged in advance with StepTypeAccessList, then
+// accessing them in the transaction isn't charged as cold access.
+type AccessList []AccessListEntry
+
+// Items returns the number of accounts and storage keys in the list.
+func (l AccessList) Items() int {
+	items := 0
+	for _, e := range l {
+		items += 1 + len(e.Keys)
+	}
+	return items
+}
+
+func (l AccessList) Verify() error {
+	if items := l.Items(); items > MaxAccessListItems {
+		return errors.IllegalArgumentError.Errorf(
+			"TooManyAccessListItems(items=%d,max=%d)", items, MaxAccessListItems)
+	}
+	addrs := make(map[string]bool, len(l))
+	for _, e := range l {
+		id := string(e.Address.Bytes())
+		if addrs[id] {
+			return errors.IllegalArgumentError.Errorf(
+				"DuplicateAccessListAddress(addr=%s)", &e.Address)
+		}
+		addrs[id] = true
+		if len(e.Keys) > 0 && !e.Address.IsContract() {
+			return errors.IllegalArgumentError.Errorf(
+				"KeysForNonContract(addr=%s)", &e.Address)
+		}
+	}
+	return nil
+}
@@ -61,6 +61,8 @@ type AccountSnapshot interface {
 	AccountData
 	trie.Object
 	StorageChangedAfter(snapshot AccountSnapshot) bool
+	// Store returns the storage of the account, or nil if it's empty.
+	Store() trie.Immutable
 	Contract() ContractSnapshot
 	ActiveContract() ContractSnapshot
 	NextContract() ContractSnapshot
@@ -56,6 +56,7 @@ type BTPContext interface {
 	BlockHeight() int64
 	GetValidatorState() ValidatorState
 	GetNetworkTypeIDs() ([]int64, error)
+	GetNetworkIDs() ([]int64, error)
 	GetNetworkTypeIDByName(name string) int64
 	GetNetworkType(ntid int64) (module.BTPNetworkType, error)
 	GetNetwork(nid int64) (module.BTPNetwork, error)
@@ -106,6 +107,20 @@ func (bc *btpContext) GetNetworkTypeIDs() ([]int64, error) {
 	return ret, err
 }
 
+// GetNetworkIDs returns IDs of all networks including closed ones in
+// ascending order.
+func (bc *btpContext) GetNetworkIDs() ([]int64, error) {
+	last := scoredb.NewVarDB(bc.store, NetworkIDKey).Int64()
+	dbase := scoredb.NewDictDB(bc.store, NetworkByIDKey, 1)
+	ids := make([]int64, 0)
+	for nid := int64(1); nid <= last; nid++ {
+		if dbase.Get(nid) != nil {
+			ids = append(ids, nid)
+		}
+	}
+	return ids, nil
+}
+
 func (bc *btpContext) GetNetworkView(nid int64) (btp.NetworkView, error) {
 	ret, _ := bc.getNetwork(nid)
 	if ret == nil {
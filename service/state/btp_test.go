@@ -0,0 +1,51 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+func TestBTPContext_GetNetworkIDs(t *testing.T) {
+	database := db.NewMapDB()
+	as := newAccountState(database, nil, nil, false)
+	bc := NewBTPContext(nil, as)
+	owner := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+
+	ids, err := bc.GetNetworkIDs()
+	assert.NoError(t, err)
+	assert.Len(t, ids, 0)
+
+	nwDB := scoredb.NewDictDB(as, NetworkByIDKey, 1)
+	idDB := scoredb.NewVarDB(as, NetworkIDKey)
+	for _, nid := range []int64{1, 2, 4} {
+		nw := NewNetwork(1, "nw", owner, 10, false)
+		assert.NoError(t, nwDB.Set(nid, nw.Bytes()))
+	}
+	assert.NoError(t, idDB.Set(5))
+
+	// networks without values are skipped in ascending order
+	ids, err = bc.GetNetworkIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 4}, ids)
+}
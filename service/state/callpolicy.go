@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+// Keys of the call policy
+const (
+	CallPolicyMaxDepth         = "maxDepth"
+	CallPolicyMaxReadOnlyDepth = "maxReadOnlyDepth"
+	CallPolicyReentrancy       = "reentrancy"
+)
+
+var AllCallPolicyKeys = []string{
+	CallPolicyMaxDepth,
+	CallPolicyMaxReadOnlyDepth,
+	CallPolicyReentrancy,
+}
+
+// Reentrancy guard modes. With ReentrancyReadOnly, it allows calling
+// a contract already in the call stack only in read-only mode.
+const (
+	ReentrancyAllow = iota
+	ReentrancyReadOnly
+	ReentrancyDeny
+)
+
+// CallPolicy limits inter-contract calls in a transaction. Zero values
+// mean no limitation.
+type CallPolicy struct {
+	// MaxDepth is the maximum depth of nested calls.
+	MaxDepth int
+	// MaxReadOnlyDepth is the maximum depth of nested calls in read-only
+	// mode.
+	MaxReadOnlyDepth int
+	// Reentrancy is the guard mode for calling a contract already in
+	// the call stack.
+	Reentrancy int
+}
+
+func (p *CallPolicy) Set(key string, value int64) error {
+	if value < 0 {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidValue(key=%s,value=%d)", key, value)
+	}
+	switch key {
+	case CallPolicyMaxDepth:
+		p.MaxDepth = int(value)
+	case CallPolicyMaxReadOnlyDepth:
+		p.MaxReadOnlyDepth = int(value)
+	case CallPolicyReentrancy:
+		if value > ReentrancyDeny {
+			return errors.IllegalArgumentError.Errorf(
+				"InvalidReentrancyMode(value=%d)", value)
+		}
+		p.Reentrancy = int(value)
+	default:
+		return errors.IllegalArgumentError.Errorf("InvalidCallPolicy(key=%s)", key)
+	}
+	return nil
+}
+
+func (p *CallPolicy) Get(key string) int64 {
+	switch key {
+	case CallPolicyMaxDepth:
+		return int64(p.MaxDepth)
+	case CallPolicyMaxReadOnlyDepth:
+		return int64(p.MaxReadOnlyDepth)
+	case CallPolicyReentrancy:
+		return int64(p.Reentrancy)
+	default:
+		return 0
+	}
+}
+
+func (p *CallPolicy) ToJSON() map[string]interface{} {
+	jso := make(map[string]interface{}, len(AllCallPolicyKeys))
+	for _, key := range AllCallPolicyKeys {
+		jso[key] = p.Get(key)
+	}
+	return jso
+}
+
+// LoadCallPolicy loads the call policy from the storage of the system
+// account.
+func LoadCallPolicy(as containerdb.BytesStoreState) CallPolicy {
+	var p CallPolicy
+	db := scoredb.NewDictDB(as, VarCallPolicy, 1)
+	for _, key := range AllCallPolicyKeys {
+		if v := db.Get(key); v != nil {
+			p.Set(key, v.Int64())
+		}
+	}
+	return p
+}
+
+// StoreCallPolicy updates the value of the call policy in the storage of
+// the system account.
+func StoreCallPolicy(as containerdb.BytesStoreState, key string, value int64) error {
+	var p CallPolicy
+	if err := p.Set(key, value); err != nil {
+		return err
+	}
+	db := scoredb.NewDictDB(as, VarCallPolicy, 1)
+	if value == 0 {
+		return db.Delete(key)
+	}
+	return db.Set(key, value)
+}
@@ -30,11 +30,15 @@ const (
 	StepTypeDeleteBase       = "deleteBase"
 	StepTypeLogBase          = "logBase"
 	StepTypeLog              = "log"
+	StepTypeColdAccount      = "coldAccount"
+	StepTypeColdStorage      = "coldStorage"
+	StepTypeAccessList       = "accessList"
 )
 
 const (
 	StepLimitTypeInvoke = "invoke"
 	StepLimitTypeQuery  = "query"
+	StepLimitTypeBlock  = "block"
 )
 
 var AllStepLimitTypes = []string{
@@ -78,6 +82,9 @@ var AllStepTypes = []string{
 	StepTypeDeleteBase,
 	StepTypeLogBase,
 	StepTypeLog,
+	StepTypeColdAccount,
+	StepTypeColdStorage,
+	StepTypeAccessList,
 }
 
 func IsValidStepType(s string) bool {
@@ -103,6 +110,10 @@ func IsValidStepType(s string) bool {
 		StepTypeLogBase,
 		StepTypeLog:
 		return true
+	case StepTypeColdAccount,
+		StepTypeColdStorage,
+		StepTypeAccessList:
+		return true
 	default:
 		return false
 	}
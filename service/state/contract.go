@@ -43,6 +43,7 @@ const (
 	CTAppZip    = "application/zip"
 	CTAppJava   = "application/java"
 	CTAppSystem = "application/x.score.system"
+	CTAppEVM    = "application/x.evm"
 )
 
 type ContractSnapshot interface {
@@ -15,6 +15,7 @@ const (
 	PythonEE EEType = "python"
 	JavaEE   EEType = "java"
 	SystemEE EEType = "system"
+	EVMEE    EEType = "evm"
 )
 
 const (
@@ -106,6 +107,8 @@ func EETypeFromContentType(ct string) (EEType, bool) {
 		return JavaEE, true
 	case CTAppSystem:
 		return SystemEE, true
+	case CTAppEVM:
+		return EVMEE, true
 	default:
 		return NullEE, false
 	}
@@ -121,7 +124,7 @@ func MustEETypeFromContentType(ct string) EEType {
 
 func ValidateEEType(et EEType) bool {
 	switch et {
-	case PythonEE, JavaEE, SystemEE:
+	case PythonEE, JavaEE, SystemEE, EVMEE:
 		return true
 	default:
 		return false
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+type StateRecordType int
+
+const (
+	// StateRecordWorld is the first record of the export. Its value is
+	// RLP list of state hash, validators hash, extension data and BTP data.
+	StateRecordWorld StateRecordType = iota
+
+	// StateRecordAccount is for an account. Key is the key of the account
+	// in the world state trie (SHA3-256 of the address ID), and value is
+	// the bytes of the account snapshot.
+	StateRecordAccount
+
+	// StateRecordStorage is for a value in the storage of the account of
+	// the last StateRecordAccount.
+	StateRecordStorage
+)
+
+type StateRecord struct {
+	Type  StateRecordType
+	Key   []byte
+	Value []byte
+}
+
+// StateChunk is a part of the exported state. Chunks are chained with
+// the hash of the previous chunk, so the hash of the last chunk identifies
+// the whole export.
+type StateChunk struct {
+	Index    int64
+	PrevHash []byte
+	Records  []*StateRecord
+}
+
+func (c *StateChunk) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(c)
+}
+
+func (c *StateChunk) Hash() []byte {
+	return crypto.SHA3Sum256(c.Bytes())
+}
+
+type stateExporter struct {
+	size  int
+	chunk *StateChunk
+	cb    func(c *StateChunk) error
+}
+
+func (e *stateExporter) add(t StateRecordType, key, value []byte) error {
+	e.chunk.Records = append(e.chunk.Records, &StateRecord{t, key, value})
+	if len(e.chunk.Records) >= e.size {
+		return e.flush()
+	}
+	return nil
+}
+
+func (e *stateExporter) flush() error {
+	if len(e.chunk.Records) == 0 {
+		return nil
+	}
+	c := e.chunk
+	if err := e.cb(c); err != nil {
+		return err
+	}
+	e.chunk = &StateChunk{
+		Index:    c.Index + 1,
+		PrevHash: c.Hash(),
+	}
+	return nil
+}
+
+// ExportWorldSnapshot exports accounts and their storages of the world
+// snapshot in the order of keys in the tries, and calls cb for each chunk
+// of size records. Extension data is given by the caller because the
+// extension snapshot depends on the platform.
+func ExportWorldSnapshot(wss WorldSnapshot, extensionData []byte, size int, cb func(c *StateChunk) error) error {
+	ws, ok := wss.(*worldSnapshotImpl)
+	if !ok {
+		return errors.UnsupportedError.Errorf("UnsupportedSnapshot(type=%T)", wss)
+	}
+	if size <= 0 {
+		return errors.IllegalArgumentError.Errorf("InvalidChunkSize(size=%d)", size)
+	}
+	e := &stateExporter{
+		size:  size,
+		chunk: &StateChunk{},
+		cb:    cb,
+	}
+	var vh []byte
+	if ws.validators != nil {
+		vh = ws.validators.Hash()
+	}
+	world := codec.BC.MustMarshalToBytes([]interface{}{
+		ws.StateHash(), vh, extensionData, ws.BTPData(),
+	})
+	if err := e.add(StateRecordWorld, nil, world); err != nil {
+		return err
+	}
+	for itr := ws.accounts.Iterator(); itr.Has(); {
+		obj, key, err := itr.Get()
+		if err != nil {
+			return err
+		}
+		as, ok := obj.(*accountSnapshotImpl)
+		if !ok {
+			return errors.InvalidStateError.Errorf("InvalidAccount(type=%T)", obj)
+		}
+		if err := e.add(StateRecordAccount, key, as.Bytes()); err != nil {
+			return err
+		}
+		if store := as.Store(); store != nil {
+			for sitr := store.Iterator(); sitr.Has(); {
+				value, key, err := sitr.Get()
+				if err != nil {
+					return err
+				}
+				if err := e.add(StateRecordStorage, key, value); err != nil {
+					return err
+				}
+				if err := sitr.Next(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := itr.Next(); err != nil {
+			return err
+		}
+	}
+	return e.flush()
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/db"
+)
+
+func newExportTestSnapshot(t *testing.T, reverse bool) WorldSnapshot {
+	ws := NewWorldState(db.NewMapDB(), nil, nil, nil, nil)
+	for i := 0; i < 5; i++ {
+		idx := i
+		if reverse {
+			idx = 4 - i
+		}
+		as := ws.GetAccountState([]byte(fmt.Sprintf("account%d", idx)))
+		as.SetBalance(big.NewInt(int64(idx + 1)))
+		for j := 0; j < idx; j++ {
+			_, err := as.SetValue([]byte(fmt.Sprintf("key%d", j)), []byte{byte(j)})
+			assert.NoError(t, err)
+		}
+	}
+	return ws.GetSnapshot()
+}
+
+func exportTestSnapshot(t *testing.T, wss WorldSnapshot, size int) []*StateChunk {
+	var chunks []*StateChunk
+	err := ExportWorldSnapshot(wss, []byte("ext"), size, func(c *StateChunk) error {
+		chunks = append(chunks, c)
+		return nil
+	})
+	assert.NoError(t, err)
+	return chunks
+}
+
+func TestExportWorldSnapshot(t *testing.T) {
+	wss := newExportTestSnapshot(t, false)
+
+	// 1 world + 5 accounts + 10 values
+	chunks := exportTestSnapshot(t, wss, 4)
+	assert.Len(t, chunks, 4)
+	var records []*StateRecord
+	var prev []byte
+	for i, c := range chunks {
+		assert.EqualValues(t, i, c.Index)
+		assert.Equal(t, prev, c.PrevHash)
+		prev = c.Hash()
+		records = append(records, c.Records...)
+	}
+	assert.Len(t, records, 16)
+	assert.Equal(t, StateRecordWorld, records[0].Type)
+
+	var accounts, values int
+	for _, r := range records[1:] {
+		switch r.Type {
+		case StateRecordAccount:
+			accounts++
+		case StateRecordStorage:
+			assert.NotZero(t, accounts)
+			values++
+		}
+	}
+	assert.Equal(t, 5, accounts)
+	assert.Equal(t, 10, values)
+
+	// same records regardless of chunk size
+	chunks2 := exportTestSnapshot(t, wss, 100)
+	assert.Len(t, chunks2, 1)
+	assert.Equal(t, records, chunks2[0].Records)
+
+	// same export regardless of the order of changes
+	chunks3 := exportTestSnapshot(t, newExportTestSnapshot(t, true), 4)
+	assert.Equal(t, prev, chunks3[len(chunks3)-1].Hash())
+
+	err := ExportWorldSnapshot(wss, nil, 0, nil)
+	assert.Error(t, err)
+}
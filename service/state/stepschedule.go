@@ -0,0 +1,168 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+// StepChange is a change of the step price or a step cost scheduled to
+// be applied at the beginning of the block at the height. Empty Type is
+// used for the step price.
+type StepChange struct {
+	Height int64
+	Type   string
+	Value  *big.Int
+}
+
+func (c *StepChange) IsPrice() bool {
+	return len(c.Type) == 0
+}
+
+func (c *StepChange) ToJSON() map[string]interface{} {
+	jso := map[string]interface{}{
+		"height": c.Height,
+	}
+	if c.IsPrice() {
+		jso["price"] = c.Value
+	} else {
+		jso["type"] = c.Type
+		jso["cost"] = c.Value
+	}
+	return jso
+}
+
+// LoadStepSchedule returns the scheduled changes ordered by height.
+func LoadStepSchedule(as containerdb.BytesStoreState) ([]*StepChange, error) {
+	bs := scoredb.NewVarDB(as, VarStepSchedule).Bytes()
+	if len(bs) == 0 {
+		return nil, nil
+	}
+	var changes []*StepChange
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &changes); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidStepSchedule")
+	}
+	return changes, nil
+}
+
+func storeStepSchedule(as containerdb.BytesStoreState, changes []*StepChange) error {
+	vdb := scoredb.NewVarDB(as, VarStepSchedule)
+	if len(changes) == 0 {
+		_, err := vdb.Delete()
+		return err
+	}
+	bs, err := codec.BC.MarshalToBytes(changes)
+	if err != nil {
+		return err
+	}
+	return vdb.Set(bs)
+}
+
+// ScheduleStepChange adds the change to the schedule. It replaces the
+// change for the same type at the same height.
+func ScheduleStepChange(as containerdb.BytesStoreState, change *StepChange) error {
+	if change.Value == nil || change.Value.Sign() < 0 {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidValue(value=%v)", change.Value)
+	}
+	changes, err := LoadStepSchedule(as)
+	if err != nil {
+		return err
+	}
+	idx := len(changes)
+	for i, c := range changes {
+		if c.Height == change.Height && c.Type == change.Type {
+			changes[i] = change
+			return storeStepSchedule(as, changes)
+		}
+		if c.Height > change.Height {
+			idx = i
+			break
+		}
+	}
+	changes = append(changes, nil)
+	copy(changes[idx+1:], changes[idx:])
+	changes[idx] = change
+	return storeStepSchedule(as, changes)
+}
+
+// CancelStepChanges removes the changes scheduled at the height. It
+// returns the number of removed changes.
+func CancelStepChanges(as containerdb.BytesStoreState, height int64) (int, error) {
+	changes, err := LoadStepSchedule(as)
+	if err != nil {
+		return 0, err
+	}
+	remains := changes[:0]
+	for _, c := range changes {
+		if c.Height != height {
+			remains = append(remains, c)
+		}
+	}
+	removed := len(changes) - len(remains)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, storeStepSchedule(as, remains)
+}
+
+// ApplyStepSchedule applies the changes scheduled at or before the height,
+// and removes them from the schedule. It returns the number of applied
+// changes.
+func ApplyStepSchedule(as containerdb.BytesStoreState, height int64) (int, error) {
+	changes, err := LoadStepSchedule(as)
+	if err != nil {
+		return 0, err
+	}
+	applied := 0
+	for _, c := range changes {
+		if c.Height > height {
+			break
+		}
+		if c.IsPrice() {
+			err = scoredb.NewVarDB(as, VarStepPrice).Set(c.Value)
+		} else {
+			err = StoreStepCost(as, c.Type, c.Value)
+		}
+		if err != nil {
+			return 0, err
+		}
+		applied += 1
+	}
+	if applied == 0 {
+		return 0, nil
+	}
+	return applied, storeStepSchedule(as, changes[applied:])
+}
+
+// StoreStepCost sets the cost of the step type in the storage of the
+// system account.
+func StoreStepCost(as containerdb.BytesStoreState, costType string, cost *big.Int) error {
+	stepCostDB := scoredb.NewDictDB(as, VarStepCosts, 1)
+	if stepCostDB.Get(costType) == nil {
+		stepTypes := scoredb.NewArrayDB(as, VarStepTypes)
+		if err := stepTypes.Put(costType); err != nil {
+			return err
+		}
+	}
+	return stepCostDB.Set(costType, cost)
+}
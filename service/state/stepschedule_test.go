@@ -0,0 +1,68 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+func TestStepSchedule(t *testing.T) {
+	database := db.NewMapDB()
+	as := newAccountState(database, nil, nil, false)
+
+	err := ScheduleStepChange(as, &StepChange{Height: 20, Value: big.NewInt(-1)})
+	assert.Error(t, err)
+
+	assert.NoError(t, ScheduleStepChange(as, &StepChange{
+		Height: 20, Value: big.NewInt(100),
+	}))
+	assert.NoError(t, ScheduleStepChange(as, &StepChange{
+		Height: 10, Type: StepTypeDefault, Value: big.NewInt(200),
+	}))
+	assert.NoError(t, ScheduleStepChange(as, &StepChange{
+		Height: 20, Type: StepTypeDefault, Value: big.NewInt(300),
+	}))
+	assert.NoError(t, ScheduleStepChange(as, &StepChange{
+		Height: 30, Value: big.NewInt(400),
+	}))
+	// replace the last one
+	assert.NoError(t, ScheduleStepChange(as, &StepChange{
+		Height: 30, Value: big.NewInt(500),
+	}))
+
+	changes, err := LoadStepSchedule(as)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 4)
+	for i, h := range []int64{10, 20, 20, 30} {
+		assert.Equal(t, h, changes[i].Height)
+	}
+	assert.EqualValues(t, 500, changes[3].Value.Int64())
+
+	n, err := ApplyStepSchedule(as, 9)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	n, err = ApplyStepSchedule(as, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.EqualValues(t, 100, scoredb.NewVarDB(as, VarStepPrice).Int64())
+	costs := scoredb.NewDictDB(as, VarStepCosts, 1)
+	assert.EqualValues(t, 300, costs.Get(StepTypeDefault).Int64())
+	assert.Equal(t, 1, scoredb.NewArrayDB(as, VarStepTypes).Size())
+
+	n, err = CancelStepChanges(as, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	n, err = CancelStepChanges(as, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	changes, err = LoadStepSchedule(as)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 0)
+}
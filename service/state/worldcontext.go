@@ -5,6 +5,7 @@ import (
 
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/scoredb"
@@ -31,6 +32,8 @@ const (
 	VarTimestampThreshold = "timestamp_threshold"
 	VarBlockInterval      = "block_interval"
 	VarCommitTimeout      = "commit_timeout"
+	VarMaxBlockInterval   = "max_block_interval"
+	VarTxPoolThreshold    = "tx_pool_threshold"
 	VarRoundLimitFactor   = "round_limit_factor"
 	VarMinimizeBlockGen   = "minimize_block_gen"
 	VarTxHashToAddress    = "tx_to_address"
@@ -38,7 +41,11 @@ const (
 	VarDepositIssueRate   = "deposit_issue_rate"
 	VarNextBlockVersion   = "next_block_version"
 	VarEnabledEETypes     = "enabled_ee_types"
+	VarDeploymentPolicy   = "deployment_policy"
+	VarPendingAudits      = "pending_audits"
 	VarSystemDepositUsage = "system_deposit_usage"
+	VarCallPolicy         = "call_policy"
+	VarStepSchedule       = "step_schedule"
 )
 
 const (
@@ -52,11 +59,39 @@ const (
 	SysConfigScorePackageValidator
 	SysConfigMembership
 	SysConfigFeeSharing
+	SysConfigEVM
 )
 
+// Deployment policies for the chain. With DeploymentPolicyAudit, deployed
+// contracts are activated after acceptance of the governance.
+// With DeploymentPolicyAllowlist, only registered deployers can deploy.
+const (
+	DeploymentPolicyOpen      = "open"
+	DeploymentPolicyAudit     = "audit"
+	DeploymentPolicyAllowlist = "allowlist"
+)
+
+// SysConfigForDeploymentPolicy returns system configuration applying
+// the policy.
+func SysConfigForDeploymentPolicy(conf int64, policy string) (int64, error) {
+	conf &^= SysConfigAudit | SysConfigDeployerWhiteList
+	switch policy {
+	case DeploymentPolicyOpen:
+	case DeploymentPolicyAudit:
+		conf |= SysConfigAudit
+	case DeploymentPolicyAllowlist:
+		conf |= SysConfigDeployerWhiteList
+	default:
+		return 0, errors.IllegalArgumentError.Errorf(
+			"InvalidDeploymentPolicy(policy=%s)", policy)
+	}
+	return conf, nil
+}
+
 const (
 	InfoBlockTimestamp = "B.timestamp"
 	InfoBlockHeight    = "B.height"
+	InfoBlockSeed      = "B.seed"
 	InfoTxHash         = "T.hash"
 	InfoTxIndex        = "T.index"
 	InfoTxTimestamp    = "T.timestamp"
@@ -95,6 +130,7 @@ type WorldContext interface {
 	Governance() module.Address
 	GetInfo() map[string]interface{}
 	WorldStateChanged(ws WorldState) WorldContext
+	BlockChanged(ws WorldState, bi module.BlockInfo) WorldContext
 	WorldVirtualState() WorldVirtualState
 	GetFuture(lq []LockRequest) WorldContext
 	SetTransactionInfo(ti *TransactionInfo)
@@ -114,7 +150,9 @@ type WorldContext interface {
 	DeployerWhiteListEnabled() bool
 	PackageValidatorEnabled() bool
 	MembershipEnabled() bool
+	EVMEnabled() bool
 	TransactionTimestampThreshold() int64
+	CallPolicy() CallPolicy
 
 	EnableSkipTransaction()
 	SkipTransactionEnabled() bool
@@ -127,6 +165,10 @@ type TransactionInfo struct {
 	From      module.Address
 	Timestamp int64
 	Nonce     *big.Int
+
+	// Retry is the number of retries for executing the transaction.
+	// It's not exposed to contracts.
+	Retry int
 }
 
 type ContractInfo struct {
@@ -187,6 +229,7 @@ type systemStorageInfo struct {
 	stepCostInfo *codec.TypedObj
 	revision     module.Revision
 	feeLimit     *big.Int
+	callPolicy   CallPolicy
 }
 
 func (si *systemStorageInfo) Update(wc *worldContext) bool {
@@ -235,6 +278,7 @@ func (si *systemStorageInfo) Update(wc *worldContext) bool {
 	}
 
 	si.sysConfig = scoredb.NewVarDB(as, VarServiceConfig).Int64()
+	si.callPolicy = LoadCallPolicy(as)
 	return true
 }
 
@@ -308,6 +352,10 @@ func (c *worldContext) MembershipEnabled() bool {
 	return (c.systemInfo.sysConfig & SysConfigMembership) != 0
 }
 
+func (c *worldContext) EVMEnabled() bool {
+	return (c.systemInfo.sysConfig & SysConfigEVM) != 0
+}
+
 func (c *worldContext) TransactionTimestampThreshold() int64 {
 	ass := c.GetAccountSnapshot(SystemID)
 	as := scoredb.NewStateStoreWith(ass)
@@ -315,6 +363,10 @@ func (c *worldContext) TransactionTimestampThreshold() int64 {
 	return tshInMS * 1000
 }
 
+func (c *worldContext) CallPolicy() CallPolicy {
+	return c.systemInfo.callPolicy
+}
+
 func (c *worldContext) IsDeployer(addr string) bool {
 	ass := c.GetAccountSnapshot(SystemID)
 	as := scoredb.NewStateStoreWith(ass)
@@ -366,6 +418,24 @@ func (c *worldContext) WorldStateChanged(ws WorldState) WorldContext {
 	return wc
 }
 
+// BlockChanged returns a new context for the block on the world state. The
+// world state should be on the snapshot of the context, then loaded system
+// information is shared, and it's reloaded only if the system storage is
+// changed in the world state.
+func (c *worldContext) BlockChanged(ws WorldState, bi module.BlockInfo) WorldContext {
+	wc := &worldContext{
+		WorldState:   ws,
+		virtualState: tryVirtualState(ws),
+		treasury:     c.treasury,
+		governance:   c.governance,
+		systemInfo:   c.systemInfo,
+		blockInfo:    bi,
+		platform:     c.platform,
+	}
+	wc.UpdateSystemInfo()
+	return wc
+}
+
 func (c *worldContext) SetTransactionInfo(ti *TransactionInfo) {
 	c.txInfo = *ti
 	c.info = nil
@@ -413,6 +483,9 @@ func (c *worldContext) stepCostInfo() interface{} {
 			case StepTypeContractUpdate:
 			case StepTypeContractDestruct:
 			case StepTypeContractSet:
+			case StepTypeColdAccount:
+			case StepTypeColdStorage:
+			case StepTypeAccessList:
 			case StepTypeInput:
 				continue
 			default:
@@ -429,6 +502,11 @@ func (c *worldContext) GetInfo() map[string]interface{} {
 		m := make(map[string]interface{})
 		m[InfoBlockHeight] = c.BlockHeight()
 		m[InfoBlockTimestamp] = c.BlockTimeStamp()
+		if c.Revision().Has(module.VRFProposerSelection) {
+			if seed := common.SeedOf(c.csInfo); seed != nil {
+				m[InfoBlockSeed] = seed
+			}
+		}
 		m[InfoTxHash] = c.txInfo.Hash
 		m[InfoTxIndex] = c.txInfo.Index
 		m[InfoTxTimestamp] = c.txInfo.Timestamp
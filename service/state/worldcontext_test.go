@@ -0,0 +1,70 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoredb"
+)
+
+type testPlatform struct{}
+
+func (testPlatform) ToRevision(value int) module.Revision {
+	if value >= 2 {
+		return module.VRFProposerSelection
+	}
+	return 0
+}
+
+type testSeedVotes struct {
+	module.CommitVoteSet
+}
+
+func (testSeedVotes) ProposerSeed() []byte {
+	return []byte{1, 2, 3}
+}
+
+func TestWorldContext_GetInfoBlockSeed(t *testing.T) {
+	csi := common.NewConsensusInfoWithSeed(nil, nil, nil, testSeedVotes{}, nil)
+	for _, rev := range []int{1, 2} {
+		ws := NewWorldState(db.NewMapDB(), nil, nil, nil, nil)
+		as := ws.GetAccountState(SystemID)
+		assert.NoError(t, scoredb.NewVarDB(as, VarRevision).Set(rev))
+
+		wc := NewWorldContext(ws, common.NewBlockInfo(10, 1000), csi, testPlatform{})
+		seed, ok := wc.GetInfo()[InfoBlockSeed]
+		if rev < 2 {
+			assert.False(t, ok)
+		} else {
+			assert.Equal(t, []byte{1, 2, 3}, seed)
+		}
+	}
+
+	ws := NewWorldState(db.NewMapDB(), nil, nil, nil, nil)
+	as := ws.GetAccountState(SystemID)
+	assert.NoError(t, scoredb.NewVarDB(as, VarRevision).Set(2))
+	csi = common.NewConsensusInfo(nil, nil, nil)
+	wc := NewWorldContext(ws, common.NewBlockInfo(10, 1000), csi, testPlatform{})
+	_, ok := wc.GetInfo()[InfoBlockSeed]
+	assert.False(t, ok)
+}
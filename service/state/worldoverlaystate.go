@@ -0,0 +1,272 @@
+package state
+
+import (
+	"sync"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+// worldOverlayState is the world state keeping changes over the base
+// snapshot in memory. It's for speculative execution like simulation and
+// estimation of transactions. Changes are applied to the trie only when
+// StateHash or Flush of its snapshot is called, so it's discarded cheaply
+// by dropping it. Caches of nodes are not used, so speculative changes
+// don't affect them.
+type worldOverlayState struct {
+	mutex sync.Mutex
+
+	base       WorldSnapshot
+	last       map[string]AccountSnapshot
+	accounts   map[string]AccountState
+	validators ValidatorState
+	extension  extensionStateHolder
+	btp        BTPState
+}
+
+func (ws *worldOverlayState) getAccountSnapshotInLock(id []byte) AccountSnapshot {
+	if ass, ok := ws.last[string(id)]; ok {
+		return ass
+	}
+	return ws.base.GetAccountSnapshot(id)
+}
+
+func (ws *worldOverlayState) GetAccountState(id []byte) AccountState {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	ids := string(id)
+	if as, ok := ws.accounts[ids]; ok {
+		return as
+	}
+	as := newAccountState(ws.Database(), ws.getAccountSnapshotInLock(id), addressIDToKey(id), false)
+	ws.accounts[ids] = as
+	return as
+}
+
+func (ws *worldOverlayState) GetAccountSnapshot(id []byte) AccountSnapshot {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	if as, ok := ws.accounts[string(id)]; ok {
+		return as.GetSnapshot()
+	}
+	if ass := ws.getAccountSnapshotInLock(id); ass != nil {
+		return ass
+	}
+	return newAccountSnapshot(ws.Database())
+}
+
+func (ws *worldOverlayState) GetSnapshot() WorldSnapshot {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	accounts := make(map[string]AccountSnapshot, len(ws.last)+len(ws.accounts))
+	for ids, ass := range ws.last {
+		accounts[ids] = ass
+	}
+	for ids, as := range ws.accounts {
+		accounts[ids] = as.GetSnapshot()
+	}
+	ws.last = accounts
+	return &worldOverlaySnapshot{
+		base:       ws.base,
+		accounts:   accounts,
+		validators: ws.validators.GetSnapshot(),
+		extension:  ws.extension.GetSnapshot(),
+		btp:        ws.btp.GetSnapshot(),
+	}
+}
+
+func (ws *worldOverlayState) GetValidatorState() ValidatorState {
+	return ws.validators
+}
+
+func (ws *worldOverlayState) GetExtensionState() ExtensionState {
+	return ws.extension.GetState()
+}
+
+func (ws *worldOverlayState) GetBTPState() BTPState {
+	return ws.btp
+}
+
+// Reset resets the state to the base snapshot or the snapshot returned by
+// GetSnapshot of the state.
+func (ws *worldOverlayState) Reset(snapshot WorldSnapshot) error {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	var wss *worldOverlaySnapshot
+	if snapshot == ws.base {
+		wss = &worldOverlaySnapshot{
+			base:       ws.base,
+			validators: ws.base.GetValidatorSnapshot(),
+			extension:  ws.base.GetExtensionSnapshot(),
+			btp:        ws.base.GetBTPSnapshot(),
+		}
+	} else if s, ok := snapshot.(*worldOverlaySnapshot); ok && s.base == ws.base {
+		wss = s
+	} else {
+		return errors.InvalidStateError.New("InvalidSnapshot")
+	}
+
+	ws.last = wss.accounts
+	for ids, as := range ws.accounts {
+		if ass := ws.getAccountSnapshotInLock([]byte(ids)); ass != nil {
+			if err := as.Reset(ass); err != nil {
+				return err
+			}
+		} else {
+			as.Clear()
+		}
+	}
+	ws.validators.Reset(wss.validators)
+	ws.extension.Reset(wss.extension)
+	ws.btp.Reset(wss.btp)
+	return nil
+}
+
+func (ws *worldOverlayState) ClearCache() {
+	// nothing to do
+}
+
+func (ws *worldOverlayState) EnableNodeCache() {
+	// nothing to do
+}
+
+func (ws *worldOverlayState) NodeCacheEnabled() bool {
+	return false
+}
+
+func (ws *worldOverlayState) Database() db.Database {
+	return ws.base.Database()
+}
+
+func (ws *worldOverlayState) EnableAccountNodeCache(id []byte) bool {
+	return false
+}
+
+// NewWorldOverlayState returns the world state over the snapshot. The
+// snapshot may be the one of other overlay state, so changes can be stacked.
+func NewWorldOverlayState(wss WorldSnapshot) WorldState {
+	ws := &worldOverlayState{
+		base:       wss,
+		accounts:   make(map[string]AccountState),
+		validators: ValidatorStateFromSnapshot(wss.GetValidatorSnapshot()),
+		btp:        NewBTPState(wss.Database(), nil),
+	}
+	ws.extension.Reset(wss.GetExtensionSnapshot())
+	ws.btp.Reset(wss.GetBTPSnapshot())
+	return ws
+}
+
+type worldOverlaySnapshot struct {
+	lock sync.Mutex
+
+	base       WorldSnapshot
+	accounts   map[string]AccountSnapshot
+	validators ValidatorSnapshot
+	extension  ExtensionSnapshot
+	btp        BTPSnapshot
+
+	real *worldSnapshotImpl
+}
+
+func (wss *worldOverlaySnapshot) GetAccountSnapshot(id []byte) AccountSnapshot {
+	if ass, ok := wss.accounts[string(id)]; ok {
+		return ass
+	}
+	return wss.base.GetAccountSnapshot(id)
+}
+
+func (wss *worldOverlaySnapshot) GetValidatorSnapshot() ValidatorSnapshot {
+	return wss.validators
+}
+
+func (wss *worldOverlaySnapshot) GetExtensionSnapshot() ExtensionSnapshot {
+	return wss.extension
+}
+
+func (wss *worldOverlaySnapshot) ExtensionData() []byte {
+	if wss.extension != nil {
+		return wss.extension.Bytes()
+	}
+	return nil
+}
+
+func (wss *worldOverlaySnapshot) GetBTPSnapshot() BTPSnapshot {
+	return wss.btp
+}
+
+func (wss *worldOverlaySnapshot) BTPData() []byte {
+	if wss.btp != nil {
+		return wss.btp.Bytes()
+	}
+	return nil
+}
+
+func (wss *worldOverlaySnapshot) Database() db.Database {
+	return wss.base.Database()
+}
+
+func realWorldSnapshotOf(wss WorldSnapshot) (*worldSnapshotImpl, error) {
+	switch s := wss.(type) {
+	case *worldSnapshotImpl:
+		return s, nil
+	case *worldOverlaySnapshot:
+		return s.realize()
+	default:
+		return nil, errors.UnsupportedError.Errorf("UnsupportedSnapshot(type=%T)", wss)
+	}
+}
+
+// realize applies changes to the trie of accounts of the base snapshot.
+func (wss *worldOverlaySnapshot) realize() (*worldSnapshotImpl, error) {
+	wss.lock.Lock()
+	defer wss.lock.Unlock()
+
+	if wss.real != nil {
+		return wss.real, nil
+	}
+	base, err := realWorldSnapshotOf(wss.base)
+	if err != nil {
+		return nil, err
+	}
+	accounts := base.accounts
+	if len(wss.accounts) > 0 {
+		ws, err := WorldStateFromSnapshot(base)
+		if err != nil {
+			return nil, err
+		}
+		for ids, ass := range wss.accounts {
+			if err := ws.GetAccountState([]byte(ids)).Reset(ass); err != nil {
+				return nil, err
+			}
+		}
+		accounts = ws.GetSnapshot().(*worldSnapshotImpl).accounts
+	}
+	wss.real = &worldSnapshotImpl{
+		database:   base.database,
+		accounts:   accounts,
+		validators: wss.validators,
+		extension:  wss.extension,
+		btp:        wss.btp,
+	}
+	return wss.real, nil
+}
+
+func (wss *worldOverlaySnapshot) StateHash() []byte {
+	real, err := wss.realize()
+	if err != nil {
+		return nil
+	}
+	return real.StateHash()
+}
+
+func (wss *worldOverlaySnapshot) Flush() error {
+	real, err := wss.realize()
+	if err != nil {
+		return err
+	}
+	return real.Flush()
+}
@@ -0,0 +1,68 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestWorldOverlayState(t *testing.T) {
+	database := db.NewMapDB()
+	ws := NewWorldState(database, nil, nil, nil, nil)
+	id1 := []byte("account1")
+	id2 := []byte("account2")
+	ws.GetAccountState(id1).SetBalance(big.NewInt(100))
+	base := ws.GetSnapshot()
+
+	ows := NewWorldOverlayState(base)
+	as1 := ows.GetAccountState(id1)
+	assert.Equal(t, int64(100), as1.GetBalance().Int64())
+	as1.SetBalance(big.NewInt(200))
+	s1 := ows.GetSnapshot()
+
+	ows.GetAccountState(id2).SetBalance(big.NewInt(300))
+	assert.Equal(t, int64(300), ows.GetAccountSnapshot(id2).GetBalance().Int64())
+
+	// base isn't changed
+	assert.Equal(t, int64(100), base.GetAccountSnapshot(id1).GetBalance().Int64())
+	assert.Nil(t, base.GetAccountSnapshot(id2))
+
+	assert.NoError(t, ows.Reset(s1))
+	assert.Equal(t, int64(200), ows.GetAccountState(id1).GetBalance().Int64())
+	assert.Equal(t, 0, ows.GetAccountState(id2).GetBalance().Sign())
+
+	assert.NoError(t, ows.Reset(base))
+	assert.Equal(t, int64(100), ows.GetAccountState(id1).GetBalance().Int64())
+
+	assert.Error(t, ows.Reset(NewWorldState(database, nil, nil, nil, nil).GetSnapshot()))
+}
+
+func TestWorldOverlayState_Stack(t *testing.T) {
+	database := db.NewMapDB()
+	ws := NewWorldState(database, nil, nil, nil, nil)
+	id1 := []byte("account1")
+	id2 := []byte("account2")
+	ws.GetAccountState(id1).SetBalance(big.NewInt(100))
+	base := ws.GetSnapshot()
+
+	ows1 := NewWorldOverlayState(base)
+	ows1.GetAccountState(id1).SetBalance(big.NewInt(200))
+	ows2 := NewWorldOverlayState(ows1.GetSnapshot())
+	assert.Equal(t, int64(200), ows2.GetAccountState(id1).GetBalance().Int64())
+	ows2.GetAccountState(id2).SetBalance(big.NewInt(300))
+	oss := ows2.GetSnapshot()
+
+	ws.GetAccountState(id1).SetBalance(big.NewInt(200))
+	ws.GetAccountState(id2).SetBalance(big.NewInt(300))
+	expected := ws.GetSnapshot()
+
+	assert.Equal(t, expected.StateHash(), oss.StateHash())
+	assert.NoError(t, oss.Flush())
+
+	wss := NewWorldSnapshot(database, oss.StateHash(), nil, nil, nil)
+	assert.Equal(t, int64(200), wss.GetAccountSnapshot(id1).GetBalance().Int64())
+	assert.Equal(t, int64(300), wss.GetAccountSnapshot(id2).GetBalance().Int64())
+}
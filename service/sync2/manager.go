@@ -7,12 +7,13 @@ import (
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/common/merkle"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/state"
 )
 
 const (
-	configSyncPriority              = 3
+	configSyncPriority              = 4
 	configExpiredTime               = 500 * time.Millisecond
 	configMaxExpiredTime            = 1300 * time.Millisecond
 	configMigrationInterval         = 200 * time.Millisecond
@@ -84,11 +85,22 @@ func (m *Manager) Term() {
 	m.ds.Term()
 }
 
-func NewSyncManager(database db.Database, nm module.NetworkManager, plt Platform, logger log.Logger) *Manager {
+// NewSyncManager returns the manager for state sync. Requests of other peers
+// are served under the throttle, and nil throttle means no limit. Without
+// the network manager, it neither serves nor sends requests.
+func NewSyncManager(database db.Database, nm module.NetworkManager, plt Platform, th *throttle.Throttle, logger log.Logger) *Manager {
 	logger = logger.WithFields(log.Fields{log.FieldKeyModule: "statesync2"})
 	m := new(Manager)
+	m.db = database
+	m.plt = plt
+	m.logger = logger
+	if nm == nil {
+		m.ds = newDataSyncer(m.db, m.reactors, logger)
+		return m
+	}
 
 	reactorV1 := newReactorV1(database, logger)
+	reactorV1.throttle = th
 	ph, err := nm.RegisterReactorForStreams("statesync", module.ProtoStateSync, reactorV1, protocol, configSyncPriority, module.NotRegisteredProtocolPolicyClose)
 	if err != nil {
 		logger.Panicf("Failed to register reactorV1 for stateSync")
@@ -98,6 +110,7 @@ func NewSyncManager(database db.Database, nm module.NetworkManager, plt Platform
 	m.reactors = append(m.reactors, reactorV1)
 
 	reactorV2 := newReactorV2(database, logger)
+	reactorV2.throttle = th
 	pi2 := module.NewProtocolInfo(module.ProtoStateSync.ID(), 1)
 	ph2, err := nm.RegisterReactorForStreams("statesync2", pi2, reactorV2, protocolv2, configSyncPriority, module.NotRegisteredProtocolPolicyClose)
 	if err != nil {
@@ -107,10 +120,6 @@ func NewSyncManager(database db.Database, nm module.NetworkManager, plt Platform
 	reactorV2.ph = ph2
 	m.reactors = append(m.reactors, reactorV2)
 
-	m.db = database
-	m.plt = plt
-	m.logger = logger
-
 	m.ds = newDataSyncer(m.db, m.reactors, logger)
 	return m
 }
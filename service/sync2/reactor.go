@@ -9,6 +9,7 @@ import (
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/module"
 )
 
@@ -32,6 +33,9 @@ type ReactorCommon struct {
 	readyPool *peerPool
 	watchers  []PeerWatcher
 	sender    DataSender
+
+	// throttle limits serving requests of peers
+	throttle *throttle.Throttle
 }
 
 func (r *ReactorCommon) OnJoin(id module.PeerID) {
@@ -201,6 +205,8 @@ func (r *ReactorV1) onHasNode(msg []byte, id module.PeerID) {
 
 func (r *ReactorV1) onRequestNodeData(msg []byte, id module.PeerID) {
 	r.logger.Tracef("OnRequestNodeData() peer=%v", id)
+	r.throttle.Acquire(id.String(), nil)
+	defer r.throttle.Release(id.String())
 	res := r.requestNode(msg, id)
 
 	b, err := c.MarshalToBytes(res)
@@ -208,6 +214,7 @@ func (r *ReactorV1) onRequestNodeData(msg []byte, id module.PeerID) {
 		r.logger.Warnf("Failed to marshal for nodeData=%v", res)
 		return
 	}
+	r.throttle.WaitToSend(len(b), nil)
 	r.logger.Tracef("responseNode ReqID=%d, Status=%d, Type=%d to peer=%v", res.ReqID, res.Status, res.Type, id)
 	if err = r.ph.Unicast(protoNodeData, b, id); err != nil {
 		r.logger.Infof("Failed to send data peerID=%v, err=%+v", id, err)
@@ -74,6 +74,8 @@ func (r *ReactorV2) request(msg []byte, id module.PeerID) *responseData {
 }
 
 func (r *ReactorV2) onRequest(msg []byte, id module.PeerID) {
+	r.throttle.Acquire(id.String(), nil)
+	defer r.throttle.Release(id.String())
 	res := r.request(msg, id)
 
 	b, err := codec.MarshalToBytes(res)
@@ -81,6 +83,7 @@ func (r *ReactorV2) onRequest(msg []byte, id module.PeerID) {
 		r.logger.Warnf("Failed to marshal for responseData=%v", res)
 		return
 	}
+	r.throttle.WaitToSend(len(b), nil)
 	r.logger.Tracef("onRequest() responseData ReqID=%d, Status=%d, peer=%v", res.ReqID, res.Status, id)
 	if err = r.ph.Unicast(protoV2Response, b, id); err != nil {
 		r.logger.Infof("onRequest() Failed to send data peer=%v", id)
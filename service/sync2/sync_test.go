@@ -348,15 +348,15 @@ func TestSyncSimpleAccountSync(t *testing.T) {
 				return newSyncManagerV1(database, srcnm, dummyExBuilder, srcLog)
 			},
 			getDstMgr: func(database db.Database, dstnm *tNetworkManager, dstLog log.Logger) *Manager {
-				return NewSyncManager(database, dstnm, dummyExBuilder, dstLog)
+				return NewSyncManager(database, dstnm, dummyExBuilder, nil, dstLog)
 			},
 		},
 		"useProtocoV2": {
 			getSrcMgr: func(database db.Database, srcnm *tNetworkManager, srcLog log.Logger) *Manager {
-				return NewSyncManager(database, srcnm, dummyExBuilder, srcLog)
+				return NewSyncManager(database, srcnm, dummyExBuilder, nil, srcLog)
 			},
 			getDstMgr: func(database db.Database, dstnm *tNetworkManager, dstLog log.Logger) *Manager {
-				return NewSyncManager(database, dstnm, dummyExBuilder, dstLog)
+				return NewSyncManager(database, dstnm, dummyExBuilder, nil, dstLog)
 			},
 		},
 	}
@@ -426,7 +426,7 @@ func TestSyncSimpleStateSyncStop(t *testing.T) {
 	dstLog := logger.WithFields(log.Fields{log.FieldKeyWallet: dstNM.id.String()[2:]})
 
 	newSyncManagerV1(srcdb, srcNM, dummyExBuilder, srcLog)
-	dstMgr := NewSyncManager(dstdb, dstNM, dummyExBuilder, dstLog)
+	dstMgr := NewSyncManager(dstdb, dstNM, dummyExBuilder, nil, dstLog)
 
 	srcNM.join(dstNM)
 
@@ -492,7 +492,7 @@ func TestSyncSimpleStateSyncJoinAndLeave(t *testing.T) {
 	newLog := logger.WithFields(log.Fields{log.FieldKeyWallet: newNM.id.String()[2:]})
 
 	newSyncManagerV1(srcdb, srcNM, dummyExBuilder, srcLog)
-	dstMgr := NewSyncManager(dstdb, dstNM, dummyExBuilder, dstLog)
+	dstMgr := NewSyncManager(dstdb, dstNM, dummyExBuilder, nil, dstLog)
 
 	srcNM.join(dstNM)
 
@@ -569,7 +569,7 @@ func getRandomSyncManager(database db.Database, nm module.NetworkManager, logger
 	if rand.Intn(2) == 0 {
 		return newSyncManagerV1(database, nm, dummyExBuilder, logger)
 	} else {
-		return NewSyncManager(database, nm, dummyExBuilder, logger)
+		return NewSyncManager(database, nm, dummyExBuilder, nil, logger)
 	}
 }
 
@@ -754,8 +754,8 @@ func testReceiptSyncByRev(t *testing.T, rev module.Revision) {
 	nm2 := newTNetworkManager(createAPeerID())
 	log1 := logger.WithFields(log.Fields{log.FieldKeyWallet: nm.id.String()[2:]})
 	log2 := logger.WithFields(log.Fields{log.FieldKeyWallet: nm2.id.String()[2:]})
-	NewSyncManager(db1, nm, dummyExBuilder, log1)
-	syncm2 := NewSyncManager(db2, nm2, dummyExBuilder, log2)
+	NewSyncManager(db1, nm, dummyExBuilder, nil, log1)
+	syncm2 := NewSyncManager(db2, nm2, dummyExBuilder, nil, log2)
 
 	nm.join(nm2)
 
@@ -0,0 +1,473 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	TokenStandardIRC2 = "IRC2"
+	TokenStandardIRC3 = "IRC3"
+
+	irc2TransferSignature = "Transfer(Address,Address,int,bytes)"
+	irc3TransferSignature = "Transfer(Address,Address,int)"
+)
+
+// tokenIndexHeightKey is the key for the last height applied to balances.
+var tokenIndexHeightKey = []byte("height")
+
+// zeroAddress is the sender of minted tokens and the receiver of burned
+// ones by the conventions of IRC-2 and IRC-3.
+var zeroAddress = common.MustNewAddressFromString("hx0000000000000000000000000000000000000000")
+
+// TokenTransfer is the Transfer event of the token. Value is the amount
+// for IRC-2 tokens, and the token ID for IRC-3 tokens.
+type TokenTransfer struct {
+	Height   int64
+	TxIndex  int
+	TxHash   []byte
+	Token    *common.Address
+	Standard string
+	From     *common.Address
+	To       *common.Address
+	Value    *big.Int
+
+	seq int64
+}
+
+func (t *TokenTransfer) ToJSON() map[string]interface{} {
+	jso := map[string]interface{}{
+		"seq":      intconv.FormatInt(t.seq),
+		"height":   intconv.FormatInt(t.Height),
+		"txIndex":  intconv.FormatInt(int64(t.TxIndex)),
+		"txHash":   common.HexPre(t.TxHash),
+		"token":    t.Token,
+		"standard": t.Standard,
+		"from":     t.From,
+		"to":       t.To,
+	}
+	if t.Standard == TokenStandardIRC3 {
+		jso["tokenId"] = intconv.FormatBigInt(t.Value)
+	} else {
+		jso["value"] = intconv.FormatBigInt(t.Value)
+	}
+	return jso
+}
+
+// decodeTokenTransfer returns the transfer of the event log, or nil if it
+// isn't Transfer event of IRC-2 or IRC-3 tokens. Parameters may be either
+// indexed or not.
+func decodeTokenTransfer(ev module.EventLog) *TokenTransfer {
+	indexed := ev.Indexed()
+	if len(indexed) == 0 {
+		return nil
+	}
+	var standard string
+	var params int
+	switch string(indexed[0]) {
+	case irc2TransferSignature:
+		standard, params = TokenStandardIRC2, 4
+	case irc3TransferSignature:
+		standard, params = TokenStandardIRC3, 3
+	default:
+		return nil
+	}
+	values := append(append([][]byte{}, indexed[1:]...), ev.Data()...)
+	if len(values) != params {
+		return nil
+	}
+	from, err := common.NewAddress(values[0])
+	if err != nil {
+		return nil
+	}
+	to, err := common.NewAddress(values[1])
+	if err != nil {
+		return nil
+	}
+	return &TokenTransfer{
+		Token:    common.AddressToPtr(ev.Address()),
+		Standard: standard,
+		From:     from,
+		To:       to,
+		Value:    intconv.BigIntSetBytes(new(big.Int), values[2]),
+	}
+}
+
+// tokenBalance is the balance of the holder for the token. It's the number
+// of tokens owned for IRC-3 tokens.
+type tokenBalance struct {
+	Standard string
+	Balance  *big.Int
+}
+
+func tokenBalanceKey(token, holder []byte) []byte {
+	key := make([]byte, 0, len(token)+len(holder))
+	key = append(key, token...)
+	return append(key, holder...)
+}
+
+func getTokenBalance(bk db.Bucket, token, holder []byte) (*tokenBalance, error) {
+	bs, err := bk.Get(tokenBalanceKey(token, holder))
+	if err != nil || bs == nil {
+		return nil, err
+	}
+	b := new(tokenBalance)
+	if _, err := codec.BC.UnmarshalFromBytes(bs, b); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidTokenBalance")
+	}
+	return b, nil
+}
+
+// appendToList appends the value to the list for the key kept in the same
+// way as the address index.
+func appendToList(bk db.Bucket, counts map[string]int64, key []byte, value interface{}) error {
+	cnt, ok := counts[string(key)]
+	if !ok {
+		var err error
+		if cnt, err = getAddressIndexCount(bk, key); err != nil {
+			return err
+		}
+	}
+	bs, err := codec.BC.MarshalToBytes(value)
+	if err != nil {
+		return err
+	}
+	if err := bk.Set(addressIndexKey(key, cnt), bs); err != nil {
+		return err
+	}
+	counts[string(key)] = cnt + 1
+	return nil
+}
+
+func storeListCounts(bk db.Bucket, counts map[string]int64) error {
+	for key, cnt := range counts {
+		bs, err := codec.BC.MarshalToBytes(cnt)
+		if err != nil {
+			return err
+		}
+		if err := bk.Set(addressIndexCountKey([]byte(key)), bs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeTokenIndex applies the transfers of the height to balances and
+// appends them to histories of the token, the sender and the receiver.
+// Balances can't be reverted, so heights applied already (ex: the chain is
+// reset to the lower height) are ignored as they have the same transfers.
+func storeTokenIndex(dbase db.Database, height int64, transfers []*TokenTransfer) error {
+	bbk, err := dbase.GetBucket(db.TokenBalanceByHolder)
+	if err != nil {
+		return err
+	}
+	if bs, err := bbk.Get(tokenIndexHeightKey); err != nil {
+		return err
+	} else if bs != nil {
+		var last int64
+		if _, err := codec.BC.UnmarshalFromBytes(bs, &last); err != nil {
+			return errors.CriticalFormatError.Wrap(err, "InvalidTokenIndexHeight")
+		}
+		if height <= last {
+			return nil
+		}
+	}
+	hbk, err := dbase.GetBucket(db.TokensByHolder)
+	if err != nil {
+		return err
+	}
+	tbk, err := dbase.GetBucket(db.TokenTransfersByAddress)
+	if err != nil {
+		return err
+	}
+
+	balances := make(map[string]*tokenBalance)
+	holders := make(map[string]int64)
+	updateBalance := func(tf *TokenTransfer, holder *common.Address, amount *big.Int) error {
+		if holder.Equal(zeroAddress) {
+			return nil
+		}
+		key := tokenBalanceKey(tf.Token.Bytes(), holder.Bytes())
+		b, ok := balances[string(key)]
+		if !ok {
+			var err error
+			if b, err = getTokenBalance(bbk, tf.Token.Bytes(), holder.Bytes()); err != nil {
+				return err
+			}
+			if b == nil {
+				b = &tokenBalance{Standard: tf.Standard, Balance: new(big.Int)}
+				if err := appendToList(hbk, holders, holder.Bytes(), tf.Token); err != nil {
+					return err
+				}
+			}
+			balances[string(key)] = b
+		}
+		b.Balance.Add(b.Balance, amount)
+		return nil
+	}
+
+	histories := make(map[string]int64)
+	one := big.NewInt(1)
+	for _, tf := range transfers {
+		amount := tf.Value
+		if tf.Standard == TokenStandardIRC3 {
+			amount = one
+		}
+		if err := updateBalance(tf, tf.From, new(big.Int).Neg(amount)); err != nil {
+			return err
+		}
+		if err := updateBalance(tf, tf.To, amount); err != nil {
+			return err
+		}
+		added := make(map[string]bool)
+		for _, addr := range []*common.Address{tf.Token, tf.From, tf.To} {
+			if addr.Equal(zeroAddress) || added[string(addr.Bytes())] {
+				continue
+			}
+			added[string(addr.Bytes())] = true
+			if err := appendToList(tbk, histories, addr.Bytes(), tf); err != nil {
+				return err
+			}
+		}
+	}
+
+	for key, b := range balances {
+		bs, err := codec.BC.MarshalToBytes(b)
+		if err != nil {
+			return err
+		}
+		if err := bbk.Set([]byte(key), bs); err != nil {
+			return err
+		}
+	}
+	if err := storeListCounts(hbk, holders); err != nil {
+		return err
+	}
+	if err := storeListCounts(tbk, histories); err != nil {
+		return err
+	}
+	return bbk.Set(tokenIndexHeightKey, codec.BC.MustMarshalToBytes(height))
+}
+
+// TokenBalance is the balance of the holder for the token derived from
+// Transfer events indexed by the node.
+type TokenBalance struct {
+	Token    module.Address
+	Holder   module.Address
+	Standard string
+	Balance  *big.Int
+}
+
+func (b *TokenBalance) ToJSON() map[string]interface{} {
+	jso := map[string]interface{}{
+		"token":   b.Token,
+		"address": b.Holder,
+		"balance": intconv.FormatBigInt(b.Balance),
+	}
+	if b.Standard != "" {
+		jso["standard"] = b.Standard
+	}
+	return jso
+}
+
+// GetTokenBalance returns the balance of the holder for the token. Balance
+// is zero for the holder never received the token.
+func GetTokenBalance(dbase db.Database, token, holder module.Address) (*TokenBalance, error) {
+	bk, err := dbase.GetBucket(db.TokenBalanceByHolder)
+	if err != nil {
+		return nil, err
+	}
+	b, err := getTokenBalance(bk, token.Bytes(), holder.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	result := &TokenBalance{Token: token, Holder: holder, Balance: new(big.Int)}
+	if b != nil {
+		result.Standard = b.Standard
+		result.Balance = b.Balance
+	}
+	return result, nil
+}
+
+// TokenBalances is a page of balances of the holder in descending order of
+// the first receipt of tokens. Next is the cursor for the next page, or -1
+// if it's the last page.
+type TokenBalances struct {
+	Holder   module.Address
+	Total    int64
+	Balances []*TokenBalance
+	Next     int64
+}
+
+func (b *TokenBalances) ToJSON() map[string]interface{} {
+	balances := make([]interface{}, 0, len(b.Balances))
+	for _, tb := range b.Balances {
+		balances = append(balances, tb.ToJSON())
+	}
+	jso := map[string]interface{}{
+		"address":  b.Holder,
+		"total":    intconv.FormatInt(b.Total),
+		"balances": balances,
+	}
+	if b.Next >= 0 {
+		jso["next"] = intconv.FormatInt(b.Next)
+	}
+	return jso
+}
+
+// pageRange checks the cursor and the limit for the list having cnt items,
+// and returns the cursor and the limit to use.
+func pageRange(cursor int64, limit int, cnt int64) (int64, int, error) {
+	if limit <= 0 {
+		limit = AddressIndexDefaultLimit
+	}
+	if limit > AddressIndexMaxLimit {
+		return 0, 0, errors.IllegalArgumentError.Errorf(
+			"TooLargeLimit(limit=%d,max=%d)", limit, AddressIndexMaxLimit)
+	}
+	if cursor >= cnt {
+		return 0, 0, errors.IllegalArgumentError.Errorf(
+			"InvalidCursor(cursor=%d,total=%d)", cursor, cnt)
+	}
+	if cursor < 0 {
+		cursor = cnt - 1
+	}
+	return cursor, limit, nil
+}
+
+// GetTokenBalances returns up to limit balances of the holder from the
+// cursor. Negative cursor means the latest token received by the holder.
+func GetTokenBalances(dbase db.Database, holder module.Address, cursor int64, limit int) (*TokenBalances, error) {
+	hbk, err := dbase.GetBucket(db.TokensByHolder)
+	if err != nil {
+		return nil, err
+	}
+	bbk, err := dbase.GetBucket(db.TokenBalanceByHolder)
+	if err != nil {
+		return nil, err
+	}
+	cnt, err := getAddressIndexCount(hbk, holder.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	cursor, limit, err = pageRange(cursor, limit, cnt)
+	if err != nil {
+		return nil, err
+	}
+	result := &TokenBalances{
+		Holder:   holder,
+		Total:    cnt,
+		Balances: make([]*TokenBalance, 0, limit),
+	}
+	seq := cursor
+	for ; seq >= 0 && len(result.Balances) < limit; seq-- {
+		bs, err := hbk.Get(addressIndexKey(holder.Bytes(), seq))
+		if err != nil {
+			return nil, err
+		}
+		token := new(common.Address)
+		if _, err := codec.BC.UnmarshalFromBytes(bs, token); err != nil {
+			return nil, errors.CriticalFormatError.Wrap(err, "InvalidTokenOfHolder")
+		}
+		b, err := getTokenBalance(bbk, token.Bytes(), holder.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			return nil, errors.NotFoundError.Errorf(
+				"TokenBalanceNotFound(token=%s,holder=%s)", token, holder)
+		}
+		result.Balances = append(result.Balances, &TokenBalance{
+			Token:    token,
+			Holder:   holder,
+			Standard: b.Standard,
+			Balance:  b.Balance,
+		})
+	}
+	result.Next = seq
+	return result, nil
+}
+
+// TokenTransfers is a page of transfers involving the address as the token,
+// the sender or the receiver in descending order. Next is the cursor for
+// the next page, or -1 if it's the last page.
+type TokenTransfers struct {
+	Address   module.Address
+	Total     int64
+	Transfers []*TokenTransfer
+	Next      int64
+}
+
+func (t *TokenTransfers) ToJSON() map[string]interface{} {
+	transfers := make([]interface{}, 0, len(t.Transfers))
+	for _, tf := range t.Transfers {
+		transfers = append(transfers, tf.ToJSON())
+	}
+	jso := map[string]interface{}{
+		"address":   t.Address,
+		"total":     intconv.FormatInt(t.Total),
+		"transfers": transfers,
+	}
+	if t.Next >= 0 {
+		jso["next"] = intconv.FormatInt(t.Next)
+	}
+	return jso
+}
+
+// GetTokenTransfers returns up to limit transfers involving the address
+// from the cursor to the old ones. Negative cursor means the latest.
+func GetTokenTransfers(dbase db.Database, addr module.Address, cursor int64, limit int) (*TokenTransfers, error) {
+	bk, err := dbase.GetBucket(db.TokenTransfersByAddress)
+	if err != nil {
+		return nil, err
+	}
+	cnt, err := getAddressIndexCount(bk, addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	cursor, limit, err = pageRange(cursor, limit, cnt)
+	if err != nil {
+		return nil, err
+	}
+	result := &TokenTransfers{
+		Address:   addr,
+		Total:     cnt,
+		Transfers: make([]*TokenTransfer, 0, limit),
+	}
+	seq := cursor
+	for ; seq >= 0 && len(result.Transfers) < limit; seq-- {
+		bs, err := bk.Get(addressIndexKey(addr.Bytes(), seq))
+		if err != nil {
+			return nil, err
+		}
+		tf := &TokenTransfer{seq: seq}
+		if _, err := codec.BC.UnmarshalFromBytes(bs, tf); err != nil {
+			return nil, errors.CriticalFormatError.Wrap(err, "InvalidTokenTransfer")
+		}
+		result.Transfers = append(result.Transfers, tf)
+	}
+	result.Next = seq
+	return result, nil
+}
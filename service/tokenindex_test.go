@@ -0,0 +1,169 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+type testEventLog struct {
+	addr    module.Address
+	indexed [][]byte
+	data    [][]byte
+}
+
+func (e *testEventLog) Address() module.Address {
+	return e.addr
+}
+
+func (e *testEventLog) Indexed() [][]byte {
+	return e.indexed
+}
+
+func (e *testEventLog) Data() [][]byte {
+	return e.data
+}
+
+func TestDecodeTokenTransfer(t *testing.T) {
+	token := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	from := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	to := common.MustNewAddressFromString("hx0000000000000000000000000000000000000003")
+	value := intconv.BigIntToBytes(big.NewInt(100))
+
+	tf := decodeTokenTransfer(&testEventLog{
+		addr:    token,
+		indexed: [][]byte{[]byte(irc2TransferSignature), from.Bytes(), to.Bytes(), value},
+		data:    [][]byte{nil},
+	})
+	if assert.NotNil(t, tf) {
+		assert.Equal(t, TokenStandardIRC2, tf.Standard)
+		assert.True(t, tf.Token.Equal(token))
+		assert.True(t, tf.From.Equal(from))
+		assert.True(t, tf.To.Equal(to))
+		assert.EqualValues(t, 100, tf.Value.Int64())
+	}
+
+	tf = decodeTokenTransfer(&testEventLog{
+		addr:    token,
+		indexed: [][]byte{[]byte(irc3TransferSignature), from.Bytes()},
+		data:    [][]byte{to.Bytes(), value},
+	})
+	if assert.NotNil(t, tf) {
+		assert.Equal(t, TokenStandardIRC3, tf.Standard)
+		assert.EqualValues(t, 100, tf.Value.Int64())
+	}
+
+	// other events and malformed ones
+	assert.Nil(t, decodeTokenTransfer(&testEventLog{
+		addr:    token,
+		indexed: [][]byte{[]byte("Transfer(Address,int)"), from.Bytes(), value},
+	}))
+	assert.Nil(t, decodeTokenTransfer(&testEventLog{
+		addr:    token,
+		indexed: [][]byte{[]byte(irc3TransferSignature), from.Bytes(), to.Bytes()},
+	}))
+	assert.Nil(t, decodeTokenTransfer(&testEventLog{
+		addr:    token,
+		indexed: [][]byte{[]byte(irc3TransferSignature), from.Bytes(), nil, value},
+	}))
+}
+
+func TestTokenIndex(t *testing.T) {
+	dbase := db.NewMapDB()
+	token := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	nft := common.MustNewAddressFromString("cx0000000000000000000000000000000000000002")
+	addr1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000003")
+	addr2 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000004")
+
+	transfer := func(tk *common.Address, standard string, from, to *common.Address, v int64) *TokenTransfer {
+		return &TokenTransfer{
+			Token:    tk,
+			Standard: standard,
+			From:     from,
+			To:       to,
+			Value:    big.NewInt(v),
+		}
+	}
+	assert.NoError(t, storeTokenIndex(dbase, 1, []*TokenTransfer{
+		transfer(token, TokenStandardIRC2, zeroAddress, addr1, 1000),
+		transfer(nft, TokenStandardIRC3, zeroAddress, addr1, 7),
+	}))
+	h2 := []*TokenTransfer{
+		transfer(token, TokenStandardIRC2, addr1, addr2, 300),
+		transfer(nft, TokenStandardIRC3, addr1, addr2, 7),
+		transfer(nft, TokenStandardIRC3, zeroAddress, addr2, 8),
+	}
+	assert.NoError(t, storeTokenIndex(dbase, 2, h2))
+
+	// heights applied already are ignored
+	assert.NoError(t, storeTokenIndex(dbase, 2, h2))
+
+	b, err := GetTokenBalance(dbase, token, addr1)
+	assert.NoError(t, err)
+	assert.Equal(t, TokenStandardIRC2, b.Standard)
+	assert.EqualValues(t, 700, b.Balance.Int64())
+
+	b, err = GetTokenBalance(dbase, nft, addr2)
+	assert.NoError(t, err)
+	assert.Equal(t, TokenStandardIRC3, b.Standard)
+	assert.EqualValues(t, 2, b.Balance.Int64())
+
+	b, err = GetTokenBalance(dbase, token, zeroAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, "", b.Standard)
+	assert.EqualValues(t, 0, b.Balance.Int64())
+
+	bs, err := GetTokenBalances(dbase, addr1, -1, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, bs.Total)
+	if assert.Len(t, bs.Balances, 2) {
+		assert.True(t, bs.Balances[0].Token.Equal(nft))
+		assert.EqualValues(t, 0, bs.Balances[0].Balance.Int64())
+		assert.True(t, bs.Balances[1].Token.Equal(token))
+	}
+	assert.EqualValues(t, -1, bs.Next)
+
+	tfs, err := GetTokenTransfers(dbase, nft, -1, 2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, tfs.Total)
+	if assert.Len(t, tfs.Transfers, 2) {
+		assert.EqualValues(t, 8, tfs.Transfers[0].Value.Int64())
+		assert.Contains(t, tfs.Transfers[0].ToJSON(), "tokenId")
+	}
+	assert.EqualValues(t, 0, tfs.Next)
+
+	tfs, err = GetTokenTransfers(dbase, addr2, -1, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, tfs.Total)
+
+	tfs, err = GetTokenTransfers(dbase, zeroAddress, -1, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, tfs.Total)
+
+	_, err = GetTokenTransfers(dbase, addr1, 4, 0)
+	assert.Error(t, err)
+	_, err = GetTokenBalances(dbase, addr1, -1, AddressIndexMaxLimit+1)
+	assert.Error(t, err)
+}
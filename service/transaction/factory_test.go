@@ -18,6 +18,8 @@ package transaction
 
 import (
 	"testing"
+
+	"github.com/icon-project/goloop/module"
 )
 
 func FuzzNewTransaction(f *testing.F) {
@@ -28,3 +30,15 @@ func FuzzNewTransaction(f *testing.F) {
 		}
 	})
 }
+
+func FuzzNewTransactionFromJSON(f *testing.F) {
+	f.Add([]byte(`{"version":"0x3","from":"hx8888888888888888888888888888888888888888","to":"hx1000000000000000000000000000000000000000","value":"0x1","stepLimit":"0x100000","timestamp":"0x5c5b5f3f4f5c0","nid":"0x1","nonce":"0x1","signature":""}`))
+	f.Add([]byte(`{"from":"hx8888888888888888888888888888888888888888","to":"hx1000000000000000000000000000000000000000","value":"0x1","fee":"0x2386f26fc10000","timestamp":"1517368136082000","tx_hash":""}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tx, err := NewTransactionFromJSON(data)
+		if err == nil {
+			tx.Verify()
+			tx.ToJSON(module.JSONVersionLast)
+		}
+	})
+}
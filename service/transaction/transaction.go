@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"reflect"
 
+	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/merkle"
 	"github.com/icon-project/goloop/common/trie"
@@ -139,6 +140,65 @@ func Wrap(t Transaction) Transaction {
 	}
 }
 
+type expirable interface {
+	Expiration() int64
+}
+
+// ExpirationOf returns the expiration of the transaction in microseconds.
+// It returns zero if the transaction doesn't have one.
+func ExpirationOf(t module.Transaction) int64 {
+	if e, ok := Unwrap(t).(expirable); ok {
+		return e.Expiration()
+	}
+	return 0
+}
+
+type signed interface {
+	Signer() (module.Address, error)
+}
+
+// SignerOf returns the address recovered from the signature of the
+// transaction. It returns nil if the transaction doesn't have one.
+func SignerOf(t module.Transaction) (module.Address, error) {
+	if s, ok := Unwrap(t).(signed); ok {
+		return s.Signer()
+	}
+	return nil, nil
+}
+
+type stepLimited interface {
+	MinimumStep(wc state.WorldContext) (*big.Int, error)
+}
+
+// MinimumStepOf returns the step limit required for the transaction at
+// least on the world context. It returns nil if the transaction doesn't
+// use steps.
+func MinimumStepOf(wc state.WorldContext, t module.Transaction) (*big.Int, error) {
+	if s, ok := Unwrap(t).(stepLimited); ok {
+		return s.MinimumStep(wc)
+	}
+	return nil, nil
+}
+
+type stepLimitDeclared interface {
+	DeclaredStepLimit() *big.Int
+}
+
+// StepLimitOf returns the step limit declared by the transaction. It returns
+// nil if the transaction doesn't declare one.
+func StepLimitOf(t module.Transaction) *big.Int {
+	if s, ok := Unwrap(t).(stepLimitDeclared); ok {
+		return s.DeclaredStepLimit()
+	}
+	return nil
+}
+
+// BundleHash returns the hash of the bundle of the transactions to be signed
+// by the sequencer. ids are IDs of the transactions in order.
+func BundleHash(ids [][]byte) []byte {
+	return crypto.SHA3Sum256(bytes.Join(ids, nil))
+}
+
 func Unwrap(t module.Transaction) module.Transaction {
 	if tp, ok := t.(*transaction); ok {
 		return tp.Transaction
@@ -9,6 +9,7 @@ import (
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/service/contract"
 	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/sigverify"
 	"github.com/icon-project/goloop/service/state"
 	"github.com/icon-project/goloop/service/txresult"
 
@@ -46,12 +47,23 @@ func (tx *transactionV2) ID() []byte {
 	return tx.txHash
 }
 
+// Signer returns the address recovered from the signature.
+func (tx *transactionV2) Signer() (module.Address, error) {
+	if err := tx.updateTxHash(); err != nil {
+		return nil, err
+	}
+	pk, err := sigverify.RecoverPublicKey(tx.Signature, tx.txHash)
+	if err != nil {
+		return nil, InvalidSignatureError.Wrap(err, "fail to recover public key")
+	}
+	return common.NewAccountAddressFromPublicKey(pk), nil
+}
+
 func (tx *transactionV2) verifySignature() error {
-	pk, err := tx.Signature.RecoverPublicKey(tx.txHash)
+	addr, err := tx.Signer()
 	if err != nil {
-		return InvalidSignatureError.Wrap(err, "fail to recover public key")
+		return err
 	}
-	addr := common.NewAccountAddressFromPublicKey(pk)
 	if addr.Equal(&tx.transactionJSON.From) {
 		return nil
 	}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transaction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+)
+
+const testTxV2JSON = `{
+	"from": "hx0000000000000000000000000000000000000001",
+	"to": "hx0000000000000000000000000000000000000002",
+	"value": "0x1",
+	"fee": "0x2386f26fc10000",
+	"timestamp": "1578638866067000",
+	"nonce": "0x1"
+}`
+
+func TestTransactionV2_Signer(t *testing.T) {
+	tx, err := parseV2([]byte(testTxV2JSON), false)
+	assert.NoError(t, err)
+	_, err = SignerOf(tx)
+	assert.True(t, InvalidSignatureError.Equals(err))
+
+	sk, pk := crypto.GenerateKeyPair()
+	sig, err := crypto.NewSignature(tx.ID(), sk)
+	assert.NoError(t, err)
+
+	var jso map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(testTxV2JSON), &jso))
+	jso["signature"] = common.Signature{Signature: sig}
+	js, err := json.Marshal(jso)
+	assert.NoError(t, err)
+
+	// the hash is not calculated yet on a freshly parsed transaction.
+	tx, err = parseV2(js, false)
+	assert.NoError(t, err)
+	signer, err := SignerOf(Wrap(tx))
+	assert.NoError(t, err)
+	assert.True(t, common.NewAccountAddressFromPublicKey(pk).Equal(signer))
+}
@@ -3,6 +3,7 @@ package transaction
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"math/big"
 
 	"github.com/icon-project/goloop/common"
@@ -12,6 +13,7 @@ import (
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/contract"
 	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/sigverify"
 	"github.com/icon-project/goloop/service/state"
 )
 
@@ -32,6 +34,70 @@ type transactionV3Data struct {
 	Signature common.Signature `json:"signature"`
 	DataType  *string          `json:"dataType,omitempty"`
 	Data      json.RawMessage  `json:"data,omitempty"`
+
+	AccessList state.AccessList `json:"accessList,omitempty"`
+	Expiration *common.HexInt64 `json:"expiration,omitempty"`
+}
+
+// RLPEncodeSelf encodes the transaction. AccessList and Expiration are
+// appended only if they exist, to keep binary form of the transactions
+// without them.
+func (tx *transactionV3Data) RLPEncodeSelf(e codec.Encoder) error {
+	e2, err := e.EncodeList()
+	if err != nil {
+		return err
+	}
+	if err := e2.EncodeMulti(
+		&tx.Version,
+		&tx.From,
+		&tx.To,
+		tx.Value,
+		&tx.StepLimit,
+		&tx.TimeStamp,
+		tx.NID,
+		tx.Nonce,
+		&tx.Signature,
+		tx.DataType,
+		[]byte(tx.Data),
+	); err != nil {
+		return err
+	}
+	if tx.AccessList != nil || tx.Expiration != nil {
+		if err := e2.Encode(tx.AccessList); err != nil {
+			return err
+		}
+	}
+	if tx.Expiration != nil {
+		return e2.Encode(tx.Expiration)
+	}
+	return nil
+}
+
+func (tx *transactionV3Data) RLPDecodeSelf(d codec.Decoder) error {
+	d2, err := d.DecodeList()
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if _, err := d2.DecodeMulti(
+		&tx.Version,
+		&tx.From,
+		&tx.To,
+		&tx.Value,
+		&tx.StepLimit,
+		&tx.TimeStamp,
+		&tx.NID,
+		&tx.Nonce,
+		&tx.Signature,
+		&tx.DataType,
+		&data,
+		&tx.AccessList,
+		&tx.Expiration,
+	); err != nil && err != io.EOF {
+		return err
+	}
+	tx.Data = data
+	return nil
 }
 
 func (tx *transactionV3Data) calcHash() ([]byte, error) {
@@ -39,6 +105,16 @@ func (tx *transactionV3Data) calcHash() ([]byte, error) {
 	sha := bytes.NewBuffer(nil)
 	sha.Write([]byte("icx_sendTransaction"))
 
+	// accessList
+	if tx.AccessList != nil {
+		sha.Write([]byte(".accessList."))
+		if bs, err := serializeAccessList(tx.AccessList); err != nil {
+			return nil, err
+		} else {
+			sha.Write(bs)
+		}
+	}
+
 	// data
 	if tx.Data != nil {
 		sha.Write([]byte(".data."))
@@ -61,6 +137,12 @@ func (tx *transactionV3Data) calcHash() ([]byte, error) {
 		sha.Write([]byte(*tx.DataType))
 	}
 
+	// expiration
+	if tx.Expiration != nil {
+		sha.Write([]byte(".expiration."))
+		sha.Write([]byte(tx.Expiration.String()))
+	}
+
 	// from
 	sha.Write([]byte(".from."))
 	sha.Write([]byte(tx.From.String()))
@@ -102,6 +184,18 @@ func (tx *transactionV3Data) calcHash() ([]byte, error) {
 	return crypto.SHA3Sum256(sha.Bytes()), nil
 }
 
+func serializeAccessList(l state.AccessList) ([]byte, error) {
+	js, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	var obj interface{}
+	if err := json.Unmarshal(js, &obj); err != nil {
+		return nil, err
+	}
+	return SerializeValue(obj)
+}
+
 type transactionV3 struct {
 	transactionV3Data
 	txHash []byte
@@ -113,12 +207,27 @@ func (tx *transactionV3) Timestamp() int64 {
 	return tx.TimeStamp.Value
 }
 
+func (tx *transactionV3) Expiration() int64 {
+	if tx.transactionV3Data.Expiration != nil {
+		return tx.transactionV3Data.Expiration.Value
+	}
+	return 0
+}
+
+// Signer returns the address recovered from the signature.
+func (tx *transactionV3) Signer() (module.Address, error) {
+	pk, err := sigverify.RecoverPublicKey(tx.Signature, tx.TxHash())
+	if err != nil {
+		return nil, InvalidSignatureError.Wrap(err, "fail to recover public key")
+	}
+	return common.NewAccountAddressFromPublicKey(pk), nil
+}
+
 func (tx *transactionV3) verifySignature() error {
-	pk, err := tx.Signature.RecoverPublicKey(tx.TxHash())
+	addr, err := tx.Signer()
 	if err != nil {
-		return InvalidSignatureError.Wrap(err, "fail to recover public key")
+		return err
 	}
-	addr := common.NewAccountAddressFromPublicKey(pk)
 	if addr.Equal(tx.From()) {
 		return nil
 	}
@@ -164,6 +273,15 @@ func (tx *transactionV3) Verify() error {
 	if tx.StepLimit.Sign() < 0 {
 		return InvalidTxValue.Errorf("InvalidTxStepLimit(%s)", tx.StepLimit.String())
 	}
+	if exp := tx.transactionV3Data.Expiration; exp != nil && exp.Value <= tx.TimeStamp.Value {
+		return InvalidTxValue.Errorf("InvalidTxExpiration(%s)", exp.String())
+	}
+
+	if tx.AccessList != nil {
+		if err := tx.AccessList.Verify(); err != nil {
+			return InvalidTxValue.Wrap(err, "InvalidAccessList")
+		}
+	}
 
 	// character level size of data element <= 512KB
 	n, err := countBytesOfCompactJSON(tx.Data)
@@ -210,6 +328,13 @@ func (tx *transactionV3) Verify() error {
 			// if _, err := contract.ParseDepositData(tx.Data); err != nil {
 			// 	return InvalidTxValue.Wrap(err, "TxData is invalid")
 			// }
+		case contract.DataTypeEVM:
+			if tx.Data == nil {
+				return InvalidTxValue.New("TxData for evm is NIL")
+			}
+			if _, err := contract.ParseEVMData(tx.Data); err != nil {
+				return InvalidTxValue.Wrap(err, "TxData is invalid")
+			}
 		}
 	}
 
@@ -229,16 +354,20 @@ func (tx *transactionV3) ValidateNetwork(nid int) bool {
 }
 
 func (tx *transactionV3) PreValidate(wc state.WorldContext, update bool) error {
-	if tx.DataType == nil || *tx.DataType != contract.DataTypePatch {
-		// stepLimit >= default step + input steps
-		cnt, err := MeasureBytesOfData(wc.Revision(), tx.Data)
-		if err != nil {
-			return err
-		}
-		minStep := big.NewInt(wc.StepsFor(state.StepTypeDefault, 1) + wc.StepsFor(state.StepTypeInput, cnt))
-		if tx.StepLimit.Cmp(minStep) < 0 {
-			return NotEnoughStepError.Errorf("NotEnoughStep(txStepLimit:%s, minStep:%s)", &tx.StepLimit.Int, minStep)
-		}
+	if tx.AccessList != nil && !wc.Revision().Has(module.ColdAccessCharge) {
+		return InvalidTxValue.New("AccessListNotAllowed")
+	}
+	if tx.transactionV3Data.Expiration != nil && !wc.Revision().Has(module.TxExpiration) {
+		return InvalidTxValue.New("ExpirationNotAllowed")
+	}
+	if tx.DataType != nil && *tx.DataType == contract.DataTypeEVM && !wc.EVMEnabled() {
+		return InvalidTxValue.New("EVMNotEnabled")
+	}
+	// stepLimit >= default step + input steps
+	if minStep, err := tx.MinimumStep(wc); err != nil {
+		return err
+	} else if minStep != nil && tx.StepLimit.Cmp(minStep) < 0 {
+		return NotEnoughStepError.Errorf("NotEnoughStep(txStepLimit:%s, minStep:%s)", &tx.StepLimit.Int, minStep)
 	}
 
 	// balance >= (fee + value)
@@ -277,6 +406,21 @@ func (tx *transactionV3) PreValidate(wc state.WorldContext, update bool) error {
 	return nil
 }
 
+// MinimumStep returns the step limit required for the transaction at least.
+// It returns nil for patch transactions, which don't use steps.
+func (tx *transactionV3) MinimumStep(wc state.WorldContext) (*big.Int, error) {
+	if tx.DataType != nil && *tx.DataType == contract.DataTypePatch {
+		return nil, nil
+	}
+	cnt, err := MeasureBytesOfData(wc.Revision(), tx.Data)
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(wc.StepsFor(state.StepTypeDefault, 1) +
+		wc.StepsFor(state.StepTypeInput, cnt) +
+		wc.StepsFor(state.StepTypeAccessList, tx.AccessList.Items())), nil
+}
+
 func (tx *transactionV3) GetHandler(cm contract.ContractManager) (Handler, error) {
 	var value *big.Int
 	if tx.Value != nil {
@@ -291,7 +435,8 @@ func (tx *transactionV3) GetHandler(cm contract.ContractManager) (Handler, error
 		value,
 		&tx.StepLimit.Int,
 		tx.DataType,
-		tx.Data)
+		tx.Data,
+		tx.AccessList)
 }
 
 func (tx *transactionV3) Group() module.TransactionGroup {
@@ -331,6 +476,15 @@ func (tx *transactionV3) Hash() []byte {
 	return crypto.SHA3Sum256(tx.Bytes())
 }
 
+// DeclaredStepLimit returns the step limit of the transaction. It returns
+// nil for patch transactions, which don't use steps.
+func (tx *transactionV3) DeclaredStepLimit() *big.Int {
+	if tx.DataType != nil && *tx.DataType == contract.DataTypePatch {
+		return nil
+	}
+	return &tx.StepLimit.Int
+}
+
 func (tx *transactionV3) Nonce() *big.Int {
 	if nonce := tx.transactionV3Data.Nonce; nonce != nil {
 		return &nonce.Int
@@ -374,6 +528,12 @@ func (tx *transactionV3) ToJSON(version module.JSONVersion) (interface{}, error)
 	if tx.transactionV3Data.Data != nil {
 		jso["data"] = json.RawMessage(tx.transactionV3Data.Data)
 	}
+	if tx.transactionV3Data.AccessList != nil {
+		jso["accessList"] = tx.transactionV3Data.AccessList
+	}
+	if tx.transactionV3Data.Expiration != nil {
+		jso["expiration"] = tx.transactionV3Data.Expiration
+	}
 	jso["txHash"] = common.HexBytes(tx.ID())
 
 	return jso, nil
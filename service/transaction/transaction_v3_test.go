@@ -0,0 +1,214 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transaction
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// legacyV3Data is transactionV3Data before AccessList is added.
+type legacyV3Data struct {
+	Version   common.HexUint16
+	From      common.Address
+	To        common.Address
+	Value     *common.HexInt
+	StepLimit common.HexInt
+	TimeStamp common.HexInt64
+	NID       *common.HexInt64
+	Nonce     *common.HexInt
+	Signature common.Signature
+	DataType  *string
+	Data      json.RawMessage
+}
+
+const testTxJSON = `{
+	"version": "0x3",
+	"from": "hx0000000000000000000000000000000000000001",
+	"to": "cx0000000000000000000000000000000000000002",
+	"stepLimit": "0x100000",
+	"timestamp": "0x5e1f0d7c8e7a0",
+	"nid": "0x1",
+	"dataType": "call",
+	"data": {"method": "transfer", "params": {"value": "0x1"}}
+}`
+
+func TestTransactionV3_BytesWithoutAccessList(t *testing.T) {
+	tx, err := parseV3JSON([]byte(testTxJSON), false)
+	assert.NoError(t, err)
+	tx3 := tx.(*transactionV3)
+	assert.Nil(t, tx3.AccessList)
+
+	d := &tx3.transactionV3Data
+	legacy := &legacyV3Data{
+		d.Version, d.From, d.To, d.Value, d.StepLimit, d.TimeStamp,
+		d.NID, d.Nonce, d.Signature, d.DataType, d.Data,
+	}
+	bs := tx.Bytes()
+	assert.Equal(t, codec.MustMarshalToBytes(legacy), bs)
+
+	tx2, err := parseV3Binary(bs)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.ID(), tx2.ID())
+	assert.Equal(t, bs, tx2.Bytes())
+}
+
+func TestTransactionV3_AccessList(t *testing.T) {
+	tx, err := parseV3JSON([]byte(testTxJSON), false)
+	assert.NoError(t, err)
+	id := tx.ID()
+
+	var jso map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(testTxJSON), &jso))
+	jso["accessList"] = []interface{}{
+		map[string]interface{}{
+			"address": "cx0000000000000000000000000000000000000002",
+			"keys":    []interface{}{"0x01", "0x0203"},
+		},
+		map[string]interface{}{
+			"address": "hx0000000000000000000000000000000000000003",
+		},
+	}
+	js, err := json.Marshal(jso)
+	assert.NoError(t, err)
+
+	txa, err := parseV3JSON(js, false)
+	assert.NoError(t, err)
+	txa3 := txa.(*transactionV3)
+	assert.False(t, txa3.raw)
+	assert.Equal(t, 4, txa3.AccessList.Items())
+	assert.NotEqual(t, id, txa.ID())
+	assert.NoError(t, txa3.AccessList.Verify())
+
+	txb, err := parseV3Binary(txa.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, txa.ID(), txb.ID())
+	assert.Equal(t, txa3.AccessList, txb.(*transactionV3).AccessList)
+
+	txa3.AccessList = append(txa3.AccessList, state.AccessListEntry{
+		Address: *common.MustNewAddressFromString("hx0000000000000000000000000000000000000003"),
+	})
+	assert.Error(t, txa3.AccessList.Verify())
+}
+
+func TestTransactionV3_VerifyAccessList(t *testing.T) {
+	sk, pk := crypto.GenerateKeyPair()
+	from := common.NewAccountAddressFromPublicKey(pk)
+	signed := func(t *testing.T, list []interface{}) Transaction {
+		var jso map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(testTxJSON), &jso))
+		jso["from"] = from.String()
+		jso["accessList"] = list
+		js, err := json.Marshal(jso)
+		assert.NoError(t, err)
+		tx, err := parseV3JSON(js, false)
+		assert.NoError(t, err)
+		tx3 := tx.(*transactionV3)
+		sig, err := crypto.NewSignature(tx3.TxHash(), sk)
+		assert.NoError(t, err)
+		tx3.Signature.Signature = sig
+		return tx
+	}
+	contract := "cx0000000000000000000000000000000000000002"
+	eoa := "hx0000000000000000000000000000000000000003"
+
+	tx := signed(t, []interface{}{
+		map[string]interface{}{"address": contract, "keys": []interface{}{"0x01"}},
+		map[string]interface{}{"address": eoa},
+	})
+	assert.NoError(t, tx.Verify())
+
+	tx = signed(t, []interface{}{
+		map[string]interface{}{"address": eoa},
+		map[string]interface{}{"address": eoa},
+	})
+	assert.True(t, InvalidTxValue.Equals(tx.Verify()))
+
+	tx = signed(t, []interface{}{
+		map[string]interface{}{"address": eoa, "keys": []interface{}{"0x01"}},
+	})
+	assert.True(t, InvalidTxValue.Equals(tx.Verify()))
+
+	keys := make([]interface{}, state.MaxAccessListItems)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("0x%04x", i)
+	}
+	tx = signed(t, []interface{}{
+		map[string]interface{}{"address": contract, "keys": keys},
+	})
+	assert.True(t, InvalidTxValue.Equals(tx.Verify()))
+}
+
+func TestTransactionV3_Expiration(t *testing.T) {
+	tx, err := parseV3JSON([]byte(testTxJSON), false)
+	assert.NoError(t, err)
+	id := tx.ID()
+	assert.EqualValues(t, 0, ExpirationOf(tx))
+
+	var jso map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(testTxJSON), &jso))
+	jso["expiration"] = "0x5e1f0d7c8e7a1"
+	js, err := json.Marshal(jso)
+	assert.NoError(t, err)
+
+	txe, err := parseV3JSON(js, false)
+	assert.NoError(t, err)
+	txe3 := txe.(*transactionV3)
+	assert.False(t, txe3.raw)
+	assert.Nil(t, txe3.AccessList)
+	assert.EqualValues(t, 0x5e1f0d7c8e7a1, ExpirationOf(Wrap(txe)))
+	assert.NotEqual(t, id, txe.ID())
+
+	txb, err := parseV3Binary(txe.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, txe.ID(), txb.ID())
+	assert.Nil(t, txb.(*transactionV3).AccessList)
+	assert.Equal(t, txe3.Expiration(), txb.(*transactionV3).Expiration())
+
+	jso["expiration"] = jso["timestamp"]
+	js, err = json.Marshal(jso)
+	assert.NoError(t, err)
+	txi, err := parseV3JSON(js, false)
+	assert.NoError(t, err)
+	assert.True(t, InvalidTxValue.Equals(txi.Verify()))
+}
+
+func TestTransactionV3_Signer(t *testing.T) {
+	tx, err := parseV3JSON([]byte(testTxJSON), false)
+	assert.NoError(t, err)
+	_, err = SignerOf(tx)
+	assert.True(t, InvalidSignatureError.Equals(err))
+
+	sk, pk := crypto.GenerateKeyPair()
+	tx3 := tx.(*transactionV3)
+	sig, err := crypto.NewSignature(tx3.TxHash(), sk)
+	assert.NoError(t, err)
+	tx3.Signature.Signature = sig
+
+	signer, err := SignerOf(Wrap(tx))
+	assert.NoError(t, err)
+	assert.True(t, common.NewAccountAddressFromPublicKey(pk).Equal(signer))
+	assert.True(t, InvalidSignatureError.Equals(tx3.verifySignature()))
+}
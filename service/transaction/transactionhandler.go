@@ -14,6 +14,10 @@ import (
 	"github.com/icon-project/goloop/service/txresult"
 )
 
+// TimeoutRetryCount is the number of retries for the transaction
+// which meets timeout in the execution.
+const TimeoutRetryCount = 1
+
 type Handler interface {
 	Prepare(ctx contract.Context) (state.WorldContext, error)
 	// Execute executes transaction in the Handler.
@@ -32,21 +36,24 @@ type transactionHandler struct {
 	dataType  *string
 	data      []byte
 
+	accessList state.AccessList
+
 	chandler contract.ContractHandler
 
 	// Assigned at Execute()
 	cc contract.CallContext
 }
 
-func NewHandler(cm contract.ContractManager, group module.TransactionGroup, from, to module.Address, value, stepLimit *big.Int, dataType *string, data []byte) (Handler, error) {
+func NewHandler(cm contract.ContractManager, group module.TransactionGroup, from, to module.Address, value, stepLimit *big.Int, dataType *string, data []byte, accessList state.AccessList) (Handler, error) {
 	th := &transactionHandler{
-		group:     group,
-		from:      from,
-		to:        to,
-		value:     value,
-		stepLimit: stepLimit,
-		dataType:  dataType,
-		data:      data,
+		group:      group,
+		from:       from,
+		to:         to,
+		value:      value,
+		stepLimit:  stepLimit,
+		dataType:   dataType,
+		data:       data,
+		accessList: accessList,
 	}
 	ctype := contract.CTypeNone // invalid contract type
 	if dataType == nil {
@@ -63,6 +70,8 @@ func NewHandler(cm contract.ContractManager, group module.TransactionGroup, from
 			ctype = contract.CTypePatch
 		case contract.DataTypeDeposit:
 			ctype = contract.CTypeDeposit
+		case contract.DataTypeEVM:
+			ctype = contract.CTypeEVM
 		default:
 			return nil, InvalidFormat.Errorf("IllegalDataType(type=%s)", *dataType)
 		}
@@ -144,22 +153,54 @@ func (th *transactionHandler) DoExecute(cc contract.CallContext, estimate, isPat
 			return err, nil, nil
 		}
 	}
+	if cc.Revision().Has(module.ColdAccessCharge) {
+		if !th.applyAccessList(cc) {
+			return scoreresult.ErrOutOfStep, nil, nil
+		}
+	}
 
 	// Execute
 	status, used, _, addr := cc.Call(th.chandler, cc.StepAvailable())
 	cc.DeductSteps(used)
 
 	// If it fails for system failure, then it needs to re-run this.
-	if code := errors.CodeOf(status); code == errors.ExecutionFailError ||
-		errors.IsCriticalCode(code) {
+	if errors.IsCode(status, errors.ExecutionFailError) ||
+		errors.IsCriticalCode(errors.CodeOf(status)) {
 		return nil, nil, status
-	} else if code == scoreresult.TimeoutError {
-		// it consumes all steps if it meets timeout.
+	} else if errors.IsCode(status, scoreresult.TimeoutError) {
+		// The executor is killed on timeout, so it retries with another
+		// executor. If it still meets timeout, then it consumes all steps
+		// to make the same result regardless of the time.
+		if txInfo := cc.TransactionInfo(); !estimate && txInfo != nil && txInfo.Retry < TimeoutRetryCount {
+			return nil, nil, errors.ExecutionFailError.Wrapf(status,
+				"ExecutionTimeout(retry=%d)", txInfo.Retry)
+		}
 		cc.DeductSteps(cc.StepAvailable())
 	}
 	return status, addr, nil
 }
 
+// applyAccessList charges steps for the access list, then it marks the
+// sender, the receiver and the entries of the list as accessed.
+func (th *transactionHandler) applyAccessList(cc contract.CallContext) bool {
+	cc.WarmUp(th.from)
+	cc.WarmUp(th.to)
+	if items := th.accessList.Items(); items > 0 {
+		if !cc.ApplySteps(state.StepTypeAccessList, items) {
+			return false
+		}
+		for i := range th.accessList {
+			e := &th.accessList[i]
+			keys := make([][]byte, len(e.Keys))
+			for j, k := range e.Keys {
+				keys[j] = k
+			}
+			cc.WarmUp(&e.Address, keys...)
+		}
+	}
+	return true
+}
+
 func (th *transactionHandler) Execute(ctx contract.Context, wcs state.WorldSnapshot, estimate bool) (txresult.Receipt, error) {
 	isPatch := th.group == module.TransactionGroupPatch
 	limit := th.stepLimit
@@ -276,11 +317,28 @@ func (th *transactionHandler) Execute(ctx contract.Context, wcs state.WorldSnaps
 	}
 	receipt.SetResult(s, stepUsed, stepPrice, addr)
 	receipt.SetReason(status)
+	setRevertReason(receipt, cc.Revision(), status)
+	if calls := cc.InternalCalls(); len(calls) > 0 {
+		receipt.SetInternalCalls(calls)
+	}
 
 	logger.TSystemf("TRANSACTION done status=%s steps=%s price=%s", s, stepUsed, stepPrice)
 	return receipt, nil
 }
 
+// setRevertReason sets the message of the user defined failure to the
+// receipt if the revision has RevertReasonInReceipt. Messages of user defined
+// failures are given by the contract, including Python and Java SCOREs
+// reporting them through the executor, so they are same for all nodes.
+func setRevertReason(receipt txresult.Receipt, rev module.Revision, status error) {
+	if !rev.Has(module.RevertReasonInReceipt) {
+		return
+	}
+	if s, _ := scoreresult.StatusOf(status); s >= module.StatusReverted {
+		receipt.SetRevertReason(status.Error())
+	}
+}
+
 func (th *transactionHandler) Dispose() {
 	// Actually it is called after calling Execute(), so cc can't be nil.
 	if th.cc != nil {
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transaction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/icon/icmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+type testCallContext struct {
+	contract.CallContext
+	txInfo    *state.TransactionInfo
+	status    error
+	used      *big.Int
+	available *big.Int
+}
+
+func (cc *testCallContext) Revision() module.Revision {
+	return module.NoRevision
+}
+
+func (cc *testCallContext) ApplySteps(t state.StepType, n int) bool {
+	return true
+}
+
+func (cc *testCallContext) StepAvailable() *big.Int {
+	return new(big.Int).Set(cc.available)
+}
+
+func (cc *testCallContext) DeductSteps(s *big.Int) bool {
+	cc.available.Sub(cc.available, s)
+	return true
+}
+
+func (cc *testCallContext) Call(handler contract.ContractHandler, limit *big.Int) (error, *big.Int, *codec.TypedObj, module.Address) {
+	return cc.status, cc.used, nil, nil
+}
+
+func (cc *testCallContext) TransactionInfo() *state.TransactionInfo {
+	return cc.txInfo
+}
+
+func TestTransactionHandler_DoExecuteTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    error
+		retry     int
+		noTxInfo  bool
+		estimate  bool
+		err       errors.Code
+		code      errors.Code
+		available int64
+	}{
+		{"Retry", scoreresult.ErrTimeout, 0, false, false,
+			errors.ExecutionFailError, errors.Success, 900},
+		{"RetriedEnough", scoreresult.ErrTimeout, TimeoutRetryCount, false, false,
+			errors.Success, scoreresult.TimeoutError, 0},
+		{"Estimate", scoreresult.ErrTimeout, 0, false, true,
+			errors.Success, scoreresult.TimeoutError, 0},
+		{"NoTxInfo", scoreresult.ErrTimeout, 0, true, false,
+			errors.Success, scoreresult.TimeoutError, 0},
+		{"ExecutionFail", errors.ExecutionFailError.New("ProxyIsClosed"), 0, false, false,
+			errors.ExecutionFailError, errors.Success, 900},
+		{"Failure", scoreresult.ErrUnknownFailure, 0, false, false,
+			errors.Success, scoreresult.UnknownFailureError, 900},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := &testCallContext{
+				status:    tt.status,
+				used:      big.NewInt(100),
+				available: big.NewInt(1000),
+			}
+			if !tt.noTxInfo {
+				cc.txInfo = &state.TransactionInfo{Retry: tt.retry}
+			}
+			th := &transactionHandler{}
+			status, _, err := th.DoExecute(cc, tt.estimate, true)
+			if tt.err == errors.Success {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.err.Equals(err))
+				assert.True(t, errors.Is(err, tt.status))
+			}
+			if tt.code == errors.Success {
+				assert.NoError(t, status)
+			} else {
+				assert.True(t, tt.code.Equals(status))
+			}
+			assert.EqualValues(t, tt.available, cc.available.Int64())
+		})
+	}
+}
+
+func TestSetRevertReason(t *testing.T) {
+	to := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	rev := icmodule.ValueToRevision(icmodule.RevisionBTP2)
+	// the executor reports reverts of Python and Java SCOREs with the
+	// status and the message
+	reverted := errors.NewRemote(errors.Code(module.StatusReverted+1), "NotEnoughToken")
+	tests := []struct {
+		name   string
+		rev    module.Revision
+		status error
+		reason string
+	}{
+		{"Reverted", rev, reverted, "NotEnoughToken"},
+		{"RevertedBefore", icmodule.ValueToRevision(icmodule.RevisionBTP2 - 1), reverted, ""},
+		{"SystemFailure", rev, scoreresult.ErrOutOfStep, ""},
+		{"Success", rev, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := txresult.NewReceipt(db.NewMapDB(), tt.rev, to)
+			s, _ := scoreresult.StatusOf(tt.status)
+			receipt.SetResult(s, big.NewInt(100), big.NewInt(10), nil)
+			setRevertReason(receipt, tt.rev, tt.status)
+			assert.Equal(t, tt.reason, receipt.RevertReason())
+		})
+	}
+}
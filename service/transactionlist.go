@@ -27,6 +27,7 @@ type transactionList struct {
 type txElement struct {
 	value transaction.Transaction
 	ts    int64
+	added int64
 	err   error
 
 	list               *transactionList
@@ -120,10 +121,11 @@ func (l *transactionList) Add(tx transaction.Transaction, ts bool) error {
 
 	e := &txElement{
 		value: tx,
+		added: time.Now().UnixNano(),
 		list:  l,
 	}
 	if ts {
-		e.ts = time.Now().UnixNano()
+		e.ts = e.added
 	}
 
 	l.idMap[tidBk][tidSlot] = e
@@ -23,9 +23,15 @@ type TransactionManager struct {
 	lock sync.Mutex
 
 	tim          TXIDManager
+	verifier     *TxVerifier
+	regulator    module.Regulator
 	patchTxPool  *TransactionPool
 	normalTxPool *TransactionPool
 
+	// bundles are ordered transaction bundles from the sequencer. They are
+	// proposed verbatim in order prior to transactions in the pool.
+	bundles [][]transaction.Transaction
+
 	callback func()
 
 	txWaiters map[hashValue][]chan<- interface{}
@@ -45,7 +51,7 @@ func (m *TransactionManager) getTxPool(g module.TransactionGroup) *TransactionPo
 
 func (m *TransactionManager) RemoveOldTxByBlockTS(group module.TransactionGroup, bts int64) {
 	ts := bts - m.tsc.TransactionThreshold(group)
-	m.getTxPool(group).DropOldTXs(ts)
+	m.getTxPool(group).DropOldTXs(ts, bts)
 }
 
 func (m *TransactionManager) HasTx(id []byte) bool {
@@ -56,6 +62,87 @@ func (m *TransactionManager) RemoveTxs(
 	g module.TransactionGroup, l module.TransactionList,
 ) {
 	m.getTxPool(g).RemoveList(l)
+	if g == module.TransactionGroupNormal {
+		m.removeBundles(l)
+	}
+}
+
+// AddBundle queues the bundle of the transactions to be proposed verbatim.
+func (m *TransactionManager) AddBundle(txs []transaction.Transaction) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, tx := range txs {
+		if err := m.tim.CheckTXForAdd(tx); err != nil {
+			return err
+		}
+	}
+	m.bundles = append(m.bundles, txs)
+	if m.callback != nil {
+		cb := m.callback
+		m.callback = nil
+		go cb()
+	}
+	return nil
+}
+
+// Bundle returns the transactions of the first bundle in the queue if
+// there is, or nil. The bundle is dropped if any of the transactions is
+// not valid for the world context.
+func (m *TransactionManager) Bundle(wc state.WorldContext) ([]module.Transaction, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if len(m.bundles) == 0 {
+		return nil, nil
+	}
+	bundle := m.bundles[0]
+	tsr := NewTxTimestampRangeFor(wc, module.TransactionGroupNormal)
+	txs := make([]module.Transaction, 0, len(bundle))
+	for _, tx := range bundle {
+		err := tsr.CheckTx(tx)
+		if err == nil {
+			var has bool
+			if has, err = m.tim.HasRecent(tx.ID()); err == nil && has {
+				err = errors.InvalidStateError.New("AlreadyProcessed")
+			}
+		}
+		if err == nil {
+			err = tx.PreValidate(wc, true)
+		}
+		if err != nil {
+			m.bundles = m.bundles[1:]
+			return nil, errors.InvalidStateError.Wrapf(err,
+				"InvalidTxInBundle(id=%#x)", tx.ID())
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func (m *TransactionManager) removeBundles(l module.TransactionList) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if len(m.bundles) == 0 || l == nil {
+		return
+	}
+	ids := make(map[string]bool)
+	for itr := l.Iterator(); itr.Has(); itr.Next() {
+		if tx, _, err := itr.Get(); err == nil {
+			ids[string(tx.ID())] = true
+		}
+	}
+	bundles := m.bundles[:0]
+	for _, bundle := range m.bundles {
+		if !ids[string(bundle[0].ID())] {
+			bundles = append(bundles, bundle)
+		}
+	}
+	for i := len(bundles); i < len(m.bundles); i++ {
+		m.bundles[i] = nil
+	}
+	m.bundles = bundles
 }
 
 func (m *TransactionManager) Candidate(
@@ -198,7 +285,7 @@ func (m *TransactionManager) VerifyTx(tx transaction.Transaction) error {
 		return errors.InvalidNetworkError.Errorf(
 			"ValidateNetwork(nid=%#x) fail", m.nid)
 	}
-	if err := tx.Verify(); err != nil {
+	if err := m.verifier.Verify(tx); err != nil {
 		return InvalidTransactionError.Wrap(err,
 			"Failed to verify transaction")
 	}
@@ -224,7 +311,7 @@ func (m *TransactionManager) addInLock(tx transaction.Transaction, direct bool)
 func (m *TransactionManager) Wait(wc state.WorldContext, cb func()) bool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	if m.patchTxPool.CheckTxs(wc) || m.normalTxPool.CheckTxs(wc) {
+	if len(m.bundles) > 0 || m.patchTxPool.CheckTxs(wc) || m.normalTxPool.CheckTxs(wc) {
 		return false
 	}
 	m.callback = cb
@@ -245,11 +332,41 @@ func (m *TransactionManager) Logger() log.Logger {
 	return m.log
 }
 
+// poolLoadMonitor notifies the regulator of the number of pending normal
+// transactions along with the monitor.
+type poolLoadMonitor struct {
+	PoolCapacityMonitor
+	regulator module.Regulator
+}
+
+func (m *poolLoadMonitor) OnPoolCapacityUpdated(group module.TransactionGroup, size, used int) {
+	if group == module.TransactionGroupNormal {
+		m.regulator.OnTxPoolUpdated(used)
+	}
+	m.PoolCapacityMonitor.OnPoolCapacityUpdated(group, size, used)
+}
+
 func (m *TransactionManager) SetPoolCapacityMonitor(pcm PoolCapacityMonitor) {
+	if m.regulator != nil {
+		pcm = &poolLoadMonitor{pcm, m.regulator}
+	}
 	m.patchTxPool.SetPoolCapacityMonitor(pcm)
 	m.normalTxPool.SetPoolCapacityMonitor(pcm)
 }
 
+// SetRegulator sets the regulator to be notified of the number of pending
+// normal transactions.
+func (m *TransactionManager) SetRegulator(r module.Regulator) {
+	m.regulator = r
+	m.SetPoolCapacityMonitor(dummyPoolCapacityMonitor{})
+}
+
+// SetVerifier sets the verifier shared with transitions, so transactions
+// verified on admission are not verified again on validation of blocks.
+func (m *TransactionManager) SetVerifier(v *TxVerifier) {
+	m.verifier = v
+}
+
 func NewTransactionManager(nid int, tsc *TxTimestampChecker, ptp *TransactionPool, ntp *TransactionPool, tim TXIDManager, logger log.Logger) *TransactionManager {
 	txm := &TransactionManager{
 		nid:          nid,
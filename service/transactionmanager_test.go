@@ -0,0 +1,133 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+type bundleWorldContext struct {
+	state.WorldContext
+	bts int64
+}
+
+func (wc *bundleWorldContext) BlockTimeStamp() int64 {
+	return wc.bts
+}
+
+func (wc *bundleWorldContext) TransactionTimestampThreshold() int64 {
+	return 0
+}
+
+type invalidTransaction struct {
+	*mockTransaction
+}
+
+func (t *invalidTransaction) PreValidate(wc state.WorldContext, update bool) error {
+	return errors.InvalidStateError.New("InvalidForTest")
+}
+
+type mockTransactionList []module.Transaction
+
+func (l mockTransactionList) Get(i int) (module.Transaction, error) {
+	if i < 0 || i >= len(l) {
+		return nil, errors.ErrNotFound
+	}
+	return l[i], nil
+}
+
+func (l mockTransactionList) Iterator() module.TransactionIterator {
+	return &mockTransactionIterator{l, 0}
+}
+
+func (l mockTransactionList) Hash() []byte {
+	return nil
+}
+
+func (l mockTransactionList) Equal(module.TransactionList) bool {
+	panic("implement me")
+}
+
+func (l mockTransactionList) Flush() error {
+	return nil
+}
+
+type mockTransactionIterator struct {
+	list mockTransactionList
+	idx  int
+}
+
+func (i *mockTransactionIterator) Has() bool {
+	return i.idx < len(i.list)
+}
+
+func (i *mockTransactionIterator) Next() error {
+	i.idx++
+	return nil
+}
+
+func (i *mockTransactionIterator) Get() (module.Transaction, int, error) {
+	tx, err := i.list.Get(i.idx)
+	return tx, i.idx, err
+}
+
+func TestTransactionManager_Bundle(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc, nil)
+	logger := log.New()
+	ptp := NewTransactionPool(module.TransactionGroupPatch, 5000, tim, &mockMonitor{}, logger)
+	ntp := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, logger)
+	tm := NewTransactionManager(1, tsc, ptp, ntp, tim, logger)
+
+	ts := time.Now().UnixNano() / 1000
+	wc := &bundleWorldContext{bts: ts}
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	newTx := func(id string) transaction.Transaction {
+		return &steppedTransaction{newMockTransaction([]byte(id), addr, ts), 0, nil}
+	}
+
+	txs, err := tm.Bundle(wc)
+	assert.NoError(t, err)
+	assert.Nil(t, txs)
+	assert.True(t, tm.Wait(wc, func() {}))
+
+	tx0, tx1, tx2 := newTx("tx0"), newTx("tx1"), newTx("tx2")
+	assert.NoError(t, tm.AddBundle([]transaction.Transaction{tx0, tx1}))
+	assert.NoError(t, tm.AddBundle([]transaction.Transaction{tx2}))
+	bad := &invalidTransaction{newMockTransaction([]byte("tx3"), addr, ts)}
+	assert.NoError(t, tm.AddBundle([]transaction.Transaction{bad}))
+	assert.False(t, tm.Wait(wc, func() {}))
+
+	txs, err = tm.Bundle(wc)
+	assert.NoError(t, err)
+	assert.Equal(t, []module.Transaction{tx0, tx1}, txs)
+
+	// the bundle is kept until it's finalized
+	txs, err = tm.Bundle(wc)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 2)
+
+	tm.RemoveTxs(module.TransactionGroupNormal, mockTransactionList{tx0, tx1})
+	txs, err = tm.Bundle(wc)
+	assert.NoError(t, err)
+	assert.Equal(t, []module.Transaction{tx2}, txs)
+
+	tm.RemoveTxs(module.TransactionGroupNormal, mockTransactionList{tx2})
+	_, err = tm.Bundle(wc)
+	assert.Error(t, err)
+
+	// the invalid bundle is dropped
+	txs, err = tm.Bundle(wc)
+	assert.NoError(t, err)
+	assert.Nil(t, txs)
+}
@@ -17,6 +17,7 @@
 package service
 
 import (
+	"math/big"
 	"sync"
 	"time"
 
@@ -65,10 +66,18 @@ type TransactionPool struct {
 	group module.TransactionGroup
 
 	size int
+	ttl  time.Duration
 	tim  TXIDManager
 
+	// priorityRatio is the percentage of a block reserved for priority
+	// transactions, which are sent to the system or the governance.
+	priorityRatio int
+
 	list *transactionList
 
+	// journal records transactions in the pool if it's set.
+	journal *txJournal
+
 	mutex sync.Mutex
 
 	txm     TxWaiterManager
@@ -91,30 +100,125 @@ func NewTransactionPool(group module.TransactionGroup, size int, tim TXIDManager
 	return pool
 }
 
-func (tp *TransactionPool) DropOldTXs(bts int64) {
+// SetTTL sets how long a transaction may stay in the pool. Zero means
+// no limit.
+func (tp *TransactionPool) SetTTL(ttl time.Duration) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+	tp.ttl = ttl
+}
+
+// SetPriorityRatio sets the percentage of transactions and bytes of a block
+// reserved for priority transactions. Zero means no reservation.
+func (tp *TransactionPool) SetPriorityRatio(ratio int) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+	tp.priorityRatio = ratio
+}
+
+// SetJournal sets the journal recording transactions of the pool, then
+// transactions in the pool are recorded in it.
+func (tp *TransactionPool) SetJournal(j *txJournal) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+	tp.journal = j
+	tp.compactJournal()
+}
+
+// CloseJournal closes the journal if it's set.
+func (tp *TransactionPool) CloseJournal() {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+	if tp.journal == nil {
+		return
+	}
+	if err := tp.journal.close(); err != nil {
+		tp.log.Warnf("Fail to close journal err=%+v", err)
+	}
+	tp.journal = nil
+}
+
+func (tp *TransactionPool) compactJournal() {
+	entries := make([]txJournalEntry, 0, tp.list.Len())
+	for e := tp.list.Front(); e != nil; e = e.Next() {
+		entries = append(entries, txJournalEntry{e.Value(), e.ts != 0})
+	}
+	if err := tp.journal.compact(entries); err != nil {
+		tp.log.Warnf("Fail to compact journal err=%+v", err)
+	}
+}
+
+func (tp *TransactionPool) journalAdd(tx transaction.Transaction, direct bool) {
+	if tp.journal == nil {
+		return
+	}
+	if err := tp.journal.add(tx, direct); err != nil {
+		tp.log.Warnf("Fail to record tx=%#x err=%+v", tx.ID(), err)
+	}
+}
+
+func (tp *TransactionPool) journalRemove(id []byte) {
+	if tp.journal == nil {
+		return
+	}
+	if err := tp.journal.remove(id); err != nil {
+		tp.log.Warnf("Fail to record removal tx=%#x err=%+v", id, err)
+	}
+}
+
+func (tp *TransactionPool) journalCheck() {
+	if tp.journal != nil && tp.journal.shouldCompact() {
+		tp.compactJournal()
+	}
+}
+
+// expiredError returns the reason to drop the element on finalizing the
+// block with the timestamp bts, or nil if it can stay in the pool. The
+// transaction dropped by the TTL is recorded, so it's not added again.
+func (tp *TransactionPool) expiredError(e *txElement, minTS, bts, now int64) error {
+	tx := e.Value()
+	if tx.Timestamp() <= minTS {
+		return ExpiredTransactionError.Errorf(
+			"ExpiredTransaction(diff=%s)", TimestampToDuration(minTS-tx.Timestamp()))
+	}
+	if err := CheckTxExpiration(bts, tx); err != nil {
+		return err
+	}
+	if tp.ttl > 0 && time.Duration(now-e.added) > tp.ttl {
+		tp.tim.AddDroppedTX(tx.ID(), tx.Timestamp())
+		return ExpiredTransactionError.Errorf("ExpiredInPool(ttl=%s)", tp.ttl)
+	}
+	return nil
+}
+
+// DropOldTXs drops transactions older than minTS, expired ones at the block
+// timestamp bts and ones staying in the pool longer than the TTL.
+func (tp *TransactionPool) DropOldTXs(minTS, bts int64) {
 	lock := common.LockForAutoCall(&tp.mutex)
 	defer lock.Unlock()
 	// tp.mutex.Lock()
 	// defer tp.mutex.Unlock()
 
 	var drops []TxDrop
+	now := time.Now().UnixNano()
 	iter := tp.list.Front()
 	for iter != nil {
 		next := iter.Next()
 		tx := iter.Value()
-		if tx.Timestamp() <= bts {
+		if err := tp.expiredError(iter, minTS, bts, now); err != nil {
 			tp.list.Remove(iter)
 			direct := iter.ts != 0
 			if iter.err == nil {
-				iter.err = ExpiredTransactionError.Errorf(
-					"ExpiredTransaction(diff=%s)", TimestampToDuration(bts-tx.Timestamp()))
+				iter.err = err
 			}
 			tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), iter.err)
 			drops = append(drops, TxDrop{tx.ID(), iter.err})
 			tp.monitor.OnDropTx(len(tx.Bytes()), direct)
+			tp.journalRemove(tx.ID())
 		}
 		iter = next
 	}
+	tp.journalCheck()
 	lock.CallAfterUnlock(func() {
 		tp.txm.OnTxDrops(drops)
 	})
@@ -142,6 +246,27 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 		maxCount = configDefaultMaxTxCount
 	}
 
+	var stepLimit, invokeLimit, steps *big.Int
+	if tp.group == module.TransactionGroupNormal {
+		if limit := wc.GetStepLimit(state.StepLimitTypeBlock); limit.Sign() > 0 {
+			stepLimit = limit
+			invokeLimit = wc.GetStepLimit(state.StepLimitTypeInvoke)
+			steps = new(big.Int)
+		}
+	}
+
+	// other transactions may use only the rest of the reserved lane
+	var gov module.Address
+	lanes := false
+	normalBytes, normalCount := maxBytes, maxCount
+	if ratio := tp.priorityRatio; ratio > 0 && tp.group == module.TransactionGroupNormal {
+		lanes = true
+		gov = wc.Governance()
+		normalBytes = maxBytes * (100 - ratio) / 100
+		normalCount = maxCount * (100 - ratio) / 100
+	}
+	normalSize, normalTxs := 0, 0
+
 	tsr := NewTxTimestampRangeFor(wc, tp.group)
 	txs := make([]module.Transaction, 0, configDefaultTxSliceCapacity)
 	dropped := make([]*txElement, 0, configDefaultTxSliceCapacity)
@@ -149,6 +274,10 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 	txSize := int(0)
 	for e := tp.list.Front(); e != nil && txSize < maxBytes && len(txs) < maxCount; e = e.Next() {
 		tx := e.Value()
+		priority := lanes && isPriorityTx(tx, gov)
+		if !priority && normalTxs >= normalCount {
+			continue
+		}
 		if err := tsr.CheckTx(tx); err != nil {
 			if ExpiredTransactionError.Equals(err) {
 				if e.err == nil {
@@ -181,8 +310,24 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 		if txSize+len(bs) > maxBytes {
 			break
 		}
+		if !priority && normalSize+len(bs) > normalBytes {
+			continue
+		}
+		if steps != nil {
+			if ts := blockStepOf(tx, invokeLimit); ts != nil {
+				next := new(big.Int).Add(steps, ts)
+				if len(txs) > 0 && next.Cmp(stepLimit) > 0 {
+					break
+				}
+				steps = next
+			}
+		}
 		txSize += len(bs)
 		txs = append(txs, tx)
+		if !priority {
+			normalSize += len(bs)
+			normalTxs++
+		}
 	}
 	lock.Unlock()
 
@@ -196,6 +341,30 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 	return txs, txSize
 }
 
+// isPriorityTx returns whether the transaction is sent to the system or the
+// governance, so it may use the lane reserved for them.
+func isPriorityTx(tx transaction.Transaction, gov module.Address) bool {
+	to := tx.To()
+	if to == nil {
+		return false
+	}
+	return to.Equal(state.SystemAddress) || (gov != nil && to.Equal(gov))
+}
+
+// blockStepOf returns the steps of the transaction counted for the block step
+// limit, which is the declared step limit bounded by the invoke step limit.
+// It returns nil if the transaction doesn't declare the step limit.
+func blockStepOf(tx transaction.Transaction, invokeLimit *big.Int) *big.Int {
+	s := transaction.StepLimitOf(tx)
+	if s == nil {
+		return nil
+	}
+	if invokeLimit.Sign() > 0 && s.Cmp(invokeLimit) > 0 {
+		return invokeLimit
+	}
+	return s
+}
+
 func (tp *TransactionPool) CheckTxs(wc state.WorldContext) bool {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
@@ -231,6 +400,7 @@ func (tp *TransactionPool) Add(tx transaction.Transaction, direct bool) error {
 
 	err := tp.list.Add(tx, direct)
 	if err == nil {
+		tp.journalAdd(tx, direct)
 		tp.monitor.OnAddTx(len(tx.Bytes()), direct)
 		tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
 	}
@@ -249,7 +419,7 @@ func (tp *TransactionPool) RemoveList(txs module.TransactionList) {
 	}
 
 	var duration time.Duration
-	var count int
+	var count, removed int
 
 	for i := txs.Iterator(); i.Has(); i.Next() {
 		t, _, err := i.Get()
@@ -262,12 +432,18 @@ func (tp *TransactionPool) RemoveList(txs module.TransactionList) {
 				duration += now.Sub(time.Unix(0, ts))
 				count += 1
 			}
+			removed += 1
 			tp.monitor.OnRemoveTx(len(t.Bytes()), ts != 0)
+			tp.journalRemove(t.ID())
 		}
 	}
+	tp.journalCheck()
 
-	if count > 0 {
+	// transactions from other nodes are also removed.
+	if removed > 0 {
 		tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
+	}
+	if count > 0 {
 		tp.monitor.OnCommit(txs.Hash(), now, duration/time.Duration(count))
 	} else {
 		tp.monitor.OnCommit(txs.Hash(), now, 0)
@@ -329,11 +505,16 @@ func (tp *TransactionPool) dropTransactions(txs []*txElement) {
 			tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), e.err)
 			drops = append(drops, TxDrop{tx.ID(), e.err})
 			tp.monitor.OnDropTx(len(tx.Bytes()), direct)
+			tp.journalRemove(tx.ID())
 		}
 	}
+	tp.journalCheck()
 	lock.CallAfterUnlock(func() {
 		tp.txm.OnTxDrops(drops)
 	})
+	if len(drops) > 0 {
+		tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
+	}
 }
 
 func (tp *TransactionPool) FilterTransactions(bloom *TxBloom, max int) []module.Transaction {
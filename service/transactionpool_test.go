@@ -1,13 +1,19 @@
 package service
 
 import (
+	"fmt"
+	"math/big"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/state"
 )
 
 type mockMonitor struct {
@@ -43,3 +49,184 @@ func TestTransactionPool_Add(t *testing.T) {
 		t.Error("Fail to add transaction with valid network ID")
 	}
 }
+
+func TestTransactionPool_DropOldTXsWithTTL(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tic := NewTxIDCache(ConfigDroppedTxSlotDuration, 10, log.New())
+	tim, _ := NewTXIDManager(dbase, tsc, tic)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	tx1 := newMockTransaction([]byte("tx1"), addr, 10)
+	if err := pool.Add(tx1, true); err != nil {
+		t.Fatalf("Fail to add transaction err=%+v", err)
+	}
+
+	pool.DropOldTXs(0, 20)
+	if pool.Used() != 1 {
+		t.Fatalf("Transaction is dropped without TTL size=%d", pool.Used())
+	}
+
+	pool.SetTTL(time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+	pool.DropOldTXs(0, 20)
+	if pool.Used() != 0 {
+		t.Fatalf("Transaction is not dropped by TTL size=%d", pool.Used())
+	}
+	if !tic.Contains(tx1.ID(), tx1.Timestamp()) {
+		t.Error("Transaction dropped by TTL is not recorded")
+	}
+}
+
+type capacityRecorder struct {
+	lock sync.Mutex
+	used []int
+}
+
+func (r *capacityRecorder) OnPoolCapacityUpdated(group module.TransactionGroup, size, used int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.used = append(r.used, used)
+}
+
+func (r *capacityRecorder) Contains(used int) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, u := range r.used {
+		if u == used {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTransactionPool_RemoveListNotifiesCapacity(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc, nil)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+
+	// transaction received from other nodes
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	tx1 := newMockTransaction([]byte("tx1"), addr, 10)
+	assert.NoError(t, pool.Add(tx1, false))
+
+	pcm := new(capacityRecorder)
+	pool.SetPoolCapacityMonitor(pcm)
+
+	pool.RemoveList(mockTransactionList{tx1})
+	assert.Equal(t, 0, pool.Used())
+	assert.True(t, pcm.Contains(0))
+}
+
+type candidateWorldContext struct {
+	state.WorldContext
+	limits map[string]int64
+	gov    module.Address
+}
+
+func (wc *candidateWorldContext) GetStepLimit(t string) *big.Int {
+	return big.NewInt(wc.limits[t])
+}
+
+func (wc *candidateWorldContext) BlockTimeStamp() int64 {
+	return 100
+}
+
+func (wc *candidateWorldContext) TransactionTimestampThreshold() int64 {
+	return 0
+}
+
+func (wc *candidateWorldContext) Governance() module.Address {
+	return wc.gov
+}
+
+type steppedTransaction struct {
+	*mockTransaction
+	stepLimit int64
+	to        module.Address
+}
+
+func (t *steppedTransaction) To() module.Address {
+	return t.to
+}
+
+func (t *steppedTransaction) PreValidate(wc state.WorldContext, update bool) error {
+	return nil
+}
+
+func (t *steppedTransaction) DeclaredStepLimit() *big.Int {
+	return big.NewInt(t.stepLimit)
+}
+
+func TestTransactionPool_CandidateWithBlockStepLimit(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc, nil)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	for i, limit := range []int64{400, 3000, 300, 200} {
+		tx := &steppedTransaction{
+			newMockTransaction([]byte(fmt.Sprintf("tx%d", i)), addr, 100),
+			limit,
+			nil,
+		}
+		if err := pool.Add(tx, true); err != nil {
+			t.Fatalf("Fail to add transaction err=%+v", err)
+		}
+	}
+
+	wc := &candidateWorldContext{limits: map[string]int64{
+		state.StepLimitTypeInvoke: 500,
+	}}
+	txs, _ := pool.Candidate(wc, 0, 0)
+	assert.Len(t, txs, 4)
+
+	// invoke limit bounds the second one, so 400+500 fits in 1000
+	wc.limits[state.StepLimitTypeBlock] = 1000
+	txs, _ = pool.Candidate(wc, 0, 0)
+	assert.Len(t, txs, 2)
+
+	// the first one is included even if it exceeds the limit by itself
+	wc.limits[state.StepLimitTypeBlock] = 100
+	txs, _ = pool.Candidate(wc, 0, 0)
+	assert.Len(t, txs, 1)
+}
+
+func TestTransactionPool_CandidateWithPriorityRatio(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc, nil)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	gov := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+	user := common.MustNewAddressFromString("cx1111111111111111111111111111111111111111")
+	tos := []module.Address{user, user, user, user, state.SystemAddress, gov}
+	for i, to := range tos {
+		tx := &steppedTransaction{
+			newMockTransaction([]byte(fmt.Sprintf("tx%d", i)), addr, 100),
+			0,
+			to,
+		}
+		if err := pool.Add(tx, true); err != nil {
+			t.Fatalf("Fail to add transaction err=%+v", err)
+		}
+	}
+
+	wc := &candidateWorldContext{limits: map[string]int64{}, gov: gov}
+	txs, _ := pool.Candidate(wc, 0, 4)
+	assert.Len(t, txs, 4)
+	assert.Equal(t, []byte("tx3"), txs[3].ID())
+
+	pool.SetPriorityRatio(50)
+	txs, _ = pool.Candidate(wc, 0, 4)
+	if assert.Len(t, txs, 4) {
+		assert.Equal(t, []byte("tx0"), txs[0].ID())
+		assert.Equal(t, []byte("tx1"), txs[1].ID())
+		assert.Equal(t, []byte("tx4"), txs[2].ID())
+		assert.Equal(t, []byte("tx5"), txs[3].ID())
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/icon-project/goloop/btp"
 	"github.com/icon-project/goloop/chain/base"
 	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/chaos"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
@@ -24,6 +25,8 @@ import (
 )
 
 const (
+	// RetryCount is the maximum number of retries for a transaction.
+	// It should be larger than or equal to transaction.TimeoutRetryCount.
 	RetryCount = 2
 )
 
@@ -86,6 +89,7 @@ type transitionContext struct {
 	tsc   *TxTimestampChecker
 	sass  state.AccountSnapshot
 	tim   TXIDManager
+	tv    *TxVerifier
 }
 
 func (tc *transitionContext) onWorldFinalize(wss state.WorldSnapshot) {
@@ -100,6 +104,11 @@ func (tc *transitionContext) onWorldFinalize(wss state.WorldSnapshot) {
 				time.Duration(interval)*time.Millisecond,
 				time.Duration(timeout)*time.Millisecond)
 		}
+		maxInterval := scoredb.NewVarDB(as, state.VarMaxBlockInterval).Int64()
+		txThreshold := scoredb.NewVarDB(as, state.VarTxPoolThreshold).Int64()
+		regulator.SetIntervalPolicy(
+			time.Duration(maxInterval)*time.Millisecond,
+			int(txThreshold))
 
 		tsThreshold := scoredb.NewVarDB(as, state.VarTimestampThreshold).Int64()
 		if tsThreshold > 0 {
@@ -138,6 +147,18 @@ type transition struct {
 	btpDigest      module.BTPDigest
 	logsBloom      txresult.LogsBloom
 
+	// internal calls of transactions by transaction ID
+	internalCalls map[string][]*txresult.InternalCall
+
+	// senders and receivers of transactions
+	accounts [][]byte
+
+	// addresses involved in transactions for the index
+	addressIndex []*addressIndexEntry
+
+	// transfers of tokens for the token index
+	tokenTransfers []*TokenTransfer
+
 	transactionCount int
 	executeDuration  time.Duration
 
@@ -233,6 +254,7 @@ func newInitTransition(dbase db.Database,
 	logger log.Logger, plt base.Platform,
 	tsc *TxTimestampChecker,
 	tim TXIDManager,
+	tv *TxVerifier,
 ) (*transition, error) {
 	wss, err := newWorldSnapshot(dbase, plt, result, validatorList)
 	if err != nil {
@@ -254,6 +276,7 @@ func newInitTransition(dbase db.Database,
 			plt:   plt,
 			tsc:   tsc,
 			tim:   tim,
+			tv:    tv,
 		},
 		step:          stepComplete,
 		result:        result,
@@ -704,6 +727,48 @@ func (t *transition) doExecute(alreadyValidated bool) {
 	}
 	t.patchReceipts = txresult.NewReceiptListFromSlice(t.db, patchReceipts)
 	t.normalReceipts = txresult.NewReceiptListFromSlice(t.db, normalReceipts)
+	t.accounts = nil
+	if err := t.collectAccounts(t.patchTransactions, patchReceipts); err != nil {
+		t.reportExecution(err)
+		return
+	}
+	if err := t.collectAccounts(t.normalTransactions, normalReceipts); err != nil {
+		t.reportExecution(err)
+		return
+	}
+	if t.chain.RecordAddressIndex() {
+		t.addressIndex = nil
+		if err := t.collectAddressIndex(module.TransactionGroupPatch, t.patchTransactions, patchReceipts); err != nil {
+			t.reportExecution(err)
+			return
+		}
+		if err := t.collectAddressIndex(module.TransactionGroupNormal, t.normalTransactions, normalReceipts); err != nil {
+			t.reportExecution(err)
+			return
+		}
+	}
+	if t.chain.RecordTokenIndex() {
+		t.tokenTransfers = nil
+		if err := t.collectTokenTransfers(t.patchTransactions, patchReceipts); err != nil {
+			t.reportExecution(err)
+			return
+		}
+		if err := t.collectTokenTransfers(t.normalTransactions, normalReceipts); err != nil {
+			t.reportExecution(err)
+			return
+		}
+	}
+	if t.chain.RecordInternalCalls() {
+		t.internalCalls = make(map[string][]*txresult.InternalCall)
+		if err := t.collectInternalCalls(t.patchTransactions, patchReceipts); err != nil {
+			t.reportExecution(err)
+			return
+		}
+		if err := t.collectInternalCalls(t.normalTransactions, normalReceipts); err != nil {
+			t.reportExecution(err)
+			return
+		}
+	}
 
 	// save gathered fee to treasury
 	tr := ctx.GetAccountState(ctx.Treasury().ID())
@@ -757,10 +822,21 @@ func (t *transition) onPlatformExecutionEnd(ctx contract.Context, er base.Execut
 	return t.plt.OnExecutionEnd(ctx, er, ctx.GetTraceLogger(module.EPhaseExecutionEnd))
 }
 
+// trustedBySyncAnchor returns whether the block of the transition is lower
+// than the anchor, so signatures of transactions don't need to be verified.
+func (t *transition) trustedBySyncAnchor() bool {
+	if t.bi == nil {
+		return false
+	}
+	anchor := t.chain.SyncAnchor()
+	return anchor != nil && t.bi.Height() < anchor.Height
+}
+
 func (t *transition) validateTxs(l module.TransactionList, wc state.WorldContext, tsr TimestampRange) error {
 	if l == nil {
 		return nil
 	}
+	var txs []transaction.Transaction
 	for i := l.Iterator(); i.Has(); i.Next() {
 		if t.canceled() {
 			return ErrTransitionInterrupted
@@ -770,13 +846,24 @@ func (t *transition) validateTxs(l module.TransactionList, wc state.WorldContext
 		if err != nil {
 			return errors.Wrap(err, "validateTxs: fail to get transaction")
 		}
-		tx := txi.(transaction.Transaction)
+		txs = append(txs, txi.(transaction.Transaction))
+	}
 
+	// signatures are verified in parallel, but errors are returned in the
+	// order of transactions with other ones.
+	var verrs []error
+	if !t.trustedBySyncAnchor() {
+		verrs = t.tv.VerifyAll(txs)
+	}
+	for i, tx := range txs {
+		if t.canceled() {
+			return ErrTransitionInterrupted
+		}
 		if !tx.ValidateNetwork(t.chain.NID()) {
 			return errors.InvalidNetworkError.New("InvalidNetworkID")
 		}
-		if err := tx.Verify(); err != nil {
-			return err
+		if verrs != nil && verrs[i] != nil {
+			return verrs[i]
 		}
 		if err := tsr.CheckTx(tx); err != nil {
 			return err
@@ -792,6 +879,9 @@ func (t *transition) executeTxs(l module.TransactionList, ctx contract.Context,
 	if l == nil {
 		return nil
 	}
+	if err := chaos.Inject(chaos.PointExecute); err != nil {
+		return err
+	}
 	if ctx.SkipTransactionEnabled() {
 		// it will skip skippable transactions
 		return t.executeTxsSequential(l, ctx, rctBuf)
@@ -826,6 +916,9 @@ func (t *transition) finalizeResult(noFlush bool, keepParent bool) error {
 				return errors.Wrap(err, "Fail to finalize with syncer")
 			}
 		} else {
+			if err := chaos.Inject(chaos.PointDBFlush); err != nil {
+				return err
+			}
 			if err := t.worldSnapshot.Flush(); err != nil {
 				return err
 			}
@@ -836,6 +929,22 @@ func (t *transition) finalizeResult(noFlush bool, keepParent bool) error {
 			if err := t.normalReceipts.Flush(); err != nil {
 				return err
 			}
+			if err := t.storeInternalCalls(); err != nil {
+				return err
+			}
+			if err := recordAccountActivity(t.db, t.bi.Height(), t.accounts); err != nil {
+				return err
+			}
+			if len(t.addressIndex) > 0 {
+				if err := storeAddressIndex(t.db, t.bi.Height(), t.addressIndex); err != nil {
+					return err
+				}
+			}
+			if len(t.tokenTransfers) > 0 {
+				if err := storeTokenIndex(t.db, t.bi.Height(), t.tokenTransfers); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	if !keepParent {
@@ -850,6 +959,131 @@ func (t *transition) finalizeResult(noFlush bool, keepParent bool) error {
 	return nil
 }
 
+func (t *transition) collectInternalCalls(l module.TransactionList, receipts []txresult.Receipt) error {
+	if l == nil {
+		return nil
+	}
+	idx := 0
+	for itr := l.Iterator(); itr.Has(); itr.Next() {
+		tx, _, err := itr.Get()
+		if err != nil {
+			return err
+		}
+		if calls := receipts[idx].InternalCalls(); len(calls) > 0 {
+			t.internalCalls[string(tx.ID())] = calls
+		}
+		idx += 1
+	}
+	return nil
+}
+
+func (t *transition) collectAccounts(l module.TransactionList, receipts []txresult.Receipt) error {
+	if l == nil {
+		return nil
+	}
+	idx := 0
+	for itr := l.Iterator(); itr.Has(); itr.Next() {
+		tx, _, err := itr.Get()
+		if err != nil {
+			return err
+		}
+		if from := tx.From(); from != nil {
+			t.accounts = append(t.accounts, from.Bytes())
+		}
+		if to := receipts[idx].To(); to != nil {
+			t.accounts = append(t.accounts, to.Bytes())
+		}
+		idx += 1
+	}
+	return nil
+}
+
+// collectAddressIndex collects the sender, the receiver, the deployed SCORE
+// and SCOREs emitting events of each transaction in the group.
+func (t *transition) collectAddressIndex(g module.TransactionGroup, l module.TransactionList, receipts []txresult.Receipt) error {
+	if l == nil {
+		return nil
+	}
+	height := t.bi.Height()
+	idx := 0
+	for itr := l.Iterator(); itr.Has(); itr.Next() {
+		tx, _, err := itr.Get()
+		if err != nil {
+			return err
+		}
+		r := receipts[idx]
+		addrs := []module.Address{tx.From(), r.To(), r.SCOREAddress()}
+		for litr := r.EventLogIterator(); litr.Has(); litr.Next() {
+			ev, err := litr.Get()
+			if err != nil {
+				return err
+			}
+			addrs = append(addrs, ev.Address())
+		}
+		atx := &AddressTransaction{
+			Height: height,
+			Group:  g,
+			Index:  idx,
+			TxHash: tx.ID(),
+		}
+		added := make(map[string]bool)
+		for _, addr := range addrs {
+			if addr == nil || added[string(addr.Bytes())] {
+				continue
+			}
+			added[string(addr.Bytes())] = true
+			t.addressIndex = append(t.addressIndex, &addressIndexEntry{
+				addr: addr.Bytes(),
+				tx:   atx,
+			})
+		}
+		idx += 1
+	}
+	return nil
+}
+
+// collectTokenTransfers collects Transfer events of IRC-2 and IRC-3 tokens
+// emitted by successful transactions.
+func (t *transition) collectTokenTransfers(l module.TransactionList, receipts []txresult.Receipt) error {
+	if l == nil {
+		return nil
+	}
+	height := t.bi.Height()
+	idx := 0
+	for itr := l.Iterator(); itr.Has(); itr.Next() {
+		tx, _, err := itr.Get()
+		if err != nil {
+			return err
+		}
+		r := receipts[idx]
+		if r.Status() == module.StatusSuccess {
+			for litr := r.EventLogIterator(); litr.Has(); litr.Next() {
+				ev, err := litr.Get()
+				if err != nil {
+					return err
+				}
+				if tf := decodeTokenTransfer(ev); tf != nil {
+					tf.Height = height
+					tf.TxIndex = idx
+					tf.TxHash = tx.ID()
+					t.tokenTransfers = append(t.tokenTransfers, tf)
+				}
+			}
+		}
+		idx += 1
+	}
+	return nil
+}
+
+func (t *transition) storeInternalCalls() error {
+	for id, calls := range t.internalCalls {
+		if err := txresult.StoreInternalCalls(t.db, []byte(id), calls); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (t *transition) cancelExecution() bool {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
@@ -921,7 +1155,7 @@ func NewInitTransition(
 	if err != nil {
 		return nil, err
 	}
-	if tr, err := newInitTransition(db, result, vl, cm, em, chain, logger, plt, tsc, tim); err != nil {
+	if tr, err := newInitTransition(db, result, vl, cm, em, chain, logger, plt, tsc, tim, nil); err != nil {
 		return nil, err
 	} else {
 		return tr, nil
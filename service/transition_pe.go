@@ -90,6 +90,7 @@ func (t *transition) executeTxsConcurrent(level int, l module.TransactionList, c
 					Nonce:     txo.Nonce(),
 					Hash:      txo.ID(),
 					From:      txo.From(),
+					Retry:     retry,
 				})
 				ctx.UpdateSystemInfo()
 				rct, err := txh.Execute(ctx, wvss, false)
@@ -45,12 +45,13 @@ func (t *transition) executeTxsSequential(l module.TransactionList, ctx contract
 			Hash:      txo.ID(),
 			From:      txo.From(),
 		}
-		ctx.SetTransactionInfo(txInfo)
 		wcs := ctx.GetSnapshot()
 		traceLogger := ctx.GetTraceLogger(module.EPhaseTransaction)
 		traceLogger.OnTransactionStart(cnt, txo.ID())
 
 		for retry := 0; ; retry++ {
+			txInfo.Retry = retry
+			ctx.SetTransactionInfo(txInfo)
 			txh, err := txo.GetHandler(t.cm)
 			if err != nil {
 				t.log.Errorf("Fail to GetHandler err=%+v", err)
@@ -128,6 +128,24 @@ func NewWorldSnapshot(database db.Database, plt base.Platform, result []byte, vl
 	return newWorldSnapshot(database, plt, result, vl)
 }
 
+// ExportState exports the world state of the result with chunks of size
+// records. See state.ExportWorldSnapshot for the format.
+func ExportState(dbase db.Database, result []byte, vl module.ValidatorList, size int, cb func(c *state.StateChunk) error) error {
+	var ed []byte
+	if len(result) > 0 {
+		tr, err := newTransitionResultFromBytes(result)
+		if err != nil {
+			return err
+		}
+		ed = tr.ExtensionData
+	}
+	wss, err := newWorldSnapshot(dbase, nil, result, vl)
+	if err != nil {
+		return err
+	}
+	return state.ExportWorldSnapshot(wss, ed, size, cb)
+}
+
 func NewBTPContext(dbase db.Database, result []byte) (state.BTPContext, error) {
 	wss, err := NewWorldSnapshot(dbase, nil, result, nil)
 	if err != nil {
@@ -150,3 +168,16 @@ func BTPDigestHashFromResult(result []byte) ([]byte, error) {
 	}
 	return r.BTPData, nil
 }
+
+// ReceiptHashFromResult returns the hash of the receipts of the group in
+// the result.
+func ReceiptHashFromResult(result []byte, g module.TransactionGroup) ([]byte, error) {
+	r, err := newTransitionResultFromBytes(result)
+	if err != nil {
+		return nil, err
+	}
+	if g == module.TransactionGroupNormal {
+		return r.NormalReceiptHash, nil
+	}
+	return r.PatchReceiptHash, nil
+}
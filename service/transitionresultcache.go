@@ -13,12 +13,21 @@ import (
 	"github.com/icon-project/goloop/service/txresult"
 )
 
+// queryContext keeps the snapshot for queries and the world context on it.
+// The world context is used only as the base of the contexts for queries,
+// so system information loaded from the snapshot is shared by them.
+type queryContext struct {
+	wss state.WorldSnapshot
+	wc  state.WorldContext
+}
+
 type trCacheItem struct {
 	result            string
 	database          *databaseAdaptor
 	transactionResult *transitionResult
 	worldSnapshot     state.WorldSnapshot
 	worldContext      state.WorldContext
+	queryContexts     map[string]*queryContext
 	normalReceipts    module.ReceiptList
 	patchReceipts     module.ReceiptList
 }
@@ -40,6 +49,8 @@ type transitionResultCache struct {
 
 	vssCache *cache.LRUCache
 
+	lastResult string
+
 	log log.Logger
 }
 
@@ -174,6 +185,64 @@ func (c *transitionResultCache) GetWorldContext(result []byte, vh []byte) (state
 	return item.worldContext, nil
 }
 
+// GetQueryContext returns the world context for queries on the result with
+// the validators of the hash for the block. The snapshot and system
+// information for the result and the validators are kept, but each query gets
+// its own read-only world state as states aren't safe for concurrent use.
+func (c *transitionResultCache) GetQueryContext(result []byte, vh []byte, bi module.BlockInfo) (state.WorldContext, error) {
+	qc, err := c.getQueryContext(result, vh)
+	if err != nil {
+		return nil, err
+	}
+	return qc.wc.BlockChanged(state.NewReadOnlyWorldState(qc.wss), bi), nil
+}
+
+func (c *transitionResultCache) getQueryContext(result []byte, vh []byte) (*queryContext, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	item, err := c.getItemInLock(result)
+	if err != nil {
+		return nil, err
+	}
+	vhs := string(vh)
+	qc, ok := item.queryContexts[vhs]
+	if !ok {
+		wss, err := c.getWorldSnapshotInLock(result, vh)
+		if err != nil {
+			return nil, err
+		}
+		qc = &queryContext{
+			wss: wss,
+			wc:  state.NewWorldContext(state.NewReadOnlyWorldState(wss), nil, nil, c.platform),
+		}
+		if item.queryContexts == nil {
+			item.queryContexts = make(map[string]*queryContext)
+		}
+		item.queryContexts[vhs] = qc
+	}
+	return qc, nil
+}
+
+// OnFinalize drops contexts for queries on results except the finalized one.
+// Queries are mostly on the last state, so contexts on old states are rarely
+// used again.
+func (c *transitionResultCache) OnFinalize(result []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	s := string(result)
+	if s == c.lastResult {
+		return
+	}
+	c.lastResult = s
+	for e := c.stateList.Front(); e != nil; e = e.Next() {
+		if item := e.Value.(*trCacheItem); item.result != s {
+			item.queryContexts = nil
+		}
+	}
+}
+
 func (c *transitionResultCache) Count() int {
 	c.lock.Lock()
 	defer c.lock.Unlock()
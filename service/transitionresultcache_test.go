@@ -17,13 +17,16 @@
 package service
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/icon-project/goloop/chain/base"
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/state"
 )
 
@@ -35,6 +38,62 @@ func (p *testPlatform) NewExtensionSnapshot(dbase db.Database, raw []byte) state
 	return nil
 }
 
+func (p *testPlatform) ToRevision(value int) module.Revision {
+	return module.Revision(value)
+}
+
+// cacheExtensionSnapshot makes states caching loaded values without locks
+// like the extension of ICON.
+type cacheExtensionSnapshot struct{}
+
+func (s *cacheExtensionSnapshot) Bytes() []byte {
+	return nil
+}
+
+func (s *cacheExtensionSnapshot) Flush() error {
+	return nil
+}
+
+func (s *cacheExtensionSnapshot) NewState(readonly bool) state.ExtensionState {
+	return &cacheExtensionState{
+		snapshot: s,
+		values:   make(map[int]int),
+	}
+}
+
+type cacheExtensionState struct {
+	snapshot *cacheExtensionSnapshot
+	values   map[int]int
+}
+
+func (s *cacheExtensionState) GetSnapshot() state.ExtensionSnapshot {
+	return s.snapshot
+}
+
+func (s *cacheExtensionState) Reset(snapshot state.ExtensionSnapshot) {
+	s.ClearCache()
+}
+
+func (s *cacheExtensionState) ClearCache() {
+	s.values = make(map[int]int)
+}
+
+func (s *cacheExtensionState) Get(key int) int {
+	if v, ok := s.values[key]; ok {
+		return v
+	}
+	s.values[key] = key
+	return key
+}
+
+type cachePlatform struct {
+	testPlatform
+}
+
+func (p *cachePlatform) NewExtensionSnapshot(dbase db.Database, raw []byte) state.ExtensionSnapshot {
+	return &cacheExtensionSnapshot{}
+}
+
 func Test_transitionResultCache_GetWorldSnapshot(t *testing.T) {
 	mdb := db.NewMapDB()
 	logger := log.GlobalLogger()
@@ -48,3 +107,128 @@ func Test_transitionResultCache_GetWorldSnapshot(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, ws)
 }
+
+func Test_transitionResultCache_GetQueryContext(t *testing.T) {
+	mdb := db.NewMapDB()
+	logger := log.GlobalLogger()
+	trc := newTransitionResultCache(mdb, &testPlatform{}, 10, 1024*1024, logger)
+
+	result1 := newTestTransitionResultBytes(t, mdb)
+	bi1 := common.NewBlockInfo(1, 1000)
+	wc1, err := trc.GetQueryContext(result1, nil, bi1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, wc1.BlockHeight())
+
+	// the snapshot is shared for the same result, but not the world state
+	bi2 := common.NewBlockInfo(2, 2000)
+	wc2, err := trc.GetQueryContext(result1, nil, bi2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, wc2.BlockHeight())
+	assert.EqualValues(t, 1, wc1.BlockHeight())
+	assert.Same(t, wc1.GetSnapshot(), wc2.GetSnapshot())
+	as1 := wc1.GetAccountState(state.SystemID)
+	assert.NotSame(t, as1, wc2.GetAccountState(state.SystemID))
+	value, err := wc2.GetAccountState(state.SystemID).GetValue([]byte("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// contexts on other results are dropped on finalization
+	item := trc.stateMap[string(result1)].Value.(*trCacheItem)
+	assert.Len(t, item.queryContexts, 1)
+	trc.OnFinalize(new(transitionResult).Bytes())
+	assert.Empty(t, item.queryContexts)
+	_, err = trc.GetQueryContext(result1, nil, bi2)
+	assert.NoError(t, err)
+
+	trc.OnFinalize(result1)
+	assert.Len(t, item.queryContexts, 1)
+}
+
+func Test_transitionResultCache_GetQueryContextParallel(t *testing.T) {
+	mdb := db.NewMapDB()
+	logger := log.GlobalLogger()
+	trc := newTransitionResultCache(mdb, &cachePlatform{}, 10, 1024*1024, logger)
+	result := newTestTransitionResultBytes(t, mdb)
+
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				bi := common.NewBlockInfo(int64(j), int64(j)*1000)
+				wc, err := trc.GetQueryContext(result, nil, bi)
+				if err != nil {
+					errs <- err
+					return
+				}
+				wc.GetAccountState([]byte{byte(i), byte(j)})
+				if _, err := wc.GetAccountState(state.SystemID).GetValue([]byte("key")); err != nil {
+					errs <- err
+					return
+				}
+				wc.GetExtensionState().(*cacheExtensionState).Get(i*100 + j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func newTestTransitionResultBytes(t testing.TB, dbase db.Database) []byte {
+	ws := state.NewWorldState(dbase, nil, nil, nil, nil)
+	as := ws.GetAccountState(state.SystemID)
+	_, err := as.SetValue([]byte("key"), []byte("value"))
+	assert.NoError(t, err)
+	wss := ws.GetSnapshot()
+	assert.NoError(t, wss.Flush())
+	tr := &transitionResult{StateHash: wss.StateHash()}
+	return tr.Bytes()
+}
+
+func BenchmarkTransitionResultCache_GetQueryContext(b *testing.B) {
+	mdb := db.NewMapDB()
+	logger := log.GlobalLogger()
+	trc := newTransitionResultCache(mdb, &testPlatform{}, 10, 1024*1024, logger)
+	result := newTestTransitionResultBytes(b, mdb)
+	bi := common.NewBlockInfo(1, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			wc, err := trc.GetQueryContext(result, nil, bi)
+			if err != nil {
+				b.Fatal(err)
+			}
+			wc.GetAccountSnapshot(state.SystemID)
+		}
+	})
+}
+
+func BenchmarkTransitionResultCache_NewQueryContext(b *testing.B) {
+	mdb := db.NewMapDB()
+	logger := log.GlobalLogger()
+	trc := newTransitionResultCache(mdb, &testPlatform{}, 10, 1024*1024, logger)
+	plt := &testPlatform{}
+	result := newTestTransitionResultBytes(b, mdb)
+	bi := common.NewBlockInfo(1, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			wss, err := trc.GetWorldSnapshot(result, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			wc := state.NewWorldContext(state.NewReadOnlyWorldState(wss), bi, nil, plt)
+			wc.GetAccountSnapshot(state.SystemID)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/state"
@@ -27,12 +28,43 @@ func CheckTxTimestamp(min, max int64, tx transaction.Transaction) error {
 	return nil
 }
 
+// CheckTxExpiration returns ExpiredTransactionError if the transaction has
+// the expiration which is not after ts.
+func CheckTxExpiration(ts int64, tx transaction.Transaction) error {
+	if exp := transaction.ExpirationOf(tx); exp != 0 && exp <= ts {
+		return ExpiredTransactionError.Errorf("Expired(expiration-%s)",
+			time.Duration(ts-exp)*time.Microsecond)
+	}
+	return nil
+}
+
 type TxTimestampChecker struct {
 	threshold int64
+	window    int64
+	clock     common.Clock
 }
 
+// CheckWithCurrent checks the transaction to be added to the pool. If the
+// window is configured and narrower than the threshold, it's used instead.
 func (c *TxTimestampChecker) CheckWithCurrent(min int64, tx transaction.Transaction) error {
-	return CheckTxTimestamp(min, (time.Now().UnixNano()/1000)+c.Threshold(), tx)
+	now := common.UnixMicroFromTime(c.clock.Now())
+	th := c.Threshold()
+	if w := atomic.LoadInt64(&c.window); w > 0 && w < th {
+		th = w
+		if now-w > min {
+			min = now - w
+		}
+	}
+	if err := CheckTxTimestamp(min, now+th, tx); err != nil {
+		return err
+	}
+	return CheckTxExpiration(now, tx)
+}
+
+// SetWindow sets the window of timestamps for transactions to be added to
+// the pool. Zero means the threshold.
+func (c *TxTimestampChecker) SetWindow(d time.Duration) {
+	atomic.StoreInt64(&c.window, DurationToTimestamp(d))
 }
 
 func (c *TxTimestampChecker) SetThreshold(d time.Duration) {
@@ -61,8 +93,15 @@ func DurationToTimestamp(d time.Duration) int64 {
 }
 
 func NewTimestampChecker() *TxTimestampChecker {
+	return NewTimestampCheckerWithClock(&common.GoTimeClock{})
+}
+
+// NewTimestampCheckerWithClock returns the checker comparing timestamps of
+// transactions with the current time of the clock.
+func NewTimestampCheckerWithClock(cl common.Clock) *TxTimestampChecker {
 	return &TxTimestampChecker{
 		threshold: ConfigTXTimestampThresholdDefault,
+		clock:     cl,
 	}
 }
 
@@ -83,15 +122,19 @@ type TimestampRange interface {
 }
 
 type timestampRange struct {
-	min, max int64
+	bts, min, max int64
 }
 
 func (r *timestampRange) CheckTx(tx transaction.Transaction) error {
-	return CheckTxTimestamp(r.min, r.max, tx)
+	if err := CheckTxTimestamp(r.min, r.max, tx); err != nil {
+		return err
+	}
+	return CheckTxExpiration(r.bts, tx)
 }
 
 func NewTimestampRange(bts int64, th int64) TimestampRange {
 	return &timestampRange{
+		bts: bts,
 		min: bts - th,
 		max: bts + th,
 	}
@@ -111,6 +154,7 @@ func NewTxTimestampRangeFor(c state.WorldContext, g module.TransactionGroup) Tim
 	th := TransactionTimestampThreshold(c, g)
 	bts := c.BlockTimeStamp()
 	return &timestampRange{
+		bts: bts,
 		min: bts - th,
 		max: bts + th,
 	}
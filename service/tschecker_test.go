@@ -0,0 +1,51 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/test/clock"
+)
+
+func TestTxTimestampChecker_CheckWithCurrent(t *testing.T) {
+	cl := &clock.Clock{}
+	cl.SetTime(time.Unix(1600000000, 0))
+	tsc := NewTimestampCheckerWithClock(cl)
+	tsc.SetThreshold(time.Minute)
+
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	now := common.UnixMicroFromTime(cl.Now())
+	tx := newMockTransaction([]byte("tx"), addr, now+DurationToTimestamp(30*time.Second))
+	assert.NoError(t, tsc.CheckWithCurrent(0, tx))
+
+	future := newMockTransaction([]byte("future"), addr, now+DurationToTimestamp(2*time.Minute))
+	err := tsc.CheckWithCurrent(0, future)
+	assert.True(t, FutureTransactionError.Equals(err))
+
+	cl.PassTime(90 * time.Second)
+	assert.NoError(t, tsc.CheckWithCurrent(0, future))
+
+	cl.PassTime(3 * time.Minute)
+	min := common.UnixMicroFromTime(cl.Now()) - tsc.Threshold()
+	err = tsc.CheckWithCurrent(min, tx)
+	assert.True(t, ExpiredTransactionError.Equals(err))
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+const (
+	txRecordAdd byte = iota + 1
+	txRecordAddDirect
+	txRecordRemove
+)
+
+const (
+	txRecordHeaderSize = 5
+	// txRecordMaxSize is the limit of the data of a record. Transactions
+	// are relayed in a network packet, so they are smaller than its payload
+	// limit (1MB). A record over it is regarded as corrupted.
+	txRecordMaxSize           = 1024 * 1024
+	configTxJournalCompactMin = 1024
+	txJournalPermission       = 0600
+	txJournalDirPermission    = 0700
+)
+
+type txJournalEntry struct {
+	tx     transaction.Transaction
+	direct bool
+}
+
+// txJournal records transactions added to and removed from the pool in the
+// file, so pending transactions can be restored after restart. Records are
+// flushed to the file on writing, and the file is compacted with the
+// transactions in the pool if removed ones take most of it.
+type txJournal struct {
+	path    string
+	file    *os.File
+	buf     *bufio.Writer
+	live    int
+	records int
+}
+
+func txJournalNameOf(g module.TransactionGroup) string {
+	if g == module.TransactionGroupPatch {
+		return "patch"
+	}
+	return "normal"
+}
+
+func readTxRecord(r io.Reader) (byte, []byte, error) {
+	var header [txRecordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > txRecordMaxSize {
+		return 0, nil, errors.InvalidStateError.Errorf("InvalidRecordSize(size=%d)", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, err
+	}
+	return header[0], data, nil
+}
+
+// readTxJournal returns transactions left in the journal in the order of
+// addition. A broken record at the end, written partially on crash, is
+// ignored with following ones.
+func readTxJournal(path string) ([]txJournalEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+
+	var entries []*txJournalEntry
+	index := make(map[string]*txJournalEntry)
+	r := bufio.NewReader(f)
+	for {
+		kind, data, err := readTxRecord(r)
+		if err != nil {
+			break
+		}
+		switch kind {
+		case txRecordAdd, txRecordAddDirect:
+			tx, err := transaction.NewTransaction(data)
+			if err != nil {
+				continue
+			}
+			if _, ok := index[string(tx.ID())]; ok {
+				continue
+			}
+			e := &txJournalEntry{tx: tx, direct: kind == txRecordAddDirect}
+			index[string(tx.ID())] = e
+			entries = append(entries, e)
+		case txRecordRemove:
+			if e, ok := index[string(data)]; ok {
+				e.tx = nil
+				delete(index, string(data))
+			}
+		}
+	}
+	result := make([]txJournalEntry, 0, len(index))
+	for _, e := range entries {
+		if e.tx != nil {
+			result = append(result, *e)
+		}
+	}
+	return result, nil
+}
+
+// openTxJournal opens the journal at the path, and returns transactions
+// recorded in it. They shall be recorded again by compact after
+// validation, because the file is written from the end.
+func openTxJournal(path string) (*txJournal, []txJournalEntry, error) {
+	entries, err := readTxJournal(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	j := &txJournal{path: path}
+	if err := j.reopen(); err != nil {
+		return nil, nil, err
+	}
+	return j, entries, nil
+}
+
+func (j *txJournal) reopen() error {
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, txJournalPermission)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	j.file = f
+	j.buf = bufio.NewWriter(f)
+	return nil
+}
+
+func writeTxRecord(w io.Writer, kind byte, data []byte) error {
+	if len(data) > txRecordMaxSize {
+		return errors.IllegalArgumentError.Errorf("TooLargeRecord(size=%d)", len(data))
+	}
+	var header [txRecordHeaderSize]byte
+	header[0] = kind
+	binary.BigEndian.PutUint32(header[1:], uint32(len(data)))
+	if _, err := w.Write(header[:]); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (j *txJournal) write(kind byte, data []byte) error {
+	if err := writeTxRecord(j.buf, kind, data); err != nil {
+		return err
+	}
+	j.records += 1
+	return errors.WithStack(j.buf.Flush())
+}
+
+func (j *txJournal) add(tx transaction.Transaction, direct bool) error {
+	kind := txRecordAdd
+	if direct {
+		kind = txRecordAddDirect
+	}
+	if err := j.write(kind, tx.Bytes()); err != nil {
+		return err
+	}
+	j.live += 1
+	return nil
+}
+
+func (j *txJournal) remove(id []byte) error {
+	j.live -= 1
+	return j.write(txRecordRemove, id)
+}
+
+func (j *txJournal) shouldCompact() bool {
+	return j.records > configTxJournalCompactMin && j.records > 2*j.live
+}
+
+// compact replaces the file with the one recording the entries only.
+func (j *txJournal) compact(entries []txJournalEntry) error {
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, txJournalPermission)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	w := bufio.NewWriter(f)
+	for _, e := range entries {
+		kind := txRecordAdd
+		if e.direct {
+			kind = txRecordAddDirect
+		}
+		if err = writeTxRecord(w, kind, e.tx.Bytes()); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = errors.WithStack(w.Flush())
+	}
+	if err == nil {
+		err = errors.WithStack(f.Sync())
+	}
+	if cerr := f.Close(); err == nil {
+		err = errors.WithStack(cerr)
+	}
+	if err == nil {
+		err = errors.WithStack(os.Rename(tmp, j.path))
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	_ = j.file.Close()
+	j.live = len(entries)
+	j.records = len(entries)
+	return j.reopen()
+}
+
+func (j *txJournal) close() error {
+	if err := j.buf.Flush(); err != nil {
+		_ = j.file.Close()
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(j.file.Close())
+}
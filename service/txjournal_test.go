@@ -0,0 +1,150 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+func newJournalTestTransaction(t *testing.T, ts int64) transaction.Transaction {
+	js := fmt.Sprintf(`{
+		"version": "0x3",
+		"from": "hx0000000000000000000000000000000000000001",
+		"to": "hx0000000000000000000000000000000000000002",
+		"value": "0x1",
+		"stepLimit": "0x100000",
+		"timestamp": "%#x",
+		"nid": "0x1"
+	}`, ts)
+	tx, err := transaction.NewTransactionFromJSON([]byte(js))
+	assert.NoError(t, err)
+	return tx
+}
+
+type journalTestLargeTransaction struct {
+	transaction.Transaction
+}
+
+func (t *journalTestLargeTransaction) Bytes() []byte {
+	return make([]byte, txRecordMaxSize+1)
+}
+
+func TestTxJournal_Basic(t *testing.T) {
+	p := path.Join(t.TempDir(), "normal")
+	j, entries, err := openTxJournal(p)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 0)
+
+	tx1 := newJournalTestTransaction(t, 1)
+	tx2 := newJournalTestTransaction(t, 2)
+	tx3 := newJournalTestTransaction(t, 3)
+	assert.NoError(t, j.add(tx1, true))
+	assert.NoError(t, j.add(tx2, false))
+	assert.NoError(t, j.add(tx3, false))
+	assert.NoError(t, j.remove(tx2.ID()))
+	assert.NoError(t, j.close())
+
+	// broken record at the end is ignored
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_APPEND, 0)
+	assert.NoError(t, err)
+	_, err = f.Write([]byte{txRecordAdd, 0, 0, 1})
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	j, entries, err = openTxJournal(p)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, tx1.ID(), entries[0].tx.ID())
+		assert.True(t, entries[0].direct)
+		assert.Equal(t, tx3.ID(), entries[1].tx.ID())
+		assert.False(t, entries[1].direct)
+	}
+
+	assert.NoError(t, j.compact(entries[1:]))
+	assert.NoError(t, j.close())
+	entries, err = readTxJournal(p)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, tx3.ID(), entries[0].tx.ID())
+	}
+}
+
+func TestTxJournal_CorruptedSize(t *testing.T) {
+	p := path.Join(t.TempDir(), "normal")
+	j, _, err := openTxJournal(p)
+	assert.NoError(t, err)
+	tx1 := newJournalTestTransaction(t, 1)
+	assert.NoError(t, j.add(tx1, true))
+	assert.Error(t, j.add(&journalTestLargeTransaction{tx1}, true))
+	assert.NoError(t, j.close())
+
+	// the size is checked before allocating the data
+	corrupted := []byte{txRecordAdd, 0xff, 0xff, 0xff, 0xff, 0x01}
+	_, _, err = readTxRecord(bytes.NewReader(corrupted))
+	assert.True(t, errors.InvalidStateError.Equals(err))
+
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_APPEND, 0)
+	assert.NoError(t, err)
+	_, err = f.Write(corrupted)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	entries, err := readTxJournal(p)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, tx1.ID(), entries[0].tx.ID())
+	}
+}
+
+func TestTransactionPool_Journal(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc, nil)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+
+	tx1 := newJournalTestTransaction(t, 1)
+	tx2 := newJournalTestTransaction(t, 2)
+	assert.NoError(t, pool.Add(tx1, true))
+
+	// transactions in the pool are recorded on setting the journal
+	p := path.Join(t.TempDir(), "normal")
+	j, _, err := openTxJournal(p)
+	assert.NoError(t, err)
+	pool.SetJournal(j)
+	assert.NoError(t, pool.Add(tx2, false))
+
+	pool.RemoveList(transaction.NewTransactionListFromSlice(dbase, []module.Transaction{tx1}))
+	pool.CloseJournal()
+
+	entries, err := readTxJournal(p)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, tx2.ID(), entries[0].tx.ID())
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/module"
 )
 
@@ -39,16 +40,47 @@ func (d *feeDetail) AddPayment(address module.Address, steps *big.Int) bool {
 	if steps.Sign() == 0 {
 		return false
 	}
+	d.add(address, steps)
+	return true
+}
+
+// add adds the amount to the payment of the address. Unlike AddPayment,
+// it adds the payment of zero amount.
+func (d *feeDetail) add(address module.Address, steps *big.Int) {
 	for _, p := range *d {
 		if p.Payer.Equal(address) {
 			p.Amount.Add(&p.Amount.Int, steps)
-			return true
+			return
 		}
 	}
 	p := new(feePayment)
 	p.Payer.Set(address)
 	p.Amount.Set(steps)
 	*d = append(*d, p)
+}
+
+// amountOf returns the amount paid by the address. It returns nil if the
+// address has no payment.
+func (d feeDetail) amountOf(address module.Address) *big.Int {
+	for _, p := range d {
+		if p.Payer.Equal(address) {
+			return p.Amount.Value()
+		}
+	}
+	return nil
+}
+
+// Equal returns whether it has the same payments. Unlike reflect.DeepEqual,
+// it compares values of amounts, so zero amounts in any form are same.
+func (d feeDetail) Equal(d2 feeDetail) bool {
+	if len(d) != len(d2) {
+		return false
+	}
+	for i, p := range d {
+		if !p.Payer.Equal(&d2[i].Payer) || p.Amount.Cmp(&d2[i].Amount.Int) != 0 {
+			return false
+		}
+	}
 	return true
 }
 
@@ -160,3 +192,56 @@ func (itr *feeIterator) Get() (module.FeePayment, error) {
 		return nil, common.ErrInvalidState
 	}
 }
+
+// FeePaymentDetail is the steps paid by the payer. FeeSteps are paid with
+// the balance or the deposit of the payer, and the rest of Steps are paid
+// with virtual steps. FeeSteps is nil if it's unknown.
+type FeePaymentDetail struct {
+	Payer    module.Address
+	Steps    *big.Int
+	FeeSteps *big.Int
+}
+
+func (d *FeePaymentDetail) VirtualSteps() *big.Int {
+	if d.FeeSteps == nil {
+		return nil
+	}
+	return new(big.Int).Sub(d.Steps, d.FeeSteps)
+}
+
+func (d *FeePaymentDetail) ToJSON(v module.JSONVersion) (interface{}, error) {
+	jso := map[string]interface{}{
+		"payer": d.Payer.String(),
+		"steps": intconv.FormatBigInt(d.Steps),
+	}
+	if d.FeeSteps != nil {
+		jso["feeSteps"] = intconv.FormatBigInt(d.FeeSteps)
+		jso["virtualSteps"] = intconv.FormatBigInt(d.VirtualSteps())
+	}
+	return jso, nil
+}
+
+type feePaymentDetails []*FeePaymentDetail
+
+func (ds *feePaymentDetails) Add(payer module.Address, steps, feeSteps *big.Int) {
+	if steps.Sign() == 0 {
+		return
+	}
+	if feeSteps == nil {
+		feeSteps = new(big.Int)
+	}
+	steps = new(big.Int).Set(steps)
+	feeSteps = new(big.Int).Set(feeSteps)
+	for _, d := range *ds {
+		if d.Payer.Equal(payer) {
+			d.Steps.Add(d.Steps, steps)
+			d.FeeSteps.Add(d.FeeSteps, feeSteps)
+			return
+		}
+	}
+	*ds = append(*ds, &FeePaymentDetail{
+		Payer:    common.AddressToPtr(payer),
+		Steps:    steps,
+		FeeSteps: feeSteps,
+	})
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package txresult
+
+import (
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+// InternalCall is a call made by a contract in the transaction.
+// Depth is 1 for calls made by the contract called by the transaction.
+// Calls are listed in the order they are made, so the call tree can be
+// built with depths.
+type InternalCall struct {
+	Depth  int
+	From   common.Address
+	To     common.Address
+	Value  common.HexInt
+	Method string
+	Status module.Status
+}
+
+type internalCallJSON struct {
+	Depth  common.HexInt32  `json:"depth"`
+	From   *common.Address  `json:"from"`
+	To     *common.Address  `json:"to"`
+	Value  *common.HexInt   `json:"value"`
+	Method string           `json:"method,omitempty"`
+	Status common.HexUint16 `json:"status"`
+}
+
+func (c *InternalCall) ToJSON(version module.JSONVersion) (interface{}, error) {
+	return &internalCallJSON{
+		Depth:  common.HexInt32{Value: int32(c.Depth)},
+		From:   &c.From,
+		To:     &c.To,
+		Value:  &c.Value,
+		Method: c.Method,
+		Status: common.HexUint16{Value: uint16(c.Status)},
+	}, nil
+}
+
+// StoreInternalCalls stores internal calls of the transaction. They are
+// stored only in the local database, so they are not synchronized with
+// other nodes.
+func StoreInternalCalls(dbase db.Database, txHash []byte, calls []*InternalCall) error {
+	bk, err := dbase.GetBucket(db.InternalCallsByTxHash)
+	if err != nil {
+		return err
+	}
+	bs, err := codec.BC.MarshalToBytes(calls)
+	if err != nil {
+		return err
+	}
+	return bk.Set(txHash, bs)
+}
+
+// LoadInternalCalls returns internal calls of the transaction. It returns
+// NotFoundError if they are not recorded.
+func LoadInternalCalls(dbase db.Database, txHash []byte) ([]*InternalCall, error) {
+	bk, err := dbase.GetBucket(db.InternalCallsByTxHash)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := bk.Get(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if bs == nil {
+		return nil, errors.NotFoundError.Errorf(
+			"InternalCallsNotFound(tx=%#x)", txHash)
+	}
+	var calls []*InternalCall
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &calls); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidInternalCalls")
+	}
+	return calls, nil
+}
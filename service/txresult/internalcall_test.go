@@ -0,0 +1,57 @@
+package txresult
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestInternalCalls_StoreAndLoad(t *testing.T) {
+	database := db.NewMapDB()
+	txHash := []byte("tx1")
+
+	_, err := LoadInternalCalls(database, txHash)
+	assert.True(t, errors.NotFoundError.Equals(err))
+
+	call1 := &InternalCall{
+		Depth:  1,
+		From:   *common.MustNewAddressFromString("cx0000000000000000000000000000000000000001"),
+		To:     *common.MustNewAddressFromString("cx0000000000000000000000000000000000000002"),
+		Method: "transfer",
+		Status: module.StatusSuccess,
+	}
+	call2 := &InternalCall{
+		Depth:  2,
+		From:   *common.MustNewAddressFromString("cx0000000000000000000000000000000000000002"),
+		To:     *common.MustNewAddressFromString("hx0000000000000000000000000000000000000003"),
+		Status: module.StatusOutOfBalance,
+	}
+	call2.Value.SetInt64(10)
+
+	err = StoreInternalCalls(database, txHash, []*InternalCall{call1, call2})
+	assert.NoError(t, err)
+
+	calls, err := LoadInternalCalls(database, txHash)
+	assert.NoError(t, err)
+	assert.Len(t, calls, 2)
+	for i, exp := range []*InternalCall{call1, call2} {
+		assert.Equal(t, exp.Depth, calls[i].Depth)
+		assert.True(t, exp.From.Equal(&calls[i].From))
+		assert.True(t, exp.To.Equal(&calls[i].To))
+		assert.Equal(t, 0, exp.Value.Cmp(&calls[i].Value.Int))
+		assert.Equal(t, exp.Method, calls[i].Method)
+		assert.Equal(t, exp.Status, calls[i].Status)
+	}
+
+	js, err := calls[1].ToJSON(module.JSONVersion3)
+	assert.NoError(t, err)
+	jso := js.(*internalCallJSON)
+	assert.EqualValues(t, 2, jso.Depth.Value)
+	assert.Equal(t, "0xa", jso.Value.String())
+	assert.Equal(t, "", jso.Method)
+}
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/codec"
@@ -172,8 +173,13 @@ const (
 const (
 	ExtensionFeeDetail = 1 << iota
 	ExtensionDisableLogsBloom
+	ExtensionRevertReason
+	ExtensionFeeSteps
 )
 
+// MaxRevertReasonLength is the maximum length of the revert reason in bytes.
+const MaxRevertReasonLength = 256
+
 type receiptData struct {
 	Status             module.Status
 	To                 common.Address
@@ -185,6 +191,8 @@ type receiptData struct {
 	SCOREAddress       *common.Address
 	FeeDetail          feeDetail
 	DisableLogsBloom   bool
+	RevertReason       string
+	FeeSteps           feeDetail
 }
 
 func (r *receiptData) Equal(r2 *receiptData) bool {
@@ -196,7 +204,9 @@ func (r *receiptData) Equal(r2 *receiptData) bool {
 		r.LogsBloom.Equal(&r2.LogsBloom) &&
 		r.SCOREAddress.Equal(r2.SCOREAddress) &&
 		r.DisableLogsBloom == r2.DisableLogsBloom &&
-		reflect.DeepEqual(r.FeeDetail, r2.FeeDetail)
+		r.RevertReason == r2.RevertReason &&
+		reflect.DeepEqual(r.FeeDetail, r2.FeeDetail) &&
+		r.FeeSteps.Equal(r2.FeeSteps)
 }
 
 func (r *receiptData) Extension() int {
@@ -207,6 +217,12 @@ func (r *receiptData) Extension() int {
 	if r.DisableLogsBloom {
 		extension |= ExtensionDisableLogsBloom
 	}
+	if len(r.RevertReason) > 0 {
+		extension |= ExtensionRevertReason
+	}
+	if r.FeeSteps.Has() {
+		extension |= ExtensionFeeSteps
+	}
 	return extension
 }
 
@@ -219,10 +235,19 @@ type receipt struct {
 	reason    error
 	// steps for fee
 	feeSteps *big.Int
+	payments feePaymentDetails
 	btpMsgs  *list.List
+	// internal calls to be recorded in the local database
+	internalCalls []*InternalCall
+
+	// whether it records steps for fee of each payer
+	recordFeeSteps bool
 }
 
 func (r *receipt) SCOREAddress() module.Address {
+	if r.data.SCOREAddress == nil {
+		return nil
+	}
 	return r.data.SCOREAddress
 }
 
@@ -318,6 +343,16 @@ func (r *receipt) RLPEncodeSelf(e codec.Encoder) error {
 				return err
 			}
 		}
+		if (extension & ExtensionRevertReason) != 0 {
+			if err = e2.Encode(r.data.RevertReason); err != nil {
+				return err
+			}
+		}
+		if (extension & ExtensionFeeSteps) != 0 {
+			if err = e2.Encode(&r.data.FeeSteps); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 }
@@ -353,6 +388,16 @@ func (r *receipt) RLPDecodeSelf(d codec.Decoder) error {
 					return err
 				}
 			}
+			if (extension & ExtensionRevertReason) != 0 {
+				if err := d2.Decode(&r.data.RevertReason); err != nil {
+					return err
+				}
+			}
+			if (extension & ExtensionFeeSteps) != 0 {
+				if err := d2.Decode(&r.data.FeeSteps); err != nil {
+					return err
+				}
+			}
 		} else {
 			return codec.ErrInvalidFormat
 		}
@@ -421,6 +466,13 @@ func (r *receipt) AddPayment(addr module.Address, steps *big.Int, feeSteps *big.
 	if ok && r.version < Version3 {
 		r.version = Version3
 	}
+	r.payments.Add(addr, steps, feeSteps)
+	if ok && r.recordFeeSteps {
+		if feeSteps == nil {
+			feeSteps = new(big.Int)
+		}
+		r.data.FeeSteps.add(addr, feeSteps)
+	}
 	// cumulate steps for fee
 	if feeSteps != nil {
 		if r.feeSteps == nil {
@@ -485,6 +537,7 @@ func (it *eventLogIterator) Get() (module.EventLog, error) {
 type failureReason struct {
 	CodeValue    common.HexUint16 `json:"code"`
 	MessageValue string           `json:"message"`
+	Reason       string           `json:"reason,omitempty"`
 }
 
 func (f *failureReason) Code() uint16 {
@@ -495,10 +548,11 @@ func (f *failureReason) Message() string {
 	return f.MessageValue
 }
 
-func failureReasonByCode(status module.Status) *failureReason {
+func failureReasonByCode(status module.Status, reason string) *failureReason {
 	return &failureReason{
 		CodeValue:    common.HexUint16{Value: uint16(status)},
 		MessageValue: status.String(),
+		Reason:       reason,
 	}
 }
 
@@ -514,6 +568,10 @@ type Receipt interface {
 	AddPayment(addr module.Address, steps *big.Int, feeSteps *big.Int)
 	// FeeByEOA returns a fee paid by EOA (not including deposit).
 	FeeByEOA() *big.Int
+	// FeePaymentDetails returns steps paid by each payer. It has fee steps
+	// for the receipt made by execution or the receipt recording them with
+	// FeeStepsInReceipt. It returns nil if there is no payment information.
+	FeePaymentDetails() []*FeePaymentDetail
 	// Fee returns total fee (excluding virtual steps).
 	Fee() *big.Int
 	DisableLogsBloom()
@@ -521,6 +579,14 @@ type Receipt interface {
 	SetResult(status module.Status, used, price *big.Int, addr module.Address)
 	SetReason(e error)
 	Reason() error
+	// SetRevertReason records the message of the revert in the receipt.
+	SetRevertReason(msg string)
+	RevertReason() string
+	// SetInternalCalls sets internal calls made by the transaction. They
+	// aren't included in the receipt, but recorded in the local database
+	// on finalization.
+	SetInternalCalls(calls []*InternalCall)
+	InternalCalls() []*InternalCall
 	Flush() error
 }
 
@@ -535,6 +601,7 @@ type receiptJSON struct {
 	LogsBloom          *LogsBloom       `json:"logsBloom"`
 	Status             common.HexUint16 `json:"status"`
 	FeeDetail          feeDetail        `json:"stepUsedDetails,omitempty"`
+	FeeSteps           feeDetail        `json:"feeStepUsedDetails,omitempty"`
 }
 
 func (r *receipt) ToJSON(version module.JSONVersion) (interface{}, error) {
@@ -566,6 +633,13 @@ func (r *receipt) ToJSON(version module.JSONVersion) (interface{}, error) {
 		}
 		jso["stepUsedDetails"] = details
 	}
+	if r.data.FeeSteps.Has() {
+		details, err := r.data.FeeSteps.ToJSON(version)
+		if err != nil {
+			return nil, err
+		}
+		jso["feeStepUsedDetails"] = details
+	}
 
 	if r.data.Status == module.StatusSuccess {
 		jso["status"] = "0x1"
@@ -574,7 +648,7 @@ func (r *receipt) ToJSON(version module.JSONVersion) (interface{}, error) {
 		}
 	} else {
 		jso["status"] = "0x0"
-		jso["failure"] = failureReasonByCode(r.data.Status)
+		jso["failure"] = failureReasonByCode(r.data.Status, r.data.RevertReason)
 	}
 	return jso, nil
 }
@@ -598,6 +672,7 @@ func (r *receipt) UnmarshalJSON(bs []byte) error {
 		data.SCOREAddress = rjson.SCOREAddress
 	} else {
 		data.Status = module.Status(rjson.Failure.CodeValue.Value)
+		data.RevertReason = rjson.Failure.Reason
 	}
 	data.To = rjson.To
 	data.CumulativeStepUsed.Set(&rjson.CumulativeStepUsed.Int)
@@ -619,6 +694,7 @@ func (r *receipt) UnmarshalJSON(bs []byte) error {
 		data.DisableLogsBloom = true
 	}
 	data.FeeDetail = rjson.FeeDetail
+	data.FeeSteps = rjson.FeeSteps
 	if r.data.Extension() != 0 && r.version < Version3 {
 		r.version = Version3
 	}
@@ -652,8 +728,14 @@ func (r *receipt) SetCumulativeStepUsed(cumulativeUsed *big.Int) {
 	r.data.CumulativeStepUsed.Set(cumulativeUsed)
 }
 
+// writableDatabase returns the database for writing receipts and event
+// logs, which are compressed if the chain is configured for it.
+func writableDatabase(database db.Database) db.Database {
+	return db.WithFlags(database, db.Flags{db.FlagCompression: true})
+}
+
 func (r *receipt) buildMerkleListOfLogs() {
-	mt := trie_manager.NewMutableForObject(r.db, nil, reflect.TypeOf((*eventLog)(nil)))
+	mt := trie_manager.NewMutableForObject(writableDatabase(r.db), nil, reflect.TypeOf((*eventLog)(nil)))
 	for idx, item := range r.data.EventLogs {
 		k, _ := codec.BC.MarshalToBytes(uint(idx))
 		_, err := mt.Set(k, item)
@@ -678,6 +760,7 @@ func (r *receipt) SetResult(status module.Status, used, price *big.Int, addr mod
 	if r.version >= Version3 {
 		r.logsBloom = r.data.LogsBloom.CompressedBytes()
 		r.data.FeeDetail.Normalize()
+		r.data.FeeSteps.Normalize()
 	}
 }
 
@@ -689,6 +772,33 @@ func (r *receipt) Reason() error {
 	return r.reason
 }
 
+func (r *receipt) SetRevertReason(msg string) {
+	if len(msg) > MaxRevertReasonLength {
+		// cut on the boundary of the character
+		n := MaxRevertReasonLength
+		for n > 0 && !utf8.RuneStart(msg[n]) {
+			n--
+		}
+		msg = msg[:n]
+	}
+	r.data.RevertReason = msg
+	if len(msg) > 0 && r.version < Version3 {
+		r.version = Version3
+	}
+}
+
+func (r *receipt) RevertReason() string {
+	return r.data.RevertReason
+}
+
+func (r *receipt) SetInternalCalls(calls []*InternalCall) {
+	r.internalCalls = calls
+}
+
+func (r *receipt) InternalCalls() []*InternalCall {
+	return r.internalCalls
+}
+
 func (r *receipt) CumulativeStepUsed() *big.Int {
 	p := new(big.Int)
 	p.Set(&r.data.CumulativeStepUsed.Int)
@@ -732,6 +842,24 @@ func (r *receipt) FeeByEOA() *big.Int {
 	return new(big.Int).Mul(r.stepsPaidByEOA(), r.data.StepPrice.Value())
 }
 
+func (r *receipt) FeePaymentDetails() []*FeePaymentDetail {
+	if len(r.payments) > 0 {
+		return r.payments
+	}
+	if !r.data.FeeDetail.Has() {
+		return nil
+	}
+	details := make([]*FeePaymentDetail, 0, len(r.data.FeeDetail))
+	for _, p := range r.data.FeeDetail {
+		details = append(details, &FeePaymentDetail{
+			Payer:    common.AddressToPtr(&p.Payer),
+			Steps:    p.Amount.Value(),
+			FeeSteps: r.data.FeeSteps.amountOf(&p.Payer),
+		})
+	}
+	return details
+}
+
 func (r *receipt) Status() module.Status {
 	return r.data.Status
 }
@@ -788,6 +916,7 @@ func NewReceipt(database db.Database, revision module.Revision, to module.Addres
 	r := new(receipt)
 	r.db = database
 	r.version = versionForRevision(revision)
+	r.recordFeeSteps = revision.Has(module.FeeStepsInReceipt)
 	r.data.To.Set(to)
 	return r
 }
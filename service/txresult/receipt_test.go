@@ -7,6 +7,7 @@ import (
 	"log"
 	"math/big"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 
@@ -136,6 +137,55 @@ func TestReceipt_DisableLogBloom(t *testing.T) {
 	}
 }
 
+func TestReceipt_RevertReason(t *testing.T) {
+	dbase := db.NewMapDB()
+	to := common.MustNewAddressFromString("cx1234")
+	for _, rev := range []module.Revision{module.NoRevision, module.LatestRevision} {
+		t.Run(fmt.Sprint("Rev", rev), func(t *testing.T) {
+			rct := NewReceipt(dbase, rev, to)
+			rct.SetRevertReason("NotEnoughToken")
+			rct.SetResult(module.StatusReverted+1, big.NewInt(100), big.NewInt(10), nil)
+			assert.NoError(t, rct.Flush())
+
+			jso, err := rct.ToJSON(module.JSONVersionLast)
+			assert.NoError(t, err)
+			failure := jso.(map[string]interface{})["failure"].(*failureReason)
+			assert.Equal(t, "NotEnoughToken", failure.Reason)
+
+			jb, err := json.Marshal(jso)
+			assert.NoError(t, err)
+			rct2, err := NewReceiptFromJSON(dbase, rev, jb)
+			assert.NoError(t, err)
+			assert.NoError(t, rct.Check(rct2))
+			assert.Equal(t, rct.Bytes(), rct2.Bytes())
+
+			rct3 := new(receipt)
+			assert.NoError(t, rct3.Reset(dbase, rct.Bytes()))
+			assert.Equal(t, "NotEnoughToken", rct3.RevertReason())
+			assert.NoError(t, rct.Check(rct3))
+		})
+	}
+
+	// long messages are cut on the boundary of the character
+	for _, tt := range []struct {
+		prefix int
+		char   string
+		length int
+	}{
+		{MaxRevertReasonLength - 1, "\u00e9", MaxRevertReasonLength - 1},
+		{MaxRevertReasonLength - 2, "\u00e9", MaxRevertReasonLength},
+		{MaxRevertReasonLength - 1, "\U0001f600", MaxRevertReasonLength - 1},
+		{MaxRevertReasonLength - 3, "\U0001f600", MaxRevertReasonLength - 3},
+		{MaxRevertReasonLength - 4, "\U0001f600", MaxRevertReasonLength},
+	} {
+		rct := NewReceipt(dbase, module.LatestRevision, to)
+		msg := string(bytes.Repeat([]byte("a"), tt.prefix)) + tt.char + "b"
+		rct.SetRevertReason(msg)
+		assert.Equal(t, msg[:tt.length], rct.RevertReason())
+		assert.True(t, utf8.ValidString(rct.RevertReason()))
+	}
+}
+
 func TestReceipt_Fee(t *testing.T) {
 	database := db.NewMapDB()
 	eoa1 := common.MustNewAddressFromString("hx9834234")
@@ -230,6 +280,89 @@ func TestReceipt_Fee(t *testing.T) {
 	})
 }
 
+func TestReceipt_FeePaymentDetails(t *testing.T) {
+	database := db.NewMapDB()
+	eoa1 := common.MustNewAddressFromString("hx9834234")
+	contract1 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+
+	r := NewReceipt(database, module.LatestRevision, contract1)
+	assert.Nil(t, r.FeePaymentDetails())
+
+	r.AddPayment(contract1, big.NewInt(100), big.NewInt(40))
+	r.AddPayment(eoa1, big.NewInt(300), big.NewInt(300))
+	r.AddPayment(contract1, big.NewInt(50), nil)
+	r.SetResult(module.StatusSuccess, big.NewInt(450), big.NewInt(85), nil)
+
+	details := r.FeePaymentDetails()
+	assert.Len(t, details, 2)
+	assert.True(t, details[0].Payer.Equal(contract1))
+	assert.Equal(t, big.NewInt(150), details[0].Steps)
+	assert.Equal(t, big.NewInt(40), details[0].FeeSteps)
+	assert.Equal(t, big.NewInt(110), details[0].VirtualSteps())
+	assert.True(t, details[1].Payer.Equal(eoa1))
+	assert.Zero(t, details[1].VirtualSteps().Sign())
+
+	// fee steps are kept in the stored receipt
+	r2 := new(receipt)
+	assert.NoError(t, r2.Reset(database, r.Bytes()))
+	assert.NoError(t, r.Check(r2))
+	details = r2.FeePaymentDetails()
+	assert.Len(t, details, 2)
+	for _, d := range details {
+		if d.Payer.Equal(contract1) {
+			assert.Equal(t, big.NewInt(40), d.FeeSteps)
+			assert.Equal(t, big.NewInt(110), d.VirtualSteps())
+		} else {
+			assert.Equal(t, big.NewInt(300), d.FeeSteps)
+			assert.Zero(t, d.VirtualSteps().Sign())
+		}
+	}
+
+	// they are not kept before FeeStepsInReceipt
+	r = NewReceipt(database, module.LatestRevision&^module.FeeStepsInReceipt, contract1)
+	r.AddPayment(contract1, big.NewInt(100), big.NewInt(40))
+	r.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(85), nil)
+	r2 = new(receipt)
+	assert.NoError(t, r2.Reset(database, r.Bytes()))
+	details = r2.FeePaymentDetails()
+	assert.Len(t, details, 1)
+	assert.Nil(t, details[0].FeeSteps)
+	assert.Nil(t, details[0].VirtualSteps())
+}
+
+func TestReceipt_JSONWithFeeSteps(t *testing.T) {
+	database := db.NewMapDB()
+	eoa1 := common.MustNewAddressFromString("hx9834234")
+	contract1 := common.MustNewAddressFromString("cx0000000000000000000000000000000000000001")
+
+	r := NewReceipt(database, module.LatestRevision, contract1)
+	r.AddPayment(contract1, big.NewInt(100), nil)
+	r.AddPayment(eoa1, big.NewInt(300), big.NewInt(300))
+	r.SetResult(module.StatusSuccess, big.NewInt(400), big.NewInt(85), nil)
+
+	jso, err := r.ToJSON(module.JSONVersionLast)
+	assert.NoError(t, err)
+	obj := jso.(map[string]interface{})
+	assert.Equal(t, map[string]string{
+		contract1.String(): "0x64",
+		eoa1.String():      "0x12c",
+	}, obj["stepUsedDetails"])
+	assert.Equal(t, map[string]string{
+		contract1.String(): "0x0",
+		eoa1.String():      "0x12c",
+	}, obj["feeStepUsedDetails"])
+
+	js, err := json.Marshal(jso)
+	assert.NoError(t, err)
+	r2, err := NewReceiptFromJSON(database, module.LatestRevision, js)
+	assert.NoError(t, err)
+	assert.True(t, r.(*receipt).data.FeeSteps.Equal(r2.(*receipt).data.FeeSteps))
+
+	r3 := new(receipt)
+	assert.NoError(t, r3.Reset(database, r.Bytes()))
+	assert.NoError(t, r.Check(r3))
+}
+
 func TestDecomposeEventSignature(t *testing.T) {
 	type args struct {
 		s string
@@ -75,7 +75,7 @@ func (l *receiptList) Flush() error {
 }
 
 func NewReceiptListFromSlice(database db.Database, list []Receipt) module.ReceiptList {
-	mt := trie_manager.NewMutableForObject(database, nil, ReceiptType)
+	mt := trie_manager.NewMutableForObject(writableDatabase(database), nil, ReceiptType)
 	for idx, r := range list {
 		k, _ := codec.BC.MarshalToBytes(uint(idx))
 		_, err := mt.Set(k, r.(*receipt))
@@ -92,6 +92,28 @@ func NewReceiptListFromHash(database db.Database, h []byte) module.ReceiptList {
 	return &receiptList{immutable}
 }
 
+// ProveReceipt returns the receipt at the index n of the receipts with the
+// hash h after verifying the proof. It doesn't need any receipts in the
+// database, so light clients can use it for receipts given by others.
+func ProveReceipt(h []byte, n int, proof [][]byte) (module.Receipt, error) {
+	b, err := codec.BC.MarshalToBytes(uint(n))
+	if err != nil {
+		return nil, err
+	}
+	immutable := trie_manager.NewImmutableForObject(db.NewMapDB(), h, ReceiptType)
+	obj, err := immutable.Prove(b, proof)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err,
+			"InvalidReceiptProof(hash=%#x,idx=%d)", h, n)
+	}
+	if rct, ok := obj.(module.Receipt); !ok {
+		return nil, errors.NotFoundError.Errorf(
+			"NoReceipt(hash=%#x,idx=%d)", h, n)
+	} else {
+		return rct, nil
+	}
+}
+
 func NewReceiptListWithBuilder(builder merkle.Builder, h []byte) module.ReceiptList {
 	database := builder.Database()
 	snapshot := trie_manager.NewImmutableForObject(database, h, ReceiptType)
@@ -7,6 +7,8 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/module"
@@ -65,3 +67,113 @@ func testReceiptListByRev(t *testing.T, rev module.Revision) {
 		idx++
 	}
 }
+
+type sizeBucket struct {
+	db.Bucket
+	size *int
+}
+
+func (bk *sizeBucket) Set(key, value []byte) error {
+	*bk.size += len(value)
+	return bk.Bucket.Set(key, value)
+}
+
+type sizeDB struct {
+	db.Database
+	size int
+}
+
+func (s *sizeDB) GetBucket(id db.BucketID) (db.Bucket, error) {
+	bk, err := s.Database.GetBucket(id)
+	if err != nil {
+		return nil, err
+	}
+	return &sizeBucket{bk, &s.size}, nil
+}
+
+func TestReceiptList_Compression(t *testing.T) {
+	addr := common.MustNewAddressFromString("cx0003737589788888888888888888888888888888")
+	from := common.MustNewAddressFromString("hx8888888888888888888888888888888888888888")
+	to := common.MustNewAddressFromString("hx9999999999999999999999999999999999999999")
+	var used, price big.Int
+
+	sizes := make(map[db.Compression]int)
+	for _, c := range []db.Compression{db.CompressionNone, db.CompressionZstd} {
+		sdb := &sizeDB{Database: db.NewMapDB()}
+		cdb, err := db.NewCompressDB(sdb, c)
+		assert.NoError(t, err)
+
+		rslice := make([]Receipt, 0)
+		for i := 0; i < 10; i++ {
+			r := NewReceipt(cdb, module.UseMPTOnEvents, addr)
+			for j := 0; j < 10; j++ {
+				r.AddLog(addr, [][]byte{
+					[]byte("Transfer(Address,Address,int,bytes)"),
+					from.Bytes(), to.Bytes(), big.NewInt(int64(j)).Bytes(),
+				}, [][]byte{[]byte("memo")})
+			}
+			used.SetInt64(int64(i * 100))
+			price.SetInt64(int64(i * 10))
+			r.SetResult(module.StatusSuccess, &used, &price, nil)
+			rslice = append(rslice, r)
+		}
+		rl1 := NewReceiptListFromSlice(cdb, rslice)
+		assert.NoError(t, rl1.Flush())
+		sizes[c] = sdb.size
+
+		rl2 := NewReceiptListFromHash(cdb, rl1.Hash())
+		idx := 0
+		for itr := rl2.Iterator(); itr.Has(); assert.NoError(t, itr.Next()) {
+			r, err := itr.Get()
+			assert.NoError(t, err)
+			assert.NoError(t, rslice[idx].Check(r))
+			cnt := 0
+			for litr := r.EventLogIterator(); litr.Has(); assert.NoError(t, litr.Next()) {
+				_, err := litr.Get()
+				assert.NoError(t, err)
+				cnt++
+			}
+			assert.Equal(t, 10, cnt)
+			idx++
+		}
+		assert.Equal(t, len(rslice), idx)
+	}
+	assert.True(t, sizes[db.CompressionZstd] < sizes[db.CompressionNone],
+		"sizes=%v", sizes)
+	log.Printf("Stored size of receipts : %v", sizes)
+}
+
+func TestProveReceipt(t *testing.T) {
+	mdb := db.NewMapDB()
+	rslice := make([]Receipt, 0)
+
+	var used, price big.Int
+	addr := common.MustNewAddressFromString("hx8888888888888888888888888888888888888888")
+	for i := 0; i < 20; i++ {
+		r := NewReceipt(mdb, module.UseMPTOnEvents, addr)
+		used.SetInt64(int64(i * 100))
+		price.SetInt64(int64(i * 10))
+		r.SetResult(module.StatusSuccess, &used, &price, nil)
+		rslice = append(rslice, r)
+	}
+	rl := NewReceiptListFromSlice(mdb, rslice)
+	hash := rl.Hash()
+
+	for idx := range rslice {
+		proof, err := rl.GetProof(idx)
+		assert.NoError(t, err)
+
+		rct, err := ProveReceipt(hash, idx, proof)
+		assert.NoError(t, err)
+		assert.Equal(t, rslice[idx].Bytes(), rct.Bytes())
+		assert.NoError(t, rslice[idx].Check(rct))
+
+		_, err = ProveReceipt(hash, (idx+1)%len(rslice), proof)
+		assert.Error(t, err)
+	}
+
+	proof, err := rl.GetProof(1)
+	assert.NoError(t, err)
+	_, err = ProveReceipt(rslice[0].Bytes(), 1, proof)
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"github.com/icon-project/goloop/common/cache"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/service/sigverify"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+const (
+	ConfigTxVerifierCacheSize = 20000
+)
+
+// TxVerifier verifies transactions and remembers verified ones, so the
+// transaction verified on admission to the pool isn't verified again on
+// proposal and import of the block including it.
+//
+// Only static checks and recovery of the signature are remembered, because
+// they depend on the bytes of the transaction only. Validation with the
+// world state (PreValidate) changes with the state, so it's done on every
+// stage. The key is the hash of the bytes instead of the transaction hash,
+// which doesn't cover the signature.
+type TxVerifier struct {
+	cache *cache.LRUCache
+}
+
+func NewTxVerifier(size int) *TxVerifier {
+	return &TxVerifier{
+		cache: cache.NewLRUCache(size, nil),
+	}
+}
+
+func keyOfTx(tx transaction.Transaction) string {
+	return string(crypto.SHA3Sum256(tx.Bytes()))
+}
+
+// Verify verifies the transaction unless it's verified before. Nil
+// verifier verifies the transaction always.
+func (v *TxVerifier) Verify(tx transaction.Transaction) error {
+	return v.VerifyAll([]transaction.Transaction{tx})[0]
+}
+
+// VerifyAll verifies transactions not verified before with workers of the
+// signature verification pool, and returns errors of them in the order.
+func (v *TxVerifier) VerifyAll(txs []transaction.Transaction) []error {
+	if v == nil {
+		return sigverify.Default().Run(len(txs), func(i int) error {
+			return txs[i].Verify()
+		})
+	}
+	errs := make([]error, len(txs))
+	keys := make([]string, 0, len(txs))
+	idxs := make([]int, 0, len(txs))
+	for i, tx := range txs {
+		key := keyOfTx(tx)
+		if _, err := v.cache.Get(key); err != nil {
+			keys = append(keys, key)
+			idxs = append(idxs, i)
+		}
+	}
+	if len(idxs) == 0 {
+		return errs
+	}
+	verrs := sigverify.Default().Run(len(idxs), func(i int) error {
+		return txs[idxs[i]].Verify()
+	})
+	for i, err := range verrs {
+		if err == nil {
+			v.cache.Put(keys[i], true)
+		}
+		errs[idxs[i]] = err
+	}
+	return errs
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+func newSignedTestTransaction(t *testing.T, sk *crypto.PrivateKey, sig []byte) transaction.Transaction {
+	from := common.NewAccountAddressFromPublicKey(sk.PublicKey())
+	js := fmt.Sprintf(`{
+		"version": "0x3",
+		"from": "%s",
+		"to": "hx0000000000000000000000000000000000000002",
+		"value": "0x1",
+		"stepLimit": "0x100000",
+		"timestamp": "0x1",
+		"nid": "0x1"`, from)
+	tx, err := transaction.NewTransactionFromJSON([]byte(js + "}"))
+	assert.NoError(t, err)
+	if sig == nil {
+		s, err := crypto.NewSignature(tx.ID(), sk)
+		assert.NoError(t, err)
+		sig, err = s.SerializeRSV()
+		assert.NoError(t, err)
+	}
+	js += fmt.Sprintf(`,"signature": "%s"}`, base64.StdEncoding.EncodeToString(sig))
+	tx, err = transaction.NewTransactionFromJSON([]byte(js))
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestTxVerifier_Verify(t *testing.T) {
+	tv := NewTxVerifier(2)
+	sk, _ := crypto.GenerateKeyPair()
+
+	tx := newSignedTestTransaction(t, sk, nil)
+	assert.NoError(t, tv.Verify(tx))
+	_, err := tv.cache.Get(string(crypto.SHA3Sum256(tx.Bytes())))
+	assert.NoError(t, err)
+	assert.NoError(t, tv.Verify(tx))
+
+	// same transaction hash with the wrong signature isn't cached one
+	sk2, _ := crypto.GenerateKeyPair()
+	s, err := crypto.NewSignature(tx.ID(), sk2)
+	assert.NoError(t, err)
+	sig, err := s.SerializeRSV()
+	assert.NoError(t, err)
+	tx2 := newSignedTestTransaction(t, sk, sig)
+	assert.Equal(t, tx.ID(), tx2.ID())
+	assert.Error(t, tv.Verify(tx2))
+	assert.Error(t, tv.Verify(tx2))
+
+	// nil verifier verifies always
+	var nv *TxVerifier
+	assert.NoError(t, nv.Verify(tx))
+	assert.Error(t, nv.Verify(tx2))
+}
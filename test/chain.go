@@ -28,6 +28,7 @@ import (
 	"github.com/icon-project/goloop/chain/gs"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/throttle"
 	"github.com/icon-project/goloop/common/wallet"
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/module"
@@ -104,6 +105,26 @@ func (c *Chain) TransactionTimeout() time.Duration {
 	return time.Second * 5
 }
 
+func (c *Chain) TxTimestampWindow() time.Duration {
+	return 0
+}
+
+func (c *Chain) TxPoolTTL() time.Duration {
+	return 0
+}
+
+func (c *Chain) TxPoolDir() string {
+	return ""
+}
+
+func (c *Chain) PriorityTxRatio() int {
+	return 0
+}
+
+func (c *Chain) Sequencer() module.Address {
+	return nil
+}
+
 func (c *Chain) ChildrenLimit() int {
 	panic("implement me")
 }
@@ -116,6 +137,30 @@ func (c *Chain) ValidateTxOnSend() bool {
 	panic("implement me")
 }
 
+func (c *Chain) RecordInternalCalls() bool {
+	return false
+}
+
+func (c *Chain) RecordAddressIndex() bool {
+	return false
+}
+
+func (c *Chain) RecordTokenIndex() bool {
+	return false
+}
+
+func (c *Chain) SyncServerThrottle() *throttle.Throttle {
+	return nil
+}
+
+func (c *Chain) SyncAnchor() *module.SyncAnchor {
+	return nil
+}
+
+func (c *Chain) HistoryCapability() *module.HistoryCapability {
+	return nil
+}
+
 var defaultGenesis = "{\n  \"accounts\": [\n    {\n      \"name\": \"god\",\n      \"address\": \"hx54f7853dc6481b670caf69c5a27c7c8fe5be8269\",\n      \"balance\": \"0x2961fff8ca4a62327800000\"\n    },\n    {\n      \"name\": \"treasury\",\n      \"address\": \"hx1000000000000000000000000000000000000000\",\n      \"balance\": \"0x0\"\n    }\n  ],\n  \"message\": \"A rhizome has no beginning or end; it is always in the middle, between things, interbeing, intermezzo. The tree is filiation, but the rhizome is alliance, uniquely alliance. The tree imposes the verb \\\"to be\\\" but the fabric of the rhizome is the conjunction, \\\"and ... and ...and...\\\"This conjunction carries enough force to shake and uproot the verb \\\"to be.\\\" Where are you going? Where are you coming from? What are you heading for? These are totally useless questions.\\n\\n - Mille Plateaux, Gilles Deleuze & Felix Guattari\\n\\n\\\"Hyperconnect the world\\\"\"\n}\n"
 
 func (c *Chain) Genesis() []byte {
@@ -170,11 +215,19 @@ func (c *Chain) Import(src string, height int64) error {
 	panic("implement me")
 }
 
-func (c *Chain) Prune(gs string, dbt string, height int64) error {
+func (c *Chain) Prune(gs string, dbt string, height int64, headers bool) error {
+	panic("implement me")
+}
+
+func (c *Chain) Rollback(height int64) error {
+	panic("implement me")
+}
+
+func (c *Chain) Tasks() []*module.ChainTaskStatus {
 	panic("implement me")
 }
 
-func (c *Chain) Backup(file string, extra []string) error {
+func (c *Chain) Backup(file string, base string, extra []string) error {
 	panic("implement me")
 }
 
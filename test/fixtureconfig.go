@@ -24,6 +24,7 @@ import (
 
 	"github.com/icon-project/goloop/block"
 	"github.com/icon-project/goloop/chain/base"
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/module"
@@ -48,6 +49,7 @@ type FixtureConfig struct {
 	Wallet            module.Wallet
 	AddDefaultNode    *bool
 	WAL               func() consensus.WALManager
+	Clock             common.Clock
 }
 
 func NewFixtureConfig(t *testing.T, o ...FixtureOption) *FixtureConfig {
@@ -77,6 +79,9 @@ func NewFixtureConfig(t *testing.T, o ...FixtureOption) *FixtureConfig {
 				ctx.C, wal, wm, nil, nil, nil,
 			)
 			assert.NotNil(ctx.Config.T, cs)
+			if ctx.Config.Clock != nil {
+				cs.SetClock(ctx.Config.Clock)
+			}
 			return cs
 		},
 		AddValidatorNodes: 0,
@@ -147,5 +152,8 @@ func (cf *FixtureConfig) Override(cf2 *FixtureConfig) *FixtureConfig {
 	if cf2.WAL != nil {
 		res.WAL = cf2.WAL
 	}
+	if cf2.Clock != nil {
+		res.Clock = cf2.Clock
+	}
 	return &res
 }
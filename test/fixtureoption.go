@@ -17,6 +17,7 @@
 package test
 
 import (
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/module"
@@ -71,6 +72,16 @@ func AddDefaultNode(v bool) FixtureOption {
 	return UseConfig(&FixtureConfig{AddDefaultNode: &v})
 }
 
+// UseClock makes consensus of nodes use the clock for timers, so timeouts
+// can be controlled by tests.
+func UseClock(cl common.Clock) FixtureOption {
+	return UseConfig(&FixtureConfig{Clock: cl})
+}
+
+func UseSMFactory(f func(ctx *NodeContext) module.ServiceManager) FixtureOption {
+	return UseConfig(&FixtureConfig{NewSM: f})
+}
+
 func UseBMFactory(f func(ctx *NodeContext) module.BlockManager) FixtureOption {
 	return UseConfig(&FixtureConfig{NewBM: f})
 }
@@ -0,0 +1,662 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sync"
+
+	"github.com/icon-project/goloop/btp"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/service/transaction"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+// ScriptedServiceManager is a ServiceManager which doesn't execute
+// transactions. Results, validators and BTP sections of transitions are
+// declared per height with HeightScript, and the others are derived from the
+// parent transition, so block manager and consensus can be tested without
+// the execution stack.
+type ScriptedServiceManager struct {
+	module.ServiceManager
+	dbase  db.Database
+	chain  module.Chain
+	logger log.Logger
+
+	mu        sync.Mutex
+	scripts   map[int64]*HeightScript
+	vls       map[string]module.ValidatorList
+	sections  map[string]module.BTPSection
+	pcms      map[string]module.BTPProofContextMap
+	emptyTXs  module.TransactionList
+	pool      []module.Transaction
+	txWaiters []func()
+}
+
+// HeightScript declares the outcome of the transition for the block at the
+// height. Its methods return the script itself, so declarations can be
+// chained.
+type HeightScript struct {
+	sm          *ScriptedServiceManager
+	height      int64
+	state       []byte
+	validators  module.ValidatorList
+	section     module.BTPSection
+	pcm         module.BTPProofContextMap
+	validateErr error
+	executeErr  error
+}
+
+// NewScriptedServiceManager returns a scripted ServiceManager for the chain.
+// Validators of the genesis are used as the initial validators unless they
+// are declared for the height zero.
+func NewScriptedServiceManager(c module.Chain) *ScriptedServiceManager {
+	dbase := c.Database()
+	sm := &ScriptedServiceManager{
+		dbase:    dbase,
+		chain:    c,
+		logger:   c.Logger(),
+		scripts:  make(map[int64]*HeightScript),
+		vls:      make(map[string]module.ValidatorList),
+		sections: make(map[string]module.BTPSection),
+		pcms:     make(map[string]module.BTPProofContextMap),
+		emptyTXs: transaction.NewTransactionListFromSlice(dbase, nil),
+	}
+	if addrs := validatorsOfGenesis(c.Genesis()); len(addrs) > 0 {
+		sm.At(0).Validators(addrs...)
+	}
+	return sm
+}
+
+func validatorsOfGenesis(gtx []byte) []module.Address {
+	var genesis struct {
+		Chain struct {
+			ValidatorList []*common.Address `json:"validatorList"`
+		} `json:"chain"`
+	}
+	if err := json.Unmarshal(gtx, &genesis); err != nil {
+		return nil
+	}
+	addrs := make([]module.Address, 0, len(genesis.Chain.ValidatorList))
+	for _, addr := range genesis.Chain.ValidatorList {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// At returns the script for the block at the height.
+func (sm *ScriptedServiceManager) At(height int64) *HeightScript {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	s, ok := sm.scripts[height]
+	if !ok {
+		s = &HeightScript{sm: sm, height: height}
+		sm.scripts[height] = s
+	}
+	return s
+}
+
+func (sm *ScriptedServiceManager) scriptFor(height int64) HeightScript {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if s, ok := sm.scripts[height]; ok {
+		return *s
+	}
+	return HeightScript{height: height}
+}
+
+// At returns the script for the block at the height of the same manager.
+func (s *HeightScript) At(height int64) *HeightScript {
+	return s.sm.At(height)
+}
+
+// Result declares the state part of the result. By default, it's derived
+// from the parent and transactions of the block, so declaring different
+// ones on nodes makes the result mismatch.
+func (s *HeightScript) Result(state []byte) *HeightScript {
+	s.sm.mu.Lock()
+	defer s.sm.mu.Unlock()
+	s.state = state
+	return s
+}
+
+// Validators declares the next validators. By default, validators of the
+// parent are kept.
+func (s *HeightScript) Validators(addrs ...module.Address) *HeightScript {
+	vs := make([]module.Validator, 0, len(addrs))
+	for _, addr := range addrs {
+		v, err := state.ValidatorFromAddress(addr)
+		if err != nil {
+			log.Panicf("invalid validator addr=%s err=%+v", addr, err)
+		}
+		vs = append(vs, v)
+	}
+	vl, err := state.ValidatorSnapshotFromSlice(s.sm.dbase, vs)
+	if err != nil {
+		log.Panicf("fail to make validators err=%+v", err)
+	}
+
+	s.sm.mu.Lock()
+	defer s.sm.mu.Unlock()
+	s.validators = vl
+	s.sm.vls[string(vl.Hash())] = vl
+	return s
+}
+
+// BTPSection declares the BTP section. By default, it's empty.
+func (s *HeightScript) BTPSection(bs module.BTPSection) *HeightScript {
+	s.sm.mu.Lock()
+	defer s.sm.mu.Unlock()
+	s.section = bs
+	if dh := bs.Digest().Hash(); dh != nil {
+		s.sm.sections[string(dh)] = bs
+	}
+	return s
+}
+
+// NextProofContextMap declares the proof context map for the next block.
+// By default, it's empty.
+func (s *HeightScript) NextProofContextMap(pcm module.BTPProofContextMap) *HeightScript {
+	s.sm.mu.Lock()
+	defer s.sm.mu.Unlock()
+	s.pcm = pcm
+	return s
+}
+
+// ValidateError makes validation of the transition fail with the error.
+// It doesn't affect transitions proposed by the manager.
+func (s *HeightScript) ValidateError(err error) *HeightScript {
+	s.sm.mu.Lock()
+	defer s.sm.mu.Unlock()
+	s.validateErr = err
+	return s
+}
+
+// ExecuteError makes execution of the transition fail with the error.
+func (s *HeightScript) ExecuteError(err error) *HeightScript {
+	s.sm.mu.Lock()
+	defer s.sm.mu.Unlock()
+	s.executeErr = err
+	return s
+}
+
+func (sm *ScriptedServiceManager) Start() {
+}
+
+func (sm *ScriptedServiceManager) Term() {
+}
+
+func (sm *ScriptedServiceManager) newTransition(
+	parent *scriptedTransition,
+	txs module.TransactionList,
+	bi module.BlockInfo,
+	csi module.ConsensusInfo,
+	validated bool,
+) *scriptedTransition {
+	return &scriptedTransition{
+		sm:        sm,
+		parent:    parent,
+		patchTxs:  sm.emptyTXs,
+		normalTxs: txs,
+		bi:        bi,
+		csi:       csi,
+		validated: validated,
+		done:      make(chan struct{}),
+	}
+}
+
+func (sm *ScriptedServiceManager) ProposeTransition(parent module.Transition, bi module.BlockInfo, csi module.ConsensusInfo) (module.Transition, error) {
+	pt, ok := parent.(*scriptedTransition)
+	if !ok {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidParent(type=%T)", parent)
+	}
+	sm.mu.Lock()
+	txs := transaction.NewTransactionListFromSlice(sm.dbase, sm.pool)
+	sm.mu.Unlock()
+	return sm.newTransition(pt, txs, bi, csi, true), nil
+}
+
+func (sm *ScriptedServiceManager) CreateInitialTransition(result []byte, nextValidators module.ValidatorList) (module.Transition, error) {
+	if nextValidators == nil {
+		vl, err := state.ValidatorSnapshotFromSlice(sm.dbase, nil)
+		if err != nil {
+			return nil, err
+		}
+		nextValidators = vl
+	}
+	tr := &scriptedTransition{
+		sm:         sm,
+		patchTxs:   sm.emptyTXs,
+		normalTxs:  sm.emptyTXs,
+		validated:  true,
+		done:       make(chan struct{}),
+		result:     result,
+		validators: nextValidators,
+		section:    btp.ZeroBTPSection,
+	}
+	close(tr.done)
+	return tr, nil
+}
+
+func (sm *ScriptedServiceManager) CreateTransition(parent module.Transition, txs module.TransactionList, bi module.BlockInfo, csi module.ConsensusInfo, validated bool) (module.Transition, error) {
+	pt, ok := parent.(*scriptedTransition)
+	if !ok {
+		return nil, errors.IllegalArgumentError.Errorf("InvalidParent(type=%T)", parent)
+	}
+	return sm.newTransition(pt, txs, bi, csi, validated), nil
+}
+
+func (sm *ScriptedServiceManager) GetPatches(parent module.Transition, bi module.BlockInfo) module.TransactionList {
+	return sm.emptyTXs
+}
+
+func (sm *ScriptedServiceManager) PatchTransition(transition module.Transition, patches module.TransactionList, bi module.BlockInfo) module.Transition {
+	return transition
+}
+
+func (sm *ScriptedServiceManager) Finalize(transition module.Transition, opt int) error {
+	tr, ok := transition.(*scriptedTransition)
+	if !ok {
+		return errors.IllegalArgumentError.Errorf("InvalidTransition(type=%T)", transition)
+	}
+	if opt&module.FinalizeNormalTransaction != 0 {
+		if err := tr.normalTxs.Flush(); err != nil {
+			return err
+		}
+		sm.removeFromPool(tr.normalTxs)
+	}
+	if opt&module.FinalizeResult != 0 {
+		if vl, ok := tr.NextValidators().(state.ValidatorSnapshot); ok {
+			if err := vl.Flush(); err != nil {
+				return err
+			}
+		}
+		if bs := tr.BTPSection(); bs != nil && bs.Digest().Hash() != nil {
+			bk, err := sm.dbase.GetBucket(db.BytesByHash)
+			if err != nil {
+				return err
+			}
+			if err := bk.Set(bs.Digest().Hash(), bs.Digest().Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (sm *ScriptedServiceManager) removeFromPool(txs module.TransactionList) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var pool []module.Transaction
+	for _, tx := range sm.pool {
+		found := false
+		for it := txs.Iterator(); it.Has(); _ = it.Next() {
+			if t, _, err := it.Get(); err == nil && bytes.Equal(t.ID(), tx.ID()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			pool = append(pool, tx)
+		}
+	}
+	sm.pool = pool
+}
+
+func (sm *ScriptedServiceManager) WaitForTransaction(parent module.Transition, bi module.BlockInfo, cb func()) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if len(sm.pool) > 0 {
+		return false
+	}
+	sm.txWaiters = append(sm.txWaiters, cb)
+	return true
+}
+
+func (sm *ScriptedServiceManager) SendTransaction(result []byte, height int64, tx interface{}) ([]byte, error) {
+	t, err := transaction.NewTransactionFromJSON(([]byte)(tx.(string)))
+	if err != nil {
+		return nil, err
+	}
+
+	locker := common.Lock(&sm.mu)
+	defer locker.Unlock()
+
+	for _, pt := range sm.pool {
+		if bytes.Equal(pt.ID(), t.ID()) {
+			return nil, errors.Errorf("Already existing TX %x", t.ID())
+		}
+	}
+	sm.pool = append(sm.pool, t)
+	txWaiters := sm.txWaiters
+	sm.txWaiters = nil
+
+	locker.Unlock()
+
+	for _, cb := range txWaiters {
+		cb()
+	}
+	return t.ID(), nil
+}
+
+func (sm *ScriptedServiceManager) TransactionFromBytes(b []byte, blockVersion int) (module.Transaction, error) {
+	return transaction.NewTransaction(b)
+}
+
+func (sm *ScriptedServiceManager) GenesisTransactionFromBytes(b []byte, blockVersion int) (module.Transaction, error) {
+	return transaction.NewGenesisTransaction(b)
+}
+
+func (sm *ScriptedServiceManager) TransactionListFromHash(hash []byte) module.TransactionList {
+	return transaction.NewTransactionListFromHash(sm.dbase, hash)
+}
+
+func (sm *ScriptedServiceManager) TransactionListFromSlice(txs []module.Transaction, version int) module.TransactionList {
+	switch version {
+	case module.BlockVersion0:
+		return transaction.NewTransactionListV1FromSlice(txs)
+	case module.BlockVersion1, module.BlockVersion2:
+		return transaction.NewTransactionListFromSlice(sm.dbase, txs)
+	default:
+		return nil
+	}
+}
+
+func (sm *ScriptedServiceManager) ReceiptListFromResult(result []byte, g module.TransactionGroup) (module.ReceiptList, error) {
+	return txresult.NewReceiptListFromSlice(sm.dbase, nil), nil
+}
+
+func (sm *ScriptedServiceManager) ValidatorListFromHash(hash []byte) module.ValidatorList {
+	sm.mu.Lock()
+	vl, ok := sm.vls[string(hash)]
+	sm.mu.Unlock()
+	if ok {
+		return vl
+	}
+	vss, err := state.ValidatorSnapshotFromHash(sm.dbase, hash)
+	if err != nil {
+		return nil
+	}
+	return vss
+}
+
+func (sm *ScriptedServiceManager) GetChainID(result []byte) (int64, error) {
+	return int64(sm.chain.CID()), nil
+}
+
+func (sm *ScriptedServiceManager) GetNetworkID(result []byte) (int64, error) {
+	return int64(sm.chain.NID()), nil
+}
+
+func (sm *ScriptedServiceManager) GetMembers(result []byte) (module.MemberList, error) {
+	return nil, nil
+}
+
+func (sm *ScriptedServiceManager) GetRoundLimit(result []byte, vl int) int64 {
+	return 0
+}
+
+func (sm *ScriptedServiceManager) GetMinimizeBlockGen(result []byte) bool {
+	return false
+}
+
+func (sm *ScriptedServiceManager) GetNextBlockVersion(result []byte) int {
+	return module.BlockVersion2
+}
+
+func (sm *ScriptedServiceManager) GetRevision(result []byte) int {
+	return 0
+}
+
+func (sm *ScriptedServiceManager) GetVRFProposerSelection(result []byte) bool {
+	return false
+}
+
+func (sm *ScriptedServiceManager) GetValidatorWeights(result []byte, validators module.ValidatorList) []*big.Int {
+	return nil
+}
+
+func (sm *ScriptedServiceManager) WaitTransactionResult(id []byte) (<-chan interface{}, error) {
+	return nil, errors.ErrNotFound
+}
+
+func (sm *ScriptedServiceManager) BTPSectionFromResult(result []byte) (module.BTPSection, error) {
+	dh, err := service.BTPDigestHashFromResult(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(dh) == 0 {
+		return btp.ZeroBTPSection, nil
+	}
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if bs, ok := sm.sections[string(dh)]; ok {
+		return bs, nil
+	}
+	return nil, errors.NotFoundError.Errorf("UnknownBTPSection(digest=%x)", dh)
+}
+
+func (sm *ScriptedServiceManager) BTPDigestFromResult(result []byte) (module.BTPDigest, error) {
+	bs, err := sm.BTPSectionFromResult(result)
+	if err != nil {
+		return nil, err
+	}
+	return bs.Digest(), nil
+}
+
+func (sm *ScriptedServiceManager) NextProofContextMapFromResult(result []byte) (module.BTPProofContextMap, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if pcm, ok := sm.pcms[string(result)]; ok {
+		return pcm, nil
+	}
+	return btp.ZeroProofContextMap, nil
+}
+
+func (sm *ScriptedServiceManager) onExecuted(result []byte, pcm module.BTPProofContextMap) {
+	if pcm == nil {
+		return
+	}
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.pcms[string(result)] = pcm
+}
+
+type scriptedTransition struct {
+	sm        *ScriptedServiceManager
+	parent    *scriptedTransition
+	patchTxs  module.TransactionList
+	normalTxs module.TransactionList
+	bi        module.BlockInfo
+	csi       module.ConsensusInfo
+	validated bool
+
+	mu         sync.Mutex
+	started    bool
+	canceled   bool
+	done       chan struct{}
+	result     []byte
+	validators module.ValidatorList
+	section    module.BTPSection
+}
+
+func (t *scriptedTransition) PatchTransactions() module.TransactionList {
+	return t.patchTxs
+}
+
+func (t *scriptedTransition) NormalTransactions() module.TransactionList {
+	return t.normalTxs
+}
+
+func (t *scriptedTransition) PatchReceipts() module.ReceiptList {
+	return txresult.NewReceiptListFromSlice(t.sm.dbase, nil)
+}
+
+func (t *scriptedTransition) NormalReceipts() module.ReceiptList {
+	return txresult.NewReceiptListFromSlice(t.sm.dbase, nil)
+}
+
+func (t *scriptedTransition) Execute(cb module.TransitionCallback) (func() bool, error) {
+	if cb == nil {
+		return nil, errors.IllegalArgumentError.New("CallbackIsNil")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.started {
+		return nil, errors.InvalidStateError.New("AlreadyStarted")
+	}
+	t.started = true
+	go t.execute(cb)
+	return t.cancel, nil
+}
+
+func (t *scriptedTransition) cancel() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.canceled = true
+	return true
+}
+
+func (t *scriptedTransition) isCanceled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.canceled
+}
+
+func (t *scriptedTransition) execute(cb module.TransitionCallback) {
+	s := t.sm.scriptFor(t.bi.Height())
+	if !t.validated && s.validateErr != nil {
+		if !t.isCanceled() {
+			cb.OnValidate(t, s.validateErr)
+		}
+		return
+	}
+	if t.isCanceled() {
+		return
+	}
+	cb.OnValidate(t, nil)
+
+	<-t.parent.done
+	if s.executeErr != nil {
+		if !t.isCanceled() {
+			cb.OnExecute(t, s.executeErr)
+		}
+		return
+	}
+
+	prev, _ := newTransitionResultFromBytes(t.parent.Result())
+	stateHash := s.state
+	if stateHash == nil {
+		var sh []byte
+		if prev != nil {
+			sh = prev.StateHash
+		}
+		stateHash = crypto.SHA3Sum256(append(append([]byte{}, sh...), t.normalTxs.Hash()...))
+	}
+	validators := s.validators
+	if validators == nil {
+		validators = t.parent.NextValidators()
+	}
+	section := s.section
+	if section == nil {
+		section = btp.ZeroBTPSection
+	}
+	result := scriptedResultBytes(stateHash, section.Digest().Hash())
+	t.sm.onExecuted(result, s.pcm)
+
+	t.mu.Lock()
+	t.result = result
+	t.validators = validators
+	t.section = section
+	close(t.done)
+	canceled := t.canceled
+	t.mu.Unlock()
+
+	if !canceled {
+		cb.OnExecute(t, nil)
+	}
+}
+
+func (t *scriptedTransition) ExecuteForTrace(ti module.TraceInfo) (func() bool, error) {
+	return nil, errors.UnsupportedError.New("NotSupported")
+}
+
+func (t *scriptedTransition) Result() []byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.result
+}
+
+func (t *scriptedTransition) NextValidators() module.ValidatorList {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.validators
+}
+
+func (t *scriptedTransition) LogsBloom() module.LogsBloom {
+	return txresult.NewLogsBloom(nil)
+}
+
+func (t *scriptedTransition) BlockInfo() module.BlockInfo {
+	return t.bi
+}
+
+func (t *scriptedTransition) Equal(tr module.Transition) bool {
+	t2, ok := tr.(*scriptedTransition)
+	if !ok {
+		return false
+	}
+	if t == t2 {
+		return true
+	}
+	return t.parent == t2.parent &&
+		t.normalTxs.Equal(t2.normalTxs) &&
+		common.BlockInfoEqual(t.bi, t2.bi) &&
+		common.ConsensusInfoEqual(t.csi, t2.csi)
+}
+
+func (t *scriptedTransition) BTPSection() module.BTPSection {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.section
+}
+
+// scriptedResultBytes returns the result in the format of the service
+// package, so that BTP digest of the result can be read by the block manager.
+func scriptedResultBytes(stateHash, btpData []byte) []byte {
+	if len(btpData) == 0 {
+		return codec.MustMarshalToBytes([]interface{}{stateHash, []byte(nil), []byte(nil)})
+	}
+	return codec.MustMarshalToBytes([]interface{}{
+		stateHash, []byte(nil), []byte(nil), []byte(nil),
+		int64(service.ExFlagBTPData), btpData,
+	})
+}
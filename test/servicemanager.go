@@ -18,6 +18,7 @@ package test
 
 import (
 	"bytes"
+	"math/big"
 	"sync"
 
 	"github.com/icon-project/goloop/btp"
@@ -242,6 +243,37 @@ func (sm *ServiceManager) GetNextBlockVersion(result []byte) int {
 	return v
 }
 
+func (sm *ServiceManager) GetRevision(result []byte) int {
+	if result == nil {
+		return 0
+	}
+	as, err := sm.getSystemByteStoreState(result)
+	if err != nil {
+		return -1
+	}
+	return int(scoredb.NewVarDB(as, state.VarRevision).Int64())
+}
+
+func (sm *ServiceManager) GetVRFProposerSelection(result []byte) bool {
+	rev := sm.GetRevision(result)
+	if rev <= 0 {
+		return false
+	}
+	return sm.plt.ToRevision(rev).Has(module.VRFProposerSelection)
+}
+
+func (sm *ServiceManager) GetValidatorWeights(result []byte, validators module.ValidatorList) []*big.Int {
+	return nil
+}
+
+func (sm *ServiceManager) GetStorageEntries(result []byte, addr module.Address, prefix, start []byte, limit int) ([]module.StorageEntry, []byte, error) {
+	return nil, nil, errors.ErrInvalidState
+}
+
+func (sm *ServiceManager) InspectTransaction(result []byte, height int64, tx interface{}) (*module.TransactionInspection, error) {
+	return nil, errors.ErrInvalidState
+}
+
 func (sm *ServiceManager) getSystemByteStoreState(result []byte) (containerdb.BytesStoreState, error) {
 	ws, err := service.NewWorldSnapshot(sm.dbase, sm.plt, result, nil)
 	if err != nil {
@@ -317,6 +349,10 @@ func (sm *ServiceManager) SendTransaction(result []byte, height int64, tx interf
 	return t.ID(), nil
 }
 
+func (sm *ServiceManager) SendTransactionBundle(result []byte, height int64, txs []interface{}, sig []byte) ([][]byte, error) {
+	return nil, errors.ErrInvalidState
+}
+
 func (sm *ServiceManager) ValidatorListFromHash(hash []byte) module.ValidatorList {
 	vl, err := state.ValidatorSnapshotFromHash(sm.dbase, hash)
 	if err != nil {
@@ -447,6 +483,19 @@ func (sm *ServiceManager) BTPNetworkTypeIDsFromResult(result []byte) ([]int64, e
 	return ntids, nil
 }
 
+func (sm *ServiceManager) BTPNetworkIDsFromResult(result []byte) ([]int64, error) {
+	sbss, err := sm.getSystemByteStoreState(result)
+	if err != nil {
+		return nil, err
+	}
+	btpContext := state.NewBTPContext(nil, sbss)
+	nids, err := btpContext.GetNetworkIDs()
+	if err != nil {
+		return nil, err
+	}
+	return nids, nil
+}
+
 func (sm *ServiceManager) NextProofContextMapFromResult(result []byte) (module.BTPProofContextMap, error) {
 	sbss, err := sm.getSystemByteStoreState(result)
 	if err != nil {